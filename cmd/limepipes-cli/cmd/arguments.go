@@ -2,13 +2,22 @@ package cmd
 
 import (
 	"fmt"
+	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
 	"github.com/tomvodi/limepipes/cmd/limepipes-cli/importtype"
+	"github.com/tomvodi/limepipes/internal/importjob"
 	"strings"
 )
 
 const DefaultOutputDir = "./parser_success"
+const DefaultExportDir = "./export"
+
+// formats of the score command
+const (
+	ScoreFormatPDF = "pdf"
+	ScoreFormatSVG = "svg"
+)
 
 // Options that can be passed to the command via command line flags
 type Options struct {
@@ -18,6 +27,23 @@ type Options struct {
 	SkipFailedFiles bool
 	Verbose         bool
 	OutputDir       string
+	ExportFormat    string
+	ScoreFormat     string
+	BookletTitle    string
+	ExportDir       string
+	Tempo           uint32
+	SetPerFolder    bool
+	Reimport        bool
+	AutoTag         bool
+	TuneMapping     map[string]string
+	Steps           int
+	TokenName       string
+	Role            string
+
+	// Owner is the name of the user who owns the imported tunes and sets,
+	// OwnerID is the id of that user.
+	Owner   string
+	OwnerID *uuid.UUID
 }
 
 func addImportFileTypes(cmd *cobra.Command, opts *Options) {
@@ -65,3 +91,119 @@ func addOutputDir(cmd *cobra.Command, opts *Options) {
 		"Output directory where to move the successful parsed files into",
 	)
 }
+
+func addExportFormat(cmd *cobra.Command, opts *Options) {
+	cmd.Flags().StringVarP(
+		&opts.ExportFormat,
+		"format",
+		"f",
+		"",
+		"File format to export the tunes to like musicxml or lilypond. Except for lilypond, a plugin that can write this format must be loaded.",
+	)
+	_ = cmd.MarkFlagRequired("format")
+}
+
+func addScoreFormat(cmd *cobra.Command, opts *Options) {
+	cmd.Flags().StringVarP(
+		&opts.ScoreFormat,
+		"format",
+		"f",
+		ScoreFormatPDF,
+		fmt.Sprintf("File format of the scores, %s or %s. SVG scores are written as one file per page.",
+			ScoreFormatPDF, ScoreFormatSVG),
+	)
+}
+
+func addBookletTitle(cmd *cobra.Command, opts *Options) {
+	cmd.Flags().StringVar(&opts.BookletTitle, "title", "",
+		"title on the title page of the booklet, the PDF file is named after it",
+	)
+	_ = cmd.MarkFlagRequired("title")
+}
+
+func addExportDir(cmd *cobra.Command, opts *Options) {
+	cmd.Flags().StringVarP(
+		&opts.ExportDir,
+		"output-dir",
+		"o",
+		DefaultExportDir,
+		"Output directory where the exported files will be written to",
+	)
+}
+
+func addTempo(cmd *cobra.Command, opts *Options) {
+	cmd.Flags().Uint32VarP(&opts.Tempo, "tempo", "t", 0,
+		"beats per minute that replace the tempo of the tunes. The tempo of a tune is used if not given.",
+	)
+}
+
+func addSetPerFolder(cmd *cobra.Command, opts *Options) {
+	cmd.Flags().BoolVar(&opts.SetPerFolder, "set-per-folder", false,
+		"create a set for every folder of an imported archive with all tunes of the files in that folder",
+	)
+}
+
+func addReimport(cmd *cobra.Command, opts *Options) {
+	cmd.Flags().BoolVar(&opts.Reimport, "reimport", false,
+		"update the tunes of an earlier import of a file with the same path instead of skipping the file",
+	)
+	cmd.Flags().StringToStringVar(&opts.TuneMapping, "tune-mapping", nil,
+		"map tune titles to the ids of the tunes they update on a reimport e.g. \"Scotland the Brave=<id>\"",
+	)
+}
+
+func addAutoTag(cmd *cobra.Command, opts *Options) {
+	cmd.Flags().BoolVar(&opts.AutoTag, "auto-tag", false,
+		"tag the imported tunes with the name of their folder and the tags in square brackets "+
+			"of their file name e.g. \"Scotland the Brave [Grade 4].bww\"",
+	)
+}
+
+// importOptions returns the options for importing the files of an archive.
+func (o *Options) importOptions() (importjob.Options, error) {
+	iOpts := importjob.Options{
+		SetPerFolder: o.SetPerFolder,
+		Reimport:     o.Reimport,
+		OwnerID:      o.OwnerID,
+		AutoTag:      o.AutoTag,
+	}
+
+	if len(o.TuneMapping) == 0 {
+		return iOpts, nil
+	}
+
+	iOpts.TuneMapping = make(map[string]uuid.UUID, len(o.TuneMapping))
+	for title, id := range o.TuneMapping {
+		tuneID, err := uuid.Parse(id)
+		if err != nil {
+			return iOpts, fmt.Errorf("invalid tune id %s for tune %s: %v", id, title, err)
+		}
+		iOpts.TuneMapping[title] = tuneID
+	}
+
+	return iOpts, nil
+}
+
+func addSteps(cmd *cobra.Command, opts *Options) {
+	cmd.Flags().IntVarP(&opts.Steps, "steps", "n", 1,
+		"number of migrations to revert",
+	)
+}
+
+func addOwner(cmd *cobra.Command, opts *Options) {
+	cmd.Flags().StringVar(&opts.Owner, "owner", "",
+		"name of the user who owns the imported tunes and sets",
+	)
+}
+
+func addTokenName(cmd *cobra.Command, opts *Options) {
+	cmd.Flags().StringVar(&opts.TokenName, "name", "cli",
+		"name that describes what the token is used for",
+	)
+}
+
+func addRole(cmd *cobra.Command, opts *Options) {
+	cmd.Flags().StringVar(&opts.Role, "role", "",
+		"role of the user in the library, one of admin, editor or viewer",
+	)
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/config"
+	"github.com/tomvodi/limepipes/internal/utils"
+	"strings"
+)
+
+// kinds of the booklet entries in the arguments of the booklet command
+const (
+	bookletSet  = "set"
+	bookletTune = "tune"
+)
+
+func NewBookletCmd(opts *Options) *cobra.Command {
+	bookletCmd := &cobra.Command{
+		Use:   "booklet set:ID|tune:ID...",
+		Short: "Engrave sets and tunes from the database as PDF booklet",
+		Long: `The given sets and tunes will be engraved in their order into one PDF file with a title page 
+and a table of contents, which is written into the output directory. Sets are given as set:ID and single 
+tunes as tune:ID. Only public sets can be added.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: newBookletRunFunc(opts),
+	}
+
+	addVerbose(bookletCmd, opts)
+	addBookletTitle(bookletCmd, opts)
+	addExportDir(bookletCmd, opts)
+
+	return bookletCmd
+}
+
+func newBookletRunFunc(opts *Options) func(*cobra.Command, []string) error {
+	return func(_ *cobra.Command, args []string) error {
+		utils.SetupConsoleLogger()
+
+		entries, err := parseBookletEntries(args)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Init()
+		if err != nil {
+			return fmt.Errorf("failed init configuration: %s", err.Error())
+		}
+
+		dbService, err := setupDbService(cfg.DbConfig())
+		if err != nil {
+			return fmt.Errorf("failed setting up database service: %s", err.Error())
+		}
+
+		te := NewTuneFileExporter(afero.NewOsFs(), dbService, nil)
+		return te.ExportBooklet(apimodel.CreateBooklet{
+			Title:   opts.BookletTitle,
+			Entries: entries,
+		}, opts)
+	}
+}
+
+// parseBookletEntries returns the sets and tunes of arguments like set:ID and tune:ID.
+func parseBookletEntries(args []string) ([]apimodel.BookletEntry, error) {
+	entries := make([]apimodel.BookletEntry, len(args))
+	for i, arg := range args {
+		kind, idArg, _ := strings.Cut(arg, ":")
+		id, err := uuid.Parse(idArg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ID in booklet entry '%s': %s", arg, err.Error())
+		}
+
+		switch kind {
+		case bookletSet:
+			entries[i].SetId = &id
+		case bookletTune:
+			entries[i].TuneId = &id
+		default:
+			return nil, fmt.Errorf("invalid booklet entry '%s', it must be set:ID or tune:ID", arg)
+		}
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/tomvodi/limepipes/internal/config"
+	"github.com/tomvodi/limepipes/internal/database"
+	"github.com/tomvodi/limepipes/internal/database/migration"
+	"github.com/tomvodi/limepipes/internal/utils"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+func NewDbCmd(opts *Options) *cobra.Command {
+	dbCmd := &cobra.Command{
+		Use:   "db",
+		Short: "Manage the schema of the database",
+		Long: `The schema of the database is changed by versioned migrations. The server and the other
+commands refuse to run as long as there are pending migrations or if the database was migrated 
+by a newer version of limepipes.`,
+	}
+
+	migrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply all pending migrations",
+		Args:  cobra.NoArgs,
+		RunE:  newDbRunFunc(migrateDb),
+	}
+
+	rollbackCmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Revert the latest applied migrations",
+		Args:  cobra.NoArgs,
+		RunE: newDbRunFunc(func(m *migration.Migrator, _ io.Writer) error {
+			return rollbackDb(m, opts.Steps)
+		}),
+	}
+	addSteps(rollbackCmd, opts)
+
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show which migrations are applied",
+		Args:  cobra.NoArgs,
+		RunE:  newDbRunFunc(printDbStatus),
+	}
+
+	dbCmd.AddCommand(migrateCmd, rollbackCmd, statusCmd)
+
+	return dbCmd
+}
+
+func newDbRunFunc(
+	run func(m *migration.Migrator, out io.Writer) error,
+) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		utils.SetupConsoleLogger()
+
+		cfg, err := config.Init()
+		if err != nil {
+			return fmt.Errorf("failed init configuration: %s", err.Error())
+		}
+
+		db, err := database.OpenDB(cfg.DbConfig())
+		if err != nil {
+			return fmt.Errorf("failed opening database: %s", err.Error())
+		}
+
+		return run(database.Migrator(db), cmd.OutOrStdout())
+	}
+}
+
+func migrateDb(m *migration.Migrator, _ io.Writer) error {
+	applied, err := m.Up()
+	for _, mig := range applied {
+		log.Info().Msgf("applied migration %d %s", mig.Version, mig.Name)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(applied) == 0 {
+		log.Info().Msg("database schema is up to date")
+	}
+
+	return nil
+}
+
+func rollbackDb(m *migration.Migrator, steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("steps must be at least 1")
+	}
+
+	reverted, err := m.Down(steps)
+	for _, mig := range reverted {
+		log.Info().Msgf("reverted migration %d %s", mig.Version, mig.Name)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(reverted) == 0 {
+		log.Info().Msg("no migration is applied")
+	}
+
+	return nil
+}
+
+func printDbStatus(m *migration.Migrator, out io.Writer) error {
+	statuses, err := m.Status()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, s := range statuses {
+		appliedAt := "pending"
+		if s.AppliedAt != nil {
+			appliedAt = s.AppliedAt.Format(time.DateTime)
+		}
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+	}
+
+	return w.Flush()
+}
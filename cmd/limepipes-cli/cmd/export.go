@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/tomvodi/limepipes/internal/config"
+	"github.com/tomvodi/limepipes/internal/export"
+	"github.com/tomvodi/limepipes/internal/utils"
+)
+
+func NewExportCmd(opts *Options) *cobra.Command {
+	exportCmd := &cobra.Command{
+		Use:   "export [tune IDs...]",
+		Short: "Export tunes from the database into files of another format",
+		Long: `The given tunes will be converted into the given file format by a plugin and written 
+into the output directory. When no tune IDs are given, all tunes of the database will be exported.
+Converted tunes are also stored in the database, so they only have to be converted once.
+The format lilypond writes LilyPond source for bagpipe.ly without a plugin, it is not stored.`,
+		RunE: newExportRunFunc(opts),
+	}
+
+	addVerbose(exportCmd, opts)
+	addExportFormat(exportCmd, opts)
+	addExportDir(exportCmd, opts)
+
+	return exportCmd
+}
+
+func newExportRunFunc(opts *Options) func(*cobra.Command, []string) error {
+	return func(_ *cobra.Command, args []string) error {
+		utils.SetupConsoleLogger()
+
+		tuneIDs, err := parseTuneIDs(args)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Init()
+		if err != nil {
+			return fmt.Errorf("failed init configuration: %s", err.Error())
+		}
+
+		return exportTunesFromDb(cfg, tuneIDs, opts)
+	}
+}
+
+func exportTunesFromDb(
+	cfg *config.Config,
+	tuneIDs []uuid.UUID,
+	opts *Options,
+) error {
+	pluginLoader, err := setupPluginLoader(cfg)
+	if err != nil {
+		return fmt.Errorf("failed setting up plugin loader: %s", err.Error())
+	}
+	defer pluginLoaderUnload(pluginLoader)
+
+	dbService, err := setupDbService(cfg.DbConfig())
+	if err != nil {
+		return fmt.Errorf("failed setting up database service: %s", err.Error())
+	}
+
+	te := NewTuneFileExporter(
+		afero.NewOsFs(),
+		dbService,
+		export.NewExporter(dbService, pluginLoader),
+	)
+	if len(tuneIDs) == 0 {
+		tuneIDs, err = te.AllTuneIDs()
+		if err != nil {
+			return err
+		}
+	}
+
+	return te.ExportTunes(tuneIDs, opts)
+}
+
+func parseTuneIDs(args []string) ([]uuid.UUID, error) {
+	tuneIDs := make([]uuid.UUID, len(args))
+	for i, arg := range args {
+		id, err := uuid.Parse(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tune ID '%s': %s", arg, err.Error())
+		}
+		tuneIDs[i] = id
+	}
+
+	return tuneIDs, nil
+}
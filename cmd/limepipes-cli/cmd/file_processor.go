@@ -7,7 +7,11 @@ import (
 	"github.com/spf13/afero"
 	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/messages"
 	"github.com/tomvodi/limepipes/cmd/limepipes-cli/importtype"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/archive"
 	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/importjob"
 	"github.com/tomvodi/limepipes/internal/interfaces"
 	"io/fs"
 	"os"
@@ -38,9 +42,10 @@ type ProcessFileContext struct {
 }
 
 type FileProcessor struct {
-	afs afero.Fs
-	pl  interfaces.PluginLoader
-	ds  interfaces.DataService
+	afs       afero.Fs
+	pl        interfaces.PluginLoader
+	ds        interfaces.DataService
+	processor *importjob.Processor
 }
 
 // revive:disable:cognitive-complexity this function has complexity of 8 which is fine with threshold of 7
@@ -53,7 +58,7 @@ func (fp *FileProcessor) ProcessFiles(
 		return err
 	}
 
-	gfo, err := fp.setupGetFilesOptions(opts)
+	gfo, err := fp.setupGetFilesOptions(opts, pfo.ImportToDb)
 	if err != nil {
 		return err
 	}
@@ -79,7 +84,7 @@ func (fp *FileProcessor) ProcessFiles(
 		pfc.Filepath = file
 		pfc.CurrentFileNr = i + 1
 
-		err = fp.processFile(pfc, opts, pfo)
+		err = fp.processPath(pfc, opts, pfo)
 		if err != nil {
 			return err
 		}
@@ -93,6 +98,7 @@ func (fp *FileProcessor) ProcessFiles(
 // checkPreconditionsAndInitConfig checks for:
 // - invalid import types
 // - creates the output directory if necessary
+// - the owner of the imported tunes exists
 func (fp *FileProcessor) checkPreconditions(
 	pfo *ProcessFilesOptions,
 	opts *Options,
@@ -106,6 +112,23 @@ func (fp *FileProcessor) checkPreconditions(
 	if err != nil {
 		return err
 	}
+
+	return fp.resolveOwner(opts)
+}
+
+// resolveOwner sets the id of the owner of the imported tunes and sets
+// if an owner is given.
+func (fp *FileProcessor) resolveOwner(opts *Options) error {
+	if opts.Owner == "" {
+		return nil
+	}
+
+	owner, err := fp.ds.GetUserByName(opts.Owner)
+	if err != nil {
+		return fmt.Errorf("failed getting owner %s: %w", opts.Owner, err)
+	}
+	opts.OwnerID = &owner.ID
+
 	return nil
 }
 
@@ -171,14 +194,19 @@ func invalidImportTypesPassed(importTypes []string) []string {
 }
 
 // setupGetFilesOptions returns a GetFilesOptions struct with the given options and
-// the valid file extensions for the given import types.
+// the valid file extensions for the given import types. If withArchives is set,
+// archives are valid files as well.
 func (fp *FileProcessor) setupGetFilesOptions(
 	opts *Options,
+	withArchives bool,
 ) (*GetFilesOptions, error) {
 	fileExtensions, err := fp.validFileExtensionsForImportTypes(opts.ImportTypes)
 	if err != nil {
 		return nil, err
 	}
+	if withArchives {
+		fileExtensions = append(fileExtensions, archive.FileExtensions...)
+	}
 
 	return &GetFilesOptions{
 		Verbose:        opts.Verbose,
@@ -210,6 +238,93 @@ func (fp *FileProcessor) validFileExtensionsForImportTypes(
 	return fileExtensions, nil
 }
 
+// processPath imports the file at the path of the context, which is either an
+// archive or a single file.
+func (fp *FileProcessor) processPath(
+	pfc *ProcessFileContext,
+	opts *Options,
+	pfo *ProcessFilesOptions,
+) error {
+	if pfo.ImportToDb && archive.IsArchive(pfc.Filepath) {
+		return fp.importArchive(pfc, opts)
+	}
+
+	return fp.processFile(pfc, opts, pfo)
+}
+
+// importArchive imports all files of an archive and logs the report of the import.
+// If the skip failed files flag is not set, it returns an error if a file of the
+// archive couldn't be imported.
+func (fp *FileProcessor) importArchive(
+	pfc *ProcessFileContext,
+	opts *Options,
+) error {
+	data, err := afero.ReadFile(fp.afs, pfc.Filepath)
+	if err != nil {
+		return fmt.Errorf("failed reading archive %s: %v", pfc.Filepath, err)
+	}
+
+	iOpts, err := opts.importOptions()
+	if err != nil {
+		return err
+	}
+
+	report, err := fp.processor.ImportArchive(pfc.Filepath, data, iOpts)
+	if report != nil {
+		logImportReport(pfc, report)
+	}
+	if err == nil {
+		err = importReportError(report)
+	}
+
+	if err != nil && opts.SkipFailedFiles {
+		log.Error().Err(err).Msgf("failed importing archive %s", pfc.Filepath)
+		return nil
+	}
+
+	return err
+}
+
+func logImportReport(pfc *ProcessFileContext, report *model.ImportReport) {
+	for _, f := range report.Duplicates() {
+		log.Warn().Msgf("skipped %s, it was already imported", f.Name)
+	}
+	for _, f := range report.Failures() {
+		log.Error().Msgf("failed importing %s: %s", f.Name, f.Error)
+	}
+	for _, s := range report.FolderSets {
+		log.Info().Msgf("created set %s", s.Title)
+	}
+	logUpdatedTunes(report.Updated())
+
+	log.Info().Msgf("(%d/%d) imported %d of %d files from archive %s (%d duplicates, %d failed)",
+		pfc.CurrentFileNr,
+		pfc.AllFilesCnt,
+		report.Imported(),
+		len(report.Files),
+		pfc.Filepath,
+		len(report.Duplicates()),
+		len(report.Failures()),
+	)
+}
+
+func logUpdatedTunes(tunes []*apimodel.ImportTune) {
+	for _, t := range tunes {
+		if t.Action == model.ReimportActionUpdated {
+			log.Info().Msgf("updated tune %s (%s)", t.Title, strings.Join(t.Changes, ", "))
+		}
+	}
+}
+
+func importReportError(report *model.ImportReport) error {
+	failures := report.Failures()
+	if len(failures) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%d of %d files couldn't be imported", len(failures), len(report.Files))
+}
+
 // processFile processes a single file by parsing it, moving it to the output directory if necessary
 // and importing the parsed tunes into the database if necessary.
 func (fp *FileProcessor) processFile(
@@ -319,7 +434,16 @@ func (fp *FileProcessor) importTunesFromFile(
 		return fmt.Errorf("failed getting file info for file %s: %v", pfc.Filepath, err)
 	}
 
-	_, _, err = fp.ds.ImportTunes(parsedTunes, fInfo)
+	iOpts, err := opts.importOptions()
+	if err != nil {
+		return err
+	}
+	fInfo.Reimport = iOpts.Reimport
+	fInfo.TuneMapping = iOpts.TuneMapping
+	fInfo.OwnerID = iOpts.OwnerID
+	fInfo.Tags = iOpts.TagsForFile(fInfo.OriginalPath)
+
+	importTunes, _, err := fp.ds.ImportTunes(parsedTunes, fInfo)
 	if err != nil {
 		if opts.SkipFailedFiles {
 			log.Error().Err(err).Msgf("failed importing parsedTunes from file %s", pfc.Filepath)
@@ -328,6 +452,7 @@ func (fp *FileProcessor) importTunesFromFile(
 
 		return fmt.Errorf("failed importing parsedTunes from file %s: %v", pfc.Filepath, err)
 	}
+	logUpdatedTunes(importTunes)
 
 	return nil
 }
@@ -477,8 +602,9 @@ func fileHasValidExtension(
 	path string,
 	fExt []string,
 ) bool {
-	ext := filepath.Ext(path)
-	return slices.Contains(fExt, ext)
+	return slices.ContainsFunc(fExt, func(ext string) bool {
+		return strings.HasSuffix(path, ext)
+	})
 }
 
 // moveProcessFile moves the file to the output directory if the MoveToOutputDir option is set.
@@ -518,8 +644,9 @@ func NewFileProcessor(
 	ds interfaces.DataService,
 ) *FileProcessor {
 	return &FileProcessor{
-		afs: afs,
-		pl:  pl,
-		ds:  ds,
+		afs:       afs,
+		pl:        pl,
+		ds:        ds,
+		processor: importjob.NewProcessor(ds, pl),
 	}
 }
@@ -11,6 +11,8 @@ import (
 	pmocks "github.com/tomvodi/limepipes-plugin-api/plugin/v1/interfaces/mocks"
 	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/messages"
 	"github.com/tomvodi/limepipes/cmd/limepipes-cli/importtype"
+	"github.com/tomvodi/limepipes/internal/archive"
+	"github.com/tomvodi/limepipes/internal/common"
 	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
 	"github.com/tomvodi/limepipes/internal/utils"
 	"os"
@@ -89,6 +91,59 @@ var _ = Describe("FileProcessor", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		When("having an archive in a directory that should be imported", func() {
+			BeforeEach(func() {
+				pfo.ArgPaths = []string{"testdata"}
+				pfo.ImportToDb = true
+				opts.SetPerFolder = true
+				err := afero.WriteFile(afs, "testdata/tunes.zip", archive.TestZip(
+					archive.TestFile{Name: "tune1.bww", Content: "tune1.bww testdata"},
+				), 0644)
+				Expect(err).NotTo(HaveOccurred())
+
+				pl.EXPECT().FileFormatForFileExtension(".bww").
+					Return(fileformat.Format_BWW, nil)
+				ds.EXPECT().GetImportFileByHash(mock.Anything).
+					Return(nil, common.ErrNotFound)
+				pl.EXPECT().PluginForFileExtension(".bww").
+					Return(filePlug, nil)
+				filePlug.EXPECT().Parse([]byte("tune1.bww testdata")).
+					Return(parsedTunes, nil)
+			})
+
+			When("a file of the archive can't be imported", func() {
+				BeforeEach(func() {
+					ds.EXPECT().ImportTunes(parsedTunes, mock.Anything).
+						Return(nil, nil, fmt.Errorf("import error"))
+				})
+
+				It("should return an error", func() {
+					Expect(err).To(HaveOccurred())
+				})
+
+				When("failed files should be skipped", func() {
+					BeforeEach(func() {
+						opts.SkipFailedFiles = true
+					})
+
+					It("should not return an error", func() {
+						Expect(err).NotTo(HaveOccurred())
+					})
+				})
+			})
+
+			When("all files of the archive were imported", func() {
+				BeforeEach(func() {
+					ds.EXPECT().ImportTunes(parsedTunes, mock.Anything).
+						Return(nil, nil, nil)
+				})
+
+				It("should not return an error", func() {
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+		})
+
 		When("having one file in a directory", func() {
 			BeforeEach(func() {
 				pfo.ArgPaths = []string{"testdata"}
@@ -299,6 +354,24 @@ func Test_getAllFilesFromPaths(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "get archives with extensions of multiple dots",
+			prepare: func(f *fields) {
+				f.opts = &GetFilesOptions{
+					FileExtensions: []string{".bww", ".zip", ".tar.gz"},
+				}
+				f.files = []string{
+					"testdata/tunes.tar.gz",
+					"testdata/tunes.zip",
+					"testdata/tunes.gz",
+				}
+				f.paths = []string{"testdata"}
+				f.want = []string{
+					"testdata/tunes.tar.gz",
+					"testdata/tunes.zip",
+				}
+			},
+		},
 		{
 			name: "get single files passed",
 			prepare: func(f *fields) {
@@ -17,6 +17,9 @@ func NewImportCmd(opts *Options) *cobra.Command {
 When given directory paths, it will import all files of that directory. It will also include 
 subdirectories when given the recursive flag.
 If a given file that has an extension which is not in the import-file-types, it will be ignored. 
+Archives (.zip, .tar.gz) are unpacked and all files in it that can be parsed are imported.
+Files that were already imported with other content can be imported again with the reimport flag.
+This updates the tunes of the earlier import that have the same title instead of creating new ones.
 `,
 		Args: cobra.MinimumNArgs(1),
 		RunE: newImportRunFunc(opts),
@@ -27,6 +30,10 @@ If a given file that has an extension which is not in the import-file-types, it
 	addRecursive(importCmd, opts)
 	addImportFileTypes(importCmd, opts)
 	addSkipFailedFiles(importCmd, opts)
+	addSetPerFolder(importCmd, opts)
+	addReimport(importCmd, opts)
+	addAutoTag(importCmd, opts)
+	addOwner(importCmd, opts)
 
 	return importCmd
 }
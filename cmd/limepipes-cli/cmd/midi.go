@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/tomvodi/limepipes/internal/config"
+	"github.com/tomvodi/limepipes/internal/utils"
+)
+
+func NewMidiCmd(opts *Options) *cobra.Command {
+	midiCmd := &cobra.Command{
+		Use:   "midi [tune IDs...]",
+		Short: "Render tunes from the database as MIDI files",
+		Long: `The music of the given tunes will be rendered as MIDI files with gracenotes and drones 
+and written into the output directory. When no tune IDs are given, all tunes of the database will be rendered.`,
+		RunE: newMidiRunFunc(opts),
+	}
+
+	addVerbose(midiCmd, opts)
+	addTempo(midiCmd, opts)
+	addExportDir(midiCmd, opts)
+
+	return midiCmd
+}
+
+func newMidiRunFunc(opts *Options) func(*cobra.Command, []string) error {
+	return func(_ *cobra.Command, args []string) error {
+		utils.SetupConsoleLogger()
+
+		tuneIDs, err := parseTuneIDs(args)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Init()
+		if err != nil {
+			return fmt.Errorf("failed init configuration: %s", err.Error())
+		}
+
+		return renderMidiFromDb(cfg, tuneIDs, opts)
+	}
+}
+
+func renderMidiFromDb(
+	cfg *config.Config,
+	tuneIDs []uuid.UUID,
+	opts *Options,
+) error {
+	dbService, err := setupDbService(cfg.DbConfig())
+	if err != nil {
+		return fmt.Errorf("failed setting up database service: %s", err.Error())
+	}
+
+	te := NewTuneFileExporter(afero.NewOsFs(), dbService, nil)
+	if len(tuneIDs) == 0 {
+		tuneIDs, err = te.AllTuneIDs()
+		if err != nil {
+			return err
+		}
+	}
+
+	return te.ExportMidi(tuneIDs, opts)
+}
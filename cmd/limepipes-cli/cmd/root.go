@@ -20,6 +20,12 @@ func Execute() {
 	opts := &Options{}
 	rootCmd.AddCommand(NewParseCmd(opts))
 	rootCmd.AddCommand(NewImportCmd(opts))
+	rootCmd.AddCommand(NewExportCmd(opts))
+	rootCmd.AddCommand(NewMidiCmd(opts))
+	rootCmd.AddCommand(NewScoreCmd(opts))
+	rootCmd.AddCommand(NewBookletCmd(opts))
+	rootCmd.AddCommand(NewDbCmd(opts))
+	rootCmd.AddCommand(NewUserCmd(opts))
 	err := rootCmd.Execute()
 	if err != nil {
 		os.Exit(1)
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/tomvodi/limepipes/internal/config"
+	"github.com/tomvodi/limepipes/internal/utils"
+)
+
+func NewScoreCmd(opts *Options) *cobra.Command {
+	scoreCmd := &cobra.Command{
+		Use:   "score [tune IDs...]",
+		Short: "Engrave tunes from the database as sheet music",
+		Long: `The music of the given tunes will be engraved as sheet music in PDF or SVG format 
+and written into the output directory. When no tune IDs are given, all tunes of the database will be engraved.`,
+		RunE: newScoreRunFunc(opts),
+	}
+
+	addVerbose(scoreCmd, opts)
+	addScoreFormat(scoreCmd, opts)
+	addExportDir(scoreCmd, opts)
+
+	return scoreCmd
+}
+
+func newScoreRunFunc(opts *Options) func(*cobra.Command, []string) error {
+	return func(_ *cobra.Command, args []string) error {
+		utils.SetupConsoleLogger()
+
+		tuneIDs, err := parseTuneIDs(args)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Init()
+		if err != nil {
+			return fmt.Errorf("failed init configuration: %s", err.Error())
+		}
+
+		return engraveScoresFromDb(cfg, tuneIDs, opts)
+	}
+}
+
+func engraveScoresFromDb(
+	cfg *config.Config,
+	tuneIDs []uuid.UUID,
+	opts *Options,
+) error {
+	dbService, err := setupDbService(cfg.DbConfig())
+	if err != nil {
+		return fmt.Errorf("failed setting up database service: %s", err.Error())
+	}
+
+	te := NewTuneFileExporter(afero.NewOsFs(), dbService, nil)
+	if len(tuneIDs) == 0 {
+		tuneIDs, err = te.AllTuneIDs()
+		if err != nil {
+			return err
+		}
+	}
+
+	return te.ExportScores(tuneIDs, opts)
+}
@@ -3,7 +3,6 @@ package cmd
 import (
 	"fmt"
 	"github.com/rs/zerolog/log"
-	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
 	"github.com/tomvodi/limepipes/internal/api"
 	"github.com/tomvodi/limepipes/internal/config"
 	"github.com/tomvodi/limepipes/internal/database"
@@ -15,12 +14,7 @@ import (
 func setupPluginLoader(
 	cfg *config.Config,
 ) (*pluginloader.Loader, error) {
-	// TODO: Load plugins from config
-	LoadPlugins := []string{
-		fileformat.Format_BWW.String(),
-	}
-
-	pluginLoader := initialize.PluginLoader(LoadPlugins)
+	pluginLoader := initialize.PluginLoader(cfg.PluginIDs())
 	err := pluginLoader.LoadPluginsFromDir(cfg.PluginsDirectoryPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed loading plugins: %w", err)
@@ -39,7 +33,7 @@ func pluginLoaderUnload(pluginLoader interfaces.PluginLoader) {
 func setupDbService(
 	cfg config.DbConfig,
 ) (*database.Service, error) {
-	db, err := database.GetInitPostgreSQLDB(cfg)
+	db, err := database.GetInitDB(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed initializing database: %s", err.Error())
 	}
@@ -0,0 +1,322 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/afero"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/booklet"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/interfaces"
+	"github.com/tomvodi/limepipes/internal/lilypond"
+	"github.com/tomvodi/limepipes/internal/midi"
+	"github.com/tomvodi/limepipes/internal/score"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// TuneFileExporter exports tunes from the database and writes them into files.
+type TuneFileExporter struct {
+	afs       afero.Fs
+	ds        interfaces.DataService
+	exporter  interfaces.TuneExporter
+	usedNames map[string]bool
+}
+
+// AllTuneIDs returns the IDs of all tunes in the database.
+func (te *TuneFileExporter) AllTuneIDs() ([]uuid.UUID, error) {
+	var tuneIDs []uuid.UUID
+	listOpts := common.TuneListOptions{
+		PageOptions: common.PageOptions{
+			Page:     1,
+			PageSize: common.MaxPageSize,
+		},
+	}
+
+	for {
+		tuneList, err := te.ds.Tunes(listOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed getting tunes: %s", err.Error())
+		}
+
+		for _, t := range tuneList.Tunes {
+			tuneIDs = append(tuneIDs, t.Id)
+		}
+
+		if listOpts.Page >= int(tuneList.Pagination.TotalPages) {
+			return tuneIDs, nil
+		}
+		listOpts.Page++
+	}
+}
+
+// ExportTunes exports the given tunes into the export format and writes them
+// into the export directory of the options. The lilypond format is written
+// from the music model of the tunes instead of by a plugin. Tunes with the same title get
+// a number appended to the file name.
+func (te *TuneFileExporter) ExportTunes(
+	tuneIDs []uuid.UUID,
+	opts *Options,
+) error {
+	if lilypond.IsFormatName(opts.ExportFormat) {
+		return te.exportAll(tuneIDs, opts, func(tuneID uuid.UUID) (string, error) {
+			return te.exportLilyPond(tuneID, opts.ExportDir)
+		})
+	}
+
+	fFormat, err := common.FileFormatFromName(opts.ExportFormat)
+	if err != nil {
+		return err
+	}
+
+	return te.exportAll(tuneIDs, opts, func(tuneID uuid.UUID) (string, error) {
+		return te.exportTune(tuneID, fFormat, opts.ExportDir)
+	})
+}
+
+// ExportMidi renders the given tunes as MIDI files in the tempo of the
+// options and writes them into the export directory of the options.
+func (te *TuneFileExporter) ExportMidi(
+	tuneIDs []uuid.UUID,
+	opts *Options,
+) error {
+	return te.exportAll(tuneIDs, opts, func(tuneID uuid.UUID) (string, error) {
+		return te.exportMidi(tuneID, opts.Tempo, opts.ExportDir)
+	})
+}
+
+// ExportScores engraves the given tunes as sheet music in the score format
+// of the options and writes them into the export directory of the options.
+func (te *TuneFileExporter) ExportScores(
+	tuneIDs []uuid.UUID,
+	opts *Options,
+) error {
+	if opts.ScoreFormat != ScoreFormatPDF && opts.ScoreFormat != ScoreFormatSVG {
+		return fmt.Errorf("%w: unknown score format '%s'", common.ErrInvalidArgument, opts.ScoreFormat)
+	}
+
+	return te.exportAll(tuneIDs, opts, func(tuneID uuid.UUID) (string, error) {
+		return te.exportScore(tuneID, opts.ScoreFormat, opts.ExportDir)
+	})
+}
+
+// ExportBooklet engraves the sets and tunes of the booklet as PDF file
+// named after its title in the export directory of the options.
+func (te *TuneFileExporter) ExportBooklet(
+	b apimodel.CreateBooklet,
+	opts *Options,
+) error {
+	s, err := booklet.NewBuilder(te.ds).Build(b, nil)
+	if err != nil {
+		return fmt.Errorf("failed engraving booklet %s: %w", b.Title, err)
+	}
+
+	if err = te.afs.MkdirAll(opts.ExportDir, os.ModePerm); err != nil {
+		return fmt.Errorf("failed creating export directory %s: %s", opts.ExportDir, err.Error())
+	}
+
+	fp := filepath.Join(opts.ExportDir, common.TitleFileName(b.Title, score.ExtensionPDF))
+	if err = te.writeFile(fp, s.PDF()); err != nil {
+		return err
+	}
+
+	log.Info().Msgf("exported booklet with %d pages to %s", s.Pages(), fp)
+
+	return nil
+}
+
+// exportAll exports every tune with the export function, which returns
+// the path of the written file.
+func (te *TuneFileExporter) exportAll(
+	tuneIDs []uuid.UUID,
+	opts *Options,
+	export func(tuneID uuid.UUID) (string, error),
+) error {
+	if err := te.afs.MkdirAll(opts.ExportDir, os.ModePerm); err != nil {
+		return fmt.Errorf("failed creating export directory %s: %s", opts.ExportDir, err.Error())
+	}
+
+	te.usedNames = map[string]bool{}
+	for i, tuneID := range tuneIDs {
+		if opts.Verbose {
+			log.Info().Msgf("exporting tune %d/%d %s", i+1, len(tuneIDs), tuneID)
+		}
+
+		fp, err := export(tuneID)
+		if err != nil {
+			return err
+		}
+
+		log.Info().Msgf("exported tune %s to %s", tuneID, fp)
+	}
+
+	return nil
+}
+
+func (te *TuneFileExporter) exportTune(
+	tuneID uuid.UUID,
+	fFormat fileformat.Format,
+	exportDir string,
+) (string, error) {
+	apiTune, err := te.ds.GetTune(tuneID)
+	if err != nil {
+		return "", fmt.Errorf("failed getting tune %s: %s", tuneID, err.Error())
+	}
+
+	tuneFile, err := te.exporter.ExportTune(tuneID, fFormat)
+	if err != nil {
+		return "", fmt.Errorf("failed exporting tune %s: %s", tuneID, err.Error())
+	}
+
+	fp := filepath.Join(exportDir, te.uniqueFileName(
+		common.TuneFileName(apiTune.Title, fFormat),
+	))
+	if err = afero.WriteFile(te.afs, fp, tuneFile.Data, 0644); err != nil {
+		return "", fmt.Errorf("failed writing file %s: %s", fp, err.Error())
+	}
+
+	return fp, nil
+}
+
+func (te *TuneFileExporter) exportMidi(
+	tuneID uuid.UUID,
+	tempo uint32,
+	exportDir string,
+) (string, error) {
+	title, muMoTune, err := te.musicModelTune(tuneID)
+	if err != nil {
+		return "", err
+	}
+
+	fp := filepath.Join(exportDir, te.uniqueFileName(
+		common.TitleFileName(title, midi.Extension),
+	))
+
+	return fp, te.writeFile(fp, midi.Render(muMoTune, tempo))
+}
+
+// exportLilyPond writes the music model of the tune as LilyPond source,
+// which is written without a plugin.
+func (te *TuneFileExporter) exportLilyPond(
+	tuneID uuid.UUID,
+	exportDir string,
+) (string, error) {
+	title, muMoTune, err := te.musicModelTune(tuneID)
+	if err != nil {
+		return "", err
+	}
+
+	fp := filepath.Join(exportDir, te.uniqueFileName(
+		common.TitleFileName(title, lilypond.Extension),
+	))
+
+	return fp, te.writeFile(fp, lilypond.Write(muMoTune))
+}
+
+// exportScore writes the score of the tune as PDF document or as SVG images
+// and returns the path of the first written file.
+func (te *TuneFileExporter) exportScore(
+	tuneID uuid.UUID,
+	format string,
+	exportDir string,
+) (string, error) {
+	title, muMoTune, err := te.musicModelTune(tuneID)
+	if err != nil {
+		return "", err
+	}
+
+	s := score.Engrave(muMoTune)
+	if format == ScoreFormatPDF {
+		fp := filepath.Join(exportDir, te.uniqueFileName(
+			common.TitleFileName(title, score.ExtensionPDF),
+		))
+		return fp, te.writeFile(fp, s.PDF())
+	}
+
+	name := te.uniqueFileName(common.TitleFileName(title, score.ExtensionSVG))
+	fp := filepath.Join(exportDir, name)
+
+	return fp, te.writeSvgPages(s, fp)
+}
+
+// writeSvgPages writes the pages of the score as SVG images, the pages after
+// the first one get the page number appended to the file path.
+func (te *TuneFileExporter) writeSvgPages(s *score.Score, fp string) error {
+	base := strings.TrimSuffix(fp, score.ExtensionSVG)
+	for page := 1; page <= s.Pages(); page++ {
+		svg, err := s.SVG(page)
+		if err != nil {
+			return err
+		}
+
+		pageFp := fp
+		if page > 1 {
+			pageFp = fmt.Sprintf("%s-page%d%s", base, page, score.ExtensionSVG)
+		}
+		if err = te.writeFile(pageFp, svg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// musicModelTune returns the title and the decoded music model of the tune.
+func (te *TuneFileExporter) musicModelTune(tuneID uuid.UUID) (string, *tune.Tune, error) {
+	apiTune, err := te.ds.GetTune(tuneID)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed getting tune %s: %s", tuneID, err.Error())
+	}
+
+	muMoFile, err := te.ds.GetTuneFile(tuneID, fileformat.Format_MUSIC_MODEL)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed getting music model of tune %s: %s", tuneID, err.Error())
+	}
+
+	muMoTune, err := muMoFile.MusicModelTune()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed decoding music model of tune %s: %s", tuneID, err.Error())
+	}
+
+	return apiTune.Title, muMoTune, nil
+}
+
+func (te *TuneFileExporter) writeFile(fp string, data []byte) error {
+	if err := afero.WriteFile(te.afs, fp, data, 0644); err != nil {
+		return fmt.Errorf("failed writing file %s: %s", fp, err.Error())
+	}
+
+	return nil
+}
+
+// uniqueFileName appends a number to the file name if it was already used
+// in the current export.
+func (te *TuneFileExporter) uniqueFileName(name string) string {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+
+	unique := name
+	for i := 2; te.usedNames[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d%s", base, i, ext)
+	}
+	te.usedNames[unique] = true
+
+	return unique
+}
+
+func NewTuneFileExporter(
+	afs afero.Fs,
+	ds interfaces.DataService,
+	exporter interfaces.TuneExporter,
+) *TuneFileExporter {
+	return &TuneFileExporter{
+		afs:       afs,
+		ds:        ds,
+		exporter:  exporter,
+		usedNames: map[string]bool{},
+	}
+}
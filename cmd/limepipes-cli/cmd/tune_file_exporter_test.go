@@ -0,0 +1,328 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"github.com/tomvodi/limepipes/internal/lilypond"
+	"github.com/tomvodi/limepipes/internal/midi"
+	"github.com/tomvodi/limepipes/internal/score"
+)
+
+var _ = Describe("TuneFileExporter", func() {
+	var err error
+	var afs afero.Fs
+	var opts *Options
+	var te *TuneFileExporter
+	var ds *mocks.DataService
+	var exporter *mocks.TuneExporter
+	var tuneID1, tuneID2 uuid.UUID
+
+	BeforeEach(func() {
+		afs = afero.NewMemMapFs()
+		opts = &Options{
+			ExportFormat: "musicxml",
+			ExportDir:    "/export",
+		}
+		ds = mocks.NewDataService(GinkgoT())
+		exporter = mocks.NewTuneExporter(GinkgoT())
+		te = NewTuneFileExporter(afs, ds, exporter)
+		tuneID1 = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+		tuneID2 = uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	})
+
+	Context("exporting tunes", func() {
+		JustBeforeEach(func() {
+			err = te.ExportTunes([]uuid.UUID{tuneID1, tuneID2}, opts)
+		})
+
+		When("the export format is unknown", func() {
+			BeforeEach(func() {
+				opts.ExportFormat = "pdf"
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(MatchError(common.ErrInvalidArgument))
+			})
+		})
+
+		When("exporting a tune fails", func() {
+			BeforeEach(func() {
+				ds.EXPECT().GetTune(tuneID1).
+					Return(&apimodel.Tune{Id: tuneID1, Title: "Tune"}, nil)
+				exporter.EXPECT().ExportTune(tuneID1, fileformat.Format_MUSIC_XML).
+					Return(nil, fmt.Errorf("no plugin"))
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		When("both tunes have the same title", func() {
+			BeforeEach(func() {
+				for i, id := range []uuid.UUID{tuneID1, tuneID2} {
+					ds.EXPECT().GetTune(id).
+						Return(&apimodel.Tune{Id: id, Title: "Mull of Kintyre"}, nil)
+					exporter.EXPECT().ExportTune(id, fileformat.Format_MUSIC_XML).
+						Return(&model.TuneFile{
+							TuneID: id,
+							Format: fileformat.Format_MUSIC_XML,
+							Data:   []byte(fmt.Sprintf("tune %d", i+1)),
+						}, nil)
+				}
+			})
+
+			It("should write both files with different names", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+
+				data, err := afero.ReadFile(afs, "/export/Mull_of_Kintyre.musicxml")
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(string(data)).To(Equal("tune 1"))
+
+				data, err = afero.ReadFile(afs, "/export/Mull_of_Kintyre_2.musicxml")
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(string(data)).To(Equal("tune 2"))
+			})
+		})
+
+		When("the export format is lilypond", func() {
+			var muMoFile *model.TuneFile
+
+			BeforeEach(func() {
+				opts.ExportFormat = "lilypond"
+				muMoFile, err = model.TuneFileFromMusicModelTune(model.TestParsedTune("Mull of Kintyre").Tune)
+				Expect(err).ShouldNot(HaveOccurred())
+				for _, id := range []uuid.UUID{tuneID1, tuneID2} {
+					ds.EXPECT().GetTune(id).
+						Return(&apimodel.Tune{Id: id, Title: "Mull of Kintyre"}, nil)
+					ds.EXPECT().GetTuneFile(id, fileformat.Format_MUSIC_MODEL).
+						Return(muMoFile, nil)
+				}
+			})
+
+			It("should write the LilyPond source without a plugin", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				muMoTune, err := muMoFile.MusicModelTune()
+				Expect(err).ShouldNot(HaveOccurred())
+
+				data, err := afero.ReadFile(afs, "/export/Mull_of_Kintyre.ly")
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(data).To(Equal(lilypond.Write(muMoTune)))
+				Expect(afero.Exists(afs, "/export/Mull_of_Kintyre_2.ly")).To(BeTrue())
+			})
+		})
+	})
+
+	Context("exporting tunes as MIDI files", func() {
+		var muMoFile *model.TuneFile
+
+		BeforeEach(func() {
+			muMoFile, err = model.TuneFileFromMusicModelTune(model.TestParsedTune("Mull of Kintyre").Tune)
+			Expect(err).ShouldNot(HaveOccurred())
+			opts.Tempo = 90
+		})
+
+		JustBeforeEach(func() {
+			err = te.ExportMidi([]uuid.UUID{tuneID1}, opts)
+		})
+
+		When("the tune has a music model", func() {
+			BeforeEach(func() {
+				ds.EXPECT().GetTune(tuneID1).
+					Return(&apimodel.Tune{Id: tuneID1, Title: "Mull of Kintyre"}, nil)
+				ds.EXPECT().GetTuneFile(tuneID1, fileformat.Format_MUSIC_MODEL).
+					Return(muMoFile, nil)
+			})
+
+			It("should write the MIDI file in the tempo of the options", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				muMoTune, err := muMoFile.MusicModelTune()
+				Expect(err).ShouldNot(HaveOccurred())
+
+				data, err := afero.ReadFile(afs, "/export/Mull_of_Kintyre.mid")
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(data).To(Equal(midi.Render(muMoTune, 90)))
+			})
+		})
+
+		When("the tune has no music model", func() {
+			BeforeEach(func() {
+				ds.EXPECT().GetTune(tuneID1).
+					Return(&apimodel.Tune{Id: tuneID1, Title: "Mull of Kintyre"}, nil)
+				ds.EXPECT().GetTuneFile(tuneID1, fileformat.Format_MUSIC_MODEL).
+					Return(nil, common.ErrNotFound)
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Context("exporting tunes as scores", func() {
+		var muMoFile *model.TuneFile
+		var expectedScore *score.Score
+
+		BeforeEach(func() {
+			muMoFile, err = model.TuneFileFromMusicModelTune(model.TestParsedTune("Mull of Kintyre").Tune)
+			Expect(err).ShouldNot(HaveOccurred())
+			muMoTune, err := muMoFile.MusicModelTune()
+			Expect(err).ShouldNot(HaveOccurred())
+			expectedScore = score.Engrave(muMoTune)
+			opts.ScoreFormat = ScoreFormatPDF
+		})
+
+		JustBeforeEach(func() {
+			err = te.ExportScores([]uuid.UUID{tuneID1}, opts)
+		})
+
+		expectTuneWithMusicModel := func() {
+			ds.EXPECT().GetTune(tuneID1).
+				Return(&apimodel.Tune{Id: tuneID1, Title: "Mull of Kintyre"}, nil)
+			ds.EXPECT().GetTuneFile(tuneID1, fileformat.Format_MUSIC_MODEL).
+				Return(muMoFile, nil)
+		}
+
+		When("the score format is PDF", func() {
+			BeforeEach(expectTuneWithMusicModel)
+
+			It("should write the PDF document", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				data, err := afero.ReadFile(afs, "/export/Mull_of_Kintyre.pdf")
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(data).To(Equal(expectedScore.PDF()))
+			})
+		})
+
+		When("the score format is SVG", func() {
+			BeforeEach(func() {
+				expectTuneWithMusicModel()
+				opts.ScoreFormat = ScoreFormatSVG
+			})
+
+			It("should write the SVG image of the page", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				svg, err := expectedScore.SVG(1)
+				Expect(err).ShouldNot(HaveOccurred())
+				data, err := afero.ReadFile(afs, "/export/Mull_of_Kintyre.svg")
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(data).To(Equal(svg))
+			})
+		})
+
+		When("the score format is unknown", func() {
+			BeforeEach(func() {
+				opts.ScoreFormat = "png"
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(MatchError(common.ErrInvalidArgument))
+			})
+		})
+	})
+
+	Context("exporting a booklet", func() {
+		var entries []apimodel.BookletEntry
+
+		BeforeEach(func() {
+			entries, err = parseBookletEntries([]string{"tune:" + tuneID1.String()})
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			err = te.ExportBooklet(apimodel.CreateBooklet{Title: "Band Practice", Entries: entries}, opts)
+		})
+
+		When("the tune has a music model", func() {
+			BeforeEach(func() {
+				muMoFile, err := model.TuneFileFromMusicModelTune(model.TestParsedTune("Mull of Kintyre").Tune)
+				Expect(err).ShouldNot(HaveOccurred())
+				ds.EXPECT().GetTune(tuneID1).
+					Return(&apimodel.Tune{Id: tuneID1, Title: "Mull of Kintyre"}, nil)
+				ds.EXPECT().GetTuneFile(tuneID1, fileformat.Format_MUSIC_MODEL).
+					Return(muMoFile, nil)
+			})
+
+			It("should write the PDF file named after the title", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				data, err := afero.ReadFile(afs, "/export/Band_Practice.pdf")
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(string(data)).To(ContainSubstring("(Mull of Kintyre)"))
+			})
+		})
+
+		When("the tune has no music model", func() {
+			BeforeEach(func() {
+				ds.EXPECT().GetTune(tuneID1).
+					Return(&apimodel.Tune{Id: tuneID1, Title: "Mull of Kintyre"}, nil)
+				ds.EXPECT().GetTuneFile(tuneID1, fileformat.Format_MUSIC_MODEL).
+					Return(nil, common.ErrNotFound)
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(MatchError(common.ErrNotFound))
+			})
+		})
+	})
+
+	Context("parsing booklet entries", func() {
+		It("should return the sets and tunes in their order", func() {
+			entries, err := parseBookletEntries([]string{"set:" + tuneID2.String(), "tune:" + tuneID1.String()})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(entries).To(Equal([]apimodel.BookletEntry{{SetId: &tuneID2}, {TuneId: &tuneID1}}))
+		})
+
+		It("should return an error for entries without kind", func() {
+			_, err := parseBookletEntries([]string{tuneID1.String()})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("getting all tune IDs", func() {
+		var tuneIDs []uuid.UUID
+
+		JustBeforeEach(func() {
+			tuneIDs, err = te.AllTuneIDs()
+		})
+
+		When("the tunes are on multiple pages", func() {
+			BeforeEach(func() {
+				ds.EXPECT().Tunes(common.TuneListOptions{PageOptions: common.PageOptions{Page: 1, PageSize: common.MaxPageSize}}).
+					Return(&apimodel.TuneList{
+						Tunes:      []apimodel.Tune{{Id: tuneID1}},
+						Pagination: apimodel.Pagination{Page: 1, TotalPages: 2},
+					}, nil)
+				ds.EXPECT().Tunes(common.TuneListOptions{PageOptions: common.PageOptions{Page: 2, PageSize: common.MaxPageSize}}).
+					Return(&apimodel.TuneList{
+						Tunes:      []apimodel.Tune{{Id: tuneID2}},
+						Pagination: apimodel.Pagination{Page: 2, TotalPages: 2},
+					}, nil)
+			})
+
+			It("should return the IDs of all pages", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(tuneIDs).To(Equal([]uuid.UUID{tuneID1, tuneID2}))
+			})
+		})
+
+		When("there are no tunes", func() {
+			BeforeEach(func() {
+				ds.EXPECT().Tunes(common.TuneListOptions{PageOptions: common.PageOptions{Page: 1, PageSize: common.MaxPageSize}}).
+					Return(&apimodel.TuneList{}, nil)
+			})
+
+			It("should return no IDs", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(tuneIDs).To(BeEmpty())
+			})
+		})
+	})
+})
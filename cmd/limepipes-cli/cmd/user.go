@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/config"
+	"github.com/tomvodi/limepipes/internal/interfaces"
+	"github.com/tomvodi/limepipes/internal/utils"
+	"io"
+)
+
+func NewUserCmd(opts *Options) *cobra.Command {
+	userCmd := &cobra.Command{
+		Use:   "user",
+		Short: "Manage the users of the API",
+		Long: `Every request to the API needs the bearer token of a user. Users that log in with a token
+of an identity provider are created on their first request, all others have to be created here.
+What a user is allowed to do depends on the role of the user, users without a role have the
+configured default role.`,
+	}
+
+	createCmd := &cobra.Command{
+		Use:   "create [name]",
+		Short: "Create a user",
+		Args:  cobra.ExactArgs(1),
+		RunE:  newUserRunFunc(opts.createUser),
+	}
+	addRole(createCmd, opts)
+
+	roleCmd := &cobra.Command{
+		Use:   "role [name]",
+		Short: "Set the role of a user",
+		Long: `Gives the user with the given name a role. This is also the way to make the
+first user an admin, who can then manage the roles of all other users with the API.`,
+		Args: cobra.ExactArgs(1),
+		RunE: newUserRunFunc(opts.setRole),
+	}
+	addRole(roleCmd, opts)
+	_ = roleCmd.MarkFlagRequired("role")
+
+	tokenCmd := &cobra.Command{
+		Use:   "token [name]",
+		Short: "Create an API token for a user",
+		Long: `Creates a new API token for the user with the given name and prints it.
+The token can't be shown again, as only its hash is stored.`,
+		Args: cobra.ExactArgs(1),
+		RunE: newUserRunFunc(opts.createAPIToken),
+	}
+	addTokenName(tokenCmd, opts)
+
+	userCmd.AddCommand(createCmd, roleCmd, tokenCmd)
+
+	return userCmd
+}
+
+func newUserRunFunc(
+	run func(ds interfaces.DataService, name string, out io.Writer) error,
+) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		utils.SetupConsoleLogger()
+
+		cfg, err := config.Init()
+		if err != nil {
+			return fmt.Errorf("failed init configuration: %s", err.Error())
+		}
+
+		dbService, err := setupDbService(cfg.DbConfig())
+		if err != nil {
+			return fmt.Errorf("failed setting up database service: %s", err.Error())
+		}
+
+		return run(dbService, args[0], cmd.OutOrStdout())
+	}
+}
+
+// createUser creates the user and gives it the role of the options, if there is one.
+func (o *Options) createUser(
+	ds interfaces.DataService,
+	name string,
+	out io.Writer,
+) error {
+	if o.Role != "" {
+		if _, err := common.ParseRole(o.Role); err != nil {
+			return err
+		}
+	}
+
+	user, err := ds.CreateUser(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(out, "created user %s with id %s\n", user.Name, user.ID)
+	if err != nil || o.Role == "" {
+		return err
+	}
+
+	return o.setRole(ds, user.Name, out)
+}
+
+// setRole gives the user the role of the options.
+func (o *Options) setRole(
+	ds interfaces.DataService,
+	userName string,
+	out io.Writer,
+) error {
+	role, err := common.ParseRole(o.Role)
+	if err != nil {
+		return err
+	}
+
+	user, err := ds.GetUserByName(userName)
+	if err != nil {
+		return err
+	}
+
+	if _, err = ds.SetMembership(user.ID, role); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(out, "user %s is %s\n", user.Name, role)
+	return err
+}
+
+// createAPIToken creates an API token with the token name of the options
+// for the user and prints it.
+func (o *Options) createAPIToken(
+	ds interfaces.DataService,
+	userName string,
+	out io.Writer,
+) error {
+	user, err := ds.GetUserByName(userName)
+	if err != nil {
+		return err
+	}
+
+	_, token, err := ds.CreateAPIToken(user.ID, o.TokenName)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(out, token)
+	return err
+}
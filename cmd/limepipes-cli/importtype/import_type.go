@@ -13,6 +13,7 @@ import (
 func FileFormatMapping() map[string]fileformat.Format {
 	return map[string]fileformat.Format{
 		FromFileFormat(fileformat.Format_BWW): fileformat.Format_BWW,
+		FromFileFormat(fileformat.Format_ABC): fileformat.Format_ABC,
 	}
 }
 
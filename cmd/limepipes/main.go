@@ -1,12 +1,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
-	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
 	"github.com/tomvodi/limepipes/internal/apigen"
+	"github.com/tomvodi/limepipes/internal/auth"
 	"github.com/tomvodi/limepipes/internal/common"
 	"github.com/tomvodi/limepipes/internal/config"
 	"github.com/tomvodi/limepipes/internal/database"
@@ -14,20 +15,54 @@ import (
 	"github.com/tomvodi/limepipes/internal/interfaces"
 	"github.com/tomvodi/limepipes/internal/utils"
 	"gorm.io/gorm"
-	"strings"
 )
 
-func setupGinEngine() *gin.Engine {
+func setupGinEngine(
+	authenticator *auth.Authenticator,
+	authorizer *auth.Authorizer,
+) *gin.Engine {
 	router := gin.Default()
 	router.Use(cors.New(cors.Config{
 		AllowOrigins: []string{"https://localhost:3000"},
-		AllowMethods: []string{"PUT", "PATCH", "POST", "GET"},
-		AllowHeaders: []string{"Origin", "Content-type"},
+		AllowMethods: []string{"PUT", "PATCH", "POST", "GET", "DELETE"},
+		AllowHeaders: []string{"Origin", "Content-type", "Authorization"},
 	}))
+	router.Use(authenticator.Middleware())
+	router.Use(authorizer.Middleware())
 
 	return router
 }
 
+// setupAuthenticator returns the authenticator for the API tokens and,
+// if they are enabled, the JWT bearer tokens.
+func setupAuthenticator(db *gorm.DB, authConfig config.AuthConfig) *auth.Authenticator {
+	if !authConfig.JwtEnabled() {
+		return initialize.Authenticator(db, nil)
+	}
+
+	jwtVerifier, err := auth.NewJWTVerifier(authConfig)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed initializing JWT verifier")
+	}
+
+	return initialize.Authenticator(db, jwtVerifier)
+}
+
+// setupAuthorizer returns the authorizer which gives users without
+// a membership the configured default role.
+func setupAuthorizer(db *gorm.DB, authConfig config.AuthConfig) *auth.Authorizer {
+	var defaultRole common.Role
+	if authConfig.DefaultRole != "" {
+		var err error
+		defaultRole, err = common.ParseRole(authConfig.DefaultRole)
+		if err != nil {
+			log.Fatal().Err(err).Msg("invalid default role")
+		}
+	}
+
+	return initialize.Authorizer(db, defaultRole)
+}
+
 func main() {
 	utils.SetupConsoleLogger()
 
@@ -36,11 +71,7 @@ func main() {
 		log.Fatal().Err(err).Msg("failed init configuration")
 	}
 
-	// TODO: Load plugins from config
-	LoadPlugins := common.PluginList{
-		strings.ToLower(fileformat.Format_BWW.String()),
-	}
-	var pluginLoader interfaces.PluginLoader = initialize.PluginLoader(LoadPlugins)
+	var pluginLoader interfaces.PluginLoader = initialize.PluginLoader(cfg.PluginIDs())
 
 	err = pluginLoader.LoadPluginsFromDir(cfg.PluginsDirectoryPath)
 	if err != nil {
@@ -54,17 +85,27 @@ func main() {
 	}(pluginLoader)
 
 	var db *gorm.DB
-	db, err = database.GetInitPostgreSQLDB(cfg.DbConfig())
+	db, err = database.GetInitDB(cfg.DbConfig())
 	if err != nil {
 		panic(fmt.Sprintf("failed initializing database: %s", err.Error()))
 	}
 
-	apiHandler, err := initialize.ApiHandler(db, cfg.HealthConfig(), pluginLoader)
+	importWorkers := initialize.ImportWorkerPool(db, pluginLoader, cfg.ImportConfig())
+	err = importWorkers.Start(context.Background())
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed starting import workers")
+	}
+	defer importWorkers.Stop()
+
+	apiHandler, err := initialize.ApiHandler(db, cfg.HealthConfig(), pluginLoader, importWorkers)
 	if err != nil {
 		panic(fmt.Sprintf("failed initializing health check: %s", err.Error()))
 	}
 
-	engine := setupGinEngine()
+	engine := setupGinEngine(
+		setupAuthenticator(db, cfg.AuthConfig()),
+		setupAuthorizer(db, cfg.AuthConfig()),
+	)
 	router := apigen.NewRouterWithGinEngine(
 		engine,
 		apigen.ApiHandleFunctions{
@@ -0,0 +1,117 @@
+// Package abc reads and writes tunes in the ABC notation, which is widely
+// used to share folk and pipe tunes on the web. The nine notes of the chanter
+// are written from G to a with the highland pipes key K:HP and embellishments
+// are written as gracenote groups like {gdc}. When reading, the gracenote
+// groups are mapped back to the embellishments that are played with them.
+package abc
+
+import (
+	"fmt"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/barline"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/length"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/pitch"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/accidental"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/timeline"
+)
+
+const (
+	// FileExtension is the extension of ABC files.
+	FileExtension = ".abc"
+	// unitLength is the default note length L:1/8 of written tunes
+	unitLength = 8
+	// pipesKey is the key of the highland pipes without key signature
+	pipesKey = "HP"
+	// arrangerPrefix marks a composer field with the arranger of the tune
+	arrangerPrefix = "arr. "
+)
+
+// pitchNames are the letters of the notes of the chanter from low G to high A.
+var pitchNames = map[pitch.Pitch]byte{
+	pitch.Pitch_LowG:  'G',
+	pitch.Pitch_LowA:  'A',
+	pitch.Pitch_B:     'B',
+	pitch.Pitch_C:     'c',
+	pitch.Pitch_D:     'd',
+	pitch.Pitch_E:     'e',
+	pitch.Pitch_F:     'f',
+	pitch.Pitch_HighG: 'g',
+	pitch.Pitch_HighA: 'a',
+}
+
+var accidentalNames = map[accidental.Accidental]string{
+	accidental.Accidental_Sharp:   "^",
+	accidental.Accidental_Flat:    "_",
+	accidental.Accidental_Natural: "=",
+}
+
+// sixtyFourths are the lengths of notes in sixty-fourth notes, which is
+// the smallest part of a dotted thirty-second note.
+var sixtyFourths = map[length.Length]int{
+	length.Length_Whole:        64,
+	length.Length_Half:         32,
+	length.Length_Quarter:      16,
+	length.Length_Eighth:       8,
+	length.Length_Sixteenth:    4,
+	length.Length_Thirtysecond: 2,
+}
+
+// endingNames are the labels of the first and second time endings like [1 and [2.
+// Endings that are played on several repeats have the numbers of them like [2,4.
+var endingNames = map[timeline.Type]string{
+	timeline.Type_First:         "1",
+	timeline.Type_Second:        "2",
+	timeline.Type_SecondOf2:     "2",
+	timeline.Type_SecondOf3:     "3",
+	timeline.Type_SecondOf4:     "4",
+	timeline.Type_SecondOf2And4: "2,4",
+	timeline.Type_SecondOf5:     "5",
+	timeline.Type_SecondOf6:     "6",
+	timeline.Type_SecondOf7:     "7",
+	timeline.Type_SecondOf8:     "8",
+	timeline.Type_Singling:      "1",
+	timeline.Type_Doubling:      "2",
+	timeline.Type_Bis:           "1,2",
+}
+
+// barlineDecorations are the decorations of jumps in the music like !D.S.!.
+var barlineDecorations = map[barline.Time]string{
+	barline.Time_Segno:        "segno",
+	barline.Time_Dalsegno:     "D.S.",
+	barline.Time_Fine:         "fine",
+	barline.Time_DacapoAlFine: "D.C.alfine",
+}
+
+// noteLength returns the length and dots of a note that takes the number of
+// sixty-fourth notes or an error if no note has this length.
+func noteLength(total int) (length.Length, uint32, error) {
+	for dots := uint32(0); dots <= 2; dots++ {
+		// a note with dots is 2-1/2^dots times as long as without them
+		factor := int(uint32(1)<<(dots+1)) - 1
+		base := total << dots
+		if base%factor != 0 {
+			continue
+		}
+		if l, ok := lengthFor(base / factor); ok {
+			return l, dots, nil
+		}
+	}
+
+	return length.Length_NoLength, 0, fmt.Errorf("no note is %d/64 long", total)
+}
+
+// lengthFor returns the length of a note without dots that takes
+// the number of sixty-fourth notes.
+func lengthFor(total int) (length.Length, bool) {
+	for l, s := range sixtyFourths {
+		if s == total {
+			return l, true
+		}
+	}
+
+	return length.Length_NoLength, false
+}
+
+// lengthOf returns the length of a note with dots in sixty-fourth notes.
+func lengthOf(l length.Length, dots uint32) int {
+	return sixtyFourths[l] * (int(uint32(1)<<(dots+1)) - 1) >> dots
+}
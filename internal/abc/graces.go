@@ -0,0 +1,53 @@
+package abc
+
+import (
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/pitch"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/embellishment"
+	"github.com/tomvodi/limepipes/internal/playback"
+	"slices"
+)
+
+// graceCandidates are the embellishments that a group of several gracenotes
+// can be. If the gracenotes of more than one of them match on a melody note,
+// the first one is taken.
+var graceCandidates = []*embellishment.Embellishment{
+	{Type: embellishment.Type_Doubling},
+	{Type: embellishment.Type_Doubling, Variant: embellishment.Variant_Half},
+	{Type: embellishment.Type_Doubling, Variant: embellishment.Variant_Thumb},
+	{Type: embellishment.Type_Strike, Variant: embellishment.Variant_G},
+	{Type: embellishment.Type_Strike, Variant: embellishment.Variant_Thumb},
+	{Type: embellishment.Type_Grip},
+	{Type: embellishment.Type_Taorluath},
+	{Type: embellishment.Type_Bubbly},
+	{Type: embellishment.Type_Birl},
+	{Type: embellishment.Type_ABirl},
+	{Type: embellishment.Type_GraceBirl},
+	{Type: embellishment.Type_ThrowD},
+	{Type: embellishment.Type_ThrowD, Weight: embellishment.Weight_Heavy},
+	{Type: embellishment.Type_Pele},
+	{Type: embellishment.Type_Pele, Variant: embellishment.Variant_Half},
+	{Type: embellishment.Type_Pele, Variant: embellishment.Variant_Thumb},
+	{Type: embellishment.Type_DoubleStrike},
+	{Type: embellishment.Type_DoubleStrike, Variant: embellishment.Variant_G},
+	{Type: embellishment.Type_DoubleStrike, Variant: embellishment.Variant_Thumb},
+	{Type: embellishment.Type_TripleStrike},
+	{Type: embellishment.Type_GTripleStrike},
+	{Type: embellishment.Type_ThumbTripleStrike},
+}
+
+// graceEmbellishment returns the embellishment that is played with the
+// gracenotes on the melody note or nil if there is none.
+func graceEmbellishment(graces []pitch.Pitch, melody pitch.Pitch) *embellishment.Embellishment {
+	if len(graces) == 1 {
+		return &embellishment.Embellishment{Type: embellishment.Type_SingleGrace, Pitch: graces[0]}
+	}
+
+	for _, c := range graceCandidates {
+		if slices.Equal(playback.GracePitches(&symbols.Note{Embellishment: c}, melody), graces) {
+			return &embellishment.Embellishment{Type: c.Type, Variant: c.Variant, Weight: c.Weight}
+		}
+	}
+
+	return nil
+}
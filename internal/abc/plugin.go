@@ -0,0 +1,65 @@
+package abc
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/afero"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/messages"
+)
+
+// PluginID is the ID under which the ABC format is registered
+// next to the plugins that are run as own processes.
+const PluginID = "abc"
+
+// Plugin reads and writes ABC files in the process of LimePipes.
+type Plugin struct {
+	afs afero.Fs
+}
+
+func (p *Plugin) PluginInfo() (*messages.PluginInfoResponse, error) {
+	return &messages.PluginInfoResponse{
+		Name:           PluginID,
+		Description:    "Reads and writes tunes in the ABC notation",
+		Type:           messages.PluginType_INOUT,
+		FileFormat:     fileformat.Format_ABC,
+		FileExtensions: []string{FileExtension},
+	}, nil
+}
+
+func (p *Plugin) ParseFromFile(filePath string) ([]*messages.ParsedTune, error) {
+	data, err := afero.ReadFile(p.afs, filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading file %s: %w", filePath, err)
+	}
+
+	return p.Parse(data)
+}
+
+func (p *Plugin) Parse(data []byte) ([]*messages.ParsedTune, error) {
+	return Read(data)
+}
+
+func (p *Plugin) ExportToFile(tunes []*tune.Tune, filePath string) error {
+	data, err := p.Export(tunes)
+	if err != nil {
+		return err
+	}
+
+	return afero.WriteFile(p.afs, filePath, data, 0644)
+}
+
+func (p *Plugin) Export(tunes []*tune.Tune) ([]byte, error) {
+	if len(tunes) == 0 {
+		return nil, errors.New("no tunes to export")
+	}
+
+	return Write(tunes), nil
+}
+
+func NewPlugin(afs afero.Fs) *Plugin {
+	return &Plugin{
+		afs: afs,
+	}
+}
@@ -0,0 +1,31 @@
+package abc
+
+import (
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/helper"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"testing"
+)
+
+func TestPlugin(t *testing.T) {
+	g := NewWithT(t)
+	p := NewPlugin(afero.NewMemMapFs())
+
+	info, err := p.PluginInfo()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(info.FileFormat).To(Equal(fileformat.Format_ABC))
+	g.Expect(info.FileExtensions).To(ConsistOf(".abc"))
+
+	g.Expect(p.ExportToFile([]*tune.Tune{testTune()}, "/tunes/test.abc")).To(Succeed())
+	parsed, err := p.ParseFromFile("/tunes/test.abc")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(parsed).To(HaveLen(1))
+	g.Expect(parsed[0].Tune).To(BeComparableTo(testTune(), helper.MusicModelCompareOptions))
+
+	_, err = p.ParseFromFile("/tunes/missing.abc")
+	g.Expect(err).To(HaveOccurred())
+	_, err = p.Export(nil)
+	g.Expect(err).To(HaveOccurred())
+}
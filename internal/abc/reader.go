@@ -0,0 +1,854 @@
+package abc
+
+import (
+	"errors"
+	"fmt"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/barline"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/boundary"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/measure"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/pitch"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/accidental"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/tie"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/timeline"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/tuplet"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/messages"
+	"strconv"
+	"strings"
+)
+
+const (
+	// noteSteps are the letters of the notes in the lowest octave of ABC,
+	// lower case letters are an octave higher
+	noteSteps = "CDEFGAB"
+	// lowGStep is the step of the low G of the chanter in the ABC octaves
+	lowGStep = 4
+	// ignoredChars are spaces and other characters without meaning for the music
+	ignoredChars = " \t\\`y)"
+	// decorationChars are the short forms of decorations like H for a fermata
+	decorationChars = "~.HLMOPSTuvJR"
+)
+
+// barlineTypes are the types of barlines at the end of measures that
+// are not regular ones.
+var barlineTypes = map[string]barline.Type{
+	"||": barline.Type_HeavyHeavy,
+	"|]": barline.Type_LightHeavy,
+}
+
+// endingTypes are the timelines of the labels of endings.
+var endingTypes = map[string]timeline.Type{
+	"1":   timeline.Type_First,
+	"2":   timeline.Type_Second,
+	"3":   timeline.Type_SecondOf3,
+	"4":   timeline.Type_SecondOf4,
+	"5":   timeline.Type_SecondOf5,
+	"6":   timeline.Type_SecondOf6,
+	"7":   timeline.Type_SecondOf7,
+	"8":   timeline.Type_SecondOf8,
+	"2,4": timeline.Type_SecondOf2And4,
+	"1,2": timeline.Type_Bis,
+}
+
+// tokenReaders read the tokens of the music that start with the character.
+var tokenReaders = map[byte]func(r *reader){
+	'"': (*reader).annotation,
+	'!': (*reader).decoration,
+	'+': (*reader).decoration,
+	'{': (*reader).graceGroup,
+	'(': (*reader).tuplet,
+	'[': (*reader).bracket,
+	'|': (*reader).barline,
+	':': (*reader).barline,
+	'-': (*reader).tie,
+	'>': (*reader).brokenRhythm,
+	'<': (*reader).brokenRhythm,
+	'^': (*reader).note,
+	'_': (*reader).note,
+	'=': (*reader).note,
+	'z': (*reader).rest,
+	'x': (*reader).rest,
+}
+
+// Read returns the tunes of the ABC data. Every tune starts with a reference
+// number field X: and ends with an empty line, text before the first tune is ignored.
+// Music that can't be played on the chanter is reported with parser messages.
+func Read(data []byte) ([]*messages.ParsedTune, error) {
+	var parsed []*messages.ParsedTune
+	for _, text := range splitTunes(string(data)) {
+		parsed = append(parsed, &messages.ParsedTune{
+			Tune:         readTune(text),
+			TuneFileData: []byte(text),
+		})
+	}
+	if len(parsed) == 0 {
+		return nil, errors.New("no tune with a reference number field X: found")
+	}
+
+	return parsed, nil
+}
+
+func splitTunes(data string) []string {
+	var tunes []string
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "X:"):
+			tunes = appendTune(tunes, lines)
+			lines = []string{line}
+		case strings.TrimSpace(line) == "":
+			tunes = appendTune(tunes, lines)
+			lines = nil
+		case lines != nil:
+			lines = append(lines, line)
+		}
+	}
+
+	return appendTune(tunes, lines)
+}
+
+func appendTune(tunes []string, lines []string) []string {
+	if len(lines) == 0 {
+		return tunes
+	}
+
+	return append(tunes, strings.Join(lines, "\n")+"\n")
+}
+
+// reader reads the fields and music of a tune.
+type reader struct {
+	t      *tune.Tune
+	header bool
+	// unit is the length of notes without length in sixty-fourth notes
+	unit int
+	// time is the time signature of the next measure
+	time *measure.TimeSignature
+
+	// m is the measure that is read
+	m *measure.Measure
+	// jump is the time of a decoration like !fine! for the next barline
+	jump barline.Time
+
+	line string
+	pos  int
+
+	// graces are the gracenote groups for the next note
+	graces     [][]pitch.Pitch
+	graceTexts []string
+	fermata    bool
+	tempo      *uint64
+	tieEnd     bool
+
+	// lastNote and lastLength are the last note and its length in sixty-fourth
+	// notes to change it with a broken rhythm. The next note is changed by
+	// the factor in broken.
+	lastNote   *symbols.Note
+	lastLength int
+	broken     [2]int
+
+	// tupletEnd ends the tuplet after tupletNotes notes
+	tupletEnd   *tuplet.Tuplet
+	tupletNotes int
+	ending      *timeline.TimeLine
+}
+
+func readTune(text string) *tune.Tune {
+	r := &reader{t: &tune.Tune{}, header: true, broken: [2]int{1, 1}}
+	for _, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+		r.readLine(line)
+	}
+	r.endMeasure(&barline.Barline{}, nil)
+
+	return r.t
+}
+
+func (r *reader) readLine(line string) {
+	switch {
+	case isField(line):
+		r.field(line[0], strings.TrimSpace(line[2:]))
+	case strings.HasPrefix(line, "%"):
+		r.comment(line)
+	case !r.header:
+		r.body(line)
+	}
+}
+
+// isField returns true for lines with an information field like T:Title.
+func isField(line string) bool {
+	if len(line) < 2 || line[1] != ':' {
+		return false
+	}
+	if len(line) > 2 && line[2] == '|' {
+		return false
+	}
+
+	return 'A' <= line[0] && line[0] <= 'Z' || 'a' <= line[0] && line[0] <= 'z'
+}
+
+// comment keeps the comments of the header as comments of the tune
+// but not stylesheet directives like %%scale.
+func (r *reader) comment(line string) {
+	if r.header && !strings.HasPrefix(line, "%%") {
+		r.t.Comments = append(r.t.Comments, strings.TrimSpace(line[1:]))
+	}
+}
+
+func (r *reader) field(name byte, value string) {
+	switch name {
+	case 'T':
+		if r.t.Title == "" {
+			r.t.Title = value
+		}
+	case 'C':
+		r.composer(value)
+	case 'R':
+		r.t.Type = value
+	case 'N':
+		r.t.Footer = append(r.t.Footer, value)
+	case 'M':
+		r.setMeter(value)
+	case 'L':
+		r.setUnit(value)
+	case 'Q':
+		r.setTempo(value)
+	case 'K':
+		r.startMusic()
+	}
+}
+
+func (r *reader) composer(value string) {
+	if strings.HasPrefix(value, strings.TrimSpace(arrangerPrefix)) {
+		r.t.Arranger = strings.TrimSpace(value[len(arrangerPrefix)-1:])
+		return
+	}
+	if r.t.Composer == "" {
+		r.t.Composer = value
+	}
+}
+
+func (r *reader) setMeter(value string) {
+	switch value {
+	case "C":
+		value = "4/4"
+	case "C|":
+		value = "2/2"
+	}
+
+	beats, beatType, ok := fraction(value)
+	if !ok {
+		return
+	}
+	r.time = &measure.TimeSignature{Beats: uint32(beats), BeatType: uint32(beatType)}
+	if r.m != nil && !hasContent(r.m) {
+		r.m.Time, r.time = r.time, nil
+	}
+}
+
+// setUnit sets the unit note length. Units shorter than a sixty-fourth note
+// or between two sixty-fourths are ignored, so the default unit is used.
+func (r *reader) setUnit(value string) {
+	num, den, ok := fraction(value)
+	if ok && 64*num%den == 0 {
+		r.unit = 64 * num / den
+	}
+}
+
+// setTempo sets the tempo of the tune or the tempo change for the next
+// note from a tempo like 1/4=80 in beats per minute of quarter notes.
+func (r *reader) setTempo(value string) {
+	beat, bpm, found := strings.Cut(value, "=")
+	if !found {
+		beat, bpm = "1/4", value
+	}
+	tempo, err := strconv.Atoi(strings.TrimSpace(bpm))
+	if err != nil || tempo <= 0 {
+		return
+	}
+	if num, den, ok := fraction(beat); ok {
+		tempo = tempo * 4 * num / den
+	}
+
+	if r.header {
+		r.t.Tempo = uint32(tempo)
+		return
+	}
+	change := uint64(tempo)
+	r.tempo = &change
+}
+
+// startMusic ends the header with the default unit length of the meter
+// if the header doesn't set one.
+func (r *reader) startMusic() {
+	r.header = false
+	if r.unit > 0 {
+		return
+	}
+
+	r.unit = unitLength
+	if r.time != nil && float64(r.time.Beats)/float64(r.time.BeatType) < 0.75 {
+		r.unit = unitLength / 2
+	}
+}
+
+// fraction returns the numerator and denominator of a fraction like 6/8.
+func fraction(s string) (int, int, bool) {
+	n, d, found := strings.Cut(strings.TrimSpace(s), "/")
+	if !found {
+		return 0, 0, false
+	}
+	num, err := strconv.Atoi(n)
+	if err != nil || num <= 0 {
+		return 0, 0, false
+	}
+	den, err := strconv.Atoi(d)
+	if err != nil || den <= 0 {
+		return 0, 0, false
+	}
+
+	return num, den, true
+}
+
+func (r *reader) body(line string) {
+	if i := strings.IndexByte(line, '%'); i >= 0 {
+		line = line[:i]
+	}
+
+	r.line, r.pos = line, 0
+	for r.pos < len(r.line) {
+		r.token()
+	}
+}
+
+func (r *reader) token() {
+	c := r.line[r.pos]
+	switch {
+	case strings.IndexByte(ignoredChars, c) >= 0:
+		r.pos++
+	case strings.IndexByte(decorationChars, c) >= 0:
+		r.pos++
+		r.decorate(string(c))
+	case isNoteLetter(c):
+		r.note()
+	case tokenReaders[c] != nil:
+		tokenReaders[c](r)
+	default:
+		r.pos++
+		r.unexpected(string(c))
+	}
+}
+
+// until returns the text after the current character up to the end character
+// and moves behind it.
+func (r *reader) until(end byte) string {
+	start := r.pos + 1
+	i := strings.IndexByte(r.line[start:], end)
+	if i < 0 {
+		r.pos = len(r.line)
+		return r.line[start:]
+	}
+	r.pos = start + i + 1
+
+	return r.line[start : start+i]
+}
+
+func (r *reader) peek() byte {
+	if r.pos >= len(r.line) {
+		return 0
+	}
+
+	return r.line[r.pos]
+}
+
+// readNumber returns the number at the current position or the default
+// if there is none.
+func (r *reader) readNumber(def int) int {
+	start := r.pos
+	for isDigit(r.peek()) {
+		r.pos++
+	}
+	n, err := strconv.Atoi(r.line[start:r.pos])
+	if err != nil {
+		return def
+	}
+
+	return n
+}
+
+// annotation skips chord symbols and texts in quotes.
+func (r *reader) annotation() {
+	r.until('"')
+}
+
+func (r *reader) decoration() {
+	r.decorate(r.until(r.line[r.pos]))
+}
+
+// decorate keeps fermatas for the next note and segnos and jumps like
+// !D.S.! for the barlines, other decorations are ignored.
+func (r *reader) decorate(name string) {
+	switch name {
+	case "fermata", "H":
+		r.fermata = true
+	case "segno", "S":
+		r.segno()
+	default:
+		if t, ok := jumpTime(name); ok {
+			r.jump = t
+		}
+	}
+}
+
+// segno marks the left barline of the measure with a segno
+// unless it starts a repeat.
+func (r *reader) segno() {
+	m := r.current()
+	if m.LeftBarline == nil {
+		m.LeftBarline = &barline.Barline{}
+	}
+	if m.LeftBarline.Time == barline.Time_NoTime {
+		m.LeftBarline.Time = barline.Time_Segno
+	}
+}
+
+// jumpTime returns the time of barlines for decorations like !D.S.!.
+func jumpTime(name string) (barline.Time, bool) {
+	for t, n := range barlineDecorations {
+		if n == name && t != barline.Time_Segno {
+			return t, true
+		}
+	}
+
+	return barline.Time_NoTime, false
+}
+
+func (r *reader) graceGroup() {
+	text := r.until('}')
+	var graces []pitch.Pitch
+	for i := 0; i < len(text); i++ {
+		if !isNoteLetter(text[i]) {
+			continue
+		}
+		p, n := notePitch(text[i:])
+		if p == pitch.Pitch_NoPitch {
+			r.fail("{"+text+"}", "gracenote is not on the chanter")
+			return
+		}
+		graces = append(graces, p)
+		i += n - 1
+	}
+
+	r.graces = append(r.graces, graces)
+	r.graceTexts = append(r.graceTexts, "{"+text+"}")
+}
+
+// tuplet starts a tuplet like (3 or (p:q:r, other brackets are slurs.
+func (r *reader) tuplet() {
+	start := r.pos
+	r.pos++
+	if !isDigit(r.peek()) {
+		return
+	}
+
+	visible := r.readNumber(0)
+	played, notes := defaultTupletPlayed(uint32(visible)), visible
+	if r.peek() == ':' {
+		r.pos++
+		played = uint32(r.readNumber(int(played)))
+		if r.peek() == ':' {
+			r.pos++
+			notes = r.readNumber(visible)
+		}
+	}
+	if played == 0 {
+		r.fail(r.line[start:r.pos], "tuplet needs the number of notes in whose time it is played")
+		return
+	}
+
+	r.add(&symbols.Symbol{Tuplet: &tuplet.Tuplet{
+		BoundaryType: boundary.Boundary_Start,
+		VisibleNotes: uint32(visible),
+		PlayedNotes:  played,
+	}})
+	r.tupletEnd = &tuplet.Tuplet{BoundaryType: boundary.Boundary_End, VisibleNotes: uint32(visible), PlayedNotes: played}
+	r.tupletNotes = notes
+}
+
+func (r *reader) countTupletNote() {
+	if r.tupletEnd == nil {
+		return
+	}
+
+	r.tupletNotes--
+	if r.tupletNotes <= 0 {
+		r.add(&symbols.Symbol{Tuplet: r.tupletEnd})
+		r.tupletEnd = nil
+	}
+}
+
+// bracket reads inline fields like [M:6/8], endings like [2 and the
+// barline [|. Chords can't be played on the chanter.
+func (r *reader) bracket() {
+	next := r.line[r.pos+1:]
+	switch {
+	case isField(next):
+		text := r.until(']')
+		r.field(text[0], strings.TrimSpace(text[2:]))
+	case strings.HasPrefix(next, "|"):
+		r.barline()
+	case len(next) > 0 && isDigit(next[0]):
+		r.pos++
+		r.startEnding()
+	default:
+		r.fail("["+r.until(']')+"]", "chords can't be played on the chanter")
+		r.skipLength()
+	}
+}
+
+func (r *reader) skipLength() {
+	for isDigit(r.peek()) || r.peek() == '/' {
+		r.pos++
+	}
+}
+
+// barline ends the measure with a barline like |, :| or |] and starts an
+// ending if it is followed by the number of one like |1.
+func (r *reader) barline() {
+	start := r.pos
+	for r.pos < len(r.line) && r.isBarlineChar() {
+		r.pos++
+	}
+
+	right, left := barlines(r.line[start:r.pos])
+	if right.Time == barline.Time_NoTime {
+		right.Time = r.jump
+	}
+	r.endMeasure(right, left)
+	if isDigit(r.peek()) {
+		r.startEnding()
+	}
+}
+
+// barlines returns the right barline of the measure that ends with the
+// barline token and the left barline of the next measure, which is nil
+// if it's a regular one.
+func barlines(token string) (*barline.Barline, *barline.Barline) {
+	core := strings.Trim(token, ":")
+	right := &barline.Barline{Type: barlineTypes[core]}
+	if strings.HasPrefix(token, ":") {
+		right.Time = barline.Time_Repeat
+	}
+
+	left := &barline.Barline{}
+	if core == "[|" {
+		left.Type = barline.Type_HeavyLight
+	}
+	if strings.HasSuffix(token, ":") {
+		left.Time = barline.Time_Repeat
+	}
+	if left.Type == barline.Type_Regular && left.Time == barline.Time_NoTime {
+		return right, nil
+	}
+
+	return right, left
+}
+
+func (r *reader) isBarlineChar() bool {
+	switch r.line[r.pos] {
+	case '|', ':', ']':
+		return true
+	case '[':
+		return r.pos+1 < len(r.line) && r.line[r.pos+1] == '|'
+	}
+
+	return false
+}
+
+// endMeasure adds the measure with the right barline to the tune and keeps
+// the left barline for the next measure. Endings end on barlines of parts.
+func (r *reader) endMeasure(right *barline.Barline, left *barline.Barline) {
+	if r.ending != nil && (left != nil || isPartBarline(right)) {
+		r.endEnding()
+	}
+	r.jump = barline.Time_NoTime
+
+	if r.m != nil && hasContent(r.m) {
+		r.m.RightBarline = right
+		r.t.Measures = append(r.t.Measures, r.m)
+		r.m = nil
+	}
+	if left != nil {
+		r.current().LeftBarline = left
+	}
+}
+
+func hasContent(m *measure.Measure) bool {
+	return len(m.Symbols) > 0 || len(m.ParserMessages) > 0
+}
+
+// current returns the measure that is read and starts a new one if needed.
+func (r *reader) current() *measure.Measure {
+	if r.m == nil {
+		r.m = &measure.Measure{Time: r.time}
+		r.time = nil
+	}
+
+	return r.m
+}
+
+func (r *reader) add(s *symbols.Symbol) {
+	m := r.current()
+	m.Symbols = append(m.Symbols, s)
+}
+
+func (r *reader) startEnding() {
+	start := r.pos
+	for isDigit(r.peek()) || r.peek() == ',' {
+		r.pos++
+	}
+	label := r.line[start:r.pos]
+
+	if r.ending != nil {
+		r.endEnding()
+	}
+	t, ok := endingTypes[label]
+	if !ok {
+		r.warn(label, "ending is not supported")
+		return
+	}
+	r.add(&symbols.Symbol{Timeline: &timeline.TimeLine{Type: t, BoundaryType: boundary.Boundary_Start}})
+	r.ending = &timeline.TimeLine{Type: t, BoundaryType: boundary.Boundary_End}
+}
+
+// endEnding ends the ending in the measure that is read or if it's empty
+// in the last measure of the tune.
+func (r *reader) endEnding() {
+	m := r.m
+	if m == nil || !hasContent(m) {
+		if len(r.t.Measures) == 0 {
+			return
+		}
+		m = r.t.Measures[len(r.t.Measures)-1]
+	}
+
+	m.Symbols = append(m.Symbols, &symbols.Symbol{Timeline: r.ending})
+	r.ending = nil
+}
+
+func (r *reader) tie() {
+	r.pos++
+	if r.lastNote == nil {
+		r.unexpected("-")
+		return
+	}
+
+	r.lastNote.Tie = tie.Tie_Start
+	r.tieEnd = true
+}
+
+// brokenRhythm makes the last note longer and the next one shorter for >
+// and the other way round for <. Every further > halves the shorter note.
+func (r *reader) brokenRhythm() {
+	c := r.line[r.pos]
+	start := r.pos
+	for r.peek() == c {
+		r.pos++
+	}
+	if r.lastNote == nil {
+		r.unexpected(r.line[start:r.pos])
+		return
+	}
+
+	half := 1 << (r.pos - start)
+	longer, shorter := [2]int{2*half - 1, half}, [2]int{1, half}
+	if c == '<' {
+		longer, shorter = shorter, longer
+	}
+
+	l, dots, err := noteLength(r.lastLength * longer[0] / longer[1])
+	if err != nil {
+		r.fail(r.line[start:r.pos], err.Error())
+		return
+	}
+	r.lastNote.Length, r.lastNote.Dots = l, dots
+	r.broken = shorter
+}
+
+// readLength returns the length of the note or rest at the current position
+// in sixty-fourth notes.
+func (r *reader) readLength() (int, error) {
+	num, den := r.readNumber(1), 1
+	for r.peek() == '/' {
+		r.pos++
+		den *= r.readNumber(2)
+	}
+	if den == 0 {
+		return 0, errors.New("note length divides by zero")
+	}
+
+	num *= r.unit * r.broken[0]
+	den *= r.broken[1]
+	r.broken = [2]int{1, 1}
+	if num%den != 0 {
+		return 0, fmt.Errorf("no note is %d/%d of a sixty-fourth note long", num, den)
+	}
+
+	return num / den, nil
+}
+
+func (r *reader) note() {
+	start := r.pos
+	acc := r.readAccidental()
+	if !isNoteLetter(r.peek()) {
+		r.unexpected(r.line[start:r.pos])
+		return
+	}
+	p, n := notePitch(r.line[r.pos:])
+	r.pos += n
+	total, err := r.readLength()
+
+	text := r.line[start:r.pos]
+	switch {
+	case p == pitch.Pitch_NoPitch:
+		r.fail(text, "note is not on the chanter")
+	case err != nil:
+		r.fail(text, err.Error())
+	default:
+		r.addNote(&symbols.Note{Pitch: p, Accidental: acc, Fermata: r.fermata}, total, text)
+	}
+}
+
+func (r *reader) readAccidental() accidental.Accidental {
+	for a, name := range accidentalNames {
+		if strings.HasPrefix(r.line[r.pos:], name) {
+			r.pos += len(name)
+			return a
+		}
+	}
+
+	return accidental.Accidental_NoAccidental
+}
+
+func (r *reader) addNote(n *symbols.Note, total int, text string) {
+	l, dots, err := noteLength(total)
+	if err != nil {
+		r.fail(text, err.Error())
+		return
+	}
+	n.Length, n.Dots = l, dots
+	if r.tieEnd {
+		n.Tie = tie.Tie_End
+		r.tieEnd = false
+	}
+	r.addGraces(n)
+
+	r.add(&symbols.Symbol{Note: n, TempoChange: r.tempo})
+	r.tempo = nil
+	r.fermata = false
+	r.lastNote, r.lastLength = n, total
+	r.countTupletNote()
+}
+
+// addGraces adds the embellishments of the gracenote groups before the note.
+// The last one is the embellishment of the note and the ones before it are
+// added as symbols without pitch.
+func (r *reader) addGraces(n *symbols.Note) {
+	for i, g := range r.graces {
+		e := graceEmbellishment(g, n.Pitch)
+		switch {
+		case e == nil:
+			r.warn(r.graceTexts[i], "gracenotes are no known embellishment")
+		case i == len(r.graces)-1:
+			n.Embellishment = e
+		default:
+			r.add(&symbols.Symbol{Note: &symbols.Note{Embellishment: e}})
+		}
+	}
+
+	r.graces, r.graceTexts = nil, nil
+}
+
+func (r *reader) rest() {
+	start := r.pos
+	r.pos++
+	total, err := r.readLength()
+	if err != nil {
+		r.fail(r.line[start:r.pos], err.Error())
+		return
+	}
+	l, dots, err := noteLength(total)
+	if err != nil || dots > 0 {
+		r.fail(r.line[start:r.pos], "rest has no supported length")
+		return
+	}
+
+	r.add(&symbols.Symbol{Rest: &symbols.Rest{Length: l}, TempoChange: r.tempo})
+	r.tempo = nil
+	r.lastNote = nil
+	r.countTupletNote()
+}
+
+// notePitch returns the pitch of the note with octave marks at the start of
+// the text and the number of characters of it. Notes that are not on the
+// chanter have no pitch.
+func notePitch(text string) (pitch.Pitch, int) {
+	step := strings.IndexByte(noteSteps, strings.ToUpper(text[:1])[0])
+	if text[0] >= 'a' {
+		step += len(noteSteps)
+	}
+
+	n := 1
+	for ; n < len(text); n++ {
+		switch text[n] {
+		case '\'':
+			step += len(noteSteps)
+		case ',':
+			step -= len(noteSteps)
+		default:
+			return stepPitch(step), n
+		}
+	}
+
+	return stepPitch(step), n
+}
+
+func stepPitch(step int) pitch.Pitch {
+	p := pitch.Pitch(step - lowGStep + 1)
+	if p < pitch.Pitch_LowG || p > pitch.Pitch_HighA {
+		return pitch.Pitch_NoPitch
+	}
+
+	return p
+}
+
+func isNoteLetter(c byte) bool {
+	return 'A' <= c && c <= 'G' || 'a' <= c && c <= 'g'
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func (r *reader) unexpected(text string) {
+	r.fail(text, "unexpected symbol")
+}
+
+// fail reports a symbol that can't be read and is left out.
+func (r *reader) fail(text string, reason string) {
+	r.current().AddMessage(&measure.ParserMessage{
+		Symbol:   text,
+		Severity: measure.Severity_Error,
+		Text:     reason,
+		Fix:      measure.Fix_SkipSymbol,
+	})
+}
+
+// warn reports a symbol that is left out without changing the music much.
+func (r *reader) warn(text string, reason string) {
+	r.current().AddMessage(&measure.ParserMessage{
+		Symbol:   text,
+		Severity: measure.Severity_Warning,
+		Text:     reason,
+		Fix:      measure.Fix_SkipSymbol,
+	})
+}
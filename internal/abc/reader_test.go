@@ -0,0 +1,146 @@
+package abc
+
+import (
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/barline"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/helper"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/length"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/measure"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/pitch"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/embellishment"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"testing"
+)
+
+const brokenRhythmTune = `%abc-2.1
+Text before the first tune
+
+X:7
+T:Broken Rhythm
+T:Subtitle
+C:arr. Pipe Major
+M:C|
+K:HP
+"intro"{g}A>B c<d | {Gdc}d2 HB2 z4 |]
+`
+
+func TestRead(t *testing.T) {
+	g := NewWithT(t)
+	dotted := note(pitch.Pitch_LowA, length.Length_Eighth)
+	dotted.Dots = 1
+	lateDotted := note(pitch.Pitch_D, length.Length_Eighth)
+	lateDotted.Dots = 1
+	fermata := note(pitch.Pitch_B, length.Length_Quarter)
+	fermata.Fermata = true
+
+	parsed, err := Read([]byte(brokenRhythmTune))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(parsed).To(HaveLen(1))
+	g.Expect(parsed[0].Tune).To(BeComparableTo(&tune.Tune{
+		Title:    "Broken Rhythm",
+		Arranger: "Pipe Major",
+		Measures: []*measure.Measure{
+			{
+				Time: &measure.TimeSignature{Beats: 2, BeatType: 2},
+				Symbols: notes(
+					graced(dotted, single(pitch.Pitch_HighG)),
+					note(pitch.Pitch_B, length.Length_Sixteenth),
+					note(pitch.Pitch_C, length.Length_Sixteenth),
+					lateDotted,
+				),
+				RightBarline: &barline.Barline{},
+			},
+			{
+				Symbols: append(
+					notes(
+						graced(note(pitch.Pitch_D, length.Length_Quarter), &embellishment.Embellishment{Type: embellishment.Type_ThrowD}),
+						fermata,
+					),
+					&symbols.Symbol{Rest: &symbols.Rest{Length: length.Length_Half}},
+				),
+				RightBarline: &barline.Barline{Type: barline.Type_LightHeavy},
+			},
+		},
+	}, helper.MusicModelCompareOptions))
+	g.Expect(string(parsed[0].TuneFileData)).To(HavePrefix("X:7\n"))
+}
+
+func TestReadDefaultUnitLength(t *testing.T) {
+	g := NewWithT(t)
+
+	parsed, err := Read([]byte("X:1\nT:Jig\nM:2/4\nK:HP\nA2B2 c4|\n"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(parsed[0].Tune.Measures[0].Symbols).To(BeComparableTo(notes(
+		note(pitch.Pitch_LowA, length.Length_Eighth),
+		note(pitch.Pitch_B, length.Length_Eighth),
+		note(pitch.Pitch_C, length.Length_Quarter),
+	), helper.MusicModelCompareOptions))
+}
+
+func TestReadMessages(t *testing.T) {
+	g := NewWithT(t)
+
+	parsed, err := Read([]byte("X:1\nT:Not for Pipes\nK:HP\n{ceg}e2 C2 [ceg]2 b2 A&B|\n"))
+	g.Expect(err).NotTo(HaveOccurred())
+	m := parsed[0].Tune.Measures[0]
+	g.Expect(m.Symbols).To(BeComparableTo(notes(
+		note(pitch.Pitch_E, length.Length_Quarter),
+		note(pitch.Pitch_LowA, length.Length_Eighth),
+		note(pitch.Pitch_B, length.Length_Eighth),
+	), helper.MusicModelCompareOptions))
+	g.Expect(m.ParserMessages).To(BeComparableTo([]*measure.ParserMessage{
+		{Symbol: "{ceg}", Severity: measure.Severity_Warning, Text: "gracenotes are no known embellishment", Fix: measure.Fix_SkipSymbol},
+		{Symbol: "C2", Severity: measure.Severity_Error, Text: "note is not on the chanter", Fix: measure.Fix_SkipSymbol},
+		{Symbol: "[ceg]", Severity: measure.Severity_Error, Text: "chords can't be played on the chanter", Fix: measure.Fix_SkipSymbol},
+		{Symbol: "b2", Severity: measure.Severity_Error, Text: "note is not on the chanter", Fix: measure.Fix_SkipSymbol},
+		{Symbol: "&", Severity: measure.Severity_Error, Text: "unexpected symbol", Fix: measure.Fix_SkipSymbol},
+	}, helper.MusicModelCompareOptions))
+}
+
+func TestReadWithoutTunes(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := Read([]byte("T:No Reference Number\nK:HP\nABc|\n"))
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestGraceEmbellishment(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(graceEmbellishment([]pitch.Pitch{pitch.Pitch_D}, pitch.Pitch_LowA)).
+		To(BeComparableTo(single(pitch.Pitch_D), helper.MusicModelCompareOptions))
+	g.Expect(graceEmbellishment([]pitch.Pitch{pitch.Pitch_HighA, pitch.Pitch_E, pitch.Pitch_F}, pitch.Pitch_E)).
+		To(BeComparableTo(&embellishment.Embellishment{
+			Type:    embellishment.Type_Doubling,
+			Variant: embellishment.Variant_Thumb,
+		}, helper.MusicModelCompareOptions))
+	g.Expect(graceEmbellishment([]pitch.Pitch{pitch.Pitch_LowG, pitch.Pitch_LowA, pitch.Pitch_LowG}, pitch.Pitch_LowA)).
+		To(BeComparableTo(&embellishment.Embellishment{Type: embellishment.Type_Birl}, helper.MusicModelCompareOptions))
+	g.Expect(graceEmbellishment([]pitch.Pitch{pitch.Pitch_C, pitch.Pitch_E}, pitch.Pitch_LowA)).To(BeNil())
+}
+
+func TestReadZeroDenominator(t *testing.T) {
+	g := NewWithT(t)
+
+	parsed, err := Read([]byte("X:1\nK:HP\nA/0 B|\n"))
+	g.Expect(err).NotTo(HaveOccurred())
+	m := parsed[0].Tune.Measures[0]
+	g.Expect(m.Symbols).To(BeComparableTo(notes(
+		note(pitch.Pitch_B, length.Length_Eighth),
+	), helper.MusicModelCompareOptions))
+	g.Expect(m.ParserMessages).To(BeComparableTo([]*measure.ParserMessage{
+		{Symbol: "A/0", Severity: measure.Severity_Error, Text: "note length divides by zero", Fix: measure.Fix_SkipSymbol},
+	}, helper.MusicModelCompareOptions))
+}
+
+func TestReadTooShortUnitLength(t *testing.T) {
+	g := NewWithT(t)
+
+	parsed, err := Read([]byte("X:1\nL:1/128\nK:HP\nAB c2|\n"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(parsed[0].Tune.Measures[0].Symbols).To(BeComparableTo(notes(
+		note(pitch.Pitch_LowA, length.Length_Eighth),
+		note(pitch.Pitch_B, length.Length_Eighth),
+		note(pitch.Pitch_C, length.Length_Quarter),
+	), helper.MusicModelCompareOptions))
+}
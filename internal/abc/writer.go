@@ -0,0 +1,376 @@
+package abc
+
+import (
+	"fmt"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/barline"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/boundary"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/length"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/measure"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/pitch"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/accidental"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/tie"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/tuplet"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"github.com/tomvodi/limepipes/internal/playback"
+)
+
+// measuresPerLine is the number of measures on a line of music
+// if no part ends before.
+const measuresPerLine = 4
+
+// barlineTexts are the barlines of the barline types.
+var barlineTexts = map[barline.Type]string{
+	barline.Type_Regular:    "|",
+	barline.Type_Heavy:      "|]",
+	barline.Type_HeavyHeavy: "||",
+	barline.Type_LightHeavy: "|]",
+	barline.Type_HeavyLight: "[|",
+}
+
+// Write returns the tunes as ABC file with a reference number for every tune.
+func Write(tunes []*tune.Tune) []byte {
+	var b []byte
+	for i, t := range tunes {
+		if i > 0 {
+			b = append(b, '\n')
+		}
+		b = append(b, writeTune(t, i+1)...)
+	}
+
+	return b
+}
+
+// writer writes the music of a tune. Notes of the same beat that are
+// shorter than a quarter note are written without space to beam them.
+type writer struct {
+	b []byte
+	// lineStart is true at the start of a line or after a barline
+	lineStart bool
+
+	// beat is the length of a beat in sixty-fourth notes and
+	// pos the position in the current measure
+	beat float64
+	pos  float64
+
+	tupletFactor float64
+	// tupletBeam is the beam of the notes of the current tuplet or -1
+	tupletBeam int
+	// lastBeam is the beam of the last written note or -1 if it's not beamed
+	lastBeam int
+
+	ornaments  []*symbols.Note
+	accidental accidental.Accidental
+}
+
+func writeTune(t *tune.Tune, number int) []byte {
+	w := &writer{beat: 16, tupletFactor: 1, tupletBeam: -1, lastBeam: -1}
+	w.header(t, number)
+
+	lineMeasures := 0
+	w.lineStart = true
+	for i, m := range t.Measures {
+		var next *measure.Measure
+		if i+1 < len(t.Measures) {
+			next = t.Measures[i+1]
+		}
+		w.measure(m, i == 0)
+		w.barline(m.RightBarline, next)
+
+		lineMeasures++
+		if next == nil || lineMeasures == measuresPerLine || isPartBarline(m.RightBarline) {
+			w.b = append(w.b, '\n')
+			lineMeasures = 0
+		}
+	}
+
+	return w.b
+}
+
+// header writes the information fields of the tune, the key field
+// comes last and starts the music.
+func (w *writer) header(t *tune.Tune, number int) {
+	w.field('X', fmt.Sprint(number))
+	w.field('T', t.Title)
+	w.field('R', t.Type)
+	w.field('C', t.Composer)
+	if t.Arranger != "" {
+		w.field('C', arrangerPrefix+t.Arranger)
+	}
+	for _, c := range t.Comments {
+		w.b = append(w.b, "% "+c+"\n"...)
+	}
+	for _, f := range t.Footer {
+		w.field('N', f)
+	}
+
+	if ts := t.FirstTimeSignature(); ts != nil {
+		w.field('M', ts.DisplayString())
+		w.setTime(ts)
+	}
+	w.field('L', fmt.Sprintf("1/%d", unitLength))
+	if t.Tempo > 0 {
+		w.field('Q', fmt.Sprintf("1/4=%d", t.Tempo))
+	}
+	w.field('K', pipesKey)
+}
+
+func (w *writer) field(name byte, value string) {
+	if value == "" {
+		return
+	}
+	w.b = append(w.b, name, ':')
+	w.b = append(w.b, value...)
+	w.b = append(w.b, '\n')
+}
+
+func (w *writer) measure(m *measure.Measure, first bool) {
+	if m.Time != nil && m.Time.BeatType > 0 && !first {
+		w.write(fmt.Sprintf("[M:%s]", m.Time.DisplayString()))
+		w.setTime(m.Time)
+	}
+	if first && m.LeftBarline != nil {
+		w.b = append(w.b, leftBarlineText(m.LeftBarline)...)
+	}
+	if m.LeftBarline != nil && m.LeftBarline.Time == barline.Time_Segno {
+		w.write("!segno!")
+	}
+
+	w.pos = 0
+	for _, s := range m.Symbols {
+		w.symbol(s)
+	}
+}
+
+// barline writes the barline at the end of a measure together with the
+// left barline of the next measure, like :: for the end and start of repeats.
+func (w *writer) barline(right *barline.Barline, next *measure.Measure) {
+	if right == nil {
+		right = &barline.Barline{}
+	}
+	if text, ok := barlineDecorations[right.Time]; ok && right.Time != barline.Time_Segno {
+		w.write("!" + text + "!")
+	}
+
+	left := &barline.Barline{}
+	if next != nil && next.LeftBarline != nil {
+		left = next.LeftBarline
+	}
+	w.b = append(w.b, barlineText(right, left)...)
+	w.lineStart = true
+	w.lastBeam = -1
+}
+
+// barlineText returns the barline between two measures. Barlines at
+// the start of a repeat or a part replace the regular barline before them.
+func barlineText(right *barline.Barline, left *barline.Barline) string {
+	repeatEnd := right.Time == barline.Time_Repeat
+	switch {
+	case repeatEnd && left.Time == barline.Time_Repeat:
+		return "::"
+	case repeatEnd:
+		return ":|"
+	case leftBarlineText(left) != "":
+		return leftBarlineText(left)
+	}
+
+	return barlineTexts[right.Type]
+}
+
+func leftBarlineText(b *barline.Barline) string {
+	if b.Time == barline.Time_Repeat {
+		return "|:"
+	}
+	if b.Type == barline.Type_Regular {
+		return ""
+	}
+
+	return barlineTexts[b.Type]
+}
+
+// write writes the text separated by a space from the text before it.
+func (w *writer) write(text string) {
+	if !w.lineStart {
+		w.b = append(w.b, ' ')
+	}
+	w.b = append(w.b, text...)
+	w.lineStart = false
+}
+
+func (w *writer) symbol(s *symbols.Symbol) {
+	if s.Tuplet != nil {
+		w.setTuplet(s.Tuplet)
+	}
+	if s.Timeline != nil && s.Timeline.BoundaryType == boundary.Boundary_Start && endingNames[s.Timeline.Type] != "" {
+		w.write("[" + endingNames[s.Timeline.Type])
+		w.lastBeam = -1
+	}
+	if s.TempoChange != nil {
+		w.write(fmt.Sprintf("[Q:1/4=%d]", *s.TempoChange))
+	}
+
+	switch {
+	case s.Note.IsValid():
+		w.note(s.Note)
+	case s.Note != nil:
+		w.ornament(s.Note)
+	case s.Rest != nil && s.Rest.Length != length.Length_NoLength:
+		w.write("z" + lengthText(lengthOf(s.Rest.Length, 0)))
+		w.advance(s.Rest.Length, 0)
+		w.lastBeam = -1
+	}
+}
+
+func (w *writer) setTuplet(t *tuplet.Tuplet) {
+	w.tupletFactor = 1
+	w.tupletBeam = -1
+	if t.BoundaryType != boundary.Boundary_Start || t.VisibleNotes == 0 || t.PlayedNotes == 0 {
+		return
+	}
+
+	w.tupletFactor = float64(t.PlayedNotes) / float64(t.VisibleNotes)
+	w.tupletBeam = w.beamAt()
+	text := fmt.Sprintf("(%d:%d", t.VisibleNotes, t.PlayedNotes)
+	if t.PlayedNotes == defaultTupletPlayed(t.VisibleNotes) {
+		text = fmt.Sprintf("(%d", t.VisibleNotes)
+	}
+	w.write(text)
+	w.lineStart = true
+}
+
+// ornament keeps the embellishments and accidentals of symbols without pitch
+// for the next melody note and ties the last note to the next one.
+func (w *writer) ornament(n *symbols.Note) {
+	if n.Embellishment != nil || n.Movement != nil {
+		w.ornaments = append(w.ornaments, n)
+	}
+	if n.IsOnlyAccidental() {
+		w.accidental = n.Accidental
+	}
+	if n.Tie == tie.Tie_Start {
+		w.b = append(w.b, '-')
+	}
+}
+
+func (w *writer) note(n *symbols.Note) {
+	text := graceGroups(append(w.ornaments, n), n.Pitch)
+	if n.Fermata {
+		text = append(text, "!fermata!"...)
+	}
+	acc := n.Accidental
+	if acc == accidental.Accidental_NoAccidental {
+		acc = w.accidental
+	}
+	text = append(text, accidentalNames[acc]...)
+	text = append(text, pitchNames[n.Pitch])
+	text = append(text, lengthText(lengthOf(n.Length, n.Dots))...)
+	if n.Tie == tie.Tie_Start {
+		text = append(text, '-')
+	}
+	w.ornaments = nil
+	w.accidental = accidental.Accidental_NoAccidental
+
+	w.writeNote(string(text), n)
+}
+
+// graceGroups returns a group of gracenotes for every ornament
+// that is played on the melody note.
+func graceGroups(ornaments []*symbols.Note, melody pitch.Pitch) []byte {
+	var text []byte
+	for _, o := range ornaments {
+		graces := playback.GracePitches(o, melody)
+		if len(graces) == 0 {
+			continue
+		}
+		text = append(text, '{')
+		for _, g := range graces {
+			text = append(text, pitchNames[g])
+		}
+		text = append(text, '}')
+	}
+
+	return text
+}
+
+// writeNote writes the note directly after the last note if both are
+// beamed together.
+func (w *writer) writeNote(text string, n *symbols.Note) {
+	beam := -1
+	if sixtyFourths[n.Length] <= sixtyFourths[length.Length_Eighth] {
+		beam = w.beamAt()
+	}
+	if beam >= 0 && beam == w.lastBeam {
+		w.b = append(w.b, text...)
+	} else {
+		w.write(text)
+	}
+	w.lastBeam = beam
+	w.advance(n.Length, n.Dots)
+}
+
+func (w *writer) advance(l length.Length, dots uint32) {
+	w.pos += float64(lengthOf(l, dots)) * w.tupletFactor
+}
+
+// beamAt returns the beat at the current position, all notes
+// of a tuplet are beamed together.
+func (w *writer) beamAt() int {
+	if w.tupletBeam >= 0 {
+		return w.tupletBeam
+	}
+
+	// a little tolerance for the rounding of tuplets
+	return int(w.pos/w.beat + 1e-6)
+}
+
+// setTime sets the length of a beat for the time signature, which is
+// three eighth notes for compound time signatures like 6/8.
+func (w *writer) setTime(ts *measure.TimeSignature) {
+	if ts.BeatType == 0 {
+		return
+	}
+	w.beat = 64 / float64(ts.BeatType)
+	if ts.BeatType >= 8 && ts.Beats%3 == 0 {
+		w.beat *= 3
+	}
+}
+
+// lengthText returns the length of a note in sixty-fourth notes as
+// multiple of the unit length like 3/2 for a dotted eighth note.
+func lengthText(total int) string {
+	num, den := total, 64/unitLength
+	for _, f := range []int{2, 2, 2, 2, 2, 2} {
+		if num%f == 0 && den%f == 0 {
+			num, den = num/f, den/f
+		}
+	}
+
+	switch {
+	case den == 1 && num == 1:
+		return ""
+	case den == 1:
+		return fmt.Sprint(num)
+	case num == 1:
+		return "/" + fmt.Sprint(den)
+	default:
+		return fmt.Sprintf("%d/%d", num, den)
+	}
+}
+
+// isPartBarline returns true for barlines that end or start a part.
+func isPartBarline(b *barline.Barline) bool {
+	return b != nil && (b.Type != barline.Type_Regular || b.Time == barline.Time_Repeat)
+}
+
+// defaultTupletPlayed returns the number of notes in whose time the notes
+// of a tuplet are played, if it is not written like in (3 for a triplet.
+func defaultTupletPlayed(visible uint32) uint32 {
+	switch visible {
+	case 2, 4:
+		return 3
+	case 3, 6:
+		return 2
+	}
+
+	return 0
+}
@@ -0,0 +1,195 @@
+package abc
+
+import (
+	"flag"
+	"fmt"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/barline"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/boundary"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/helper"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/length"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/measure"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/pitch"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/accidental"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/embellishment"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/tie"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/timeline"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/tuplet"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+func note(p pitch.Pitch, l length.Length) *symbols.Note {
+	return &symbols.Note{Pitch: p, Length: l}
+}
+
+func graced(n *symbols.Note, e *embellishment.Embellishment) *symbols.Note {
+	n.Embellishment = e
+	return n
+}
+
+func single(p pitch.Pitch) *embellishment.Embellishment {
+	return &embellishment.Embellishment{Type: embellishment.Type_SingleGrace, Pitch: p}
+}
+
+func notes(nn ...*symbols.Note) []*symbols.Symbol {
+	syms := make([]*symbols.Symbol, len(nn))
+	for i, n := range nn {
+		syms[i] = &symbols.Symbol{Note: n}
+	}
+	return syms
+}
+
+func timelineSymbol(t timeline.Type, b boundary.Boundary) *symbols.Symbol {
+	return &symbols.Symbol{Timeline: &timeline.TimeLine{Type: t, BoundaryType: b}}
+}
+
+func tupletSymbol(b boundary.Boundary) *symbols.Symbol {
+	return &symbols.Symbol{Tuplet: &tuplet.Tuplet{BoundaryType: b, VisibleNotes: 3, PlayedNotes: 2}}
+}
+
+// testTune returns a tune with the symbols that can be written in ABC
+// and are read back the same way.
+func testTune() *tune.Tune {
+	dotted := note(pitch.Pitch_D, length.Length_Eighth)
+	dotted.Dots = 1
+	tieStart := note(pitch.Pitch_D, length.Length_Quarter)
+	tieStart.Tie = tie.Tie_Start
+	tieEnd := note(pitch.Pitch_D, length.Length_Half)
+	tieEnd.Tie = tie.Tie_End
+	fermata := note(pitch.Pitch_F, length.Length_Quarter)
+	fermata.Fermata = true
+	sharp := note(pitch.Pitch_C, length.Length_Eighth)
+	sharp.Accidental = accidental.Accidental_Sharp
+	throw := note(pitch.Pitch_D, length.Length_Quarter)
+	throw.Dots = 1
+	tempo := uint64(90)
+
+	triplet := []*symbols.Symbol{tupletSymbol(boundary.Boundary_Start)}
+	triplet = append(triplet, notes(
+		note(pitch.Pitch_HighG, length.Length_Eighth),
+		note(pitch.Pitch_HighA, length.Length_Eighth),
+		note(pitch.Pitch_HighG, length.Length_Eighth),
+	)...)
+	triplet = append(triplet, tupletSymbol(boundary.Boundary_End))
+	triplet = append(triplet, notes(
+		graced(note(pitch.Pitch_E, length.Length_Quarter), &embellishment.Embellishment{Type: embellishment.Type_Grip}),
+		note(pitch.Pitch_D, length.Length_Half),
+	)...)
+
+	first := []*symbols.Symbol{timelineSymbol(timeline.Type_First, boundary.Boundary_Start)}
+	first = append(first, notes(note(pitch.Pitch_E, length.Length_Half), fermata)...)
+	first = append(first,
+		&symbols.Symbol{Rest: &symbols.Rest{Length: length.Length_Quarter}},
+		timelineSymbol(timeline.Type_First, boundary.Boundary_End),
+	)
+
+	return &tune.Tune{
+		Title:    "Test Tune",
+		Type:     "March",
+		Composer: "Trad.",
+		Arranger: "Tom",
+		Comments: []string{"from the test"},
+		Footer:   []string{"All parts twice"},
+		Tempo:    80,
+		Measures: []*measure.Measure{
+			{
+				Time:        &measure.TimeSignature{Beats: 4, BeatType: 4},
+				LeftBarline: &barline.Barline{Time: barline.Time_Repeat},
+				Symbols: notes(
+					graced(note(pitch.Pitch_LowA, length.Length_Eighth), single(pitch.Pitch_HighG)),
+					note(pitch.Pitch_B, length.Length_Eighth),
+					graced(note(pitch.Pitch_C, length.Length_Quarter), &embellishment.Embellishment{Type: embellishment.Type_Doubling}),
+					dotted,
+					note(pitch.Pitch_E, length.Length_Sixteenth),
+					note(pitch.Pitch_F, length.Length_Quarter),
+				),
+				RightBarline: &barline.Barline{},
+			},
+			{
+				LeftBarline:  &barline.Barline{Time: barline.Time_Segno},
+				Symbols:      triplet,
+				RightBarline: &barline.Barline{Time: barline.Time_Repeat},
+			},
+			{
+				LeftBarline: &barline.Barline{Time: barline.Time_Repeat},
+				Symbols: notes(
+					graced(note(pitch.Pitch_C, length.Length_Quarter), &embellishment.Embellishment{Type: embellishment.Type_Taorluath}),
+					tieStart,
+					tieEnd,
+				),
+				RightBarline: &barline.Barline{},
+			},
+			{
+				Symbols:      first,
+				RightBarline: &barline.Barline{Time: barline.Time_Repeat},
+			},
+			{
+				Symbols: []*symbols.Symbol{
+					timelineSymbol(timeline.Type_Second, boundary.Boundary_Start),
+					{Note: note(pitch.Pitch_HighA, length.Length_Whole), TempoChange: &tempo},
+					timelineSymbol(timeline.Type_Second, boundary.Boundary_End),
+				},
+				RightBarline: &barline.Barline{Time: barline.Time_Dalsegno},
+			},
+			{
+				Time:        &measure.TimeSignature{Beats: 6, BeatType: 8},
+				LeftBarline: &barline.Barline{Type: barline.Type_HeavyLight},
+				Symbols: append(
+					[]*symbols.Symbol{{Note: &symbols.Note{Embellishment: &embellishment.Embellishment{Type: embellishment.Type_ThrowD}}}},
+					notes(
+						graced(throw, single(pitch.Pitch_HighG)),
+						sharp,
+						note(pitch.Pitch_B, length.Length_Sixteenth),
+						note(pitch.Pitch_LowA, length.Length_Sixteenth),
+						note(pitch.Pitch_LowG, length.Length_Eighth),
+					)...,
+				),
+				RightBarline: &barline.Barline{Type: barline.Type_LightHeavy, Time: barline.Time_Fine},
+			},
+		},
+	}
+}
+
+func TestWrite(t *testing.T) {
+	g := NewWithT(t)
+	data := Write([]*tune.Tune{testTune()})
+
+	golden := filepath.Join("testdata", "test_tune.abc")
+	if *update {
+		g.Expect(os.WriteFile(golden, data, 0644)).To(Succeed())
+	}
+	expected, err := os.ReadFile(golden)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(Equal(string(expected)))
+}
+
+func TestWriteAndRead(t *testing.T) {
+	g := NewWithT(t)
+	second := testTune()
+	second.Title = "Second Tune"
+	tunes := []*tune.Tune{testTune(), second}
+
+	parsed, err := Read(Write(tunes))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(parsed).To(HaveLen(2))
+	for i, p := range parsed {
+		g.Expect(p.Tune).To(BeComparableTo(tunes[i], helper.MusicModelCompareOptions))
+		g.Expect(string(p.TuneFileData)).To(HavePrefix(fmt.Sprintf("X:%d\nT:%s\n", i+1, tunes[i].Title)))
+	}
+}
+
+func TestLengthText(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(lengthText(8)).To(Equal(""))
+	g.Expect(lengthText(16)).To(Equal("2"))
+	g.Expect(lengthText(12)).To(Equal("3/2"))
+	g.Expect(lengthText(4)).To(Equal("/2"))
+	g.Expect(lengthText(2)).To(Equal("/4"))
+	g.Expect(lengthText(64)).To(Equal("8"))
+}
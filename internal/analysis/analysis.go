@@ -0,0 +1,257 @@
+// Package analysis creates statistics of the music of tunes from their
+// music model, like the pitch range and the embellishments that are used.
+package analysis
+
+import (
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/barline"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/measure"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/pitch"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/embellishment"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/movement"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/playback"
+	"slices"
+	"strings"
+)
+
+// Analyze returns the analysis of the music of the tune with the id.
+func Analyze(tuneID uuid.UUID, t *tune.Tune) apimodel.TuneAnalysis {
+	a := &analyzer{
+		tuneID: tuneID,
+		analysis: apimodel.TuneAnalysis{
+			TuneCount:      1,
+			TimeSignatures: []string{},
+			Pitches:        map[string]int32{},
+			NoteLengths:    map[string]int32{},
+			Embellishments: map[string]int32{},
+		},
+	}
+	var previous *measure.Measure
+	for i, m := range t.Measures {
+		if startsPart(previous, m) {
+			a.startPart()
+		}
+		a.addMeasure(i, m)
+		previous = m
+	}
+	a.finish(t)
+
+	return a.analysis
+}
+
+// Combine returns the analysis of several tunes from the analyses of the tunes.
+func Combine(analyses []apimodel.TuneAnalysis) apimodel.TuneAnalysis {
+	combined := apimodel.TuneAnalysis{
+		TimeSignatures: []string{},
+		Pitches:        map[string]int32{},
+		NoteLengths:    map[string]int32{},
+		Embellishments: map[string]int32{},
+	}
+	for _, a := range analyses {
+		combined.TuneCount += a.TuneCount
+		combined.MeasureCount += a.MeasureCount
+		combined.PartCount += a.PartCount
+		combined.TimeSignatures = appendMissing(combined.TimeSignatures, a.TimeSignatures...)
+		combined.LowestPitch = lowerPitch(combined.LowestPitch, a.LowestPitch)
+		combined.HighestPitch = higherPitch(combined.HighestPitch, a.HighestPitch)
+		combined.Duration = addDuration(combined.Duration, a.Duration)
+		combined.Messages = append(combined.Messages, a.Messages...)
+		addCounts(combined.Pitches, a.Pitches)
+		addCounts(combined.NoteLengths, a.NoteLengths)
+		addCounts(combined.Embellishments, a.Embellishments)
+		combined.Movements = addCounts(combined.Movements, a.Movements)
+	}
+
+	return combined
+}
+
+// Messages returns the messages of the parser about the measures of the tune with the id.
+func Messages(tuneID uuid.UUID, t *tune.Tune) []apimodel.ParserMessage {
+	var messages []apimodel.ParserMessage
+	for i, m := range t.Measures {
+		for _, pm := range m.ParserMessages {
+			messages = append(messages, parserMessage(tuneID, i, pm))
+		}
+	}
+
+	return messages
+}
+
+// analyzer walks through the measures of a tune and collects its analysis.
+type analyzer struct {
+	tuneID   uuid.UUID
+	analysis apimodel.TuneAnalysis
+	lowest   pitch.Pitch
+	highest  pitch.Pitch
+}
+
+// startsPart returns true if the measure starts a new part,
+// which is the case if there is a heavy barline before it.
+func startsPart(previous *measure.Measure, m *measure.Measure) bool {
+	if previous == nil {
+		return true
+	}
+
+	return isHeavy(previous.RightBarline) || isHeavy(m.LeftBarline)
+}
+
+func isHeavy(b *barline.Barline) bool {
+	return b != nil && b.Type != barline.Type_Regular
+}
+
+func (a *analyzer) startPart() {
+	a.analysis.PartCount++
+}
+
+func (a *analyzer) addMeasure(idx int, m *measure.Measure) {
+	if m.Time != nil {
+		a.analysis.TimeSignatures = appendMissing(a.analysis.TimeSignatures, m.Time.DisplayString())
+	}
+	if a.addSymbols(m.Symbols) {
+		a.analysis.MeasureCount++
+	}
+	a.addMessages(idx, m.ParserMessages)
+}
+
+// addSymbols adds the symbols to the analysis and returns true
+// if there is a note or rest among them.
+func (a *analyzer) addSymbols(syms []*symbols.Symbol) bool {
+	hasMusic := false
+	for _, s := range syms {
+		hasMusic = a.addSymbol(s) || hasMusic
+	}
+
+	return hasMusic
+}
+
+// addSymbol adds the symbol to the analysis and returns true if it is a note or rest.
+func (a *analyzer) addSymbol(s *symbols.Symbol) bool {
+	if s.Note != nil {
+		a.addOrnaments(s.Note)
+	}
+
+	switch {
+	case s.IsValidNote():
+		a.addNote(s.Note)
+	case s.Rest != nil:
+	default:
+		return false
+	}
+
+	return true
+}
+
+func (a *analyzer) addOrnaments(n *symbols.Note) {
+	if e := n.Embellishment; e != nil && e.Type != embellishment.Type_NoEmbellishment {
+		a.analysis.Embellishments[variantName(e.Variant.String(), "NoVariant")+e.Type.String()]++
+	}
+	if mv := n.Movement; mv != nil && mv.Type != movement.Type_NoMovement {
+		if a.analysis.Movements == nil {
+			a.analysis.Movements = map[string]int32{}
+		}
+		a.analysis.Movements[variantName(mv.Variant.String(), "NoVariant")+mv.Type.String()]++
+	}
+}
+
+// variantName returns the name of the variant or an empty string for no variant.
+func variantName(name string, noVariant string) string {
+	if name == noVariant {
+		return ""
+	}
+
+	return name
+}
+
+func (a *analyzer) addNote(n *symbols.Note) {
+	a.analysis.Pitches[n.Pitch.String()]++
+	a.analysis.NoteLengths[n.Length.String()]++
+	if a.lowest == pitch.Pitch_NoPitch || n.Pitch < a.lowest {
+		a.lowest = n.Pitch
+	}
+	a.highest = max(a.highest, n.Pitch)
+}
+
+func (a *analyzer) addMessages(idx int, messages []*measure.ParserMessage) {
+	for _, m := range messages {
+		a.analysis.Messages = append(a.analysis.Messages, parserMessage(a.tuneID, idx, m))
+	}
+}
+
+func parserMessage(tuneID uuid.UUID, idx int, m *measure.ParserMessage) apimodel.ParserMessage {
+	return apimodel.ParserMessage{
+		TuneId:   tuneID,
+		Measure:  int32(idx + 1),
+		Severity: strings.ToLower(m.Severity.String()),
+		Symbol:   m.Symbol,
+		Text:     m.Text,
+		Fix:      strings.ToLower(variantName(m.Fix.String(), "NoFix")),
+	}
+}
+
+// finish sets the pitch range and the duration of the tune, which is the
+// duration of its playback, so that it is as long as its MIDI and audio files.
+func (a *analyzer) finish(t *tune.Tune) {
+	if a.lowest != pitch.Pitch_NoPitch {
+		a.analysis.LowestPitch = a.lowest.String()
+		a.analysis.HighestPitch = a.highest.String()
+	}
+	if a.analysis.MeasureCount > 0 {
+		duration := playback.Play(t, 0).Duration
+		a.analysis.Duration = &duration
+	}
+}
+
+func appendMissing(values []string, add ...string) []string {
+	for _, v := range add {
+		if !slices.Contains(values, v) {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
+func lowerPitch(a string, b string) string {
+	if a == "" || (b != "" && pitch.Pitch_value[b] < pitch.Pitch_value[a]) {
+		return b
+	}
+
+	return a
+}
+
+func higherPitch(a string, b string) string {
+	if pitch.Pitch_value[b] > pitch.Pitch_value[a] {
+		return b
+	}
+
+	return a
+}
+
+func addDuration(sum *float64, d *float64) *float64 {
+	if d == nil {
+		return sum
+	}
+
+	total := *d
+	if sum != nil {
+		total += *sum
+	}
+
+	return &total
+}
+
+// addCounts adds the counts to the counts of the same names in sum, which is
+// created if there are counts to add. It returns sum.
+func addCounts(sum map[string]int32, counts map[string]int32) map[string]int32 {
+	if sum == nil && len(counts) > 0 {
+		sum = map[string]int32{}
+	}
+	for name, c := range counts {
+		sum[name] += c
+	}
+
+	return sum
+}
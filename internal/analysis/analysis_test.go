@@ -0,0 +1,201 @@
+package analysis
+
+import (
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/barline"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/boundary"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/length"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/measure"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/pitch"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/embellishment"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/movement"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/timeline"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/tuplet"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/playback"
+	"testing"
+)
+
+func note(p pitch.Pitch, l length.Length, dots uint32) *symbols.Symbol {
+	return &symbols.Symbol{Note: &symbols.Note{Pitch: p, Length: l, Dots: dots}}
+}
+
+func twoPartTune() *tune.Tune {
+	tempoChange := uint64(40)
+
+	return &tune.Tune{
+		Tempo: 60,
+		Measures: []*measure.Measure{
+			{
+				LeftBarline: &barline.Barline{Type: barline.Type_HeavyLight, Time: barline.Time_Repeat},
+				Time:        &measure.TimeSignature{Beats: 2, BeatType: 4},
+				Symbols: []*symbols.Symbol{
+					{Note: &symbols.Note{Embellishment: &embellishment.Embellishment{Type: embellishment.Type_Doubling}}},
+					note(pitch.Pitch_LowA, length.Length_Quarter, 1),
+					{Note: &symbols.Note{
+						Pitch:         pitch.Pitch_B,
+						Length:        length.Length_Eighth,
+						Embellishment: &embellishment.Embellishment{Type: embellishment.Type_Doubling, Variant: embellishment.Variant_Half},
+					}},
+				},
+			},
+			{
+				RightBarline: &barline.Barline{Type: barline.Type_LightHeavy, Time: barline.Time_Repeat},
+				Symbols: []*symbols.Symbol{
+					{Tuplet: &tuplet.Tuplet{BoundaryType: boundary.Boundary_Start, VisibleNotes: 3, PlayedNotes: 2}},
+					note(pitch.Pitch_C, length.Length_Eighth, 0),
+					note(pitch.Pitch_D, length.Length_Eighth, 0),
+					note(pitch.Pitch_E, length.Length_Eighth, 0),
+					{Tuplet: &tuplet.Tuplet{BoundaryType: boundary.Boundary_End, VisibleNotes: 3, PlayedNotes: 2}},
+					{Rest: &symbols.Rest{Length: length.Length_Quarter}},
+				},
+			},
+			{
+				Time: &measure.TimeSignature{Beats: 6, BeatType: 8},
+				Symbols: []*symbols.Symbol{
+					{TempoChange: &tempoChange},
+					{Note: &symbols.Note{Movement: &movement.Movement{Type: movement.Type_Cadence}}},
+					note(pitch.Pitch_HighA, length.Length_Quarter, 1),
+					note(pitch.Pitch_F, length.Length_Quarter, 1),
+				},
+				ParserMessages: []*measure.ParserMessage{
+					{
+						Symbol:   "gg",
+						Severity: measure.Severity_Warning,
+						Text:     "unknown symbol",
+						Fix:      measure.Fix_SkipSymbol,
+					},
+				},
+			},
+			{InlineTexts: []string{"D.C."}},
+		},
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	g := NewWithT(t)
+	tuneID := uuid.New()
+
+	a := Analyze(tuneID, twoPartTune())
+
+	g.Expect(a.TuneCount).To(Equal(int32(1)))
+	g.Expect(a.MeasureCount).To(Equal(int32(3)))
+	g.Expect(a.PartCount).To(Equal(int32(2)))
+	g.Expect(a.TimeSignatures).To(Equal([]string{"2/4", "6/8"}))
+	g.Expect(a.LowestPitch).To(Equal("LowA"))
+	g.Expect(a.HighestPitch).To(Equal("HighA"))
+	g.Expect(a.Pitches).To(Equal(map[string]int32{
+		"LowA": 1, "B": 1, "C": 1, "D": 1, "E": 1, "F": 1, "HighA": 1,
+	}))
+	g.Expect(a.NoteLengths).To(Equal(map[string]int32{"Quarter": 3, "Eighth": 4}))
+	g.Expect(a.Embellishments).To(Equal(map[string]int32{"Doubling": 1, "HalfDoubling": 1}))
+	g.Expect(a.Movements).To(Equal(map[string]int32{"Cadence": 1}))
+	// the repeated first part takes 2 * 4 seconds at 60 bpm and
+	// the second part 2 dotted quarter beats at 40 bpm
+	g.Expect(a.Duration).NotTo(BeNil())
+	g.Expect(*a.Duration).To(BeNumerically("~", 11, 1e-9))
+	g.Expect(a.Messages).To(Equal([]apimodel.ParserMessage{
+		{
+			TuneId:   tuneID,
+			Measure:  3,
+			Severity: "warning",
+			Symbol:   "gg",
+			Text:     "unknown symbol",
+			Fix:      "skipsymbol",
+		},
+	}))
+}
+
+func TestAnalyzeWithoutMusic(t *testing.T) {
+	g := NewWithT(t)
+
+	a := Analyze(uuid.New(), &tune.Tune{})
+
+	g.Expect(a.TuneCount).To(Equal(int32(1)))
+	g.Expect(a.MeasureCount).To(BeZero())
+	g.Expect(a.PartCount).To(BeZero())
+	g.Expect(a.LowestPitch).To(BeEmpty())
+	g.Expect(a.HighestPitch).To(BeEmpty())
+	g.Expect(a.Movements).To(BeNil())
+	g.Expect(a.Duration).To(BeNil())
+	g.Expect(a.Messages).To(BeNil())
+}
+
+func TestAnalyzeDurationOfPlayback(t *testing.T) {
+	g := NewWithT(t)
+
+	first := []*symbols.Symbol{{Timeline: &timeline.TimeLine{Type: timeline.Type_First, BoundaryType: boundary.Boundary_Start}}}
+	first = append(first, note(pitch.Pitch_B, length.Length_Half, 0))
+	first = append(first, &symbols.Symbol{Timeline: &timeline.TimeLine{Type: timeline.Type_First, BoundaryType: boundary.Boundary_End}})
+	withEndings := &tune.Tune{Measures: []*measure.Measure{
+		{
+			Time:    &measure.TimeSignature{Beats: 2, BeatType: 4},
+			Symbols: []*symbols.Symbol{note(pitch.Pitch_LowA, length.Length_Half, 0)},
+		},
+		{
+			Symbols:      first,
+			RightBarline: &barline.Barline{Time: barline.Time_Repeat},
+		},
+		{
+			Symbols: []*symbols.Symbol{note(pitch.Pitch_C, length.Length_Half, 0)},
+		},
+	}}
+
+	a := Analyze(uuid.New(), withEndings)
+
+	// the first ending is skipped on the second pass, at 80 beats per minute
+	g.Expect(*a.Duration).To(BeNumerically("~", 4*1.5, 1e-9))
+	g.Expect(*a.Duration).To(Equal(playback.Play(withEndings, 0).Duration))
+}
+
+func TestCombine(t *testing.T) {
+	g := NewWithT(t)
+
+	// the tune without a tempo is played at the default tempo
+	withoutTempo := &tune.Tune{Measures: []*measure.Measure{
+		{
+			Time: &measure.TimeSignature{Beats: 3, BeatType: 4},
+			Symbols: []*symbols.Symbol{
+				note(pitch.Pitch_LowG, length.Length_Half, 1),
+			},
+		},
+	}}
+
+	a := Combine([]apimodel.TuneAnalysis{
+		Analyze(uuid.New(), twoPartTune()),
+		Analyze(uuid.New(), withoutTempo),
+	})
+
+	g.Expect(a.TuneCount).To(Equal(int32(2)))
+	g.Expect(a.MeasureCount).To(Equal(int32(4)))
+	g.Expect(a.PartCount).To(Equal(int32(3)))
+	g.Expect(a.TimeSignatures).To(Equal([]string{"2/4", "6/8", "3/4"}))
+	g.Expect(a.LowestPitch).To(Equal("LowG"))
+	g.Expect(a.HighestPitch).To(Equal("HighA"))
+	g.Expect(a.NoteLengths).To(Equal(map[string]int32{"Quarter": 3, "Eighth": 4, "Half": 1}))
+	g.Expect(a.Movements).To(Equal(map[string]int32{"Cadence": 1}))
+	g.Expect(*a.Duration).To(BeNumerically("~", 13.25, 1e-9))
+	g.Expect(a.Messages).To(HaveLen(1))
+}
+
+func TestCombineNothing(t *testing.T) {
+	g := NewWithT(t)
+
+	a := Combine(nil)
+
+	g.Expect(a.TuneCount).To(BeZero())
+	g.Expect(a.TimeSignatures).To(BeEmpty())
+	g.Expect(a.Pitches).To(BeEmpty())
+	g.Expect(a.Duration).To(BeNil())
+}
+
+func TestMessages(t *testing.T) {
+	g := NewWithT(t)
+	tuneID := uuid.New()
+
+	g.Expect(Messages(tuneID, twoPartTune())).To(Equal(Analyze(tuneID, twoPartTune()).Messages))
+	g.Expect(Messages(tuneID, &tune.Tune{})).To(BeNil())
+}
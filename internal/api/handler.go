@@ -2,23 +2,29 @@ package api
 
 import (
 	"errors"
-	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
 	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/auth"
+	"github.com/tomvodi/limepipes/internal/booklet"
 	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/export"
 	"github.com/tomvodi/limepipes/internal/interfaces"
-	"io"
-	"mime/multipart"
 	"net/http"
-	"path/filepath"
+	"time"
 )
 
 type Handler struct {
 	service       interfaces.DataService
 	pluginLoader  interfaces.PluginLoader
 	healthChecker interfaces.HealthChecker
+	exporter      interfaces.TuneExporter
+	importQueue   interfaces.ImportJobQueue
+	booklets      *booklet.Builder
+
+	// eventPollInterval is the interval in which the import job is
+	// read from the database while streaming its progress events.
+	eventPollInterval time.Duration
 }
 
 func (a *Handler) Home(c *gin.Context) {
@@ -36,114 +42,43 @@ func (a *Handler) Health(c *gin.Context) {
 	handleFunc(c)
 }
 
-func (a *Handler) ImportFile(c *gin.Context) {
-	iFile, err := c.FormFile("file")
-	if err != nil {
-		httpErrorResponse(c, http.StatusBadRequest, err)
-		return
-	}
-
-	fExt := filepath.Ext(iFile.Filename)
-	if fExt == "" {
-		c.JSON(http.StatusBadRequest,
-			apimodel.Error{
-				Message: "import file does not have an extension",
-			},
-		)
-		return
-	}
-
-	fType, err := a.pluginLoader.FileFormatForFileExtension(fExt)
-	if err != nil {
-		c.JSON(http.StatusBadRequest,
-			apimodel.Error{
-				Message: fmt.Sprintf("file extension %s is currently not supported: %s", fExt, err.Error()),
-			},
-		)
-		return
-	}
-
-	fInfo, err := a.createImportFileInfo(iFile, fType)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError,
-			apimodel.Error{
-				Message: err.Error(),
-			})
-		return
-	}
-	_, err = a.service.GetImportFileByHash(fInfo.Hash)
-
-	if !errors.Is(err, common.ErrNotFound) {
-		c.JSON(http.StatusConflict,
-			fmt.Sprintf("file %s was already imported", iFile.Filename))
-		return
-	}
-
-	importTunes, importSet, err := a.importFile(fInfo, fExt)
-	if err != nil {
-		httpErrorResponse(c, http.StatusInternalServerError, err)
-		return
-	}
-
-	importResponse := &apimodel.ImportFile{
-		Name:  iFile.Filename,
-		Set:   *importSet,
-		Tunes: importTunes,
-	}
-
-	c.JSON(http.StatusOK, importResponse)
+func httpErrorResponse(c *gin.Context, code int, err error) {
+	c.JSON(code, apimodel.Error{
+		Message: err.Error(),
+	})
 }
 
-func (a *Handler) createImportFileInfo(
-	iFile *multipart.FileHeader,
-	fFormat fileformat.Format,
-) (*common.ImportFileInfo, error) {
-	fileReader, err := iFile.Open()
-	if err != nil {
-		return nil, fmt.Errorf("failed open file %s for reading", iFile.Filename)
-	}
-	defer fileReader.Close()
-
-	fileData, err := io.ReadAll(fileReader)
-	if err != nil {
-		return nil, fmt.Errorf("failed reading file %s: %s", iFile.Filename, err.Error())
+// requestAuthor returns the author of the changes of a request,
+// which is the name of the authenticated user.
+func requestAuthor(c *gin.Context) string {
+	user := auth.User(c)
+	if user == nil {
+		return ""
 	}
 
-	fInfo, err := common.NewImportFileInfo(iFile.Filename, fFormat, fileData)
-	if err != nil {
-		return nil, fmt.Errorf("failed creating import file info for file %s: %s", iFile.Filename, err.Error())
-	}
-
-	return fInfo, nil
+	return user.Name
 }
 
-func (a *Handler) importFile(
-	fInfo *common.ImportFileInfo,
-	fileExt string,
-) ([]*apimodel.ImportTune, *apimodel.BasicMusicSet, error) {
-	filePlugin, err := a.pluginLoader.PluginForFileExtension(fileExt)
-	if err != nil {
-		return nil, nil, fmt.Errorf("fileData extension %s is currently not supported (no plugin): %s", fileExt, err.Error())
+// requestUserID returns the id of the authenticated user of a request
+// or nil if the request is not authenticated.
+func requestUserID(c *gin.Context) *uuid.UUID {
+	user := auth.User(c)
+	if user == nil {
+		return nil
 	}
 
-	parsedTunes, err := filePlugin.Parse(fInfo.Data)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed parsing fileData %s: %s", fInfo.Name, err.Error())
-	}
-
-	return a.service.ImportTunes(parsedTunes, fInfo)
-}
-
-func httpErrorResponse(c *gin.Context, code int, err error) {
-	c.JSON(code, apimodel.Error{
-		Message: err.Error(),
-	})
+	return &user.ID
 }
 
 func handleResponseForError(c *gin.Context, err error) {
 	code := http.StatusInternalServerError
-	if errors.Is(err, common.ErrNotFound) {
+	switch {
+	case errors.Is(err, common.ErrNotFound):
 		code = http.StatusNotFound
+	case errors.Is(err, common.ErrInvalidArgument):
+		code = http.StatusBadRequest
+	case errors.Is(err, common.ErrAlreadyExists):
+		code = http.StatusConflict
 	}
 
 	c.JSON(code, apimodel.Error{
@@ -162,7 +97,7 @@ func (a *Handler) CreateTune(c *gin.Context) {
 		return
 	}
 
-	tune, err := a.service.CreateTune(createTune, nil)
+	tune, err := a.service.CreateTune(createTune, nil, requestUserID(c))
 	if err != nil {
 		httpErrorResponse(c, http.StatusInternalServerError, err)
 		return
@@ -172,7 +107,7 @@ func (a *Handler) CreateTune(c *gin.Context) {
 }
 
 func (a *Handler) GetTune(c *gin.Context) {
-	tuneID, err := uuid.Parse(c.Param("tuneID"))
+	tuneID, err := uuid.Parse(c.Param("tuneId"))
 	if err != nil {
 		httpErrorResponse(c, http.StatusBadRequest, err)
 		return
@@ -188,9 +123,16 @@ func (a *Handler) GetTune(c *gin.Context) {
 }
 
 func (a *Handler) ListTunes(c *gin.Context) {
-	tunes, err := a.service.Tunes()
+	var listOpts common.TuneListOptions
+	if err := c.ShouldBindQuery(&listOpts); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tunes, err := a.service.Tunes(listOpts)
 	if err != nil {
-		httpErrorResponse(c, http.StatusInternalServerError, err)
+		handleResponseForError(c, err)
+		return
 	}
 
 	c.JSON(http.StatusOK, tunes)
@@ -203,13 +145,13 @@ func (a *Handler) UpdateTune(c *gin.Context) {
 		return
 	}
 
-	tuneID, err := uuid.Parse(c.Param("tuneID"))
+	tuneID, err := uuid.Parse(c.Param("tuneId"))
 	if err != nil {
 		httpErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
-	tune, err := a.service.UpdateTune(tuneID, updateTune)
+	tune, err := a.service.UpdateTune(tuneID, updateTune, requestAuthor(c))
 	if err != nil {
 		handleResponseForError(c, err)
 		return
@@ -219,7 +161,7 @@ func (a *Handler) UpdateTune(c *gin.Context) {
 }
 
 func (a *Handler) DeleteTune(c *gin.Context) {
-	tuneID, err := uuid.Parse(c.Param("tuneID"))
+	tuneID, err := uuid.Parse(c.Param("tuneId"))
 	if err != nil {
 		httpErrorResponse(c, http.StatusBadRequest, err)
 		return
@@ -241,7 +183,7 @@ func (a *Handler) CreateSet(c *gin.Context) {
 		return
 	}
 
-	set, err := a.service.CreateMusicSet(createSet, nil)
+	set, err := a.service.CreateMusicSet(createSet, nil, requestUserID(c))
 	if err != nil {
 		httpErrorResponse(c, http.StatusInternalServerError, err)
 		return
@@ -251,13 +193,13 @@ func (a *Handler) CreateSet(c *gin.Context) {
 }
 
 func (a *Handler) GetSet(c *gin.Context) {
-	setID, err := uuid.Parse(c.Param("setID"))
+	setID, err := uuid.Parse(c.Param("setId"))
 	if err != nil {
 		httpErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
-	set, err := a.service.GetMusicSet(setID)
+	set, err := a.service.GetMusicSet(setID, requestUserID(c))
 	if err != nil {
 		handleResponseForError(c, err)
 		return
@@ -267,7 +209,7 @@ func (a *Handler) GetSet(c *gin.Context) {
 }
 
 func (a *Handler) ListSets(c *gin.Context) {
-	sets, err := a.service.MusicSets()
+	sets, err := a.service.MusicSets(requestUserID(c))
 	if err != nil {
 		httpErrorResponse(c, http.StatusInternalServerError, err)
 	}
@@ -282,12 +224,12 @@ func (a *Handler) UpdateSet(c *gin.Context) {
 		return
 	}
 
-	setID, err := uuid.Parse(c.Param("setID"))
+	setID, err := uuid.Parse(c.Param("setId"))
 	if err != nil {
 		httpErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
-	set, err := a.service.UpdateMusicSet(setID, updateSet)
+	set, err := a.service.UpdateMusicSet(setID, updateSet, requestUserID(c))
 	if err != nil {
 		handleResponseForError(c, err)
 		return
@@ -297,13 +239,13 @@ func (a *Handler) UpdateSet(c *gin.Context) {
 }
 
 func (a *Handler) DeleteSet(c *gin.Context) {
-	setID, err := uuid.Parse(c.Param("setID"))
+	setID, err := uuid.Parse(c.Param("setId"))
 	if err != nil {
 		httpErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
-	if err := a.service.DeleteMusicSet(setID); err != nil {
+	if err := a.service.DeleteMusicSet(setID, requestUserID(c)); err != nil {
 		handleResponseForError(c, err)
 		return
 	}
@@ -318,13 +260,13 @@ func (a *Handler) AssignTunesToSet(c *gin.Context) {
 		return
 	}
 
-	setID, err := uuid.Parse(c.Param("setID"))
+	setID, err := uuid.Parse(c.Param("setId"))
 	if err != nil {
 		httpErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
-	set, err := a.service.AssignTunesToMusicSet(setID, tuneIDs)
+	set, err := a.service.AssignTunesToMusicSet(setID, tuneIDs, requestUserID(c))
 	if err != nil {
 		handleResponseForError(c, err)
 		return
@@ -333,14 +275,40 @@ func (a *Handler) AssignTunesToSet(c *gin.Context) {
 	c.JSON(http.StatusOK, set)
 }
 
+func (a *Handler) Search(c *gin.Context) {
+	var searchOpts common.SearchOptions
+	if err := c.ShouldBindQuery(&searchOpts); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	searchOpts.UserID = requestUserID(c)
+
+	result, err := a.service.Search(searchOpts)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// revive:disable:argument-limit the handler needs all of these services
 func NewAPIHandler(
 	service interfaces.DataService,
 	pluginLoader interfaces.PluginLoader,
 	healthChecker interfaces.HealthChecker,
+	importQueue interfaces.ImportJobQueue,
 ) *Handler {
 	return &Handler{
-		service:       service,
-		pluginLoader:  pluginLoader,
-		healthChecker: healthChecker,
+		service:           service,
+		pluginLoader:      pluginLoader,
+		healthChecker:     healthChecker,
+		exporter:          export.NewExporter(service, pluginLoader),
+		importQueue:       importQueue,
+		booklets:          booklet.NewBuilder(service),
+		eventPollInterval: defaultEventPollInterval,
 	}
 }
+
+// revive:enable:argument-limit
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes/internal/common"
+	"net/http"
+)
+
+// GetTuneAnalysis returns the analysis of the music of the tune.
+func (a *Handler) GetTuneAnalysis(c *gin.Context) {
+	tuneID, err := uuid.Parse(c.Param("tuneId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	analysis, err := a.service.TuneAnalysis(tuneID)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, analysis)
+}
+
+// GetSetAnalysis returns the combined analysis of the tunes of the set.
+func (a *Handler) GetSetAnalysis(c *gin.Context) {
+	setID, err := uuid.Parse(c.Param("setId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	analysis, err := a.service.MusicSetAnalysis(setID, requestUserID(c))
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, analysis)
+}
+
+// GetTunesAnalysis returns the combined analysis of all tunes that match
+// the same filters as the tune list.
+func (a *Handler) GetTunesAnalysis(c *gin.Context) {
+	var listOpts common.TuneListOptions
+	if err := c.ShouldBindQuery(&listOpts); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	analysis, err := a.service.TunesAnalysis(listOpts)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, analysis)
+}
@@ -0,0 +1,148 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("Api Handler Analysis", func() {
+	var c *gin.Context
+	var httpRec *httptest.ResponseRecorder
+	var api *Handler
+	var dataService *mocks.DataService
+	var id uuid.UUID
+	var analysis *apimodel.TuneAnalysis
+
+	BeforeEach(func() {
+		id = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+		duration := 12.5
+		analysis = &apimodel.TuneAnalysis{
+			TuneCount:      1,
+			MeasureCount:   16,
+			PartCount:      2,
+			TimeSignatures: []string{"2/4"},
+			LowestPitch:    "LowG",
+			HighestPitch:   "HighA",
+			Pitches:        map[string]int32{"LowG": 3},
+			NoteLengths:    map[string]int32{"Quarter": 3},
+			Embellishments: map[string]int32{"Doubling": 1},
+			Duration:       &duration,
+		}
+
+		httpRec = httptest.NewRecorder()
+		c, _ = gin.CreateTestContext(httpRec)
+		dataService = mocks.NewDataService(GinkgoT())
+		api = &Handler{
+			service: dataService,
+		}
+	})
+
+	Context("Get Tune Analysis", func() {
+		JustBeforeEach(func() {
+			api.GetTuneAnalysis(c)
+		})
+
+		When("the tune has music", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{{Key: "tuneId", Value: id.String()}}
+				dataService.EXPECT().TuneAnalysis(id).Return(analysis, nil)
+			})
+
+			It("should return the analysis", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Body.String()).To(Equal(
+					`{"tuneCount":1,"measureCount":16,"partCount":2,"timeSignatures":["2/4"],` +
+						`"lowestPitch":"LowG","highestPitch":"HighA","pitches":{"LowG":3},` +
+						`"noteLengths":{"Quarter":3},"embellishments":{"Doubling":1},"duration":12.5}`))
+			})
+		})
+
+		When("the tune doesn't exist", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{{Key: "tuneId", Value: id.String()}}
+				dataService.EXPECT().TuneAnalysis(id).Return(nil, common.ErrNotFound)
+			})
+
+			It("should return NotFound", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("the tuneId is no uuid", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{{Key: "tuneId", Value: "not a uuid"}}
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	Context("Get Set Analysis", func() {
+		JustBeforeEach(func() {
+			api.GetSetAnalysis(c)
+		})
+
+		When("the set exists", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{{Key: "setId", Value: id.String()}}
+				dataService.EXPECT().MusicSetAnalysis(id, (*uuid.UUID)(nil)).Return(analysis, nil)
+			})
+
+			It("should return the analysis", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Body.String()).To(ContainSubstring(`"tuneCount":1`))
+			})
+		})
+
+		When("the set doesn't exist", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{{Key: "setId", Value: id.String()}}
+				dataService.EXPECT().MusicSetAnalysis(id, (*uuid.UUID)(nil)).Return(nil, common.ErrNotFound)
+			})
+
+			It("should return NotFound", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Context("Get Tunes Analysis", func() {
+		JustBeforeEach(func() {
+			api.GetTunesAnalysis(c)
+		})
+
+		When("filtering the tunes by type", func() {
+			BeforeEach(func() {
+				c.Request = httptest.NewRequest(http.MethodGet, "/tunes/analysis?type=March", nil)
+				dataService.EXPECT().TunesAnalysis(common.TuneListOptions{Type: "March"}).
+					Return(analysis, nil)
+			})
+
+			It("should return the analysis of the filtered tunes", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Body.String()).To(ContainSubstring(`"measureCount":16`))
+			})
+		})
+
+		When("the sort field is unknown", func() {
+			BeforeEach(func() {
+				c.Request = httptest.NewRequest(http.MethodGet, "/tunes/analysis?sort=unknown", nil)
+				dataService.EXPECT().TunesAnalysis(common.TuneListOptions{Sort: "unknown"}).
+					Return(nil, common.ErrInvalidArgument)
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+})
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/audio"
+	"github.com/tomvodi/limepipes/internal/common"
+	"net/http"
+)
+
+// GetTuneAudio synthesizes the music model of the tune as audio file.
+func (a *Handler) GetTuneAudio(c *gin.Context) {
+	var audioOpts common.AudioOptions
+	if err := c.ShouldBindQuery(&audioOpts); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tuneID, err := uuid.Parse(c.Param("tuneId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	apiTune, err := a.service.GetTune(tuneID)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	muMoTune, err := a.musicModelTune(tuneID)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	sendAudio(c, []*tune.Tune{muMoTune}, audioOptions(audioOpts, apiTune.Title))
+}
+
+// GetSetAudio synthesizes the tunes of the set in their order as one audio file.
+// Tunes without music model are left out.
+func (a *Handler) GetSetAudio(c *gin.Context) {
+	var audioOpts common.AudioOptions
+	if err := c.ShouldBindQuery(&audioOpts); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	setID, err := uuid.Parse(c.Param("setId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	set, err := a.service.GetMusicSet(setID, requestUserID(c))
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	muMoTunes, err := a.setMusicModelTunes(set)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	sendAudio(c, muMoTunes, audioOptions(audioOpts, set.Title))
+}
+
+// setMusicModelTunes returns the music models of the tunes of the set
+// that have one.
+func (a *Handler) setMusicModelTunes(set *apimodel.MusicSet) ([]*tune.Tune, error) {
+	var muMoTunes []*tune.Tune
+	for _, t := range set.Tunes {
+		muMoTune, err := a.musicModelTune(t.Id)
+		if errors.Is(err, common.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		muMoTunes = append(muMoTunes, muMoTune)
+	}
+
+	if len(muMoTunes) == 0 {
+		return nil, fmt.Errorf("%w: set %s has no tunes with music", common.ErrNotFound, set.Id)
+	}
+
+	return muMoTunes, nil
+}
+
+func audioOptions(opts common.AudioOptions, title string) audio.Options {
+	return audio.Options{
+		Format:  audio.Format(opts.Format),
+		Timbre:  audio.Timbre(opts.Timbre),
+		Speed:   opts.Speed,
+		CountIn: opts.CountIn,
+		Title:   title,
+	}
+}
+
+func sendAudio(c *gin.Context, tunes []*tune.Tune, opts audio.Options) {
+	data, err := audio.Render(tunes, opts)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	setAttachmentName(c, common.TitleFileName(opts.Title, audio.Extension(opts.Format)))
+	c.Data(http.StatusOK, audio.ContentType(opts.Format), data)
+}
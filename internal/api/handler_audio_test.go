@@ -0,0 +1,217 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/audio"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("Api Handler Audio", func() {
+	var c *gin.Context
+	var httpRec *httptest.ResponseRecorder
+	var api *Handler
+	var dataService *mocks.DataService
+	var tuneID uuid.UUID
+	var muMoFile *model.TuneFile
+	var muMoTune *tune.Tune
+
+	BeforeEach(func() {
+		var err error
+		tuneID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+		muMoFile, err = model.TuneFileFromMusicModelTune(model.TestParsedTune("Scotland the Brave").Tune)
+		Expect(err).ShouldNot(HaveOccurred())
+		muMoTune, err = muMoFile.MusicModelTune()
+		Expect(err).ShouldNot(HaveOccurred())
+
+		httpRec = httptest.NewRecorder()
+		c, _ = gin.CreateTestContext(httpRec)
+		dataService = mocks.NewDataService(GinkgoT())
+		api = &Handler{
+			service: dataService,
+		}
+	})
+
+	Context("GetTuneAudio", func() {
+		BeforeEach(func() {
+			c.Params = gin.Params{{Key: "tuneId", Value: tuneID.String()}}
+			c.Request = httptest.NewRequest(http.MethodGet, "/tunes/"+tuneID.String()+"/audio", nil)
+		})
+
+		JustBeforeEach(func() {
+			api.GetTuneAudio(c)
+		})
+
+		When("the tune has a music model", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().GetTune(tuneID).
+					Return(&apimodel.Tune{Id: tuneID, Title: "Scotland the Brave"}, nil)
+				dataService.EXPECT().GetTuneFile(tuneID, fileformat.Format_MUSIC_MODEL).
+					Return(muMoFile, nil)
+			})
+
+			It("should return the tune played by the pipes as WAV file", func() {
+				data, err := audio.Render([]*tune.Tune{muMoTune}, audio.Options{})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Header().Get("Content-Type")).To(Equal("audio/wav"))
+				Expect(httpRec.Header().Get("Content-Disposition")).
+					To(Equal("attachment; filename=Scotland_the_Brave.wav"))
+				Expect(httpRec.Body.Bytes()).To(Equal(data))
+			})
+
+			When("options are given", func() {
+				BeforeEach(func() {
+					c.Request = httptest.NewRequest(http.MethodGet, "/tunes/"+tuneID.String()+
+						"/audio?format=ogg&timbre=practice-chanter&speed=0.8&countIn=4", nil)
+				})
+
+				It("should return the audio file of the options", func() {
+					data, err := audio.Render([]*tune.Tune{muMoTune}, audio.Options{
+						Format:  audio.FormatOGG,
+						Timbre:  audio.TimbrePracticeChanter,
+						Speed:   0.8,
+						CountIn: 4,
+						Title:   "Scotland the Brave",
+					})
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(httpRec.Code).To(Equal(http.StatusOK))
+					Expect(httpRec.Header().Get("Content-Type")).To(Equal("audio/ogg; codecs=flac"))
+					Expect(httpRec.Header().Get("Content-Disposition")).
+						To(Equal("attachment; filename=Scotland_the_Brave.oga"))
+					Expect(httpRec.Body.Bytes()).To(Equal(data))
+				})
+			})
+		})
+
+		When("the timbre is unknown", func() {
+			BeforeEach(func() {
+				c.Request = httptest.NewRequest(http.MethodGet, "/tunes/"+tuneID.String()+"/audio?timbre=harp", nil)
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the speed is too fast", func() {
+			BeforeEach(func() {
+				c.Request = httptest.NewRequest(http.MethodGet, "/tunes/"+tuneID.String()+"/audio?speed=3", nil)
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the tune has no music model", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().GetTune(tuneID).
+					Return(&apimodel.Tune{Id: tuneID, Title: "Scotland the Brave"}, nil)
+				dataService.EXPECT().GetTuneFile(tuneID, fileformat.Format_MUSIC_MODEL).
+					Return(nil, common.ErrNotFound)
+			})
+
+			It("should return NotFound", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("the tuneId is no uuid", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{{Key: "tuneId", Value: "not a uuid"}}
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	Context("GetSetAudio", func() {
+		var setID uuid.UUID
+		var tuneWithoutMusicID uuid.UUID
+
+		BeforeEach(func() {
+			setID = uuid.MustParse("00000000-0000-0000-0000-000000000010")
+			tuneWithoutMusicID = uuid.MustParse("00000000-0000-0000-0000-000000000002")
+			c.Params = gin.Params{{Key: "setId", Value: setID.String()}}
+			c.Request = httptest.NewRequest(http.MethodGet, "/sets/"+setID.String()+"/audio", nil)
+		})
+
+		JustBeforeEach(func() {
+			api.GetSetAudio(c)
+		})
+
+		When("the set has tunes with and without music model", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().GetMusicSet(setID, (*uuid.UUID)(nil)).
+					Return(&apimodel.MusicSet{
+						Id:    setID,
+						Title: "Competition Set",
+						Tunes: []apimodel.Tune{{Id: tuneID}, {Id: tuneWithoutMusicID}, {Id: tuneID}},
+					}, nil)
+				dataService.EXPECT().GetTuneFile(tuneID, fileformat.Format_MUSIC_MODEL).
+					Return(muMoFile, nil).Times(2)
+				dataService.EXPECT().GetTuneFile(tuneWithoutMusicID, fileformat.Format_MUSIC_MODEL).
+					Return(nil, common.ErrNotFound)
+			})
+
+			It("should return the tunes with music model as one audio file", func() {
+				data, err := audio.Render([]*tune.Tune{muMoTune, muMoTune}, audio.Options{})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Header().Get("Content-Disposition")).
+					To(Equal("attachment; filename=Competition_Set.wav"))
+				Expect(httpRec.Body.Bytes()).To(Equal(data))
+			})
+		})
+
+		When("no tune of the set has a music model", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().GetMusicSet(setID, (*uuid.UUID)(nil)).
+					Return(&apimodel.MusicSet{
+						Id:    setID,
+						Title: "Competition Set",
+						Tunes: []apimodel.Tune{{Id: tuneWithoutMusicID}},
+					}, nil)
+				dataService.EXPECT().GetTuneFile(tuneWithoutMusicID, fileformat.Format_MUSIC_MODEL).
+					Return(nil, common.ErrNotFound)
+			})
+
+			It("should return NotFound", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("the set doesn't exist", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().GetMusicSet(setID, (*uuid.UUID)(nil)).
+					Return(nil, common.ErrNotFound)
+			})
+
+			It("should return NotFound", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("the setId is no uuid", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{{Key: "setId", Value: "not a uuid"}}
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+})
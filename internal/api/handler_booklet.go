@@ -0,0 +1,30 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/score"
+	"net/http"
+	"strconv"
+)
+
+// CreateBooklet engraves the sets and tunes of the request body in their
+// order as one PDF document with a title page and a table of contents.
+func (a *Handler) CreateBooklet(c *gin.Context) {
+	var create apimodel.CreateBooklet
+	if err := c.ShouldBindJSON(&create); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	s, err := a.booklets.Build(create, requestUserID(c))
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.Header(scorePagesHeader, strconv.Itoa(s.Pages()))
+	setAttachmentName(c, common.TitleFileName(create.Title, score.ExtensionPDF))
+	c.Data(http.StatusOK, score.ContentTypePDF, s.PDF())
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/booklet"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("Api Handler Booklet", func() {
+	var c *gin.Context
+	var httpRec *httptest.ResponseRecorder
+	var api *Handler
+	var dataService *mocks.DataService
+	var setID, tuneID uuid.UUID
+
+	BeforeEach(func() {
+		setID = uuid.MustParse("00000000-0000-0000-0000-0000000000bb")
+		tuneID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+		httpRec = httptest.NewRecorder()
+		c, _ = gin.CreateTestContext(httpRec)
+		dataService = mocks.NewDataService(GinkgoT())
+		api = &Handler{
+			service:  dataService,
+			booklets: booklet.NewBuilder(dataService),
+		}
+	})
+
+	JustBeforeEach(func() {
+		api.CreateBooklet(c)
+	})
+
+	When("the set has a tune with music model", func() {
+		BeforeEach(func() {
+			muMoFile, err := model.TuneFileFromMusicModelTune(model.TestParsedTune("Scotland the Brave").Tune)
+			Expect(err).ShouldNot(HaveOccurred())
+			mockJSONPost(c, http.MethodPost, apimodel.CreateBooklet{
+				Title:   "Band Practice",
+				Entries: []apimodel.BookletEntry{{SetId: &setID}},
+			})
+			dataService.EXPECT().GetMusicSet(setID, (*uuid.UUID)(nil)).
+				Return(&apimodel.MusicSet{Id: setID, Title: "Competition Set", Tunes: []apimodel.Tune{{Id: tuneID}}}, nil)
+			dataService.EXPECT().GetTune(tuneID).
+				Return(&apimodel.Tune{Id: tuneID, Title: "Scotland the Brave"}, nil)
+			dataService.EXPECT().GetTuneFile(tuneID, fileformat.Format_MUSIC_MODEL).
+				Return(muMoFile, nil)
+		})
+
+		It("should return the booklet as PDF attachment", func() {
+			Expect(httpRec.Code).To(Equal(http.StatusOK))
+			Expect(httpRec.Header().Get("Content-Type")).To(Equal("application/pdf"))
+			Expect(httpRec.Header().Get("Content-Disposition")).
+				To(Equal("attachment; filename=Band_Practice.pdf"))
+			Expect(httpRec.Header().Get("X-Score-Pages")).To(Equal("3"))
+			Expect(httpRec.Body.String()).To(HavePrefix("%PDF-"))
+		})
+	})
+
+	When("the set is not found", func() {
+		BeforeEach(func() {
+			mockJSONPost(c, http.MethodPost, apimodel.CreateBooklet{
+				Title:   "Band Practice",
+				Entries: []apimodel.BookletEntry{{SetId: &setID}},
+			})
+			dataService.EXPECT().GetMusicSet(setID, (*uuid.UUID)(nil)).
+				Return(&apimodel.MusicSet{}, common.ErrNotFound)
+		})
+
+		It("should return NotFound", func() {
+			Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	When("no entries are given", func() {
+		BeforeEach(func() {
+			mockJSONPost(c, http.MethodPost, apimodel.CreateBooklet{Title: "Band Practice"})
+		})
+
+		It("should return BadRequest", func() {
+			Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	When("an entry has a set and a tune", func() {
+		BeforeEach(func() {
+			mockJSONPost(c, http.MethodPost, apimodel.CreateBooklet{
+				Title:   "Band Practice",
+				Entries: []apimodel.BookletEntry{{SetId: &setID, TuneId: &tuneID}},
+			})
+		})
+
+		It("should return BadRequest", func() {
+			Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	When("an entry has neither a set nor a tune", func() {
+		BeforeEach(func() {
+			mockJSONPost(c, http.MethodPost, apimodel.CreateBooklet{
+				Title:   "Band Practice",
+				Entries: []apimodel.BookletEntry{{}},
+			})
+		})
+
+		It("should return BadRequest", func() {
+			Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+})
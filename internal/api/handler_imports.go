@@ -0,0 +1,301 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/archive"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/importjob"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const defaultEventPollInterval = 500 * time.Millisecond
+
+// ImportFile creates an import job for the uploaded file. The file is
+// imported in the background, so the response only contains the queued job.
+func (a *Handler) ImportFile(c *gin.Context) {
+	fInfo, err := a.uploadedImportFile(c)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	opts, err := formImportOptions(c)
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	_, err = a.service.GetImportFileByHash(fInfo.Hash)
+	if !errors.Is(err, common.ErrNotFound) {
+		c.JSON(http.StatusConflict,
+			fmt.Sprintf("file %s was already imported", fInfo.OriginalPath))
+		return
+	}
+
+	job := &model.ImportJob{
+		FileName:     fInfo.OriginalPath,
+		FileFormat:   fInfo.FileFormat,
+		Hash:         fInfo.Hash,
+		Data:         fInfo.Data,
+		SetPerFolder: opts.SetPerFolder,
+		Reimport:     opts.Reimport,
+		TuneMapping:  opts.TuneMapping,
+		OwnerID:      requestUserID(c),
+		AutoTag:      opts.AutoTag,
+	}
+	if err = a.importQueue.Enqueue(job); err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	apiJob, err := importjob.APIImportJob(job)
+	if err != nil {
+		httpErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Header("Location", "/imports/"+job.ID.String())
+	c.JSON(http.StatusAccepted, apiJob)
+}
+
+// uploadedImportFile reads the uploaded file of an import request.
+// The file is either a file of a format that a plugin can parse, or an archive.
+func (a *Handler) uploadedImportFile(c *gin.Context) (*common.ImportFileInfo, error) {
+	iFile, err := c.FormFile("file")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", common.ErrInvalidArgument, err.Error())
+	}
+
+	fType, err := a.importFileFormat(iFile.Filename)
+	if err != nil {
+		return nil, err
+	}
+
+	fileData, err := formFileData(c, "file")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", common.ErrInvalidArgument, err.Error())
+	}
+
+	fInfo, err := common.NewImportFileInfo(iFile.Filename, fType, fileData)
+	if err != nil {
+		return nil, err
+	}
+	return fInfo, nil
+}
+
+// importFileFormat returns the file format of an import file. Archives
+// don't have a file format, as they may contain files of different formats.
+func (a *Handler) importFileFormat(fileName string) (fileformat.Format, error) {
+	if archive.IsArchive(fileName) {
+		return fileformat.Format_Unknown, nil
+	}
+
+	fExt := filepath.Ext(fileName)
+	if fExt == "" {
+		return fileformat.Format_Unknown,
+			fmt.Errorf("%w: import file does not have an extension", common.ErrInvalidArgument)
+	}
+
+	fType, err := a.pluginLoader.FileFormatForFileExtension(fExt)
+	if err != nil {
+		return fileformat.Format_Unknown, fmt.Errorf("%w: file extension %s is currently not supported: %s",
+			common.ErrInvalidArgument, fExt, err.Error())
+	}
+
+	return fType, nil
+}
+
+// formImportOptions reads the options of an import from the form fields. The
+// tune mapping is a JSON object with tune titles as keys and tune ids as values.
+func formImportOptions(c *gin.Context) (importjob.Options, error) {
+	var opts importjob.Options
+	var err error
+	if opts.SetPerFolder, err = formBool(c, "setPerFolder"); err != nil {
+		return opts, err
+	}
+	if opts.Reimport, err = formBool(c, "reimport"); err != nil {
+		return opts, err
+	}
+	if opts.AutoTag, err = formBool(c, "autoTag"); err != nil {
+		return opts, err
+	}
+
+	tuneMapping := c.PostForm("tuneMapping")
+	if tuneMapping == "" {
+		return opts, nil
+	}
+	if !opts.Reimport {
+		return opts, fmt.Errorf("a tune mapping can only be given for a reimport")
+	}
+	if err = json.Unmarshal([]byte(tuneMapping), &opts.TuneMapping); err != nil {
+		return opts, fmt.Errorf("invalid tune mapping: %s", err.Error())
+	}
+
+	return opts, nil
+}
+
+func formBool(c *gin.Context, field string) (bool, error) {
+	value := c.PostForm(field)
+	if value == "" {
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid value '%s' for %s", value, field)
+	}
+
+	return b, nil
+}
+
+// ListImports returns the import history with the newest import first.
+func (a *Handler) ListImports(c *gin.Context) {
+	var listOpts common.ImportListOptions
+	if err := c.ShouldBindQuery(&listOpts); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	jobs, pagination, err := a.service.ImportJobs(listOpts, requestUserID(c))
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	importList := apimodel.ImportList{
+		Imports:    make([]apimodel.ImportJob, len(jobs)),
+		Pagination: *pagination,
+	}
+	for i, job := range jobs {
+		apiJob, err := importjob.APIImportJob(job)
+		if err != nil {
+			httpErrorResponse(c, http.StatusInternalServerError, err)
+			return
+		}
+		importList.Imports[i] = *apiJob
+	}
+
+	c.JSON(http.StatusOK, importList)
+}
+
+func (a *Handler) GetImport(c *gin.Context) {
+	apiJob, err := a.apiImportJob(c)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, apiJob)
+}
+
+// GetImportOriginal sends the uploaded file of an import as attachment.
+func (a *Handler) GetImportOriginal(c *gin.Context) {
+	job, err := a.importJob(c)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{
+		"filename": job.FileName,
+	})
+	c.Header("Content-Disposition", disposition)
+	c.Data(http.StatusOK, common.FileFormatContentType(job.FileFormat), job.Data)
+}
+
+// DeleteImport deletes an import with everything it created that is not
+// used by another import.
+func (a *Handler) DeleteImport(c *gin.Context) {
+	importID, err := uuid.Parse(c.Param("importId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err = a.service.DeleteImport(importID, requestUserID(c)); err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetImportEvents streams the state of an import job as server-sent events.
+// An event is sent whenever the job changed and the stream ends when
+// the job is finished.
+func (a *Handler) GetImportEvents(c *gin.Context) {
+	apiJob, err := a.apiImportJob(c)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	ticker := time.NewTicker(a.eventPollInterval)
+	defer ticker.Stop()
+
+	lastEvent := sendImportEvent(c, apiJob, nil)
+	for apiJob.FinishedAt == nil {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-ticker.C:
+		}
+
+		apiJob, err = a.apiImportJob(c)
+		if err != nil {
+			c.SSEvent("error", apimodel.Error{Message: err.Error()})
+			return
+		}
+		lastEvent = sendImportEvent(c, apiJob, lastEvent)
+	}
+}
+
+// sendImportEvent sends the job as event if it differs from
+// the last sent event and returns the sent event.
+func sendImportEvent(
+	c *gin.Context,
+	apiJob *apimodel.ImportJob,
+	lastEvent []byte,
+) []byte {
+	event, err := json.Marshal(apiJob)
+	if err != nil || bytes.Equal(event, lastEvent) {
+		return lastEvent
+	}
+
+	c.SSEvent("progress", string(event))
+	c.Writer.Flush()
+
+	return event
+}
+
+func (a *Handler) apiImportJob(c *gin.Context) (*apimodel.ImportJob, error) {
+	job, err := a.importJob(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return importjob.APIImportJob(job)
+}
+
+// importJob returns the import job of the route that is visible to the user
+// of the request.
+func (a *Handler) importJob(c *gin.Context) (*model.ImportJob, error) {
+	jobID, err := uuid.Parse(c.Param("importId"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", common.ErrInvalidArgument, err.Error())
+	}
+
+	return a.service.GetImportJob(jobID, requestUserID(c))
+}
@@ -0,0 +1,594 @@
+package api
+
+import (
+	"fmt"
+	"github.com/SamuelTissot/sqltime"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("Api Handler Imports", func() {
+	var c *gin.Context
+	var httpRec *httptest.ResponseRecorder
+	var api *Handler
+	var jobID uuid.UUID
+	var createdAt time.Time
+	var dataService *mocks.DataService
+	var pluginLoader *mocks.PluginLoader
+	var importQueue *mocks.ImportJobQueue
+
+	BeforeEach(func() {
+		jobID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+		createdAt = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+		httpRec = httptest.NewRecorder()
+		c, _ = gin.CreateTestContext(httpRec)
+		dataService = mocks.NewDataService(GinkgoT())
+		pluginLoader = mocks.NewPluginLoader(GinkgoT())
+		importQueue = mocks.NewImportJobQueue(GinkgoT())
+		api = &Handler{
+			service:           dataService,
+			pluginLoader:      pluginLoader,
+			importQueue:       importQueue,
+			eventPollInterval: time.Millisecond,
+		}
+	})
+
+	Context("ImportFile", func() {
+		JustBeforeEach(func() {
+			api.ImportFile(c)
+		})
+
+		BeforeEach(func() {
+			c.Request = multipartRequestForFile(multipartRequest{
+				Fieldname:  "file",
+				Filename:   "test.bww",
+				Content:    []byte("test file content"),
+				Endpoint:   "/imports",
+				HTTPMethod: http.MethodPost,
+			})
+		})
+
+		When("fieldname is wrong", func() {
+			BeforeEach(func() {
+				c.Request = multipartRequestForFile(multipartRequest{
+					Fieldname:  "wrongfieldname",
+					Filename:   "test.bww",
+					Content:    []byte("test file content"),
+					Endpoint:   "/imports",
+					HTTPMethod: http.MethodPost,
+				})
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("file has no extension", func() {
+			BeforeEach(func() {
+				c.Request = multipartRequestForFile(multipartRequest{
+					Fieldname:  "file",
+					Filename:   "test",
+					Content:    []byte("test file content"),
+					Endpoint:   "/imports",
+					HTTPMethod: http.MethodPost,
+				})
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("file extension is not supported", func() {
+			BeforeEach(func() {
+				c.Request = multipartRequestForFile(multipartRequest{
+					Fieldname:  "file",
+					Filename:   "test.abc",
+					Content:    []byte("test file content"),
+					Endpoint:   "/imports",
+					HTTPMethod: http.MethodPost,
+				})
+				pluginLoader.EXPECT().FileFormatForFileExtension(".abc").
+					Return(fileformat.Format_Unknown, fmt.Errorf("file extension .abc is not supported"))
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the value for setPerFolder is not a boolean", func() {
+			BeforeEach(func() {
+				c.Request = multipartRequestForFile(multipartRequest{
+					Fieldname:  "file",
+					Filename:   "tunes.zip",
+					Content:    []byte("zip content"),
+					Endpoint:   "/imports",
+					HTTPMethod: http.MethodPost,
+					Fields:     map[string]string{"setPerFolder": "maybe"},
+				})
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("a tune mapping is given without reimport", func() {
+			BeforeEach(func() {
+				c.Request = multipartRequestForFile(multipartRequest{
+					Fieldname:  "file",
+					Filename:   "test.bww",
+					Content:    []byte("test file content"),
+					Endpoint:   "/imports",
+					HTTPMethod: http.MethodPost,
+					Fields: map[string]string{
+						"tuneMapping": `{"Scotland the Brave":"00000000-0000-0000-0000-000000000002"}`,
+					},
+				})
+				pluginLoader.EXPECT().FileFormatForFileExtension(".bww").
+					Return(fileformat.Format_BWW, nil)
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the tune mapping is invalid", func() {
+			BeforeEach(func() {
+				c.Request = multipartRequestForFile(multipartRequest{
+					Fieldname:  "file",
+					Filename:   "test.bww",
+					Content:    []byte("test file content"),
+					Endpoint:   "/imports",
+					HTTPMethod: http.MethodPost,
+					Fields: map[string]string{
+						"reimport":    "true",
+						"tuneMapping": `{"Scotland the Brave":"not a uuid"}`,
+					},
+				})
+				pluginLoader.EXPECT().FileFormatForFileExtension(".bww").
+					Return(fileformat.Format_BWW, nil)
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("a file is reimported with a tune mapping", func() {
+			BeforeEach(func() {
+				c.Request = multipartRequestForFile(multipartRequest{
+					Fieldname:  "file",
+					Filename:   "test.bww",
+					Content:    []byte("test file content"),
+					Endpoint:   "/imports",
+					HTTPMethod: http.MethodPost,
+					Fields: map[string]string{
+						"reimport":    "true",
+						"tuneMapping": `{"Scotland the Brave":"00000000-0000-0000-0000-000000000002"}`,
+					},
+				})
+				pluginLoader.EXPECT().FileFormatForFileExtension(".bww").
+					Return(fileformat.Format_BWW, nil)
+				dataService.EXPECT().GetImportFileByHash(mock.Anything).
+					Return(nil, common.ErrNotFound)
+				importQueue.EXPECT().Enqueue(mock.Anything).
+					RunAndReturn(func(job *model.ImportJob) error {
+						Expect(job.Reimport).To(BeTrue())
+						Expect(job.TuneMapping).To(Equal(map[string]uuid.UUID{
+							"Scotland the Brave": uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+						}))
+						job.ID = jobID
+						return nil
+					})
+			})
+
+			It("should return Accepted", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusAccepted))
+			})
+		})
+
+		When("an archive is uploaded", func() {
+			BeforeEach(func() {
+				c.Request = multipartRequestForFile(multipartRequest{
+					Fieldname:  "file",
+					Filename:   "tunes.tar.gz",
+					Content:    []byte("archive content"),
+					Endpoint:   "/imports",
+					HTTPMethod: http.MethodPost,
+					Fields:     map[string]string{"setPerFolder": "true"},
+				})
+				dataService.EXPECT().GetImportFileByHash(mock.Anything).
+					Return(nil, common.ErrNotFound)
+				importQueue.EXPECT().Enqueue(mock.Anything).
+					RunAndReturn(func(job *model.ImportJob) error {
+						Expect(job.FileName).To(Equal("tunes.tar.gz"))
+						Expect(job.FileFormat).To(Equal(fileformat.Format_Unknown))
+						Expect(job.SetPerFolder).To(BeTrue())
+						job.ID = jobID
+						return nil
+					})
+			})
+
+			It("should enqueue the archive without checking its file format", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusAccepted))
+			})
+		})
+
+		When("file was already imported", func() {
+			BeforeEach(func() {
+				pluginLoader.EXPECT().FileFormatForFileExtension(".bww").
+					Return(fileformat.Format_BWW, nil)
+				dataService.EXPECT().GetImportFileByHash("60f5237ed4049f0382661ef009d2bc42e48c3ceb3edb6600f7024e7ab3b838f3").
+					Return(nil, nil)
+			})
+
+			It("should return a http conflict", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusConflict))
+			})
+		})
+
+		When("enqueuing the import job fails", func() {
+			BeforeEach(func() {
+				pluginLoader.EXPECT().FileFormatForFileExtension(".bww").
+					Return(fileformat.Format_BWW, nil)
+				dataService.EXPECT().GetImportFileByHash("60f5237ed4049f0382661ef009d2bc42e48c3ceb3edb6600f7024e7ab3b838f3").
+					Return(nil, common.ErrNotFound)
+				importQueue.EXPECT().Enqueue(mock.Anything).
+					Return(fmt.Errorf("database down"))
+			})
+
+			It("should return InternalServerError", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		When("the import job was enqueued", func() {
+			BeforeEach(func() {
+				pluginLoader.EXPECT().FileFormatForFileExtension(".bww").
+					Return(fileformat.Format_BWW, nil)
+				dataService.EXPECT().GetImportFileByHash("60f5237ed4049f0382661ef009d2bc42e48c3ceb3edb6600f7024e7ab3b838f3").
+					Return(nil, common.ErrNotFound)
+				importQueue.EXPECT().Enqueue(mock.Anything).
+					RunAndReturn(func(job *model.ImportJob) error {
+						Expect(job.FileName).To(Equal("test.bww"))
+						Expect(job.FileFormat).To(Equal(fileformat.Format_BWW))
+						Expect(job.Data).To(Equal([]byte("test file content")))
+						job.ID = jobID
+						job.Status = model.ImportJobStatusQueued
+						job.CreatedAt = sqltime.Time{Time: createdAt}
+						return nil
+					})
+			})
+
+			It("should return Accepted with the queued job", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusAccepted))
+				Expect(httpRec.Header().Get("Location")).
+					To(Equal("/imports/00000000-0000-0000-0000-000000000001"))
+				Expect(httpRec.Body.String()).To(Equal(
+					`{"id":"00000000-0000-0000-0000-000000000001","status":"queued","fileName":"test.bww",` +
+						`"progress":{"total":0,"done":0},"createdAt":"2024-05-01T10:00:00Z"}`))
+			})
+		})
+	})
+
+	Context("GetImport", func() {
+		JustBeforeEach(func() {
+			api.GetImport(c)
+		})
+
+		BeforeEach(func() {
+			c.Params = gin.Params{{Key: "importId", Value: jobID.String()}}
+		})
+
+		When("no uuid as importId", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{{Key: "importId", Value: "not a uuid"}}
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the import job doesn't exist", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().GetImportJob(jobID, (*uuid.UUID)(nil)).
+					Return(nil, common.ErrNotFound)
+			})
+
+			It("should return NotFound", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("the import job is completed", func() {
+			BeforeEach(func() {
+				finishedAt := sqltime.Time{Time: createdAt.Add(time.Second)}
+				job := &model.ImportJob{
+					Status:     model.ImportJobStatusCompleted,
+					FileName:   "test.bww",
+					FilesTotal: 1,
+					FilesDone:  1,
+					Report:     []byte(`{"files":[{"name":"test.bww","error":"failed parsing file test.bww: xxx"}]}`),
+					FinishedAt: &finishedAt,
+				}
+				job.ID = jobID
+				job.CreatedAt = sqltime.Time{Time: createdAt}
+				dataService.EXPECT().GetImportJob(jobID, (*uuid.UUID)(nil)).Return(job, nil)
+			})
+
+			It("should return the job with its report", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Body.String()).To(Equal(
+					`{"id":"00000000-0000-0000-0000-000000000001","status":"completed","fileName":"test.bww",` +
+						`"progress":{"total":1,"done":1},` +
+						`"files":[{"name":"test.bww","error":"failed parsing file test.bww: xxx"}],` +
+						`"createdAt":"2024-05-01T10:00:00Z","finishedAt":"2024-05-01T10:00:01Z"}`))
+			})
+		})
+	})
+
+	Context("ListImports", func() {
+		JustBeforeEach(func() {
+			api.ListImports(c)
+		})
+
+		BeforeEach(func() {
+			c.Request = httptest.NewRequest(http.MethodGet, "/imports?page=2&pageSize=1", nil)
+		})
+
+		When("the page size is too big", func() {
+			BeforeEach(func() {
+				c.Request = httptest.NewRequest(http.MethodGet, "/imports?pageSize=1000", nil)
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("listing the imports fails", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().ImportJobs(common.ImportListOptions{PageOptions: common.PageOptions{Page: 2, PageSize: 1}}, (*uuid.UUID)(nil)).
+					Return(nil, nil, fmt.Errorf("failed"))
+			})
+
+			It("should return InternalServerError", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		When("there are imports", func() {
+			BeforeEach(func() {
+				job := &model.ImportJob{
+					Status:   model.ImportJobStatusQueued,
+					FileName: "test.bww",
+				}
+				job.ID = jobID
+				job.CreatedAt = sqltime.Time{Time: createdAt}
+				dataService.EXPECT().ImportJobs(common.ImportListOptions{PageOptions: common.PageOptions{Page: 2, PageSize: 1}}, (*uuid.UUID)(nil)).
+					Return([]*model.ImportJob{job}, &apimodel.Pagination{
+						Page:       2,
+						PageSize:   1,
+						TotalCount: 2,
+						TotalPages: 2,
+					}, nil)
+			})
+
+			It("should return the page of imports", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Body.String()).To(Equal(
+					`{"imports":[{"id":"00000000-0000-0000-0000-000000000001","status":"queued",` +
+						`"fileName":"test.bww","progress":{"total":0,"done":0},"createdAt":"2024-05-01T10:00:00Z"}],` +
+						`"pagination":{"page":2,"pageSize":1,"totalCount":2,"totalPages":2}}`))
+			})
+		})
+	})
+
+	Context("GetImportOriginal", func() {
+		JustBeforeEach(func() {
+			api.GetImportOriginal(c)
+		})
+
+		BeforeEach(func() {
+			c.Params = gin.Params{{Key: "importId", Value: jobID.String()}}
+		})
+
+		When("no uuid as importId", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{{Key: "importId", Value: "not a uuid"}}
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the import doesn't exist", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().GetImportJob(jobID, (*uuid.UUID)(nil)).
+					Return(nil, common.ErrNotFound)
+			})
+
+			It("should return NotFound", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("the import is a bww file", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().GetImportJob(jobID, (*uuid.UUID)(nil)).Return(&model.ImportJob{
+					FileName:   "Scotland the Brave.bww",
+					FileFormat: fileformat.Format_BWW,
+					Data:       []byte("bww data"),
+				}, nil)
+			})
+
+			It("should return the original file as attachment", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Header().Get("Content-Type")).To(Equal("text/plain; charset=utf-8"))
+				Expect(httpRec.Header().Get("Content-Disposition")).
+					To(Equal(`attachment; filename="Scotland the Brave.bww"`))
+				Expect(httpRec.Body.String()).To(Equal("bww data"))
+			})
+		})
+
+		When("the import is an archive", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().GetImportJob(jobID, (*uuid.UUID)(nil)).Return(&model.ImportJob{
+					FileName: "tunes.zip",
+					Data:     []byte("zip data"),
+				}, nil)
+			})
+
+			It("should return the archive as binary attachment", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Header().Get("Content-Type")).To(Equal("application/octet-stream"))
+				Expect(httpRec.Header().Get("Content-Disposition")).
+					To(Equal("attachment; filename=tunes.zip"))
+			})
+		})
+	})
+
+	Context("DeleteImport", func() {
+		JustBeforeEach(func() {
+			api.DeleteImport(c)
+		})
+
+		BeforeEach(func() {
+			c.Params = gin.Params{{Key: "importId", Value: jobID.String()}}
+		})
+
+		When("no uuid as importId", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{{Key: "importId", Value: "not a uuid"}}
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the import doesn't exist", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().DeleteImport(jobID, (*uuid.UUID)(nil)).Return(common.ErrNotFound)
+			})
+
+			It("should return NotFound", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("the import is not finished yet", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().DeleteImport(jobID, (*uuid.UUID)(nil)).
+					Return(fmt.Errorf("%w: not finished", common.ErrInvalidArgument))
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the import was deleted", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().DeleteImport(jobID, (*uuid.UUID)(nil)).Return(nil)
+			})
+
+			It("should return NoContent", func() {
+				Expect(c.Writer.Status()).To(Equal(http.StatusNoContent))
+			})
+		})
+	})
+
+	Context("GetImportEvents", func() {
+		var runningJob *model.ImportJob
+		var completedJob *model.ImportJob
+
+		JustBeforeEach(func() {
+			api.GetImportEvents(c)
+		})
+
+		BeforeEach(func() {
+			c.Params = gin.Params{{Key: "importId", Value: jobID.String()}}
+			c.Request = httptest.NewRequest(http.MethodGet, "/imports/"+jobID.String()+"/events", nil)
+
+			runningJob = &model.ImportJob{
+				Status:     model.ImportJobStatusRunning,
+				FileName:   "test.bww",
+				FilesTotal: 1,
+			}
+			runningJob.ID = jobID
+			runningJob.CreatedAt = sqltime.Time{Time: createdAt}
+
+			finishedAt := sqltime.Time{Time: createdAt.Add(time.Second)}
+			completedJob = &model.ImportJob{
+				Status:     model.ImportJobStatusCompleted,
+				FileName:   "test.bww",
+				FilesTotal: 1,
+				FilesDone:  1,
+				FinishedAt: &finishedAt,
+			}
+			completedJob.ID = jobID
+			completedJob.CreatedAt = sqltime.Time{Time: createdAt}
+		})
+
+		When("the import job doesn't exist", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().GetImportJob(jobID, (*uuid.UUID)(nil)).
+					Return(nil, common.ErrNotFound)
+			})
+
+			It("should return NotFound", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("the import job is already finished", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().GetImportJob(jobID, (*uuid.UUID)(nil)).Return(completedJob, nil)
+			})
+
+			It("should send a single event", func() {
+				Expect(httpRec.Header().Get("Content-Type")).To(Equal("text/event-stream"))
+				Expect(httpRec.Body.String()).To(Equal(
+					"event:progress\n" +
+						`data:{"id":"00000000-0000-0000-0000-000000000001","status":"completed","fileName":"test.bww",` +
+						`"progress":{"total":1,"done":1},"createdAt":"2024-05-01T10:00:00Z","finishedAt":"2024-05-01T10:00:01Z"}` +
+						"\n\n"))
+			})
+		})
+
+		When("the import job finishes while streaming", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().GetImportJob(jobID, (*uuid.UUID)(nil)).Return(runningJob, nil).Times(2)
+				dataService.EXPECT().GetImportJob(jobID, (*uuid.UUID)(nil)).Return(completedJob, nil).Once()
+			})
+
+			It("should only send an event when the job changed", func() {
+				body := httpRec.Body.String()
+				Expect(body).To(ContainSubstring(`"status":"running"`))
+				Expect(body).To(ContainSubstring(`"status":"completed"`))
+				Expect(body).To(HavePrefix("event:progress\n"))
+				Expect(body).To(HaveSuffix("\n\n"))
+				Expect(body).To(MatchRegexp(`^(event:progress\ndata:[^\n]+\n\n){2}$`))
+			})
+		})
+	})
+})
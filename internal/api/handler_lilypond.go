@@ -0,0 +1,28 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/lilypond"
+	"net/http"
+)
+
+// exportLilyPond writes the music model of the tune as LilyPond source.
+// There is no plugin for LilyPond, so the source is not stored with the tune.
+func (a *Handler) exportLilyPond(c *gin.Context, tuneID uuid.UUID) {
+	apiTune, err := a.service.GetTune(tuneID)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	muMoTune, err := a.musicModelTune(tuneID)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	setAttachmentName(c, common.TitleFileName(apiTune.Title, lilypond.Extension))
+	c.Data(http.StatusOK, lilypond.ContentType, lilypond.Write(muMoTune))
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/auth"
+	"github.com/tomvodi/limepipes/internal/common"
+	"net/http"
+)
+
+func (a *Handler) ListMemberships(c *gin.Context) {
+	memberships, err := a.service.Memberships()
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	apiMemberships := make([]apimodel.Membership, len(memberships))
+	for i, m := range memberships {
+		apiMemberships[i] = auth.APIMembership(m)
+	}
+
+	c.JSON(http.StatusOK, apiMemberships)
+}
+
+// SetMembership gives the user the role of the request body. A user who
+// has no membership yet becomes a member.
+func (a *Handler) SetMembership(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	var update apimodel.UpdateMembership
+	if err = c.ShouldBindJSON(&update); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	membership, err := a.service.SetMembership(userID, common.Role(update.Role))
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, auth.APIMembership(membership))
+}
+
+func (a *Handler) DeleteMembership(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err = a.service.DeleteMembership(userID); err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"fmt"
+	"github.com/SamuelTissot/sqltime"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("Api Handler Memberships", func() {
+	var c *gin.Context
+	var httpRec *httptest.ResponseRecorder
+	var api *Handler
+	var dataService *mocks.DataService
+	var userID uuid.UUID
+	var membership *model.Membership
+
+	BeforeEach(func() {
+		userID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+		changedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+		membership = &model.Membership{
+			BaseModel: model.BaseModel{
+				CreatedAt: sqltime.Time{Time: changedAt},
+				UpdatedAt: sqltime.Time{Time: changedAt},
+			},
+			UserID: userID,
+			User:   model.User{Name: "learner"},
+			Role:   common.RoleViewer,
+		}
+
+		httpRec = httptest.NewRecorder()
+		c, _ = gin.CreateTestContext(httpRec)
+		dataService = mocks.NewDataService(GinkgoT())
+		api = &Handler{
+			service: dataService,
+		}
+	})
+
+	Context("List Memberships", func() {
+		JustBeforeEach(func() {
+			api.ListMemberships(c)
+		})
+
+		When("there is a member", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().Memberships().Return([]*model.Membership{membership}, nil)
+			})
+
+			It("should return the membership", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Body.String()).To(Equal(
+					`[{"userId":"00000000-0000-0000-0000-000000000001","userName":"learner",` +
+						`"role":"viewer","createdAt":"2024-05-01T10:00:00Z","updatedAt":"2024-05-01T10:00:00Z"}]`))
+			})
+		})
+	})
+
+	Context("Set Membership", func() {
+		BeforeEach(func() {
+			c.Params = gin.Params{{Key: "userId", Value: userID.String()}}
+		})
+
+		JustBeforeEach(func() {
+			api.SetMembership(c)
+		})
+
+		When("no uuid as userId", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{{Key: "userId", Value: "not a uuid"}}
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the role is unknown", func() {
+			BeforeEach(func() {
+				mockJSONPost(c, http.MethodPut, apimodel.UpdateMembership{Role: "drum major"})
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the user doesn't exist", func() {
+			BeforeEach(func() {
+				mockJSONPost(c, http.MethodPut, apimodel.UpdateMembership{Role: "viewer"})
+				dataService.EXPECT().SetMembership(userID, common.RoleViewer).
+					Return(nil, fmt.Errorf("%w: user", common.ErrNotFound))
+			})
+
+			It("should return NotFound", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("the user is the last admin", func() {
+			BeforeEach(func() {
+				mockJSONPost(c, http.MethodPut, apimodel.UpdateMembership{Role: "viewer"})
+				dataService.EXPECT().SetMembership(userID, common.RoleViewer).
+					Return(nil, fmt.Errorf("%w: last admin", common.ErrInvalidArgument))
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the role was set", func() {
+			BeforeEach(func() {
+				mockJSONPost(c, http.MethodPut, apimodel.UpdateMembership{Role: "viewer"})
+				dataService.EXPECT().SetMembership(userID, common.RoleViewer).
+					Return(membership, nil)
+			})
+
+			It("should return the membership", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Body.String()).To(ContainSubstring(`"role":"viewer"`))
+			})
+		})
+	})
+
+	Context("Delete Membership", func() {
+		BeforeEach(func() {
+			c.Params = gin.Params{{Key: "userId", Value: userID.String()}}
+		})
+
+		JustBeforeEach(func() {
+			api.DeleteMembership(c)
+		})
+
+		When("the user has no membership", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().DeleteMembership(userID).Return(common.ErrNotFound)
+			})
+
+			It("should return NotFound", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("the membership was deleted", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().DeleteMembership(userID).Return(nil)
+			})
+
+			It("should return NoContent", func() {
+				Expect(c.Writer.Status()).To(Equal(http.StatusNoContent))
+			})
+		})
+	})
+})
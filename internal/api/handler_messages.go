@@ -0,0 +1,97 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"net/http"
+)
+
+// ListMessages lists the parser messages of all tunes, filtered
+// e.g. by their severity to find the tunes that failed to import cleanly.
+func (a *Handler) ListMessages(c *gin.Context) {
+	var listOpts common.MessageListOptions
+	if err := c.ShouldBindQuery(&listOpts); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	messages, err := a.service.Messages(listOpts)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, messages)
+}
+
+// UpdateMessage marks a parser message as reviewed or as not reviewed.
+func (a *Handler) UpdateMessage(c *gin.Context) {
+	var update apimodel.UpdateTuneMessage
+	if err := c.ShouldBindJSON(&update); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	messageID, err := uuid.Parse(c.Param("messageId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	message, err := a.service.UpdateMessage(messageID, update, requestAuthor(c))
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, message)
+}
+
+// ListTuneMessages lists the parser messages of the tune.
+func (a *Handler) ListTuneMessages(c *gin.Context) {
+	var listOpts common.MessageListOptions
+	if err := c.ShouldBindQuery(&listOpts); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tuneID, err := uuid.Parse(c.Param("tuneId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	messages, err := a.service.TuneMessages(tuneID, listOpts)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, messages)
+}
+
+// UpdateTuneMessages marks all parser messages of the tune
+// as reviewed or as not reviewed.
+func (a *Handler) UpdateTuneMessages(c *gin.Context) {
+	var update apimodel.UpdateTuneMessage
+	if err := c.ShouldBindJSON(&update); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tuneID, err := uuid.Parse(c.Param("tuneId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	messages, err := a.service.UpdateTuneMessages(tuneID, update, requestAuthor(c))
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, messages)
+}
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("Api Handler Messages", func() {
+	var c *gin.Context
+	var httpRec *httptest.ResponseRecorder
+	var api *Handler
+	var dataService *mocks.DataService
+	var tuneID uuid.UUID
+	var messageID uuid.UUID
+	var message apimodel.TuneMessage
+	var reviewed = true
+
+	BeforeEach(func() {
+		tuneID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+		messageID = uuid.MustParse("00000000-0000-0000-0000-000000000002")
+		message = apimodel.TuneMessage{
+			Id:        messageID,
+			TuneId:    tuneID,
+			TuneTitle: "Scotland the Brave",
+			Measure:   3,
+			Severity:  "error",
+			Text:      "unknown note",
+		}
+
+		httpRec = httptest.NewRecorder()
+		c, _ = gin.CreateTestContext(httpRec)
+		dataService = mocks.NewDataService(GinkgoT())
+		api = &Handler{
+			service: dataService,
+		}
+	})
+
+	Context("List Messages", func() {
+		JustBeforeEach(func() {
+			api.ListMessages(c)
+		})
+
+		When("listing the errors that aren't reviewed", func() {
+			BeforeEach(func() {
+				c.Request = httptest.NewRequest(http.MethodGet, "/messages?severity=error&reviewed=false", nil)
+				notReviewed := false
+				dataService.EXPECT().Messages(common.MessageListOptions{Severity: "error", Reviewed: &notReviewed}).
+					Return(&apimodel.TuneMessageList{
+						Messages:   []apimodel.TuneMessage{message},
+						Pagination: apimodel.Pagination{Page: 1, PageSize: 50, TotalCount: 1, TotalPages: 1},
+					}, nil)
+			})
+
+			It("should return the messages", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Body.String()).To(Equal(
+					`{"messages":[{"id":"00000000-0000-0000-0000-000000000002",` +
+						`"tuneId":"00000000-0000-0000-0000-000000000001","tuneTitle":"Scotland the Brave",` +
+						`"measure":3,"severity":"error","text":"unknown note","reviewed":false}],` +
+						`"pagination":{"page":1,"pageSize":50,"totalCount":1,"totalPages":1}}`))
+			})
+		})
+
+		When("the severity is unknown", func() {
+			BeforeEach(func() {
+				c.Request = httptest.NewRequest(http.MethodGet, "/messages?severity=fatal", nil)
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	Context("List Tune Messages", func() {
+		JustBeforeEach(func() {
+			api.ListTuneMessages(c)
+		})
+
+		When("the tune has messages", func() {
+			BeforeEach(func() {
+				c.Request = httptest.NewRequest(http.MethodGet, "/tunes/"+tuneID.String()+"/messages", nil)
+				c.Params = gin.Params{{Key: "tuneId", Value: tuneID.String()}}
+				dataService.EXPECT().TuneMessages(tuneID, common.MessageListOptions{}).
+					Return([]apimodel.TuneMessage{message}, nil)
+			})
+
+			It("should return the messages", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Body.String()).To(ContainSubstring(`"text":"unknown note"`))
+			})
+		})
+
+		When("the tune doesn't exist", func() {
+			BeforeEach(func() {
+				c.Request = httptest.NewRequest(http.MethodGet, "/tunes/"+tuneID.String()+"/messages", nil)
+				c.Params = gin.Params{{Key: "tuneId", Value: tuneID.String()}}
+				dataService.EXPECT().TuneMessages(tuneID, common.MessageListOptions{}).
+					Return(nil, common.ErrNotFound)
+			})
+
+			It("should return NotFound", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Context("Update Message", func() {
+		JustBeforeEach(func() {
+			api.UpdateMessage(c)
+		})
+
+		When("marking a message as reviewed", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{{Key: "messageId", Value: messageID.String()}}
+				update := apimodel.UpdateTuneMessage{Reviewed: &reviewed}
+				mockJSONPost(c, http.MethodPut, update)
+				reviewedMessage := message
+				reviewedMessage.Reviewed = true
+				dataService.EXPECT().UpdateMessage(messageID, update, "").Return(&reviewedMessage, nil)
+			})
+
+			It("should return the reviewed message", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Body.String()).To(ContainSubstring(`"reviewed":true`))
+			})
+		})
+
+		When("the reviewed state is missing", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{{Key: "messageId", Value: messageID.String()}}
+				mockJSONPost(c, http.MethodPut, map[string]string{})
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the message doesn't exist", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{{Key: "messageId", Value: messageID.String()}}
+				update := apimodel.UpdateTuneMessage{Reviewed: &reviewed}
+				mockJSONPost(c, http.MethodPut, update)
+				dataService.EXPECT().UpdateMessage(messageID, update, "").Return(nil, common.ErrNotFound)
+			})
+
+			It("should return NotFound", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Context("Update Tune Messages", func() {
+		JustBeforeEach(func() {
+			api.UpdateTuneMessages(c)
+		})
+
+		When("marking all messages of a tune as reviewed", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{{Key: "tuneId", Value: tuneID.String()}}
+				update := apimodel.UpdateTuneMessage{Reviewed: &reviewed}
+				mockJSONPost(c, http.MethodPut, update)
+				dataService.EXPECT().UpdateTuneMessages(tuneID, update, "").
+					Return([]apimodel.TuneMessage{message}, nil)
+			})
+
+			It("should return the messages of the tune", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Body.String()).To(ContainSubstring(`"id":"00000000-0000-0000-0000-000000000002"`))
+			})
+		})
+
+		When("the tuneId is no uuid", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{{Key: "tuneId", Value: "not a uuid"}}
+				mockJSONPost(c, http.MethodPut, apimodel.UpdateTuneMessage{Reviewed: &reviewed})
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+})
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/midi"
+	"net/http"
+)
+
+// GetTuneMidi renders the music model of the tune as MIDI file.
+func (a *Handler) GetTuneMidi(c *gin.Context) {
+	var midiOpts common.MidiOptions
+	if err := c.ShouldBindQuery(&midiOpts); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tuneID, err := uuid.Parse(c.Param("tuneId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	apiTune, err := a.service.GetTune(tuneID)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	muMoTune, err := a.musicModelTune(tuneID)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	setAttachmentName(c, common.TitleFileName(apiTune.Title, midi.Extension))
+	c.Data(http.StatusOK, midi.ContentType, midi.Render(muMoTune, midiOpts.Tempo))
+}
+
+// musicModelTune returns the decoded music model of the tune.
+func (a *Handler) musicModelTune(tuneID uuid.UUID) (*tune.Tune, error) {
+	muMoFile, err := a.service.GetTuneFile(tuneID, fileformat.Format_MUSIC_MODEL)
+	if err != nil {
+		return nil, err
+	}
+
+	return muMoFile.MusicModelTune()
+}
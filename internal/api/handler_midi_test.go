@@ -0,0 +1,110 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"github.com/tomvodi/limepipes/internal/midi"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("Api Handler Midi", func() {
+	var c *gin.Context
+	var httpRec *httptest.ResponseRecorder
+	var api *Handler
+	var dataService *mocks.DataService
+	var tuneID uuid.UUID
+	var muMoFile *model.TuneFile
+
+	BeforeEach(func() {
+		var err error
+		tuneID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+		muMoFile, err = model.TuneFileFromMusicModelTune(model.TestParsedTune("Scotland the Brave").Tune)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		httpRec = httptest.NewRecorder()
+		c, _ = gin.CreateTestContext(httpRec)
+		c.Params = gin.Params{{Key: "tuneId", Value: tuneID.String()}}
+		c.Request = httptest.NewRequest(http.MethodGet, "/tunes/"+tuneID.String()+"/midi", nil)
+		dataService = mocks.NewDataService(GinkgoT())
+		api = &Handler{
+			service: dataService,
+		}
+	})
+
+	JustBeforeEach(func() {
+		api.GetTuneMidi(c)
+	})
+
+	When("the tune has a music model", func() {
+		BeforeEach(func() {
+			dataService.EXPECT().GetTune(tuneID).
+				Return(&apimodel.Tune{Id: tuneID, Title: "Scotland the Brave"}, nil)
+			dataService.EXPECT().GetTuneFile(tuneID, fileformat.Format_MUSIC_MODEL).
+				Return(muMoFile, nil)
+		})
+
+		It("should return the MIDI file as attachment", func() {
+			muMoTune, err := muMoFile.MusicModelTune()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(httpRec.Code).To(Equal(http.StatusOK))
+			Expect(httpRec.Header().Get("Content-Type")).To(Equal("audio/midi"))
+			Expect(httpRec.Header().Get("Content-Disposition")).
+				To(Equal("attachment; filename=Scotland_the_Brave.mid"))
+			Expect(httpRec.Body.Bytes()).To(Equal(midi.Render(muMoTune, 0)))
+		})
+
+		When("a tempo is given", func() {
+			BeforeEach(func() {
+				c.Request = httptest.NewRequest(http.MethodGet, "/tunes/"+tuneID.String()+"/midi?tempo=100", nil)
+			})
+
+			It("should render the tune in that tempo", func() {
+				muMoTune, err := muMoFile.MusicModelTune()
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Body.Bytes()).To(Equal(midi.Render(muMoTune, 100)))
+			})
+		})
+	})
+
+	When("the tempo is too slow", func() {
+		BeforeEach(func() {
+			c.Request = httptest.NewRequest(http.MethodGet, "/tunes/"+tuneID.String()+"/midi?tempo=5", nil)
+		})
+
+		It("should return BadRequest", func() {
+			Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	When("the tune has no music model", func() {
+		BeforeEach(func() {
+			dataService.EXPECT().GetTune(tuneID).
+				Return(&apimodel.Tune{Id: tuneID, Title: "Scotland the Brave"}, nil)
+			dataService.EXPECT().GetTuneFile(tuneID, fileformat.Format_MUSIC_MODEL).
+				Return(nil, common.ErrNotFound)
+		})
+
+		It("should return NotFound", func() {
+			Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	When("the tuneId is no uuid", func() {
+		BeforeEach(func() {
+			c.Params = gin.Params{{Key: "tuneId", Value: "not a uuid"}}
+		})
+
+		It("should return BadRequest", func() {
+			Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+})
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"net/http"
+)
+
+func (a *Handler) ListPeople(c *gin.Context) {
+	people, err := a.service.People()
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, people)
+}
+
+func (a *Handler) GetPerson(c *gin.Context) {
+	personID, err := uuid.Parse(c.Param("personId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	person, err := a.service.GetPerson(personID)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, person)
+}
+
+// ListPersonTunes lists the tunes the person composed or arranged. They can be
+// filtered, sorted and paginated with the same query parameters as all tunes.
+func (a *Handler) ListPersonTunes(c *gin.Context) {
+	var listOpts common.TuneListOptions
+	if err := c.ShouldBindQuery(&listOpts); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	personID, err := uuid.Parse(c.Param("personId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tunes, err := a.service.PersonTunes(personID, listOpts)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tunes)
+}
+
+func (a *Handler) UpdatePerson(c *gin.Context) {
+	var updatePerson apimodel.UpdatePerson
+	if err := c.ShouldBindJSON(&updatePerson); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	personID, err := uuid.Parse(c.Param("personId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	person, err := a.service.UpdatePerson(personID, updatePerson)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, person)
+}
+
+// MergePeople merges the people of the request body into
+// the person of the path.
+func (a *Handler) MergePeople(c *gin.Context) {
+	var merge apimodel.MergePeople
+	if err := c.ShouldBindJSON(&merge); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	personID, err := uuid.Parse(c.Param("personId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	person, err := a.service.MergePeople(personID, merge)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, person)
+}
+
+// SetPersonAliases replaces the aliases of the person with
+// the names in the request body.
+func (a *Handler) SetPersonAliases(c *gin.Context) {
+	var aliases []string
+	if err := c.ShouldBindJSON(&aliases); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	personID, err := uuid.Parse(c.Param("personId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	person, err := a.service.SetPersonAliases(personID, aliases)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, person)
+}
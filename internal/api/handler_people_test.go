@@ -0,0 +1,191 @@
+package api
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("Api Handler People", func() {
+	var c *gin.Context
+	var httpRec *httptest.ResponseRecorder
+	var api *Handler
+	var dataService *mocks.DataService
+	var person *apimodel.Person
+
+	BeforeEach(func() {
+		person = &apimodel.Person{
+			Id:      uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Name:    "G.S. McLennan",
+			Aliases: []string{"George S. MacLennan"},
+		}
+
+		httpRec = httptest.NewRecorder()
+		c, _ = gin.CreateTestContext(httpRec)
+		dataService = mocks.NewDataService(GinkgoT())
+		api = &Handler{
+			service: dataService,
+		}
+	})
+
+	Context("List People", func() {
+		JustBeforeEach(func() {
+			api.ListPeople(c)
+		})
+
+		When("there is a person", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().People().Return([]*apimodel.Person{person}, nil)
+			})
+
+			It("should return the person", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Body.String()).To(Equal(
+					`[{"id":"00000000-0000-0000-0000-000000000001","name":"G.S. McLennan",` +
+						`"aliases":["George S. MacLennan"]}]`))
+			})
+		})
+	})
+
+	Context("Get Person", func() {
+		JustBeforeEach(func() {
+			api.GetPerson(c)
+		})
+
+		When("the person doesn't exist", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{{Key: "personId", Value: person.Id.String()}}
+				dataService.EXPECT().GetPerson(person.Id).Return(nil, common.ErrNotFound)
+			})
+
+			It("should return NotFound", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Context("List Person Tunes", func() {
+		BeforeEach(func() {
+			c.Params = gin.Params{{Key: "personId", Value: person.Id.String()}}
+		})
+
+		JustBeforeEach(func() {
+			api.ListPersonTunes(c)
+		})
+
+		When("requesting a filtered page", func() {
+			BeforeEach(func() {
+				c.Request = httptest.NewRequest(http.MethodGet,
+					"/people/"+person.Id.String()+"/tunes?page=2&type=March", nil)
+				dataService.EXPECT().PersonTunes(person.Id, common.TuneListOptions{PageOptions: common.PageOptions{Page: 2}, Type: "March"}).
+					Return(&apimodel.TuneList{}, nil)
+			})
+
+			It("should pass the options to the service", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		When("the page size is too big", func() {
+			BeforeEach(func() {
+				c.Request = httptest.NewRequest(http.MethodGet,
+					"/people/"+person.Id.String()+"/tunes?pageSize=1000", nil)
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	Context("Update Person", func() {
+		BeforeEach(func() {
+			c.Params = gin.Params{{Key: "personId", Value: person.Id.String()}}
+		})
+
+		JustBeforeEach(func() {
+			api.UpdatePerson(c)
+		})
+
+		When("the name is missing", func() {
+			BeforeEach(func() {
+				mockJSONPost(c, http.MethodPut, apimodel.UpdatePerson{})
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("another person has the name", func() {
+			BeforeEach(func() {
+				update := apimodel.UpdatePerson{Name: "Donald MacLeod"}
+				mockJSONPost(c, http.MethodPut, update)
+				dataService.EXPECT().UpdatePerson(person.Id, update).
+					Return(nil, fmt.Errorf("%w: person Donald MacLeod", common.ErrAlreadyExists))
+			})
+
+			It("should return Conflict", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusConflict))
+			})
+		})
+	})
+
+	Context("Merge People", func() {
+		var otherID uuid.UUID
+
+		BeforeEach(func() {
+			otherID = uuid.MustParse("00000000-0000-0000-0000-000000000002")
+			c.Params = gin.Params{{Key: "personId", Value: person.Id.String()}}
+		})
+
+		JustBeforeEach(func() {
+			api.MergePeople(c)
+		})
+
+		When("no people to merge are given", func() {
+			BeforeEach(func() {
+				mockJSONPost(c, http.MethodPost, apimodel.MergePeople{PersonIds: []uuid.UUID{}})
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("merging another person", func() {
+			BeforeEach(func() {
+				merge := apimodel.MergePeople{PersonIds: []uuid.UUID{otherID}}
+				mockJSONPost(c, http.MethodPost, merge)
+				dataService.EXPECT().MergePeople(person.Id, merge).Return(person, nil)
+			})
+
+			It("should return the merged person", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Body.String()).To(ContainSubstring(`"name":"G.S. McLennan"`))
+			})
+		})
+	})
+
+	Context("Set Person Aliases", func() {
+		BeforeEach(func() {
+			c.Params = gin.Params{{Key: "personId", Value: "not a uuid"}}
+			mockJSONPost(c, http.MethodPut, []string{"George S. MacLennan"})
+		})
+
+		JustBeforeEach(func() {
+			api.SetPersonAliases(c)
+		})
+
+		It("should return BadRequest for no uuid as personId", func() {
+			Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+})
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/score"
+	"net/http"
+	"strconv"
+)
+
+// scorePagesHeader is the response header with the number of pages of an engraved score.
+const scorePagesHeader = "X-Score-Pages"
+
+// GetTuneScoreSvg engraves the music model of the tune and returns a page of it as SVG image.
+func (a *Handler) GetTuneScoreSvg(c *gin.Context) {
+	var scoreOpts common.ScoreOptions
+	if err := c.ShouldBindQuery(&scoreOpts); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	if scoreOpts.Page == 0 {
+		scoreOpts.Page = 1
+	}
+
+	tuneID, err := uuid.Parse(c.Param("tuneId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	_, s, err := a.engraveTune(tuneID)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	svg, err := s.SVG(scoreOpts.Page)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.Header(scorePagesHeader, strconv.Itoa(s.Pages()))
+	c.Data(http.StatusOK, score.ContentTypeSVG, svg)
+}
+
+// GetTuneScorePdf engraves the music model of the tune as PDF document.
+func (a *Handler) GetTuneScorePdf(c *gin.Context) {
+	tuneID, err := uuid.Parse(c.Param("tuneId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	title, s, err := a.engraveTune(tuneID)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.Header(scorePagesHeader, strconv.Itoa(s.Pages()))
+	setAttachmentName(c, common.TitleFileName(title, score.ExtensionPDF))
+	c.Data(http.StatusOK, score.ContentTypePDF, s.PDF())
+}
+
+// engraveTune returns the title of the tune and the score of its music model.
+func (a *Handler) engraveTune(tuneID uuid.UUID) (string, *score.Score, error) {
+	apiTune, err := a.service.GetTune(tuneID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	muMoTune, err := a.musicModelTune(tuneID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return apiTune.Title, score.Engrave(muMoTune), nil
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"github.com/tomvodi/limepipes/internal/score"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("Api Handler Score", func() {
+	var c *gin.Context
+	var httpRec *httptest.ResponseRecorder
+	var api *Handler
+	var dataService *mocks.DataService
+	var tuneID uuid.UUID
+	var muMoFile *model.TuneFile
+	var expectedScore *score.Score
+
+	BeforeEach(func() {
+		var err error
+		tuneID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+		muMoFile, err = model.TuneFileFromMusicModelTune(model.TestParsedTune("Scotland the Brave").Tune)
+		Expect(err).ShouldNot(HaveOccurred())
+		muMoTune, err := muMoFile.MusicModelTune()
+		Expect(err).ShouldNot(HaveOccurred())
+		expectedScore = score.Engrave(muMoTune)
+
+		httpRec = httptest.NewRecorder()
+		c, _ = gin.CreateTestContext(httpRec)
+		c.Params = gin.Params{{Key: "tuneId", Value: tuneID.String()}}
+		dataService = mocks.NewDataService(GinkgoT())
+		api = &Handler{
+			service: dataService,
+		}
+	})
+
+	expectTuneWithMusicModel := func() {
+		dataService.EXPECT().GetTune(tuneID).
+			Return(&apimodel.Tune{Id: tuneID, Title: "Scotland the Brave"}, nil)
+		dataService.EXPECT().GetTuneFile(tuneID, fileformat.Format_MUSIC_MODEL).
+			Return(muMoFile, nil)
+	}
+
+	Context("SVG", func() {
+		BeforeEach(func() {
+			c.Request = httptest.NewRequest(http.MethodGet, "/tunes/"+tuneID.String()+"/score.svg", nil)
+		})
+
+		JustBeforeEach(func() {
+			api.GetTuneScoreSvg(c)
+		})
+
+		When("the tune has a music model", func() {
+			BeforeEach(expectTuneWithMusicModel)
+
+			It("should return the first page", func() {
+				svg, err := expectedScore.SVG(1)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Header().Get("Content-Type")).To(Equal("image/svg+xml"))
+				Expect(httpRec.Header().Get("Content-Disposition")).To(BeEmpty())
+				Expect(httpRec.Header().Get("X-Score-Pages")).To(Equal("1"))
+				Expect(httpRec.Body.Bytes()).To(Equal(svg))
+			})
+
+			When("a page after the last one is requested", func() {
+				BeforeEach(func() {
+					c.Request = httptest.NewRequest(http.MethodGet, "/tunes/"+tuneID.String()+"/score.svg?page=2", nil)
+				})
+
+				It("should return NotFound", func() {
+					Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		When("the page is no positive number", func() {
+			BeforeEach(func() {
+				c.Request = httptest.NewRequest(http.MethodGet, "/tunes/"+tuneID.String()+"/score.svg?page=-1", nil)
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the tuneId is no uuid", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{{Key: "tuneId", Value: "not a uuid"}}
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	Context("PDF", func() {
+		BeforeEach(func() {
+			c.Request = httptest.NewRequest(http.MethodGet, "/tunes/"+tuneID.String()+"/score.pdf", nil)
+		})
+
+		JustBeforeEach(func() {
+			api.GetTuneScorePdf(c)
+		})
+
+		When("the tune has a music model", func() {
+			BeforeEach(expectTuneWithMusicModel)
+
+			It("should return the PDF document as attachment", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Header().Get("Content-Type")).To(Equal("application/pdf"))
+				Expect(httpRec.Header().Get("Content-Disposition")).
+					To(Equal("attachment; filename=Scotland_the_Brave.pdf"))
+				Expect(httpRec.Body.Bytes()).To(Equal(expectedScore.PDF()))
+			})
+		})
+
+		When("the tune has no music model", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().GetTune(tuneID).
+					Return(&apimodel.Tune{Id: tuneID, Title: "Scotland the Brave"}, nil)
+				dataService.EXPECT().GetTuneFile(tuneID, fileformat.Format_MUSIC_MODEL).
+					Return(nil, common.ErrNotFound)
+			})
+
+			It("should return NotFound", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+})
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"net/http"
+)
+
+func (a *Handler) ListTags(c *gin.Context) {
+	tags, err := a.service.Tags()
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tags)
+}
+
+func (a *Handler) CreateTag(c *gin.Context) {
+	var createTag apimodel.CreateTag
+	if err := c.ShouldBindJSON(&createTag); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tag, err := a.service.CreateTag(createTag)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tag)
+}
+
+func (a *Handler) GetTag(c *gin.Context) {
+	tagID, err := uuid.Parse(c.Param("tagId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tag, err := a.service.GetTag(tagID)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tag)
+}
+
+func (a *Handler) UpdateTag(c *gin.Context) {
+	var updateTag apimodel.UpdateTag
+	if err := c.ShouldBindJSON(&updateTag); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tagID, err := uuid.Parse(c.Param("tagId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tag, err := a.service.UpdateTag(tagID, updateTag)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tag)
+}
+
+func (a *Handler) DeleteTag(c *gin.Context) {
+	tagID, err := uuid.Parse(c.Param("tagId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err = a.service.DeleteTag(tagID); err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AssignTagsToTune replaces the tags of the tune with the tags
+// of the ids in the request body.
+func (a *Handler) AssignTagsToTune(c *gin.Context) {
+	var tagIDs []uuid.UUID
+	if err := c.ShouldBindJSON(&tagIDs); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tuneID, err := uuid.Parse(c.Param("tuneId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tune, err := a.service.AssignTagsToTune(tuneID, tagIDs)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tune)
+}
+
+// AssignTagsToSet replaces the tags of the set with the tags
+// of the ids in the request body.
+func (a *Handler) AssignTagsToSet(c *gin.Context) {
+	var tagIDs []uuid.UUID
+	if err := c.ShouldBindJSON(&tagIDs); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	setID, err := uuid.Parse(c.Param("setId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	set, err := a.service.AssignTagsToMusicSet(setID, tagIDs, requestUserID(c))
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, set)
+}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("Api Handler Tags", func() {
+	var c *gin.Context
+	var httpRec *httptest.ResponseRecorder
+	var api *Handler
+	var dataService *mocks.DataService
+	var tag *apimodel.Tag
+
+	BeforeEach(func() {
+		tag = &apimodel.Tag{
+			Id:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Name: "grade 2",
+		}
+
+		httpRec = httptest.NewRecorder()
+		c, _ = gin.CreateTestContext(httpRec)
+		dataService = mocks.NewDataService(GinkgoT())
+		api = &Handler{
+			service: dataService,
+		}
+	})
+
+	Context("List Tags", func() {
+		JustBeforeEach(func() {
+			api.ListTags(c)
+		})
+
+		When("there is a tag", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().Tags().Return([]*apimodel.Tag{tag}, nil)
+			})
+
+			It("should return the tag", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Body.String()).To(Equal(
+					`[{"id":"00000000-0000-0000-0000-000000000001","name":"grade 2"}]`))
+			})
+		})
+	})
+
+	Context("Create Tag", func() {
+		JustBeforeEach(func() {
+			api.CreateTag(c)
+		})
+
+		When("the name is missing", func() {
+			BeforeEach(func() {
+				mockJSONPost(c, http.MethodPost, apimodel.CreateTag{Description: "no name"})
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("a tag with the name already exists", func() {
+			BeforeEach(func() {
+				create := apimodel.CreateTag{Name: "grade 2"}
+				mockJSONPost(c, http.MethodPost, create)
+				dataService.EXPECT().CreateTag(create).
+					Return(nil, fmt.Errorf("%w: tag grade 2", common.ErrAlreadyExists))
+			})
+
+			It("should return Conflict", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusConflict))
+			})
+		})
+
+		When("the tag is valid", func() {
+			BeforeEach(func() {
+				create := apimodel.CreateTag{Name: "grade 2"}
+				mockJSONPost(c, http.MethodPost, create)
+				dataService.EXPECT().CreateTag(create).Return(tag, nil)
+			})
+
+			It("should return the tag", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Body.String()).To(ContainSubstring(`"name":"grade 2"`))
+			})
+		})
+	})
+
+	Context("Delete Tag", func() {
+		BeforeEach(func() {
+			c.Params = gin.Params{{Key: "tagId", Value: tag.Id.String()}}
+		})
+
+		JustBeforeEach(func() {
+			api.DeleteTag(c)
+		})
+
+		When("no uuid as tagId", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{{Key: "tagId", Value: "not a uuid"}}
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the tag doesn't exist", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().DeleteTag(tag.Id).Return(common.ErrNotFound)
+			})
+
+			It("should return NotFound", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("the tag exists", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().DeleteTag(tag.Id).Return(nil)
+			})
+
+			It("should return NoContent", func() {
+				Expect(c.Writer.Status()).To(Equal(http.StatusNoContent))
+			})
+		})
+	})
+
+	Context("Assign Tags To Tune", func() {
+		var tuneID uuid.UUID
+
+		BeforeEach(func() {
+			tuneID = uuid.MustParse("00000000-0000-0000-0000-000000000002")
+			c.Params = gin.Params{{Key: "tuneId", Value: tuneID.String()}}
+		})
+
+		JustBeforeEach(func() {
+			api.AssignTagsToTune(c)
+		})
+
+		When("the body is no list of ids", func() {
+			BeforeEach(func() {
+				mockJSONPost(c, http.MethodPut, []string{"grade 2"})
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("assigning a tag", func() {
+			BeforeEach(func() {
+				mockJSONPost(c, http.MethodPut, []uuid.UUID{tag.Id})
+				dataService.EXPECT().AssignTagsToTune(tuneID, []uuid.UUID{tag.Id}).
+					Return(&apimodel.Tune{Id: tuneID, Title: "tune", Tags: []string{"grade 2"}}, nil)
+			})
+
+			It("should return the tune with the tag", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Body.String()).To(ContainSubstring(`"tags":["grade 2"]`))
+			})
+		})
+	})
+})
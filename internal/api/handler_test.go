@@ -9,12 +9,8 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/rs/zerolog/log"
-	"github.com/stretchr/testify/mock"
-	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
-	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
-	pmocks "github.com/tomvodi/limepipes-plugin-api/plugin/v1/interfaces/mocks"
-	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/messages"
 	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/auth"
 	"github.com/tomvodi/limepipes/internal/common"
 	"github.com/tomvodi/limepipes/internal/database/model"
 	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
@@ -31,6 +27,7 @@ type multipartRequest struct {
 	Content    []byte
 	Endpoint   string
 	HTTPMethod string
+	Fields     map[string]string // additional form fields
 }
 
 var _ = Describe("Api Handler", func() {
@@ -39,20 +36,24 @@ var _ = Describe("Api Handler", func() {
 	var httpRec *httptest.ResponseRecorder
 	var api *Handler
 	var testID1 uuid.UUID
+	var userID uuid.UUID
 	var dataService *mocks.DataService
 	var healthChecker *mocks.HealthChecker
 	var pluginLoader *mocks.PluginLoader
-	var lpPlugin *pmocks.LimePipesPlugin
 
 	BeforeEach(func() {
 		testID1 = uuid.MustParse("00000000-0000-0000-0000-000000000001")
 
 		httpRec = httptest.NewRecorder()
 		c, _ = gin.CreateTestContext(httpRec)
+		userID = uuid.MustParse("00000000-0000-0000-0000-0000000000aa")
+		auth.SetUser(c, &model.User{
+			BaseModel: model.BaseModel{ID: userID},
+			Name:      "piper",
+		})
 		dataService = mocks.NewDataService(GinkgoT())
 		healthChecker = mocks.NewHealthChecker(GinkgoT())
 		pluginLoader = mocks.NewPluginLoader(GinkgoT())
-		lpPlugin = pmocks.NewLimePipesPlugin(GinkgoT())
 		api = &Handler{
 			service:       dataService,
 			healthChecker: healthChecker,
@@ -110,14 +111,14 @@ var _ = Describe("Api Handler", func() {
 		BeforeEach(func() {
 			tuneID = testID1
 			c.Params = gin.Params{
-				{Key: "tuneID", Value: tuneID.String()},
+				{Key: "tuneId", Value: tuneID.String()},
 			}
 		})
 
 		When("no uuid as tuneID", func() {
 			BeforeEach(func() {
 				c.Params = gin.Params{
-					{Key: "tuneID", Value: "not a uuid"},
+					{Key: "tuneId", Value: "not a uuid"},
 				}
 			})
 
@@ -156,13 +157,20 @@ var _ = Describe("Api Handler", func() {
 	})
 
 	Context("List Tunes", func() {
+		var listOpts common.TuneListOptions
+
 		JustBeforeEach(func() {
 			api.ListTunes(c)
 		})
 
+		BeforeEach(func() {
+			listOpts = common.TuneListOptions{}
+			c.Request = httptest.NewRequest(http.MethodGet, "/tunes", nil)
+		})
+
 		When("service returns an error", func() {
 			BeforeEach(func() {
-				dataService.EXPECT().Tunes().
+				dataService.EXPECT().Tunes(listOpts).
 					Return(nil, fmt.Errorf("xxx"))
 			})
 
@@ -171,13 +179,79 @@ var _ = Describe("Api Handler", func() {
 			})
 		})
 
+		When("service returns an invalid argument error", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().Tunes(listOpts).
+					Return(nil, common.ErrInvalidArgument)
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the page size is too big", func() {
+			BeforeEach(func() {
+				c.Request = httptest.NewRequest(http.MethodGet, "/tunes?pageSize=1000", nil)
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the page is not a number", func() {
+			BeforeEach(func() {
+				c.Request = httptest.NewRequest(http.MethodGet, "/tunes?page=first", nil)
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("requesting a filtered and sorted page", func() {
+			BeforeEach(func() {
+				c.Request = httptest.NewRequest(http.MethodGet,
+					"/tunes?page=2&pageSize=10&sort=type,-title&title=brave&type=March&timeSig=2/4&composer=mac&arranger=smith",
+					nil,
+				)
+				listOpts = common.TuneListOptions{
+					PageOptions: common.PageOptions{
+						Page:     2,
+						PageSize: 10,
+					},
+					Sort:     "type,-title",
+					Title:    "brave",
+					Type:     "March",
+					TimeSig:  "2/4",
+					Composer: "mac",
+					Arranger: "smith",
+				}
+				dataService.EXPECT().Tunes(listOpts).
+					Return(&apimodel.TuneList{}, nil)
+			})
+
+			It("should pass all options to the service", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+			})
+		})
+
 		When("service returns tunes", func() {
 			BeforeEach(func() {
-				dataService.EXPECT().Tunes().
-					Return([]*apimodel.Tune{
-						{
-							Id:    testID1,
-							Title: "test title",
+				dataService.EXPECT().Tunes(listOpts).
+					Return(&apimodel.TuneList{
+						Tunes: []apimodel.Tune{
+							{
+								Id:    testID1,
+								Title: "test title",
+							},
+						},
+						Pagination: apimodel.Pagination{
+							Page:       1,
+							PageSize:   50,
+							TotalCount: 1,
+							TotalPages: 1,
 						},
 					}, nil)
 			})
@@ -186,7 +260,104 @@ var _ = Describe("Api Handler", func() {
 				Expect(httpRec.Code).To(Equal(http.StatusOK))
 				data, err := io.ReadAll(httpRec.Body)
 				Expect(err).ShouldNot(HaveOccurred())
-				Expect(string(data)).To(Equal("[{\"id\":\"00000000-0000-0000-0000-000000000001\",\"title\":\"test title\"}]"))
+				Expect(string(data)).To(Equal("{\"tunes\":[{\"id\":\"00000000-0000-0000-0000-000000000001\",\"title\":\"test title\"}]," +
+					"\"pagination\":{\"page\":1,\"pageSize\":50,\"totalCount\":1,\"totalPages\":1}}"))
+			})
+		})
+	})
+
+	Context("Search", func() {
+		JustBeforeEach(func() {
+			api.Search(c)
+		})
+
+		When("no query was given", func() {
+			BeforeEach(func() {
+				c.Request = httptest.NewRequest(http.MethodGet, "/search", nil)
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("searching for an unknown kind", func() {
+			BeforeEach(func() {
+				c.Request = httptest.NewRequest(http.MethodGet, "/search?q=laddie&kind=person", nil)
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the limit is too big", func() {
+			BeforeEach(func() {
+				c.Request = httptest.NewRequest(http.MethodGet, "/search?q=laddie&limit=1000", nil)
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("service returns an invalid argument error", func() {
+			BeforeEach(func() {
+				c.Request = httptest.NewRequest(http.MethodGet, "/search?q=%2B%2B", nil)
+				dataService.EXPECT().Search(common.SearchOptions{Query: "++", UserID: &userID}).
+					Return(nil, common.ErrInvalidArgument)
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("service returns an error", func() {
+			BeforeEach(func() {
+				c.Request = httptest.NewRequest(http.MethodGet, "/search?q=laddie", nil)
+				dataService.EXPECT().Search(common.SearchOptions{Query: "laddie", UserID: &userID}).
+					Return(nil, fmt.Errorf("xxx"))
+			})
+
+			It("should return a server error", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		When("service returns hits", func() {
+			BeforeEach(func() {
+				c.Request = httptest.NewRequest(http.MethodGet,
+					"/search?q=highland+laddie&kind=tune&limit=5", nil)
+				dataService.EXPECT().Search(common.SearchOptions{
+					Query:  "highland laddie",
+					Kind:   common.SearchKindTune,
+					Limit:  5,
+					UserID: &userID,
+				}).Return(&apimodel.SearchResult{
+					Query: "highland laddie",
+					Hits: []apimodel.SearchHit{
+						{
+							Kind:  "tune",
+							Id:    testID1,
+							Title: "Highland Laddie",
+							Rank:  1.5,
+							Highlights: map[string]string{
+								"title": "<mark>Highland</mark> <mark>Laddie</mark>",
+							},
+						},
+					},
+				}, nil)
+			})
+
+			It("should return ok and the hits", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				data, err := io.ReadAll(httpRec.Body)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(string(data)).To(Equal("{\"query\":\"highland laddie\",\"hits\":[{\"kind\":\"tune\"," +
+					"\"id\":\"00000000-0000-0000-0000-000000000001\",\"title\":\"Highland Laddie\",\"rank\":1.5," +
+					"\"highlights\":{\"title\":\"\\u003cmark\\u003eHighland\\u003c/mark\\u003e " +
+					"\\u003cmark\\u003eLaddie\\u003c/mark\\u003e\"}}]}"))
 			})
 		})
 	})
@@ -201,7 +372,7 @@ var _ = Describe("Api Handler", func() {
 		BeforeEach(func() {
 			tuneID = testID1
 			c.Params = gin.Params{
-				{Key: "tuneID", Value: tuneID.String()},
+				{Key: "tuneId", Value: tuneID.String()},
 			}
 		})
 
@@ -233,7 +404,7 @@ var _ = Describe("Api Handler", func() {
 			When("no uuid as tuneID", func() {
 				BeforeEach(func() {
 					c.Params = gin.Params{
-						{Key: "tuneID", Value: "not a uuid"},
+						{Key: "tuneId", Value: "not a uuid"},
 					}
 				})
 
@@ -244,7 +415,7 @@ var _ = Describe("Api Handler", func() {
 
 			When("service returns an error on update", func() {
 				BeforeEach(func() {
-					dataService.EXPECT().UpdateTune(tuneID, tune).
+					dataService.EXPECT().UpdateTune(tuneID, tune, "piper").
 						Return(nil, fmt.Errorf("xxx"))
 				})
 
@@ -255,7 +426,7 @@ var _ = Describe("Api Handler", func() {
 
 			When("service successfully updates tune", func() {
 				BeforeEach(func() {
-					dataService.EXPECT().UpdateTune(tuneID, tune).
+					dataService.EXPECT().UpdateTune(tuneID, tune, "piper").
 						Return(&apimodel.Tune{
 							Id:    testID1,
 							Title: tune.Title,
@@ -282,14 +453,14 @@ var _ = Describe("Api Handler", func() {
 		BeforeEach(func() {
 			tuneID = testID1
 			c.Params = gin.Params{
-				{Key: "tuneID", Value: tuneID.String()},
+				{Key: "tuneId", Value: tuneID.String()},
 			}
 		})
 
 		When("no uuid as tuneID", func() {
 			BeforeEach(func() {
 				c.Params = gin.Params{
-					{Key: "tuneID", Value: "not a uuid"},
+					{Key: "tuneId", Value: "not a uuid"},
 				}
 			})
 
@@ -359,7 +530,7 @@ var _ = Describe("Api Handler", func() {
 
 			When("service returns an error on creation", func() {
 				BeforeEach(func() {
-					dataService.EXPECT().CreateTune(tune, (*model.ImportFile)(nil)).
+					dataService.EXPECT().CreateTune(tune, (*model.ImportFile)(nil), &userID).
 						Return(nil, fmt.Errorf("xxx"))
 				})
 
@@ -370,7 +541,7 @@ var _ = Describe("Api Handler", func() {
 
 			When("service successfully creates tune", func() {
 				BeforeEach(func() {
-					dataService.EXPECT().CreateTune(tune, (*model.ImportFile)(nil)).
+					dataService.EXPECT().CreateTune(tune, (*model.ImportFile)(nil), &userID).
 						Return(&apimodel.Tune{
 							Id:    testID1,
 							Title: tune.Title,
@@ -397,14 +568,14 @@ var _ = Describe("Api Handler", func() {
 		BeforeEach(func() {
 			setID = testID1
 			c.Params = gin.Params{
-				{Key: "setID", Value: setID.String()},
+				{Key: "setId", Value: setID.String()},
 			}
 		})
 
 		When("no uuid as setID", func() {
 			BeforeEach(func() {
 				c.Params = gin.Params{
-					{Key: "setID", Value: "not a uuid"},
+					{Key: "setId", Value: "not a uuid"},
 				}
 			})
 
@@ -415,7 +586,7 @@ var _ = Describe("Api Handler", func() {
 
 		When("service returns an error", func() {
 			BeforeEach(func() {
-				dataService.EXPECT().GetMusicSet(setID).
+				dataService.EXPECT().GetMusicSet(setID, &userID).
 					Return(nil, fmt.Errorf("xxx"))
 			})
 
@@ -426,7 +597,7 @@ var _ = Describe("Api Handler", func() {
 
 		When("service returns a set", func() {
 			BeforeEach(func() {
-				dataService.EXPECT().GetMusicSet(setID).
+				dataService.EXPECT().GetMusicSet(setID, &userID).
 					Return(&apimodel.MusicSet{
 						Id:    setID,
 						Title: "test title",
@@ -480,7 +651,7 @@ var _ = Describe("Api Handler", func() {
 
 			When("service returns an error on creation", func() {
 				BeforeEach(func() {
-					dataService.EXPECT().CreateMusicSet(set, (*model.ImportFile)(nil)).
+					dataService.EXPECT().CreateMusicSet(set, (*model.ImportFile)(nil), &userID).
 						Return(nil, fmt.Errorf("xxx"))
 				})
 
@@ -491,7 +662,7 @@ var _ = Describe("Api Handler", func() {
 
 			When("service successfully creates set", func() {
 				BeforeEach(func() {
-					dataService.EXPECT().CreateMusicSet(set, (*model.ImportFile)(nil)).
+					dataService.EXPECT().CreateMusicSet(set, (*model.ImportFile)(nil), &userID).
 						Return(&apimodel.MusicSet{
 							Id:    testID1,
 							Title: set.Title,
@@ -515,7 +686,7 @@ var _ = Describe("Api Handler", func() {
 
 		When("service returns an error", func() {
 			BeforeEach(func() {
-				dataService.EXPECT().MusicSets().
+				dataService.EXPECT().MusicSets(&userID).
 					Return(nil, fmt.Errorf("xxx"))
 			})
 
@@ -526,7 +697,7 @@ var _ = Describe("Api Handler", func() {
 
 		When("service returns sets", func() {
 			BeforeEach(func() {
-				dataService.EXPECT().MusicSets().
+				dataService.EXPECT().MusicSets(&userID).
 					Return([]*apimodel.MusicSet{
 						{
 							Id:    testID1,
@@ -554,7 +725,7 @@ var _ = Describe("Api Handler", func() {
 		BeforeEach(func() {
 			setID = testID1
 			c.Params = gin.Params{
-				{Key: "setID", Value: setID.String()},
+				{Key: "setId", Value: setID.String()},
 			}
 		})
 
@@ -586,7 +757,7 @@ var _ = Describe("Api Handler", func() {
 			When("no uuid as setID", func() {
 				BeforeEach(func() {
 					c.Params = gin.Params{
-						{Key: "setID", Value: "not a uuid"},
+						{Key: "setId", Value: "not a uuid"},
 					}
 				})
 
@@ -597,7 +768,7 @@ var _ = Describe("Api Handler", func() {
 
 			When("service returns an error on update", func() {
 				BeforeEach(func() {
-					dataService.EXPECT().UpdateMusicSet(setID, set).
+					dataService.EXPECT().UpdateMusicSet(setID, set, &userID).
 						Return(nil, fmt.Errorf("xxx"))
 				})
 
@@ -608,7 +779,7 @@ var _ = Describe("Api Handler", func() {
 
 			When("service successfully updates set", func() {
 				BeforeEach(func() {
-					dataService.EXPECT().UpdateMusicSet(setID, set).
+					dataService.EXPECT().UpdateMusicSet(setID, set, &userID).
 						Return(&apimodel.MusicSet{
 							Id:    testID1,
 							Title: set.Title,
@@ -635,14 +806,14 @@ var _ = Describe("Api Handler", func() {
 		BeforeEach(func() {
 			setID = testID1
 			c.Params = gin.Params{
-				{Key: "setID", Value: setID.String()},
+				{Key: "setId", Value: setID.String()},
 			}
 		})
 
 		When("no uuid as setID", func() {
 			BeforeEach(func() {
 				c.Params = gin.Params{
-					{Key: "setID", Value: "not a uuid"},
+					{Key: "setId", Value: "not a uuid"},
 				}
 			})
 
@@ -653,7 +824,7 @@ var _ = Describe("Api Handler", func() {
 
 		When("service returns an error", func() {
 			BeforeEach(func() {
-				dataService.EXPECT().DeleteMusicSet(setID).
+				dataService.EXPECT().DeleteMusicSet(setID, &userID).
 					Return(fmt.Errorf("xxx"))
 			})
 
@@ -664,7 +835,7 @@ var _ = Describe("Api Handler", func() {
 
 		When("service successfully deletes set", func() {
 			BeforeEach(func() {
-				dataService.EXPECT().DeleteMusicSet(setID).
+				dataService.EXPECT().DeleteMusicSet(setID, &userID).
 					Return(nil)
 			})
 
@@ -686,7 +857,7 @@ var _ = Describe("Api Handler", func() {
 			setID = testID1
 			testID2 = uuid.MustParse("00000000-0000-0000-0000-000000000002")
 			c.Params = gin.Params{
-				{Key: "setID", Value: setID.String()},
+				{Key: "setId", Value: setID.String()},
 			}
 		})
 
@@ -711,7 +882,7 @@ var _ = Describe("Api Handler", func() {
 			When("no uuid as setID", func() {
 				BeforeEach(func() {
 					c.Params = gin.Params{
-						{Key: "setID", Value: "not a uuid"},
+						{Key: "setId", Value: "not a uuid"},
 					}
 				})
 
@@ -722,7 +893,7 @@ var _ = Describe("Api Handler", func() {
 
 			When("service returns an error", func() {
 				BeforeEach(func() {
-					dataService.EXPECT().AssignTunesToMusicSet(setID, []uuid.UUID{testID1, testID2}).
+					dataService.EXPECT().AssignTunesToMusicSet(setID, []uuid.UUID{testID1, testID2}, &userID).
 						Return(nil, fmt.Errorf("xxx"))
 				})
 
@@ -733,7 +904,7 @@ var _ = Describe("Api Handler", func() {
 
 			When("service successfully assigns tunes to set", func() {
 				BeforeEach(func() {
-					dataService.EXPECT().AssignTunesToMusicSet(setID, []uuid.UUID{testID1, testID2}).
+					dataService.EXPECT().AssignTunesToMusicSet(setID, []uuid.UUID{testID1, testID2}, &userID).
 						Return(&apimodel.MusicSet{
 							Id:    testID1,
 							Title: "test music set",
@@ -749,181 +920,6 @@ var _ = Describe("Api Handler", func() {
 			})
 		})
 	})
-
-	Context("ImportFile", func() {
-		JustBeforeEach(func() {
-			api.ImportFile(c)
-		})
-
-		When("fieldname is wrong", func() {
-			BeforeEach(func() {
-				c.Request = multipartRequestForFile(multipartRequest{
-					Fieldname:  "wrongfieldname",
-					Filename:   "test.bww",
-					Content:    []byte("test file content"),
-					Endpoint:   "/imports",
-					HTTPMethod: http.MethodPost,
-				})
-			})
-
-			It("should return BadRequest", func() {
-				api.ImportFile(c)
-				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
-			})
-		})
-
-		When("file has no extension", func() {
-			BeforeEach(func() {
-				c.Request = multipartRequestForFile(multipartRequest{
-					Fieldname:  "file",
-					Filename:   "test",
-					Content:    []byte("test file content"),
-					Endpoint:   "/imports",
-					HTTPMethod: http.MethodPost,
-				})
-			})
-
-			It("should return BadRequest", func() {
-				api.ImportFile(c)
-				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
-			})
-		})
-
-		When("file extension is not supported", func() {
-			BeforeEach(func() {
-				c.Request = multipartRequestForFile(multipartRequest{
-					Fieldname:  "file",
-					Filename:   "test.abc",
-					Content:    []byte("test file content"),
-					Endpoint:   "/imports",
-					HTTPMethod: http.MethodPost,
-				})
-				pluginLoader.EXPECT().FileFormatForFileExtension(".abc").
-					Return(fileformat.Format_Unknown, fmt.Errorf("file extension .abc is not supported"))
-			})
-
-			It("should return BadRequest", func() {
-				api.ImportFile(c)
-				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
-			})
-		})
-
-		When("file was already imported", func() {
-			BeforeEach(func() {
-				c.Request = multipartRequestForFile(multipartRequest{
-					Fieldname:  "file",
-					Filename:   "test.bww",
-					Content:    []byte("test file content"),
-					Endpoint:   "/imports",
-					HTTPMethod: http.MethodPost,
-				})
-				pluginLoader.EXPECT().FileFormatForFileExtension(".bww").
-					Return(fileformat.Format_BWW, nil)
-				dataService.EXPECT().GetImportFileByHash("60f5237ed4049f0382661ef009d2bc42e48c3ceb3edb6600f7024e7ab3b838f3").
-					Return(nil, nil)
-			})
-
-			It("should return a http conflict", func() {
-				api.ImportFile(c)
-				Expect(httpRec.Code).To(Equal(http.StatusConflict))
-			})
-		})
-
-		When("there is no plugin for the file extension", func() {
-			BeforeEach(func() {
-				c.Request = multipartRequestForFile(multipartRequest{
-					Fieldname:  "file",
-					Filename:   "test.bww",
-					Content:    []byte("test file content"),
-					Endpoint:   "/imports",
-					HTTPMethod: http.MethodPost,
-				})
-				pluginLoader.EXPECT().FileFormatForFileExtension(".bww").
-					Return(fileformat.Format_BWW, nil)
-				dataService.EXPECT().GetImportFileByHash("60f5237ed4049f0382661ef009d2bc42e48c3ceb3edb6600f7024e7ab3b838f3").
-					Return(nil, common.ErrNotFound)
-				pluginLoader.EXPECT().PluginForFileExtension(".bww").
-					Return(nil, fmt.Errorf("no plugin found for file extension .bww"))
-			})
-
-			It("should return InternalServerError", func() {
-				api.ImportFile(c)
-				Expect(httpRec.Code).To(Equal(http.StatusInternalServerError))
-			})
-		})
-
-		When("plugin fails parsing the file", func() {
-			BeforeEach(func() {
-				c.Request = multipartRequestForFile(multipartRequest{
-					Fieldname:  "file",
-					Filename:   "test.bww",
-					Content:    []byte("test file content"),
-					Endpoint:   "/imports",
-					HTTPMethod: http.MethodPost,
-				})
-				pluginLoader.EXPECT().FileFormatForFileExtension(".bww").
-					Return(fileformat.Format_BWW, nil)
-				dataService.EXPECT().GetImportFileByHash("60f5237ed4049f0382661ef009d2bc42e48c3ceb3edb6600f7024e7ab3b838f3").
-					Return(nil, common.ErrNotFound)
-				pluginLoader.EXPECT().PluginForFileExtension(".bww").
-					Return(lpPlugin, nil)
-				lpPlugin.EXPECT().Parse([]byte("test file content")).
-					Return(nil, fmt.Errorf("failed parsing file test.bww: xxx"))
-			})
-
-			It("should return InternalServerError", func() {
-				api.ImportFile(c)
-				Expect(httpRec.Code).To(Equal(http.StatusInternalServerError))
-			})
-		})
-
-		When("plugin successfully parses the file", func() {
-			BeforeEach(func() {
-				c.Request = multipartRequestForFile(multipartRequest{
-					Fieldname:  "file",
-					Filename:   "test.bww",
-					Content:    []byte("test file content"),
-					Endpoint:   "/imports",
-					HTTPMethod: http.MethodPost,
-				})
-				pluginLoader.EXPECT().FileFormatForFileExtension(".bww").
-					Return(fileformat.Format_BWW, nil)
-				dataService.EXPECT().GetImportFileByHash("60f5237ed4049f0382661ef009d2bc42e48c3ceb3edb6600f7024e7ab3b838f3").
-					Return(nil, common.ErrNotFound)
-				pluginLoader.EXPECT().PluginForFileExtension(".bww").
-					Return(lpPlugin, nil)
-				parsedTunes := []*messages.ParsedTune{
-					{
-						Tune: &tune.Tune{
-							Title: "test title",
-						},
-						TuneFileData: []byte("test file content"),
-					},
-				}
-				lpPlugin.EXPECT().Parse([]byte("test file content")).
-					Return(parsedTunes, nil)
-				dataService.EXPECT().ImportTunes(parsedTunes, mock.Anything).
-					Return([]*apimodel.ImportTune{
-						{
-							Id:    testID1,
-							Title: "test tune",
-						},
-					},
-						&apimodel.BasicMusicSet{
-							Id:    testID1,
-							Title: "test music set",
-						}, nil)
-			})
-
-			It("should return ok and the imported tunes", func() {
-				api.ImportFile(c)
-				Expect(httpRec.Code).To(Equal(http.StatusOK))
-				data, err := io.ReadAll(httpRec.Body)
-				Expect(err).ShouldNot(HaveOccurred())
-				Expect(string(data)).To(Equal("{\"name\":\"test.bww\",\"set\":{\"id\":\"00000000-0000-0000-0000-000000000001\",\"title\":\"test music set\"},\"tunes\":[{\"id\":\"00000000-0000-0000-0000-000000000001\",\"title\":\"test tune\"}]}{\"name\":\"test.bww\",\"set\":{\"id\":\"00000000-0000-0000-0000-000000000001\",\"title\":\"test music set\"},\"tunes\":[{\"id\":\"00000000-0000-0000-0000-000000000001\",\"title\":\"test tune\"}]}"))
-			})
-		})
-	})
 })
 
 func multipartRequestForFile(
@@ -936,6 +932,9 @@ func multipartRequestForFile(
 
 	_, err = dataPart.Write(req.Content)
 	Expect(err).NotTo(HaveOccurred())
+	for name, value := range req.Fields {
+		Expect(mulWriter.WriteField(name, value)).To(Succeed())
+	}
 	err = mulWriter.Close()
 	Expect(err).NotTo(HaveOccurred())
 
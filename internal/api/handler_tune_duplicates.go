@@ -0,0 +1,49 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"net/http"
+)
+
+// ListTuneDuplicates lists the tunes whose music is similar to the music
+// of the tune, even if they were imported from different files.
+func (a *Handler) ListTuneDuplicates(c *gin.Context) {
+	tuneID, err := uuid.Parse(c.Param("tuneId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	duplicates, err := a.service.TuneDuplicates(tuneID)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, duplicates)
+}
+
+// MergeTunes merges the tunes of the request body into the tune of the path.
+func (a *Handler) MergeTunes(c *gin.Context) {
+	var merge apimodel.MergeTunes
+	if err := c.ShouldBindJSON(&merge); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tuneID, err := uuid.Parse(c.Param("tuneId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tune, err := a.service.MergeTunes(tuneID, merge)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tune)
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("Api Handler Tune Duplicates", func() {
+	var c *gin.Context
+	var httpRec *httptest.ResponseRecorder
+	var api *Handler
+	var dataService *mocks.DataService
+	var tuneID uuid.UUID
+	var duplicateID uuid.UUID
+
+	BeforeEach(func() {
+		tuneID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+		duplicateID = uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+		httpRec = httptest.NewRecorder()
+		c, _ = gin.CreateTestContext(httpRec)
+		dataService = mocks.NewDataService(GinkgoT())
+		api = &Handler{
+			service: dataService,
+		}
+	})
+
+	Context("List Tune Duplicates", func() {
+		JustBeforeEach(func() {
+			api.ListTuneDuplicates(c)
+		})
+
+		When("the tune has a duplicate", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{{Key: "tuneId", Value: tuneID.String()}}
+				dataService.EXPECT().TuneDuplicates(tuneID).Return([]*apimodel.TuneDuplicate{
+					{Tune: apimodel.Tune{Id: duplicateID, Title: "Scotland the Brave"}, Similarity: 0.9},
+				}, nil)
+			})
+
+			It("should return the duplicate with its similarity", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Body.String()).To(Equal(
+					`[{"tune":{"id":"00000000-0000-0000-0000-000000000002","title":"Scotland the Brave"},` +
+						`"similarity":0.9}]`))
+			})
+		})
+
+		When("the tune doesn't exist", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{{Key: "tuneId", Value: tuneID.String()}}
+				dataService.EXPECT().TuneDuplicates(tuneID).Return(nil, common.ErrNotFound)
+			})
+
+			It("should return NotFound", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("the tuneId is no uuid", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{{Key: "tuneId", Value: "not a uuid"}}
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	Context("Merge Tunes", func() {
+		BeforeEach(func() {
+			c.Params = gin.Params{{Key: "tuneId", Value: tuneID.String()}}
+		})
+
+		JustBeforeEach(func() {
+			api.MergeTunes(c)
+		})
+
+		When("no tunes to merge are given", func() {
+			BeforeEach(func() {
+				mockJSONPost(c, http.MethodPost, apimodel.MergeTunes{TuneIds: []uuid.UUID{}})
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("merging a duplicate", func() {
+			BeforeEach(func() {
+				merge := apimodel.MergeTunes{TuneIds: []uuid.UUID{duplicateID}}
+				mockJSONPost(c, http.MethodPost, merge)
+				dataService.EXPECT().MergeTunes(tuneID, merge).
+					Return(&apimodel.Tune{Id: tuneID, Title: "Scotland the Brave"}, nil)
+			})
+
+			It("should return the merged tune", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Body.String()).To(ContainSubstring(`"id":"00000000-0000-0000-0000-000000000001"`))
+			})
+		})
+	})
+})
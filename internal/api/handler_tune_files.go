@@ -0,0 +1,211 @@
+package api
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/lilypond"
+	"io"
+	"mime"
+	"net/http"
+)
+
+func (a *Handler) ListTuneFiles(c *gin.Context) {
+	tuneID, err := uuid.Parse(c.Param("tuneId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tuneFiles, err := a.service.GetTuneFiles(tuneID)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	apiFiles := make([]apimodel.TuneFile, len(tuneFiles))
+	for i, tf := range tuneFiles {
+		apiFiles[i] = apiTuneFile(tf)
+	}
+
+	c.JSON(http.StatusOK, apiFiles)
+}
+
+func (a *Handler) GetTuneFile(c *gin.Context) {
+	tuneID, fFormat, err := tuneFileParams(c)
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tune, err := a.service.GetTune(tuneID)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	tuneFile, err := a.service.GetTuneFile(tuneID, fFormat)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	sendTuneFile(c, tune.Title, tuneFile)
+}
+
+func (a *Handler) UploadTuneFile(c *gin.Context) {
+	tuneID, fFormat, err := tuneFileParams(c)
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if fFormat == fileformat.Format_MUSIC_MODEL {
+		httpErrorResponse(c, http.StatusBadRequest,
+			fmt.Errorf("music model files are created by importing a tune and can't be uploaded"))
+		return
+	}
+
+	fileData, err := formFileData(c, "file")
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tuneFile := &model.TuneFile{
+		Format:         fFormat,
+		Data:           fileData,
+		SingleTuneData: true,
+	}
+	if err = a.service.AddFileToTune(tuneID, tuneFile, requestAuthor(c)); err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, apiTuneFile(tuneFile))
+}
+
+func (a *Handler) DeleteTuneFile(c *gin.Context) {
+	tuneID, fFormat, err := tuneFileParams(c)
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if fFormat == fileformat.Format_MUSIC_MODEL {
+		httpErrorResponse(c, http.StatusBadRequest,
+			fmt.Errorf("the music model file of a tune can't be deleted"))
+		return
+	}
+
+	if err = a.service.DeleteFileFromTune(tuneID, fFormat, requestAuthor(c)); err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func tuneFileParams(c *gin.Context) (uuid.UUID, fileformat.Format, error) {
+	tuneID, err := uuid.Parse(c.Param("tuneId"))
+	if err != nil {
+		return uuid.Nil, fileformat.Format_Unknown, err
+	}
+
+	fFormat, err := common.FileFormatFromName(c.Param("format"))
+	if err != nil {
+		return uuid.Nil, fileformat.Format_Unknown, err
+	}
+
+	return tuneID, fFormat, nil
+}
+
+func formFileData(c *gin.Context, field string) ([]byte, error) {
+	fHeader, err := c.FormFile(field)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := fHeader.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed open file %s for reading", fHeader.Filename)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading file %s: %s", fHeader.Filename, err.Error())
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("file %s is empty", fHeader.Filename)
+	}
+
+	return data, nil
+}
+
+// sendTuneFile sends the data of the tune file as an attachment
+// with a file name made of the tune title.
+func sendTuneFile(c *gin.Context, title string, tuneFile *model.TuneFile) {
+	setAttachmentName(c, common.TuneFileName(title, tuneFile.Format))
+	c.Data(http.StatusOK, common.FileFormatContentType(tuneFile.Format), tuneFile.Data)
+}
+
+// setAttachmentName sets the header that lets clients save
+// the data of the response in a file with the name.
+func setAttachmentName(c *gin.Context, fileName string) {
+	disposition := mime.FormatMediaType("attachment", map[string]string{
+		"filename": fileName,
+	})
+	c.Header("Content-Disposition", disposition)
+}
+
+func apiTuneFile(tf *model.TuneFile) apimodel.TuneFile {
+	return apimodel.TuneFile{
+		Format:      common.FileFormatName(tf.Format),
+		ContentType: common.FileFormatContentType(tf.Format),
+		Size:        int64(len(tf.Data)),
+	}
+}
+
+func (a *Handler) ExportTune(c *gin.Context) {
+	var exportOpts common.ExportOptions
+	if err := c.ShouldBindQuery(&exportOpts); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tuneID, err := uuid.Parse(c.Param("tuneId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if lilypond.IsFormatName(exportOpts.Format) {
+		a.exportLilyPond(c, tuneID)
+		return
+	}
+
+	fFormat, err := common.FileFormatFromName(exportOpts.Format)
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tune, err := a.service.GetTune(tuneID)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	tuneFile, err := a.exporter.ExportTune(tuneID, fFormat)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	sendTuneFile(c, tune.Title, tuneFile)
+}
@@ -0,0 +1,391 @@
+package api
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/auth"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"github.com/tomvodi/limepipes/internal/lilypond"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("Api Handler Tune Files", func() {
+	var c *gin.Context
+	var httpRec *httptest.ResponseRecorder
+	var api *Handler
+	var tuneID uuid.UUID
+	var dataService *mocks.DataService
+	var exporter *mocks.TuneExporter
+
+	BeforeEach(func() {
+		tuneID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+		httpRec = httptest.NewRecorder()
+		c, _ = gin.CreateTestContext(httpRec)
+		dataService = mocks.NewDataService(GinkgoT())
+		exporter = mocks.NewTuneExporter(GinkgoT())
+		api = &Handler{
+			service:  dataService,
+			exporter: exporter,
+		}
+		c.Params = gin.Params{
+			{Key: "tuneId", Value: tuneID.String()},
+			{Key: "format", Value: "bww"},
+		}
+	})
+
+	Context("List Tune Files", func() {
+		JustBeforeEach(func() {
+			api.ListTuneFiles(c)
+		})
+
+		When("no uuid as tuneId", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{{Key: "tuneId", Value: "not a uuid"}}
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the tune doesn't exist", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().GetTuneFiles(tuneID).
+					Return(nil, common.ErrNotFound)
+			})
+
+			It("should return NotFound", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("the tune has files", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().GetTuneFiles(tuneID).
+					Return([]*model.TuneFile{
+						{
+							TuneID: tuneID,
+							Format: fileformat.Format_MUSIC_MODEL,
+							Data:   []byte("model"),
+						},
+						{
+							TuneID:         tuneID,
+							Format:         fileformat.Format_BWW,
+							Data:           []byte("bww data"),
+							SingleTuneData: true,
+						},
+					}, nil)
+			})
+
+			It("should return the formats and sizes of the files", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Body.String()).To(Equal(
+					`[{"format":"music_model","contentType":"application/octet-stream","size":5},` +
+						`{"format":"bww","contentType":"text/plain; charset=utf-8","size":8}]`))
+			})
+		})
+	})
+
+	Context("Get Tune File", func() {
+		JustBeforeEach(func() {
+			api.GetTuneFile(c)
+		})
+
+		When("the format is unknown", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{
+					{Key: "tuneId", Value: tuneID.String()},
+					{Key: "format", Value: "mp3"},
+				}
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the tune doesn't exist", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().GetTune(tuneID).
+					Return(nil, common.ErrNotFound)
+			})
+
+			It("should return NotFound", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("the tune has no file of that format", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().GetTune(tuneID).
+					Return(&apimodel.Tune{Id: tuneID, Title: "test"}, nil)
+				dataService.EXPECT().GetTuneFile(tuneID, fileformat.Format_BWW).
+					Return(nil, common.ErrNotFound)
+			})
+
+			It("should return NotFound", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("the tune has a file of that format", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{
+					{Key: "tuneId", Value: tuneID.String()},
+					{Key: "format", Value: "BWW"},
+				}
+				dataService.EXPECT().GetTune(tuneID).
+					Return(&apimodel.Tune{Id: tuneID, Title: "Scotland the Brave!"}, nil)
+				dataService.EXPECT().GetTuneFile(tuneID, fileformat.Format_BWW).
+					Return(&model.TuneFile{
+						TuneID: tuneID,
+						Format: fileformat.Format_BWW,
+						Data:   []byte("bww data"),
+					}, nil)
+			})
+
+			It("should return the file data as attachment", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Header().Get("Content-Type")).To(Equal("text/plain; charset=utf-8"))
+				Expect(httpRec.Header().Get("Content-Disposition")).
+					To(Equal("attachment; filename=Scotland_the_Brave.bww"))
+				Expect(httpRec.Body.String()).To(Equal("bww data"))
+			})
+		})
+	})
+
+	Context("Upload Tune File", func() {
+		JustBeforeEach(func() {
+			api.UploadTuneFile(c)
+		})
+
+		BeforeEach(func() {
+			c.Request = multipartRequestForFile(multipartRequest{
+				Fieldname:  "file",
+				Filename:   "test.bww",
+				Content:    []byte("bww data"),
+				Endpoint:   "/tunes/" + tuneID.String() + "/files/bww",
+				HTTPMethod: http.MethodPost,
+			})
+		})
+
+		When("uploading a music model file", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{
+					{Key: "tuneId", Value: tuneID.String()},
+					{Key: "format", Value: "music_model"},
+				}
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the file is empty", func() {
+			BeforeEach(func() {
+				c.Request = multipartRequestForFile(multipartRequest{
+					Fieldname:  "file",
+					Filename:   "test.bww",
+					Content:    []byte{},
+					Endpoint:   "/tunes/" + tuneID.String() + "/files/bww",
+					HTTPMethod: http.MethodPost,
+				})
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the tune already has a file of that format", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().AddFileToTune(tuneID, mock.Anything, "").
+					Return(fmt.Errorf("%w: bww", common.ErrAlreadyExists))
+			})
+
+			It("should return Conflict", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusConflict))
+			})
+		})
+
+		When("the file was added", func() {
+			BeforeEach(func() {
+				auth.SetUser(c, &model.User{Name: "piper"})
+				dataService.EXPECT().AddFileToTune(tuneID, &model.TuneFile{
+					Format:         fileformat.Format_BWW,
+					Data:           []byte("bww data"),
+					SingleTuneData: true,
+				}, "piper").Return(nil)
+			})
+
+			It("should return Created with the file info", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusCreated))
+				Expect(httpRec.Body.String()).To(Equal(
+					`{"format":"bww","contentType":"text/plain; charset=utf-8","size":8}`))
+			})
+		})
+	})
+
+	Context("Delete Tune File", func() {
+		JustBeforeEach(func() {
+			api.DeleteTuneFile(c)
+		})
+
+		BeforeEach(func() {
+			c.Request = httptest.NewRequest(http.MethodDelete,
+				"/tunes/"+tuneID.String()+"/files/bww", nil)
+		})
+
+		When("deleting the music model file", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{
+					{Key: "tuneId", Value: tuneID.String()},
+					{Key: "format", Value: "music_model"},
+				}
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the file doesn't exist", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().DeleteFileFromTune(tuneID, fileformat.Format_BWW, "").
+					Return(common.ErrNotFound)
+			})
+
+			It("should return NotFound", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("the file was deleted", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().DeleteFileFromTune(tuneID, fileformat.Format_BWW, "").
+					Return(nil)
+			})
+
+			It("should return NoContent", func() {
+				Expect(c.Writer.Status()).To(Equal(http.StatusNoContent))
+			})
+		})
+	})
+
+	Context("Export Tune", func() {
+		JustBeforeEach(func() {
+			api.ExportTune(c)
+		})
+
+		BeforeEach(func() {
+			c.Params = gin.Params{{Key: "tuneId", Value: tuneID.String()}}
+			c.Request = httptest.NewRequest(http.MethodGet,
+				"/tunes/"+tuneID.String()+"/export?format=musicxml", nil)
+		})
+
+		When("no format was given", func() {
+			BeforeEach(func() {
+				c.Request = httptest.NewRequest(http.MethodGet,
+					"/tunes/"+tuneID.String()+"/export", nil)
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the format is unknown", func() {
+			BeforeEach(func() {
+				c.Request = httptest.NewRequest(http.MethodGet,
+					"/tunes/"+tuneID.String()+"/export?format=pdf", nil)
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the tune doesn't exist", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().GetTune(tuneID).
+					Return(nil, common.ErrNotFound)
+			})
+
+			It("should return NotFound", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("no plugin can export that format", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().GetTune(tuneID).
+					Return(&apimodel.Tune{Id: tuneID, Title: "test"}, nil)
+				exporter.EXPECT().ExportTune(tuneID, fileformat.Format_MUSIC_XML).
+					Return(nil, common.ErrInvalidArgument)
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the tune was exported", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().GetTune(tuneID).
+					Return(&apimodel.Tune{Id: tuneID, Title: "Scotland the Brave"}, nil)
+				exporter.EXPECT().ExportTune(tuneID, fileformat.Format_MUSIC_XML).
+					Return(&model.TuneFile{
+						TuneID: tuneID,
+						Format: fileformat.Format_MUSIC_XML,
+						Data:   []byte("<score-partwise/>"),
+					}, nil)
+			})
+
+			It("should return the exported file as attachment", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Header().Get("Content-Type")).
+					To(Equal("application/vnd.recordare.musicxml+xml"))
+				Expect(httpRec.Header().Get("Content-Disposition")).
+					To(Equal("attachment; filename=Scotland_the_Brave.musicxml"))
+				Expect(httpRec.Body.String()).To(Equal("<score-partwise/>"))
+			})
+		})
+
+		When("the format is lilypond", func() {
+			var muMoFile *model.TuneFile
+
+			BeforeEach(func() {
+				var err error
+				muMoFile, err = model.TuneFileFromMusicModelTune(model.TestParsedTune("Scotland the Brave").Tune)
+				Expect(err).ShouldNot(HaveOccurred())
+				c.Request = httptest.NewRequest(http.MethodGet,
+					"/tunes/"+tuneID.String()+"/export?format=lilypond", nil)
+				dataService.EXPECT().GetTune(tuneID).
+					Return(&apimodel.Tune{Id: tuneID, Title: "Scotland the Brave"}, nil)
+				dataService.EXPECT().GetTuneFile(tuneID, fileformat.Format_MUSIC_MODEL).
+					Return(muMoFile, nil)
+			})
+
+			It("should return the LilyPond source as attachment", func() {
+				muMoTune, err := muMoFile.MusicModelTune()
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Header().Get("Content-Type")).To(Equal(lilypond.ContentType))
+				Expect(httpRec.Header().Get("Content-Disposition")).
+					To(Equal("attachment; filename=Scotland_the_Brave.ly"))
+				Expect(httpRec.Body.Bytes()).To(Equal(lilypond.Write(muMoTune)))
+			})
+		})
+	})
+})
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/revision"
+	"net/http"
+	"strconv"
+)
+
+func (a *Handler) ListTuneRevisions(c *gin.Context) {
+	tuneID, err := uuid.Parse(c.Param("tuneId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	revisions, err := a.service.TuneRevisions(tuneID)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	apiRevisions := make([]apimodel.TuneRevision, len(revisions))
+	for i, r := range revisions {
+		apiRevisions[i] = revision.APITuneRevision(r)
+	}
+
+	c.JSON(http.StatusOK, apiRevisions)
+}
+
+// GetTuneRevisionDiff returns the changes between two revisions of a tune.
+func (a *Handler) GetTuneRevisionDiff(c *gin.Context) {
+	var diffOpts common.RevisionDiffOptions
+	if err := c.ShouldBindQuery(&diffOpts); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tuneID, err := uuid.Parse(c.Param("tuneId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	from, err := a.service.TuneRevision(tuneID, diffOpts.From)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+	to, err := a.service.TuneRevision(tuneID, diffOpts.To)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	diff, err := revision.Diff(from, to)
+	if err != nil {
+		httpErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// RestoreTuneRevision sets a tune back to the state of one of its revisions.
+func (a *Handler) RestoreTuneRevision(c *gin.Context) {
+	tuneID, err := uuid.Parse(c.Param("tuneId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	number, err := strconv.ParseUint(c.Param("revision"), 10, 32)
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tune, err := a.service.RestoreTuneRevision(tuneID, uint(number), requestAuthor(c))
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tune)
+}
@@ -0,0 +1,195 @@
+package api
+
+import (
+	"github.com/SamuelTissot/sqltime"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/auth"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("Api Handler Tune Revisions", func() {
+	var c *gin.Context
+	var httpRec *httptest.ResponseRecorder
+	var api *Handler
+	var tuneID uuid.UUID
+	var createdAt time.Time
+	var dataService *mocks.DataService
+
+	BeforeEach(func() {
+		tuneID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+		createdAt = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+		httpRec = httptest.NewRecorder()
+		c, _ = gin.CreateTestContext(httpRec)
+		dataService = mocks.NewDataService(GinkgoT())
+		api = &Handler{
+			service: dataService,
+		}
+		c.Params = gin.Params{{Key: "tuneId", Value: tuneID.String()}}
+	})
+
+	Context("List Tune Revisions", func() {
+		JustBeforeEach(func() {
+			api.ListTuneRevisions(c)
+		})
+
+		When("no uuid as tuneId", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{{Key: "tuneId", Value: "not a uuid"}}
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the tune doesn't exist", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().TuneRevisions(tuneID).
+					Return(nil, common.ErrNotFound)
+			})
+
+			It("should return NotFound", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("the tune has revisions", func() {
+			BeforeEach(func() {
+				revision := &model.TuneRevision{
+					TuneID: tuneID,
+					Number: 2,
+					Author: "piper",
+					Title:  "Scotland the Brave",
+					Type:   "march",
+					Files: []model.TuneRevisionFile{
+						{Format: fileformat.Format_MUSIC_MODEL},
+						{Format: fileformat.Format_BWW, SingleTuneData: true},
+					},
+				}
+				revision.CreatedAt = sqltime.Time{Time: createdAt}
+				dataService.EXPECT().TuneRevisions(tuneID).
+					Return([]*model.TuneRevision{revision}, nil)
+			})
+
+			It("should return the revisions", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Body.String()).To(Equal(
+					`[{"number":2,"author":"piper","createdAt":"2024-05-01T10:00:00Z",` +
+						`"title":"Scotland the Brave","type":"march","files":["music_model","bww"]}]`))
+			})
+		})
+	})
+
+	Context("Get Tune Revision Diff", func() {
+		JustBeforeEach(func() {
+			api.GetTuneRevisionDiff(c)
+		})
+
+		BeforeEach(func() {
+			c.Request = httptest.NewRequest(http.MethodGet,
+				"/tunes/"+tuneID.String()+"/revisions/diff?from=1&to=2", nil)
+		})
+
+		When("a revision number is missing", func() {
+			BeforeEach(func() {
+				c.Request = httptest.NewRequest(http.MethodGet,
+					"/tunes/"+tuneID.String()+"/revisions/diff?from=1", nil)
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("a revision doesn't exist", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().TuneRevision(tuneID, uint(1)).
+					Return(&model.TuneRevision{Number: 1}, nil)
+				dataService.EXPECT().TuneRevision(tuneID, uint(2)).
+					Return(nil, common.ErrNotFound)
+			})
+
+			It("should return NotFound", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("both revisions exist", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().TuneRevision(tuneID, uint(1)).
+					Return(&model.TuneRevision{Number: 1, Title: "Scotland"}, nil)
+				dataService.EXPECT().TuneRevision(tuneID, uint(2)).
+					Return(&model.TuneRevision{Number: 2, Title: "Scotland the Brave"}, nil)
+			})
+
+			It("should return the changes", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Body.String()).To(Equal(
+					`{"from":1,"to":2,"fields":[{"field":"title","from":"Scotland","to":"Scotland the Brave"}]}`))
+			})
+		})
+	})
+
+	Context("Restore Tune Revision", func() {
+		JustBeforeEach(func() {
+			api.RestoreTuneRevision(c)
+		})
+
+		BeforeEach(func() {
+			c.Params = gin.Params{
+				{Key: "tuneId", Value: tuneID.String()},
+				{Key: "revision", Value: "1"},
+			}
+			c.Request = httptest.NewRequest(http.MethodPost,
+				"/tunes/"+tuneID.String()+"/revisions/1/restore", nil)
+			auth.SetUser(c, &model.User{Name: "piper"})
+		})
+
+		When("the revision is not a number", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{
+					{Key: "tuneId", Value: tuneID.String()},
+					{Key: "revision", Value: "first"},
+				}
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the revision doesn't exist", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().RestoreTuneRevision(tuneID, uint(1), "piper").
+					Return(nil, common.ErrNotFound)
+			})
+
+			It("should return NotFound", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("the revision was restored", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().RestoreTuneRevision(tuneID, uint(1), "piper").
+					Return(&apimodel.Tune{Id: tuneID, Title: "Scotland"}, nil)
+			})
+
+			It("should return the restored tune", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Body.String()).To(ContainSubstring(`"title":"Scotland"`))
+			})
+		})
+	})
+})
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"net/http"
+)
+
+func (a *Handler) ListTuneTypes(c *gin.Context) {
+	tuneTypes, err := a.service.TuneTypes()
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tuneTypes)
+}
+
+func (a *Handler) GetTuneType(c *gin.Context) {
+	tuneTypeID, err := uuid.Parse(c.Param("tuneTypeId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tuneType, err := a.service.GetTuneType(tuneTypeID)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tuneType)
+}
+
+func (a *Handler) UpdateTuneType(c *gin.Context) {
+	var updateType apimodel.UpdateTuneType
+	if err := c.ShouldBindJSON(&updateType); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tuneTypeID, err := uuid.Parse(c.Param("tuneTypeId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tuneType, err := a.service.UpdateTuneType(tuneTypeID, updateType)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tuneType)
+}
+
+// MergeTuneTypes merges the tune types of the request body into
+// the tune type of the path.
+func (a *Handler) MergeTuneTypes(c *gin.Context) {
+	var merge apimodel.MergeTuneTypes
+	if err := c.ShouldBindJSON(&merge); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tuneTypeID, err := uuid.Parse(c.Param("tuneTypeId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tuneType, err := a.service.MergeTuneTypes(tuneTypeID, merge)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tuneType)
+}
+
+// SetTuneTypeAliases replaces the aliases of the tune type with
+// the names in the request body.
+func (a *Handler) SetTuneTypeAliases(c *gin.Context) {
+	var aliases []string
+	if err := c.ShouldBindJSON(&aliases); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tuneTypeID, err := uuid.Parse(c.Param("tuneTypeId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tuneType, err := a.service.SetTuneTypeAliases(tuneTypeID, aliases)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tuneType)
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("Api Handler Tune Types", func() {
+	var c *gin.Context
+	var httpRec *httptest.ResponseRecorder
+	var api *Handler
+	var dataService *mocks.DataService
+	var tuneType *apimodel.TuneType
+
+	BeforeEach(func() {
+		tuneType = &apimodel.TuneType{
+			Id:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Name:      "March",
+			Aliases:   []string{"2/4 March"},
+			TuneCount: 3,
+		}
+
+		httpRec = httptest.NewRecorder()
+		c, _ = gin.CreateTestContext(httpRec)
+		dataService = mocks.NewDataService(GinkgoT())
+		api = &Handler{
+			service: dataService,
+		}
+	})
+
+	Context("List Tune Types", func() {
+		JustBeforeEach(func() {
+			api.ListTuneTypes(c)
+		})
+
+		When("there is a tune type", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().TuneTypes().Return([]*apimodel.TuneType{tuneType}, nil)
+			})
+
+			It("should return the tune type", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Body.String()).To(Equal(
+					`[{"id":"00000000-0000-0000-0000-000000000001","name":"March",` +
+						`"aliases":["2/4 March"],"tuneCount":3}]`))
+			})
+		})
+	})
+
+	Context("Update Tune Type", func() {
+		BeforeEach(func() {
+			c.Params = gin.Params{{Key: "tuneTypeId", Value: tuneType.Id.String()}}
+		})
+
+		JustBeforeEach(func() {
+			api.UpdateTuneType(c)
+		})
+
+		When("the name is missing", func() {
+			BeforeEach(func() {
+				mockJSONPost(c, http.MethodPut, apimodel.UpdateTuneType{})
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("another tune type has the name", func() {
+			BeforeEach(func() {
+				update := apimodel.UpdateTuneType{Name: "Retreat"}
+				mockJSONPost(c, http.MethodPut, update)
+				dataService.EXPECT().UpdateTuneType(tuneType.Id, update).
+					Return(nil, fmt.Errorf("%w: tune type retreat", common.ErrAlreadyExists))
+			})
+
+			It("should return Conflict", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusConflict))
+			})
+		})
+	})
+
+	Context("Merge Tune Types", func() {
+		var otherID uuid.UUID
+
+		BeforeEach(func() {
+			otherID = uuid.MustParse("00000000-0000-0000-0000-000000000002")
+			c.Params = gin.Params{{Key: "tuneTypeId", Value: tuneType.Id.String()}}
+		})
+
+		JustBeforeEach(func() {
+			api.MergeTuneTypes(c)
+		})
+
+		When("no tune types to merge are given", func() {
+			BeforeEach(func() {
+				mockJSONPost(c, http.MethodPost, apimodel.MergeTuneTypes{TuneTypeIds: []uuid.UUID{}})
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("merging another tune type", func() {
+			BeforeEach(func() {
+				merge := apimodel.MergeTuneTypes{TuneTypeIds: []uuid.UUID{otherID}}
+				mockJSONPost(c, http.MethodPost, merge)
+				dataService.EXPECT().MergeTuneTypes(tuneType.Id, merge).Return(tuneType, nil)
+			})
+
+			It("should return the merged tune type", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Body.String()).To(ContainSubstring(`"tuneCount":3`))
+			})
+		})
+	})
+
+	Context("Set Tune Type Aliases", func() {
+		BeforeEach(func() {
+			c.Params = gin.Params{{Key: "tuneTypeId", Value: "not a uuid"}}
+			mockJSONPost(c, http.MethodPut, []string{"2/4 March"})
+		})
+
+		JustBeforeEach(func() {
+			api.SetTuneTypeAliases(c)
+		})
+
+		It("should return BadRequest for no uuid as tuneTypeId", func() {
+			Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+})
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/auth"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"net/http"
+)
+
+// authenticatedUser returns the user of the request. If there is none,
+// it responds with an unauthorized error and returns nil.
+func authenticatedUser(c *gin.Context) *model.User {
+	user := auth.User(c)
+	if user == nil {
+		httpErrorResponse(c, http.StatusUnauthorized,
+			fmt.Errorf("%w: request is not authenticated", auth.ErrUnauthorized))
+	}
+
+	return user
+}
+
+func (a *Handler) GetCurrentUser(c *gin.Context) {
+	user := authenticatedUser(c)
+	if user == nil {
+		return
+	}
+
+	apiUser := auth.APIUser(user)
+	apiUser.Role = string(auth.Role(c))
+	c.JSON(http.StatusOK, apiUser)
+}
+
+func (a *Handler) ListAPITokens(c *gin.Context) {
+	user := authenticatedUser(c)
+	if user == nil {
+		return
+	}
+
+	tokens, err := a.service.APITokens(user.ID)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	apiTokens := make([]apimodel.ApiToken, len(tokens))
+	for i, t := range tokens {
+		apiTokens[i] = auth.APIToken(t)
+	}
+
+	c.JSON(http.StatusOK, apiTokens)
+}
+
+// CreateAPIToken creates a new API token for the user of the request.
+// The token is only part of this response.
+func (a *Handler) CreateAPIToken(c *gin.Context) {
+	var createToken apimodel.CreateApiToken
+	if err := c.ShouldBindJSON(&createToken); err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	user := authenticatedUser(c)
+	if user == nil {
+		return
+	}
+
+	apiToken, token, err := a.service.CreateAPIToken(user.ID, createToken.Name)
+	if err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, apimodel.CreatedApiToken{
+		ApiToken: auth.APIToken(apiToken),
+		Token:    token,
+	})
+}
+
+func (a *Handler) DeleteAPIToken(c *gin.Context) {
+	tokenID, err := uuid.Parse(c.Param("tokenId"))
+	if err != nil {
+		httpErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	user := authenticatedUser(c)
+	if user == nil {
+		return
+	}
+
+	if err = a.service.DeleteAPIToken(user.ID, tokenID); err != nil {
+		handleResponseForError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"github.com/SamuelTissot/sqltime"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/auth"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("Api Handler Users", func() {
+	var c *gin.Context
+	var httpRec *httptest.ResponseRecorder
+	var api *Handler
+	var dataService *mocks.DataService
+	var user *model.User
+	var tokenID uuid.UUID
+	var createdAt time.Time
+
+	BeforeEach(func() {
+		tokenID = uuid.MustParse("00000000-0000-0000-0000-000000000002")
+		createdAt = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+		user = &model.User{
+			BaseModel: model.BaseModel{ID: uuid.MustParse("00000000-0000-0000-0000-000000000001")},
+			Name:      "piper",
+		}
+
+		httpRec = httptest.NewRecorder()
+		c, _ = gin.CreateTestContext(httpRec)
+		dataService = mocks.NewDataService(GinkgoT())
+		api = &Handler{
+			service: dataService,
+		}
+		auth.SetUser(c, user)
+	})
+
+	Context("Get Current User", func() {
+		JustBeforeEach(func() {
+			api.GetCurrentUser(c)
+		})
+
+		When("the request is not authenticated", func() {
+			BeforeEach(func() {
+				c, _ = gin.CreateTestContext(httpRec)
+			})
+
+			It("should return Unauthorized", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		It("should return the user", func() {
+			Expect(httpRec.Code).To(Equal(http.StatusOK))
+			Expect(httpRec.Body.String()).To(Equal(
+				`{"id":"00000000-0000-0000-0000-000000000001","name":"piper"}`))
+		})
+
+		When("the user has a role", func() {
+			BeforeEach(func() {
+				auth.SetRole(c, common.RoleEditor)
+			})
+
+			It("should return the user with the role", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Body.String()).To(Equal(
+					`{"id":"00000000-0000-0000-0000-000000000001","name":"piper","role":"editor"}`))
+			})
+		})
+	})
+
+	Context("List API Tokens", func() {
+		JustBeforeEach(func() {
+			api.ListAPITokens(c)
+		})
+
+		When("the user has a token", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().APITokens(user.ID).Return([]*model.APIToken{
+					{
+						BaseModel: model.BaseModel{
+							ID:        tokenID,
+							CreatedAt: sqltime.Time{Time: createdAt},
+						},
+						Name: "laptop",
+						Hash: "secret hash",
+					},
+				}, nil)
+			})
+
+			It("should return the tokens without their hash", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Body.String()).To(Equal(
+					`[{"id":"00000000-0000-0000-0000-000000000002","name":"laptop",` +
+						`"createdAt":"2024-05-01T10:00:00Z"}]`))
+			})
+		})
+	})
+
+	Context("Create API Token", func() {
+		JustBeforeEach(func() {
+			api.CreateAPIToken(c)
+		})
+
+		When("no name is given", func() {
+			BeforeEach(func() {
+				mockJSONPost(c, http.MethodPost, apimodel.CreateApiToken{})
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the token was created", func() {
+			BeforeEach(func() {
+				mockJSONPost(c, http.MethodPost, apimodel.CreateApiToken{Name: "laptop"})
+				dataService.EXPECT().CreateAPIToken(user.ID, "laptop").Return(&model.APIToken{
+					BaseModel: model.BaseModel{
+						ID:        tokenID,
+						CreatedAt: sqltime.Time{Time: createdAt},
+					},
+					Name: "laptop",
+				}, "lp_token", nil)
+			})
+
+			It("should return the token once", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(httpRec.Body.String()).To(Equal(
+					`{"id":"00000000-0000-0000-0000-000000000002","name":"laptop",` +
+						`"createdAt":"2024-05-01T10:00:00Z","token":"lp_token"}`))
+			})
+		})
+	})
+
+	Context("Delete API Token", func() {
+		BeforeEach(func() {
+			c.Params = gin.Params{{Key: "tokenId", Value: tokenID.String()}}
+		})
+
+		JustBeforeEach(func() {
+			api.DeleteAPIToken(c)
+		})
+
+		When("no uuid as tokenId", func() {
+			BeforeEach(func() {
+				c.Params = gin.Params{{Key: "tokenId", Value: "not a uuid"}}
+			})
+
+			It("should return BadRequest", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the token is not a token of the user", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().DeleteAPIToken(user.ID, tokenID).
+					Return(common.ErrNotFound)
+			})
+
+			It("should return NotFound", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("the token was deleted", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().DeleteAPIToken(user.ID, tokenID).Return(nil)
+			})
+
+			It("should return NoContent", func() {
+				Expect(c.Writer.Status()).To(Equal(http.StatusNoContent))
+			})
+		})
+	})
+})
@@ -0,0 +1,28 @@
+/*
+ * Set and Tune API
+ *
+ * API for managing sets and tunes
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apimodel
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type ApiToken struct {
+
+	// Unique identifier for an object
+	Id uuid.UUID `json:"id"`
+
+	// The name that describes what the token is used for
+	Name string `json:"name"`
+
+	CreatedAt time.Time `json:"createdAt"`
+
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+}
@@ -0,0 +1,22 @@
+/*
+ * Set and Tune API
+ *
+ * API for managing sets and tunes
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apimodel
+
+import "github.com/google/uuid"
+
+// BookletEntry - Either a set or a single tune of a booklet
+type BookletEntry struct {
+
+	// The id of a set whose tunes are added in their order
+	SetId *uuid.UUID `json:"setId,omitempty" binding:"required_without=TuneId,excluded_with=TuneId"`
+
+	// The id of a single tune
+	TuneId *uuid.UUID `json:"tuneId,omitempty"`
+}
@@ -0,0 +1,16 @@
+/*
+ * Set and Tune API
+ *
+ * API for managing sets and tunes
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apimodel
+
+type CreateApiToken struct {
+
+	// The name that describes what the token is used for
+	Name string `json:"name" binding:"required"`
+}
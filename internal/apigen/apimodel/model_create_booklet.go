@@ -0,0 +1,19 @@
+/*
+ * Set and Tune API
+ *
+ * API for managing sets and tunes
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apimodel
+
+type CreateBooklet struct {
+
+	// The title on the title page of the booklet
+	Title string `json:"title" binding:"required"`
+
+	// The sets and tunes of the booklet in their order
+	Entries []BookletEntry `json:"entries" binding:"required,min=1,dive"`
+}
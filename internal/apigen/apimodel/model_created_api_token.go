@@ -0,0 +1,17 @@
+/*
+ * Set and Tune API
+ *
+ * API for managing sets and tunes
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apimodel
+
+type CreatedApiToken struct {
+	ApiToken
+
+	// The token, it is only returned once on creation
+	Token string `json:"token"`
+}
@@ -0,0 +1,19 @@
+/*
+ * Set and Tune API
+ *
+ * API for managing sets and tunes
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apimodel
+
+type FieldChange struct {
+
+	Field string `json:"field"`
+
+	From string `json:"from"`
+
+	To string `json:"to"`
+}
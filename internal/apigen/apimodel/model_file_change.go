@@ -0,0 +1,18 @@
+/*
+ * Set and Tune API
+ *
+ * API for managing sets and tunes
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apimodel
+
+type FileChange struct {
+
+	Format string `json:"format"`
+
+	// added, removed or changed
+	Change string `json:"change"`
+}
@@ -14,8 +14,14 @@ type ImportFile struct {
 	// the imported filename
 	Name string `json:"name" binding:"required"`
 
-	Set BasicMusicSet `json:"set,omitempty"`
+	Set *BasicMusicSet `json:"set,omitempty"`
 
 	// if import was successful, the array of imported tunes
 	Tunes []*ImportTune `json:"tunes,omitempty"`
+
+	// true if the file was skipped because it was already imported before
+	Duplicate bool `json:"duplicate,omitempty"`
+
+	// if import failed, the reason why the file couldn't be imported
+	Error string `json:"error,omitempty"`
 }
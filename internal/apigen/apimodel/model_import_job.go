@@ -0,0 +1,44 @@
+/*
+ * Set and Tune API
+ *
+ * API for managing sets and tunes
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apimodel
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type ImportJob struct {
+
+	// Unique identifier for an object
+	Id uuid.UUID `json:"id"`
+
+	// The status of the import job, one of queued, running, completed or failed
+	Status string `json:"status"`
+
+	// The name of the uploaded file
+	FileName string `json:"fileName"`
+
+	// The reason why the whole import job failed
+	Error string `json:"error,omitempty"`
+
+	Progress ImportProgress `json:"progress"`
+
+	// The import result of every file of the job
+	Files []ImportFile `json:"files,omitempty"`
+
+	// The sets that were created for the folders of an imported archive
+	FolderSets []*BasicMusicSet `json:"folderSets,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+}
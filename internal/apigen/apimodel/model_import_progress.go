@@ -0,0 +1,19 @@
+/*
+ * Set and Tune API
+ *
+ * API for managing sets and tunes
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apimodel
+
+type ImportProgress struct {
+
+	// The number of files to import
+	Total int32 `json:"total"`
+
+	// The number of files that were already processed
+	Done int32 `json:"done"`
+}
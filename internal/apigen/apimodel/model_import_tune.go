@@ -31,4 +31,10 @@ type ImportTune struct {
 	Errors []string `json:"errors,omitempty"`
 
 	Infos []string `json:"infos,omitempty"`
+
+	// What happened to the tune on a re-import (created, updated or unchanged)
+	Action string `json:"action,omitempty"`
+
+	// The fields and files of the tune that were changed by a re-import
+	Changes []string `json:"changes,omitempty"`
 }
@@ -0,0 +1,22 @@
+/*
+ * Set and Tune API
+ *
+ * API for managing sets and tunes
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apimodel
+
+type MeasureChange struct {
+
+	// added, removed or changed
+	Change string `json:"change"`
+
+	// Number of the measure in the revision the changes are compared from
+	From int32 `json:"from,omitempty"`
+
+	// Number of the measure in the revision the changes are compared to
+	To int32 `json:"to,omitempty"`
+}
@@ -0,0 +1,31 @@
+/*
+ * Set and Tune API
+ *
+ * API for managing sets and tunes
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apimodel
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type Membership struct {
+
+	// Unique identifier for an object
+	UserId uuid.UUID `json:"userId"`
+
+	// The unique name of the user
+	UserName string `json:"userName"`
+
+	// The role of the user, one of admin, editor or viewer
+	Role string `json:"role"`
+
+	CreatedAt time.Time `json:"createdAt"`
+
+	UpdatedAt time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,18 @@
+/*
+ * Set and Tune API
+ *
+ * API for managing sets and tunes
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apimodel
+
+import "github.com/google/uuid"
+
+type MergePeople struct {
+
+	// The ids of the people to merge into the person
+	PersonIds []uuid.UUID `json:"personIds" binding:"required,min=1"`
+}
@@ -0,0 +1,18 @@
+/*
+ * Set and Tune API
+ *
+ * API for managing sets and tunes
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apimodel
+
+import "github.com/google/uuid"
+
+type MergeTuneTypes struct {
+
+	// The ids of the tune types to merge into the tune type
+	TuneTypeIds []uuid.UUID `json:"tuneTypeIds" binding:"required,min=1"`
+}
@@ -23,8 +23,17 @@ type MusicSet struct {
 	// A description of the Set
 	Description string `json:"description,omitempty"`
 
-	// The name of the creator of the set
+	// The name of the creator of the set, it is the name of the owner for sets that have one
 	Creator string `json:"creator,omitempty"`
 
+	// Private sets are only visible to their owner
+	Private bool `json:"private,omitempty"`
+
+	// The id of the user who owns the set
+	OwnerId *uuid.UUID `json:"ownerId,omitempty"`
+
+	// The names of the tags of the set
+	Tags []string `json:"tags,omitempty"`
+
 	Tunes []Tune `json:"tunes,omitempty"`
 }
@@ -0,0 +1,25 @@
+/*
+ * Set and Tune API
+ *
+ * API for managing sets and tunes
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apimodel
+
+type Pagination struct {
+
+	// The current page, starting with 1
+	Page int32 `json:"page"`
+
+	// The maximum number of items on a page
+	PageSize int32 `json:"pageSize"`
+
+	// The number of all items that match the request
+	TotalCount int64 `json:"totalCount"`
+
+	// The number of pages for the given page size
+	TotalPages int32 `json:"totalPages"`
+}
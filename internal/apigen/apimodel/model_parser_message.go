@@ -0,0 +1,32 @@
+/*
+ * Set and Tune API
+ *
+ * API for managing sets and tunes
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apimodel
+
+import "github.com/google/uuid"
+
+type ParserMessage struct {
+
+	// The id of the tune the message is about
+	TuneId uuid.UUID `json:"tuneId"`
+
+	// The number of the measure starting with 1
+	Measure int32 `json:"measure"`
+
+	// The severity of the message, e.g. error, warning or info
+	Severity string `json:"severity"`
+
+	// The symbol of the imported file the message is about
+	Symbol string `json:"symbol,omitempty"`
+
+	Text string `json:"text"`
+
+	// How the parser handled the symbol, e.g. skipsymbol
+	Fix string `json:"fix,omitempty"`
+}
@@ -0,0 +1,24 @@
+/*
+ * Set and Tune API
+ *
+ * API for managing sets and tunes
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apimodel
+
+import "github.com/google/uuid"
+
+type Person struct {
+
+	// Unique identifier for an object
+	Id uuid.UUID `json:"id"`
+
+	// The unique name of the person
+	Name string `json:"name"`
+
+	// Other spellings of the name that tunes are linked to this person for
+	Aliases []string `json:"aliases,omitempty"`
+}
@@ -0,0 +1,29 @@
+/*
+ * Set and Tune API
+ *
+ * API for managing sets and tunes
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apimodel
+
+import "github.com/google/uuid"
+
+type SearchHit struct {
+
+	// The kind of the found object (tune or set)
+	Kind string `json:"kind"`
+
+	// Unique identifier for an object
+	Id uuid.UUID `json:"id"`
+
+	Title string `json:"title"`
+
+	// The relevance of the hit, higher is better
+	Rank float64 `json:"rank"`
+
+	// HTML escaped text fragments of the matched fields with the matches enclosed in <mark></mark> tags
+	Highlights map[string]string `json:"highlights,omitempty"`
+}
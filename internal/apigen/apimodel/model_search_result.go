@@ -0,0 +1,19 @@
+/*
+ * Set and Tune API
+ *
+ * API for managing sets and tunes
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apimodel
+
+type SearchResult struct {
+
+	// The query that was searched for
+	Query string `json:"query"`
+
+	// The found tunes and sets ordered by their relevance
+	Hits []SearchHit `json:"hits"`
+}
@@ -0,0 +1,24 @@
+/*
+ * Set and Tune API
+ *
+ * API for managing sets and tunes
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apimodel
+
+import "github.com/google/uuid"
+
+type Tag struct {
+
+	// Unique identifier for an object
+	Id uuid.UUID `json:"id"`
+
+	// The unique name of the tag
+	Name string `json:"name"`
+
+	// A description of what the tag is used for
+	Description string `json:"description,omitempty"`
+}
@@ -24,5 +24,17 @@ type Tune struct {
 
 	Composer string `json:"composer,omitempty"`
 
+	// The id of the person who composed the tune
+	ComposerId *uuid.UUID `json:"composerId,omitempty"`
+
 	Arranger string `json:"arranger,omitempty"`
+
+	// The id of the person who arranged the tune
+	ArrangerId *uuid.UUID `json:"arrangerId,omitempty"`
+
+	// The id of the user who owns the tune
+	OwnerId *uuid.UUID `json:"ownerId,omitempty"`
+
+	// The names of the tags of the tune
+	Tags []string `json:"tags,omitempty"`
 }
@@ -0,0 +1,49 @@
+/*
+ * Set and Tune API
+ *
+ * API for managing sets and tunes
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apimodel
+
+type TuneAnalysis struct {
+
+	// The number of analysed tunes, which is 1 for a single tune
+	TuneCount int32 `json:"tuneCount"`
+
+	// The number of measures with notes or rests
+	MeasureCount int32 `json:"measureCount"`
+
+	// The number of parts, which are separated by heavy barlines
+	PartCount int32 `json:"partCount"`
+
+	// The time signatures in the order they are first used
+	TimeSignatures []string `json:"timeSignatures"`
+
+	LowestPitch string `json:"lowestPitch,omitempty"`
+
+	HighestPitch string `json:"highestPitch,omitempty"`
+
+	// The number of notes by their pitch
+	Pitches map[string]int32 `json:"pitches"`
+
+	// The number of notes by their length
+	NoteLengths map[string]int32 `json:"noteLengths"`
+
+	// The number of embellishments by their variant and type, e.g. HalfDoubling
+	Embellishments map[string]int32 `json:"embellishments"`
+
+	// The number of piobaireachd movements by their variant and type
+	Movements map[string]int32 `json:"movements,omitempty"`
+
+	// The duration in seconds of the playback of the tunes, as in their MIDI and audio files: repeated parts
+	// are played twice without their first endings and tunes without a tempo at 80 beats per minute.
+	// It is missing if no tune has measures.
+	Duration *float64 `json:"duration,omitempty"`
+
+	// The messages of the parser when the tunes were imported
+	Messages []ParserMessage `json:"messages,omitempty"`
+}
@@ -0,0 +1,17 @@
+/*
+ * Set and Tune API
+ *
+ * API for managing sets and tunes
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apimodel
+
+type TuneDuplicate struct {
+	Tune Tune `json:"tune"`
+
+	// How similar the music of the tunes is, from 0 for nothing in common to 1 for the same music
+	Similarity float64 `json:"similarity"`
+}
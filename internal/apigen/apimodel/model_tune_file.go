@@ -0,0 +1,22 @@
+/*
+ * Set and Tune API
+ *
+ * API for managing sets and tunes
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apimodel
+
+type TuneFile struct {
+
+	// The file format in lower case e.g. bww or music_model
+	Format string `json:"format"`
+
+	// The MIME type the file is served with
+	ContentType string `json:"contentType"`
+
+	// The size of the file in bytes
+	Size int64 `json:"size"`
+}
@@ -0,0 +1,38 @@
+/*
+ * Set and Tune API
+ *
+ * API for managing sets and tunes
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apimodel
+
+import (
+	"time"
+)
+
+type TuneRevision struct {
+
+	// Consecutive number of the revision for the tune
+	Number int32 `json:"number"`
+
+	// The user who made the change
+	Author string `json:"author,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+
+	Title string `json:"title"`
+
+	Type string `json:"type,omitempty"`
+
+	TimeSig string `json:"timeSig,omitempty"`
+
+	Composer string `json:"composer,omitempty"`
+
+	Arranger string `json:"arranger,omitempty"`
+
+	// The file formats of the tune files of the revision
+	Files []string `json:"files,omitempty"`
+}
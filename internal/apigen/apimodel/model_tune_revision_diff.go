@@ -0,0 +1,25 @@
+/*
+ * Set and Tune API
+ *
+ * API for managing sets and tunes
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apimodel
+
+type TuneRevisionDiff struct {
+
+	// Number of the revision the changes are compared from
+	From int32 `json:"from"`
+
+	// Number of the revision the changes are compared to
+	To int32 `json:"to"`
+
+	Fields []FieldChange `json:"fields,omitempty"`
+
+	Files []FileChange `json:"files,omitempty"`
+
+	Measures []MeasureChange `json:"measures,omitempty"`
+}
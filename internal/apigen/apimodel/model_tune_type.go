@@ -0,0 +1,27 @@
+/*
+ * Set and Tune API
+ *
+ * API for managing sets and tunes
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apimodel
+
+import "github.com/google/uuid"
+
+type TuneType struct {
+
+	// Unique identifier for an object
+	Id uuid.UUID `json:"id"`
+
+	// The unique name of the tune type
+	Name string `json:"name"`
+
+	// Other names of the tune type that imported tunes are given this type for
+	Aliases []string `json:"aliases,omitempty"`
+
+	// The number of tunes of this type
+	TuneCount int64 `json:"tuneCount"`
+}
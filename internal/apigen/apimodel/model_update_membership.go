@@ -0,0 +1,16 @@
+/*
+ * Set and Tune API
+ *
+ * API for managing sets and tunes
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apimodel
+
+type UpdateMembership struct {
+
+	// The role of the user, one of admin, editor or viewer
+	Role string `json:"role" binding:"required,oneof=admin editor viewer"`
+}
@@ -22,5 +22,8 @@ type UpdateSet struct {
 	// The name of the creator of the set
 	Creator string `json:"creator,omitempty"`
 
+	// Private sets are only visible to their owner
+	Private bool `json:"private,omitempty"`
+
 	Tunes []uuid.UUID `json:"tunes,omitempty"`
 }
@@ -0,0 +1,19 @@
+/*
+ * Set and Tune API
+ *
+ * API for managing sets and tunes
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apimodel
+
+type UpdateTag struct {
+
+	// The unique name of the tag
+	Name string `json:"name" binding:"required"`
+
+	// A description of what the tag is used for
+	Description string `json:"description,omitempty"`
+}
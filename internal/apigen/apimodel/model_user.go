@@ -0,0 +1,24 @@
+/*
+ * Set and Tune API
+ *
+ * API for managing sets and tunes
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apimodel
+
+import "github.com/google/uuid"
+
+type User struct {
+
+	// Unique identifier for an object
+	Id uuid.UUID `json:"id"`
+
+	// The unique name of the user
+	Name string `json:"name"`
+
+	// The role of the user, empty if the user has no role
+	Role string `json:"role,omitempty"`
+}
@@ -16,34 +16,146 @@ import (
 type ApiHandler interface {
 
 
+    // AssignTagsToSet Put /sets/:setId/tags
+    // Replace the tags of a set 
+     AssignTagsToSet(c *gin.Context)
+
+    // AssignTagsToTune Put /tunes/:tuneId/tags
+    // Replace the tags of a tune 
+     AssignTagsToTune(c *gin.Context)
+
     // AssignTunesToSet Put /sets/:setId/tunes
     // Assign tunes to a set 
      AssignTunesToSet(c *gin.Context)
 
+    // CreateAPIToken Post /users/me/tokens
+    // Create an API token for the authenticated user 
+     CreateAPIToken(c *gin.Context)
+
+    // CreateBooklet Post /booklets
+    // Engrave sets and tunes as PDF booklet 
+     CreateBooklet(c *gin.Context)
+
     // CreateSet Post /sets
     // Create a new set 
      CreateSet(c *gin.Context)
 
+    // CreateTag Post /tags
+    // Create a tag 
+     CreateTag(c *gin.Context)
+
     // CreateTune Post /tunes
     // Create a new tune 
      CreateTune(c *gin.Context)
 
+    // DeleteAPIToken Delete /users/me/tokens/:tokenId
+    // Delete an API token of the authenticated user 
+     DeleteAPIToken(c *gin.Context)
+
+    // DeleteImport Delete /imports/:importId
+    // Delete an import and roll back what it created 
+     DeleteImport(c *gin.Context)
+
+    // DeleteMembership Delete /memberships/:userId
+    // Remove the membership of a user 
+     DeleteMembership(c *gin.Context)
+
     // DeleteSet Delete /sets/:setId
     // Delete a set by ID 
      DeleteSet(c *gin.Context)
 
+    // DeleteTag Delete /tags/:tagId
+    // Delete a tag 
+     DeleteTag(c *gin.Context)
+
     // DeleteTune Delete /tunes/:tuneId
     // Delete a tune by ID 
      DeleteTune(c *gin.Context)
 
+    // DeleteTuneFile Delete /tunes/:tuneId/files/:format
+    // Delete the file of a tune in the given format 
+     DeleteTuneFile(c *gin.Context)
+
+    // ExportTune Get /tunes/:tuneId/export
+    // Export a tune to another file format 
+     ExportTune(c *gin.Context)
+
+    // GetCurrentUser Get /users/me
+    // Get the authenticated user 
+     GetCurrentUser(c *gin.Context)
+
+    // GetImport Get /imports/:importId
+    // Get an import job 
+     GetImport(c *gin.Context)
+
+    // GetImportEvents Get /imports/:importId/events
+    // Stream the progress of an import job 
+     GetImportEvents(c *gin.Context)
+
+    // GetImportOriginal Get /imports/:importId/original
+    // Download the original file of an import 
+     GetImportOriginal(c *gin.Context)
+
+    // GetPerson Get /people/:personId
+    // Get a person 
+     GetPerson(c *gin.Context)
+
     // GetSet Get /sets/:setId
     // Get a set by ID 
      GetSet(c *gin.Context)
 
+    // GetSetAnalysis Get /sets/:setId/analysis
+    // Get the combined analysis of the tunes of a set 
+     GetSetAnalysis(c *gin.Context)
+
+    // GetSetAudio Get /sets/:setId/audio
+    // Synthesize the tunes of the set as one audio file 
+     GetSetAudio(c *gin.Context)
+
+    // GetTag Get /tags/:tagId
+    // Get a tag 
+     GetTag(c *gin.Context)
+
     // GetTune Get /tunes/:tuneId
     // Get a tune by ID 
      GetTune(c *gin.Context)
 
+    // GetTuneAnalysis Get /tunes/:tuneId/analysis
+    // Get the analysis of the music of a tune 
+     GetTuneAnalysis(c *gin.Context)
+
+    // GetTuneAudio Get /tunes/:tuneId/audio
+    // Synthesize the tune as audio file 
+     GetTuneAudio(c *gin.Context)
+
+    // GetTuneFile Get /tunes/:tuneId/files/:format
+    // Download the file of a tune in the given format 
+     GetTuneFile(c *gin.Context)
+
+    // GetTuneMidi Get /tunes/:tuneId/midi
+    // Render a tune as MIDI file 
+     GetTuneMidi(c *gin.Context)
+
+    // GetTuneRevisionDiff Get /tunes/:tuneId/revisions/diff
+    // Compare two revisions of a tune 
+     GetTuneRevisionDiff(c *gin.Context)
+
+    // GetTuneScorePdf Get /tunes/:tuneId/score.pdf
+    // Engrave the tune as PDF document 
+     GetTuneScorePdf(c *gin.Context)
+
+    // GetTuneScoreSvg Get /tunes/:tuneId/score.svg
+    // Engrave a page of the tune as SVG image 
+     GetTuneScoreSvg(c *gin.Context)
+
+    // GetTuneType Get /tune-types/:tuneTypeId
+    // Get a tune type 
+     GetTuneType(c *gin.Context)
+
+    // GetTunesAnalysis Get /tunes/analysis
+    // Get the combined analysis of the tunes matching the filters 
+     GetTunesAnalysis(c *gin.Context)
+
     // Health Get /health
     // Check the health of the service 
      Health(c *gin.Context)
@@ -56,20 +168,124 @@ type ApiHandler interface {
     // Import tunes/sets from a file 
      ImportFile(c *gin.Context)
 
+    // ListAPITokens Get /users/me/tokens
+    // List the API tokens of the authenticated user 
+     ListAPITokens(c *gin.Context)
+
+    // ListImports Get /imports
+    // List the import history 
+     ListImports(c *gin.Context)
+
+    // ListMemberships Get /memberships
+    // List the memberships of all users 
+     ListMemberships(c *gin.Context)
+
+    // ListMessages Get /messages
+    // List the parser messages of all tunes 
+     ListMessages(c *gin.Context)
+
+    // ListPeople Get /people
+    // List all people 
+     ListPeople(c *gin.Context)
+
+    // ListPersonTunes Get /people/:personId/tunes
+    // List the tunes of a person 
+     ListPersonTunes(c *gin.Context)
+
     // ListSets Get /sets
     // List all sets 
      ListSets(c *gin.Context)
 
+    // ListTags Get /tags
+    // List all tags 
+     ListTags(c *gin.Context)
+
+    // ListTuneDuplicates Get /tunes/:tuneId/duplicates
+    // List the tunes with similar music 
+     ListTuneDuplicates(c *gin.Context)
+
+    // ListTuneFiles Get /tunes/:tuneId/files
+    // List the file formats available for a tune 
+     ListTuneFiles(c *gin.Context)
+
+    // ListTuneMessages Get /tunes/:tuneId/messages
+    // List the parser messages of a tune 
+     ListTuneMessages(c *gin.Context)
+
+    // ListTuneRevisions Get /tunes/:tuneId/revisions
+    // List the revisions of a tune 
+     ListTuneRevisions(c *gin.Context)
+
+    // ListTuneTypes Get /tune-types
+    // List all tune types 
+     ListTuneTypes(c *gin.Context)
+
     // ListTunes Get /tunes
     // List all tunes 
      ListTunes(c *gin.Context)
 
+    // MergePeople Post /people/:personId/merge
+    // Merge people into a person 
+     MergePeople(c *gin.Context)
+
+    // MergeTuneTypes Post /tune-types/:tuneTypeId/merge
+    // Merge tune types into a tune type 
+     MergeTuneTypes(c *gin.Context)
+
+    // MergeTunes Post /tunes/:tuneId/merge
+    // Merge tunes into a tune 
+     MergeTunes(c *gin.Context)
+
+    // RestoreTuneRevision Post /tunes/:tuneId/revisions/:revision/restore
+    // Restore a revision of a tune 
+     RestoreTuneRevision(c *gin.Context)
+
+    // Search Get /search
+    // Search tunes and sets 
+     Search(c *gin.Context)
+
+    // SetMembership Put /memberships/:userId
+    // Give a user a role 
+     SetMembership(c *gin.Context)
+
+    // SetPersonAliases Put /people/:personId/aliases
+    // Set the aliases of a person 
+     SetPersonAliases(c *gin.Context)
+
+    // SetTuneTypeAliases Put /tune-types/:tuneTypeId/aliases
+    // Replace the aliases of a tune type 
+     SetTuneTypeAliases(c *gin.Context)
+
+    // UpdateMessage Put /messages/:messageId
+    // Mark a parser message as reviewed 
+     UpdateMessage(c *gin.Context)
+
+    // UpdatePerson Put /people/:personId
+    // Rename a person 
+     UpdatePerson(c *gin.Context)
+
     // UpdateSet Put /sets/:setId
     // Update a set by ID 
      UpdateSet(c *gin.Context)
 
+    // UpdateTag Put /tags/:tagId
+    // Update a tag 
+     UpdateTag(c *gin.Context)
+
     // UpdateTune Put /tunes/:tuneId
     // Update a tune by ID 
      UpdateTune(c *gin.Context)
 
+    // UpdateTuneMessages Put /tunes/:tuneId/messages
+    // Mark all parser messages of a tune as reviewed 
+     UpdateTuneMessages(c *gin.Context)
+
+    // UpdateTuneType Put /tune-types/:tuneTypeId
+    // Rename a tune type 
+     UpdateTuneType(c *gin.Context)
+
+    // UploadTuneFile Post /tunes/:tuneId/files/:format
+    // Upload a file of a tune in the given format 
+     UploadTuneFile(c *gin.Context)
+
 }
\ No newline at end of file
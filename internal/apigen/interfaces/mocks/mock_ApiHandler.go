@@ -21,6 +21,72 @@ func (_m *ApiHandler) EXPECT() *ApiHandler_Expecter {
 	return &ApiHandler_Expecter{mock: &_m.Mock}
 }
 
+// AssignTagsToSet provides a mock function with given fields: c
+func (_m *ApiHandler) AssignTagsToSet(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_AssignTagsToSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignTagsToSet'
+type ApiHandler_AssignTagsToSet_Call struct {
+	*mock.Call
+}
+
+// AssignTagsToSet is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) AssignTagsToSet(c interface{}) *ApiHandler_AssignTagsToSet_Call {
+	return &ApiHandler_AssignTagsToSet_Call{Call: _e.mock.On("AssignTagsToSet", c)}
+}
+
+func (_c *ApiHandler_AssignTagsToSet_Call) Run(run func(c *gin.Context)) *ApiHandler_AssignTagsToSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_AssignTagsToSet_Call) Return() *ApiHandler_AssignTagsToSet_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_AssignTagsToSet_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_AssignTagsToSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AssignTagsToTune provides a mock function with given fields: c
+func (_m *ApiHandler) AssignTagsToTune(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_AssignTagsToTune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignTagsToTune'
+type ApiHandler_AssignTagsToTune_Call struct {
+	*mock.Call
+}
+
+// AssignTagsToTune is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) AssignTagsToTune(c interface{}) *ApiHandler_AssignTagsToTune_Call {
+	return &ApiHandler_AssignTagsToTune_Call{Call: _e.mock.On("AssignTagsToTune", c)}
+}
+
+func (_c *ApiHandler_AssignTagsToTune_Call) Run(run func(c *gin.Context)) *ApiHandler_AssignTagsToTune_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_AssignTagsToTune_Call) Return() *ApiHandler_AssignTagsToTune_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_AssignTagsToTune_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_AssignTagsToTune_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AssignTunesToSet provides a mock function with given fields: c
 func (_m *ApiHandler) AssignTunesToSet(c *gin.Context) {
 	_m.Called(c)
@@ -54,6 +120,72 @@ func (_c *ApiHandler_AssignTunesToSet_Call) RunAndReturn(run func(*gin.Context))
 	return _c
 }
 
+// CreateAPIToken provides a mock function with given fields: c
+func (_m *ApiHandler) CreateAPIToken(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_CreateAPIToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAPIToken'
+type ApiHandler_CreateAPIToken_Call struct {
+	*mock.Call
+}
+
+// CreateAPIToken is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) CreateAPIToken(c interface{}) *ApiHandler_CreateAPIToken_Call {
+	return &ApiHandler_CreateAPIToken_Call{Call: _e.mock.On("CreateAPIToken", c)}
+}
+
+func (_c *ApiHandler_CreateAPIToken_Call) Run(run func(c *gin.Context)) *ApiHandler_CreateAPIToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_CreateAPIToken_Call) Return() *ApiHandler_CreateAPIToken_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_CreateAPIToken_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_CreateAPIToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateBooklet provides a mock function with given fields: c
+func (_m *ApiHandler) CreateBooklet(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_CreateBooklet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBooklet'
+type ApiHandler_CreateBooklet_Call struct {
+	*mock.Call
+}
+
+// CreateBooklet is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) CreateBooklet(c interface{}) *ApiHandler_CreateBooklet_Call {
+	return &ApiHandler_CreateBooklet_Call{Call: _e.mock.On("CreateBooklet", c)}
+}
+
+func (_c *ApiHandler_CreateBooklet_Call) Run(run func(c *gin.Context)) *ApiHandler_CreateBooklet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_CreateBooklet_Call) Return() *ApiHandler_CreateBooklet_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_CreateBooklet_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_CreateBooklet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateSet provides a mock function with given fields: c
 func (_m *ApiHandler) CreateSet(c *gin.Context) {
 	_m.Called(c)
@@ -87,6 +219,39 @@ func (_c *ApiHandler_CreateSet_Call) RunAndReturn(run func(*gin.Context)) *ApiHa
 	return _c
 }
 
+// CreateTag provides a mock function with given fields: c
+func (_m *ApiHandler) CreateTag(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_CreateTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTag'
+type ApiHandler_CreateTag_Call struct {
+	*mock.Call
+}
+
+// CreateTag is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) CreateTag(c interface{}) *ApiHandler_CreateTag_Call {
+	return &ApiHandler_CreateTag_Call{Call: _e.mock.On("CreateTag", c)}
+}
+
+func (_c *ApiHandler_CreateTag_Call) Run(run func(c *gin.Context)) *ApiHandler_CreateTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_CreateTag_Call) Return() *ApiHandler_CreateTag_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_CreateTag_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_CreateTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateTune provides a mock function with given fields: c
 func (_m *ApiHandler) CreateTune(c *gin.Context) {
 	_m.Called(c)
@@ -120,365 +285,1982 @@ func (_c *ApiHandler_CreateTune_Call) RunAndReturn(run func(*gin.Context)) *ApiH
 	return _c
 }
 
-// DeleteSet provides a mock function with given fields: c
-func (_m *ApiHandler) DeleteSet(c *gin.Context) {
+// DeleteAPIToken provides a mock function with given fields: c
+func (_m *ApiHandler) DeleteAPIToken(c *gin.Context) {
 	_m.Called(c)
 }
 
-// ApiHandler_DeleteSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSet'
-type ApiHandler_DeleteSet_Call struct {
+// ApiHandler_DeleteAPIToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAPIToken'
+type ApiHandler_DeleteAPIToken_Call struct {
 	*mock.Call
 }
 
-// DeleteSet is a helper method to define mock.On call
+// DeleteAPIToken is a helper method to define mock.On call
 //   - c *gin.Context
-func (_e *ApiHandler_Expecter) DeleteSet(c interface{}) *ApiHandler_DeleteSet_Call {
-	return &ApiHandler_DeleteSet_Call{Call: _e.mock.On("DeleteSet", c)}
+func (_e *ApiHandler_Expecter) DeleteAPIToken(c interface{}) *ApiHandler_DeleteAPIToken_Call {
+	return &ApiHandler_DeleteAPIToken_Call{Call: _e.mock.On("DeleteAPIToken", c)}
 }
 
-func (_c *ApiHandler_DeleteSet_Call) Run(run func(c *gin.Context)) *ApiHandler_DeleteSet_Call {
+func (_c *ApiHandler_DeleteAPIToken_Call) Run(run func(c *gin.Context)) *ApiHandler_DeleteAPIToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(*gin.Context))
 	})
 	return _c
 }
 
-func (_c *ApiHandler_DeleteSet_Call) Return() *ApiHandler_DeleteSet_Call {
+func (_c *ApiHandler_DeleteAPIToken_Call) Return() *ApiHandler_DeleteAPIToken_Call {
 	_c.Call.Return()
 	return _c
 }
 
-func (_c *ApiHandler_DeleteSet_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_DeleteSet_Call {
+func (_c *ApiHandler_DeleteAPIToken_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_DeleteAPIToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteTune provides a mock function with given fields: c
-func (_m *ApiHandler) DeleteTune(c *gin.Context) {
+// DeleteImport provides a mock function with given fields: c
+func (_m *ApiHandler) DeleteImport(c *gin.Context) {
 	_m.Called(c)
 }
 
-// ApiHandler_DeleteTune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTune'
-type ApiHandler_DeleteTune_Call struct {
+// ApiHandler_DeleteImport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteImport'
+type ApiHandler_DeleteImport_Call struct {
 	*mock.Call
 }
 
-// DeleteTune is a helper method to define mock.On call
+// DeleteImport is a helper method to define mock.On call
 //   - c *gin.Context
-func (_e *ApiHandler_Expecter) DeleteTune(c interface{}) *ApiHandler_DeleteTune_Call {
-	return &ApiHandler_DeleteTune_Call{Call: _e.mock.On("DeleteTune", c)}
+func (_e *ApiHandler_Expecter) DeleteImport(c interface{}) *ApiHandler_DeleteImport_Call {
+	return &ApiHandler_DeleteImport_Call{Call: _e.mock.On("DeleteImport", c)}
 }
 
-func (_c *ApiHandler_DeleteTune_Call) Run(run func(c *gin.Context)) *ApiHandler_DeleteTune_Call {
+func (_c *ApiHandler_DeleteImport_Call) Run(run func(c *gin.Context)) *ApiHandler_DeleteImport_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(*gin.Context))
 	})
 	return _c
 }
 
-func (_c *ApiHandler_DeleteTune_Call) Return() *ApiHandler_DeleteTune_Call {
+func (_c *ApiHandler_DeleteImport_Call) Return() *ApiHandler_DeleteImport_Call {
 	_c.Call.Return()
 	return _c
 }
 
-func (_c *ApiHandler_DeleteTune_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_DeleteTune_Call {
+func (_c *ApiHandler_DeleteImport_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_DeleteImport_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSet provides a mock function with given fields: c
-func (_m *ApiHandler) GetSet(c *gin.Context) {
+// DeleteMembership provides a mock function with given fields: c
+func (_m *ApiHandler) DeleteMembership(c *gin.Context) {
 	_m.Called(c)
 }
 
-// ApiHandler_GetSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSet'
-type ApiHandler_GetSet_Call struct {
+// ApiHandler_DeleteMembership_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMembership'
+type ApiHandler_DeleteMembership_Call struct {
 	*mock.Call
 }
 
-// GetSet is a helper method to define mock.On call
+// DeleteMembership is a helper method to define mock.On call
 //   - c *gin.Context
-func (_e *ApiHandler_Expecter) GetSet(c interface{}) *ApiHandler_GetSet_Call {
-	return &ApiHandler_GetSet_Call{Call: _e.mock.On("GetSet", c)}
+func (_e *ApiHandler_Expecter) DeleteMembership(c interface{}) *ApiHandler_DeleteMembership_Call {
+	return &ApiHandler_DeleteMembership_Call{Call: _e.mock.On("DeleteMembership", c)}
 }
 
-func (_c *ApiHandler_GetSet_Call) Run(run func(c *gin.Context)) *ApiHandler_GetSet_Call {
+func (_c *ApiHandler_DeleteMembership_Call) Run(run func(c *gin.Context)) *ApiHandler_DeleteMembership_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(*gin.Context))
 	})
 	return _c
 }
 
-func (_c *ApiHandler_GetSet_Call) Return() *ApiHandler_GetSet_Call {
+func (_c *ApiHandler_DeleteMembership_Call) Return() *ApiHandler_DeleteMembership_Call {
 	_c.Call.Return()
 	return _c
 }
 
-func (_c *ApiHandler_GetSet_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_GetSet_Call {
+func (_c *ApiHandler_DeleteMembership_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_DeleteMembership_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTune provides a mock function with given fields: c
-func (_m *ApiHandler) GetTune(c *gin.Context) {
+// DeleteSet provides a mock function with given fields: c
+func (_m *ApiHandler) DeleteSet(c *gin.Context) {
 	_m.Called(c)
 }
 
-// ApiHandler_GetTune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTune'
-type ApiHandler_GetTune_Call struct {
+// ApiHandler_DeleteSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSet'
+type ApiHandler_DeleteSet_Call struct {
 	*mock.Call
 }
 
-// GetTune is a helper method to define mock.On call
+// DeleteSet is a helper method to define mock.On call
 //   - c *gin.Context
-func (_e *ApiHandler_Expecter) GetTune(c interface{}) *ApiHandler_GetTune_Call {
-	return &ApiHandler_GetTune_Call{Call: _e.mock.On("GetTune", c)}
+func (_e *ApiHandler_Expecter) DeleteSet(c interface{}) *ApiHandler_DeleteSet_Call {
+	return &ApiHandler_DeleteSet_Call{Call: _e.mock.On("DeleteSet", c)}
 }
 
-func (_c *ApiHandler_GetTune_Call) Run(run func(c *gin.Context)) *ApiHandler_GetTune_Call {
+func (_c *ApiHandler_DeleteSet_Call) Run(run func(c *gin.Context)) *ApiHandler_DeleteSet_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(*gin.Context))
 	})
 	return _c
 }
 
-func (_c *ApiHandler_GetTune_Call) Return() *ApiHandler_GetTune_Call {
+func (_c *ApiHandler_DeleteSet_Call) Return() *ApiHandler_DeleteSet_Call {
 	_c.Call.Return()
 	return _c
 }
 
-func (_c *ApiHandler_GetTune_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_GetTune_Call {
+func (_c *ApiHandler_DeleteSet_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_DeleteSet_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Health provides a mock function with given fields: c
-func (_m *ApiHandler) Health(c *gin.Context) {
+// DeleteTag provides a mock function with given fields: c
+func (_m *ApiHandler) DeleteTag(c *gin.Context) {
 	_m.Called(c)
 }
 
-// ApiHandler_Health_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Health'
-type ApiHandler_Health_Call struct {
+// ApiHandler_DeleteTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTag'
+type ApiHandler_DeleteTag_Call struct {
 	*mock.Call
 }
 
-// Health is a helper method to define mock.On call
+// DeleteTag is a helper method to define mock.On call
 //   - c *gin.Context
-func (_e *ApiHandler_Expecter) Health(c interface{}) *ApiHandler_Health_Call {
-	return &ApiHandler_Health_Call{Call: _e.mock.On("Health", c)}
+func (_e *ApiHandler_Expecter) DeleteTag(c interface{}) *ApiHandler_DeleteTag_Call {
+	return &ApiHandler_DeleteTag_Call{Call: _e.mock.On("DeleteTag", c)}
 }
 
-func (_c *ApiHandler_Health_Call) Run(run func(c *gin.Context)) *ApiHandler_Health_Call {
+func (_c *ApiHandler_DeleteTag_Call) Run(run func(c *gin.Context)) *ApiHandler_DeleteTag_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(*gin.Context))
 	})
 	return _c
 }
 
-func (_c *ApiHandler_Health_Call) Return() *ApiHandler_Health_Call {
+func (_c *ApiHandler_DeleteTag_Call) Return() *ApiHandler_DeleteTag_Call {
 	_c.Call.Return()
 	return _c
 }
 
-func (_c *ApiHandler_Health_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_Health_Call {
+func (_c *ApiHandler_DeleteTag_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_DeleteTag_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Home provides a mock function with given fields: c
-func (_m *ApiHandler) Home(c *gin.Context) {
+// DeleteTune provides a mock function with given fields: c
+func (_m *ApiHandler) DeleteTune(c *gin.Context) {
 	_m.Called(c)
 }
 
-// ApiHandler_Home_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Home'
-type ApiHandler_Home_Call struct {
+// ApiHandler_DeleteTune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTune'
+type ApiHandler_DeleteTune_Call struct {
 	*mock.Call
 }
 
-// Home is a helper method to define mock.On call
+// DeleteTune is a helper method to define mock.On call
 //   - c *gin.Context
-func (_e *ApiHandler_Expecter) Home(c interface{}) *ApiHandler_Home_Call {
-	return &ApiHandler_Home_Call{Call: _e.mock.On("Home", c)}
+func (_e *ApiHandler_Expecter) DeleteTune(c interface{}) *ApiHandler_DeleteTune_Call {
+	return &ApiHandler_DeleteTune_Call{Call: _e.mock.On("DeleteTune", c)}
 }
 
-func (_c *ApiHandler_Home_Call) Run(run func(c *gin.Context)) *ApiHandler_Home_Call {
+func (_c *ApiHandler_DeleteTune_Call) Run(run func(c *gin.Context)) *ApiHandler_DeleteTune_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(*gin.Context))
 	})
 	return _c
 }
 
-func (_c *ApiHandler_Home_Call) Return() *ApiHandler_Home_Call {
+func (_c *ApiHandler_DeleteTune_Call) Return() *ApiHandler_DeleteTune_Call {
 	_c.Call.Return()
 	return _c
 }
 
-func (_c *ApiHandler_Home_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_Home_Call {
+func (_c *ApiHandler_DeleteTune_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_DeleteTune_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ImportFile provides a mock function with given fields: c
-func (_m *ApiHandler) ImportFile(c *gin.Context) {
+// DeleteTuneFile provides a mock function with given fields: c
+func (_m *ApiHandler) DeleteTuneFile(c *gin.Context) {
 	_m.Called(c)
 }
 
-// ApiHandler_ImportFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportFile'
-type ApiHandler_ImportFile_Call struct {
+// ApiHandler_DeleteTuneFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTuneFile'
+type ApiHandler_DeleteTuneFile_Call struct {
 	*mock.Call
 }
 
-// ImportFile is a helper method to define mock.On call
+// DeleteTuneFile is a helper method to define mock.On call
 //   - c *gin.Context
-func (_e *ApiHandler_Expecter) ImportFile(c interface{}) *ApiHandler_ImportFile_Call {
-	return &ApiHandler_ImportFile_Call{Call: _e.mock.On("ImportFile", c)}
+func (_e *ApiHandler_Expecter) DeleteTuneFile(c interface{}) *ApiHandler_DeleteTuneFile_Call {
+	return &ApiHandler_DeleteTuneFile_Call{Call: _e.mock.On("DeleteTuneFile", c)}
 }
 
-func (_c *ApiHandler_ImportFile_Call) Run(run func(c *gin.Context)) *ApiHandler_ImportFile_Call {
+func (_c *ApiHandler_DeleteTuneFile_Call) Run(run func(c *gin.Context)) *ApiHandler_DeleteTuneFile_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(*gin.Context))
 	})
 	return _c
 }
 
-func (_c *ApiHandler_ImportFile_Call) Return() *ApiHandler_ImportFile_Call {
+func (_c *ApiHandler_DeleteTuneFile_Call) Return() *ApiHandler_DeleteTuneFile_Call {
 	_c.Call.Return()
 	return _c
 }
 
-func (_c *ApiHandler_ImportFile_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_ImportFile_Call {
+func (_c *ApiHandler_DeleteTuneFile_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_DeleteTuneFile_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListSets provides a mock function with given fields: c
-func (_m *ApiHandler) ListSets(c *gin.Context) {
+// ExportTune provides a mock function with given fields: c
+func (_m *ApiHandler) ExportTune(c *gin.Context) {
 	_m.Called(c)
 }
 
-// ApiHandler_ListSets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSets'
-type ApiHandler_ListSets_Call struct {
+// ApiHandler_ExportTune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportTune'
+type ApiHandler_ExportTune_Call struct {
 	*mock.Call
 }
 
-// ListSets is a helper method to define mock.On call
+// ExportTune is a helper method to define mock.On call
 //   - c *gin.Context
-func (_e *ApiHandler_Expecter) ListSets(c interface{}) *ApiHandler_ListSets_Call {
-	return &ApiHandler_ListSets_Call{Call: _e.mock.On("ListSets", c)}
+func (_e *ApiHandler_Expecter) ExportTune(c interface{}) *ApiHandler_ExportTune_Call {
+	return &ApiHandler_ExportTune_Call{Call: _e.mock.On("ExportTune", c)}
 }
 
-func (_c *ApiHandler_ListSets_Call) Run(run func(c *gin.Context)) *ApiHandler_ListSets_Call {
+func (_c *ApiHandler_ExportTune_Call) Run(run func(c *gin.Context)) *ApiHandler_ExportTune_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(*gin.Context))
 	})
 	return _c
 }
 
-func (_c *ApiHandler_ListSets_Call) Return() *ApiHandler_ListSets_Call {
+func (_c *ApiHandler_ExportTune_Call) Return() *ApiHandler_ExportTune_Call {
 	_c.Call.Return()
 	return _c
 }
 
-func (_c *ApiHandler_ListSets_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_ListSets_Call {
+func (_c *ApiHandler_ExportTune_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_ExportTune_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListTunes provides a mock function with given fields: c
-func (_m *ApiHandler) ListTunes(c *gin.Context) {
+// GetCurrentUser provides a mock function with given fields: c
+func (_m *ApiHandler) GetCurrentUser(c *gin.Context) {
 	_m.Called(c)
 }
 
-// ApiHandler_ListTunes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTunes'
-type ApiHandler_ListTunes_Call struct {
+// ApiHandler_GetCurrentUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCurrentUser'
+type ApiHandler_GetCurrentUser_Call struct {
 	*mock.Call
 }
 
-// ListTunes is a helper method to define mock.On call
+// GetCurrentUser is a helper method to define mock.On call
 //   - c *gin.Context
-func (_e *ApiHandler_Expecter) ListTunes(c interface{}) *ApiHandler_ListTunes_Call {
-	return &ApiHandler_ListTunes_Call{Call: _e.mock.On("ListTunes", c)}
+func (_e *ApiHandler_Expecter) GetCurrentUser(c interface{}) *ApiHandler_GetCurrentUser_Call {
+	return &ApiHandler_GetCurrentUser_Call{Call: _e.mock.On("GetCurrentUser", c)}
 }
 
-func (_c *ApiHandler_ListTunes_Call) Run(run func(c *gin.Context)) *ApiHandler_ListTunes_Call {
+func (_c *ApiHandler_GetCurrentUser_Call) Run(run func(c *gin.Context)) *ApiHandler_GetCurrentUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(*gin.Context))
 	})
 	return _c
 }
 
-func (_c *ApiHandler_ListTunes_Call) Return() *ApiHandler_ListTunes_Call {
+func (_c *ApiHandler_GetCurrentUser_Call) Return() *ApiHandler_GetCurrentUser_Call {
 	_c.Call.Return()
 	return _c
 }
 
-func (_c *ApiHandler_ListTunes_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_ListTunes_Call {
+func (_c *ApiHandler_GetCurrentUser_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_GetCurrentUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateSet provides a mock function with given fields: c
-func (_m *ApiHandler) UpdateSet(c *gin.Context) {
+// GetImport provides a mock function with given fields: c
+func (_m *ApiHandler) GetImport(c *gin.Context) {
 	_m.Called(c)
 }
 
-// ApiHandler_UpdateSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSet'
-type ApiHandler_UpdateSet_Call struct {
+// ApiHandler_GetImport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetImport'
+type ApiHandler_GetImport_Call struct {
 	*mock.Call
 }
 
-// UpdateSet is a helper method to define mock.On call
+// GetImport is a helper method to define mock.On call
 //   - c *gin.Context
-func (_e *ApiHandler_Expecter) UpdateSet(c interface{}) *ApiHandler_UpdateSet_Call {
-	return &ApiHandler_UpdateSet_Call{Call: _e.mock.On("UpdateSet", c)}
+func (_e *ApiHandler_Expecter) GetImport(c interface{}) *ApiHandler_GetImport_Call {
+	return &ApiHandler_GetImport_Call{Call: _e.mock.On("GetImport", c)}
 }
 
-func (_c *ApiHandler_UpdateSet_Call) Run(run func(c *gin.Context)) *ApiHandler_UpdateSet_Call {
+func (_c *ApiHandler_GetImport_Call) Run(run func(c *gin.Context)) *ApiHandler_GetImport_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(*gin.Context))
 	})
 	return _c
 }
 
-func (_c *ApiHandler_UpdateSet_Call) Return() *ApiHandler_UpdateSet_Call {
+func (_c *ApiHandler_GetImport_Call) Return() *ApiHandler_GetImport_Call {
 	_c.Call.Return()
 	return _c
 }
 
-func (_c *ApiHandler_UpdateSet_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_UpdateSet_Call {
+func (_c *ApiHandler_GetImport_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_GetImport_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateTune provides a mock function with given fields: c
-func (_m *ApiHandler) UpdateTune(c *gin.Context) {
+// GetImportEvents provides a mock function with given fields: c
+func (_m *ApiHandler) GetImportEvents(c *gin.Context) {
 	_m.Called(c)
 }
 
-// ApiHandler_UpdateTune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTune'
-type ApiHandler_UpdateTune_Call struct {
+// ApiHandler_GetImportEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetImportEvents'
+type ApiHandler_GetImportEvents_Call struct {
 	*mock.Call
 }
 
-// UpdateTune is a helper method to define mock.On call
+// GetImportEvents is a helper method to define mock.On call
 //   - c *gin.Context
-func (_e *ApiHandler_Expecter) UpdateTune(c interface{}) *ApiHandler_UpdateTune_Call {
-	return &ApiHandler_UpdateTune_Call{Call: _e.mock.On("UpdateTune", c)}
+func (_e *ApiHandler_Expecter) GetImportEvents(c interface{}) *ApiHandler_GetImportEvents_Call {
+	return &ApiHandler_GetImportEvents_Call{Call: _e.mock.On("GetImportEvents", c)}
 }
 
-func (_c *ApiHandler_UpdateTune_Call) Run(run func(c *gin.Context)) *ApiHandler_UpdateTune_Call {
+func (_c *ApiHandler_GetImportEvents_Call) Run(run func(c *gin.Context)) *ApiHandler_GetImportEvents_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(*gin.Context))
 	})
 	return _c
 }
 
-func (_c *ApiHandler_UpdateTune_Call) Return() *ApiHandler_UpdateTune_Call {
+func (_c *ApiHandler_GetImportEvents_Call) Return() *ApiHandler_GetImportEvents_Call {
 	_c.Call.Return()
 	return _c
 }
 
-func (_c *ApiHandler_UpdateTune_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_UpdateTune_Call {
+func (_c *ApiHandler_GetImportEvents_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_GetImportEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetImportOriginal provides a mock function with given fields: c
+func (_m *ApiHandler) GetImportOriginal(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_GetImportOriginal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetImportOriginal'
+type ApiHandler_GetImportOriginal_Call struct {
+	*mock.Call
+}
+
+// GetImportOriginal is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) GetImportOriginal(c interface{}) *ApiHandler_GetImportOriginal_Call {
+	return &ApiHandler_GetImportOriginal_Call{Call: _e.mock.On("GetImportOriginal", c)}
+}
+
+func (_c *ApiHandler_GetImportOriginal_Call) Run(run func(c *gin.Context)) *ApiHandler_GetImportOriginal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_GetImportOriginal_Call) Return() *ApiHandler_GetImportOriginal_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_GetImportOriginal_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_GetImportOriginal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPerson provides a mock function with given fields: c
+func (_m *ApiHandler) GetPerson(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_GetPerson_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPerson'
+type ApiHandler_GetPerson_Call struct {
+	*mock.Call
+}
+
+// GetPerson is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) GetPerson(c interface{}) *ApiHandler_GetPerson_Call {
+	return &ApiHandler_GetPerson_Call{Call: _e.mock.On("GetPerson", c)}
+}
+
+func (_c *ApiHandler_GetPerson_Call) Run(run func(c *gin.Context)) *ApiHandler_GetPerson_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_GetPerson_Call) Return() *ApiHandler_GetPerson_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_GetPerson_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_GetPerson_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSet provides a mock function with given fields: c
+func (_m *ApiHandler) GetSet(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_GetSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSet'
+type ApiHandler_GetSet_Call struct {
+	*mock.Call
+}
+
+// GetSet is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) GetSet(c interface{}) *ApiHandler_GetSet_Call {
+	return &ApiHandler_GetSet_Call{Call: _e.mock.On("GetSet", c)}
+}
+
+func (_c *ApiHandler_GetSet_Call) Run(run func(c *gin.Context)) *ApiHandler_GetSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_GetSet_Call) Return() *ApiHandler_GetSet_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_GetSet_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_GetSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSetAnalysis provides a mock function with given fields: c
+func (_m *ApiHandler) GetSetAnalysis(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_GetSetAnalysis_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSetAnalysis'
+type ApiHandler_GetSetAnalysis_Call struct {
+	*mock.Call
+}
+
+// GetSetAnalysis is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) GetSetAnalysis(c interface{}) *ApiHandler_GetSetAnalysis_Call {
+	return &ApiHandler_GetSetAnalysis_Call{Call: _e.mock.On("GetSetAnalysis", c)}
+}
+
+func (_c *ApiHandler_GetSetAnalysis_Call) Run(run func(c *gin.Context)) *ApiHandler_GetSetAnalysis_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_GetSetAnalysis_Call) Return() *ApiHandler_GetSetAnalysis_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_GetSetAnalysis_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_GetSetAnalysis_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSetAudio provides a mock function with given fields: c
+func (_m *ApiHandler) GetSetAudio(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_GetSetAudio_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSetAudio'
+type ApiHandler_GetSetAudio_Call struct {
+	*mock.Call
+}
+
+// GetSetAudio is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) GetSetAudio(c interface{}) *ApiHandler_GetSetAudio_Call {
+	return &ApiHandler_GetSetAudio_Call{Call: _e.mock.On("GetSetAudio", c)}
+}
+
+func (_c *ApiHandler_GetSetAudio_Call) Run(run func(c *gin.Context)) *ApiHandler_GetSetAudio_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_GetSetAudio_Call) Return() *ApiHandler_GetSetAudio_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_GetSetAudio_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_GetSetAudio_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTag provides a mock function with given fields: c
+func (_m *ApiHandler) GetTag(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_GetTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTag'
+type ApiHandler_GetTag_Call struct {
+	*mock.Call
+}
+
+// GetTag is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) GetTag(c interface{}) *ApiHandler_GetTag_Call {
+	return &ApiHandler_GetTag_Call{Call: _e.mock.On("GetTag", c)}
+}
+
+func (_c *ApiHandler_GetTag_Call) Run(run func(c *gin.Context)) *ApiHandler_GetTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_GetTag_Call) Return() *ApiHandler_GetTag_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_GetTag_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_GetTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTune provides a mock function with given fields: c
+func (_m *ApiHandler) GetTune(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_GetTune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTune'
+type ApiHandler_GetTune_Call struct {
+	*mock.Call
+}
+
+// GetTune is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) GetTune(c interface{}) *ApiHandler_GetTune_Call {
+	return &ApiHandler_GetTune_Call{Call: _e.mock.On("GetTune", c)}
+}
+
+func (_c *ApiHandler_GetTune_Call) Run(run func(c *gin.Context)) *ApiHandler_GetTune_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_GetTune_Call) Return() *ApiHandler_GetTune_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_GetTune_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_GetTune_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTuneAnalysis provides a mock function with given fields: c
+func (_m *ApiHandler) GetTuneAnalysis(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_GetTuneAnalysis_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTuneAnalysis'
+type ApiHandler_GetTuneAnalysis_Call struct {
+	*mock.Call
+}
+
+// GetTuneAnalysis is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) GetTuneAnalysis(c interface{}) *ApiHandler_GetTuneAnalysis_Call {
+	return &ApiHandler_GetTuneAnalysis_Call{Call: _e.mock.On("GetTuneAnalysis", c)}
+}
+
+func (_c *ApiHandler_GetTuneAnalysis_Call) Run(run func(c *gin.Context)) *ApiHandler_GetTuneAnalysis_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_GetTuneAnalysis_Call) Return() *ApiHandler_GetTuneAnalysis_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_GetTuneAnalysis_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_GetTuneAnalysis_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTuneAudio provides a mock function with given fields: c
+func (_m *ApiHandler) GetTuneAudio(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_GetTuneAudio_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTuneAudio'
+type ApiHandler_GetTuneAudio_Call struct {
+	*mock.Call
+}
+
+// GetTuneAudio is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) GetTuneAudio(c interface{}) *ApiHandler_GetTuneAudio_Call {
+	return &ApiHandler_GetTuneAudio_Call{Call: _e.mock.On("GetTuneAudio", c)}
+}
+
+func (_c *ApiHandler_GetTuneAudio_Call) Run(run func(c *gin.Context)) *ApiHandler_GetTuneAudio_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_GetTuneAudio_Call) Return() *ApiHandler_GetTuneAudio_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_GetTuneAudio_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_GetTuneAudio_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTuneFile provides a mock function with given fields: c
+func (_m *ApiHandler) GetTuneFile(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_GetTuneFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTuneFile'
+type ApiHandler_GetTuneFile_Call struct {
+	*mock.Call
+}
+
+// GetTuneFile is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) GetTuneFile(c interface{}) *ApiHandler_GetTuneFile_Call {
+	return &ApiHandler_GetTuneFile_Call{Call: _e.mock.On("GetTuneFile", c)}
+}
+
+func (_c *ApiHandler_GetTuneFile_Call) Run(run func(c *gin.Context)) *ApiHandler_GetTuneFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_GetTuneFile_Call) Return() *ApiHandler_GetTuneFile_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_GetTuneFile_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_GetTuneFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTuneMidi provides a mock function with given fields: c
+func (_m *ApiHandler) GetTuneMidi(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_GetTuneMidi_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTuneMidi'
+type ApiHandler_GetTuneMidi_Call struct {
+	*mock.Call
+}
+
+// GetTuneMidi is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) GetTuneMidi(c interface{}) *ApiHandler_GetTuneMidi_Call {
+	return &ApiHandler_GetTuneMidi_Call{Call: _e.mock.On("GetTuneMidi", c)}
+}
+
+func (_c *ApiHandler_GetTuneMidi_Call) Run(run func(c *gin.Context)) *ApiHandler_GetTuneMidi_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_GetTuneMidi_Call) Return() *ApiHandler_GetTuneMidi_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_GetTuneMidi_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_GetTuneMidi_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTuneRevisionDiff provides a mock function with given fields: c
+func (_m *ApiHandler) GetTuneRevisionDiff(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_GetTuneRevisionDiff_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTuneRevisionDiff'
+type ApiHandler_GetTuneRevisionDiff_Call struct {
+	*mock.Call
+}
+
+// GetTuneRevisionDiff is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) GetTuneRevisionDiff(c interface{}) *ApiHandler_GetTuneRevisionDiff_Call {
+	return &ApiHandler_GetTuneRevisionDiff_Call{Call: _e.mock.On("GetTuneRevisionDiff", c)}
+}
+
+func (_c *ApiHandler_GetTuneRevisionDiff_Call) Run(run func(c *gin.Context)) *ApiHandler_GetTuneRevisionDiff_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_GetTuneRevisionDiff_Call) Return() *ApiHandler_GetTuneRevisionDiff_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_GetTuneRevisionDiff_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_GetTuneRevisionDiff_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTuneScorePdf provides a mock function with given fields: c
+func (_m *ApiHandler) GetTuneScorePdf(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_GetTuneScorePdf_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTuneScorePdf'
+type ApiHandler_GetTuneScorePdf_Call struct {
+	*mock.Call
+}
+
+// GetTuneScorePdf is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) GetTuneScorePdf(c interface{}) *ApiHandler_GetTuneScorePdf_Call {
+	return &ApiHandler_GetTuneScorePdf_Call{Call: _e.mock.On("GetTuneScorePdf", c)}
+}
+
+func (_c *ApiHandler_GetTuneScorePdf_Call) Run(run func(c *gin.Context)) *ApiHandler_GetTuneScorePdf_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_GetTuneScorePdf_Call) Return() *ApiHandler_GetTuneScorePdf_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_GetTuneScorePdf_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_GetTuneScorePdf_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTuneScoreSvg provides a mock function with given fields: c
+func (_m *ApiHandler) GetTuneScoreSvg(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_GetTuneScoreSvg_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTuneScoreSvg'
+type ApiHandler_GetTuneScoreSvg_Call struct {
+	*mock.Call
+}
+
+// GetTuneScoreSvg is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) GetTuneScoreSvg(c interface{}) *ApiHandler_GetTuneScoreSvg_Call {
+	return &ApiHandler_GetTuneScoreSvg_Call{Call: _e.mock.On("GetTuneScoreSvg", c)}
+}
+
+func (_c *ApiHandler_GetTuneScoreSvg_Call) Run(run func(c *gin.Context)) *ApiHandler_GetTuneScoreSvg_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_GetTuneScoreSvg_Call) Return() *ApiHandler_GetTuneScoreSvg_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_GetTuneScoreSvg_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_GetTuneScoreSvg_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTuneType provides a mock function with given fields: c
+func (_m *ApiHandler) GetTuneType(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_GetTuneType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTuneType'
+type ApiHandler_GetTuneType_Call struct {
+	*mock.Call
+}
+
+// GetTuneType is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) GetTuneType(c interface{}) *ApiHandler_GetTuneType_Call {
+	return &ApiHandler_GetTuneType_Call{Call: _e.mock.On("GetTuneType", c)}
+}
+
+func (_c *ApiHandler_GetTuneType_Call) Run(run func(c *gin.Context)) *ApiHandler_GetTuneType_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_GetTuneType_Call) Return() *ApiHandler_GetTuneType_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_GetTuneType_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_GetTuneType_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTunesAnalysis provides a mock function with given fields: c
+func (_m *ApiHandler) GetTunesAnalysis(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_GetTunesAnalysis_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTunesAnalysis'
+type ApiHandler_GetTunesAnalysis_Call struct {
+	*mock.Call
+}
+
+// GetTunesAnalysis is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) GetTunesAnalysis(c interface{}) *ApiHandler_GetTunesAnalysis_Call {
+	return &ApiHandler_GetTunesAnalysis_Call{Call: _e.mock.On("GetTunesAnalysis", c)}
+}
+
+func (_c *ApiHandler_GetTunesAnalysis_Call) Run(run func(c *gin.Context)) *ApiHandler_GetTunesAnalysis_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_GetTunesAnalysis_Call) Return() *ApiHandler_GetTunesAnalysis_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_GetTunesAnalysis_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_GetTunesAnalysis_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Health provides a mock function with given fields: c
+func (_m *ApiHandler) Health(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_Health_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Health'
+type ApiHandler_Health_Call struct {
+	*mock.Call
+}
+
+// Health is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) Health(c interface{}) *ApiHandler_Health_Call {
+	return &ApiHandler_Health_Call{Call: _e.mock.On("Health", c)}
+}
+
+func (_c *ApiHandler_Health_Call) Run(run func(c *gin.Context)) *ApiHandler_Health_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_Health_Call) Return() *ApiHandler_Health_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_Health_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_Health_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Home provides a mock function with given fields: c
+func (_m *ApiHandler) Home(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_Home_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Home'
+type ApiHandler_Home_Call struct {
+	*mock.Call
+}
+
+// Home is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) Home(c interface{}) *ApiHandler_Home_Call {
+	return &ApiHandler_Home_Call{Call: _e.mock.On("Home", c)}
+}
+
+func (_c *ApiHandler_Home_Call) Run(run func(c *gin.Context)) *ApiHandler_Home_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_Home_Call) Return() *ApiHandler_Home_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_Home_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_Home_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportFile provides a mock function with given fields: c
+func (_m *ApiHandler) ImportFile(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_ImportFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportFile'
+type ApiHandler_ImportFile_Call struct {
+	*mock.Call
+}
+
+// ImportFile is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) ImportFile(c interface{}) *ApiHandler_ImportFile_Call {
+	return &ApiHandler_ImportFile_Call{Call: _e.mock.On("ImportFile", c)}
+}
+
+func (_c *ApiHandler_ImportFile_Call) Run(run func(c *gin.Context)) *ApiHandler_ImportFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_ImportFile_Call) Return() *ApiHandler_ImportFile_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_ImportFile_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_ImportFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAPITokens provides a mock function with given fields: c
+func (_m *ApiHandler) ListAPITokens(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_ListAPITokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAPITokens'
+type ApiHandler_ListAPITokens_Call struct {
+	*mock.Call
+}
+
+// ListAPITokens is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) ListAPITokens(c interface{}) *ApiHandler_ListAPITokens_Call {
+	return &ApiHandler_ListAPITokens_Call{Call: _e.mock.On("ListAPITokens", c)}
+}
+
+func (_c *ApiHandler_ListAPITokens_Call) Run(run func(c *gin.Context)) *ApiHandler_ListAPITokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_ListAPITokens_Call) Return() *ApiHandler_ListAPITokens_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_ListAPITokens_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_ListAPITokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListImports provides a mock function with given fields: c
+func (_m *ApiHandler) ListImports(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_ListImports_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListImports'
+type ApiHandler_ListImports_Call struct {
+	*mock.Call
+}
+
+// ListImports is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) ListImports(c interface{}) *ApiHandler_ListImports_Call {
+	return &ApiHandler_ListImports_Call{Call: _e.mock.On("ListImports", c)}
+}
+
+func (_c *ApiHandler_ListImports_Call) Run(run func(c *gin.Context)) *ApiHandler_ListImports_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_ListImports_Call) Return() *ApiHandler_ListImports_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_ListImports_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_ListImports_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListMemberships provides a mock function with given fields: c
+func (_m *ApiHandler) ListMemberships(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_ListMemberships_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListMemberships'
+type ApiHandler_ListMemberships_Call struct {
+	*mock.Call
+}
+
+// ListMemberships is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) ListMemberships(c interface{}) *ApiHandler_ListMemberships_Call {
+	return &ApiHandler_ListMemberships_Call{Call: _e.mock.On("ListMemberships", c)}
+}
+
+func (_c *ApiHandler_ListMemberships_Call) Run(run func(c *gin.Context)) *ApiHandler_ListMemberships_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_ListMemberships_Call) Return() *ApiHandler_ListMemberships_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_ListMemberships_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_ListMemberships_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListMessages provides a mock function with given fields: c
+func (_m *ApiHandler) ListMessages(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_ListMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListMessages'
+type ApiHandler_ListMessages_Call struct {
+	*mock.Call
+}
+
+// ListMessages is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) ListMessages(c interface{}) *ApiHandler_ListMessages_Call {
+	return &ApiHandler_ListMessages_Call{Call: _e.mock.On("ListMessages", c)}
+}
+
+func (_c *ApiHandler_ListMessages_Call) Run(run func(c *gin.Context)) *ApiHandler_ListMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_ListMessages_Call) Return() *ApiHandler_ListMessages_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_ListMessages_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_ListMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPeople provides a mock function with given fields: c
+func (_m *ApiHandler) ListPeople(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_ListPeople_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPeople'
+type ApiHandler_ListPeople_Call struct {
+	*mock.Call
+}
+
+// ListPeople is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) ListPeople(c interface{}) *ApiHandler_ListPeople_Call {
+	return &ApiHandler_ListPeople_Call{Call: _e.mock.On("ListPeople", c)}
+}
+
+func (_c *ApiHandler_ListPeople_Call) Run(run func(c *gin.Context)) *ApiHandler_ListPeople_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_ListPeople_Call) Return() *ApiHandler_ListPeople_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_ListPeople_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_ListPeople_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPersonTunes provides a mock function with given fields: c
+func (_m *ApiHandler) ListPersonTunes(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_ListPersonTunes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPersonTunes'
+type ApiHandler_ListPersonTunes_Call struct {
+	*mock.Call
+}
+
+// ListPersonTunes is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) ListPersonTunes(c interface{}) *ApiHandler_ListPersonTunes_Call {
+	return &ApiHandler_ListPersonTunes_Call{Call: _e.mock.On("ListPersonTunes", c)}
+}
+
+func (_c *ApiHandler_ListPersonTunes_Call) Run(run func(c *gin.Context)) *ApiHandler_ListPersonTunes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_ListPersonTunes_Call) Return() *ApiHandler_ListPersonTunes_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_ListPersonTunes_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_ListPersonTunes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSets provides a mock function with given fields: c
+func (_m *ApiHandler) ListSets(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_ListSets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSets'
+type ApiHandler_ListSets_Call struct {
+	*mock.Call
+}
+
+// ListSets is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) ListSets(c interface{}) *ApiHandler_ListSets_Call {
+	return &ApiHandler_ListSets_Call{Call: _e.mock.On("ListSets", c)}
+}
+
+func (_c *ApiHandler_ListSets_Call) Run(run func(c *gin.Context)) *ApiHandler_ListSets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_ListSets_Call) Return() *ApiHandler_ListSets_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_ListSets_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_ListSets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTags provides a mock function with given fields: c
+func (_m *ApiHandler) ListTags(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_ListTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTags'
+type ApiHandler_ListTags_Call struct {
+	*mock.Call
+}
+
+// ListTags is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) ListTags(c interface{}) *ApiHandler_ListTags_Call {
+	return &ApiHandler_ListTags_Call{Call: _e.mock.On("ListTags", c)}
+}
+
+func (_c *ApiHandler_ListTags_Call) Run(run func(c *gin.Context)) *ApiHandler_ListTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_ListTags_Call) Return() *ApiHandler_ListTags_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_ListTags_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_ListTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTuneDuplicates provides a mock function with given fields: c
+func (_m *ApiHandler) ListTuneDuplicates(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_ListTuneDuplicates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTuneDuplicates'
+type ApiHandler_ListTuneDuplicates_Call struct {
+	*mock.Call
+}
+
+// ListTuneDuplicates is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) ListTuneDuplicates(c interface{}) *ApiHandler_ListTuneDuplicates_Call {
+	return &ApiHandler_ListTuneDuplicates_Call{Call: _e.mock.On("ListTuneDuplicates", c)}
+}
+
+func (_c *ApiHandler_ListTuneDuplicates_Call) Run(run func(c *gin.Context)) *ApiHandler_ListTuneDuplicates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_ListTuneDuplicates_Call) Return() *ApiHandler_ListTuneDuplicates_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_ListTuneDuplicates_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_ListTuneDuplicates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTuneFiles provides a mock function with given fields: c
+func (_m *ApiHandler) ListTuneFiles(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_ListTuneFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTuneFiles'
+type ApiHandler_ListTuneFiles_Call struct {
+	*mock.Call
+}
+
+// ListTuneFiles is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) ListTuneFiles(c interface{}) *ApiHandler_ListTuneFiles_Call {
+	return &ApiHandler_ListTuneFiles_Call{Call: _e.mock.On("ListTuneFiles", c)}
+}
+
+func (_c *ApiHandler_ListTuneFiles_Call) Run(run func(c *gin.Context)) *ApiHandler_ListTuneFiles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_ListTuneFiles_Call) Return() *ApiHandler_ListTuneFiles_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_ListTuneFiles_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_ListTuneFiles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTuneMessages provides a mock function with given fields: c
+func (_m *ApiHandler) ListTuneMessages(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_ListTuneMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTuneMessages'
+type ApiHandler_ListTuneMessages_Call struct {
+	*mock.Call
+}
+
+// ListTuneMessages is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) ListTuneMessages(c interface{}) *ApiHandler_ListTuneMessages_Call {
+	return &ApiHandler_ListTuneMessages_Call{Call: _e.mock.On("ListTuneMessages", c)}
+}
+
+func (_c *ApiHandler_ListTuneMessages_Call) Run(run func(c *gin.Context)) *ApiHandler_ListTuneMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_ListTuneMessages_Call) Return() *ApiHandler_ListTuneMessages_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_ListTuneMessages_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_ListTuneMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTuneRevisions provides a mock function with given fields: c
+func (_m *ApiHandler) ListTuneRevisions(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_ListTuneRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTuneRevisions'
+type ApiHandler_ListTuneRevisions_Call struct {
+	*mock.Call
+}
+
+// ListTuneRevisions is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) ListTuneRevisions(c interface{}) *ApiHandler_ListTuneRevisions_Call {
+	return &ApiHandler_ListTuneRevisions_Call{Call: _e.mock.On("ListTuneRevisions", c)}
+}
+
+func (_c *ApiHandler_ListTuneRevisions_Call) Run(run func(c *gin.Context)) *ApiHandler_ListTuneRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_ListTuneRevisions_Call) Return() *ApiHandler_ListTuneRevisions_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_ListTuneRevisions_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_ListTuneRevisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTuneTypes provides a mock function with given fields: c
+func (_m *ApiHandler) ListTuneTypes(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_ListTuneTypes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTuneTypes'
+type ApiHandler_ListTuneTypes_Call struct {
+	*mock.Call
+}
+
+// ListTuneTypes is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) ListTuneTypes(c interface{}) *ApiHandler_ListTuneTypes_Call {
+	return &ApiHandler_ListTuneTypes_Call{Call: _e.mock.On("ListTuneTypes", c)}
+}
+
+func (_c *ApiHandler_ListTuneTypes_Call) Run(run func(c *gin.Context)) *ApiHandler_ListTuneTypes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_ListTuneTypes_Call) Return() *ApiHandler_ListTuneTypes_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_ListTuneTypes_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_ListTuneTypes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTunes provides a mock function with given fields: c
+func (_m *ApiHandler) ListTunes(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_ListTunes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTunes'
+type ApiHandler_ListTunes_Call struct {
+	*mock.Call
+}
+
+// ListTunes is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) ListTunes(c interface{}) *ApiHandler_ListTunes_Call {
+	return &ApiHandler_ListTunes_Call{Call: _e.mock.On("ListTunes", c)}
+}
+
+func (_c *ApiHandler_ListTunes_Call) Run(run func(c *gin.Context)) *ApiHandler_ListTunes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_ListTunes_Call) Return() *ApiHandler_ListTunes_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_ListTunes_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_ListTunes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MergePeople provides a mock function with given fields: c
+func (_m *ApiHandler) MergePeople(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_MergePeople_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergePeople'
+type ApiHandler_MergePeople_Call struct {
+	*mock.Call
+}
+
+// MergePeople is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) MergePeople(c interface{}) *ApiHandler_MergePeople_Call {
+	return &ApiHandler_MergePeople_Call{Call: _e.mock.On("MergePeople", c)}
+}
+
+func (_c *ApiHandler_MergePeople_Call) Run(run func(c *gin.Context)) *ApiHandler_MergePeople_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_MergePeople_Call) Return() *ApiHandler_MergePeople_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_MergePeople_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_MergePeople_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MergeTuneTypes provides a mock function with given fields: c
+func (_m *ApiHandler) MergeTuneTypes(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_MergeTuneTypes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergeTuneTypes'
+type ApiHandler_MergeTuneTypes_Call struct {
+	*mock.Call
+}
+
+// MergeTuneTypes is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) MergeTuneTypes(c interface{}) *ApiHandler_MergeTuneTypes_Call {
+	return &ApiHandler_MergeTuneTypes_Call{Call: _e.mock.On("MergeTuneTypes", c)}
+}
+
+func (_c *ApiHandler_MergeTuneTypes_Call) Run(run func(c *gin.Context)) *ApiHandler_MergeTuneTypes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_MergeTuneTypes_Call) Return() *ApiHandler_MergeTuneTypes_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_MergeTuneTypes_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_MergeTuneTypes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MergeTunes provides a mock function with given fields: c
+func (_m *ApiHandler) MergeTunes(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_MergeTunes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergeTunes'
+type ApiHandler_MergeTunes_Call struct {
+	*mock.Call
+}
+
+// MergeTunes is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) MergeTunes(c interface{}) *ApiHandler_MergeTunes_Call {
+	return &ApiHandler_MergeTunes_Call{Call: _e.mock.On("MergeTunes", c)}
+}
+
+func (_c *ApiHandler_MergeTunes_Call) Run(run func(c *gin.Context)) *ApiHandler_MergeTunes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_MergeTunes_Call) Return() *ApiHandler_MergeTunes_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_MergeTunes_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_MergeTunes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreTuneRevision provides a mock function with given fields: c
+func (_m *ApiHandler) RestoreTuneRevision(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_RestoreTuneRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreTuneRevision'
+type ApiHandler_RestoreTuneRevision_Call struct {
+	*mock.Call
+}
+
+// RestoreTuneRevision is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) RestoreTuneRevision(c interface{}) *ApiHandler_RestoreTuneRevision_Call {
+	return &ApiHandler_RestoreTuneRevision_Call{Call: _e.mock.On("RestoreTuneRevision", c)}
+}
+
+func (_c *ApiHandler_RestoreTuneRevision_Call) Run(run func(c *gin.Context)) *ApiHandler_RestoreTuneRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_RestoreTuneRevision_Call) Return() *ApiHandler_RestoreTuneRevision_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_RestoreTuneRevision_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_RestoreTuneRevision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Search provides a mock function with given fields: c
+func (_m *ApiHandler) Search(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type ApiHandler_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) Search(c interface{}) *ApiHandler_Search_Call {
+	return &ApiHandler_Search_Call{Call: _e.mock.On("Search", c)}
+}
+
+func (_c *ApiHandler_Search_Call) Run(run func(c *gin.Context)) *ApiHandler_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_Search_Call) Return() *ApiHandler_Search_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_Search_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetMembership provides a mock function with given fields: c
+func (_m *ApiHandler) SetMembership(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_SetMembership_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMembership'
+type ApiHandler_SetMembership_Call struct {
+	*mock.Call
+}
+
+// SetMembership is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) SetMembership(c interface{}) *ApiHandler_SetMembership_Call {
+	return &ApiHandler_SetMembership_Call{Call: _e.mock.On("SetMembership", c)}
+}
+
+func (_c *ApiHandler_SetMembership_Call) Run(run func(c *gin.Context)) *ApiHandler_SetMembership_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_SetMembership_Call) Return() *ApiHandler_SetMembership_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_SetMembership_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_SetMembership_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetPersonAliases provides a mock function with given fields: c
+func (_m *ApiHandler) SetPersonAliases(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_SetPersonAliases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPersonAliases'
+type ApiHandler_SetPersonAliases_Call struct {
+	*mock.Call
+}
+
+// SetPersonAliases is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) SetPersonAliases(c interface{}) *ApiHandler_SetPersonAliases_Call {
+	return &ApiHandler_SetPersonAliases_Call{Call: _e.mock.On("SetPersonAliases", c)}
+}
+
+func (_c *ApiHandler_SetPersonAliases_Call) Run(run func(c *gin.Context)) *ApiHandler_SetPersonAliases_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_SetPersonAliases_Call) Return() *ApiHandler_SetPersonAliases_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_SetPersonAliases_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_SetPersonAliases_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTuneTypeAliases provides a mock function with given fields: c
+func (_m *ApiHandler) SetTuneTypeAliases(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_SetTuneTypeAliases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTuneTypeAliases'
+type ApiHandler_SetTuneTypeAliases_Call struct {
+	*mock.Call
+}
+
+// SetTuneTypeAliases is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) SetTuneTypeAliases(c interface{}) *ApiHandler_SetTuneTypeAliases_Call {
+	return &ApiHandler_SetTuneTypeAliases_Call{Call: _e.mock.On("SetTuneTypeAliases", c)}
+}
+
+func (_c *ApiHandler_SetTuneTypeAliases_Call) Run(run func(c *gin.Context)) *ApiHandler_SetTuneTypeAliases_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_SetTuneTypeAliases_Call) Return() *ApiHandler_SetTuneTypeAliases_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_SetTuneTypeAliases_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_SetTuneTypeAliases_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateMessage provides a mock function with given fields: c
+func (_m *ApiHandler) UpdateMessage(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_UpdateMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMessage'
+type ApiHandler_UpdateMessage_Call struct {
+	*mock.Call
+}
+
+// UpdateMessage is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) UpdateMessage(c interface{}) *ApiHandler_UpdateMessage_Call {
+	return &ApiHandler_UpdateMessage_Call{Call: _e.mock.On("UpdateMessage", c)}
+}
+
+func (_c *ApiHandler_UpdateMessage_Call) Run(run func(c *gin.Context)) *ApiHandler_UpdateMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_UpdateMessage_Call) Return() *ApiHandler_UpdateMessage_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_UpdateMessage_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_UpdateMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePerson provides a mock function with given fields: c
+func (_m *ApiHandler) UpdatePerson(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_UpdatePerson_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePerson'
+type ApiHandler_UpdatePerson_Call struct {
+	*mock.Call
+}
+
+// UpdatePerson is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) UpdatePerson(c interface{}) *ApiHandler_UpdatePerson_Call {
+	return &ApiHandler_UpdatePerson_Call{Call: _e.mock.On("UpdatePerson", c)}
+}
+
+func (_c *ApiHandler_UpdatePerson_Call) Run(run func(c *gin.Context)) *ApiHandler_UpdatePerson_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_UpdatePerson_Call) Return() *ApiHandler_UpdatePerson_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_UpdatePerson_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_UpdatePerson_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSet provides a mock function with given fields: c
+func (_m *ApiHandler) UpdateSet(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_UpdateSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSet'
+type ApiHandler_UpdateSet_Call struct {
+	*mock.Call
+}
+
+// UpdateSet is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) UpdateSet(c interface{}) *ApiHandler_UpdateSet_Call {
+	return &ApiHandler_UpdateSet_Call{Call: _e.mock.On("UpdateSet", c)}
+}
+
+func (_c *ApiHandler_UpdateSet_Call) Run(run func(c *gin.Context)) *ApiHandler_UpdateSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_UpdateSet_Call) Return() *ApiHandler_UpdateSet_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_UpdateSet_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_UpdateSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTag provides a mock function with given fields: c
+func (_m *ApiHandler) UpdateTag(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_UpdateTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTag'
+type ApiHandler_UpdateTag_Call struct {
+	*mock.Call
+}
+
+// UpdateTag is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) UpdateTag(c interface{}) *ApiHandler_UpdateTag_Call {
+	return &ApiHandler_UpdateTag_Call{Call: _e.mock.On("UpdateTag", c)}
+}
+
+func (_c *ApiHandler_UpdateTag_Call) Run(run func(c *gin.Context)) *ApiHandler_UpdateTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_UpdateTag_Call) Return() *ApiHandler_UpdateTag_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_UpdateTag_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_UpdateTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTune provides a mock function with given fields: c
+func (_m *ApiHandler) UpdateTune(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_UpdateTune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTune'
+type ApiHandler_UpdateTune_Call struct {
+	*mock.Call
+}
+
+// UpdateTune is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) UpdateTune(c interface{}) *ApiHandler_UpdateTune_Call {
+	return &ApiHandler_UpdateTune_Call{Call: _e.mock.On("UpdateTune", c)}
+}
+
+func (_c *ApiHandler_UpdateTune_Call) Run(run func(c *gin.Context)) *ApiHandler_UpdateTune_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_UpdateTune_Call) Return() *ApiHandler_UpdateTune_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_UpdateTune_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_UpdateTune_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTuneMessages provides a mock function with given fields: c
+func (_m *ApiHandler) UpdateTuneMessages(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_UpdateTuneMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTuneMessages'
+type ApiHandler_UpdateTuneMessages_Call struct {
+	*mock.Call
+}
+
+// UpdateTuneMessages is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) UpdateTuneMessages(c interface{}) *ApiHandler_UpdateTuneMessages_Call {
+	return &ApiHandler_UpdateTuneMessages_Call{Call: _e.mock.On("UpdateTuneMessages", c)}
+}
+
+func (_c *ApiHandler_UpdateTuneMessages_Call) Run(run func(c *gin.Context)) *ApiHandler_UpdateTuneMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_UpdateTuneMessages_Call) Return() *ApiHandler_UpdateTuneMessages_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_UpdateTuneMessages_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_UpdateTuneMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTuneType provides a mock function with given fields: c
+func (_m *ApiHandler) UpdateTuneType(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_UpdateTuneType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTuneType'
+type ApiHandler_UpdateTuneType_Call struct {
+	*mock.Call
+}
+
+// UpdateTuneType is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) UpdateTuneType(c interface{}) *ApiHandler_UpdateTuneType_Call {
+	return &ApiHandler_UpdateTuneType_Call{Call: _e.mock.On("UpdateTuneType", c)}
+}
+
+func (_c *ApiHandler_UpdateTuneType_Call) Run(run func(c *gin.Context)) *ApiHandler_UpdateTuneType_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_UpdateTuneType_Call) Return() *ApiHandler_UpdateTuneType_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_UpdateTuneType_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_UpdateTuneType_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UploadTuneFile provides a mock function with given fields: c
+func (_m *ApiHandler) UploadTuneFile(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ApiHandler_UploadTuneFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UploadTuneFile'
+type ApiHandler_UploadTuneFile_Call struct {
+	*mock.Call
+}
+
+// UploadTuneFile is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *ApiHandler_Expecter) UploadTuneFile(c interface{}) *ApiHandler_UploadTuneFile_Call {
+	return &ApiHandler_UploadTuneFile_Call{Call: _e.mock.On("UploadTuneFile", c)}
+}
+
+func (_c *ApiHandler_UploadTuneFile_Call) Run(run func(c *gin.Context)) *ApiHandler_UploadTuneFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *ApiHandler_UploadTuneFile_Call) Return() *ApiHandler_UploadTuneFile_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ApiHandler_UploadTuneFile_Call) RunAndReturn(run func(*gin.Context)) *ApiHandler_UploadTuneFile_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -69,48 +69,216 @@ type ApiHandleFunctions struct {
 
 func getRoutes(handleFunctions ApiHandleFunctions) []Route {
 	return []Route{ 
+		{
+			"AssignTagsToSet",
+			http.MethodPut,
+			"/sets/:setId/tags",
+			handleFunctions.ApiHandler.AssignTagsToSet,
+		},
+		{
+			"AssignTagsToTune",
+			http.MethodPut,
+			"/tunes/:tuneId/tags",
+			handleFunctions.ApiHandler.AssignTagsToTune,
+		},
 		{
 			"AssignTunesToSet",
 			http.MethodPut,
 			"/sets/:setId/tunes",
 			handleFunctions.ApiHandler.AssignTunesToSet,
 		},
+		{
+			"CreateAPIToken",
+			http.MethodPost,
+			"/users/me/tokens",
+			handleFunctions.ApiHandler.CreateAPIToken,
+		},
+		{
+			"CreateBooklet",
+			http.MethodPost,
+			"/booklets",
+			handleFunctions.ApiHandler.CreateBooklet,
+		},
 		{
 			"CreateSet",
 			http.MethodPost,
 			"/sets",
 			handleFunctions.ApiHandler.CreateSet,
 		},
+		{
+			"CreateTag",
+			http.MethodPost,
+			"/tags",
+			handleFunctions.ApiHandler.CreateTag,
+		},
 		{
 			"CreateTune",
 			http.MethodPost,
 			"/tunes",
 			handleFunctions.ApiHandler.CreateTune,
 		},
+		{
+			"DeleteAPIToken",
+			http.MethodDelete,
+			"/users/me/tokens/:tokenId",
+			handleFunctions.ApiHandler.DeleteAPIToken,
+		},
+		{
+			"DeleteImport",
+			http.MethodDelete,
+			"/imports/:importId",
+			handleFunctions.ApiHandler.DeleteImport,
+		},
+		{
+			"DeleteMembership",
+			http.MethodDelete,
+			"/memberships/:userId",
+			handleFunctions.ApiHandler.DeleteMembership,
+		},
 		{
 			"DeleteSet",
 			http.MethodDelete,
 			"/sets/:setId",
 			handleFunctions.ApiHandler.DeleteSet,
 		},
+		{
+			"DeleteTag",
+			http.MethodDelete,
+			"/tags/:tagId",
+			handleFunctions.ApiHandler.DeleteTag,
+		},
 		{
 			"DeleteTune",
 			http.MethodDelete,
 			"/tunes/:tuneId",
 			handleFunctions.ApiHandler.DeleteTune,
 		},
+		{
+			"DeleteTuneFile",
+			http.MethodDelete,
+			"/tunes/:tuneId/files/:format",
+			handleFunctions.ApiHandler.DeleteTuneFile,
+		},
+		{
+			"ExportTune",
+			http.MethodGet,
+			"/tunes/:tuneId/export",
+			handleFunctions.ApiHandler.ExportTune,
+		},
+		{
+			"GetCurrentUser",
+			http.MethodGet,
+			"/users/me",
+			handleFunctions.ApiHandler.GetCurrentUser,
+		},
+		{
+			"GetImport",
+			http.MethodGet,
+			"/imports/:importId",
+			handleFunctions.ApiHandler.GetImport,
+		},
+		{
+			"GetImportEvents",
+			http.MethodGet,
+			"/imports/:importId/events",
+			handleFunctions.ApiHandler.GetImportEvents,
+		},
+		{
+			"GetImportOriginal",
+			http.MethodGet,
+			"/imports/:importId/original",
+			handleFunctions.ApiHandler.GetImportOriginal,
+		},
+		{
+			"GetPerson",
+			http.MethodGet,
+			"/people/:personId",
+			handleFunctions.ApiHandler.GetPerson,
+		},
 		{
 			"GetSet",
 			http.MethodGet,
 			"/sets/:setId",
 			handleFunctions.ApiHandler.GetSet,
 		},
+		{
+			"GetSetAnalysis",
+			http.MethodGet,
+			"/sets/:setId/analysis",
+			handleFunctions.ApiHandler.GetSetAnalysis,
+		},
+		{
+			"GetSetAudio",
+			http.MethodGet,
+			"/sets/:setId/audio",
+			handleFunctions.ApiHandler.GetSetAudio,
+		},
+		{
+			"GetTag",
+			http.MethodGet,
+			"/tags/:tagId",
+			handleFunctions.ApiHandler.GetTag,
+		},
 		{
 			"GetTune",
 			http.MethodGet,
 			"/tunes/:tuneId",
 			handleFunctions.ApiHandler.GetTune,
 		},
+		{
+			"GetTuneAnalysis",
+			http.MethodGet,
+			"/tunes/:tuneId/analysis",
+			handleFunctions.ApiHandler.GetTuneAnalysis,
+		},
+		{
+			"GetTuneAudio",
+			http.MethodGet,
+			"/tunes/:tuneId/audio",
+			handleFunctions.ApiHandler.GetTuneAudio,
+		},
+		{
+			"GetTuneFile",
+			http.MethodGet,
+			"/tunes/:tuneId/files/:format",
+			handleFunctions.ApiHandler.GetTuneFile,
+		},
+		{
+			"GetTuneMidi",
+			http.MethodGet,
+			"/tunes/:tuneId/midi",
+			handleFunctions.ApiHandler.GetTuneMidi,
+		},
+		{
+			"GetTuneRevisionDiff",
+			http.MethodGet,
+			"/tunes/:tuneId/revisions/diff",
+			handleFunctions.ApiHandler.GetTuneRevisionDiff,
+		},
+		{
+			"GetTuneScorePdf",
+			http.MethodGet,
+			"/tunes/:tuneId/score.pdf",
+			handleFunctions.ApiHandler.GetTuneScorePdf,
+		},
+		{
+			"GetTuneScoreSvg",
+			http.MethodGet,
+			"/tunes/:tuneId/score.svg",
+			handleFunctions.ApiHandler.GetTuneScoreSvg,
+		},
+		{
+			"GetTuneType",
+			http.MethodGet,
+			"/tune-types/:tuneTypeId",
+			handleFunctions.ApiHandler.GetTuneType,
+		},
+		{
+			"GetTunesAnalysis",
+			http.MethodGet,
+			"/tunes/analysis",
+			handleFunctions.ApiHandler.GetTunesAnalysis,
+		},
 		{
 			"Health",
 			http.MethodGet,
@@ -129,29 +297,185 @@ func getRoutes(handleFunctions ApiHandleFunctions) []Route {
 			"/imports",
 			handleFunctions.ApiHandler.ImportFile,
 		},
+		{
+			"ListAPITokens",
+			http.MethodGet,
+			"/users/me/tokens",
+			handleFunctions.ApiHandler.ListAPITokens,
+		},
+		{
+			"ListImports",
+			http.MethodGet,
+			"/imports",
+			handleFunctions.ApiHandler.ListImports,
+		},
+		{
+			"ListMemberships",
+			http.MethodGet,
+			"/memberships",
+			handleFunctions.ApiHandler.ListMemberships,
+		},
+		{
+			"ListMessages",
+			http.MethodGet,
+			"/messages",
+			handleFunctions.ApiHandler.ListMessages,
+		},
+		{
+			"ListPeople",
+			http.MethodGet,
+			"/people",
+			handleFunctions.ApiHandler.ListPeople,
+		},
+		{
+			"ListPersonTunes",
+			http.MethodGet,
+			"/people/:personId/tunes",
+			handleFunctions.ApiHandler.ListPersonTunes,
+		},
 		{
 			"ListSets",
 			http.MethodGet,
 			"/sets",
 			handleFunctions.ApiHandler.ListSets,
 		},
+		{
+			"ListTags",
+			http.MethodGet,
+			"/tags",
+			handleFunctions.ApiHandler.ListTags,
+		},
+		{
+			"ListTuneDuplicates",
+			http.MethodGet,
+			"/tunes/:tuneId/duplicates",
+			handleFunctions.ApiHandler.ListTuneDuplicates,
+		},
+		{
+			"ListTuneFiles",
+			http.MethodGet,
+			"/tunes/:tuneId/files",
+			handleFunctions.ApiHandler.ListTuneFiles,
+		},
+		{
+			"ListTuneMessages",
+			http.MethodGet,
+			"/tunes/:tuneId/messages",
+			handleFunctions.ApiHandler.ListTuneMessages,
+		},
+		{
+			"ListTuneRevisions",
+			http.MethodGet,
+			"/tunes/:tuneId/revisions",
+			handleFunctions.ApiHandler.ListTuneRevisions,
+		},
+		{
+			"ListTuneTypes",
+			http.MethodGet,
+			"/tune-types",
+			handleFunctions.ApiHandler.ListTuneTypes,
+		},
 		{
 			"ListTunes",
 			http.MethodGet,
 			"/tunes",
 			handleFunctions.ApiHandler.ListTunes,
 		},
+		{
+			"MergePeople",
+			http.MethodPost,
+			"/people/:personId/merge",
+			handleFunctions.ApiHandler.MergePeople,
+		},
+		{
+			"MergeTuneTypes",
+			http.MethodPost,
+			"/tune-types/:tuneTypeId/merge",
+			handleFunctions.ApiHandler.MergeTuneTypes,
+		},
+		{
+			"MergeTunes",
+			http.MethodPost,
+			"/tunes/:tuneId/merge",
+			handleFunctions.ApiHandler.MergeTunes,
+		},
+		{
+			"RestoreTuneRevision",
+			http.MethodPost,
+			"/tunes/:tuneId/revisions/:revision/restore",
+			handleFunctions.ApiHandler.RestoreTuneRevision,
+		},
+		{
+			"Search",
+			http.MethodGet,
+			"/search",
+			handleFunctions.ApiHandler.Search,
+		},
+		{
+			"SetMembership",
+			http.MethodPut,
+			"/memberships/:userId",
+			handleFunctions.ApiHandler.SetMembership,
+		},
+		{
+			"SetPersonAliases",
+			http.MethodPut,
+			"/people/:personId/aliases",
+			handleFunctions.ApiHandler.SetPersonAliases,
+		},
+		{
+			"SetTuneTypeAliases",
+			http.MethodPut,
+			"/tune-types/:tuneTypeId/aliases",
+			handleFunctions.ApiHandler.SetTuneTypeAliases,
+		},
+		{
+			"UpdateMessage",
+			http.MethodPut,
+			"/messages/:messageId",
+			handleFunctions.ApiHandler.UpdateMessage,
+		},
+		{
+			"UpdatePerson",
+			http.MethodPut,
+			"/people/:personId",
+			handleFunctions.ApiHandler.UpdatePerson,
+		},
 		{
 			"UpdateSet",
 			http.MethodPut,
 			"/sets/:setId",
 			handleFunctions.ApiHandler.UpdateSet,
 		},
+		{
+			"UpdateTag",
+			http.MethodPut,
+			"/tags/:tagId",
+			handleFunctions.ApiHandler.UpdateTag,
+		},
 		{
 			"UpdateTune",
 			http.MethodPut,
 			"/tunes/:tuneId",
 			handleFunctions.ApiHandler.UpdateTune,
 		},
+		{
+			"UpdateTuneMessages",
+			http.MethodPut,
+			"/tunes/:tuneId/messages",
+			handleFunctions.ApiHandler.UpdateTuneMessages,
+		},
+		{
+			"UpdateTuneType",
+			http.MethodPut,
+			"/tune-types/:tuneTypeId",
+			handleFunctions.ApiHandler.UpdateTuneType,
+		},
+		{
+			"UploadTuneFile",
+			http.MethodPost,
+			"/tunes/:tuneId/files/:format",
+			handleFunctions.ApiHandler.UploadTuneFile,
+		},
 	}
 }
@@ -0,0 +1,207 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"github.com/spf13/afero"
+	"github.com/tomvodi/limepipes/internal/common"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+)
+
+const (
+	// MaxFiles is the maximum number of files an archive may contain.
+	MaxFiles = 10000
+	// MaxSize is the maximum size of all unpacked files of an archive.
+	MaxSize = 256 << 20
+)
+
+// FileExtensions are the extensions of the supported archive files.
+var FileExtensions = []string{".zip", ".tar.gz", ".tgz"}
+
+var errTooLarge = fmt.Errorf("%w: archive exceeds the limit of %d files or %d bytes",
+	common.ErrInvalidArgument, MaxFiles, MaxSize)
+
+// IsArchive returns true if the file name has the extension of a supported archive.
+func IsArchive(fileName string) bool {
+	lower := strings.ToLower(fileName)
+	return slices.ContainsFunc(FileExtensions, func(ext string) bool {
+		return strings.HasSuffix(lower, ext)
+	})
+}
+
+// Extract unpacks the archive data into an in-memory file system. The type of the
+// archive is determined by the file name. Entries that would end up outside the root
+// of the file system are skipped.
+func Extract(fileName string, data []byte) (afero.Fs, error) {
+	afs := afero.NewMemMapFs()
+	ex := &extractor{afs: afs}
+
+	var err error
+	if strings.HasSuffix(strings.ToLower(fileName), ".zip") {
+		err = ex.extractZip(data)
+	} else {
+		err = ex.extractTarGz(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed extracting archive %s: %w", fileName, err)
+	}
+
+	return afs, nil
+}
+
+// Files returns the paths of all files of an extracted archive in lexical order.
+// Hidden files and folders and the resource forks of macOS are omitted.
+func Files(afs afero.Fs) ([]string, error) {
+	fc := &fileCollector{}
+	if err := afero.Walk(afs, "/", fc.visit); err != nil {
+		return nil, err
+	}
+
+	slices.Sort(fc.files)
+
+	return fc.files, nil
+}
+
+type fileCollector struct {
+	files []string
+}
+
+func (fc *fileCollector) visit(p string, info fs.FileInfo, err error) error {
+	if err != nil {
+		return err
+	}
+
+	if p != "/" && isHidden(info.Name()) {
+		if info.IsDir() {
+			return fs.SkipDir
+		}
+		return nil
+	}
+
+	if !info.IsDir() {
+		fc.files = append(fc.files, p)
+	}
+
+	return nil
+}
+
+func isHidden(name string) bool {
+	return strings.HasPrefix(name, ".") || name == "__MACOSX"
+}
+
+type extractor struct {
+	afs       afero.Fs
+	fileCount int
+	size      int64
+}
+
+func (e *extractor) extractZip(data []byte) error {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+
+		if err = e.extractZipFile(f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *extractor) extractZipFile(f *zip.File) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	return e.writeFile(f.Name, rc)
+}
+
+func (e *extractor) extractTarGz(data []byte) error {
+	gzr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer gzr.Close()
+
+	return e.extractTar(tar.NewReader(gzr))
+}
+
+func (e *extractor) extractTar(tr *tar.Reader) error {
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err = e.extractTarEntry(hdr, tr); err != nil {
+			return err
+		}
+	}
+}
+
+func (e *extractor) extractTarEntry(hdr *tar.Header, tr *tar.Reader) error {
+	if hdr.Typeflag != tar.TypeReg {
+		return nil
+	}
+
+	return e.writeFile(hdr.Name, tr)
+}
+
+// writeFile writes the archive entry into the file system while enforcing
+// the limits of the archive.
+func (e *extractor) writeFile(name string, r io.Reader) error {
+	filePath, ok := entryPath(name)
+	if !ok {
+		return nil
+	}
+
+	e.fileCount++
+	if e.fileCount > MaxFiles {
+		return errTooLarge
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, MaxSize-e.size+1))
+	if err != nil {
+		return err
+	}
+	e.size += int64(len(data))
+	if e.size > MaxSize {
+		return errTooLarge
+	}
+
+	if err = e.afs.MkdirAll(path.Dir(filePath), 0755); err != nil {
+		return err
+	}
+
+	return afero.WriteFile(e.afs, filePath, data, 0644)
+}
+
+// entryPath returns the absolute path of an archive entry in the extracted
+// file system. It returns false for entries that point outside the archive.
+func entryPath(name string) (string, bool) {
+	name = strings.ReplaceAll(name, "\\", "/")
+	if path.IsAbs(name) || slices.Contains(strings.Split(name, "/"), "..") {
+		return "", false
+	}
+
+	return path.Join("/", name), true
+}
@@ -0,0 +1,85 @@
+package archive
+
+import (
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	"github.com/tomvodi/limepipes/internal/common"
+	"testing"
+)
+
+func TestIsArchive(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(IsArchive("tunes.zip")).To(BeTrue())
+	g.Expect(IsArchive("tunes.TAR.GZ")).To(BeTrue())
+	g.Expect(IsArchive("tunes.tgz")).To(BeTrue())
+	g.Expect(IsArchive("tunes.gz")).To(BeFalse())
+	g.Expect(IsArchive("tune.bww")).To(BeFalse())
+}
+
+func TestExtract(t *testing.T) {
+	files := []TestFile{
+		{Name: "marches/scotland.bww", Content: "scotland"},
+		{Name: "marches/.hidden.bww", Content: "hidden"},
+		{Name: "__MACOSX/marches/._scotland.bww", Content: "resource fork"},
+		{Name: "../outside.bww", Content: "outside"},
+		{Name: "reels/mason.bww", Content: "mason"},
+		{Name: "readme.txt", Content: "readme"},
+	}
+
+	tests := []struct {
+		name     string
+		fileName string
+		data     []byte
+	}{
+		{
+			name:     "zip archive",
+			fileName: "tunes.zip",
+			data:     TestZip(files...),
+		},
+		{
+			name:     "tar.gz archive",
+			fileName: "tunes.tar.gz",
+			data:     TestTarGz(files...),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			afs, err := Extract(tt.fileName, tt.data)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			paths, err := Files(afs)
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(paths).To(Equal([]string{
+				"/marches/scotland.bww",
+				"/readme.txt",
+				"/reels/mason.bww",
+			}))
+
+			data, err := afero.ReadFile(afs, "/reels/mason.bww")
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(string(data)).To(Equal("mason"))
+		})
+	}
+}
+
+func TestExtractInvalidArchive(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	_, err := Extract("tunes.zip", []byte("no zip"))
+	g.Expect(err).Should(HaveOccurred())
+}
+
+func TestExtractTooManyFiles(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	files := make([]TestFile, MaxFiles+1)
+	for i := range files {
+		files[i] = TestFile{Name: "tune.bww"}
+	}
+
+	_, err := Extract("tunes.zip", TestZip(files...))
+	g.Expect(err).To(MatchError(common.ErrInvalidArgument))
+}
@@ -0,0 +1,61 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+)
+
+// TestFile is a file that is written into an archive for testing.
+type TestFile struct {
+	Name    string
+	Content string
+}
+
+func TestZip(files ...TestFile) []byte {
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for _, f := range files {
+		w, err := zw.Create(f.Name)
+		if err != nil {
+			panic(err)
+		}
+		if _, err = w.Write([]byte(f.Content)); err != nil {
+			panic(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		panic(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestTarGz(files ...TestFile) []byte {
+	buf := new(bytes.Buffer)
+	gzw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gzw)
+	for _, f := range files {
+		err := tw.WriteHeader(&tar.Header{
+			Name:     f.Name,
+			Mode:     0644,
+			Size:     int64(len(f.Content)),
+			Typeflag: tar.TypeReg,
+		})
+		if err != nil {
+			panic(err)
+		}
+		if _, err = tw.Write([]byte(f.Content)); err != nil {
+			panic(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		panic(err)
+	}
+	if err := gzw.Close(); err != nil {
+		panic(err)
+	}
+
+	return buf.Bytes()
+}
@@ -0,0 +1,116 @@
+// Package audio synthesizes the music model of tunes as audio files to practise
+// with, either in the sound of a practice chanter or of the pipes with drones.
+package audio
+
+import (
+	"fmt"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/playback"
+)
+
+// SampleRate are the samples per second of the synthesized audio.
+const SampleRate = 22050
+
+// Timbre is the instrument that plays the tunes.
+type Timbre string
+
+const (
+	TimbrePipes           Timbre = "pipes"
+	TimbrePracticeChanter Timbre = "practice-chanter"
+)
+
+// Format is the file format of the synthesized audio.
+type Format string
+
+const (
+	FormatWAV Format = "wav"
+	// FormatOGG files are Ogg streams with the audio losslessly compressed
+	// with FLAC, as by the Ogg mapping of FLAC.
+	FormatOGG Format = "ogg"
+)
+
+// fileType is the content type and extension of files of a format.
+type fileType struct {
+	contentType string
+	extension   string
+}
+
+var formatFileTypes = map[Format]fileType{
+	FormatWAV: {contentType: "audio/wav", extension: ".wav"},
+	// .oga is the extension of Ogg audio with other codecs than Vorbis
+	FormatOGG: {contentType: "audio/ogg; codecs=flac", extension: ".oga"},
+}
+
+// Options of the synthesized audio. The zero value synthesizes
+// the pipes at the tempo of the tunes without count-in as WAV file.
+type Options struct {
+	Format Format
+	Timbre Timbre
+	// Speed scales the tempo of the tunes, e.g. 0.8 plays them slower
+	Speed float64
+	// CountIn is the number of clicks on the beat before the first tune
+	CountIn uint32
+	// Title is written into the file if the format supports it
+	Title string
+}
+
+// Render synthesizes the tunes one after another as one continuous track
+// and returns it as file in the format of the options.
+func Render(tunes []*tune.Tune, opts Options) ([]byte, error) {
+	format := formatOrDefault(opts.Format)
+	if _, ok := formatFileTypes[format]; !ok {
+		return nil, fmt.Errorf("%w: unknown audio format %s", common.ErrInvalidArgument, opts.Format)
+	}
+	t, ok := timbres[timbreOrDefault(opts.Timbre)]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown timbre %s", common.ErrInvalidArgument, opts.Timbre)
+	}
+
+	samples := synthesize(perform(tunes, opts.Speed), t, opts.CountIn)
+	if format == FormatOGG {
+		return encodeOGG(samples, opts.Title), nil
+	}
+
+	return encodeWAV(samples), nil
+}
+
+// ContentType returns the content type of files of the format.
+func ContentType(format Format) string {
+	return formatFileTypes[formatOrDefault(format)].contentType
+}
+
+// Extension returns the file extension with a leading dot for files of the format.
+func Extension(format Format) string {
+	return formatFileTypes[formatOrDefault(format)].extension
+}
+
+// perform returns the performance of the tunes one after another.
+func perform(tunes []*tune.Tune, speed float64) *playback.Performance {
+	if speed == 0 {
+		speed = 1
+	}
+
+	perf := &playback.Performance{}
+	for _, t := range tunes {
+		perf.Append(playback.PlayAtSpeed(t, speed))
+	}
+
+	return perf
+}
+
+func formatOrDefault(format Format) Format {
+	if format == "" {
+		return FormatWAV
+	}
+
+	return format
+}
+
+func timbreOrDefault(timbre Timbre) Timbre {
+	if timbre == "" {
+		return TimbrePipes
+	}
+
+	return timbre
+}
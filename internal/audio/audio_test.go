@@ -0,0 +1,147 @@
+package audio
+
+import (
+	"encoding/binary"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/length"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/measure"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/pitch"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/embellishment"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"github.com/tomvodi/limepipes/internal/common"
+	"testing"
+)
+
+// testTune returns a tune of two seconds with a low A, a rest and an E.
+func testTune() *tune.Tune {
+	return &tune.Tune{
+		Tempo: 60,
+		Measures: []*measure.Measure{
+			{
+				Time: &measure.TimeSignature{Beats: 2, BeatType: 4},
+				Symbols: []*symbols.Symbol{
+					{Note: &symbols.Note{
+						Pitch:         pitch.Pitch_LowA,
+						Length:        length.Length_Eighth,
+						Embellishment: &embellishment.Embellishment{Type: embellishment.Type_SingleGrace, Pitch: pitch.Pitch_HighG},
+					}},
+					{Rest: &symbols.Rest{Length: length.Length_Eighth}},
+					{Note: &symbols.Note{Pitch: pitch.Pitch_E, Length: length.Length_Quarter}},
+				},
+			},
+		},
+	}
+}
+
+// wavSamples returns the samples of a mono 16 bit WAV file.
+func wavSamples(g *WithT, data []byte) []int16 {
+	g.Expect(string(data[0:4])).To(Equal("RIFF"))
+	g.Expect(binary.LittleEndian.Uint32(data[4:])).To(Equal(uint32(len(data) - 8)))
+	g.Expect(string(data[8:16])).To(Equal("WAVEfmt "))
+	g.Expect(binary.LittleEndian.Uint16(data[20:])).To(Equal(uint16(pcmFormat)))
+	g.Expect(binary.LittleEndian.Uint16(data[22:])).To(Equal(uint16(1)))
+	g.Expect(binary.LittleEndian.Uint32(data[24:])).To(Equal(uint32(SampleRate)))
+	g.Expect(binary.LittleEndian.Uint16(data[34:])).To(Equal(uint16(16)))
+	g.Expect(string(data[36:40])).To(Equal("data"))
+	g.Expect(binary.LittleEndian.Uint32(data[40:])).To(Equal(uint32(len(data) - 44)))
+
+	samples := make([]int16, (len(data)-44)/2)
+	for i := range samples {
+		samples[i] = int16(binary.LittleEndian.Uint16(data[44+2*i:]))
+	}
+
+	return samples
+}
+
+// isSilent returns true if the samples between the seconds are all zero.
+func isSilent(samples []int16, from float64, to float64) bool {
+	for _, s := range samples[sampleOf(from):sampleOf(to)] {
+		if s != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestRenderWAV(t *testing.T) {
+	g := NewWithT(t)
+
+	data, err := Render([]*tune.Tune{testTune()}, Options{})
+
+	g.Expect(err).ShouldNot(HaveOccurred())
+	samples := wavSamples(g, data)
+	g.Expect(samples).To(HaveLen(2 * SampleRate))
+	// the drones of the pipes sound during the rest
+	g.Expect(isSilent(samples, 0.6, 0.9)).To(BeFalse())
+
+	peak := int16(0)
+	for _, s := range samples {
+		peak = max(peak, s, -s)
+	}
+	g.Expect(peak).To(Equal(int16(29490)))
+}
+
+func TestRenderPracticeChanter(t *testing.T) {
+	g := NewWithT(t)
+
+	data, err := Render([]*tune.Tune{testTune()}, Options{Timbre: TimbrePracticeChanter})
+
+	g.Expect(err).ShouldNot(HaveOccurred())
+	samples := wavSamples(g, data)
+	g.Expect(samples).To(HaveLen(2 * SampleRate))
+	// the practice chanter has no drones
+	g.Expect(isSilent(samples, 0.01, 0.49)).To(BeFalse())
+	g.Expect(isSilent(samples, 0.5, 1)).To(BeTrue())
+	g.Expect(isSilent(samples, 1.01, 1.99)).To(BeFalse())
+}
+
+func TestRenderCountIn(t *testing.T) {
+	g := NewWithT(t)
+
+	data, err := Render([]*tune.Tune{testTune()}, Options{Timbre: TimbrePracticeChanter, CountIn: 2})
+
+	g.Expect(err).ShouldNot(HaveOccurred())
+	samples := wavSamples(g, data)
+	// two clicks at 60 beats per minute before the tune
+	g.Expect(samples).To(HaveLen(4 * SampleRate))
+	g.Expect(isSilent(samples, 0, clickSeconds)).To(BeFalse())
+	g.Expect(isSilent(samples, clickSeconds, 1)).To(BeTrue())
+	g.Expect(isSilent(samples, 1, 1+clickSeconds)).To(BeFalse())
+	g.Expect(isSilent(samples, 1+clickSeconds, 2)).To(BeTrue())
+	g.Expect(isSilent(samples, 2, 2.49)).To(BeFalse())
+}
+
+func TestRenderSpeedAndSeveralTunes(t *testing.T) {
+	g := NewWithT(t)
+
+	data, err := Render([]*tune.Tune{testTune(), testTune()}, Options{Timbre: TimbrePracticeChanter, Speed: 0.5})
+
+	g.Expect(err).ShouldNot(HaveOccurred())
+	samples := wavSamples(g, data)
+	g.Expect(samples).To(HaveLen(8 * SampleRate))
+	// the rests of both tunes take twice as long
+	g.Expect(isSilent(samples, 1, 2)).To(BeTrue())
+	g.Expect(isSilent(samples, 5, 6)).To(BeTrue())
+	g.Expect(isSilent(samples, 4.01, 4.99)).To(BeFalse())
+}
+
+func TestRenderInvalidOptions(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := Render([]*tune.Tune{testTune()}, Options{Format: "mp3"})
+	g.Expect(err).To(MatchError(common.ErrInvalidArgument))
+
+	_, err = Render([]*tune.Tune{testTune()}, Options{Timbre: "harp"})
+	g.Expect(err).To(MatchError(common.ErrInvalidArgument))
+}
+
+func TestContentTypeAndExtension(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(ContentType("")).To(Equal("audio/wav"))
+	g.Expect(Extension("")).To(Equal(".wav"))
+	g.Expect(ContentType(FormatOGG)).To(Equal("audio/ogg; codecs=flac"))
+	g.Expect(Extension(FormatOGG)).To(Equal(".oga"))
+}
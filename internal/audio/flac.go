@@ -0,0 +1,236 @@
+package audio
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+)
+
+const (
+	// flacBlockSize is the number of samples of the FLAC frames,
+	// only the last frame may be shorter.
+	flacBlockSize = 4096
+
+	// flacOrder is the order of the fixed predictor of the subframes,
+	// which predicts a sample from the slope of the two samples before it.
+	flacOrder = 2
+
+	flacMaxRiceParam = 14
+
+	flacStreamInfo    = 0
+	flacVorbisComment = 4
+	flacVendor        = "limepipes"
+)
+
+// flacStreamInfoBlock returns the STREAMINFO metadata block of the samples.
+func flacStreamInfoBlock(samples []int16) []byte {
+	w := &bitWriter{}
+	w.writeBits(flacBlockSize, 16)
+	w.writeBits(flacBlockSize, 16)
+	// the minimum and maximum frame sizes are unknown
+	w.writeBits(0, 24)
+	w.writeBits(0, 24)
+	w.writeBits(SampleRate, 20)
+	w.writeBits(0, 3)
+	w.writeBits(bitsPerSample-1, 5)
+	w.writeBits(uint64(len(samples)), 36)
+
+	sum := md5.New()
+	for _, s := range samples {
+		_ = binary.Write(sum, binary.LittleEndian, s)
+	}
+
+	return flacMetadataBlock(flacStreamInfo, false, append(w.bytes(), sum.Sum(nil)...))
+}
+
+// flacVorbisCommentBlock returns the VORBIS_COMMENT metadata block with the title.
+func flacVorbisCommentBlock(title string) []byte {
+	data := binary.LittleEndian.AppendUint32(nil, uint32(len(flacVendor)))
+	data = append(data, flacVendor...)
+	if title == "" {
+		return flacMetadataBlock(flacVorbisComment, true, binary.LittleEndian.AppendUint32(data, 0))
+	}
+
+	comment := "TITLE=" + title
+	data = binary.LittleEndian.AppendUint32(data, 1)
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(comment)))
+
+	return flacMetadataBlock(flacVorbisComment, true, append(data, comment...))
+}
+
+func flacMetadataBlock(blockType byte, last bool, data []byte) []byte {
+	if last {
+		blockType |= 0x80
+	}
+	size := len(data)
+
+	return append([]byte{blockType, byte(size >> 16), byte(size >> 8), byte(size)}, data...)
+}
+
+// flacFrame returns a frame of a block of samples of a mono stream with
+// the number of the frame. The samples of the block are predicted by a
+// fixed predictor and the residuals are rice coded.
+func flacFrame(number uint64, block []int16) []byte {
+	w := &bitWriter{}
+	w.writeBits(0xFFF8, 16)
+	blockSizeCode := uint64(12)
+	if len(block) != flacBlockSize {
+		blockSizeCode = 7
+	}
+	w.writeBits(blockSizeCode, 4)
+	// 22.05 kHz, mono and 16 bits per sample
+	w.writeBits(0b0110, 4)
+	w.writeBits(0b0000_100_0, 8)
+	w.writeUTF8(number)
+	if blockSizeCode == 7 {
+		w.writeBits(uint64(len(block)-1), 16)
+	}
+	w.writeBits(uint64(crc8(w.bytes())), 8)
+
+	writeFixedSubframe(w, block)
+	w.align()
+	frame := w.bytes()
+
+	return binary.BigEndian.AppendUint16(frame, crc16(frame))
+}
+
+// writeFixedSubframe writes the subframe of the block with the fixed predictor,
+// blocks shorter than the order of the predictor are written verbatim.
+func writeFixedSubframe(w *bitWriter, block []int16) {
+	if len(block) <= flacOrder {
+		w.writeBits(0b0_000001_0, 8)
+		for _, s := range block {
+			w.writeBits(uint64(uint16(s)), bitsPerSample)
+		}
+		return
+	}
+
+	w.writeBits(0b0_001000_0|flacOrder<<1, 8)
+	for _, s := range block[:flacOrder] {
+		w.writeBits(uint64(uint16(s)), bitsPerSample)
+	}
+
+	residuals := make([]uint64, len(block)-flacOrder)
+	for i := range residuals {
+		n := i + flacOrder
+		r := int64(block[n]) - 2*int64(block[n-1]) + int64(block[n-2])
+		residuals[i] = uint64(r<<1 ^ r>>63)
+	}
+	writeRiceResiduals(w, residuals)
+}
+
+// writeRiceResiduals writes the zigzag coded residuals as a single partition
+// with the rice parameter that takes the fewest bits.
+func writeRiceResiduals(w *bitWriter, residuals []uint64) {
+	param := bestRiceParam(residuals)
+	// rice coding with 4 bit parameters in 2^0 partitions
+	w.writeBits(0b00, 2)
+	w.writeBits(0, 4)
+	w.writeBits(uint64(param), 4)
+	for _, r := range residuals {
+		w.writeUnary(r >> param)
+		w.writeBits(r&(1<<param-1), param)
+	}
+}
+
+func bestRiceParam(residuals []uint64) uint {
+	best := uint(0)
+	bestBits := uint64(0)
+	for param := uint(0); param <= flacMaxRiceParam; param++ {
+		bits := uint64(0)
+		for _, r := range residuals {
+			bits += r>>param + 1 + uint64(param)
+		}
+		if param == 0 || bits < bestBits {
+			best, bestBits = param, bits
+		}
+	}
+
+	return best
+}
+
+// bitWriter writes values with the most significant bit first.
+type bitWriter struct {
+	data  []byte
+	nbits uint
+}
+
+func (w *bitWriter) writeBits(v uint64, n uint) {
+	for i := n; i > 0; i-- {
+		w.writeBit(v >> (i - 1) & 1)
+	}
+}
+
+func (w *bitWriter) writeBit(bit uint64) {
+	if w.nbits%8 == 0 {
+		w.data = append(w.data, 0)
+	}
+	w.data[len(w.data)-1] |= byte(bit) << (7 - w.nbits%8)
+	w.nbits++
+}
+
+// writeUnary writes the value as that many zeros followed by a one.
+func (w *bitWriter) writeUnary(v uint64) {
+	for ; v > 0; v-- {
+		w.writeBit(0)
+	}
+	w.writeBit(1)
+}
+
+// writeUTF8 writes the value coded like UTF-8 characters with up to 36 bits.
+func (w *bitWriter) writeUTF8(v uint64) {
+	if v < 0x80 {
+		w.writeBits(v, 8)
+		return
+	}
+
+	followers := uint(1)
+	for v >= 1<<(6*followers+6-followers) {
+		followers++
+	}
+	lead := uint64(0xFF) << (7 - followers) & 0xFF
+	w.writeBits(lead|v>>(6*followers), 8)
+	for i := followers; i > 0; i-- {
+		w.writeBits(0x80|v>>(6*(i-1))&0x3F, 8)
+	}
+}
+
+// align pads the last byte with zeros.
+func (w *bitWriter) align() {
+	w.nbits = uint(len(w.data)) * 8
+}
+
+func (w *bitWriter) bytes() []byte {
+	return w.data
+}
+
+func crc8(data []byte) byte {
+	crc := byte(0)
+	for _, b := range data {
+		crc ^= b
+		for range 8 {
+			if crc&0x80 != 0 {
+				crc = crc<<1 ^ 0x07
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return crc
+}
+
+func crc16(data []byte) uint16 {
+	crc := uint16(0)
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for range 8 {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x8005
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return crc
+}
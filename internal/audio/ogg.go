@@ -0,0 +1,96 @@
+package audio
+
+import "encoding/binary"
+
+// header types of Ogg pages
+const (
+	oggFirstPage = 0x02
+	oggLastPage  = 0x04
+)
+
+const (
+	oggSerial      = 0x4C696D65
+	oggMaxSegments = 255
+)
+
+// oggCRCTable is the table of the CRC of Ogg pages with the polynomial 0x04C11DB7.
+var oggCRCTable = func() [256]uint32 {
+	var table [256]uint32
+	for i := range table {
+		crc := uint32(i) << 24
+		for range 8 {
+			if crc&0x80000000 != 0 {
+				crc = crc<<1 ^ 0x04C11DB7
+			} else {
+				crc <<= 1
+			}
+		}
+		table[i] = crc
+	}
+	return table
+}()
+
+// oggStream writes the packets of a logical stream into Ogg pages.
+type oggStream struct {
+	data     []byte
+	sequence uint32
+}
+
+// encodeOGG returns the samples as Ogg file with the FLAC mapping, where the
+// first packet has the STREAMINFO, the second the VORBIS_COMMENT with the title
+// and every further packet a FLAC frame on its own page.
+func encodeOGG(samples []int16, title string) []byte {
+	s := &oggStream{}
+	first := append([]byte{0x7F}, "FLAC"...)
+	// mapping version 1.0 and one header packet after the first one
+	first = append(first, 1, 0, 0, 1)
+	first = append(first, "fLaC"...)
+	s.writePage(oggFirstPage, 0, append(first, flacStreamInfoBlock(samples)...))
+	frames := (len(samples) + flacBlockSize - 1) / flacBlockSize
+	commentHeaderType := byte(0)
+	if frames == 0 {
+		commentHeaderType = oggLastPage
+	}
+	s.writePage(commentHeaderType, 0, flacVorbisCommentBlock(title))
+
+	for i := range frames {
+		end := min((i+1)*flacBlockSize, len(samples))
+		headerType := byte(0)
+		if i == frames-1 {
+			headerType = oggLastPage
+		}
+		s.writePage(headerType, uint64(end), flacFrame(uint64(i), samples[i*flacBlockSize:end]))
+	}
+
+	return s.data
+}
+
+// writePage writes a page with the packet. The granule position are the
+// samples up to the end of the packet.
+func (s *oggStream) writePage(headerType byte, granule uint64, packet []byte) {
+	segments := len(packet)/oggMaxSegments + 1
+	page := append([]byte("OggS"), 0, headerType)
+	page = binary.LittleEndian.AppendUint64(page, granule)
+	page = binary.LittleEndian.AppendUint32(page, oggSerial)
+	page = binary.LittleEndian.AppendUint32(page, s.sequence)
+	page = binary.LittleEndian.AppendUint32(page, 0)
+	page = append(page, byte(segments))
+	for i := 1; i < segments; i++ {
+		page = append(page, oggMaxSegments)
+	}
+	page = append(page, byte(len(packet)%oggMaxSegments))
+	page = append(page, packet...)
+	binary.LittleEndian.PutUint32(page[22:], oggCRC(page))
+
+	s.data = append(s.data, page...)
+	s.sequence++
+}
+
+func oggCRC(data []byte) uint32 {
+	crc := uint32(0)
+	for _, b := range data {
+		crc = crc<<8 ^ oggCRCTable[byte(crc>>24)^b]
+	}
+
+	return crc
+}
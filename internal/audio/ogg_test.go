@@ -0,0 +1,200 @@
+package audio
+
+import (
+	"encoding/binary"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"testing"
+)
+
+// oggPackets returns the packets of the pages of an Ogg file with a logical
+// stream and checks the header of every page.
+func oggPackets(g *WithT, data []byte) (packets [][]byte, granules []uint64, headerTypes []byte) {
+	for sequence := uint32(0); len(data) > 0; sequence++ {
+		g.Expect(string(data[0:4])).To(Equal("OggS"))
+		g.Expect(binary.LittleEndian.Uint32(data[14:])).To(Equal(uint32(oggSerial)))
+		g.Expect(binary.LittleEndian.Uint32(data[18:])).To(Equal(sequence))
+
+		segments := int(data[26])
+		size := 0
+		for _, s := range data[27 : 27+segments] {
+			size += int(s)
+		}
+		pageSize := 27 + segments + size
+		page := append([]byte{}, data[:pageSize]...)
+		crc := binary.LittleEndian.Uint32(page[22:])
+		binary.LittleEndian.PutUint32(page[22:], 0)
+		g.Expect(oggCRC(page)).To(Equal(crc))
+
+		packets = append(packets, data[27+segments:pageSize])
+		granules = append(granules, binary.LittleEndian.Uint64(data[6:]))
+		headerTypes = append(headerTypes, data[5])
+		data = data[pageSize:]
+	}
+
+	return packets, granules, headerTypes
+}
+
+// bitReader reads values with the most significant bit first.
+type bitReader struct {
+	data []byte
+	pos  uint
+}
+
+func (r *bitReader) readBits(n uint) uint64 {
+	v := uint64(0)
+	for range n {
+		v = v<<1 | uint64(r.data[r.pos/8]>>(7-r.pos%8)&1)
+		r.pos++
+	}
+	return v
+}
+
+func (r *bitReader) readUnary() uint64 {
+	v := uint64(0)
+	for r.readBits(1) == 0 {
+		v++
+	}
+	return v
+}
+
+// decodeFlacFrame returns the samples of a frame written by flacFrame.
+func decodeFlacFrame(g *WithT, frame []byte) []int16 {
+	g.Expect(crc16(frame[:len(frame)-2])).To(Equal(binary.BigEndian.Uint16(frame[len(frame)-2:])))
+
+	r := &bitReader{data: frame}
+	g.Expect(r.readBits(16)).To(Equal(uint64(0xFFF8)))
+	blockSize := flacBlockSize
+	blockSizeCode := r.readBits(4)
+	g.Expect(r.readBits(12)).To(Equal(uint64(0b0110_0000_1000)))
+	// skips the UTF-8 coded frame number
+	for lead := r.readBits(8); lead&0xC0 == 0xC0; lead <<= 1 {
+		r.pos += 8
+	}
+	if blockSizeCode == 7 {
+		blockSize = int(r.readBits(16)) + 1
+	}
+	g.Expect(r.readBits(8)).To(Equal(uint64(crc8(frame[:r.pos/8-1]))))
+
+	subframe := r.readBits(8)
+	samples := make([]int16, blockSize)
+	if subframe == 0b0_000001_0 {
+		for i := range samples {
+			samples[i] = int16(r.readBits(bitsPerSample))
+		}
+		return samples
+	}
+
+	g.Expect(subframe).To(Equal(uint64(0b0_001000_0 | flacOrder<<1)))
+	for i := range flacOrder {
+		samples[i] = int16(r.readBits(bitsPerSample))
+	}
+	g.Expect(r.readBits(6)).To(Equal(uint64(0)))
+	param := uint(r.readBits(4))
+	for i := flacOrder; i < blockSize; i++ {
+		u := r.readUnary()<<param | r.readBits(param)
+		residual := int64(u>>1) ^ -int64(u&1)
+		samples[i] = int16(residual + 2*int64(samples[i-1]) - int64(samples[i-2]))
+	}
+
+	return samples
+}
+
+func TestRenderOGG(t *testing.T) {
+	g := NewWithT(t)
+	opts := Options{Format: FormatOGG, Title: "Scotland the Brave"}
+
+	data, err := Render([]*tune.Tune{testTune()}, opts)
+
+	g.Expect(err).ShouldNot(HaveOccurred())
+	packets, granules, headerTypes := oggPackets(g, data)
+	g.Expect(headerTypes[0]).To(Equal(byte(oggFirstPage)))
+	g.Expect(headerTypes[1 : len(headerTypes)-1]).To(HaveEach(byte(0)))
+	g.Expect(headerTypes[len(headerTypes)-1]).To(Equal(byte(oggLastPage)))
+
+	header := packets[0]
+	g.Expect(string(header[0:5])).To(Equal("\x7FFLAC"))
+	g.Expect(string(header[9:13])).To(Equal("fLaC"))
+	streamInfo := &bitReader{data: header[17:]}
+	streamInfo.pos = 80
+	g.Expect(streamInfo.readBits(20)).To(Equal(uint64(SampleRate)))
+	g.Expect(streamInfo.readBits(3)).To(Equal(uint64(0)))
+	g.Expect(streamInfo.readBits(5)).To(Equal(uint64(bitsPerSample - 1)))
+	g.Expect(streamInfo.readBits(36)).To(Equal(uint64(2 * SampleRate)))
+	g.Expect(string(packets[1])).To(ContainSubstring("TITLE=Scotland the Brave"))
+
+	wav, err := Render([]*tune.Tune{testTune()}, Options{})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	var samples []int16
+	for i, frame := range packets[2:] {
+		samples = append(samples, decodeFlacFrame(g, frame)...)
+		g.Expect(granules[i+2]).To(Equal(uint64(len(samples))))
+	}
+	g.Expect(samples).To(Equal(wavSamples(g, wav)))
+}
+
+func TestFlacFrameOfShortBlock(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(decodeFlacFrame(g, flacFrame(1000, []int16{-3, 7}))).To(Equal([]int16{-3, 7}))
+	g.Expect(decodeFlacFrame(g, flacFrame(5, []int16{-3, 7, 32767, -32768}))).
+		To(Equal([]int16{-3, 7, 32767, -32768}))
+}
+
+// TestEncodeOGGReference compares a stream with the bytes of the Ogg mapping
+// of FLAC and the FLAC specification (RFC 9639) that were put together by hand.
+func TestEncodeOGGReference(t *testing.T) {
+	g := NewWithT(t)
+
+	reference := []byte{
+		// first page with version 0, beginning of stream, granule 0, the serial,
+		// sequence 0, the CRC and one segment of 51 bytes
+		'O', 'g', 'g', 'S', 0x00, 0x02,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x65, 0x6D, 0x69, 0x4C, 0x00, 0x00, 0x00, 0x00,
+		0xC2, 0x85, 0x47, 0x05, 0x01, 0x33,
+		// mapping FLAC 1.0 with one more header packet
+		0x7F, 'F', 'L', 'A', 'C', 0x01, 0x00, 0x00, 0x01,
+		'f', 'L', 'a', 'C',
+		// STREAMINFO, not the last block, of 34 bytes
+		0x00, 0x00, 0x00, 0x22,
+		// minimum and maximum block size of 4096 and unknown frame sizes
+		0x10, 0x00, 0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		// 22050 Hz, 1 channel, 16 bits per sample and 2 samples
+		0x05, 0x62, 0x20, 0xF0, 0x00, 0x00, 0x00, 0x02,
+		// MD5 of the samples as signed 16 bit little endian
+		0x33, 0x29, 0x6C, 0x39, 0xD7, 0x5D, 0x11, 0x5E, 0x6F, 0x56, 0x9D, 0xD7, 0x9E, 0x61, 0x61, 0x20,
+
+		// second page with sequence 1 and one segment of 21 bytes
+		'O', 'g', 'g', 'S', 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x65, 0x6D, 0x69, 0x4C, 0x01, 0x00, 0x00, 0x00,
+		0x89, 0xB5, 0x73, 0xA7, 0x01, 0x15,
+		// VORBIS_COMMENT, the last block, of 17 bytes with the vendor and no comments
+		0x84, 0x00, 0x00, 0x11,
+		0x09, 0x00, 0x00, 0x00, 'l', 'i', 'm', 'e', 'p', 'i', 'p', 'e', 's', 0x00, 0x00, 0x00, 0x00,
+
+		// last page with end of stream, granule 2, sequence 2 and one segment of 15 bytes
+		'O', 'g', 'g', 'S', 0x00, 0x04,
+		0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x65, 0x6D, 0x69, 0x4C, 0x02, 0x00, 0x00, 0x00,
+		0x65, 0xC9, 0x31, 0x9D, 0x01, 0x0F,
+		// frame header with fixed block size, a 16 bit block size of 2 samples after
+		// the frame number 0, 22050 Hz, mono, 16 bits per sample and the CRC-8
+		0xFF, 0xF8, 0x76, 0x08, 0x00, 0x00, 0x01, 0x56,
+		// verbatim subframe with -3 and 7
+		0x02, 0xFF, 0xFD, 0x00, 0x07,
+		// CRC-16 of the frame
+		0xC3, 0x38,
+	}
+
+	g.Expect(encodeOGG([]int16{-3, 7}, "")).To(Equal(reference))
+}
+
+func TestEncodeOGGWithoutSamples(t *testing.T) {
+	g := NewWithT(t)
+
+	_, _, headerTypes := oggPackets(g, encodeOGG(nil, ""))
+
+	g.Expect(headerTypes).To(Equal([]byte{oggFirstPage, oggLastPage}))
+}
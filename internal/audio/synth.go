@@ -0,0 +1,167 @@
+package audio
+
+import (
+	"github.com/tomvodi/limepipes/internal/playback"
+	"math"
+)
+
+const (
+	// tableSize is the number of samples of one period of a wavetable.
+	tableSize = 2048
+
+	// fadeSeconds is the time in which tones fade in and out to avoid clicks.
+	fadeSeconds = 0.004
+
+	clickFrequency = 1760
+	clickSeconds   = 0.03
+	clickDecay     = 150
+	clickGain      = 0.8
+
+	// peakLevel is the level of the loudest sample relative to the maximum.
+	peakLevel = 0.9
+)
+
+// timbre is the sound of an instrument with wavetables of one period of its tones.
+type timbre struct {
+	chanter     []float32
+	chanterGain float32
+	// drones is nil for instruments without drones
+	drones    []float32
+	droneGain float32
+}
+
+// timbres are made of the amplitudes of the harmonics of the tones. The conical
+// bore of the pipes chanter sounds bright with strong overtones, the cylindrical
+// bore of the practice chanter mostly has odd harmonics and sounds softer.
+var timbres = map[Timbre]timbre{
+	TimbrePipes: {
+		chanter:     wavetable(1, 0.9, 0.8, 0.65, 0.55, 0.45, 0.35, 0.3, 0.25, 0.2),
+		chanterGain: 0.6,
+		drones:      wavetable(1, 0.7, 0.55, 0.45, 0.38, 0.32, 0.27, 0.23, 0.2, 0.17, 0.15, 0.13),
+		droneGain:   0.25,
+	},
+	TimbrePracticeChanter: {
+		chanter:     wavetable(1, 0.08, 0.45, 0.05, 0.25, 0.03, 0.12, 0.02, 0.06),
+		chanterGain: 0.8,
+	},
+}
+
+// tone is a tone of a wavetable from its start for its duration in seconds.
+type tone struct {
+	start    float64
+	duration float64
+	freq     float64
+	table    []float32
+	gain     float32
+}
+
+// mixer adds up the samples of tones.
+type mixer struct {
+	samples []float32
+}
+
+// wavetable returns one period of a tone with the amplitudes of its harmonics.
+func wavetable(amplitudes ...float64) []float32 {
+	table := make([]float32, tableSize)
+	peak := 0.0
+	values := make([]float64, tableSize)
+	for i := range values {
+		for n, a := range amplitudes {
+			values[i] += a * math.Sin(2*math.Pi*float64((n+1)*i)/tableSize)
+		}
+		peak = max(peak, math.Abs(values[i]))
+	}
+	for i, v := range values {
+		table[i] = float32(v / peak)
+	}
+
+	return table
+}
+
+// synthesize returns the samples of the performance played by the timbre
+// after the clicks of the count-in.
+func synthesize(perf *playback.Performance, t timbre, countIn uint32) []int16 {
+	offset := 0.0
+	if countIn > 0 && len(perf.Tempos) > 0 {
+		offset = float64(countIn) * 60 / perf.Tempos[0].BPM
+	}
+
+	m := &mixer{samples: make([]float32, sampleOf(offset+perf.Duration))}
+	for i := range countIn {
+		m.addClick(float64(i) * offset / float64(countIn))
+	}
+	for _, n := range perf.Notes {
+		m.addTone(tone{
+			start:    offset + n.Start,
+			duration: n.Duration,
+			freq:     playback.Frequency(n.Pitch),
+			table:    t.chanter,
+			gain:     t.chanterGain,
+		})
+	}
+	if t.drones != nil {
+		for _, freq := range []float64{playback.TenorDroneFrequency, playback.BassDroneFrequency} {
+			m.addTone(tone{start: offset, duration: perf.Duration, freq: freq, table: t.drones, gain: t.droneGain})
+		}
+	}
+
+	return m.pcm()
+}
+
+// addTone adds the tone, which fades in and out.
+func (m *mixer) addTone(t tone) {
+	first := sampleOf(t.start)
+	last := min(sampleOf(t.start+t.duration), len(m.samples))
+	fade := float32(fadeSeconds * SampleRate)
+	step := t.freq * tableSize / SampleRate
+	phase := 0.0
+	for i := first; i < last; i++ {
+		env := min(1, float32(i-first)/fade, float32(last-i)/fade)
+		m.samples[i] += tableValue(t.table, phase) * t.gain * env
+		phase += step
+		if phase >= tableSize {
+			phase -= tableSize
+		}
+	}
+}
+
+// addClick adds a short decaying click at the time.
+func (m *mixer) addClick(start float64) {
+	first := sampleOf(start)
+	last := min(sampleOf(start+clickSeconds), len(m.samples))
+	for i := first; i < last; i++ {
+		t := float64(i-first) / SampleRate
+		m.samples[i] += float32(math.Sin(2*math.Pi*clickFrequency*t) * math.Exp(-t*clickDecay) * clickGain)
+	}
+}
+
+// pcm returns the samples as 16 bit values with the loudest sample at the peak level.
+func (m *mixer) pcm() []int16 {
+	peak := float32(0)
+	for _, s := range m.samples {
+		peak = max(peak, s, -s)
+	}
+	scale := float32(0)
+	if peak > 0 {
+		scale = peakLevel * math.MaxInt16 / peak
+	}
+
+	pcm := make([]int16, len(m.samples))
+	for i, s := range m.samples {
+		pcm[i] = int16(math.Round(float64(s * scale)))
+	}
+
+	return pcm
+}
+
+// tableValue returns the linear interpolated value of the table at the phase.
+func tableValue(table []float32, phase float64) float32 {
+	i := int(phase)
+	frac := float32(phase - float64(i))
+
+	return table[i]*(1-frac) + table[(i+1)%tableSize]*frac
+}
+
+func sampleOf(seconds float64) int {
+	return int(math.Round(seconds * SampleRate))
+}
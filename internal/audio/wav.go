@@ -0,0 +1,37 @@
+package audio
+
+import "encoding/binary"
+
+const (
+	bitsPerSample  = 16
+	bytesPerSample = bitsPerSample / 8
+	pcmFormat      = 1
+	fmtChunkSize   = 16
+)
+
+// encodeWAV returns the samples as mono WAV file with 16 bit PCM data.
+func encodeWAV(samples []int16) []byte {
+	dataSize := uint32(len(samples) * bytesPerSample)
+
+	data := make([]byte, 0, 44+dataSize)
+	data = append(data, "RIFF"...)
+	data = binary.LittleEndian.AppendUint32(data, 36+dataSize)
+	data = append(data, "WAVE"...)
+
+	data = append(data, "fmt "...)
+	data = binary.LittleEndian.AppendUint32(data, fmtChunkSize)
+	data = binary.LittleEndian.AppendUint16(data, pcmFormat)
+	data = binary.LittleEndian.AppendUint16(data, 1)
+	data = binary.LittleEndian.AppendUint32(data, SampleRate)
+	data = binary.LittleEndian.AppendUint32(data, SampleRate*bytesPerSample)
+	data = binary.LittleEndian.AppendUint16(data, bytesPerSample)
+	data = binary.LittleEndian.AppendUint16(data, bitsPerSample)
+
+	data = append(data, "data"...)
+	data = binary.LittleEndian.AppendUint32(data, dataSize)
+	for _, s := range samples {
+		data = binary.LittleEndian.AppendUint16(data, uint16(s))
+	}
+
+	return data
+}
@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/database/model"
+)
+
+func APIUser(user *model.User) apimodel.User {
+	return apimodel.User{
+		Id:   user.ID,
+		Name: user.Name,
+	}
+}
+
+func APIToken(token *model.APIToken) apimodel.ApiToken {
+	apiToken := apimodel.ApiToken{
+		Id:        token.ID,
+		Name:      token.Name,
+		CreatedAt: token.CreatedAt.Time,
+	}
+	if token.LastUsedAt != nil {
+		apiToken.LastUsedAt = &token.LastUsedAt.Time
+	}
+
+	return apiToken
+}
+
+func APIMembership(membership *model.Membership) apimodel.Membership {
+	return apimodel.Membership{
+		UserId:    membership.UserID,
+		UserName:  membership.User.Name,
+		Role:      string(membership.Role),
+		CreatedAt: membership.CreatedAt.Time,
+		UpdatedAt: membership.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/interfaces"
+	"net/http"
+	"strings"
+)
+
+var ErrUnauthorized = fmt.Errorf("unauthorized")
+
+const bearerPrefix = "Bearer "
+
+// publicPaths are the routes that can be accessed without authentication.
+var publicPaths = map[string]bool{
+	"/":       true,
+	"/health": true,
+}
+
+// Authenticator authenticates the requests to the API with the bearer token
+// of the Authorization header. A token is either an API token of a user or,
+// if a verifier is set, a token of an external identity provider.
+type Authenticator struct {
+	service  interfaces.DataService
+	verifier interfaces.TokenVerifier
+}
+
+// Middleware returns a gin middleware that rejects all requests to non-public
+// routes without a valid token and stores the user of the token in the context.
+func (a *Authenticator) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if publicPaths[c.FullPath()] {
+			c.Next()
+			return
+		}
+
+		user, err := a.Authenticate(c.GetHeader("Authorization"))
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer realm="limepipes"`)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, apimodel.Error{
+				Message: err.Error(),
+			})
+			return
+		}
+
+		SetUser(c, user)
+		c.Next()
+	}
+}
+
+// Authenticate returns the user of the bearer token in the given value
+// of an Authorization header.
+func (a *Authenticator) Authenticate(authHeader string) (*model.User, error) {
+	token, ok := strings.CutPrefix(authHeader, bearerPrefix)
+	token = strings.TrimSpace(token)
+	if !ok || token == "" {
+		return nil, fmt.Errorf("%w: missing bearer token", ErrUnauthorized)
+	}
+
+	if common.IsAPIToken(token) {
+		return a.userByAPIToken(token)
+	}
+
+	if a.verifier == nil {
+		return nil, fmt.Errorf("%w: invalid API token", ErrUnauthorized)
+	}
+
+	claims, err := a.verifier.Verify(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnauthorized, err.Error())
+	}
+
+	user, err := a.service.UserForExternalID(*claims)
+	if err != nil {
+		log.Error().Err(err).Msgf("failed getting user for subject %s", claims.Subject)
+		return nil, fmt.Errorf("%w: no user for token", ErrUnauthorized)
+	}
+
+	return user, nil
+}
+
+func (a *Authenticator) userByAPIToken(token string) (*model.User, error) {
+	user, err := a.service.UserByAPIToken(token)
+	if err != nil {
+		if !errors.Is(err, common.ErrNotFound) {
+			log.Error().Err(err).Msg("failed getting user for API token")
+		}
+		return nil, fmt.Errorf("%w: invalid API token", ErrUnauthorized)
+	}
+
+	return user, nil
+}
+
+// NewAuthenticator returns an authenticator that accepts the API tokens of
+// the service. The verifier is optional and verifies all other tokens.
+func NewAuthenticator(
+	service interfaces.DataService,
+	verifier interfaces.TokenVerifier,
+) *Authenticator {
+	return &Authenticator{
+		service:  service,
+		verifier: verifier,
+	}
+}
@@ -0,0 +1,140 @@
+package auth
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("Authenticator", func() {
+	var engine *gin.Engine
+	var httpRec *httptest.ResponseRecorder
+	var request *http.Request
+	var dataService *mocks.DataService
+	var verifier *mocks.TokenVerifier
+	var authenticator *Authenticator
+	var piper *model.User
+	var requestUser *model.User
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		dataService = mocks.NewDataService(GinkgoT())
+		verifier = mocks.NewTokenVerifier(GinkgoT())
+		authenticator = NewAuthenticator(dataService, verifier)
+		piper = &model.User{
+			BaseModel: model.BaseModel{ID: uuid.MustParse("00000000-0000-0000-0000-000000000001")},
+			Name:      "piper",
+		}
+		requestUser = nil
+
+		engine = gin.New()
+		engine.Use(authenticator.Middleware())
+		engine.GET("/health", func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+		engine.GET("/tunes", func(c *gin.Context) {
+			requestUser = User(c)
+			c.Status(http.StatusOK)
+		})
+
+		httpRec = httptest.NewRecorder()
+		request = httptest.NewRequest(http.MethodGet, "/tunes", nil)
+	})
+
+	JustBeforeEach(func() {
+		engine.ServeHTTP(httpRec, request)
+	})
+
+	When("requesting a public route without a token", func() {
+		BeforeEach(func() {
+			request = httptest.NewRequest(http.MethodGet, "/health", nil)
+		})
+
+		It("should return ok", func() {
+			Expect(httpRec.Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	When("requesting without a token", func() {
+		It("should return unauthorized", func() {
+			Expect(httpRec.Code).To(Equal(http.StatusUnauthorized))
+			Expect(httpRec.Header().Get("WWW-Authenticate")).To(ContainSubstring("Bearer"))
+			Expect(httpRec.Body.String()).To(ContainSubstring("missing bearer token"))
+		})
+	})
+
+	When("requesting with a valid API token", func() {
+		BeforeEach(func() {
+			request.Header.Set("Authorization", "Bearer lp_token")
+			dataService.EXPECT().UserByAPIToken("lp_token").Return(piper, nil)
+		})
+
+		It("should store the user of the token in the context", func() {
+			Expect(httpRec.Code).To(Equal(http.StatusOK))
+			Expect(requestUser).To(Equal(piper))
+		})
+	})
+
+	When("requesting with an unknown API token", func() {
+		BeforeEach(func() {
+			request.Header.Set("Authorization", "Bearer lp_unknown")
+			dataService.EXPECT().UserByAPIToken("lp_unknown").
+				Return(nil, common.ErrNotFound)
+		})
+
+		It("should return unauthorized", func() {
+			Expect(httpRec.Code).To(Equal(http.StatusUnauthorized))
+			Expect(requestUser).To(BeNil())
+		})
+	})
+
+	When("requesting with a token of an identity provider", func() {
+		BeforeEach(func() {
+			request.Header.Set("Authorization", "Bearer eyJ.jwt")
+			verifier.EXPECT().Verify("eyJ.jwt").Return(&common.TokenClaims{
+				Subject: "sub-1",
+				Name:    "piper",
+			}, nil)
+			dataService.EXPECT().UserForExternalID(common.TokenClaims{
+				Subject: "sub-1",
+				Name:    "piper",
+			}).Return(piper, nil)
+		})
+
+		It("should store the user of the subject in the context", func() {
+			Expect(httpRec.Code).To(Equal(http.StatusOK))
+			Expect(requestUser).To(Equal(piper))
+		})
+	})
+
+	When("the token of an identity provider is invalid", func() {
+		BeforeEach(func() {
+			request.Header.Set("Authorization", "Bearer eyJ.expired")
+			verifier.EXPECT().Verify("eyJ.expired").
+				Return(nil, fmt.Errorf("token is expired"))
+		})
+
+		It("should return unauthorized", func() {
+			Expect(httpRec.Code).To(Equal(http.StatusUnauthorized))
+			Expect(httpRec.Body.String()).To(ContainSubstring("token is expired"))
+		})
+	})
+
+	When("there is no verifier for tokens of an identity provider", func() {
+		BeforeEach(func() {
+			authenticator.verifier = nil
+			request.Header.Set("Authorization", "Bearer eyJ.jwt")
+		})
+
+		It("should return unauthorized", func() {
+			Expect(httpRec.Code).To(Equal(http.StatusUnauthorized))
+		})
+	})
+})
@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/interfaces"
+	"net/http"
+)
+
+var ErrForbidden = fmt.Errorf("forbidden")
+
+// Authorizer checks that the role of the authenticated user grants the
+// permission that the requested route needs. The role of a user is the
+// one of the user's membership or the default role if there is none.
+type Authorizer struct {
+	service     interfaces.DataService
+	defaultRole common.Role
+}
+
+// Middleware returns a gin middleware that rejects all requests to non-public
+// routes that the user isn't allowed to call and stores the role of the user
+// in the context. It has to be used after the middleware of the Authenticator.
+func (a *Authorizer) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if publicPaths[c.FullPath()] {
+			c.Next()
+			return
+		}
+
+		role, err := a.authorize(User(c), c.Request.Method, c.FullPath())
+		if err != nil {
+			abortWithAuthError(c, err)
+			return
+		}
+
+		SetRole(c, role)
+		c.Next()
+	}
+}
+
+// Role returns the role of the user.
+func (a *Authorizer) Role(userID uuid.UUID) (common.Role, error) {
+	membership, err := a.service.GetMembership(userID)
+	if errors.Is(err, common.ErrNotFound) {
+		return a.defaultRole, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return membership.Role, nil
+}
+
+func (a *Authorizer) authorize(
+	user *model.User,
+	method string,
+	path string,
+) (common.Role, error) {
+	if user == nil {
+		return "", fmt.Errorf("%w: request is not authenticated", ErrUnauthorized)
+	}
+
+	permission, ok := RoutePermission(method, path)
+	if !ok {
+		return "", fmt.Errorf("%w: %s %s", ErrForbidden, method, path)
+	}
+
+	role, err := a.Role(user.ID)
+	if err != nil {
+		return "", err
+	}
+
+	if !HasPermission(role, permission) {
+		if role == "" {
+			return "", fmt.Errorf("%w: user %s has no role", ErrForbidden, user.Name)
+		}
+		return "", fmt.Errorf("%w: role %s doesn't have the %s permission",
+			ErrForbidden, role, permission)
+	}
+
+	return role, nil
+}
+
+func abortWithAuthError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrUnauthorized):
+		c.AbortWithStatusJSON(http.StatusUnauthorized, apimodel.Error{Message: err.Error()})
+	case errors.Is(err, ErrForbidden):
+		c.AbortWithStatusJSON(http.StatusForbidden, apimodel.Error{Message: err.Error()})
+	default:
+		log.Error().Err(err).Msg("failed authorizing request")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, apimodel.Error{
+			Message: "failed authorizing request",
+		})
+	}
+}
+
+// NewAuthorizer returns an authorizer that gives users without a membership
+// the default role. If it is empty, these users are only allowed to call
+// the routes that need no role.
+func NewAuthorizer(
+	service interfaces.DataService,
+	defaultRole common.Role,
+) *Authorizer {
+	return &Authorizer{
+		service:     service,
+		defaultRole: defaultRole,
+	}
+}
@@ -0,0 +1,156 @@
+package auth
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("Authorizer", func() {
+	var engine *gin.Engine
+	var httpRec *httptest.ResponseRecorder
+	var request *http.Request
+	var dataService *mocks.DataService
+	var authorizer *Authorizer
+	var learner *model.User
+	var requestRole common.Role
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		dataService = mocks.NewDataService(GinkgoT())
+		authorizer = NewAuthorizer(dataService, "")
+		learner = &model.User{
+			BaseModel: model.BaseModel{ID: uuid.MustParse("00000000-0000-0000-0000-000000000002")},
+			Name:      "learner",
+		}
+		requestRole = ""
+
+		handler := func(c *gin.Context) {
+			requestRole = Role(c)
+			c.Status(http.StatusOK)
+		}
+		engine = gin.New()
+		engine.Use(func(c *gin.Context) {
+			SetUser(c, learner)
+		})
+		engine.Use(authorizer.Middleware())
+		engine.GET("/health", handler)
+		engine.GET("/tunes", handler)
+		engine.POST("/tunes", handler)
+		engine.GET("/users/me", handler)
+		engine.GET("/unknown", handler)
+
+		httpRec = httptest.NewRecorder()
+		request = httptest.NewRequest(http.MethodGet, "/tunes", nil)
+	})
+
+	JustBeforeEach(func() {
+		engine.ServeHTTP(httpRec, request)
+	})
+
+	When("the user is a viewer", func() {
+		BeforeEach(func() {
+			dataService.EXPECT().GetMembership(learner.ID).
+				Return(&model.Membership{UserID: learner.ID, Role: common.RoleViewer}, nil)
+		})
+
+		It("should be allowed to read", func() {
+			Expect(httpRec.Code).To(Equal(http.StatusOK))
+			Expect(requestRole).To(Equal(common.RoleViewer))
+		})
+
+		When("creating a tune", func() {
+			BeforeEach(func() {
+				request = httptest.NewRequest(http.MethodPost, "/tunes", nil)
+			})
+
+			It("should return forbidden", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusForbidden))
+				Expect(httpRec.Body.String()).To(ContainSubstring("viewer"))
+			})
+		})
+	})
+
+	When("the user is an editor", func() {
+		BeforeEach(func() {
+			dataService.EXPECT().GetMembership(learner.ID).
+				Return(&model.Membership{UserID: learner.ID, Role: common.RoleEditor}, nil)
+			request = httptest.NewRequest(http.MethodPost, "/tunes", nil)
+		})
+
+		It("should be allowed to create a tune", func() {
+			Expect(httpRec.Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	When("the user has no membership", func() {
+		BeforeEach(func() {
+			dataService.EXPECT().GetMembership(learner.ID).
+				Return(nil, fmt.Errorf("%w: membership", common.ErrNotFound))
+		})
+
+		It("should return forbidden", func() {
+			Expect(httpRec.Code).To(Equal(http.StatusForbidden))
+			Expect(httpRec.Body.String()).To(ContainSubstring("has no role"))
+		})
+
+		When("requesting the own user", func() {
+			BeforeEach(func() {
+				request = httptest.NewRequest(http.MethodGet, "/users/me", nil)
+			})
+
+			It("should return ok", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		When("there is a default role", func() {
+			BeforeEach(func() {
+				authorizer.defaultRole = common.RoleViewer
+			})
+
+			It("should have the default role", func() {
+				Expect(httpRec.Code).To(Equal(http.StatusOK))
+				Expect(requestRole).To(Equal(common.RoleViewer))
+			})
+		})
+	})
+
+	When("the membership can't be read", func() {
+		BeforeEach(func() {
+			dataService.EXPECT().GetMembership(learner.ID).
+				Return(nil, fmt.Errorf("connection lost"))
+		})
+
+		It("should return an internal server error", func() {
+			Expect(httpRec.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+
+	When("requesting a public route", func() {
+		BeforeEach(func() {
+			request = httptest.NewRequest(http.MethodGet, "/health", nil)
+		})
+
+		It("should return ok", func() {
+			Expect(httpRec.Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	When("requesting a route without a permission", func() {
+		BeforeEach(func() {
+			request = httptest.NewRequest(http.MethodGet, "/unknown", nil)
+		})
+
+		It("should return forbidden", func() {
+			Expect(httpRec.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+})
@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+)
+
+const (
+	userContextKey = "limepipes.user"
+	roleContextKey = "limepipes.role"
+)
+
+// SetUser stores the authenticated user of a request in its context.
+func SetUser(c *gin.Context, user *model.User) {
+	c.Set(userContextKey, user)
+}
+
+// User returns the authenticated user of a request or nil
+// if the request is not authenticated.
+func User(c *gin.Context) *model.User {
+	value, ok := c.Get(userContextKey)
+	if !ok {
+		return nil
+	}
+
+	user, _ := value.(*model.User)
+	return user
+}
+
+// SetRole stores the role of the authenticated user of a request in its context.
+func SetRole(c *gin.Context, role common.Role) {
+	c.Set(roleContextKey, role)
+}
+
+// Role returns the role of the authenticated user of a request. It is empty
+// if the user has no role or the request is not authorized.
+func Role(c *gin.Context) common.Role {
+	value, _ := c.Get(roleContextKey)
+	role, _ := value.(common.Role)
+	return role
+}
@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/config"
+	"os"
+)
+
+const defaultNameClaim = "preferred_username"
+
+// JWTVerifier verifies JWT bearer tokens of an identity provider that are
+// either signed with a shared HMAC secret or with the private key of
+// a RSA or ECDSA public key.
+type JWTVerifier struct {
+	key       any
+	parser    *jwt.Parser
+	nameClaim string
+}
+
+func (v *JWTVerifier) Verify(token string) (*common.TokenClaims, error) {
+	claims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(token, claims, func(*jwt.Token) (any, error) {
+		return v.key, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil {
+		return nil, err
+	}
+	if subject == "" {
+		return nil, fmt.Errorf("token has no subject")
+	}
+
+	name, _ := claims[v.nameClaim].(string)
+
+	return &common.TokenClaims{
+		Subject: subject,
+		Name:    name,
+	}, nil
+}
+
+// NewJWTVerifier returns a verifier for the JWT settings of the config.
+// The public key is preferred over the secret if both are set.
+func NewJWTVerifier(cfg config.AuthConfig) (*JWTVerifier, error) {
+	key, methods, err := jwtKey(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+	}
+	if cfg.JwtIssuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.JwtIssuer))
+	}
+	if cfg.JwtAudience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.JwtAudience))
+	}
+
+	nameClaim := cfg.JwtNameClaim
+	if nameClaim == "" {
+		nameClaim = defaultNameClaim
+	}
+
+	return &JWTVerifier{
+		key:       key,
+		parser:    jwt.NewParser(opts...),
+		nameClaim: nameClaim,
+	}, nil
+}
+
+// jwtKey returns the key to verify the tokens and the signing
+// methods that are valid for it.
+func jwtKey(cfg config.AuthConfig) (any, []string, error) {
+	if cfg.JwtPublicKeyPath == "" {
+		if cfg.JwtSecret == "" {
+			return nil, nil, fmt.Errorf("neither a JWT secret nor a public key is configured")
+		}
+		return []byte(cfg.JwtSecret), []string{"HS256", "HS384", "HS512"}, nil
+	}
+
+	pemData, err := os.ReadFile(cfg.JwtPublicKeyPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed reading JWT public key: %w", err)
+	}
+
+	if rsaKey, err := jwt.ParseRSAPublicKeyFromPEM(pemData); err == nil {
+		return rsaKey, []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512"}, nil
+	}
+
+	ecKey, err := jwt.ParseECPublicKeyFromPEM(pemData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("JWT public key is neither a RSA nor an ECDSA key: %w", err)
+	}
+
+	return ecKey, []string{"ES256", "ES384", "ES512"}, nil
+}
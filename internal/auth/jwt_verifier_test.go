@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"github.com/golang-jwt/jwt/v5"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/config"
+	"time"
+)
+
+var _ = Describe("JWTVerifier", func() {
+	var err error
+	var verifier *JWTVerifier
+	var claims jwt.MapClaims
+	var secret string
+	var verifiedClaims *common.TokenClaims
+
+	BeforeEach(func() {
+		secret = "secret"
+		verifier, err = NewJWTVerifier(config.AuthConfig{
+			JwtSecret:   "secret",
+			JwtIssuer:   "https://id.example.com",
+			JwtAudience: "limepipes",
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		claims = jwt.MapClaims{
+			"sub":                "sub-1",
+			"preferred_username": "piper",
+			"iss":                "https://id.example.com",
+			"aud":                "limepipes",
+			"exp":                time.Now().Add(time.Hour).Unix(),
+		}
+	})
+
+	JustBeforeEach(func() {
+		token, signErr := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).
+			SignedString([]byte(secret))
+		Expect(signErr).ShouldNot(HaveOccurred())
+		verifiedClaims, err = verifier.Verify(token)
+	})
+
+	It("should return the subject and the name of a valid token", func() {
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(verifiedClaims).To(Equal(&common.TokenClaims{
+			Subject: "sub-1",
+			Name:    "piper",
+		}))
+	})
+
+	When("the token is signed with another secret", func() {
+		BeforeEach(func() {
+			secret = "other"
+		})
+
+		It("should return an error", func() {
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("the token is expired", func() {
+		BeforeEach(func() {
+			claims["exp"] = time.Now().Add(-time.Hour).Unix()
+		})
+
+		It("should return an error", func() {
+			Expect(err).To(MatchError(jwt.ErrTokenExpired))
+		})
+	})
+
+	When("the token is for another audience", func() {
+		BeforeEach(func() {
+			claims["aud"] = "other"
+		})
+
+		It("should return an error", func() {
+			Expect(err).To(MatchError(jwt.ErrTokenInvalidAudience))
+		})
+	})
+
+	When("the token has no subject", func() {
+		BeforeEach(func() {
+			delete(claims, "sub")
+		})
+
+		It("should return an error", func() {
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("NewJWTVerifier", func() {
+	It("should return an error without a secret or a public key", func() {
+		_, err := NewJWTVerifier(config.AuthConfig{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should return an error if the public key doesn't exist", func() {
+		_, err := NewJWTVerifier(config.AuthConfig{JwtPublicKeyPath: "/not/existing.pem"})
+		Expect(err).To(HaveOccurred())
+	})
+})
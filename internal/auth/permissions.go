@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"github.com/tomvodi/limepipes/internal/common"
+	"net/http"
+	"slices"
+)
+
+// Permission is the right to call a group of API operations.
+type Permission string
+
+const (
+	// PermissionAuthenticated is needed by operations that every
+	// authenticated user may call, even without a role.
+	PermissionAuthenticated Permission = "authenticated"
+	PermissionRead          Permission = "read"
+	PermissionEdit          Permission = "edit"
+	PermissionImport        Permission = "import"
+	PermissionManageMembers Permission = "manage_members"
+)
+
+var rolePermissions = map[common.Role][]Permission{
+	common.RoleViewer: {
+		PermissionRead,
+	},
+	common.RoleEditor: {
+		PermissionRead,
+		PermissionEdit,
+		PermissionImport,
+	},
+	common.RoleAdmin: {
+		PermissionRead,
+		PermissionEdit,
+		PermissionImport,
+		PermissionManageMembers,
+	},
+}
+
+// routePermissions are the permissions needed for the routes of the API
+// by their method and path. Routes that aren't listed here are denied.
+var routePermissions = map[string]Permission{
+	route(http.MethodGet, "/users/me"):                    PermissionAuthenticated,
+	route(http.MethodGet, "/users/me/tokens"):             PermissionAuthenticated,
+	route(http.MethodPost, "/users/me/tokens"):            PermissionAuthenticated,
+	route(http.MethodDelete, "/users/me/tokens/:tokenId"): PermissionAuthenticated,
+
+	route(http.MethodGet, "/tunes"):                        PermissionRead,
+	route(http.MethodGet, "/tunes/analysis"):               PermissionRead,
+	route(http.MethodGet, "/tunes/:tuneId"):                PermissionRead,
+	route(http.MethodGet, "/tunes/:tuneId/export"):         PermissionRead,
+	route(http.MethodGet, "/tunes/:tuneId/files"):          PermissionRead,
+	route(http.MethodGet, "/tunes/:tuneId/files/:format"):  PermissionRead,
+	route(http.MethodGet, "/tunes/:tuneId/revisions"):      PermissionRead,
+	route(http.MethodGet, "/tunes/:tuneId/revisions/diff"): PermissionRead,
+	route(http.MethodGet, "/tunes/:tuneId/duplicates"):     PermissionRead,
+	route(http.MethodGet, "/tunes/:tuneId/analysis"):       PermissionRead,
+	route(http.MethodGet, "/tunes/:tuneId/messages"):       PermissionRead,
+	route(http.MethodGet, "/tunes/:tuneId/midi"):           PermissionRead,
+	route(http.MethodGet, "/tunes/:tuneId/audio"):          PermissionRead,
+	route(http.MethodGet, "/tunes/:tuneId/score.svg"):      PermissionRead,
+	route(http.MethodGet, "/tunes/:tuneId/score.pdf"):      PermissionRead,
+	route(http.MethodGet, "/sets"):                         PermissionRead,
+	route(http.MethodGet, "/sets/:setId"):                  PermissionRead,
+	route(http.MethodGet, "/sets/:setId/analysis"):         PermissionRead,
+	route(http.MethodGet, "/sets/:setId/audio"):            PermissionRead,
+	route(http.MethodGet, "/tags"):                         PermissionRead,
+	route(http.MethodGet, "/tags/:tagId"):                  PermissionRead,
+	route(http.MethodGet, "/tune-types"):                   PermissionRead,
+	route(http.MethodGet, "/tune-types/:tuneTypeId"):       PermissionRead,
+	route(http.MethodGet, "/people"):                       PermissionRead,
+	route(http.MethodGet, "/people/:personId"):             PermissionRead,
+	route(http.MethodGet, "/people/:personId/tunes"):       PermissionRead,
+	route(http.MethodGet, "/messages"):                     PermissionRead,
+	route(http.MethodGet, "/search"):                       PermissionRead,
+	route(http.MethodGet, "/imports"):                      PermissionRead,
+	route(http.MethodGet, "/imports/:importId"):            PermissionRead,
+	route(http.MethodGet, "/imports/:importId/events"):     PermissionRead,
+	route(http.MethodGet, "/imports/:importId/original"):   PermissionRead,
+	// booklets are only engraved and not stored
+	route(http.MethodPost, "/booklets"): PermissionRead,
+
+	route(http.MethodPost, "/tunes"):                                     PermissionEdit,
+	route(http.MethodPut, "/tunes/:tuneId"):                              PermissionEdit,
+	route(http.MethodDelete, "/tunes/:tuneId"):                           PermissionEdit,
+	route(http.MethodPost, "/tunes/:tuneId/merge"):                       PermissionEdit,
+	route(http.MethodPut, "/tunes/:tuneId/messages"):                     PermissionEdit,
+	route(http.MethodPut, "/messages/:messageId"):                        PermissionEdit,
+	route(http.MethodPost, "/tunes/:tuneId/files/:format"):               PermissionEdit,
+	route(http.MethodDelete, "/tunes/:tuneId/files/:format"):             PermissionEdit,
+	route(http.MethodPost, "/tunes/:tuneId/revisions/:revision/restore"): PermissionEdit,
+	route(http.MethodPost, "/sets"):                                      PermissionEdit,
+	route(http.MethodPut, "/sets/:setId"):                                PermissionEdit,
+	route(http.MethodDelete, "/sets/:setId"):                             PermissionEdit,
+	route(http.MethodPut, "/sets/:setId/tunes"):                          PermissionEdit,
+	route(http.MethodPut, "/tunes/:tuneId/tags"):                         PermissionEdit,
+	route(http.MethodPut, "/sets/:setId/tags"):                           PermissionEdit,
+	route(http.MethodPost, "/tags"):                                      PermissionEdit,
+	route(http.MethodPut, "/tags/:tagId"):                                PermissionEdit,
+	route(http.MethodDelete, "/tags/:tagId"):                             PermissionEdit,
+	route(http.MethodPut, "/tune-types/:tuneTypeId"):                     PermissionEdit,
+	route(http.MethodPost, "/tune-types/:tuneTypeId/merge"):              PermissionEdit,
+	route(http.MethodPut, "/tune-types/:tuneTypeId/aliases"):             PermissionEdit,
+	route(http.MethodPut, "/people/:personId"):                           PermissionEdit,
+	route(http.MethodPost, "/people/:personId/merge"):                    PermissionEdit,
+	route(http.MethodPut, "/people/:personId/aliases"):                   PermissionEdit,
+
+	route(http.MethodPost, "/imports"):             PermissionImport,
+	route(http.MethodDelete, "/imports/:importId"): PermissionImport,
+
+	route(http.MethodGet, "/memberships"):            PermissionManageMembers,
+	route(http.MethodPut, "/memberships/:userId"):    PermissionManageMembers,
+	route(http.MethodDelete, "/memberships/:userId"): PermissionManageMembers,
+}
+
+func route(method string, path string) string {
+	return method + " " + path
+}
+
+// RoutePermission returns the permission that is needed for the route
+// and false if the route is unknown.
+func RoutePermission(method string, path string) (Permission, bool) {
+	permission, ok := routePermissions[route(method, path)]
+	return permission, ok
+}
+
+// HasPermission returns true if the role grants the permission. Every role,
+// even none, has the PermissionAuthenticated permission.
+func HasPermission(role common.Role, permission Permission) bool {
+	if permission == PermissionAuthenticated {
+		return true
+	}
+
+	return slices.Contains(rolePermissions[role], permission)
+}
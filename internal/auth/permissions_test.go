@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes/internal/apigen"
+	apimocks "github.com/tomvodi/limepipes/internal/apigen/interfaces/mocks"
+	"github.com/tomvodi/limepipes/internal/common"
+)
+
+var _ = Describe("Permissions", func() {
+	It("should have a permission for every route of the API", func() {
+		gin.SetMode(gin.TestMode)
+		router := apigen.NewRouterWithGinEngine(gin.New(), apigen.ApiHandleFunctions{
+			ApiHandler: apimocks.NewApiHandler(GinkgoT()),
+		})
+
+		for _, r := range router.Routes() {
+			if publicPaths[r.Path] {
+				continue
+			}
+			_, ok := RoutePermission(r.Method, r.Path)
+			Expect(ok).To(BeTrue(), "no permission for %s %s", r.Method, r.Path)
+		}
+	})
+
+	It("should grant the permissions of the lower roles to the higher ones", func() {
+		Expect(HasPermission(common.RoleViewer, PermissionRead)).To(BeTrue())
+		Expect(HasPermission(common.RoleViewer, PermissionEdit)).To(BeFalse())
+		Expect(HasPermission(common.RoleEditor, PermissionImport)).To(BeTrue())
+		Expect(HasPermission(common.RoleEditor, PermissionManageMembers)).To(BeFalse())
+		Expect(HasPermission(common.RoleAdmin, PermissionManageMembers)).To(BeTrue())
+		Expect(HasPermission("", PermissionRead)).To(BeFalse())
+		Expect(HasPermission("", PermissionAuthenticated)).To(BeTrue())
+	})
+})
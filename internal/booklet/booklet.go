@@ -0,0 +1,119 @@
+package booklet
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/interfaces"
+	"github.com/tomvodi/limepipes/internal/score"
+)
+
+// Builder engraves stored sets and tunes together as booklet for printing.
+type Builder struct {
+	service interfaces.DataService
+}
+
+// Build engraves the sets and tunes of the booklet in their order with the
+// credits of the stored tunes. Sets must be visible to the user and their
+// tunes without music model are left out, a single tune must have one.
+// It returns an ErrNotFound error if none of the tunes has music.
+func (b *Builder) Build(
+	booklet apimodel.CreateBooklet,
+	userID *uuid.UUID,
+) (*score.Score, error) {
+	var sections []score.BookletSection
+	tuneCount := 0
+	for _, e := range booklet.Entries {
+		section, err := b.section(e, userID)
+		if err != nil {
+			return nil, err
+		}
+		sections = append(sections, section)
+		tuneCount += len(section.Tunes)
+	}
+
+	if tuneCount == 0 {
+		return nil, fmt.Errorf("%w: booklet has no tunes with music", common.ErrNotFound)
+	}
+
+	return score.Booklet(booklet.Title, sections), nil
+}
+
+func (b *Builder) section(
+	e apimodel.BookletEntry,
+	userID *uuid.UUID,
+) (score.BookletSection, error) {
+	switch {
+	case e.SetId != nil:
+		return b.setSection(*e.SetId, userID)
+	case e.TuneId != nil:
+		t, err := b.creditedTune(*e.TuneId)
+		if err != nil {
+			return score.BookletSection{}, err
+		}
+		return score.BookletSection{Tunes: []*tune.Tune{t}}, nil
+	default:
+		return score.BookletSection{}, fmt.Errorf("%w: booklet entry has neither a set nor a tune",
+			common.ErrInvalidArgument)
+	}
+}
+
+// setSection returns the section of the set with its tunes that have music.
+func (b *Builder) setSection(
+	setID uuid.UUID,
+	userID *uuid.UUID,
+) (score.BookletSection, error) {
+	set, err := b.service.GetMusicSet(setID, userID)
+	if err != nil {
+		return score.BookletSection{}, err
+	}
+
+	section := score.BookletSection{Title: set.Title}
+	for _, t := range set.Tunes {
+		muMoTune, err := b.creditedTune(t.Id)
+		if errors.Is(err, common.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return score.BookletSection{}, err
+		}
+		section.Tunes = append(section.Tunes, muMoTune)
+	}
+
+	return section, nil
+}
+
+// creditedTune returns the music model of the tune with the title, composer
+// and arranger of the stored tune, which may have been edited since the import.
+func (b *Builder) creditedTune(tuneID uuid.UUID) (*tune.Tune, error) {
+	apiTune, err := b.service.GetTune(tuneID)
+	if err != nil {
+		return nil, err
+	}
+
+	muMoFile, err := b.service.GetTuneFile(tuneID, fileformat.Format_MUSIC_MODEL)
+	if err != nil {
+		return nil, err
+	}
+
+	muMoTune, err := muMoFile.MusicModelTune()
+	if err != nil {
+		return nil, err
+	}
+
+	muMoTune.Title = apiTune.Title
+	muMoTune.Composer = apiTune.Composer
+	muMoTune.Arranger = apiTune.Arranger
+
+	return muMoTune, nil
+}
+
+func NewBuilder(service interfaces.DataService) *Builder {
+	return &Builder{
+		service: service,
+	}
+}
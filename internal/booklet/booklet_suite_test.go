@@ -0,0 +1,13 @@
+package booklet_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestBooklet(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Booklet Suite")
+}
@@ -0,0 +1,132 @@
+package booklet
+
+import (
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"github.com/tomvodi/limepipes/internal/score"
+)
+
+var _ = Describe("Builder", func() {
+	var err error
+	var builder *Builder
+	var dataService *mocks.DataService
+	var booklet apimodel.CreateBooklet
+	var result *score.Score
+	var userID, setID, tuneID1, tuneID2, tuneID3 uuid.UUID
+	var muMoFile *model.TuneFile
+
+	BeforeEach(func() {
+		userID = uuid.MustParse("00000000-0000-0000-0000-0000000000aa")
+		setID = uuid.MustParse("00000000-0000-0000-0000-0000000000bb")
+		tuneID1 = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+		tuneID2 = uuid.MustParse("00000000-0000-0000-0000-000000000002")
+		tuneID3 = uuid.MustParse("00000000-0000-0000-0000-000000000003")
+		dataService = mocks.NewDataService(GinkgoT())
+		builder = NewBuilder(dataService)
+
+		muMoFile, err = model.TuneFileFromMusicModelTune(model.TestParsedTune("imported title").Tune)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		booklet = apimodel.CreateBooklet{
+			Title: "Band Practice",
+			Entries: []apimodel.BookletEntry{
+				{SetId: &setID},
+				{TuneId: &tuneID3},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		result, err = builder.Build(booklet, &userID)
+	})
+
+	expectTune := func(id uuid.UUID, title string, muMo *model.TuneFile, muMoErr error) {
+		dataService.EXPECT().GetTune(id).
+			Return(&apimodel.Tune{Id: id, Title: title, Composer: "Piper Composer"}, nil)
+		dataService.EXPECT().GetTuneFile(id, fileformat.Format_MUSIC_MODEL).
+			Return(muMo, muMoErr)
+	}
+
+	When("the set has a tune without music model", func() {
+		BeforeEach(func() {
+			dataService.EXPECT().GetMusicSet(setID, &userID).
+				Return(&apimodel.MusicSet{
+					Id:    setID,
+					Title: "Competition Set",
+					Tunes: []apimodel.Tune{{Id: tuneID1}, {Id: tuneID2}},
+				}, nil)
+			expectTune(tuneID1, "First Tune", muMoFile, nil)
+			expectTune(tuneID2, "Second Tune", nil, common.ErrNotFound)
+			expectTune(tuneID3, "Single Tune", muMoFile, nil)
+		})
+
+		It("should engrave the other tunes with the credits of the stored tunes", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			// title page, contents and a page per tune
+			Expect(result.Pages()).To(Equal(4))
+
+			pdf := string(result.PDF())
+			Expect(pdf).To(ContainSubstring("(Competition Set)"))
+			Expect(pdf).To(ContainSubstring("(First Tune)"))
+			Expect(pdf).To(ContainSubstring("(Single Tune)"))
+			Expect(pdf).To(ContainSubstring("(Piper Composer)"))
+			Expect(pdf).ToNot(ContainSubstring("(Second Tune)"))
+			Expect(pdf).ToNot(ContainSubstring("(imported title)"))
+		})
+	})
+
+	When("none of the tunes has music", func() {
+		BeforeEach(func() {
+			dataService.EXPECT().GetMusicSet(setID, &userID).
+				Return(&apimodel.MusicSet{
+					Id:    setID,
+					Title: "Competition Set",
+					Tunes: []apimodel.Tune{{Id: tuneID1}},
+				}, nil)
+			expectTune(tuneID1, "First Tune", nil, common.ErrNotFound)
+			booklet.Entries = booklet.Entries[:1]
+		})
+
+		It("should return a not found error", func() {
+			Expect(err).To(MatchError(common.ErrNotFound))
+		})
+	})
+
+	When("the single tune has no music model", func() {
+		BeforeEach(func() {
+			expectTune(tuneID3, "Single Tune", nil, common.ErrNotFound)
+			booklet.Entries = booklet.Entries[1:]
+		})
+
+		It("should return a not found error", func() {
+			Expect(err).To(MatchError(common.ErrNotFound))
+		})
+	})
+
+	When("the set is not visible to the user", func() {
+		BeforeEach(func() {
+			dataService.EXPECT().GetMusicSet(setID, &userID).
+				Return(&apimodel.MusicSet{}, common.ErrNotFound)
+		})
+
+		It("should return a not found error", func() {
+			Expect(err).To(MatchError(common.ErrNotFound))
+		})
+	})
+
+	When("an entry has neither a set nor a tune", func() {
+		BeforeEach(func() {
+			booklet.Entries = []apimodel.BookletEntry{{}}
+		})
+
+		It("should return an invalid argument error", func() {
+			Expect(err).To(MatchError(common.ErrInvalidArgument))
+		})
+	})
+})
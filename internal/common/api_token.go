@@ -0,0 +1,46 @@
+package common
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// APITokenPrefix distinguishes API tokens from bearer tokens
+// of an external identity provider.
+const APITokenPrefix = "lp_"
+
+const apiTokenBytes = 32
+
+// TokenClaims are the claims of a verified bearer token of an
+// external identity provider.
+type TokenClaims struct {
+	// Subject is the unique id of the user at the identity provider
+	Subject string
+
+	// Name is the preferred user name, it may be empty
+	Name string
+}
+
+// NewAPIToken returns a new random API token.
+func NewAPIToken() (string, error) {
+	b := make([]byte, apiTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed creating API token: %w", err)
+	}
+
+	return APITokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// IsAPIToken returns true if the bearer token is an API token.
+func IsAPIToken(token string) bool {
+	return strings.HasPrefix(token, APITokenPrefix)
+}
+
+// APITokenHash returns the hash of the API token, which is stored instead
+// of the token.
+func APITokenHash(token string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(token)))
+}
@@ -0,0 +1,26 @@
+package common
+
+import (
+	. "github.com/onsi/gomega"
+	"testing"
+)
+
+func TestNewAPIToken(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	token, err := NewAPIToken()
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(IsAPIToken(token)).To(BeTrue())
+
+	other, err := NewAPIToken()
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(other).ShouldNot(Equal(token))
+}
+
+func TestAPITokenHash(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(APITokenHash("lp_token")).To(Equal(APITokenHash("lp_token")))
+	g.Expect(APITokenHash("lp_token")).ShouldNot(Equal(APITokenHash("lp_other")))
+	g.Expect(APITokenHash("lp_token")).To(HaveLen(64))
+}
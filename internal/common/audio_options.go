@@ -0,0 +1,14 @@
+package common
+
+// AudioOptions contains the query parameters of the GET /tunes/{tuneId}/audio
+// and GET /sets/{setId}/audio endpoints.
+type AudioOptions struct {
+	// Format is the file format, wav or ogg
+	Format string `form:"format" binding:"omitempty,oneof=wav ogg"`
+	// Timbre is the instrument, pipes or practice-chanter
+	Timbre string `form:"timbre" binding:"omitempty,oneof=pipes practice-chanter"`
+	// Speed scales the tempo of the tunes
+	Speed float64 `form:"speed" binding:"omitempty,min=0.25,max=2"`
+	// CountIn is the number of clicks before the first tune
+	CountIn uint32 `form:"countIn" binding:"omitempty,max=16"`
+}
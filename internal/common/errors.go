@@ -4,3 +4,5 @@ import "fmt"
 
 var ErrNotFound = fmt.Errorf("not found")
 var ErrSkipped = fmt.Errorf("skipped")
+var ErrInvalidArgument = fmt.Errorf("invalid argument")
+var ErrAlreadyExists = fmt.Errorf("already exists")
@@ -0,0 +1,7 @@
+package common
+
+// ExportOptions contains the query parameters of the GET /tunes/{tuneId}/export endpoint.
+type ExportOptions struct {
+	// Format is the name of the file format to export to e.g. "musicxml"
+	Format string `form:"format" binding:"required"`
+}
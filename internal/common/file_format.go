@@ -0,0 +1,103 @@
+package common
+
+import (
+	"fmt"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"strings"
+	"unicode"
+)
+
+type fileFormatInfo struct {
+	contentType string
+	extension   string
+}
+
+var fileFormatInfos = map[fileformat.Format]fileFormatInfo{
+	fileformat.Format_MUSIC_MODEL: {
+		contentType: "application/octet-stream",
+		extension:   ".gob",
+	},
+	fileformat.Format_BWW: {
+		contentType: "text/plain; charset=utf-8",
+		extension:   ".bww",
+	},
+	fileformat.Format_MUSIC_XML: {
+		contentType: "application/vnd.recordare.musicxml+xml",
+		extension:   ".musicxml",
+	},
+	fileformat.Format_ABC: {
+		contentType: "text/vnd.abc; charset=utf-8",
+		extension:   ".abc",
+	},
+}
+
+// FileFormatFromName returns the file format for a name like "bww" or "music_xml".
+// The name is case-insensitive and the underscores are optional, so
+// "MusicXml" is also a valid name. It returns an ErrInvalidArgument error
+// if there is no file format with that name.
+func FileFormatFromName(name string) (fileformat.Format, error) {
+	normalized := normalizeFileFormatName(name)
+	for f := range fileFormatInfos {
+		if normalizeFileFormatName(f.String()) == normalized {
+			return f, nil
+		}
+	}
+
+	return fileformat.Format_Unknown,
+		fmt.Errorf("%w: unknown file format '%s'", ErrInvalidArgument, name)
+}
+
+// FileFormatName returns the name of the file format as it is used in URLs.
+func FileFormatName(f fileformat.Format) string {
+	return strings.ToLower(f.String())
+}
+
+// FileFormatContentType returns the MIME type of files of the given format.
+func FileFormatContentType(f fileformat.Format) string {
+	if info, ok := fileFormatInfos[f]; ok {
+		return info.contentType
+	}
+
+	return "application/octet-stream"
+}
+
+// FileFormatExtension returns the file extension with a leading dot
+// for files of the given format.
+func FileFormatExtension(f fileformat.Format) string {
+	if info, ok := fileFormatInfos[f]; ok {
+		return info.extension
+	}
+
+	return ".bin"
+}
+
+// TuneFileName returns a file name for a tune of the given format. The name
+// only contains letters, digits, dashes and underscores, so it can safely
+// be used on all file systems.
+func TuneFileName(title string, f fileformat.Format) string {
+	return TitleFileName(title, FileFormatExtension(f))
+}
+
+// TitleFileName returns a file name like TuneFileName for a tune file
+// with the extension, which has a leading dot.
+func TitleFileName(title string, extension string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '-':
+			return r
+		case unicode.IsSpace(r), r == '_':
+			return '_'
+		}
+		return -1
+	}, strings.TrimSpace(title))
+
+	if name == "" {
+		name = "tune"
+	}
+
+	return name + extension
+}
+
+func normalizeFileFormatName(name string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "_", "")
+}
@@ -0,0 +1,79 @@
+package common
+
+import (
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"testing"
+)
+
+func TestFileFormatFromName(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name     string
+		fileType string
+		want     fileformat.Format
+		wantErr  bool
+	}{
+		{
+			name:     "lower case name",
+			fileType: "bww",
+			want:     fileformat.Format_BWW,
+		},
+		{
+			name:     "name with underscore",
+			fileType: "music_model",
+			want:     fileformat.Format_MUSIC_MODEL,
+		},
+		{
+			name:     "mixed case name without underscore",
+			fileType: "MusicXml",
+			want:     fileformat.Format_MUSIC_XML,
+		},
+		{
+			name:     "unknown format",
+			fileType: "unknown",
+			wantErr:  true,
+		},
+		{
+			name:     "empty name",
+			fileType: "",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(*testing.T) {
+			got, err := FileFormatFromName(tt.fileType)
+			if tt.wantErr {
+				g.Expect(err).To(MatchError(ErrInvalidArgument))
+				return
+			}
+
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestFileFormatName(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(FileFormatName(fileformat.Format_MUSIC_XML)).To(Equal("music_xml"))
+	g.Expect(FileFormatExtension(fileformat.Format_BWW)).To(Equal(".bww"))
+	g.Expect(FileFormatContentType(fileformat.Format_Unknown)).To(Equal("application/octet-stream"))
+}
+
+func TestTuneFileName(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(TuneFileName("Scotland the Brave!", fileformat.Format_BWW)).To(Equal("Scotland_the_Brave.bww"))
+	g.Expect(TuneFileName(" 79th's Farewell ", fileformat.Format_MUSIC_XML)).To(Equal("79ths_Farewell.musicxml"))
+	g.Expect(TuneFileName("???", fileformat.Format_ABC)).To(Equal("tune.abc"))
+}
+
+func TestTitleFileName(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(TitleFileName("Scotland the Brave", ".mid")).To(Equal("Scotland_the_Brave.mid"))
+	g.Expect(TitleFileName("", ".mid")).To(Equal("tune.mid"))
+}
@@ -1,6 +1,7 @@
 package common
 
 import (
+	"github.com/google/uuid"
 	"github.com/spf13/afero"
 	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
 )
@@ -11,6 +12,26 @@ type ImportFileInfo struct {
 	Name         string
 	Hash         string
 	Data         []byte
+
+	// ImportJobID is the import job that imports the file. It is nil
+	// for files that are not imported by a job, e.g. by the CLI.
+	ImportJobID *uuid.UUID
+
+	// OwnerID is the user who imports the file and who owns
+	// the created tunes and sets.
+	OwnerID *uuid.UUID
+
+	// Reimport updates the tunes of an earlier import of a file with the same
+	// path instead of creating new tunes. The tunes are matched by their title.
+	Reimport bool
+
+	// TuneMapping maps tune titles of the file to the ids of the tunes
+	// they update on a re-import. It takes precedence over matching by title.
+	TuneMapping map[string]uuid.UUID
+
+	// Tags are the names of the tags the imported tunes and their set get.
+	// Tags that don't exist yet are created.
+	Tags []string
 }
 
 func NewImportFileInfoFromLocalFile(
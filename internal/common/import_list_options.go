@@ -0,0 +1,7 @@
+package common
+
+// ImportListOptions contains the paging options for listing the import history.
+// The form tags are the query parameter names of the GET /imports endpoint.
+type ImportListOptions struct {
+	PageOptions
+}
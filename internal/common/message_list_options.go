@@ -0,0 +1,14 @@
+package common
+
+// MessageListOptions contains the paging and filter options for listing
+// the messages of the parser. The form tags are the query parameter names
+// of the GET /messages endpoint.
+type MessageListOptions struct {
+	PageOptions
+
+	// Severity only lists the messages with this severity.
+	Severity string `form:"severity" binding:"omitempty,oneof=info warning error"`
+
+	// Reviewed only lists the reviewed or the not yet reviewed messages.
+	Reviewed *bool `form:"reviewed"`
+}
@@ -0,0 +1,7 @@
+package common
+
+// MidiOptions contains the query parameters of the GET /tunes/{tuneId}/midi endpoint.
+type MidiOptions struct {
+	// Tempo are the beats per minute that replace the tempo of the tune
+	Tempo uint32 `form:"tempo" binding:"omitempty,min=20,max=300"`
+}
@@ -0,0 +1,32 @@
+package common
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+// PageOptions contains the paging options of the list endpoints.
+// The form tags are the query parameter names of the endpoints.
+type PageOptions struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`
+	PageSize int `form:"pageSize" binding:"omitempty,min=1,max=200"`
+}
+
+// PageOrDefault returns the requested page or the first page if none was given.
+func (o PageOptions) PageOrDefault() int {
+	if o.Page < 1 {
+		return 1
+	}
+
+	return o.Page
+}
+
+// PageSizeOrDefault returns the requested page size which is limited
+// to MaxPageSize or the DefaultPageSize if none was given.
+func (o PageOptions) PageSizeOrDefault() int {
+	if o.PageSize < 1 {
+		return DefaultPageSize
+	}
+
+	return min(o.PageSize, MaxPageSize)
+}
@@ -0,0 +1,18 @@
+package common
+
+import (
+	. "github.com/onsi/gomega"
+	"testing"
+)
+
+func TestPageOptions_Defaults(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	opts := PageOptions{}
+	g.Expect(opts.PageOrDefault()).To(Equal(1))
+	g.Expect(opts.PageSizeOrDefault()).To(Equal(DefaultPageSize))
+
+	opts = PageOptions{Page: 3, PageSize: MaxPageSize + 1}
+	g.Expect(opts.PageOrDefault()).To(Equal(3))
+	g.Expect(opts.PageSizeOrDefault()).To(Equal(MaxPageSize))
+}
@@ -0,0 +1,145 @@
+package common
+
+import (
+	"slices"
+	"strings"
+)
+
+// NameMatch is how well two spellings of a person's name match.
+type NameMatch int
+
+const (
+	NoNameMatch NameMatch = iota
+
+	// FuzzyNameMatch are names with the same surname, apart from a typo or
+	// a "Mac" written as "Mc", and given names where one can be the initial
+	// of the other, like "George S. MacLennan" and "G.S. McLennan".
+	FuzzyNameMatch
+
+	// ExactNameMatch are names that only differ in their case, punctuation
+	// or titles, like "P/M G.S. McLennan" and "G S McLennan".
+	ExactNameMatch
+)
+
+// personTitles are the words before a name that are ignored when matching names.
+var personTitles = []string{
+	"p/m", "pm", "pipe", "major", "d/m", "dm", "drum",
+	"mr", "mrs", "ms", "miss", "dr", "sir", "rev",
+	"capt", "captain", "lt", "sgt", "cpl", "pte",
+}
+
+// personSuffixes are the words after a name that are ignored when matching names.
+var personSuffixes = []string{
+	"jr", "jnr", "sr", "snr", "mbe", "obe", "bem",
+}
+
+// MatchPersonNames returns how well the two names of persons match.
+func MatchPersonNames(a string, b string) NameMatch {
+	wordsA := personNameWords(a)
+	wordsB := personNameWords(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return NoNameMatch
+	}
+	if slices.Equal(wordsA, wordsB) {
+		return ExactNameMatch
+	}
+	// Without given names, a surname alone could be anyone
+	if len(wordsA) < 2 || len(wordsB) < 2 {
+		return NoNameMatch
+	}
+
+	if !surnamesMatch(wordsA[len(wordsA)-1], wordsB[len(wordsB)-1]) ||
+		!givenNamesMatch(wordsA[:len(wordsA)-1], wordsB[:len(wordsB)-1]) {
+		return NoNameMatch
+	}
+
+	return FuzzyNameMatch
+}
+
+// BestPersonMatch returns the index of the candidate whose names match the
+// name best and how well they match. A candidate is a person with all of the
+// names the person is known by. The first candidate wins if several candidates
+// match equally well. The index is -1 if no candidate matches.
+func BestPersonMatch(name string, candidates [][]string) (int, NameMatch) {
+	best := -1
+	bestMatch := NoNameMatch
+	for i, names := range candidates {
+		for _, n := range names {
+			if m := MatchPersonNames(name, n); m > bestMatch {
+				best = i
+				bestMatch = m
+			}
+		}
+	}
+
+	return best, bestMatch
+}
+
+// personNameWords returns the lower case words of the name without
+// punctuation, titles and suffixes.
+func personNameWords(name string) []string {
+	name = strings.ToLower(strings.ReplaceAll(name, "'", ""))
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return r == ' ' || r == '.' || r == ',' || r == '-' || r == '\t'
+	})
+
+	for len(words) > 1 && slices.Contains(personTitles, words[0]) {
+		words = words[1:]
+	}
+	for len(words) > 1 && slices.Contains(personSuffixes, words[len(words)-1]) {
+		words = words[:len(words)-1]
+	}
+
+	return words
+}
+
+func surnamesMatch(a string, b string) bool {
+	a = strings.Replace(a, "mac", "mc", 1)
+	b = strings.Replace(b, "mac", "mc", 1)
+	if a == b {
+		return true
+	}
+
+	// Short names differ too much with a single typo
+	return min(len(a), len(b)) >= 5 && editDistance(a, b) <= 1
+}
+
+// givenNamesMatch returns true if the given names match as far as both
+// names have them. A single letter matches every name with this initial.
+func givenNamesMatch(a []string, b []string) bool {
+	for i := range min(len(a), len(b)) {
+		if a[i] == b[i] {
+			continue
+		}
+		isInitial := len(a[i]) == 1 || len(b[i]) == 1
+		if !isInitial || a[i][0] != b[i][0] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// editDistance returns the Levenshtein distance of the two strings.
+func editDistance(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur := make([]int, len(rb)+1)
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+
+	return prev[len(rb)]
+}
@@ -0,0 +1,54 @@
+package common
+
+import (
+	. "github.com/onsi/gomega"
+	"testing"
+)
+
+func TestMatchPersonNames(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		a    string
+		b    string
+		want NameMatch
+	}{
+		{a: "G.S. McLennan", b: "g s mclennan", want: ExactNameMatch},
+		{a: "P/M G.S. McLennan", b: "G S McLennan", want: ExactNameMatch},
+		{a: "Pipe Major Donald MacLeod MBE", b: "Donald MacLeod", want: ExactNameMatch},
+		{a: "Trad.", b: "trad", want: ExactNameMatch},
+		{a: "George S. McLennan", b: "G.S. McLennan", want: FuzzyNameMatch},
+		{a: "G. MacLennan", b: "G.S. McLennan", want: FuzzyNameMatch},
+		{a: "John McLellan", b: "John McLelan", want: FuzzyNameMatch},
+		{a: "John McLellan", b: "Jock McLellan", want: NoNameMatch},
+		{a: "J. Wilson", b: "J. Watson", want: NoNameMatch},
+		{a: "J. Kerr", b: "J. Carr", want: NoNameMatch},
+		{a: "McLennan", b: "G.S. McLennan", want: NoNameMatch},
+		{a: "", b: "", want: NoNameMatch},
+	}
+	for _, tt := range tests {
+		g.Expect(MatchPersonNames(tt.a, tt.b)).To(Equal(tt.want), "%s and %s", tt.a, tt.b)
+		g.Expect(MatchPersonNames(tt.b, tt.a)).To(Equal(tt.want), "%s and %s", tt.b, tt.a)
+	}
+}
+
+func TestBestPersonMatch(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	candidates := [][]string{
+		{"Donald MacLeod"},
+		{"George S. McLennan", "G.S. McLennan"},
+	}
+
+	idx, match := BestPersonMatch("P/M G S McLennan", candidates)
+	g.Expect(idx).To(Equal(1))
+	g.Expect(match).To(Equal(ExactNameMatch))
+
+	idx, match = BestPersonMatch("D. McLeod", candidates)
+	g.Expect(idx).To(Equal(0))
+	g.Expect(match).To(Equal(FuzzyNameMatch))
+
+	idx, match = BestPersonMatch("Peter MacLeod", candidates)
+	g.Expect(idx).To(Equal(-1))
+	g.Expect(match).To(Equal(NoNameMatch))
+}
@@ -0,0 +1,9 @@
+package common
+
+// RevisionDiffOptions are the numbers of the two revisions of a tune to
+// compare. The form tags are the query parameter names of the
+// GET /tunes/{tuneId}/revisions/diff endpoint.
+type RevisionDiffOptions struct {
+	From uint `form:"from" binding:"required,min=1"`
+	To   uint `form:"to" binding:"required,min=1"`
+}
@@ -0,0 +1,34 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Role is the role of a user in the library, which defines what the
+// user is allowed to do.
+type Role string
+
+const (
+	// RoleViewer can read and export tunes and sets.
+	RoleViewer Role = "viewer"
+	// RoleEditor can additionally import, create, change and delete tunes and sets.
+	RoleEditor Role = "editor"
+	// RoleAdmin can additionally manage the memberships of the users.
+	RoleAdmin Role = "admin"
+)
+
+var roles = []Role{RoleViewer, RoleEditor, RoleAdmin}
+
+// ParseRole returns the role with the given case-insensitive name. It returns
+// an ErrInvalidArgument error if there is no role with that name.
+func ParseRole(name string) (Role, error) {
+	normalized := Role(strings.ToLower(strings.TrimSpace(name)))
+	for _, r := range roles {
+		if r == normalized {
+			return r, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: unknown role '%s'", ErrInvalidArgument, name)
+}
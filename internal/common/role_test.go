@@ -0,0 +1,21 @@
+package common
+
+import (
+	. "github.com/onsi/gomega"
+	"testing"
+)
+
+func TestParseRole(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	role, err := ParseRole("editor")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(role).To(Equal(RoleEditor))
+
+	role, err = ParseRole(" Admin ")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(role).To(Equal(RoleAdmin))
+
+	_, err = ParseRole("piper")
+	g.Expect(err).To(MatchError(ErrInvalidArgument))
+}
@@ -0,0 +1,7 @@
+package common
+
+// ScoreOptions contains the query parameters of the GET /tunes/{tuneId}/score.svg endpoint.
+type ScoreOptions struct {
+	// Page is the page of the score starting at 1
+	Page int `form:"page" binding:"omitempty,min=1"`
+}
@@ -0,0 +1,55 @@
+package common
+
+import (
+	"github.com/google/uuid"
+	"strings"
+	"unicode"
+)
+
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+)
+
+// SearchKind is the kind of object a search hit refers to.
+type SearchKind string
+
+const (
+	SearchKindTune SearchKind = "tune"
+	SearchKindSet  SearchKind = "set"
+)
+
+// SearchOptions contains the query parameters of the GET /search endpoint.
+type SearchOptions struct {
+	Query string `form:"q" binding:"required"`
+
+	// Kind restricts the search to tunes or sets, if empty both are searched
+	Kind  SearchKind `form:"kind" binding:"omitempty,oneof=tune set"`
+	Limit int        `form:"limit" binding:"omitempty,min=1,max=100"`
+
+	// UserID is the user who searches, only their private sets are found
+	UserID *uuid.UUID `form:"-"`
+}
+
+// LimitOrDefault returns the requested limit which is at most MaxSearchLimit
+// or the DefaultSearchLimit if none was given.
+func (o SearchOptions) LimitOrDefault() int {
+	if o.Limit < 1 {
+		return DefaultSearchLimit
+	}
+
+	return min(o.Limit, MaxSearchLimit)
+}
+
+// IncludesKind returns true if objects of the given kind should be searched.
+func (o SearchOptions) IncludesKind(kind SearchKind) bool {
+	return o.Kind == "" || o.Kind == kind
+}
+
+// SearchTerms returns the lower case words of the query without
+// any punctuation or other special characters.
+func (o SearchOptions) SearchTerms() []string {
+	return strings.FieldsFunc(strings.ToLower(o.Query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
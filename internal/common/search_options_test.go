@@ -0,0 +1,52 @@
+package common
+
+import (
+	. "github.com/onsi/gomega"
+	"testing"
+)
+
+func TestSearchOptions_SearchTerms(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{
+			name:  "empty query",
+			query: "",
+			want:  []string{},
+		},
+		{
+			name:  "query with tsquery operators",
+			query: "highland & !laddie:*",
+			want:  []string{"highland", "laddie"},
+		},
+		{
+			name:  "query with upper case letters and digits",
+			query: "  The 79th's Farewell",
+			want:  []string{"the", "79th", "s", "farewell"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(*testing.T) {
+			opts := SearchOptions{Query: tt.query}
+			g.Expect(opts.SearchTerms()).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestSearchOptions_LimitAndKind(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	opts := SearchOptions{}
+	g.Expect(opts.LimitOrDefault()).To(Equal(DefaultSearchLimit))
+	g.Expect(opts.IncludesKind(SearchKindTune)).To(BeTrue())
+	g.Expect(opts.IncludesKind(SearchKindSet)).To(BeTrue())
+
+	opts = SearchOptions{Kind: SearchKindSet, Limit: MaxSearchLimit + 1}
+	g.Expect(opts.LimitOrDefault()).To(Equal(MaxSearchLimit))
+	g.Expect(opts.IncludesKind(SearchKindTune)).To(BeFalse())
+	g.Expect(opts.IncludesKind(SearchKindSet)).To(BeTrue())
+}
@@ -0,0 +1,49 @@
+package common
+
+import (
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// fileNameTagPattern matches the tags in square brackets of a file name
+// like "Scotland the Brave [Grade 4].bww".
+var fileNameTagPattern = regexp.MustCompile(`\[([^\[\]]+)]`)
+
+// TagNames splits a comma separated list of tag names. The names are trimmed
+// and names that only differ in their case are only returned once.
+func TagNames(list string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// TagsFromPath returns the tags for a file that is imported with auto
+// tagging: the name of the folder of the file and the tags in square
+// brackets of the file name.
+func TagsFromPath(filePath string) []string {
+	filePath = filepath.ToSlash(filePath)
+
+	var tags []string
+	folder := path.Base(path.Dir(filePath))
+	if folder != "." && folder != "/" {
+		tags = append(tags, folder)
+	}
+
+	for _, match := range fileNameTagPattern.FindAllStringSubmatch(path.Base(filePath), -1) {
+		tags = append(tags, match[1])
+	}
+
+	return TagNames(strings.Join(tags, ","))
+}
@@ -0,0 +1,49 @@
+package common
+
+import (
+	. "github.com/onsi/gomega"
+	"testing"
+)
+
+func TestTagNames(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(TagNames("")).To(BeEmpty())
+	g.Expect(TagNames("Grade 4, repertoire,,grade 4")).To(Equal([]string{"Grade 4", "repertoire"}))
+}
+
+func TestTagsFromPath(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{
+			name: "file without folder and tags",
+			path: "scotland_the_brave.bww",
+			want: nil,
+		},
+		{
+			name: "file in a folder",
+			path: "competition/grade 4/scotland_the_brave.bww",
+			want: []string{"grade 4"},
+		},
+		{
+			name: "file with tags in its name",
+			path: "/music/Scotland the Brave [Grade 4] [repertoire].bww",
+			want: []string{"music", "Grade 4", "repertoire"},
+		},
+		{
+			name: "folder and file tag with the same name",
+			path: "repertoire/Highland Laddie [Repertoire].bww",
+			want: []string{"repertoire"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(*testing.T) {
+			g.Expect(TagsFromPath(tt.path)).To(Equal(tt.want))
+		})
+	}
+}
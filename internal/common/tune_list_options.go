@@ -0,0 +1,86 @@
+package common
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"slices"
+	"strings"
+)
+
+// TuneListOptions contains the paging, filter and sort options
+// for listing tunes. The form tags are the query parameter names
+// of the GET /tunes endpoint.
+type TuneListOptions struct {
+	PageOptions
+
+	// Sort is a comma separated list of fields to sort by.
+	// A field prefixed with a minus sorts in descending order e.g. "type,-title"
+	Sort string `form:"sort"`
+
+	// Title, Composer and Arranger filter case-insensitive by a substring,
+	// Type and TimeSig filter by the exact value (also case-insensitive).
+	Title    string `form:"title"`
+	Type     string `form:"type"`
+	TimeSig  string `form:"timeSig"`
+	Composer string `form:"composer"`
+	Arranger string `form:"arranger"`
+
+	// Tags is a comma separated list of tag names,
+	// only tunes with all of these tags are listed.
+	Tags string `form:"tags"`
+
+	// PersonID only lists the tunes the person composed or arranged.
+	// It isn't a query parameter but set by the tunes endpoint of a person.
+	PersonID *uuid.UUID `form:"-"`
+}
+
+// SortField is a single field to sort a list by.
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// TuneSortFields are the fields tunes can be sorted by.
+var TuneSortFields = []string{
+	"title",
+	"type",
+	"timeSig",
+	"composer",
+	"arranger",
+	"createdAt",
+	"updatedAt",
+}
+
+// TagNames returns the names of the tags to filter by.
+func (o TuneListOptions) TagNames() []string {
+	return TagNames(o.Tags)
+}
+
+// SortFields parses the sort option into sort fields. It returns an
+// ErrInvalidArgument error if a field is not one of the TuneSortFields.
+func (o TuneListOptions) SortFields() ([]SortField, error) {
+	return ParseSortFields(o.Sort, TuneSortFields)
+}
+
+// ParseSortFields parses a comma separated sort string like "type,-title"
+// into sort fields. Only fields from validFields are allowed.
+func ParseSortFields(sort string, validFields []string) ([]SortField, error) {
+	var fields []SortField
+	for _, f := range strings.Split(sort, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+
+		sf := SortField{
+			Field: strings.TrimPrefix(f, "-"),
+			Desc:  strings.HasPrefix(f, "-"),
+		}
+		if !slices.Contains(validFields, sf.Field) {
+			return nil, fmt.Errorf("%w: can't sort by field '%s'", ErrInvalidArgument, sf.Field)
+		}
+		fields = append(fields, sf)
+	}
+
+	return fields, nil
+}
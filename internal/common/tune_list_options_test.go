@@ -0,0 +1,54 @@
+package common
+
+import (
+	. "github.com/onsi/gomega"
+	"testing"
+)
+
+func TestParseSortFields(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	validFields := []string{"title", "type"}
+	tests := []struct {
+		name    string
+		sort    string
+		want    []SortField
+		wantErr bool
+	}{
+		{
+			name: "empty sort string",
+			sort: "",
+			want: nil,
+		},
+		{
+			name: "single ascending field",
+			sort: "title",
+			want: []SortField{{Field: "title"}},
+		},
+		{
+			name: "multiple fields with descending order and spaces",
+			sort: "type, -title,",
+			want: []SortField{
+				{Field: "type"},
+				{Field: "title", Desc: true},
+			},
+		},
+		{
+			name:    "invalid field",
+			sort:    "title,composer",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(*testing.T) {
+			got, err := ParseSortFields(tt.sort, validFields)
+			if tt.wantErr {
+				g.Expect(err).To(MatchError(ErrInvalidArgument))
+				return
+			}
+
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
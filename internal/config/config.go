@@ -1,11 +1,17 @@
 package config
 
+import "strings"
+
 type Config struct {
 	ServerURL string `mapstructure:"API_SERVER_URL"`
 
 	TLSCertPath    string `mapstructure:"TLS_CERT_PATH"`
 	TLSCertKeyPath string `mapstructure:"TLS_CERT_KEY_PATH"`
 
+	// DbDriver is the database to use, either postgres (default) or sqlite.
+	// SQLite stores the whole database in the file at DbPath.
+	DbDriver   string `mapstructure:"DB_DRIVER"`
+	DbPath     string `mapstructure:"DB_PATH"`
 	DbHost     string `mapstructure:"DB_HOST"`
 	DbPort     string `mapstructure:"DB_PORT"`
 	DbName     string `mapstructure:"DB_NAME"`
@@ -14,23 +20,72 @@ type Config struct {
 	DbSslMode  string `mapstructure:"DB_SSL_MODE"`
 	DbTimeZone string `mapstructure:"DB_TIMEZONE"`
 
+	// DbAutoMigrate applies pending migrations on start instead of
+	// refusing to start until they are applied with the CLI.
+	DbAutoMigrate bool `mapstructure:"DB_AUTO_MIGRATE"`
+
 	HealthCacheDurationSeconds uint32 `mapstructure:"HEALTH_CACHE_DURATION_SECONDS"`
 	HealthGlobalTimeoutSeconds uint32 `mapstructure:"HEALTH_GLOBAL_TIMEOUT_SECONDS"`
 	HealthRefreshPeriodSeconds uint32 `mapstructure:"HEALTH_REFRESH_PERIOD_SECONDS"`
 	HealthInitialDelaySeconds  uint32 `mapstructure:"HEALTH_INITIAL_DELAY_SECONDS"`
 
 	PluginsDirectoryPath string `mapstructure:"PLUGINS_DIRECTORY_PATH"`
+
+	ImportWorkers             int `mapstructure:"IMPORT_WORKERS"`
+	ImportPollIntervalSeconds int `mapstructure:"IMPORT_POLL_INTERVAL_SECONDS"`
+
+	// The JWT bearer tokens of an external identity provider are only accepted
+	// if either the HMAC secret or the path to the PEM encoded public key is set.
+	AuthJwtSecret        string `mapstructure:"AUTH_JWT_SECRET"`
+	AuthJwtPublicKeyPath string `mapstructure:"AUTH_JWT_PUBLIC_KEY_PATH"`
+	AuthJwtIssuer        string `mapstructure:"AUTH_JWT_ISSUER"`
+	AuthJwtAudience      string `mapstructure:"AUTH_JWT_AUDIENCE"`
+	AuthJwtNameClaim     string `mapstructure:"AUTH_JWT_NAME_CLAIM"`
+
+	// AuthDefaultRole is the role of users without a membership, none if empty
+	AuthDefaultRole string `mapstructure:"AUTH_DEFAULT_ROLE"`
+
+	// Plugins is a comma separated list of the plugins to load from the plugins directory
+	Plugins []string `mapstructure:"PLUGINS"`
 }
 
 func (c *Config) DbConfig() DbConfig {
 	return DbConfig{
-		Host:     c.DbHost,
-		Port:     c.DbPort,
-		DbName:   c.DbName,
-		User:     c.DbUser,
-		Password: c.DbPassword,
-		SslMode:  c.DbSslMode,
-		TimeZone: c.DbTimeZone,
+		Driver:      c.DbDriver,
+		Path:        c.DbPath,
+		Host:        c.DbHost,
+		Port:        c.DbPort,
+		DbName:      c.DbName,
+		User:        c.DbUser,
+		Password:    c.DbPassword,
+		SslMode:     c.DbSslMode,
+		TimeZone:    c.DbTimeZone,
+		AutoMigrate: c.DbAutoMigrate,
+	}
+}
+
+// PluginIDs returns the lower case IDs of the plugins to load.
+// If no plugins are configured, only the bww plugin will be loaded.
+func (c *Config) PluginIDs() []string {
+	var ids []string
+	for _, p := range c.Plugins {
+		p = strings.ToLower(strings.TrimSpace(p))
+		if p != "" {
+			ids = append(ids, p)
+		}
+	}
+
+	if len(ids) == 0 {
+		return []string{"bww"}
+	}
+
+	return ids
+}
+
+func (c *Config) ImportConfig() ImportConfig {
+	return ImportConfig{
+		Workers:             c.ImportWorkers,
+		PollIntervalSeconds: c.ImportPollIntervalSeconds,
 	}
 }
 
@@ -42,3 +97,14 @@ func (c *Config) HealthConfig() HealthConfig {
 		InitialDelaySeconds:  c.HealthInitialDelaySeconds,
 	}
 }
+
+func (c *Config) AuthConfig() AuthConfig {
+	return AuthConfig{
+		JwtSecret:        c.AuthJwtSecret,
+		JwtPublicKeyPath: c.AuthJwtPublicKeyPath,
+		JwtIssuer:        c.AuthJwtIssuer,
+		JwtAudience:      c.AuthJwtAudience,
+		JwtNameClaim:     c.AuthJwtNameClaim,
+		DefaultRole:      c.AuthDefaultRole,
+	}
+}
@@ -1,6 +1,13 @@
 package config
 
+const (
+	DbDriverPostgres = "postgres"
+	DbDriverSQLite   = "sqlite"
+)
+
 type DbConfig struct {
+	Driver   string
+	Path     string
 	Host     string
 	Port     string
 	DbName   string
@@ -8,6 +15,15 @@ type DbConfig struct {
 	Password string
 	SslMode  string
 	TimeZone string
+
+	// AutoMigrate applies pending migrations when the database is opened
+	AutoMigrate bool
+}
+
+// IsSQLite returns true if the database is a SQLite file.
+// Without a driver, PostgreSQL is used.
+func (c DbConfig) IsSQLite() bool {
+	return c.Driver == DbDriverSQLite
 }
 
 type HealthConfig struct {
@@ -16,3 +32,26 @@ type HealthConfig struct {
 	RefreshPeriodSeconds uint32
 	InitialDelaySeconds  uint32
 }
+
+type ImportConfig struct {
+	Workers             int
+	PollIntervalSeconds int
+}
+
+type AuthConfig struct {
+	JwtSecret        string
+	JwtPublicKeyPath string
+	JwtIssuer        string
+	JwtAudience      string
+
+	// JwtNameClaim is the claim with the user name, preferred_username if empty
+	JwtNameClaim string
+
+	// DefaultRole is the role of users without a membership, none if empty
+	DefaultRole string
+}
+
+// JwtEnabled returns true if JWT bearer tokens are accepted.
+func (c AuthConfig) JwtEnabled() bool {
+	return c.JwtSecret != "" || c.JwtPublicKeyPath != ""
+}
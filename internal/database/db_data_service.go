@@ -17,6 +17,8 @@ import (
 	"github.com/tomvodi/limepipes/internal/database/model"
 	"github.com/tomvodi/limepipes/internal/interfaces"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"maps"
 	"strings"
 )
 
@@ -25,23 +27,187 @@ type Service struct {
 	validator interfaces.APIModelValidator
 }
 
-func (d *Service) Tunes() ([]*apimodel.Tune, error) {
+func (d *Service) Tunes(opts common.TuneListOptions) (*apimodel.TuneList, error) {
+	sortFields, err := opts.SortFields()
+	if err != nil {
+		return nil, err
+	}
+
+	var totalCount int64
+	if err := d.tuneListQuery(opts).Count(&totalCount).Error; err != nil {
+		return nil, err
+	}
+
+	page := opts.PageOrDefault()
+	pageSize := opts.PageSizeOrDefault()
 	var tunes []model.Tune
-	if err := d.db.Find(&tunes).Error; err != nil {
+	err = d.tuneListQuery(opts).
+		Select("tunes.*").
+		Preload("TuneType").
+		Preload("Tags", preloadTags).
+		Order(tuneListOrder(sortFields)).
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&tunes).Error
+	if err != nil {
 		return nil, err
 	}
 
-	var apiTunes []*apimodel.Tune
-	if err := copier.Copy(&apiTunes, tunes); err != nil {
+	apiTunes, err := apiTunesFromDbTunes(tunes)
+	if err != nil {
 		return nil, err
 	}
 
+	return &apimodel.TuneList{
+		Tunes:      apiTunes,
+		Pagination: newPagination(page, pageSize, totalCount),
+	}, nil
+}
+
+func newPagination(page int, pageSize int, totalCount int64) apimodel.Pagination {
+	return apimodel.Pagination{
+		Page:       int32(page),
+		PageSize:   int32(pageSize),
+		TotalCount: totalCount,
+		TotalPages: int32((totalCount + int64(pageSize) - 1) / int64(pageSize)),
+	}
+}
+
+// apiTunesFromDbTunes converts database tunes with preloaded tune types to api tunes.
+func apiTunesFromDbTunes(tunes []model.Tune) ([]apimodel.Tune, error) {
+	apiTunes := make([]apimodel.Tune, len(tunes))
+	for i, t := range tunes {
+		if err := copier.Copy(&apiTunes[i], &t); err != nil {
+			return nil, err
+		}
+		if t.TuneType != nil {
+			apiTunes[i].Type = t.TuneType.Name
+		}
+		apiTunes[i].Tags = tagNamesOf(t.Tags)
+	}
+
 	return apiTunes, nil
 }
 
+// tuneListQuery returns the query for all tunes that match the filters
+// of the given list options.
+func (d *Service) tuneListQuery(opts common.TuneListOptions) *gorm.DB {
+	query := d.db.Model(&model.Tune{}).
+		Joins("LEFT JOIN tune_types ON tune_types.id = tunes.tune_type_id")
+
+	substringFilters := []struct {
+		column string
+		value  string
+	}{
+		{"tunes.title", opts.Title},
+		{"tunes.composer", opts.Composer},
+		{"tunes.arranger", opts.Arranger},
+	}
+	for _, f := range substringFilters {
+		if strings.TrimSpace(f.value) == "" {
+			continue
+		}
+		query = query.Where(
+			fmt.Sprintf("lower(%s) LIKE ? ESCAPE '\\'", f.column),
+			"%"+escapeLikePattern(strings.ToLower(f.value))+"%",
+		)
+	}
+
+	if strings.TrimSpace(opts.Type) != "" {
+		query = query.Where("lower(tune_types.name) = ?", strings.ToLower(opts.Type))
+	}
+	if strings.TrimSpace(opts.TimeSig) != "" {
+		query = query.Where("tunes.time_sig = ?", opts.TimeSig)
+	}
+	if opts.PersonID != nil {
+		query = query.Where("tunes.composer_id = ? OR tunes.arranger_id = ?",
+			*opts.PersonID, *opts.PersonID)
+	}
+
+	return withAllTags(query, opts.TagNames())
+}
+
+// withAllTags restricts the tune query to tunes that have all of the tags.
+func withAllTags(query *gorm.DB, tagNames []string) *gorm.DB {
+	for _, name := range tagNames {
+		query = query.Where("tunes.id IN (SELECT tune_tags.tune_id FROM tune_tags "+
+			"JOIN tags ON tags.id = tune_tags.tag_id WHERE lower(tags.name) = ?)",
+			strings.ToLower(name))
+	}
+
+	return query
+}
+
+var tuneSortColumns = map[string]clause.Column{
+	"title":     {Table: "tunes", Name: "title"},
+	"type":      {Table: "tune_types", Name: "name"},
+	"timeSig":   {Table: "tunes", Name: "time_sig"},
+	"composer":  {Table: "tunes", Name: "composer"},
+	"arranger":  {Table: "tunes", Name: "arranger"},
+	"createdAt": {Table: "tunes", Name: "created_at"},
+	"updatedAt": {Table: "tunes", Name: "updated_at"},
+}
+
+// tuneListOrder returns the order clause for the given sort fields.
+// Tunes are sorted by title if no sort fields are given. The tune ID is always
+// the last sort column, so that pages are stable for tunes with equal values.
+func tuneListOrder(sortFields []common.SortField) clause.OrderBy {
+	if len(sortFields) == 0 {
+		sortFields = []common.SortField{{Field: "title"}}
+	}
+
+	var orderBy clause.OrderBy
+	for _, sf := range sortFields {
+		orderBy.Columns = append(orderBy.Columns, clause.OrderByColumn{
+			Column: tuneSortColumns[sf.Field],
+			Desc:   sf.Desc,
+		})
+	}
+	orderBy.Columns = append(orderBy.Columns, clause.OrderByColumn{
+		Column: clause.Column{Table: "tunes", Name: "id"},
+	})
+
+	return orderBy
+}
+
+// escapeLikePattern escapes the wildcard characters of a LIKE pattern
+// so that they are matched literally.
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer(
+		"\\", "\\\\",
+		"%", "\\%",
+		"_", "\\_",
+	).Replace(s)
+}
+
 func (d *Service) CreateTune(
 	ct apimodel.CreateTune,
 	importFile *model.ImportFile,
+	ownerID *uuid.UUID,
+) (*apimodel.Tune, error) {
+	var apiTune *apimodel.Tune
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		txService := d.withTx(tx)
+
+		var err error
+		apiTune, err = txService.createTune(ct, importFile, ownerID)
+		if err != nil {
+			return err
+		}
+
+		return txService.recordTuneRevision(apiTune.Id, "")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return apiTune, nil
+}
+
+func (d *Service) createTune(
+	ct apimodel.CreateTune,
+	importFile *model.ImportFile,
+	ownerID *uuid.UUID,
 ) (*apimodel.Tune, error) {
 	if strings.TrimSpace(ct.Title) == "" {
 		return nil, fmt.Errorf("can'ct create tune without a title")
@@ -51,6 +217,7 @@ func (d *Service) CreateTune(
 	if err != nil {
 		return nil, err
 	}
+	dbTune.OwnerID = ownerID
 
 	if err = d.db.Create(&dbTune).Error; err != nil {
 		return &apimodel.Tune{}, err
@@ -63,6 +230,7 @@ func (d *Service) CreateTune(
 	if dbTune.TuneType != nil {
 		apiTune.Type = dbTune.TuneType.Name
 	}
+	apiTune.Tags = tagNamesOf(dbTune.Tags)
 
 	return apiTune, nil
 }
@@ -92,6 +260,9 @@ func (d *Service) initDbTuneForCreation(
 		dbTune.TuneTypeID = &tuneType.ID
 		dbTune.TuneType = tuneType
 	}
+	if err = d.setTunePeople(dbTune); err != nil {
+		return nil, err
+	}
 
 	return dbTune, nil
 }
@@ -101,6 +272,7 @@ func (d *Service) GetTune(id uuid.UUID) (*apimodel.Tune, error) {
 	if err := d.db.
 		Preload("Sets").
 		Preload("TuneType").
+		Preload("Tags", preloadTags).
 		First(t, id).Error; err != nil {
 		return &apimodel.Tune{}, common.ErrNotFound
 	}
@@ -113,6 +285,7 @@ func (d *Service) GetTune(id uuid.UUID) (*apimodel.Tune, error) {
 	if t.TuneType != nil {
 		apiTune.Type = t.TuneType.Name
 	}
+	apiTune.Tags = tagNamesOf(t.Tags)
 
 	return apiTune, nil
 }
@@ -156,7 +329,11 @@ func (d *Service) createImportFile(importFile *common.ImportFileInfo) (*model.Im
 	return dbImportFile, nil
 }
 
-func (d *Service) UpdateTune(id uuid.UUID, updateTune apimodel.UpdateTune) (*apimodel.Tune, error) {
+func (d *Service) UpdateTune(
+	id uuid.UUID,
+	updateTune apimodel.UpdateTune,
+	author string,
+) (*apimodel.Tune, error) {
 	if err := d.validator.ValidateUpdateTune(updateTune); err != nil {
 		return nil, err
 	}
@@ -178,7 +355,18 @@ func (d *Service) UpdateTune(id uuid.UUID, updateTune apimodel.UpdateTune) (*api
 	updateVals["TuneTypeID"] = tuneType.ID
 	delete(updateVals, "Type")
 
-	if err := d.db.Model(t).Updates(updateVals).Error; err != nil {
+	peopleVals, err := d.tunePeopleUpdateVals(updateTune.Composer, updateTune.Arranger)
+	if err != nil {
+		return nil, err
+	}
+	delete(updateVals, "Composer")
+	delete(updateVals, "Arranger")
+	maps.Copy(updateVals, peopleVals)
+
+	err = d.changeTune(id, author, func(tx *Service) error {
+		return tx.db.Model(t).Updates(updateVals).Error
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -187,6 +375,7 @@ func (d *Service) UpdateTune(id uuid.UUID, updateTune apimodel.UpdateTune) (*api
 		return nil, err
 	}
 	apiTune.Type = tuneType.Name
+	apiTune.Tags = tagNamesOf(t.Tags)
 
 	return apiTune, nil
 }
@@ -204,10 +393,18 @@ func (d *Service) DeleteTune(id uuid.UUID) error {
 	return nil
 }
 
+// getOrCreateTuneType returns the tune type that has the name or the name
+// as alias. A new tune type is only created if there is none.
 func (d *Service) getOrCreateTuneType(
 	name string,
 ) (*model.TuneType, error) {
-	tuneType, err := d.getTuneTypeByName(name)
+	name = normalizeName(name)
+	tuneType, err := d.getTuneTypeByAlias(name)
+	if !errors.Is(err, common.ErrNotFound) {
+		return tuneType, err
+	}
+
+	tuneType, err = d.getTuneTypeByName(name)
 	if errors.Is(err, common.ErrNotFound) {
 		return d.createTuneType(name)
 	}
@@ -241,9 +438,25 @@ func (d *Service) createTuneType(name string) (*model.TuneType, error) {
 	return dbTuneType, nil
 }
 
-func (d *Service) MusicSets() ([]*apimodel.MusicSet, error) {
+// visibleSets restricts a query of music sets to the public sets and the
+// private sets of the given user. Without a user only public sets are visible.
+func visibleSets(userID *uuid.UUID) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if userID == nil {
+			return db.Where("music_sets.private = ?", false)
+		}
+
+		return db.Where("music_sets.private = ? OR music_sets.owner_id = ?", false, *userID)
+	}
+}
+
+// MusicSets returns all music sets that are visible to the user.
+func (d *Service) MusicSets(userID *uuid.UUID) ([]*apimodel.MusicSet, error) {
 	var sets []model.MusicSet
-	if err := d.db.Find(&sets).Error; err != nil {
+	err := d.db.Scopes(visibleSets(userID)).
+		Preload("Tags", preloadTags).
+		Find(&sets).Error
+	if err != nil {
 		return nil, err
 	}
 
@@ -265,9 +478,12 @@ func (d *Service) MusicSets() ([]*apimodel.MusicSet, error) {
 	return apiSets, nil
 }
 
+// CreateMusicSet creates a music set that is owned by the given user.
+// The creator of a set with an owner is always the name of the owner.
 func (d *Service) CreateMusicSet(
 	musicSet apimodel.CreateSet,
 	importFile *model.ImportFile,
+	ownerID *uuid.UUID,
 ) (*apimodel.MusicSet, error) {
 	if strings.TrimSpace(musicSet.Title) == "" {
 		return nil, fmt.Errorf("can't create music set without a title")
@@ -278,6 +494,15 @@ func (d *Service) CreateMusicSet(
 		return nil, err
 	}
 
+	if ownerID != nil {
+		owner, err := d.GetUser(*ownerID)
+		if err != nil {
+			return nil, err
+		}
+		dbSet.OwnerID = ownerID
+		dbSet.Creator = owner.Name
+	}
+
 	apiSet, err := d.createMusicSetWithTuneIDs(
 		dbSet,
 		musicSet.Tunes,
@@ -319,12 +544,12 @@ func (d *Service) createMusicSetWithTuneIDs(
 		return nil, err
 	}
 
-	err = d.db.Transaction(func(_ *gorm.DB) error {
-		if err = d.db.Create(&dbSet).Error; err != nil {
+	err = d.db.Transaction(func(tx *gorm.DB) error {
+		if err = tx.Create(&dbSet).Error; err != nil {
 			return err
 		}
 
-		if err := d.createMusicSetTuneRelations(dbSet.ID, tuneIDs); err != nil {
+		if err := d.withTx(tx).createMusicSetTuneRelations(dbSet.ID, tuneIDs); err != nil {
 			return err
 		}
 
@@ -343,12 +568,19 @@ func (d *Service) createMusicSetWithTuneIDs(
 	return apiSet, nil
 }
 
-func (d *Service) GetMusicSet(id uuid.UUID) (*apimodel.MusicSet, error) {
-	var set = &model.MusicSet{}
-	if err := d.db.First(set, id).Error; err != nil {
+// GetMusicSet returns the music set if it is visible to the user.
+// Private sets of other users are not found.
+func (d *Service) GetMusicSet(id uuid.UUID, userID *uuid.UUID) (*apimodel.MusicSet, error) {
+	set, err := d.visibleMusicSet(id, userID)
+	if err != nil {
 		return &apimodel.MusicSet{}, common.ErrNotFound
 	}
 
+	err = d.db.Model(set).Scopes(preloadTags).Association("Tags").Find(&set.Tags)
+	if err != nil {
+		return &apimodel.MusicSet{}, err
+	}
+
 	apiSet, err := apiSetFromDbSet(set)
 	if err != nil {
 		return &apimodel.MusicSet{}, err
@@ -361,6 +593,15 @@ func (d *Service) GetMusicSet(id uuid.UUID) (*apimodel.MusicSet, error) {
 	return apiSet, nil
 }
 
+func (d *Service) visibleMusicSet(id uuid.UUID, userID *uuid.UUID) (*model.MusicSet, error) {
+	var set = &model.MusicSet{}
+	if err := d.db.Scopes(visibleSets(userID)).First(set, id).Error; err != nil {
+		return nil, common.ErrNotFound
+	}
+
+	return set, nil
+}
+
 func apiSetFromDbSet(dbSet *model.MusicSet) (*apimodel.MusicSet, error) {
 	apiSet := &apimodel.MusicSet{}
 	if err := copier.Copy(apiSet, dbSet); err != nil {
@@ -368,16 +609,25 @@ func apiSetFromDbSet(dbSet *model.MusicSet) (*apimodel.MusicSet, error) {
 	}
 	// Copier creates a default empty slice if the tunes are nil
 	// so we set it to nil if there are no tunes
-	if len(apiSet.Tunes) == 0 {
-		apiSet.Tunes = nil
+	apiSet.Tunes = nil
+	if len(dbSet.Tunes) > 0 {
+		tunes, err := apiTunesFromDbTunes(dbSet.Tunes)
+		if err != nil {
+			return nil, err
+		}
+		apiSet.Tunes = tunes
 	}
+	apiSet.Tags = tagNamesOf(dbSet.Tags)
 
 	return apiSet, nil
 }
 
 // returns that music set that contains all tunes in the given order
-func (d *Service) getMusicSetByTuneIDs(tuneIDs []uuid.UUID) (*apimodel.MusicSet, error) {
-	allMusicSets, err := d.MusicSets()
+func (d *Service) getMusicSetByTuneIDs(
+	tuneIDs []uuid.UUID,
+	userID *uuid.UUID,
+) (*apimodel.MusicSet, error) {
+	allMusicSets, err := d.MusicSets(userID)
 	if err != nil {
 		return nil, err
 	}
@@ -425,9 +675,11 @@ func (d *Service) setTunesInAPISet(apiSet *apimodel.MusicSet) error {
 	}
 
 	if len(setTunes) > 0 {
-		if err := copier.Copy(&apiSet.Tunes, &setTunes); err != nil {
+		apiTunes, err := apiTunesFromDbTunes(setTunes)
+		if err != nil {
 			return err
 		}
+		apiSet.Tunes = apiTunes
 	}
 
 	return nil
@@ -436,6 +688,7 @@ func (d *Service) setTunesInAPISet(apiSet *apimodel.MusicSet) error {
 func (d *Service) UpdateMusicSet(
 	id uuid.UUID,
 	updateSet apimodel.UpdateSet,
+	userID *uuid.UUID,
 ) (*apimodel.MusicSet, error) {
 	var err error
 	if err = d.validator.ValidateUpdateSet(updateSet); err != nil {
@@ -443,11 +696,16 @@ func (d *Service) UpdateMusicSet(
 	}
 
 	// Check whether there is a music set with that id
-	_, err = d.GetMusicSet(id)
+	set, err := d.visibleMusicSet(id, userID)
 	if err != nil {
 		return nil, err
 	}
 
+	// The creator of a set with an owner is the owner
+	if set.OwnerID != nil {
+		updateSet.Creator = set.Creator
+	}
+
 	var updateVals = map[string]any{}
 	if err := mapstructure.Decode(&updateSet, &updateVals); err != nil {
 		return nil, err
@@ -504,16 +762,17 @@ func (d *Service) updateMusicSetInDb(
 	updateVals map[string]any,
 	tuneIDs []uuid.UUID,
 ) error {
-	return d.db.Transaction(func(_ *gorm.DB) error {
-		if err := d.deleteMusicSetTuneRelations(dbSet); err != nil {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		txService := d.withTx(tx)
+		if err := txService.deleteMusicSetTuneRelations(dbSet); err != nil {
 			return err
 		}
 
-		if err := d.db.Model(dbSet).Updates(updateVals).Error; err != nil {
+		if err := tx.Model(dbSet).Updates(updateVals).Error; err != nil {
 			return err
 		}
 
-		if err := d.createMusicSetTuneRelations(dbSet.ID, tuneIDs); err != nil {
+		if err := txService.createMusicSetTuneRelations(dbSet.ID, tuneIDs); err != nil {
 			return err
 		}
 
@@ -521,18 +780,18 @@ func (d *Service) updateMusicSetInDb(
 	})
 }
 
-func (d *Service) DeleteMusicSet(id uuid.UUID) error {
-	var set = &model.MusicSet{}
-	if err := d.db.Preload("Tunes").First(set, id).Error; err != nil {
-		return common.ErrNotFound
+func (d *Service) DeleteMusicSet(id uuid.UUID, userID *uuid.UUID) error {
+	set, err := d.visibleMusicSet(id, userID)
+	if err != nil {
+		return err
 	}
 
-	err := d.db.Transaction(func(_ *gorm.DB) error {
-		if err := d.deleteMusicSetTuneRelations(set); err != nil {
+	err = d.db.Transaction(func(tx *gorm.DB) error {
+		if err := d.withTx(tx).deleteMusicSetTuneRelations(set); err != nil {
 			return err
 		}
 
-		if err := d.db.Delete(set).Error; err != nil {
+		if err := tx.Delete(set).Error; err != nil {
 			return err
 		}
 
@@ -545,10 +804,11 @@ func (d *Service) DeleteMusicSet(id uuid.UUID) error {
 func (d *Service) AssignTunesToMusicSet(
 	setID uuid.UUID,
 	tuneIDs []uuid.UUID,
+	userID *uuid.UUID,
 ) (*apimodel.MusicSet, error) {
-	set := &model.MusicSet{}
-	if err := d.db.Preload("Tunes").First(set, setID).Error; err != nil {
-		return nil, common.ErrNotFound
+	set, err := d.visibleMusicSet(setID, userID)
+	if err != nil {
+		return nil, err
 	}
 
 	newTunes, err := d.dbTunesFromIDs(tuneIDs)
@@ -564,12 +824,7 @@ func (d *Service) AssignTunesToMusicSet(
 	// Return new set with tunes in order
 	set.Tunes = newTunes
 
-	apiSet := &apimodel.MusicSet{}
-	if err := copier.Copy(apiSet, set); err != nil {
-		return nil, err
-	}
-
-	return apiSet, nil
+	return apiSetFromDbSet(set)
 }
 
 func (d *Service) replaceMusicSetTuneRelations(
@@ -577,12 +832,13 @@ func (d *Service) replaceMusicSetTuneRelations(
 	tuneIDs []uuid.UUID,
 ) error {
 	// delete old music set-tune relations and create new ones
-	err := d.db.Transaction(func(_ *gorm.DB) error {
-		if err := d.deleteMusicSetTuneRelations(set); err != nil {
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		txService := d.withTx(tx)
+		if err := txService.deleteMusicSetTuneRelations(set); err != nil {
 			return err
 		}
 
-		if err := d.createMusicSetTuneRelations(set.ID, tuneIDs); err != nil {
+		if err := txService.createMusicSetTuneRelations(set.ID, tuneIDs); err != nil {
 			return err
 		}
 
@@ -676,6 +932,10 @@ func (d *Service) ImportTunes(
 		return nil, nil, fmt.Errorf("file %s already imported", fileInfo.Name)
 	}
 
+	if fileInfo.Reimport {
+		return d.reimportTunesToDatabase(parsedTunes, fileInfo)
+	}
+
 	return d.importTunesToDatabase(parsedTunes, fileInfo)
 }
 
@@ -686,23 +946,23 @@ func (d *Service) importTunesToDatabase(
 	var apiTunes []*apimodel.ImportTune
 	var musicSet *apimodel.BasicMusicSet
 
-	dbTx := func(_ *gorm.DB) error {
-		importFile, err := d.createImportFile(fInfo)
+	dbTx := func(tx *gorm.DB) error {
+		txService := d.withTx(tx)
+		importFile, err := txService.createImportFile(fInfo)
 		if err != nil {
 			return err
 		}
 
-		apiTunes, err = d.importTunes(parsedTunes, importFile, fInfo.FileFormat)
+		apiTunes, err = txService.importTunes(parsedTunes, importFile, fInfo.FileFormat)
 		if err != nil {
 			return err
 		}
-
-		musicSet, err = d.createMusicSetForTunes(apiTunes, importFile)
-		if err != nil {
+		if err = linkImportFileTunes(tx, importFile.ID, apiTunes); err != nil {
 			return err
 		}
 
-		return nil
+		musicSet, err = txService.createTaggedMusicSetForTunes(apiTunes, importFile, fInfo.Tags)
+		return err
 	}
 
 	err := d.db.Transaction(dbTx)
@@ -775,31 +1035,104 @@ func (d *Service) createTuneWithFiles(
 	}
 	createTune.TimeSig = timeSigDisplayStringFromTune(t)
 
-	apiTune, err := d.CreateTune(createTune, importFile)
+	apiTune, err := d.createTune(createTune, importFile, importFile.OwnerID)
 	if err != nil {
 		return nil, err
 	}
-	muMoTuneFile, err := model.TuneFileFromMusicModelTune(t)
+	if err = d.addParsedTuneFiles(apiTune.Id, pTune, fFormat); err != nil {
+		return nil, err
+	}
+
+	if err = d.recordTuneRevision(apiTune.Id, ""); err != nil {
+		return nil, err
+	}
+
+	return apiTune, nil
+}
+
+// addParsedTuneFiles adds the files of the parsed tune to the tune
+// and updates what is derived from its music.
+func (d *Service) addParsedTuneFiles(
+	tuneID uuid.UUID,
+	pTune *messages.ParsedTune,
+	fFormat fileformat.Format,
+) error {
+	tuneFiles, err := parsedTuneFiles(pTune, fFormat)
 	if err != nil {
+		return err
+	}
+	for _, tf := range tuneFiles {
+		if err = d.addFileToTune(tuneID, tf); err != nil {
+			return err
+		}
+	}
+
+	return d.updateTuneMusic(tuneID)
+}
+
+// updateTuneMusic updates the fingerprint, the parser messages and the
+// exported files of the tune after its music model changed.
+func (d *Service) updateTuneMusic(tuneID uuid.UUID) error {
+	t, err := d.tuneMusicModel(tuneID)
+	if err != nil {
+		return err
+	}
+	if err = d.updateTuneFingerprint(tuneID, t); err != nil {
+		return err
+	}
+	if err = d.deleteExportedFiles(tuneID); err != nil {
+		return err
+	}
+
+	return d.replaceParserMessages(tuneID, t)
+}
+
+// deleteExportedFiles deletes the files that were exported from the former
+// music of the tune, so that they are exported again from the current music.
+func (d *Service) deleteExportedFiles(tuneID uuid.UUID) error {
+	return d.db.Where("tune_id = ? AND exported = ?", tuneID, true).
+		Delete(&model.TuneFile{}).Error
+}
+
+// tuneMusicModel returns the music model of the tune or nil if it has none.
+func (d *Service) tuneMusicModel(tuneID uuid.UUID) (*tune.Tune, error) {
+	var files []model.TuneFile
+	err := d.db.Where("tune_id = ? AND format = ? AND single_tune_data = ?",
+		tuneID, fileformat.Format_MUSIC_MODEL, false).
+		Find(&files).Error
+	if err != nil || len(files) == 0 {
 		return nil, err
 	}
 
-	if err = d.AddFileToTune(apiTune.Id, muMoTuneFile); err != nil {
+	t, err := files[0].MusicModelTune()
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding music model of tune %s: %w", tuneID, err)
+	}
+
+	return t, nil
+}
+
+// parsedTuneFiles returns the music model file and, if the parsed tune has
+// data of the single tune, the file of the imported format.
+func parsedTuneFiles(
+	pTune *messages.ParsedTune,
+	fFormat fileformat.Format,
+) ([]*model.TuneFile, error) {
+	muMoTuneFile, err := model.TuneFileFromMusicModelTune(pTune.Tune)
+	if err != nil {
 		return nil, err
 	}
 
+	tuneFiles := []*model.TuneFile{muMoTuneFile}
 	if pTune.TuneFileData != nil {
-		muMoTuneFile = &model.TuneFile{
+		tuneFiles = append(tuneFiles, &model.TuneFile{
 			Format:         fFormat,
 			Data:           pTune.TuneFileData,
 			SingleTuneData: true,
-		}
-		if err = d.AddFileToTune(apiTune.Id, muMoTuneFile); err != nil {
-			return nil, err
-		}
+		})
 	}
 
-	return apiTune, nil
+	return tuneFiles, nil
 }
 
 func timeSigDisplayStringFromTune(
@@ -865,13 +1198,13 @@ func (d *Service) createMusicSetForTunes(
 	}
 
 	musicSetTitle := musicSetTitleFromTunes(tunes)
-	apiSet, err = d.getMusicSetByTuneIDs(tuneIDs)
+	apiSet, err = d.getMusicSetByTuneIDs(tuneIDs, importFile.OwnerID)
 	if errors.Is(err, common.ErrNotFound) { // music set not yet in db
 		createSet := apimodel.CreateSet{
 			Title: musicSetTitle,
 			Tunes: tuneIDs,
 		}
-		apiSet, err = d.CreateMusicSet(createSet, importFile)
+		apiSet, err = d.CreateMusicSet(createSet, importFile, importFile.OwnerID)
 		if err != nil {
 			return nil, fmt.Errorf("failed creating set for file %s: %s",
 				importFile.Name,
@@ -1061,12 +1394,39 @@ func (d *Service) GetTuneFiles(tuneID uuid.UUID) ([]*model.TuneFile, error) {
 	return tuneFiles, nil
 }
 
-func (d *Service) AddFileToTune(tuneID uuid.UUID, tFile *model.TuneFile) error {
+func (d *Service) AddFileToTune(tuneID uuid.UUID, tFile *model.TuneFile, author string) error {
+	return d.changeTune(tuneID, author, func(tx *Service) error {
+		return tx.addFileToTune(tuneID, tFile)
+	})
+}
+
+// AddExportedFileToTune stores a file that was exported from the music model
+// of the tune. Exported files are no change of the tune, so no revision is
+// recorded for them.
+func (d *Service) AddExportedFileToTune(tuneID uuid.UUID, tFile *model.TuneFile) error {
+	tFile.Exported = true
+
+	return d.addFileToTune(tuneID, tFile)
+}
+
+func (d *Service) addFileToTune(tuneID uuid.UUID, tFile *model.TuneFile) error {
 	var t = &model.Tune{}
 	if err := d.db.First(t, tuneID).Error; err != nil {
 		return common.ErrNotFound
 	}
 
+	var existing int64
+	err := d.db.Model(&model.TuneFile{}).
+		Where("tune_id = ? AND format = ?", tuneID, tFile.Format).
+		Count(&existing).Error
+	if err != nil {
+		return err
+	}
+	if existing > 0 {
+		return fmt.Errorf("%w: tune %s already has a file of format %s",
+			common.ErrAlreadyExists, tuneID, tFile.Format.String())
+	}
+
 	tFile.TuneID = tuneID
 
 	if err := d.db.Create(tFile).Error; err != nil {
@@ -1076,16 +1436,35 @@ func (d *Service) AddFileToTune(tuneID uuid.UUID, tFile *model.TuneFile) error {
 	return nil
 }
 
-func (d *Service) DeleteFileFromTune(tuneID uuid.UUID, fFormat fileformat.Format) error {
-	tuneFile := &model.TuneFile{
-		TuneID: tuneID,
-		Format: fFormat,
-	}
-	if err := d.db.Delete(tuneFile).Error; err != nil {
-		return err
-	}
+func (d *Service) DeleteFileFromTune(
+	tuneID uuid.UUID,
+	fFormat fileformat.Format,
+	author string,
+) error {
+	return d.changeTune(tuneID, author, func(tx *Service) error {
+		tuneFile := &model.TuneFile{
+			TuneID: tuneID,
+			Format: fFormat,
+		}
+		res := tx.db.Delete(tuneFile)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return common.ErrNotFound
+		}
 
-	return nil
+		return nil
+	})
+}
+
+// withTx returns a service that runs all queries in the transaction, so
+// that the helpers of the service can be used inside of a transaction.
+func (d *Service) withTx(tx *gorm.DB) *Service {
+	return &Service{
+		db:        tx,
+		validator: d.validator,
+	}
 }
 
 func NewDbDataService(
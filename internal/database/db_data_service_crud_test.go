@@ -27,7 +27,7 @@ var _ = Describe("DbDataService CRUD", func() {
 	BeforeEach(func() {
 		cfg, err = config.InitTest()
 		Expect(err).ShouldNot(HaveOccurred())
-		gormDb, err = GetInitTestPostgreSQLDB(cfg.DbConfig(), "testdb")
+		gormDb, err = GetInitTestDB(cfg.DbConfig(), "testdb")
 		validator = mocks.NewAPIModelValidator(GinkgoT())
 
 		service = &Service{
@@ -47,7 +47,7 @@ var _ = Describe("DbDataService CRUD", func() {
 		BeforeEach(func() {
 			_, err = service.CreateTune(apimodel.CreateTune{
 				Title: "",
-			}, nil)
+			}, nil, nil)
 		})
 
 		It("should return an error", func() {
@@ -110,7 +110,7 @@ var _ = Describe("DbDataService CRUD", func() {
 		BeforeEach(func() {
 			tune, err = service.CreateTune(apimodel.CreateTune{
 				Title: "title",
-			}, nil)
+			}, nil, nil)
 		})
 
 		It("should succeed", func() {
@@ -133,20 +133,24 @@ var _ = Describe("DbDataService CRUD", func() {
 				TimeSig:  "2/4",
 				Composer: "mr. x",
 				Arranger: "mr. y",
-			}, nil)
+			}, nil, nil)
 		})
 
 		It("should succeed", func() {
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(tune.Id).ShouldNot(Equal(uuid.Nil))
+			Expect(tune.ComposerId).ShouldNot(BeNil())
+			Expect(tune.ArrangerId).ShouldNot(BeNil())
 			Expect(tune).Should(Equal(
 				&apimodel.Tune{
-					Id:       tune.Id,
-					Title:    "title",
-					Type:     "march",
-					TimeSig:  "2/4",
-					Composer: "mr. x",
-					Arranger: "mr. y",
+					Id:         tune.Id,
+					Title:      "title",
+					Type:       "march",
+					TimeSig:    "2/4",
+					Composer:   "mr. x",
+					ComposerId: tune.ComposerId,
+					Arranger:   "mr. y",
+					ArrangerId: tune.ArrangerId,
 				}))
 		})
 
@@ -172,19 +176,23 @@ var _ = Describe("DbDataService CRUD", func() {
 					Arranger: "new arranger",
 				}
 				validator.EXPECT().ValidateUpdateTune(update).Return(nil)
-				tune, err = service.UpdateTune(tune.Id, update)
+				tune, err = service.UpdateTune(tune.Id, update, "")
 			})
 
 			It("should succeed", func() {
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(tune.Id).ShouldNot(Equal(uuid.Nil))
+				Expect(tune.ComposerId).ShouldNot(BeNil())
+				Expect(tune.ArrangerId).ShouldNot(BeNil())
 				Expect(tune).To(Equal(&apimodel.Tune{
-					Id:       tune.Id,
-					Title:    "new title",
-					Type:     "new type",
-					TimeSig:  "new time signature",
-					Composer: "new composer",
-					Arranger: "new arranger",
+					Id:         tune.Id,
+					Title:      "new title",
+					Type:       "new type",
+					TimeSig:    "new time signature",
+					Composer:   "new composer",
+					ComposerId: tune.ComposerId,
+					Arranger:   "new arranger",
+					ArrangerId: tune.ArrangerId,
 				}))
 			})
 
@@ -197,12 +205,14 @@ var _ = Describe("DbDataService CRUD", func() {
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(tune.Id).ShouldNot(Equal(uuid.Nil))
 					Expect(tune).To(Equal(&apimodel.Tune{
-						Id:       tune.Id,
-						Title:    "new title",
-						Type:     "new type",
-						TimeSig:  "new time signature",
-						Composer: "new composer",
-						Arranger: "new arranger",
+						Id:         tune.Id,
+						Title:      "new title",
+						Type:       "new type",
+						TimeSig:    "new time signature",
+						Composer:   "new composer",
+						ComposerId: tune.ComposerId,
+						Arranger:   "new arranger",
+						ArrangerId: tune.ArrangerId,
 					}))
 				})
 			})
@@ -219,7 +229,7 @@ var _ = Describe("DbDataService CRUD", func() {
 				}
 				validator.EXPECT().ValidateUpdateTune(update).
 					Return(fmt.Errorf("missing title"))
-				tune, err = service.UpdateTune(tune.Id, update)
+				tune, err = service.UpdateTune(tune.Id, update, "")
 			})
 
 			It("should fail", func() {
@@ -237,7 +247,7 @@ var _ = Describe("DbDataService CRUD", func() {
 				parsedTune = model.TestParsedTune("test tune")
 				tuneFile, err = model.TuneFileFromMusicModelTune(parsedTune.Tune)
 				Expect(err).ShouldNot(HaveOccurred())
-				err = service.AddFileToTune(tune.Id, tuneFile)
+				err = service.AddFileToTune(tune.Id, tuneFile, "")
 			})
 
 			It("should add that file", func() {
@@ -256,9 +266,31 @@ var _ = Describe("DbDataService CRUD", func() {
 				})
 			})
 
+			When("adding another file of the same format", func() {
+				BeforeEach(func() {
+					tuneFile, err = model.TuneFileFromMusicModelTune(parsedTune.Tune)
+					Expect(err).ShouldNot(HaveOccurred())
+					err = service.AddFileToTune(tune.Id, tuneFile, "")
+				})
+
+				It("should return an already exists error", func() {
+					Expect(err).To(MatchError(common.ErrAlreadyExists))
+				})
+			})
+
+			When("deleting a file of a format the tune doesn't have", func() {
+				BeforeEach(func() {
+					err = service.DeleteFileFromTune(tune.Id, fileformat.Format_BWW, "")
+				})
+
+				It("should return a not found error", func() {
+					Expect(err).To(MatchError(common.ErrNotFound))
+				})
+			})
+
 			When("deleting that file", func() {
 				BeforeEach(func() {
-					err = service.DeleteFileFromTune(tune.Id, fileformat.Format_MUSIC_MODEL)
+					err = service.DeleteFileFromTune(tune.Id, fileformat.Format_MUSIC_MODEL, "")
 				})
 
 				It("should succeed", func() {
@@ -322,38 +354,208 @@ var _ = Describe("DbDataService CRUD", func() {
 	Context("creating two tunes", func() {
 		var tune1 *apimodel.Tune
 		var tune2 *apimodel.Tune
-		var tunes []*apimodel.Tune
+		var tuneList *apimodel.TuneList
 
 		BeforeEach(func() {
 			tune1, err = service.CreateTune(apimodel.CreateTune{
 				Title: "tune1",
-			}, nil)
+			}, nil, nil)
 			tune2, err = service.CreateTune(apimodel.CreateTune{
 				Title: "tune2",
-			}, nil)
+			}, nil, nil)
 		})
 
 		It("should return both tunes", func() {
-			tunes, err = service.Tunes()
+			tuneList, err = service.Tunes(common.TuneListOptions{})
 			Expect(err).ShouldNot(HaveOccurred())
-			Expect(tunes).To(HaveLen(2))
-			Expect(tunes[0].Id).ShouldNot(Equal(uuid.Nil))
-			Expect(tunes[1].Id).ShouldNot(Equal(uuid.Nil))
-			tune1.Id = tunes[0].Id
-			tune2.Id = tunes[1].Id
-			Expect(tunes).To(Equal([]*apimodel.Tune{
-				tune1,
-				tune2,
+			Expect(tuneList.Tunes).To(Equal([]apimodel.Tune{
+				*tune1,
+				*tune2,
+			}))
+			Expect(tuneList.Pagination).To(Equal(apimodel.Pagination{
+				Page:       1,
+				PageSize:   common.DefaultPageSize,
+				TotalCount: 2,
+				TotalPages: 1,
 			}))
 		})
 	})
 
+	Context("having tunes with different types, composers and time signatures", func() {
+		var tuneList *apimodel.TuneList
+		var listOpts common.TuneListOptions
+
+		BeforeEach(func() {
+			listOpts = common.TuneListOptions{}
+			for _, ct := range []apimodel.CreateTune{
+				{Title: "Scotland the Brave", Type: "March", TimeSig: "4/4", Composer: "Trad."},
+				{Title: "The Brown Haired Maiden", Type: "Strathspey", TimeSig: "4/4", Composer: "Trad."},
+				{Title: "Mrs MacPherson of Inveran", Type: "Reel", TimeSig: "2/2", Composer: "J. Wilson"},
+				{Title: "The Braes of Brecklet", Type: "March", TimeSig: "2/4", Composer: "G.S. McLennan"},
+				{Title: "100% Pipes_Tune", Type: "Jig", TimeSig: "6/8", Arranger: "P/M Smith"},
+			} {
+				_, err = service.CreateTune(ct, nil, nil)
+				Expect(err).ShouldNot(HaveOccurred())
+			}
+		})
+
+		JustBeforeEach(func() {
+			tuneList, err = service.Tunes(listOpts)
+		})
+
+		tuneTitles := func(tl *apimodel.TuneList) []string {
+			var titles []string
+			for _, t := range tl.Tunes {
+				titles = append(titles, t.Title)
+			}
+			return titles
+		}
+
+		It("should return all tunes sorted by title with their type", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(tuneTitles(tuneList)).To(Equal([]string{
+				"100% Pipes_Tune",
+				"Mrs MacPherson of Inveran",
+				"Scotland the Brave",
+				"The Braes of Brecklet",
+				"The Brown Haired Maiden",
+			}))
+			Expect(tuneList.Tunes[0].Type).To(Equal("Jig"))
+		})
+
+		When("requesting the second page with two tunes per page", func() {
+			BeforeEach(func() {
+				listOpts.Page = 2
+				listOpts.PageSize = 2
+			})
+
+			It("should return the tunes of that page and the pagination info", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(tuneTitles(tuneList)).To(Equal([]string{
+					"Scotland the Brave",
+					"The Braes of Brecklet",
+				}))
+				Expect(tuneList.Pagination).To(Equal(apimodel.Pagination{
+					Page:       2,
+					PageSize:   2,
+					TotalCount: 5,
+					TotalPages: 3,
+				}))
+			})
+		})
+
+		When("requesting a page after the last one", func() {
+			BeforeEach(func() {
+				listOpts.Page = 4
+				listOpts.PageSize = 2
+			})
+
+			It("should return no tunes but the total count", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(tuneList.Tunes).To(BeEmpty())
+				Expect(tuneList.Pagination.TotalCount).To(Equal(int64(5)))
+			})
+		})
+
+		When("filtering by a part of the title", func() {
+			BeforeEach(func() {
+				listOpts.Title = "BRA"
+			})
+
+			It("should only return tunes containing that text case-insensitive", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(tuneTitles(tuneList)).To(Equal([]string{
+					"Scotland the Brave",
+					"The Braes of Brecklet",
+				}))
+				Expect(tuneList.Pagination.TotalCount).To(Equal(int64(2)))
+			})
+		})
+
+		When("filtering by a title with LIKE wildcard characters", func() {
+			BeforeEach(func() {
+				listOpts.Title = "0% pipes_"
+			})
+
+			It("should match the wildcard characters literally", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(tuneTitles(tuneList)).To(Equal([]string{
+					"100% Pipes_Tune",
+				}))
+			})
+		})
+
+		When("filtering by type and time signature", func() {
+			BeforeEach(func() {
+				listOpts.Type = "march"
+				listOpts.TimeSig = "4/4"
+			})
+
+			It("should only return tunes with that type and time signature", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(tuneTitles(tuneList)).To(Equal([]string{
+					"Scotland the Brave",
+				}))
+			})
+		})
+
+		When("filtering by composer and arranger", func() {
+			BeforeEach(func() {
+				listOpts.Composer = "trad"
+			})
+
+			It("should only return tunes from that composer", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(tuneList.Tunes).To(HaveLen(2))
+			})
+
+			When("also filtering by an arranger no tune of the composer has", func() {
+				BeforeEach(func() {
+					listOpts.Arranger = "smith"
+				})
+
+				It("should return no tunes", func() {
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(tuneList.Tunes).To(BeEmpty())
+					Expect(tuneList.Pagination.TotalPages).To(Equal(int32(0)))
+				})
+			})
+		})
+
+		When("sorting by type ascending and title descending", func() {
+			BeforeEach(func() {
+				listOpts.Sort = "type,-title"
+			})
+
+			It("should return the tunes in that order", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(tuneTitles(tuneList)).To(Equal([]string{
+					"100% Pipes_Tune",
+					"The Braes of Brecklet",
+					"Scotland the Brave",
+					"Mrs MacPherson of Inveran",
+					"The Brown Haired Maiden",
+				}))
+			})
+		})
+
+		When("sorting by an unknown field", func() {
+			BeforeEach(func() {
+				listOpts.Sort = "id"
+			})
+
+			It("should return an invalid argument error", func() {
+				Expect(err).To(MatchError(common.ErrInvalidArgument))
+			})
+		})
+	})
+
 	// Sets
 	Context("creating a set without a title", func() {
 		BeforeEach(func() {
 			_, err = service.CreateMusicSet(apimodel.CreateSet{
 				Title: "",
-			}, nil)
+			}, nil, nil)
 		})
 
 		It("should return an error", func() {
@@ -368,7 +570,7 @@ var _ = Describe("DbDataService CRUD", func() {
 				Title:       "title",
 				Description: "desc",
 				Creator:     "creator",
-			}, nil)
+			}, nil, nil)
 		})
 
 		It("should succeed", func() {
@@ -386,7 +588,7 @@ var _ = Describe("DbDataService CRUD", func() {
 		When("getting it again from service", func() {
 			var returnedSet *apimodel.MusicSet
 			BeforeEach(func() {
-				returnedSet, err = service.GetMusicSet(musicSet.Id)
+				returnedSet, err = service.GetMusicSet(musicSet.Id, nil)
 			})
 
 			It("should return the same musicSet", func() {
@@ -403,7 +605,7 @@ var _ = Describe("DbDataService CRUD", func() {
 					Creator:     "new creator",
 				}
 				validator.EXPECT().ValidateUpdateSet(update).Return(nil)
-				musicSet, err = service.UpdateMusicSet(musicSet.Id, update)
+				musicSet, err = service.UpdateMusicSet(musicSet.Id, update, nil)
 			})
 
 			It("should succeed", func() {
@@ -419,7 +621,7 @@ var _ = Describe("DbDataService CRUD", func() {
 
 			When("retrieving that updated set", func() {
 				BeforeEach(func() {
-					musicSet, err = service.GetMusicSet(musicSet.Id)
+					musicSet, err = service.GetMusicSet(musicSet.Id, nil)
 				})
 
 				It("should return the same updated tune", func() {
@@ -444,7 +646,7 @@ var _ = Describe("DbDataService CRUD", func() {
 				}
 				validator.EXPECT().ValidateUpdateSet(update).
 					Return(fmt.Errorf("missing title"))
-				musicSet, err = service.UpdateMusicSet(musicSet.Id, update)
+				musicSet, err = service.UpdateMusicSet(musicSet.Id, update, nil)
 			})
 
 			It("should fail", func() {
@@ -460,14 +662,14 @@ var _ = Describe("DbDataService CRUD", func() {
 			BeforeEach(func() {
 				tune1, err = service.CreateTune(apimodel.CreateTune{
 					Title: "tune1",
-				}, nil)
+				}, nil, nil)
 				Expect(err).NotTo(HaveOccurred())
 				tune2, err = service.CreateTune(apimodel.CreateTune{
 					Title: "tune2",
-				}, nil)
+				}, nil, nil)
 				Expect(err).NotTo(HaveOccurred())
 				tuneIDs = []uuid.UUID{tune1.Id, tune2.Id}
-				_, err = service.AssignTunesToMusicSet(musicSet.Id, tuneIDs)
+				_, err = service.AssignTunesToMusicSet(musicSet.Id, tuneIDs, nil)
 			})
 
 			It("should add those tunes", func() {
@@ -476,7 +678,7 @@ var _ = Describe("DbDataService CRUD", func() {
 
 			When("retrieving the music set with tunes", func() {
 				BeforeEach(func() {
-					apiMusicSet, err = service.GetMusicSet(musicSet.Id)
+					apiMusicSet, err = service.GetMusicSet(musicSet.Id, nil)
 				})
 
 				It("should contain those tunes", func() {
@@ -501,7 +703,7 @@ var _ = Describe("DbDataService CRUD", func() {
 						Tunes:       reverseIDs,
 					}
 					validator.EXPECT().ValidateUpdateSet(upd).Return(nil)
-					apiMusicSet, err = service.UpdateMusicSet(musicSet.Id, upd)
+					apiMusicSet, err = service.UpdateMusicSet(musicSet.Id, upd, nil)
 				})
 
 				It("should succeed", func() {
@@ -517,7 +719,7 @@ var _ = Describe("DbDataService CRUD", func() {
 
 		When("deleting that music set", func() {
 			BeforeEach(func() {
-				err = service.DeleteMusicSet(musicSet.Id)
+				err = service.DeleteMusicSet(musicSet.Id, nil)
 			})
 
 			It("should have removed that music set", func() {
@@ -526,7 +728,7 @@ var _ = Describe("DbDataService CRUD", func() {
 
 			When("retrieving that music set again", func() {
 				BeforeEach(func() {
-					musicSet, err = service.GetMusicSet(musicSet.Id)
+					musicSet, err = service.GetMusicSet(musicSet.Id, nil)
 				})
 
 				It("should return a not found error", func() {
@@ -544,14 +746,14 @@ var _ = Describe("DbDataService CRUD", func() {
 		BeforeEach(func() {
 			set1, err = service.CreateMusicSet(apimodel.CreateSet{
 				Title: "set1",
-			}, nil)
+			}, nil, nil)
 			set2, err = service.CreateMusicSet(apimodel.CreateSet{
 				Title: "set2",
-			}, nil)
+			}, nil, nil)
 		})
 
 		It("should return both sets", func() {
-			sets, err = service.MusicSets()
+			sets, err = service.MusicSets(nil)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(sets).To(HaveLen(2))
 			Expect(sets[0].Id).ShouldNot(Equal(uuid.Nil))
@@ -0,0 +1,224 @@
+package database
+
+import (
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/messages"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/config"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("DbDataService Import History", func() {
+	var err error
+	var service *Service
+	var gormDb *gorm.DB
+	var job *model.ImportJob
+	var jobTunes []*apimodel.ImportTune
+	var legacyTunes []*apimodel.ImportTune
+	var legacyFile *model.ImportFile
+
+	importFile := func(name string, jobID *uuid.UUID, tuneTitles ...string) []*apimodel.ImportTune {
+		fileInfo, err := common.NewImportFileInfo(name, fileformat.Format_BWW, []byte(name))
+		Expect(err).ShouldNot(HaveOccurred())
+		fileInfo.ImportJobID = jobID
+
+		var parsedTunes []*messages.ParsedTune
+		for _, t := range tuneTitles {
+			parsedTunes = append(parsedTunes, model.TestParsedTune(t))
+		}
+		tunes, _, err := service.ImportTunes(parsedTunes, fileInfo)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		return tunes
+	}
+
+	BeforeEach(func() {
+		cfg, err := config.InitTest()
+		Expect(err).ShouldNot(HaveOccurred())
+		gormDb, err = GetInitTestDB(cfg.DbConfig(), "testdb")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		service = &Service{db: gormDb}
+
+		legacyTunes = importFile("legacy.bww", nil, "legacy tune")
+		legacyHash, err := common.HashFromData([]byte("legacy.bww"))
+		Expect(err).ShouldNot(HaveOccurred())
+		legacyFile, err = service.GetImportFileByHash(legacyHash)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		job = &model.ImportJob{
+			Status:   model.ImportJobStatusRunning,
+			FileName: "tunes.zip",
+			Data:     []byte("zip data"),
+		}
+		Expect(service.CreateImportJob(job)).To(Succeed())
+		jobTunes = importFile("folder/job.bww", &job.ID, "job tune 1", "job tune 2")
+	})
+
+	AfterEach(func() {
+		db, err := gormDb.DB()
+		Expect(err).ShouldNot(HaveOccurred())
+		err = db.Close()
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	When("listing the imports", func() {
+		var jobs []*model.ImportJob
+		var pagination *apimodel.Pagination
+
+		BeforeEach(func() {
+			jobs, pagination, err = service.ImportJobs(common.ImportListOptions{}, nil)
+		})
+
+		It("should return the job and the file imported without a job", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(jobs).To(HaveLen(2))
+			Expect(jobs[0].ID).To(Equal(job.ID))
+			Expect(jobs[0].Data).To(BeEmpty())
+			Expect(jobs[1].ID).To(Equal(legacyFile.ID))
+			Expect(jobs[1].FileName).To(Equal("legacy.bww"))
+			Expect(jobs[1].Status).To(Equal(model.ImportJobStatusCompleted))
+			Expect(pagination.TotalCount).To(Equal(int64(2)))
+		})
+	})
+
+	When("getting the file imported without a job", func() {
+		BeforeEach(func() {
+			job, err = service.GetImportJob(legacyFile.ID, nil)
+		})
+
+		It("should return it as completed job with the imported tunes", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(job.Data).To(Equal([]byte("legacy.bww")))
+			Expect(job.FilesDone).To(Equal(uint(1)))
+			Expect(string(job.Report)).To(ContainSubstring(legacyTunes[0].Id.String()))
+		})
+	})
+
+	When("deleting a job that is still running", func() {
+		BeforeEach(func() {
+			err = service.DeleteImport(job.ID, nil)
+		})
+
+		It("should return an invalid argument error", func() {
+			Expect(err).To(MatchError(common.ErrInvalidArgument))
+		})
+	})
+
+	Context("the job is completed and one of its tunes is in another set", func() {
+		var otherSet *apimodel.MusicSet
+
+		BeforeEach(func() {
+			job.Status = model.ImportJobStatusCompleted
+			Expect(service.UpdateImportJob(job)).To(Succeed())
+
+			otherSet, err = service.CreateMusicSet(apimodel.CreateSet{
+				Title: "other set",
+				Tunes: []uuid.UUID{jobTunes[0].Id},
+			}, nil, nil)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		When("deleting the job", func() {
+			BeforeEach(func() {
+				err = service.DeleteImport(job.ID, nil)
+			})
+
+			It("should delete the job and the tunes that are not shared", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				_, err = service.GetImportJob(job.ID, nil)
+				Expect(err).To(MatchError(common.ErrNotFound))
+				_, err = service.GetTune(jobTunes[1].Id)
+				Expect(err).To(MatchError(common.ErrNotFound))
+			})
+
+			It("should keep the tune of the other set", func() {
+				_, err = service.GetTune(jobTunes[0].Id)
+				Expect(err).ShouldNot(HaveOccurred())
+				_, err = service.GetMusicSet(otherSet.Id, nil)
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+
+			It("should keep the other import", func() {
+				_, err = service.GetTune(legacyTunes[0].Id)
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+	})
+
+	When("deleting the file imported without a job", func() {
+		BeforeEach(func() {
+			err = service.DeleteImport(legacyFile.ID, nil)
+		})
+
+		It("should delete the file and its tunes", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = service.GetImportJob(legacyFile.ID, nil)
+			Expect(err).To(MatchError(common.ErrNotFound))
+			_, err = service.GetTune(legacyTunes[0].Id)
+			Expect(err).To(MatchError(common.ErrNotFound))
+		})
+	})
+
+	When("deleting an import that doesn't exist", func() {
+		BeforeEach(func() {
+			err = service.DeleteImport(uuid.New(), nil)
+		})
+
+		It("should return a not found error", func() {
+			Expect(err).To(MatchError(common.ErrNotFound))
+		})
+	})
+
+	Context("having an import of a user", func() {
+		var owner, otherUser uuid.UUID
+		var ownJob *model.ImportJob
+
+		BeforeEach(func() {
+			owner = uuid.New()
+			otherUser = uuid.New()
+			ownJob = &model.ImportJob{
+				Status:   model.ImportJobStatusCompleted,
+				FileName: "private.bww",
+				Data:     []byte("private data"),
+				OwnerID:  &owner,
+			}
+			Expect(service.CreateImportJob(ownJob)).To(Succeed())
+		})
+
+		It("should list the import only for its owner", func() {
+			jobs, _, err := service.ImportJobs(common.ImportListOptions{}, &owner)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(jobs).To(HaveLen(3))
+			Expect(jobs[0].ID).To(Equal(ownJob.ID))
+
+			jobs, pagination, err := service.ImportJobs(common.ImportListOptions{}, &otherUser)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(jobs).To(HaveLen(2))
+			Expect(pagination.TotalCount).To(Equal(int64(2)))
+		})
+
+		It("should return the import with its data only to its owner", func() {
+			job, err = service.GetImportJob(ownJob.ID, &owner)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(job.Data).To(Equal([]byte("private data")))
+
+			_, err = service.GetImportJob(ownJob.ID, &otherUser)
+			Expect(err).To(MatchError(common.ErrNotFound))
+			_, err = service.GetImportJob(ownJob.ID, nil)
+			Expect(err).To(MatchError(common.ErrNotFound))
+		})
+
+		It("should not delete the import for another user", func() {
+			err = service.DeleteImport(ownJob.ID, &otherUser)
+			Expect(err).To(MatchError(common.ErrNotFound))
+			_, err = service.GetImportJob(ownJob.ID, &owner)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+	})
+})
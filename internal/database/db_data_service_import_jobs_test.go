@@ -0,0 +1,132 @@
+package database
+
+import (
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/config"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("DbDataService Import Jobs", func() {
+	var err error
+	var cfg *config.Config
+	var service *Service
+	var gormDb *gorm.DB
+	var job *model.ImportJob
+
+	BeforeEach(func() {
+		cfg, err = config.InitTest()
+		Expect(err).ShouldNot(HaveOccurred())
+		gormDb, err = GetInitTestDB(cfg.DbConfig(), "testdb")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		service = &Service{
+			db:        gormDb,
+			validator: mocks.NewAPIModelValidator(GinkgoT()),
+		}
+	})
+
+	AfterEach(func() {
+		db, err := gormDb.DB()
+		Expect(err).ShouldNot(HaveOccurred())
+		err = db.Close()
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	When("there is no queued job", func() {
+		BeforeEach(func() {
+			job, err = service.ClaimNextImportJob()
+		})
+
+		It("should return a not found error", func() {
+			Expect(err).To(MatchError(common.ErrNotFound))
+			Expect(job).To(BeNil())
+		})
+	})
+
+	When("getting a job that doesn't exist", func() {
+		BeforeEach(func() {
+			job, err = service.GetImportJob(uuid.New(), nil)
+		})
+
+		It("should return a not found error", func() {
+			Expect(err).To(MatchError(common.ErrNotFound))
+		})
+	})
+
+	Context("having created a job", func() {
+		BeforeEach(func() {
+			job = &model.ImportJob{
+				FileName:   "test.bww",
+				FileFormat: fileformat.Format_BWW,
+				Data:       []byte("test file content"),
+			}
+			Expect(service.CreateImportJob(job)).To(Succeed())
+		})
+
+		It("should be queued", func() {
+			stored, err := service.GetImportJob(job.ID, nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(stored.Status).To(Equal(model.ImportJobStatusQueued))
+			Expect(stored.Data).To(Equal([]byte("test file content")))
+		})
+
+		When("claiming the next job", func() {
+			var claimed *model.ImportJob
+
+			BeforeEach(func() {
+				claimed, err = service.ClaimNextImportJob()
+			})
+
+			It("should return the job as running job", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(claimed.ID).To(Equal(job.ID))
+				Expect(claimed.Status).To(Equal(model.ImportJobStatusRunning))
+				Expect(claimed.StartedAt).NotTo(BeNil())
+			})
+
+			It("should not be claimed a second time", func() {
+				_, err = service.ClaimNextImportJob()
+				Expect(err).To(MatchError(common.ErrNotFound))
+			})
+
+			When("requeuing the running jobs", func() {
+				var count int64
+
+				BeforeEach(func() {
+					count, err = service.RequeueRunningImportJobs()
+				})
+
+				It("should put the job back into the queue", func() {
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(count).To(BeEquivalentTo(1))
+					stored, err := service.GetImportJob(job.ID, nil)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(stored.Status).To(Equal(model.ImportJobStatusQueued))
+					Expect(stored.StartedAt).To(BeNil())
+				})
+			})
+
+			When("updating the claimed job", func() {
+				BeforeEach(func() {
+					claimed.Status = model.ImportJobStatusCompleted
+					claimed.FilesTotal = 1
+					claimed.FilesDone = 1
+					Expect(service.UpdateImportJob(claimed)).To(Succeed())
+				})
+
+				It("should store the changes", func() {
+					stored, err := service.GetImportJob(job.ID, nil)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(stored.Finished()).To(BeTrue())
+					Expect(stored.FilesDone).To(BeEquivalentTo(1))
+				})
+			})
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/helper"
@@ -29,7 +30,7 @@ var _ = Describe("DbDataService Import", func() {
 	BeforeEach(func() {
 		cfg, err := config.InitTest()
 		Expect(err).ShouldNot(HaveOccurred())
-		gormDb, err = GetInitTestPostgreSQLDB(cfg.DbConfig(), "testdb")
+		gormDb, err = GetInitTestDB(cfg.DbConfig(), "testdb")
 		Expect(err).ShouldNot(HaveOccurred())
 
 		service = &Service{db: gormDb}
@@ -216,7 +217,7 @@ var _ = Describe("DbDataService Import", func() {
 				When("I retrieve the set", func() {
 					BeforeEach(func() {
 						setID := returnSet.Id
-						musicSet, err = service.GetMusicSet(setID)
+						musicSet, err = service.GetMusicSet(setID, nil)
 					})
 
 					It("should successfully got that set", func() {
@@ -256,7 +257,7 @@ var _ = Describe("DbDataService Import", func() {
 				When("I retrieve the set", func() {
 					BeforeEach(func() {
 						setID := returnSet.Id
-						musicSet, err = service.GetMusicSet(setID)
+						musicSet, err = service.GetMusicSet(setID, nil)
 					})
 
 					It("should successfully got that set", func() {
@@ -271,4 +272,196 @@ var _ = Describe("DbDataService Import", func() {
 			})
 		})
 	})
+
+	Context("having imported a file with two tunes", func() {
+		var importedTunes []*apimodel.ImportTune
+		var importedSet *apimodel.BasicMusicSet
+		var firstHash string
+
+		BeforeEach(func() {
+			fileInfo, err = common.NewImportFileInfo("testfile.bww", fileformat.Format_BWW, []byte(`BagpipeReader:1.0`))
+			Expect(err).ShouldNot(HaveOccurred())
+			importedTunes, importedSet, err = service.ImportTunes([]*messages.ParsedTune{
+				model.TestParsedTune("tune 1"),
+				model.TestParsedTune("tune 2"),
+			}, fileInfo)
+			Expect(err).ShouldNot(HaveOccurred())
+			firstHash = fileInfo.Hash
+		})
+
+		When("reimporting a changed version of the file", func() {
+			BeforeEach(func() {
+				fileInfo, err = common.NewImportFileInfo("testfile.bww", fileformat.Format_BWW, []byte(`BagpipeReader:1.1`))
+				Expect(err).ShouldNot(HaveOccurred())
+				fileInfo.Reimport = true
+
+				changedTune := model.TestParsedTune("tune 2")
+				changedTune.Tune.Composer = "someone else"
+				returnTunes, returnSet, err = service.ImportTunes([]*messages.ParsedTune{
+					model.TestParsedTune("tune 1"),
+					changedTune,
+				}, fileInfo)
+			})
+
+			It("should keep the ids of the tunes and report what changed", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(returnTunes).To(HaveLen(2))
+				Expect(returnTunes[0].Id).To(Equal(importedTunes[0].Id))
+				Expect(returnTunes[0].Action).To(Equal(model.ReimportActionUnchanged))
+				Expect(returnTunes[1].Id).To(Equal(importedTunes[1].Id))
+				Expect(returnTunes[1].Action).To(Equal(model.ReimportActionUpdated))
+				Expect(returnTunes[1].Changes).To(Equal([]string{"composer", "music_model"}))
+			})
+
+			It("should have updated the tune", func() {
+				t, err := service.GetTune(importedTunes[1].Id)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(t.Composer).To(Equal("someone else"))
+			})
+
+			It("should keep the set of the tunes", func() {
+				Expect(returnSet.Id).To(Equal(importedSet.Id))
+			})
+
+			When("deleting the first import", func() {
+				BeforeEach(func() {
+					Expect(err).ShouldNot(HaveOccurred())
+					first, err := service.GetImportFileByHash(firstHash)
+					Expect(err).ShouldNot(HaveOccurred())
+					err = service.DeleteImport(first.ID, nil)
+					Expect(err).ShouldNot(HaveOccurred())
+				})
+
+				It("should keep the tunes and the set of the reimport", func() {
+					for _, t := range returnTunes {
+						_, err = service.GetTune(t.Id)
+						Expect(err).ShouldNot(HaveOccurred())
+					}
+					_, err = service.GetMusicSet(returnSet.Id, nil)
+					Expect(err).ShouldNot(HaveOccurred())
+				})
+
+				It("should still report the tunes for the reimport", func() {
+					reimport, err := service.GetImportFileByHash(fileInfo.Hash)
+					Expect(err).ShouldNot(HaveOccurred())
+					report, err := service.importFileReport(reimport)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(report.Files[0].Tunes).To(HaveLen(2))
+					Expect(report.Files[0].Set.Id).To(Equal(returnSet.Id))
+				})
+
+				When("deleting the reimport as well", func() {
+					BeforeEach(func() {
+						reimport, err := service.GetImportFileByHash(fileInfo.Hash)
+						Expect(err).ShouldNot(HaveOccurred())
+						err = service.DeleteImport(reimport.ID, nil)
+						Expect(err).ShouldNot(HaveOccurred())
+					})
+
+					It("should delete the tunes and the set", func() {
+						for _, t := range returnTunes {
+							_, err = service.GetTune(t.Id)
+							Expect(err).To(MatchError(common.ErrNotFound))
+						}
+						_, err = service.GetMusicSet(returnSet.Id, nil)
+						Expect(err).To(MatchError(common.ErrNotFound))
+					})
+				})
+			})
+		})
+
+		When("reimporting a changed version of the file after a tune was exported", func() {
+			BeforeEach(func() {
+				err = service.AddExportedFileToTune(importedTunes[1].Id, &model.TuneFile{
+					Format:         fileformat.Format_MUSIC_XML,
+					Data:           []byte("<score-partwise/>"),
+					SingleTuneData: true,
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				fileInfo, err = common.NewImportFileInfo("testfile.bww", fileformat.Format_BWW, []byte(`BagpipeReader:1.1`))
+				Expect(err).ShouldNot(HaveOccurred())
+				fileInfo.Reimport = true
+
+				changedTune := model.TestParsedTune("tune 2")
+				changedTune.Tune.Composer = "someone else"
+				returnTunes, _, err = service.ImportTunes([]*messages.ParsedTune{
+					changedTune,
+				}, fileInfo)
+			})
+
+			It("should delete the exported file of the former music", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				_, err = service.GetTuneFile(importedTunes[1].Id, fileformat.Format_MUSIC_XML)
+				Expect(err).To(MatchError(common.ErrNotFound))
+			})
+		})
+
+		When("reimporting fails after the first tune was updated", func() {
+			BeforeEach(func() {
+				fileInfo, err = common.NewImportFileInfo("testfile.bww", fileformat.Format_BWW, []byte(`BagpipeReader:1.1`))
+				Expect(err).ShouldNot(HaveOccurred())
+				fileInfo.Reimport = true
+				fileInfo.TuneMapping = map[string]uuid.UUID{"tune 3": uuid.New()}
+
+				changedTune := model.TestParsedTune("tune 1")
+				changedTune.Tune.Composer = "someone else"
+				returnTunes, _, err = service.ImportTunes([]*messages.ParsedTune{
+					changedTune,
+					model.TestParsedTune("tune 3"),
+				}, fileInfo)
+			})
+
+			It("should neither update the tune nor store the file", func() {
+				Expect(err).To(MatchError(common.ErrInvalidArgument))
+				t, err := service.GetTune(importedTunes[0].Id)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(t.Composer).NotTo(Equal("someone else"))
+
+				imported, err := service.hasImportFile(fileInfo)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(imported).To(BeFalse())
+			})
+		})
+
+		When("reimporting the file with another spelling of the arranger", func() {
+			BeforeEach(func() {
+				fileInfo, err = common.NewImportFileInfo("testfile.bww", fileformat.Format_BWW, []byte(`BagpipeReader:1.1`))
+				Expect(err).ShouldNot(HaveOccurred())
+				fileInfo.Reimport = true
+
+				changedTune := model.TestParsedTune("tune 1")
+				changedTune.Tune.Arranger = "Someone Arranged It."
+				returnTunes, _, err = service.ImportTunes([]*messages.ParsedTune{
+					changedTune,
+				}, fileInfo)
+			})
+
+			It("should not report the arranger as changed", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(returnTunes[0].Arranger).To(Equal("someone arranged it"))
+				Expect(returnTunes[0].Changes).ToNot(ContainElement("arranger"))
+			})
+		})
+
+		When("reimporting the file with a tune mapped to another title", func() {
+			BeforeEach(func() {
+				fileInfo, err = common.NewImportFileInfo("renamed.bww", fileformat.Format_BWW, []byte(`BagpipeReader:1.1`))
+				Expect(err).ShouldNot(HaveOccurred())
+				fileInfo.Reimport = true
+				fileInfo.TuneMapping = map[string]uuid.UUID{"tune 1 renamed": importedTunes[0].Id}
+
+				returnTunes, _, err = service.ImportTunes([]*messages.ParsedTune{
+					model.TestParsedTune("tune 1 renamed"),
+				}, fileInfo)
+			})
+
+			It("should update the title of the mapped tune", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(returnTunes[0].Id).To(Equal(importedTunes[0].Id))
+				Expect(returnTunes[0].Title).To(Equal("tune 1 renamed"))
+				Expect(returnTunes[0].Changes).To(ContainElement("title"))
+			})
+		})
+	})
 })
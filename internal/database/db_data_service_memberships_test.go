@@ -0,0 +1,145 @@
+package database
+
+import (
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/config"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("DbDataService Memberships", func() {
+	var err error
+	var service *Service
+	var gormDb *gorm.DB
+	var pipeMajor *model.User
+	var learner *model.User
+	var membership *model.Membership
+
+	BeforeEach(func() {
+		cfg, err := config.InitTest()
+		Expect(err).ShouldNot(HaveOccurred())
+		gormDb, err = GetInitTestDB(cfg.DbConfig(), "testdb")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		service = &Service{
+			db:        gormDb,
+			validator: mocks.NewAPIModelValidator(GinkgoT()),
+		}
+
+		pipeMajor, err = service.CreateUser("pipe major")
+		Expect(err).ShouldNot(HaveOccurred())
+		learner, err = service.CreateUser("learner")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		_, err = service.SetMembership(pipeMajor.ID, common.RoleAdmin)
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		db, err := gormDb.DB()
+		Expect(err).ShouldNot(HaveOccurred())
+		err = db.Close()
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should not find a membership of a user without one", func() {
+		_, err = service.GetMembership(learner.ID)
+		Expect(err).To(MatchError(common.ErrNotFound))
+	})
+
+	When("giving a user a role", func() {
+		BeforeEach(func() {
+			membership, err = service.SetMembership(learner.ID, common.RoleViewer)
+		})
+
+		It("should have created the membership", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(membership.Role).To(Equal(common.RoleViewer))
+			Expect(membership.User.Name).To(Equal("learner"))
+
+			stored, err := service.GetMembership(learner.ID)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(stored.ID).To(Equal(membership.ID))
+			Expect(stored.User.Name).To(Equal("learner"))
+		})
+
+		It("should list the memberships with their users", func() {
+			memberships, err := service.Memberships()
+			Expect(err).ShouldNot(HaveOccurred())
+			var names []string
+			for _, m := range memberships {
+				names = append(names, m.User.Name)
+			}
+			Expect(names).To(ConsistOf("pipe major", "learner"))
+		})
+
+		When("changing the role", func() {
+			BeforeEach(func() {
+				_, err = service.SetMembership(learner.ID, common.RoleEditor)
+			})
+
+			It("should have updated the membership", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				stored, err := service.GetMembership(learner.ID)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(stored.ID).To(Equal(membership.ID))
+				Expect(stored.Role).To(Equal(common.RoleEditor))
+			})
+		})
+
+		When("deleting the membership", func() {
+			BeforeEach(func() {
+				err = service.DeleteMembership(learner.ID)
+			})
+
+			It("should have deleted it", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				_, err = service.GetMembership(learner.ID)
+				Expect(err).To(MatchError(common.ErrNotFound))
+			})
+		})
+	})
+
+	It("should not give a user an unknown role", func() {
+		_, err = service.SetMembership(learner.ID, "drum major")
+		Expect(err).To(MatchError(common.ErrInvalidArgument))
+	})
+
+	It("should not give a role to a user that doesn't exist", func() {
+		_, err = service.SetMembership(uuid.New(), common.RoleViewer)
+		Expect(err).To(MatchError(common.ErrNotFound))
+	})
+
+	It("should not delete a membership that doesn't exist", func() {
+		err = service.DeleteMembership(learner.ID)
+		Expect(err).To(MatchError(common.ErrNotFound))
+	})
+
+	Context("the last admin", func() {
+		It("should not lose the admin role", func() {
+			_, err = service.SetMembership(pipeMajor.ID, common.RoleEditor)
+			Expect(err).To(MatchError(common.ErrInvalidArgument))
+		})
+
+		It("should not lose the membership", func() {
+			err = service.DeleteMembership(pipeMajor.ID)
+			Expect(err).To(MatchError(common.ErrInvalidArgument))
+		})
+
+		When("there is another admin", func() {
+			BeforeEach(func() {
+				_, err = service.SetMembership(learner.ID, common.RoleAdmin)
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+
+			It("should be possible to change the role", func() {
+				_, err = service.SetMembership(pipeMajor.ID, common.RoleEditor)
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+	})
+})
@@ -0,0 +1,198 @@
+package database
+
+import (
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/measure"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/messages"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/config"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("DbDataService Parser Messages", func() {
+	var err error
+	var service *Service
+	var gormDb *gorm.DB
+	var tunes []*apimodel.ImportTune
+	var reviewed = true
+	var notReviewed = false
+
+	// tuneWithError returns a parsed tune with an error about its
+	// first measure in addition to the warning of the test tune.
+	tuneWithError := func(title string) *messages.ParsedTune {
+		pTune := model.TestParsedTune(title)
+		m := pTune.Tune.Measures[0]
+		m.ParserMessages = append(m.ParserMessages, &measure.ParserMessage{
+			Symbol:   "LA_16",
+			Severity: measure.Severity_Error,
+			Text:     "unknown note",
+		})
+		return pTune
+	}
+
+	importTunes := func(data string, reimport bool, pTunes ...*messages.ParsedTune) []*apimodel.ImportTune {
+		fileInfo, err := common.NewImportFileInfo("testfile.bww", fileformat.Format_BWW, []byte(data))
+		Expect(err).ShouldNot(HaveOccurred())
+		fileInfo.Reimport = reimport
+		imported, _, err := service.ImportTunes(pTunes, fileInfo)
+		Expect(err).ShouldNot(HaveOccurred())
+		return imported
+	}
+
+	BeforeEach(func() {
+		cfg, err := config.InitTest()
+		Expect(err).ShouldNot(HaveOccurred())
+		gormDb, err = GetInitTestDB(cfg.DbConfig(), "testdb")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		service = &Service{
+			db:        gormDb,
+			validator: mocks.NewAPIModelValidator(GinkgoT()),
+		}
+
+		tunes = importTunes(`BagpipeReader:1.0`, false,
+			model.TestParsedTune("tune 1"),
+			tuneWithError("tune 2"),
+		)
+	})
+
+	AfterEach(func() {
+		db, err := gormDb.DB()
+		Expect(err).ShouldNot(HaveOccurred())
+		err = db.Close()
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should have stored the messages of the imported tunes", func() {
+		msgs, err := service.TuneMessages(tunes[0].Id, common.MessageListOptions{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(msgs).To(HaveLen(1))
+		Expect(msgs[0].Id).ToNot(Equal(uuid.Nil))
+		Expect(msgs[0]).To(Equal(apimodel.TuneMessage{
+			Id:        msgs[0].Id,
+			TuneId:    tunes[0].Id,
+			TuneTitle: "tune 1",
+			Measure:   1,
+			Severity:  "warning",
+			Symbol:    "^te",
+			Text:      "some warning",
+			Fix:       "skipsymbol",
+		}))
+	})
+
+	It("should filter the messages of a tune by their severity", func() {
+		msgs, err := service.TuneMessages(tunes[1].Id, common.MessageListOptions{Severity: "error"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(msgs).To(HaveLen(1))
+		Expect(msgs[0].Text).To(Equal("unknown note"))
+	})
+
+	It("should not list the messages of an unknown tune", func() {
+		_, err = service.TuneMessages(uuid.New(), common.MessageListOptions{})
+		Expect(err).To(MatchError(common.ErrNotFound))
+	})
+
+	It("should list the messages of all tunes by the title of their tune", func() {
+		list, err := service.Messages(common.MessageListOptions{PageOptions: common.PageOptions{PageSize: 2}})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(list.Pagination.TotalCount).To(Equal(int64(3)))
+		Expect(list.Pagination.TotalPages).To(Equal(int32(2)))
+		Expect(list.Messages).To(HaveLen(2))
+		Expect(list.Messages[0].TuneTitle).To(Equal("tune 1"))
+		Expect(list.Messages[1].TuneTitle).To(Equal("tune 2"))
+	})
+
+	It("should list the errors of all tunes", func() {
+		list, err := service.Messages(common.MessageListOptions{Severity: "error"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(list.Messages).To(HaveLen(1))
+		Expect(list.Messages[0].TuneId).To(Equal(tunes[1].Id))
+	})
+
+	When("a message is marked as reviewed", func() {
+		var message *apimodel.TuneMessage
+
+		BeforeEach(func() {
+			list, err := service.Messages(common.MessageListOptions{Severity: "error"})
+			Expect(err).ShouldNot(HaveOccurred())
+			message, err = service.UpdateMessage(list.Messages[0].Id,
+				apimodel.UpdateTuneMessage{Reviewed: &reviewed}, "piper")
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should be reviewed by the author", func() {
+			Expect(message.Reviewed).To(BeTrue())
+			Expect(message.ReviewedBy).To(Equal("piper"))
+			Expect(message.TuneTitle).To(Equal("tune 2"))
+		})
+
+		It("should not be listed with the messages to review", func() {
+			list, err := service.Messages(common.MessageListOptions{Reviewed: &notReviewed})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(list.Messages).To(HaveLen(2))
+			Expect(list.Messages).ToNot(ContainElement(HaveField("Id", message.Id)))
+		})
+
+		It("should stay reviewed if the tune is imported again with the same error", func() {
+			changed := tuneWithError("tune 2")
+			changed.Tune.Measures[0].ParserMessages = changed.Tune.Measures[0].ParserMessages[1:]
+			changed.Tune.Measures[0].Comments = []string{"changed comment"}
+			changed.Tune.Measures[0].ParserMessages = append(changed.Tune.Measures[0].ParserMessages,
+				&measure.ParserMessage{Symbol: "^3e", Severity: measure.Severity_Info, Text: "new info"})
+			importTunes(`BagpipeReader:1.1`, true, model.TestParsedTune("tune 1"), changed)
+
+			msgs, err := service.TuneMessages(tunes[1].Id, common.MessageListOptions{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(msgs).To(HaveLen(2))
+			Expect(msgs).To(ContainElement(HaveField("Id", message.Id)))
+			Expect(msgs).To(ContainElement(HaveField("Text", "new info")))
+			Expect(msgs).ToNot(ContainElement(HaveField("Text", "some warning")))
+			for _, m := range msgs {
+				Expect(m.Reviewed).To(Equal(m.Id == message.Id))
+			}
+		})
+
+		It("should be possible to mark it as not reviewed again", func() {
+			message, err = service.UpdateMessage(message.Id,
+				apimodel.UpdateTuneMessage{Reviewed: &notReviewed}, "piper")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(message.Reviewed).To(BeFalse())
+			Expect(message.ReviewedBy).To(BeEmpty())
+		})
+	})
+
+	It("should not update an unknown message", func() {
+		_, err = service.UpdateMessage(uuid.New(), apimodel.UpdateTuneMessage{Reviewed: &reviewed}, "piper")
+		Expect(err).To(MatchError(common.ErrNotFound))
+	})
+
+	It("should mark all messages of a tune as reviewed", func() {
+		msgs, err := service.UpdateTuneMessages(tunes[1].Id, apimodel.UpdateTuneMessage{Reviewed: &reviewed}, "piper")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(msgs).To(HaveLen(2))
+		Expect(msgs).To(HaveEach(HaveField("Reviewed", true)))
+
+		list, err := service.Messages(common.MessageListOptions{Reviewed: &notReviewed})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(list.Messages).To(HaveLen(1))
+		Expect(list.Messages[0].TuneId).To(Equal(tunes[0].Id))
+	})
+
+	It("should delete the messages with their tune", func() {
+		err = gormDb.Where("tune_id = ?", tunes[1].Id).Delete(&model.MusicSetTunes{}).Error
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(service.DeleteTune(tunes[1].Id)).To(Succeed())
+		list, err := service.Messages(common.MessageListOptions{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(list.Messages).To(HaveLen(1))
+		var cnt int64
+		Expect(gormDb.Model(&model.ParserMessage{}).Count(&cnt).Error).ShouldNot(HaveOccurred())
+		Expect(cnt).To(Equal(int64(1)))
+	})
+})
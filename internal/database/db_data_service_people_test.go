@@ -0,0 +1,220 @@
+package database
+
+import (
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/config"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("DbDataService People", func() {
+	var err error
+	var service *Service
+	var gormDb *gorm.DB
+	var mcLennan *apimodel.Person
+	var macLeod *apimodel.Person
+	var brown *apimodel.Tune
+
+	personNamed := func(name string) *apimodel.Person {
+		people, err := service.People()
+		Expect(err).ShouldNot(HaveOccurred())
+		for _, p := range people {
+			if p.Name == name {
+				return p
+			}
+		}
+		Fail("no person " + name)
+		return nil
+	}
+
+	BeforeEach(func() {
+		cfg, err := config.InitTest()
+		Expect(err).ShouldNot(HaveOccurred())
+		gormDb, err = GetInitTestDB(cfg.DbConfig(), "testdb")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		service = &Service{
+			db:        gormDb,
+			validator: mocks.NewAPIModelValidator(GinkgoT()),
+		}
+
+		_, err = service.CreateTune(apimodel.CreateTune{
+			Title:    "The Little Cascade",
+			Composer: "G.S. McLennan",
+		}, nil, nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		brown, err = service.CreateTune(apimodel.CreateTune{
+			Title:    "Brown Haired Maiden",
+			Composer: " P/M  George S. MacLennan",
+			Arranger: "Donald MacLeod",
+		}, nil, nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		mcLennan = personNamed("G.S. McLennan")
+		macLeod = personNamed("Donald MacLeod")
+	})
+
+	AfterEach(func() {
+		db, err := gormDb.DB()
+		Expect(err).ShouldNot(HaveOccurred())
+		err = db.Close()
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should link another spelling of a composer to the same person", func() {
+		Expect(brown.Composer).To(Equal("G.S. McLennan"))
+		Expect(brown.ComposerId).To(Equal(&mcLennan.Id))
+		Expect(brown.ArrangerId).To(Equal(&macLeod.Id))
+		Expect(service.People()).To(Equal([]*apimodel.Person{
+			{Id: macLeod.Id, Name: "Donald MacLeod"},
+			{Id: mcLennan.Id, Name: "G.S. McLennan", Aliases: []string{"P/M George S. MacLennan"}},
+		}))
+	})
+
+	It("should not link a tune to a person with only the same surname", func() {
+		tune, err := service.CreateTune(apimodel.CreateTune{
+			Title:    "Mrs John MacColl",
+			Composer: "John McLennan",
+		}, nil, nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(tune.ComposerId).ShouldNot(Equal(&mcLennan.Id))
+		Expect(service.People()).To(HaveLen(3))
+	})
+
+	It("should not find an unknown person", func() {
+		_, err = service.GetPerson(uuid.New())
+		Expect(err).To(MatchError(common.ErrNotFound))
+	})
+
+	It("should list the tunes a person composed or arranged", func() {
+		tunes, err := service.PersonTunes(mcLennan.Id, common.TuneListOptions{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(tunes.Pagination.TotalCount).To(BeEquivalentTo(2))
+
+		tunes, err = service.PersonTunes(macLeod.Id, common.TuneListOptions{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(tunes.Tunes).To(HaveLen(1))
+		Expect(tunes.Tunes[0].Id).To(Equal(brown.Id))
+	})
+
+	It("should not list the tunes of an unknown person", func() {
+		_, err = service.PersonTunes(uuid.New(), common.TuneListOptions{})
+		Expect(err).To(MatchError(common.ErrNotFound))
+	})
+
+	When("renaming a person", func() {
+		var renamed *apimodel.Person
+
+		BeforeEach(func() {
+			renamed, err = service.UpdatePerson(mcLennan.Id, apimodel.UpdatePerson{Name: "George S. McLennan"})
+		})
+
+		It("should have the old name as alias", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(renamed).To(Equal(&apimodel.Person{
+				Id:      mcLennan.Id,
+				Name:    "George S. McLennan",
+				Aliases: []string{"G.S. McLennan", "P/M George S. MacLennan"},
+			}))
+		})
+
+		It("should have renamed the composer of the tunes", func() {
+			tune, err := service.GetTune(brown.Id)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(tune.Composer).To(Equal("George S. McLennan"))
+		})
+
+		It("should have recorded a revision of the tunes with the new name", func() {
+			revisions, err := service.TuneRevisions(brown.Id)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(revisions).To(HaveLen(2))
+			Expect(revisions[0].Composer).To(Equal("G.S. McLennan"))
+			Expect(revisions[1].Composer).To(Equal("George S. McLennan"))
+		})
+	})
+
+	It("should not rename a person to an alias of another person", func() {
+		_, err = service.UpdatePerson(macLeod.Id, apimodel.UpdatePerson{Name: "p/m george s. maclennan"})
+		Expect(err).To(MatchError(common.ErrAlreadyExists))
+	})
+
+	When("merging a person into another one", func() {
+		var merged *apimodel.Person
+
+		BeforeEach(func() {
+			merged, err = service.MergePeople(mcLennan.Id, apimodel.MergePeople{
+				PersonIds: []uuid.UUID{macLeod.Id},
+			})
+		})
+
+		It("should have moved the tunes and names to the person", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(merged.Aliases).To(Equal([]string{"Donald MacLeod", "P/M George S. MacLennan"}))
+
+			tune, err := service.GetTune(brown.Id)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(tune.Arranger).To(Equal("G.S. McLennan"))
+			Expect(tune.ArrangerId).To(Equal(&mcLennan.Id))
+		})
+
+		It("should have recorded a revision of the tunes of the merged person", func() {
+			revisions, err := service.TuneRevisions(brown.Id)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(revisions).To(HaveLen(2))
+			Expect(revisions[1].Arranger).To(Equal("G.S. McLennan"))
+		})
+
+		It("should have deleted the merged person", func() {
+			_, err = service.GetPerson(macLeod.Id)
+			Expect(err).To(MatchError(common.ErrNotFound))
+		})
+	})
+
+	It("should not merge a person into itself", func() {
+		_, err = service.MergePeople(mcLennan.Id, apimodel.MergePeople{
+			PersonIds: []uuid.UUID{mcLennan.Id},
+		})
+		Expect(err).To(MatchError(common.ErrInvalidArgument))
+	})
+
+	It("should not merge unknown people", func() {
+		_, err = service.MergePeople(mcLennan.Id, apimodel.MergePeople{
+			PersonIds: []uuid.UUID{macLeod.Id, uuid.New()},
+		})
+		Expect(err).To(MatchError(common.ErrNotFound))
+		Expect(service.GetPerson(macLeod.Id)).ToNot(BeNil())
+	})
+
+	When("setting the aliases of a person", func() {
+		var person *apimodel.Person
+
+		BeforeEach(func() {
+			person, err = service.SetPersonAliases(macLeod.Id, []string{
+				"Pipe Major Donald MacLeod", "Pipe  Major Donald MacLeod", "donald macleod", "",
+			})
+		})
+
+		It("should only have the distinct aliases", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(person.Aliases).To(Equal([]string{"Pipe Major Donald MacLeod"}))
+		})
+
+		It("should link tunes with the alias to the person", func() {
+			tune, err := service.CreateTune(apimodel.CreateTune{
+				Title:    "Donald MacLean's Farewell to Oban",
+				Composer: "PIPE MAJOR DONALD MACLEOD",
+			}, nil, nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(tune.Composer).To(Equal("Donald MacLeod"))
+			Expect(tune.ComposerId).To(Equal(&macLeod.Id))
+		})
+
+		It("should not give another person the alias", func() {
+			_, err = service.SetPersonAliases(mcLennan.Id, []string{"Pipe Major Donald MacLeod"})
+			Expect(err).To(MatchError(common.ErrAlreadyExists))
+		})
+	})
+})
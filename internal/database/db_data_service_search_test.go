@@ -0,0 +1,209 @@
+package database
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/config"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("DbDataService Search", func() {
+	var err error
+	var cfg *config.Config
+	var service *Service
+	var gormDb *gorm.DB
+	var result *apimodel.SearchResult
+	var laddie, scotland *apimodel.Tune
+	var set *apimodel.MusicSet
+
+	BeforeEach(func() {
+		cfg, err = config.InitTest()
+		Expect(err).ShouldNot(HaveOccurred())
+		gormDb, err = GetInitTestDB(cfg.DbConfig(), "testdb")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		service = &Service{
+			db:        gormDb,
+			validator: mocks.NewAPIModelValidator(GinkgoT()),
+		}
+
+		laddie, err = service.CreateTune(apimodel.CreateTune{
+			Title:    "Highland Laddie",
+			Type:     "March",
+			Composer: "Trad.",
+		}, nil, nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		scotland, err = service.CreateTune(apimodel.CreateTune{
+			Title:    "Scotland the Brave",
+			Type:     "March",
+			Arranger: "Donald MacLeod",
+		}, nil, nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		set, err = service.CreateMusicSet(apimodel.CreateSet{
+			Title:       "Competition MSR",
+			Description: "The set for the highland games",
+			Creator:     "Pipe Major",
+		}, nil, nil)
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		db, err := gormDb.DB()
+		Expect(err).ShouldNot(HaveOccurred())
+		err = db.Close()
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	When("searching for words of a tune and a set", func() {
+		BeforeEach(func() {
+			result, err = service.Search(common.SearchOptions{Query: "Highland"})
+		})
+
+		It("should return both with highlights", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(result.Query).To(Equal("Highland"))
+			Expect(result.Hits).To(HaveLen(2))
+			Expect(result.Hits).To(ContainElement(SatisfyAll(
+				HaveField("Kind", "tune"),
+				HaveField("Id", laddie.Id),
+				HaveField("Highlights", HaveKeyWithValue("title", "<mark>Highland</mark> Laddie")),
+			)))
+			Expect(result.Hits).To(ContainElement(SatisfyAll(
+				HaveField("Kind", "set"),
+				HaveField("Id", set.Id),
+				HaveField("Highlights", HaveKey("description")),
+			)))
+		})
+	})
+
+	When("searching only for tunes", func() {
+		BeforeEach(func() {
+			result, err = service.Search(common.SearchOptions{
+				Query: "highland",
+				Kind:  common.SearchKindTune,
+			})
+		})
+
+		It("should only return the tune", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(result.Hits).To(HaveLen(1))
+			Expect(result.Hits[0].Id).To(Equal(laddie.Id))
+		})
+	})
+
+	When("searching with the beginning of a word", func() {
+		BeforeEach(func() {
+			result, err = service.Search(common.SearchOptions{Query: "macle"})
+		})
+
+		It("should find the tune by its arranger", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(result.Hits).To(HaveLen(1))
+			Expect(result.Hits[0].Id).To(Equal(scotland.Id))
+			Expect(result.Hits[0].Highlights).To(HaveKey("arranger"))
+		})
+	})
+
+	When("searching for a misspelled title", func() {
+		BeforeEach(func() {
+			result, err = service.Search(common.SearchOptions{Query: "hieland laddie"})
+		})
+
+		It("should find the tune", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(result.Hits).ToNot(BeEmpty())
+			Expect(result.Hits[0].Id).To(Equal(laddie.Id))
+		})
+	})
+
+	When("searching for the tune type", func() {
+		BeforeEach(func() {
+			result, err = service.Search(common.SearchOptions{Query: "march", Limit: 1})
+		})
+
+		It("should only return as many hits as the limit", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(result.Hits).To(HaveLen(1))
+			Expect(result.Hits[0].Highlights).To(HaveKey("type"))
+		})
+	})
+
+	When("searching a title with markup", func() {
+		BeforeEach(func() {
+			_, err = service.CreateTune(apimodel.CreateTune{
+				Title: `<img src=x onerror="alert(1)"> Jig & Reel`,
+			}, nil, nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			result, err = service.Search(common.SearchOptions{Query: "reel"})
+		})
+
+		It("should return the highlights with the markup escaped", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(result.Hits).To(HaveLen(1))
+			Expect(result.Hits[0].Highlights).To(HaveKeyWithValue("title", SatisfyAll(
+				ContainSubstring("<mark>Reel</mark>"),
+				Not(ContainSubstring("<img")),
+			)))
+		})
+
+		It("should escape the text before highlighting the matches", func() {
+			Expect(highlightHTML(`<img src=x onerror="alert(1)"> Jig & ` + matchStart + "Reel" + matchStop)).
+				To(Equal("&lt;img src=x onerror=&#34;alert(1)&#34;&gt; Jig &amp; <mark>Reel</mark>"))
+		})
+	})
+
+	When("searching for something that doesn't exist", func() {
+		BeforeEach(func() {
+			result, err = service.Search(common.SearchOptions{Query: "hornpipe"})
+		})
+
+		It("should return an empty list of hits", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(result.Hits).ToNot(BeNil())
+			Expect(result.Hits).To(BeEmpty())
+		})
+	})
+
+	When("searching with a query without any words", func() {
+		BeforeEach(func() {
+			result, err = service.Search(common.SearchOptions{Query: "&& !"})
+		})
+
+		It("should return an invalid argument error", func() {
+			Expect(err).To(MatchError(common.ErrInvalidArgument))
+		})
+	})
+
+	// The full text search is SQL of PostgreSQL, so it is only tested when
+	// the tests run with DB_DRIVER=postgres as in the CI.
+	When("searching with the full text search of PostgreSQL", func() {
+		var memoryHits []apimodel.SearchHit
+
+		BeforeEach(func() {
+			if isSQLite(gormDb) {
+				Skip("the full text search only runs on PostgreSQL")
+			}
+
+			result, err = service.Search(common.SearchOptions{Query: "highl"})
+			Expect(err).ShouldNot(HaveOccurred())
+			tuneHits, err := service.searchTunesInMemory([]string{"highl"})
+			Expect(err).ShouldNot(HaveOccurred())
+			setHits, err := service.searchSetsInMemory([]string{"highl"}, nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			memoryHits = append(tuneHits, setHits...)
+		})
+
+		It("should find the same tunes and sets as the search in memory", func() {
+			Expect(result.Hits).To(HaveLen(len(memoryHits)))
+			for _, hit := range memoryHits {
+				Expect(result.Hits).To(ContainElement(SatisfyAll(
+					HaveField("Kind", hit.Kind),
+					HaveField("Id", hit.Id),
+				)))
+			}
+		})
+	})
+})
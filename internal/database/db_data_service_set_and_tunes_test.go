@@ -24,7 +24,7 @@ var _ = Describe("DbDataService", func() {
 	BeforeEach(func() {
 		cfg, err = config.InitTest()
 		Expect(err).ShouldNot(HaveOccurred())
-		gormDb, err = GetInitTestPostgreSQLDB(cfg.DbConfig(), "testdb")
+		gormDb, err = GetInitTestDB(cfg.DbConfig(), "testdb")
 		validator = mocks.NewAPIModelValidator(GinkgoT())
 
 		service = &Service{
@@ -42,11 +42,11 @@ var _ = Describe("DbDataService", func() {
 
 	Context("having some tunes created", func() {
 		BeforeEach(func() {
-			tune1, err = service.CreateTune(apimodel.CreateTune{Title: "tune 1"}, nil)
+			tune1, err = service.CreateTune(apimodel.CreateTune{Title: "tune 1"}, nil, nil)
 			Expect(err).ShouldNot(HaveOccurred())
-			tune2, err = service.CreateTune(apimodel.CreateTune{Title: "tune 2"}, nil)
+			tune2, err = service.CreateTune(apimodel.CreateTune{Title: "tune 2"}, nil, nil)
 			Expect(err).ShouldNot(HaveOccurred())
-			tune3, err = service.CreateTune(apimodel.CreateTune{Title: "tune 3"}, nil)
+			tune3, err = service.CreateTune(apimodel.CreateTune{Title: "tune 3"}, nil, nil)
 			Expect(err).ShouldNot(HaveOccurred())
 		})
 
@@ -70,6 +70,7 @@ var _ = Describe("DbDataService", func() {
 						Tunes: tuneIDs,
 					},
 					nil,
+					nil,
 				)
 				Expect(err).ShouldNot(HaveOccurred())
 			})
@@ -80,7 +81,7 @@ var _ = Describe("DbDataService", func() {
 
 			When("retrieving that music set from database", func() {
 				BeforeEach(func() {
-					musicSet, err = service.GetMusicSet(musicSet.Id)
+					musicSet, err = service.GetMusicSet(musicSet.Id, nil)
 				})
 
 				It("should have the tunes in correct order", func() {
@@ -96,7 +97,7 @@ var _ = Describe("DbDataService", func() {
 						expectedTuneOrder[0].Id,
 						expectedTuneOrder[1].Id,
 						expectedTuneOrder[2].Id,
-					})
+					}, nil)
 				})
 
 				It("should get the music set", func() {
@@ -125,6 +126,7 @@ var _ = Describe("DbDataService", func() {
 					musicSet, err = service.UpdateMusicSet(
 						musicSet.Id,
 						updateSet,
+						nil,
 					)
 					Expect(err).ShouldNot(HaveOccurred())
 				})
@@ -143,6 +145,7 @@ var _ = Describe("DbDataService", func() {
 				musicSet, err = service.CreateMusicSet(
 					apimodel.CreateSet{Title: "test music set"},
 					nil,
+					nil,
 				)
 				Expect(err).ShouldNot(HaveOccurred())
 			})
@@ -153,6 +156,7 @@ var _ = Describe("DbDataService", func() {
 					musicSetAfterAssignment, err = service.AssignTunesToMusicSet(
 						musicSet.Id,
 						[]uuid.UUID{tune2.Id, tune1.Id, tune3.Id},
+						nil,
 					)
 				})
 
@@ -171,7 +175,7 @@ var _ = Describe("DbDataService", func() {
 
 				When("getting the same set from service", func() {
 					BeforeEach(func() {
-						musicSetAfterAssignment, err = service.GetMusicSet(musicSetAfterAssignment.Id)
+						musicSetAfterAssignment, err = service.GetMusicSet(musicSetAfterAssignment.Id, nil)
 						Expect(err).ShouldNot(HaveOccurred())
 					})
 
@@ -187,7 +191,7 @@ var _ = Describe("DbDataService", func() {
 
 				When("getting the list of sets", func() {
 					BeforeEach(func() {
-						musicSets, err = service.MusicSets()
+						musicSets, err = service.MusicSets(nil)
 					})
 
 					It("should also have the tunes in the same order", func() {
@@ -213,7 +217,7 @@ var _ = Describe("DbDataService", func() {
 
 				When("deleting that set", func() {
 					BeforeEach(func() {
-						err = service.DeleteMusicSet(musicSetAfterAssignment.Id)
+						err = service.DeleteMusicSet(musicSetAfterAssignment.Id, nil)
 					})
 
 					It("should get deleted", func() {
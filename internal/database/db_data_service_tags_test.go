@@ -0,0 +1,245 @@
+package database
+
+import (
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/messages"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/config"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("DbDataService Tags", func() {
+	var err error
+	var service *Service
+	var gormDb *gorm.DB
+	var grade2 *apimodel.Tag
+	var repertoire *apimodel.Tag
+	var tune1 *apimodel.Tune
+	var tune2 *apimodel.Tune
+
+	BeforeEach(func() {
+		cfg, err := config.InitTest()
+		Expect(err).ShouldNot(HaveOccurred())
+		gormDb, err = GetInitTestDB(cfg.DbConfig(), "testdb")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		service = &Service{
+			db:        gormDb,
+			validator: mocks.NewAPIModelValidator(GinkgoT()),
+		}
+
+		grade2, err = service.CreateTag(apimodel.CreateTag{
+			Name:        "Grade 2",
+			Description: "tunes for grade 2 competitions",
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		repertoire, err = service.CreateTag(apimodel.CreateTag{Name: " repertoire "})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		tune1, err = service.CreateTune(apimodel.CreateTune{Title: "tune 1"}, nil, nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		tune2, err = service.CreateTune(apimodel.CreateTune{Title: "tune 2"}, nil, nil)
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		db, err := gormDb.DB()
+		Expect(err).ShouldNot(HaveOccurred())
+		err = db.Close()
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should list all tags ordered by name", func() {
+		tags, err := service.Tags()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(tags).To(Equal([]*apimodel.Tag{grade2, repertoire}))
+		Expect(repertoire.Name).To(Equal("repertoire"))
+	})
+
+	It("should get a tag", func() {
+		tag, err := service.GetTag(grade2.Id)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(tag).To(Equal(grade2))
+	})
+
+	It("should not find an unknown tag", func() {
+		_, err = service.GetTag(uuid.New())
+		Expect(err).To(MatchError(common.ErrNotFound))
+	})
+
+	It("should not create a tag with the name of another tag in another case", func() {
+		_, err = service.CreateTag(apimodel.CreateTag{Name: "GRADE 2"})
+		Expect(err).To(MatchError(common.ErrAlreadyExists))
+	})
+
+	It("should not create a tag without a name", func() {
+		_, err = service.CreateTag(apimodel.CreateTag{Name: "  "})
+		Expect(err).To(MatchError(common.ErrInvalidArgument))
+	})
+
+	When("updating a tag", func() {
+		var updated *apimodel.Tag
+
+		BeforeEach(func() {
+			updated, err = service.UpdateTag(grade2.Id, apimodel.UpdateTag{Name: "grade 2"})
+		})
+
+		It("should be allowed to change the case of its name", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(updated).To(Equal(&apimodel.Tag{Id: grade2.Id, Name: "grade 2"}))
+		})
+	})
+
+	It("should not rename a tag to the name of another tag", func() {
+		_, err = service.UpdateTag(grade2.Id, apimodel.UpdateTag{Name: "Repertoire"})
+		Expect(err).To(MatchError(common.ErrAlreadyExists))
+	})
+
+	When("assigning tags to a tune", func() {
+		var tune *apimodel.Tune
+
+		BeforeEach(func() {
+			tune, err = service.AssignTagsToTune(tune1.Id, []uuid.UUID{repertoire.Id, grade2.Id})
+		})
+
+		It("should return the tune with the tags ordered by name", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(tune.Tags).To(Equal([]string{"Grade 2", "repertoire"}))
+		})
+
+		It("should list the tune with the tags", func() {
+			tunes, err := service.Tunes(common.TuneListOptions{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(tunes.Tunes[0].Tags).To(Equal([]string{"Grade 2", "repertoire"}))
+			Expect(tunes.Tunes[1].Tags).To(BeNil())
+		})
+
+		When("assigning other tags to the tune", func() {
+			BeforeEach(func() {
+				tune, err = service.AssignTagsToTune(tune1.Id, []uuid.UUID{repertoire.Id})
+			})
+
+			It("should have replaced the tags", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(tune.Tags).To(Equal([]string{"repertoire"}))
+			})
+		})
+
+		When("the second tune only has one of the tags", func() {
+			BeforeEach(func() {
+				_, err = service.AssignTagsToTune(tune2.Id, []uuid.UUID{repertoire.Id})
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+
+			It("should filter the tunes by one tag", func() {
+				tunes, err := service.Tunes(common.TuneListOptions{Tags: "REPERTOIRE"})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(tunes.Pagination.TotalCount).To(BeEquivalentTo(2))
+			})
+
+			It("should only list the tunes with all tags", func() {
+				tunes, err := service.Tunes(common.TuneListOptions{Tags: "repertoire, grade 2"})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(tunes.Pagination.TotalCount).To(BeEquivalentTo(1))
+				Expect(tunes.Tunes[0].Id).To(Equal(tune1.Id))
+			})
+		})
+
+		When("deleting one of the tags", func() {
+			BeforeEach(func() {
+				Expect(service.DeleteTag(grade2.Id)).To(Succeed())
+			})
+
+			It("should have removed the tag from the tune", func() {
+				tune, err = service.GetTune(tune1.Id)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(tune.Tags).To(Equal([]string{"repertoire"}))
+			})
+		})
+	})
+
+	It("should not assign unknown tags to a tune", func() {
+		_, err = service.AssignTagsToTune(tune1.Id, []uuid.UUID{grade2.Id, uuid.New()})
+		Expect(err).To(MatchError(common.ErrNotFound))
+	})
+
+	It("should not assign tags to an unknown tune", func() {
+		_, err = service.AssignTagsToTune(uuid.New(), []uuid.UUID{grade2.Id})
+		Expect(err).To(MatchError(common.ErrNotFound))
+	})
+
+	When("assigning tags to a set", func() {
+		var set *apimodel.MusicSet
+
+		BeforeEach(func() {
+			set, err = service.CreateMusicSet(apimodel.CreateSet{
+				Title: "competition set",
+				Tunes: []uuid.UUID{tune1.Id, tune2.Id},
+			}, nil, nil)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			set, err = service.AssignTagsToMusicSet(set.Id, []uuid.UUID{grade2.Id}, nil)
+		})
+
+		It("should return the set with the tags", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(set.Tags).To(Equal([]string{"Grade 2"}))
+			Expect(set.Tunes).To(HaveLen(2))
+		})
+
+		It("should list the set with the tags", func() {
+			sets, err := service.MusicSets(nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(sets).To(HaveLen(1))
+			Expect(sets[0].Tags).To(Equal([]string{"Grade 2"}))
+		})
+	})
+
+	When("importing tunes with tags", func() {
+		var importedTunes []*apimodel.ImportTune
+		var importedSet *apimodel.BasicMusicSet
+
+		BeforeEach(func() {
+			fileInfo, err := common.NewImportFileInfo(
+				"grade 2/tunes [learning].bww",
+				fileformat.Format_BWW,
+				[]byte(`BagpipeReader:1.0`),
+			)
+			Expect(err).ShouldNot(HaveOccurred())
+			fileInfo.Tags = []string{"grade 2", "learning"}
+
+			importedTunes, importedSet, err = service.ImportTunes(
+				[]*messages.ParsedTune{
+					model.TestParsedTune("imported tune 1"),
+					model.TestParsedTune("imported tune 2"),
+				},
+				fileInfo,
+			)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should have tagged the tunes with existing and new tags", func() {
+			for _, t := range importedTunes {
+				tune, err := service.GetTune(t.Id)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(tune.Tags).To(Equal([]string{"Grade 2", "learning"}))
+			}
+
+			tags, err := service.Tags()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(tags).To(HaveLen(3))
+		})
+
+		It("should have tagged the set", func() {
+			set, err := service.GetMusicSet(importedSet.Id, nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(set.Tags).To(Equal([]string{"Grade 2", "learning"}))
+		})
+	})
+})
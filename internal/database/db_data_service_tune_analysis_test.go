@@ -0,0 +1,108 @@
+package database
+
+import (
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/pitch"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/embellishment"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/messages"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/config"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("DbDataService Tune Analysis", func() {
+	var err error
+	var service *Service
+	var gormDb *gorm.DB
+	var brave *apimodel.ImportTune
+	var laddie *apimodel.ImportTune
+
+	importMelody := func(pTune *messages.ParsedTune) *apimodel.ImportTune {
+		fileInfo, err := common.NewImportFileInfo(pTune.Tune.Title+".bww", fileformat.Format_BWW, pTune.TuneFileData)
+		Expect(err).ShouldNot(HaveOccurred())
+		tunes, _, err := service.ImportTunes([]*messages.ParsedTune{pTune}, fileInfo)
+		Expect(err).ShouldNot(HaveOccurred())
+		return tunes[0]
+	}
+
+	BeforeEach(func() {
+		cfg, err := config.InitTest()
+		Expect(err).ShouldNot(HaveOccurred())
+		gormDb, err = GetInitTestDB(cfg.DbConfig(), "testdb")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		service = &Service{
+			db:        gormDb,
+			validator: mocks.NewAPIModelValidator(GinkgoT()),
+		}
+
+		brave = importMelody(parsedMelody("Scotland the Brave", embellishment.Type_Doubling,
+			pitch.Pitch_LowA, pitch.Pitch_B, pitch.Pitch_C))
+		laddie = importMelody(parsedMelody("Highland Laddie", embellishment.Type_NoEmbellishment,
+			pitch.Pitch_HighG, pitch.Pitch_F))
+	})
+
+	AfterEach(func() {
+		db, err := gormDb.DB()
+		Expect(err).ShouldNot(HaveOccurred())
+		err = db.Close()
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should analyse the music of a tune", func() {
+		a, err := service.TuneAnalysis(brave.Id)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(a.TuneCount).To(Equal(int32(1)))
+		Expect(a.MeasureCount).To(Equal(int32(3)))
+		Expect(a.LowestPitch).To(Equal("LowA"))
+		Expect(a.HighestPitch).To(Equal("C"))
+		Expect(a.Embellishments).To(Equal(map[string]int32{"Doubling": 3}))
+	})
+
+	It("should not analyse a tune without music", func() {
+		t, err := service.CreateTune(apimodel.CreateTune{Title: "Scotland the Brave"}, nil, nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = service.TuneAnalysis(t.Id)
+		Expect(err).To(MatchError(common.ErrNotFound))
+	})
+
+	It("should not analyse an unknown tune", func() {
+		_, err = service.TuneAnalysis(uuid.New())
+		Expect(err).To(MatchError(common.ErrNotFound))
+	})
+
+	It("should analyse the tunes of a set", func() {
+		set, err := service.CreateMusicSet(apimodel.CreateSet{Title: "competition"}, nil, nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = service.AssignTunesToMusicSet(set.Id, []uuid.UUID{laddie.Id, brave.Id}, nil)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		a, err := service.MusicSetAnalysis(set.Id, nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(a.TuneCount).To(Equal(int32(2)))
+		Expect(a.MeasureCount).To(Equal(int32(5)))
+		Expect(a.LowestPitch).To(Equal("LowA"))
+		Expect(a.HighestPitch).To(Equal("HighG"))
+	})
+
+	It("should not analyse an unknown set", func() {
+		_, err = service.MusicSetAnalysis(uuid.New(), nil)
+		Expect(err).To(MatchError(common.ErrNotFound))
+	})
+
+	It("should analyse the tunes matching the filters", func() {
+		a, err := service.TunesAnalysis(common.TuneListOptions{Title: "laddie"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(a.TuneCount).To(Equal(int32(1)))
+		Expect(a.Pitches).To(Equal(map[string]int32{"HighG": 1, "F": 1}))
+
+		a, err = service.TunesAnalysis(common.TuneListOptions{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(a.TuneCount).To(Equal(int32(2)))
+	})
+})
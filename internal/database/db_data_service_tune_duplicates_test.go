@@ -0,0 +1,214 @@
+package database
+
+import (
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/length"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/measure"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/pitch"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/embellishment"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/messages"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/config"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"gorm.io/gorm"
+)
+
+// parsedMelody returns a parsed tune with a measure of a quarter note for every
+// pitch. The notes have the given embellishment, which doesn't change the melody.
+func parsedMelody(
+	title string,
+	emb embellishment.Type,
+	pitches ...pitch.Pitch,
+) *messages.ParsedTune {
+	t := &tune.Tune{Title: title}
+	for _, p := range pitches {
+		t.Measures = append(t.Measures, &measure.Measure{
+			Symbols: []*symbols.Symbol{{Note: &symbols.Note{
+				Pitch:         p,
+				Length:        length.Length_Quarter,
+				Embellishment: &embellishment.Embellishment{Type: emb},
+			}}},
+		})
+	}
+
+	return &messages.ParsedTune{Tune: t, TuneFileData: []byte(title)}
+}
+
+var _ = Describe("DbDataService Tune Duplicates", func() {
+	var err error
+	var service *Service
+	var gormDb *gorm.DB
+	var brave *apimodel.ImportTune
+	var rewritten *apimodel.ImportTune
+	var variant *apimodel.ImportTune
+	var other *apimodel.ImportTune
+
+	importMelody := func(pTune *messages.ParsedTune) *apimodel.ImportTune {
+		fileInfo, err := common.NewImportFileInfo(pTune.Tune.Title+".bww", fileformat.Format_BWW, pTune.TuneFileData)
+		Expect(err).ShouldNot(HaveOccurred())
+		tunes, _, err := service.ImportTunes([]*messages.ParsedTune{pTune}, fileInfo)
+		Expect(err).ShouldNot(HaveOccurred())
+		return tunes[0]
+	}
+
+	BeforeEach(func() {
+		cfg, err := config.InitTest()
+		Expect(err).ShouldNot(HaveOccurred())
+		gormDb, err = GetInitTestDB(cfg.DbConfig(), "testdb")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		service = &Service{
+			db:        gormDb,
+			validator: mocks.NewAPIModelValidator(GinkgoT()),
+		}
+
+		melody := []pitch.Pitch{pitch.Pitch_LowA, pitch.Pitch_B, pitch.Pitch_C, pitch.Pitch_D, pitch.Pitch_E}
+		brave = importMelody(parsedMelody("Scotland the Brave", embellishment.Type_NoEmbellishment, melody...))
+		rewritten = importMelody(parsedMelody("Scotland The Brave (2)", embellishment.Type_Doubling, melody...))
+		variant = importMelody(parsedMelody("Scotland the Brave variant", embellishment.Type_NoEmbellishment,
+			pitch.Pitch_LowA, pitch.Pitch_B, pitch.Pitch_C, pitch.Pitch_D, pitch.Pitch_HighA))
+		other = importMelody(parsedMelody("Highland Laddie", embellishment.Type_NoEmbellishment,
+			pitch.Pitch_HighG, pitch.Pitch_F, pitch.Pitch_E, pitch.Pitch_D))
+	})
+
+	AfterEach(func() {
+		db, err := gormDb.DB()
+		Expect(err).ShouldNot(HaveOccurred())
+		err = db.Close()
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should find the tunes with similar music, most similar first", func() {
+		duplicates, err := service.TuneDuplicates(brave.Id)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(duplicates).To(HaveLen(2))
+		Expect(duplicates[0].Tune.Id).To(Equal(rewritten.Id))
+		Expect(duplicates[0].Similarity).To(BeNumerically("==", 1))
+		Expect(duplicates[1].Tune.Id).To(Equal(variant.Id))
+		Expect(duplicates[1].Similarity).To(BeNumerically("~", 0.8, 0.0001))
+	})
+
+	It("should not find duplicates of a tune with other music", func() {
+		duplicates, err := service.TuneDuplicates(other.Id)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(duplicates).To(BeEmpty())
+	})
+
+	It("should not find duplicates of a tune without music", func() {
+		t, err := service.CreateTune(apimodel.CreateTune{Title: "Scotland the Brave"}, nil, nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		duplicates, err := service.TuneDuplicates(t.Id)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(duplicates).To(BeEmpty())
+	})
+
+	It("should not find duplicates of an unknown tune", func() {
+		_, err = service.TuneDuplicates(uuid.New())
+		Expect(err).To(MatchError(common.ErrNotFound))
+	})
+
+	When("merging a duplicate into a tune", func() {
+		var set *apimodel.MusicSet
+		var tag *apimodel.Tag
+		var merged *apimodel.Tune
+
+		BeforeEach(func() {
+			set, err = service.CreateMusicSet(apimodel.CreateSet{Title: "competition"}, nil, nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = service.AssignTunesToMusicSet(set.Id, []uuid.UUID{rewritten.Id, other.Id}, nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			tag, err = service.CreateTag(apimodel.CreateTag{Name: "grade 2"})
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = service.AssignTagsToTune(rewritten.Id, []uuid.UUID{tag.Id})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			merged, err = service.MergeTunes(brave.Id, apimodel.MergeTunes{
+				TuneIds: []uuid.UUID{rewritten.Id},
+			})
+		})
+
+		It("should have the tags of the duplicate", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(merged.Id).To(Equal(brave.Id))
+			Expect(merged.Tags).To(Equal([]string{"grade 2"}))
+		})
+
+		It("should have replaced the duplicate in its sets", func() {
+			set, err = service.GetMusicSet(set.Id, nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(set.Tunes).To(HaveLen(2))
+			Expect(set.Tunes[0].Id).To(Equal(brave.Id))
+			Expect(set.Tunes[1].Id).To(Equal(other.Id))
+		})
+
+		It("should have recorded a revision of the tune", func() {
+			revisions, err := service.TuneRevisions(brave.Id)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(revisions).To(HaveLen(2))
+		})
+
+		It("should have deleted the duplicate", func() {
+			_, err = service.GetTune(rewritten.Id)
+			Expect(err).To(MatchError(common.ErrNotFound))
+		})
+	})
+
+	When("merging a duplicate into a tune of the same set", func() {
+		var set *apimodel.MusicSet
+
+		BeforeEach(func() {
+			set, err = service.CreateMusicSet(apimodel.CreateSet{
+				Title: "competition",
+				Tunes: []uuid.UUID{rewritten.Id, other.Id, brave.Id, variant.Id},
+			}, nil, nil)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			_, err = service.MergeTunes(brave.Id, apimodel.MergeTunes{
+				TuneIds: []uuid.UUID{rewritten.Id},
+			})
+		})
+
+		It("should have the tune only once in the set", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			set, err = service.GetMusicSet(set.Id, nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(set.Tunes).To(HaveLen(3))
+			Expect(set.Tunes[0].Id).To(Equal(other.Id))
+			Expect(set.Tunes[1].Id).To(Equal(brave.Id))
+			Expect(set.Tunes[2].Id).To(Equal(variant.Id))
+		})
+
+		It("should have numbered the tunes of the set again", func() {
+			var orders []uint
+			err = gormDb.Model(&model.MusicSetTunes{}).
+				Where("music_set_id = ?", set.Id).
+				Order("\"order\"").
+				Pluck("order", &orders).Error
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(orders).To(Equal([]uint{1, 2, 3}))
+		})
+	})
+
+	It("should not merge a tune into itself", func() {
+		_, err = service.MergeTunes(brave.Id, apimodel.MergeTunes{
+			TuneIds: []uuid.UUID{brave.Id},
+		})
+		Expect(err).To(MatchError(common.ErrInvalidArgument))
+	})
+
+	It("should not merge unknown tunes", func() {
+		_, err = service.MergeTunes(brave.Id, apimodel.MergeTunes{
+			TuneIds: []uuid.UUID{rewritten.Id, uuid.New()},
+		})
+		Expect(err).To(MatchError(common.ErrNotFound))
+		_, err = service.GetTune(rewritten.Id)
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,199 @@
+package database
+
+import (
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/config"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("DbDataService Tune Revisions", func() {
+	var err error
+	var service *Service
+	var gormDb *gorm.DB
+	var validator *mocks.APIModelValidator
+	var tune *apimodel.Tune
+
+	BeforeEach(func() {
+		cfg, err := config.InitTest()
+		Expect(err).ShouldNot(HaveOccurred())
+		gormDb, err = GetInitTestDB(cfg.DbConfig(), "testdb")
+		Expect(err).ShouldNot(HaveOccurred())
+		validator = mocks.NewAPIModelValidator(GinkgoT())
+
+		service = &Service{
+			db:        gormDb,
+			validator: validator,
+		}
+
+		tune, err = service.CreateTune(apimodel.CreateTune{
+			Title:    "Scotland",
+			Type:     "march",
+			Composer: "trad.",
+		}, nil, nil)
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		db, err := gormDb.DB()
+		Expect(err).ShouldNot(HaveOccurred())
+		err = db.Close()
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	When("a tune was created", func() {
+		var revisions []*model.TuneRevision
+
+		BeforeEach(func() {
+			revisions, err = service.TuneRevisions(tune.Id)
+		})
+
+		It("should have a first revision without author", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(revisions).To(HaveLen(1))
+			Expect(revisions[0].Number).To(BeEquivalentTo(1))
+			Expect(revisions[0].Author).To(BeEmpty())
+			Expect(revisions[0].Title).To(Equal("Scotland"))
+			Expect(revisions[0].Type).To(Equal("march"))
+		})
+	})
+
+	When("listing the revisions of a tune that doesn't exist", func() {
+		BeforeEach(func() {
+			_, err = service.TuneRevisions(uuid.New())
+		})
+
+		It("should return a not found error", func() {
+			Expect(err).To(MatchError(common.ErrNotFound))
+		})
+	})
+
+	When("getting a revision that doesn't exist", func() {
+		BeforeEach(func() {
+			_, err = service.TuneRevision(tune.Id, 5)
+		})
+
+		It("should return a not found error", func() {
+			Expect(err).To(MatchError(common.ErrNotFound))
+		})
+	})
+
+	When("a change of the tune fails", func() {
+		BeforeEach(func() {
+			err = service.changeTune(tune.Id, "piper", func(tx *Service) error {
+				err := tx.db.Model(&model.Tune{}).
+					Where("id = ?", tune.Id).
+					Update("title", "Changed").Error
+				Expect(err).ShouldNot(HaveOccurred())
+
+				return common.ErrInvalidArgument
+			})
+		})
+
+		It("should roll back the change without recording a revision", func() {
+			Expect(err).To(MatchError(common.ErrInvalidArgument))
+			current, err := service.GetTune(tune.Id)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(current.Title).To(Equal("Scotland"))
+
+			revisions, err := service.TuneRevisions(tune.Id)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(revisions).To(HaveLen(1))
+		})
+	})
+
+	When("storing a revision with a number that the tune already has", func() {
+		BeforeEach(func() {
+			err = gormDb.Create(&model.TuneRevision{TuneID: tune.Id, Number: 1}).Error
+		})
+
+		It("should fail", func() {
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("an exported file is added to the tune", func() {
+		BeforeEach(func() {
+			err = service.AddExportedFileToTune(tune.Id, &model.TuneFile{
+				Format:         fileformat.Format_MUSIC_XML,
+				Data:           []byte("<score-partwise/>"),
+				SingleTuneData: true,
+			})
+		})
+
+		It("should not record a revision", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			revisions, err := service.TuneRevisions(tune.Id)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(revisions).To(HaveLen(1))
+
+			tuneFile, err := service.GetTuneFile(tune.Id, fileformat.Format_MUSIC_XML)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(tuneFile.Exported).To(BeTrue())
+		})
+
+		When("the tune is changed afterwards", func() {
+			BeforeEach(func() {
+				err = service.AddFileToTune(tune.Id, &model.TuneFile{
+					Format: fileformat.Format_BWW,
+					Data:   []byte("BagpipeReader:1.0"),
+				}, "piper")
+			})
+
+			It("should leave the exported file out of the revision", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				revision, err := service.TuneRevision(tune.Id, 2)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(revision.File(fileformat.Format_BWW)).NotTo(BeNil())
+				Expect(revision.File(fileformat.Format_MUSIC_XML)).To(BeNil())
+			})
+		})
+	})
+
+	Context("the tune was updated", func() {
+		BeforeEach(func() {
+			update := apimodel.UpdateTune{
+				Title:    "Scotland the Brave",
+				Type:     "slow march",
+				Composer: "trad.",
+			}
+			validator.EXPECT().ValidateUpdateTune(update).Return(nil)
+			_, err = service.UpdateTune(tune.Id, update, "piper")
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should have recorded a revision with the author", func() {
+			revision, err := service.TuneRevision(tune.Id, 2)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(revision.Author).To(Equal("piper"))
+			Expect(revision.Title).To(Equal("Scotland the Brave"))
+			Expect(revision.Type).To(Equal("slow march"))
+		})
+
+		When("restoring the first revision", func() {
+			var restored *apimodel.Tune
+
+			BeforeEach(func() {
+				restored, err = service.RestoreTuneRevision(tune.Id, 1, "drummer")
+			})
+
+			It("should restore the tune and record a new revision", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(restored.Title).To(Equal("Scotland"))
+				Expect(restored.Type).To(Equal("march"))
+
+				revisions, err := service.TuneRevisions(tune.Id)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(revisions).To(HaveLen(3))
+				Expect(revisions[2].Author).To(Equal("drummer"))
+				Expect(revisions[2].Title).To(Equal("Scotland"))
+			})
+		})
+	})
+})
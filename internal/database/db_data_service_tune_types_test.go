@@ -0,0 +1,207 @@
+package database
+
+import (
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/config"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("DbDataService Tune Types", func() {
+	var err error
+	var service *Service
+	var gormDb *gorm.DB
+	var march *apimodel.TuneType
+	var retreat *apimodel.TuneType
+	var brown *apimodel.Tune
+
+	tuneTypeNamed := func(name string) *apimodel.TuneType {
+		tuneTypes, err := service.TuneTypes()
+		Expect(err).ShouldNot(HaveOccurred())
+		for _, t := range tuneTypes {
+			if t.Name == name {
+				return t
+			}
+		}
+		Fail("no tune type " + name)
+		return nil
+	}
+
+	BeforeEach(func() {
+		cfg, err := config.InitTest()
+		Expect(err).ShouldNot(HaveOccurred())
+		gormDb, err = GetInitTestDB(cfg.DbConfig(), "testdb")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		service = &Service{
+			db:        gormDb,
+			validator: mocks.NewAPIModelValidator(GinkgoT()),
+		}
+
+		_, err = service.CreateTune(apimodel.CreateTune{Title: "Scotland the Brave", Type: "March"}, nil, nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		brown, err = service.CreateTune(apimodel.CreateTune{Title: "Brown Haired Maiden", Type: "Retreat  March "}, nil, nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		march = tuneTypeNamed("March")
+		retreat = tuneTypeNamed("Retreat March")
+	})
+
+	AfterEach(func() {
+		db, err := gormDb.DB()
+		Expect(err).ShouldNot(HaveOccurred())
+		err = db.Close()
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should list the tune types with the number of their tunes", func() {
+		tuneTypes, err := service.TuneTypes()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(tuneTypes).To(Equal([]*apimodel.TuneType{
+			{Id: march.Id, Name: "March", TuneCount: 1},
+			{Id: retreat.Id, Name: "Retreat March", TuneCount: 1},
+		}))
+	})
+
+	It("should not find an unknown tune type", func() {
+		_, err = service.GetTuneType(uuid.New())
+		Expect(err).To(MatchError(common.ErrNotFound))
+	})
+
+	When("renaming a tune type", func() {
+		var renamed *apimodel.TuneType
+
+		BeforeEach(func() {
+			renamed, err = service.UpdateTuneType(retreat.Id, apimodel.UpdateTuneType{Name: "Retreat"})
+		})
+
+		It("should have the old name as alias", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(renamed).To(Equal(&apimodel.TuneType{
+				Id:        retreat.Id,
+				Name:      "Retreat",
+				Aliases:   []string{"Retreat March"},
+				TuneCount: 1,
+			}))
+		})
+
+		It("should give tunes with the old name the renamed tune type", func() {
+			tune, err := service.CreateTune(apimodel.CreateTune{Title: "Battle's O'er", Type: "retreat march"}, nil, nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(tune.Type).To(Equal("Retreat"))
+		})
+
+		When("renaming it back to the old name", func() {
+			BeforeEach(func() {
+				renamed, err = service.UpdateTuneType(retreat.Id, apimodel.UpdateTuneType{Name: "Retreat March"})
+			})
+
+			It("should have the other name as alias", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(renamed.Name).To(Equal("Retreat March"))
+				Expect(renamed.Aliases).To(Equal([]string{"Retreat"}))
+			})
+		})
+	})
+
+	It("should not rename a tune type to the name of another one", func() {
+		_, err = service.UpdateTuneType(retreat.Id, apimodel.UpdateTuneType{Name: "march"})
+		Expect(err).To(MatchError(common.ErrAlreadyExists))
+	})
+
+	When("merging a tune type into another one", func() {
+		var merged *apimodel.TuneType
+
+		BeforeEach(func() {
+			_, err = service.SetTuneTypeAliases(retreat.Id, []string{"Retreat Air"})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			merged, err = service.MergeTuneTypes(march.Id, apimodel.MergeTuneTypes{
+				TuneTypeIds: []uuid.UUID{retreat.Id},
+			})
+		})
+
+		It("should have moved the tunes and names to the tune type", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(merged).To(Equal(&apimodel.TuneType{
+				Id:        march.Id,
+				Name:      "March",
+				Aliases:   []string{"Retreat Air", "Retreat March"},
+				TuneCount: 2,
+			}))
+
+			tune, err := service.GetTune(brown.Id)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(tune.Type).To(Equal("March"))
+		})
+
+		It("should have recorded a revision of the tunes with the new type", func() {
+			revisions, err := service.TuneRevisions(brown.Id)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(revisions).To(HaveLen(2))
+			Expect(revisions[0].Type).To(Equal("Retreat March"))
+			Expect(revisions[1].Type).To(Equal("March"))
+		})
+
+		It("should have deleted the merged tune type", func() {
+			_, err = service.GetTuneType(retreat.Id)
+			Expect(err).To(MatchError(common.ErrNotFound))
+		})
+
+		It("should filter the tunes of both types by the tune type", func() {
+			tunes, err := service.Tunes(common.TuneListOptions{Type: "march"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(tunes.Pagination.TotalCount).To(BeEquivalentTo(2))
+		})
+	})
+
+	It("should not merge a tune type into itself", func() {
+		_, err = service.MergeTuneTypes(march.Id, apimodel.MergeTuneTypes{
+			TuneTypeIds: []uuid.UUID{march.Id},
+		})
+		Expect(err).To(MatchError(common.ErrInvalidArgument))
+	})
+
+	It("should not merge unknown tune types", func() {
+		_, err = service.MergeTuneTypes(march.Id, apimodel.MergeTuneTypes{
+			TuneTypeIds: []uuid.UUID{retreat.Id, uuid.New()},
+		})
+		Expect(err).To(MatchError(common.ErrNotFound))
+		Expect(tuneTypeNamed("Retreat March").TuneCount).To(BeEquivalentTo(1))
+	})
+
+	When("setting the aliases of a tune type", func() {
+		var tuneType *apimodel.TuneType
+
+		BeforeEach(func() {
+			tuneType, err = service.SetTuneTypeAliases(march.Id, []string{
+				"2/4 March", " 2/4  march", "march", "",
+			})
+		})
+
+		It("should only have the distinct aliases", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(tuneType.Aliases).To(Equal([]string{"2/4 March"}))
+		})
+
+		It("should give tunes with the alias the tune type", func() {
+			tune, err := service.CreateTune(apimodel.CreateTune{Title: "Highland Laddie", Type: "2/4 MARCH"}, nil, nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(tune.Type).To(Equal("March"))
+			Expect(service.TuneTypes()).To(HaveLen(2))
+		})
+
+		It("should not give another tune type the alias", func() {
+			_, err = service.SetTuneTypeAliases(retreat.Id, []string{"2/4 march"})
+			Expect(err).To(MatchError(common.ErrAlreadyExists))
+		})
+
+		It("should not give an alias the name of another tune type", func() {
+			_, err = service.SetTuneTypeAliases(retreat.Id, []string{"March"})
+			Expect(err).To(MatchError(common.ErrAlreadyExists))
+		})
+	})
+})
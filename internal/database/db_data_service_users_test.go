@@ -0,0 +1,255 @@
+package database
+
+import (
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/config"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("DbDataService Users", func() {
+	var err error
+	var service *Service
+	var gormDb *gorm.DB
+	var validator *mocks.APIModelValidator
+	var piper *model.User
+	var drummer *model.User
+
+	BeforeEach(func() {
+		cfg, err := config.InitTest()
+		Expect(err).ShouldNot(HaveOccurred())
+		gormDb, err = GetInitTestDB(cfg.DbConfig(), "testdb")
+		Expect(err).ShouldNot(HaveOccurred())
+		validator = mocks.NewAPIModelValidator(GinkgoT())
+
+		service = &Service{
+			db:        gormDb,
+			validator: validator,
+		}
+
+		piper, err = service.CreateUser("piper")
+		Expect(err).ShouldNot(HaveOccurred())
+		drummer, err = service.CreateUser("drummer")
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		db, err := gormDb.DB()
+		Expect(err).ShouldNot(HaveOccurred())
+		err = db.Close()
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	Context("creating users", func() {
+		It("should not create a user with a name that is taken", func() {
+			_, err = service.CreateUser("piper")
+			Expect(err).To(MatchError(common.ErrAlreadyExists))
+		})
+
+		It("should not create a user without a name", func() {
+			_, err = service.CreateUser("  ")
+			Expect(err).To(MatchError(common.ErrInvalidArgument))
+		})
+
+		It("should find the user by name", func() {
+			user, err := service.GetUserByName("piper")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(user.ID).To(Equal(piper.ID))
+
+			_, err = service.GetUserByName("bagpiper")
+			Expect(err).To(MatchError(common.ErrNotFound))
+		})
+
+		It("should return a not found error for a user that doesn't exist", func() {
+			_, err = service.GetUser(uuid.New())
+			Expect(err).To(MatchError(common.ErrNotFound))
+		})
+	})
+
+	Context("users of an identity provider", func() {
+		var user *model.User
+
+		BeforeEach(func() {
+			user, err = service.UserForExternalID(common.TokenClaims{
+				Subject: "sub-1",
+				Name:    "pipe major",
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should create the user on the first login", func() {
+			Expect(user.Name).To(Equal("pipe major"))
+			Expect(*user.ExternalID).To(Equal("sub-1"))
+		})
+
+		It("should return the same user on the next login", func() {
+			again, err := service.UserForExternalID(common.TokenClaims{
+				Subject: "sub-1",
+				Name:    "renamed",
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(again.ID).To(Equal(user.ID))
+		})
+
+		It("should use the subject if there is no name", func() {
+			other, err := service.UserForExternalID(common.TokenClaims{Subject: "sub-2"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(other.Name).To(Equal("sub-2"))
+		})
+
+		It("should add a number to a name that a local user already has", func() {
+			other, err := service.UserForExternalID(common.TokenClaims{
+				Subject: "sub-2",
+				Name:    "piper",
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(other.Name).To(Equal("piper 2"))
+			Expect(other.ID).ToNot(Equal(piper.ID))
+
+			third, err := service.UserForExternalID(common.TokenClaims{
+				Subject: "sub-3",
+				Name:    "piper",
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(third.Name).To(Equal("piper 3"))
+		})
+	})
+
+	Context("having an API token", func() {
+		var apiToken *model.APIToken
+		var token string
+
+		BeforeEach(func() {
+			apiToken, token, err = service.CreateAPIToken(piper.ID, "laptop")
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should only store the hash of the token", func() {
+			Expect(common.IsAPIToken(token)).To(BeTrue())
+			Expect(apiToken.Hash).To(Equal(common.APITokenHash(token)))
+		})
+
+		It("should return the user of the token and record its use", func() {
+			user, err := service.UserByAPIToken(token)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(user.ID).To(Equal(piper.ID))
+
+			tokens, err := service.APITokens(piper.ID)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(tokens).To(HaveLen(1))
+			Expect(tokens[0].Name).To(Equal("laptop"))
+			Expect(tokens[0].LastUsedAt).ShouldNot(BeNil())
+		})
+
+		It("should not find a user for an unknown token", func() {
+			_, err = service.UserByAPIToken("lp_unknown")
+			Expect(err).To(MatchError(common.ErrNotFound))
+		})
+
+		It("should not delete the token for another user", func() {
+			err = service.DeleteAPIToken(drummer.ID, apiToken.ID)
+			Expect(err).To(MatchError(common.ErrNotFound))
+		})
+
+		When("deleting the token", func() {
+			BeforeEach(func() {
+				err = service.DeleteAPIToken(piper.ID, apiToken.ID)
+			})
+
+			It("should not accept the token anymore", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				_, err = service.UserByAPIToken(token)
+				Expect(err).To(MatchError(common.ErrNotFound))
+			})
+		})
+	})
+
+	Context("having a private and a public set of a user", func() {
+		var privateSet *apimodel.MusicSet
+		var publicSet *apimodel.MusicSet
+
+		BeforeEach(func() {
+			privateSet, err = service.CreateMusicSet(apimodel.CreateSet{
+				Title:   "Practice Highland Set",
+				Creator: "someone else",
+				Private: true,
+			}, nil, &piper.ID)
+			Expect(err).ShouldNot(HaveOccurred())
+			publicSet, err = service.CreateMusicSet(apimodel.CreateSet{
+				Title: "Competition Highland Set",
+			}, nil, &piper.ID)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should have the owner as creator", func() {
+			Expect(privateSet.Creator).To(Equal("piper"))
+			Expect(*privateSet.OwnerId).To(Equal(piper.ID))
+			Expect(privateSet.Private).To(BeTrue())
+		})
+
+		It("should show the private set only to the owner", func() {
+			sets, err := service.MusicSets(&piper.ID)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(sets).To(HaveLen(2))
+
+			sets, err = service.MusicSets(&drummer.ID)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(sets).To(HaveLen(1))
+			Expect(sets[0].Id).To(Equal(publicSet.Id))
+
+			sets, err = service.MusicSets(nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(sets).To(HaveLen(1))
+		})
+
+		It("should not find the private set for other users", func() {
+			_, err = service.GetMusicSet(privateSet.Id, &piper.ID)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			_, err = service.GetMusicSet(privateSet.Id, &drummer.ID)
+			Expect(err).To(MatchError(common.ErrNotFound))
+			err = service.DeleteMusicSet(privateSet.Id, &drummer.ID)
+			Expect(err).To(MatchError(common.ErrNotFound))
+			_, err = service.AssignTunesToMusicSet(privateSet.Id, []uuid.UUID{}, &drummer.ID)
+			Expect(err).To(MatchError(common.ErrNotFound))
+		})
+
+		It("should keep the creator on an update", func() {
+			validator.EXPECT().ValidateUpdateSet(apimodel.UpdateSet{
+				Title:   "Practice Set",
+				Creator: "someone else",
+				Private: true,
+			}).Return(nil)
+
+			updated, err := service.UpdateMusicSet(privateSet.Id, apimodel.UpdateSet{
+				Title:   "Practice Set",
+				Creator: "someone else",
+				Private: true,
+			}, &piper.ID)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(updated.Creator).To(Equal("piper"))
+		})
+
+		It("should only find the private set in a search of the owner", func() {
+			result, err := service.Search(common.SearchOptions{
+				Query:  "highland",
+				UserID: &piper.ID,
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(result.Hits).To(HaveLen(2))
+
+			result, err = service.Search(common.SearchOptions{
+				Query:  "highland",
+				UserID: &drummer.ID,
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(result.Hits).To(HaveLen(1))
+			Expect(result.Hits[0].Id).To(Equal(publicSet.Id))
+		})
+	})
+})
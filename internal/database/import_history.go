@@ -0,0 +1,361 @@
+package database
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jinzhu/copier"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"path/filepath"
+)
+
+// importHistoryQuery selects the ids of all imports. These are the import jobs
+// and the import files that were not imported by a job, e.g. with the CLI.
+// The %[1]s placeholders take the condition of visibleImportsCondition.
+const importHistoryQuery = `SELECT id, created_at FROM import_jobs WHERE %[1]s
+UNION ALL
+SELECT id, created_at FROM import_files WHERE import_job_id IS NULL AND %[1]s`
+
+// visibleImportsCondition returns the condition and its value that restrict
+// imports to the imports of the given user and the imports without an owner,
+// e.g. of the CLI. Without a user only imports without an owner are visible.
+func visibleImportsCondition(userID *uuid.UUID) (string, []any) {
+	if userID == nil {
+		return "owner_id IS NULL", nil
+	}
+
+	return "(owner_id IS NULL OR owner_id = ?)", []any{*userID}
+}
+
+// visibleImports restricts a query of import jobs or files to the imports
+// that are visible to the given user.
+func visibleImports(userID *uuid.UUID) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		cond, condVals := visibleImportsCondition(userID)
+		return db.Where(cond, condVals...)
+	}
+}
+
+// ImportJobs returns a page of the import history that is visible to the user
+// with the newest import first. The jobs don't contain the data of the imported
+// files. Import files that were not imported by a job are returned as completed
+// import job.
+func (d *Service) ImportJobs(
+	opts common.ImportListOptions,
+	userID *uuid.UUID,
+) ([]*model.ImportJob, *apimodel.Pagination, error) {
+	cond, condVals := visibleImportsCondition(userID)
+	historyQuery := fmt.Sprintf(importHistoryQuery, cond)
+	// the condition is part of both selects of the history
+	condVals = append(condVals, condVals...)
+
+	var totalCount int64
+	err := d.db.Raw("SELECT count(*) FROM ("+historyQuery+") AS history", condVals...).
+		Scan(&totalCount).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	page := opts.PageOrDefault()
+	pageSize := opts.PageSizeOrDefault()
+	var rows []struct {
+		ID uuid.UUID
+	}
+	err = d.db.Raw(historyQuery+" ORDER BY created_at DESC LIMIT ? OFFSET ?",
+		append(condVals, pageSize, (page-1)*pageSize)...).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	jobs := make([]*model.ImportJob, len(rows))
+	for i, r := range rows {
+		if jobs[i], err = d.findImportJob(r.ID, false, userID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	pagination := newPagination(page, pageSize, totalCount)
+
+	return jobs, &pagination, nil
+}
+
+// findImportJob returns the import job with the given id that is visible to
+// the user. If there is no job with this id, it looks for an import file without
+// a job. The data of the imported file is only loaded if withData is set.
+func (d *Service) findImportJob(id uuid.UUID, withData bool, userID *uuid.UUID) (*model.ImportJob, error) {
+	job := &model.ImportJob{}
+	err := d.importQuery(withData).Scopes(visibleImports(userID)).First(job, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return d.legacyImportJob(id, withData, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (d *Service) importQuery(withData bool) *gorm.DB {
+	if withData {
+		return d.db
+	}
+
+	return d.db.Omit("data")
+}
+
+// legacyImportJob returns an import file that was not imported by a job as
+// completed import job, so that all imports can be handled the same way.
+func (d *Service) legacyImportJob(id uuid.UUID, withData bool, userID *uuid.UUID) (*model.ImportJob, error) {
+	importFile := &model.ImportFile{}
+	err := d.importQuery(withData).
+		Scopes(visibleImports(userID)).
+		Where("import_job_id IS NULL").
+		First(importFile, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, common.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := d.importFileReport(importFile)
+	if err != nil {
+		return nil, err
+	}
+	reportData, err := json.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+
+	finishedAt := importFile.CreatedAt
+	return &model.ImportJob{
+		BaseModel:  importFile.BaseModel,
+		Status:     model.ImportJobStatusCompleted,
+		FileName:   filepath.Base(importFile.OriginalPath),
+		Hash:       importFile.Hash,
+		Data:       importFile.Data,
+		FilesTotal: 1,
+		FilesDone:  1,
+		Report:     reportData,
+		StartedAt:  &finishedAt,
+		FinishedAt: &finishedAt,
+	}, nil
+}
+
+// importFileReport returns the report for an import file with the tunes
+// and the set of the import of the file.
+func (d *Service) importFileReport(importFile *model.ImportFile) (*model.ImportReport, error) {
+	var tunes []model.Tune
+	err := d.db.Preload("TuneType").
+		Where("id IN (?)", importFileTuneIDs(d.db, importFile.ID)).
+		Order("created_at").
+		Find(&tunes).Error
+	if err != nil {
+		return nil, err
+	}
+
+	apiTunes, err := apiTunesFromDbTunes(tunes)
+	if err != nil {
+		return nil, err
+	}
+
+	importedFile := apimodel.ImportFile{
+		Name: filepath.Base(importFile.OriginalPath),
+	}
+	if err = copier.Copy(&importedFile.Tunes, apiTunes); err != nil {
+		return nil, err
+	}
+
+	var sets []model.MusicSet
+	err = d.db.Where("import_file_id = ?", importFile.ID).Limit(1).Find(&sets).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(sets) > 0 {
+		importedFile.Set = &apimodel.BasicMusicSet{}
+		if err = copier.Copy(importedFile.Set, &sets[0]); err != nil {
+			return nil, err
+		}
+	}
+
+	return &model.ImportReport{
+		Files: []apimodel.ImportFile{importedFile},
+	}, nil
+}
+
+// DeleteImport rolls back an import of the user. It deletes the sets and tunes that were
+// created by the files of the import and the import itself. Tunes that other
+// imports, like a later re-import, also imported or that are part of sets of
+// other imports are kept. Jobs that are not finished yet can't be deleted.
+func (d *Service) DeleteImport(id uuid.UUID, userID *uuid.UUID) error {
+	job, err := d.findImportJob(id, false, userID)
+	if err != nil {
+		return err
+	}
+	if !job.Finished() {
+		return fmt.Errorf("%w: import %s is not finished yet", common.ErrInvalidArgument, id)
+	}
+
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		return rollbackImport(tx, job)
+	})
+}
+
+func rollbackImport(tx *gorm.DB, job *model.ImportJob) error {
+	var fileIDs []uuid.UUID
+	err := tx.Model(&model.ImportFile{}).
+		Where("id = ? OR import_job_id = ?", job.ID, job.ID).
+		Pluck("id", &fileIDs).Error
+	if err != nil {
+		return err
+	}
+
+	for _, fileID := range fileIDs {
+		if err = rollbackImportFile(tx, fileID); err != nil {
+			return err
+		}
+	}
+
+	// the sets of the folders of an archive don't belong to an import file
+	setIDs, err := folderSetIDs(job)
+	if err != nil {
+		return err
+	}
+	if err = deleteMusicSets(tx, setIDs); err != nil {
+		return err
+	}
+
+	return tx.Delete(&model.ImportJob{}, job.ID).Error
+}
+
+func folderSetIDs(job *model.ImportJob) ([]uuid.UUID, error) {
+	if len(job.Report) == 0 {
+		return nil, nil
+	}
+
+	report := &model.ImportReport{}
+	if err := json.Unmarshal(job.Report, report); err != nil {
+		return nil, err
+	}
+
+	setIDs := make([]uuid.UUID, len(report.FolderSets))
+	for i, s := range report.FolderSets {
+		setIDs[i] = s.Id
+	}
+
+	return setIDs, nil
+}
+
+func rollbackImportFile(tx *gorm.DB, fileID uuid.UUID) error {
+	if err := handOverClaimedSets(tx, fileID); err != nil {
+		return err
+	}
+
+	var setIDs []uuid.UUID
+	err := tx.Model(&model.MusicSet{}).
+		Where("import_file_id = ?", fileID).
+		Pluck("id", &setIDs).Error
+	if err != nil {
+		return err
+	}
+	if err = deleteMusicSets(tx, setIDs); err != nil {
+		return err
+	}
+	if err = handOverClaimedTunes(tx, fileID); err != nil {
+		return err
+	}
+
+	// tunes that are still in a set are shared with other imports and are kept
+	err = tx.Model(&model.Tune{}).
+		Where("import_file_id = ? AND id IN (SELECT tune_id FROM music_set_tunes)", fileID).
+		Update("import_file_id", uuid.Nil).Error
+	if err != nil {
+		return err
+	}
+
+	err = tx.Where("import_file_id = ?", fileID).Delete(&model.Tune{}).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Delete(&model.ImportFile{}, fileID).Error
+}
+
+func deleteMusicSets(tx *gorm.DB, setIDs []uuid.UUID) error {
+	if len(setIDs) == 0 {
+		return nil
+	}
+
+	err := tx.Where("music_set_id IN ?", setIDs).Delete(&model.MusicSetTunes{}).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Delete(&model.MusicSet{}, setIDs).Error
+}
+
+// handOverClaimedTunes hands the tunes of the import file that other imports
+// also imported over to the newest of these imports, so that they are kept.
+func handOverClaimedTunes(tx *gorm.DB, fileID uuid.UUID) error {
+	newestClaim := tx.Model(&model.ImportFileTune{}).
+		Select("import_file_tunes.import_file_id").
+		Joins("JOIN import_files ON import_files.id = import_file_tunes.import_file_id").
+		Where("import_file_tunes.tune_id = tunes.id AND import_file_tunes.import_file_id <> ?", fileID).
+		Order("import_files.created_at DESC").
+		Limit(1)
+	claimed := tx.Model(&model.ImportFileTune{}).
+		Select("tune_id").
+		Where("import_file_id <> ?", fileID)
+
+	return tx.Model(&model.Tune{}).
+		Where("import_file_id = ? AND id IN (?)", fileID, claimed).
+		Update("import_file_id", newestClaim).Error
+}
+
+// handOverClaimedSets hands the sets of the import file that a later import
+// reused over to the newest of these imports, so that they are kept. An import
+// reused a set if it has no set of its own and imported all tunes of the set.
+func handOverClaimedSets(tx *gorm.DB, fileID uuid.UUID) error {
+	newestClaim := tx.Model(&model.ImportFile{}).
+		Select("import_files.id").
+		Where("import_files.id <> ?", fileID).
+		Where("NOT EXISTS (SELECT 1 FROM music_sets AS own WHERE own.import_file_id = import_files.id)").
+		Where(`NOT EXISTS (SELECT 1 FROM music_set_tunes
+			WHERE music_set_tunes.music_set_id = music_sets.id
+			AND music_set_tunes.tune_id NOT IN (
+				SELECT tune_id FROM import_file_tunes WHERE import_file_tunes.import_file_id = import_files.id))`).
+		Order("import_files.created_at DESC").
+		Limit(1)
+
+	return tx.Model(&model.MusicSet{}).
+		Where("import_file_id = ? AND EXISTS (?)", fileID, newestClaim).
+		Update("import_file_id", newestClaim).Error
+}
+
+// importFileTuneIDs returns the query of the ids of the tunes of the import file.
+func importFileTuneIDs(db *gorm.DB, fileID uuid.UUID) *gorm.DB {
+	return db.Model(&model.ImportFileTune{}).
+		Select("tune_id").
+		Where("import_file_id = ?", fileID)
+}
+
+// linkImportFileTunes links the import file to the tunes of its report.
+func linkImportFileTunes(tx *gorm.DB, fileID uuid.UUID, tunes []*apimodel.ImportTune) error {
+	links := make([]model.ImportFileTune, 0, len(tunes))
+	for _, t := range tunes {
+		links = append(links, model.ImportFileTune{ImportFileID: fileID, TuneID: t.Id})
+	}
+	if len(links) == 0 {
+		return nil
+	}
+
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Omit(clause.Associations).
+		Create(&links).Error
+}
@@ -0,0 +1,110 @@
+package database
+
+import (
+	"fmt"
+	"github.com/SamuelTissot/sqltime"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+)
+
+func (d *Service) CreateImportJob(job *model.ImportJob) error {
+	if job.Status == "" {
+		job.Status = model.ImportJobStatusQueued
+	}
+
+	if err := d.db.Create(job).Error; err != nil {
+		return fmt.Errorf("failed creating import job for file %s: %w", job.FileName, err)
+	}
+
+	return nil
+}
+
+// GetImportJob returns the import job with the given id if it is visible to the
+// user. Import files that were not imported by a job are returned as completed
+// import job.
+func (d *Service) GetImportJob(id uuid.UUID, userID *uuid.UUID) (*model.ImportJob, error) {
+	return d.findImportJob(id, true, userID)
+}
+
+func (d *Service) UpdateImportJob(job *model.ImportJob) error {
+	return d.db.Save(job).Error
+}
+
+// ClaimNextImportJob marks the oldest queued import job as running and returns it.
+// A job can only be claimed once, even if multiple workers try to claim it at the
+// same time. It returns an ErrNotFound error if there is no queued job.
+func (d *Service) ClaimNextImportJob() (*model.ImportJob, error) {
+	for {
+		jobID, err := d.oldestQueuedImportJobID()
+		if err != nil {
+			return nil, err
+		}
+
+		claimed, err := d.claimImportJob(jobID)
+		if err != nil {
+			return nil, err
+		}
+		if claimed {
+			return d.importJobByID(jobID)
+		}
+		// another worker claimed the job in the meantime, so try the next one
+	}
+}
+
+// importJobByID returns the import job with the given id of any user.
+func (d *Service) importJobByID(id uuid.UUID) (*model.ImportJob, error) {
+	job := &model.ImportJob{}
+	if err := d.db.First(job, id).Error; err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (d *Service) oldestQueuedImportJobID() (uuid.UUID, error) {
+	var jobIDs []uuid.UUID
+	err := d.db.Model(&model.ImportJob{}).
+		Where("status = ?", model.ImportJobStatusQueued).
+		Order("created_at").
+		Limit(1).
+		Pluck("id", &jobIDs).Error
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if len(jobIDs) == 0 {
+		return uuid.Nil, common.ErrNotFound
+	}
+
+	return jobIDs[0], nil
+}
+
+// claimImportJob sets the job to running if it is still queued.
+// It returns false if the job isn't queued anymore.
+func (d *Service) claimImportJob(jobID uuid.UUID) (bool, error) {
+	res := d.db.Model(&model.ImportJob{}).
+		Where("id = ? AND status = ?", jobID, model.ImportJobStatusQueued).
+		Updates(map[string]any{
+			"status":     model.ImportJobStatusRunning,
+			"started_at": sqltime.Now(),
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected == 1, nil
+}
+
+// RequeueRunningImportJobs puts all running import jobs back into the queue.
+// It must only be called when no worker is running, e.g. after a restart
+// where jobs were interrupted.
+func (d *Service) RequeueRunningImportJobs() (int64, error) {
+	res := d.db.Model(&model.ImportJob{}).
+		Where("status = ?", model.ImportJobStatusRunning).
+		Updates(map[string]any{
+			"status":     model.ImportJobStatusQueued,
+			"started_at": nil,
+		})
+
+	return res.RowsAffected, res.Error
+}
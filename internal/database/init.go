@@ -1,25 +1,138 @@
 package database
 
 import (
+	"errors"
 	"fmt"
+	"github.com/glebarez/sqlite"
+	"github.com/rs/zerolog/log"
 	"github.com/tomvodi/limepipes/internal/config"
-	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/database/migration"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"os"
+	"path/filepath"
 )
 
-func migrateDb(db *gorm.DB) error {
-	return db.AutoMigrate(
-		&model.MusicSet{},
-		&model.Tune{},
-		&model.MusicSetTunes{},
-		&model.TuneFile{},
-		&model.ImportFile{},
-		&model.TuneType{},
-	)
+// sqliteDsnParams enable the foreign keys for the cascading deletes and
+// let concurrent writers, like the import workers, wait for each other.
+// Transactions take the write lock when they begin, so that two transactions
+// that read before they write don't fail on upgrading their locks.
+const sqliteDsnParams = "?_pragma=foreign_keys(1)&_pragma=busy_timeout(10000)" +
+	"&_pragma=journal_mode(WAL)&_txlock=immediate"
+
+func isSQLite(db *gorm.DB) bool {
+	return db.Name() == sqlite.DriverName
 }
 
-func GetInitPostgreSQLDB(
+// GetInitDB opens the database of the configured driver and checks that
+// all migrations are applied. Pending migrations are applied first if
+// auto migration is enabled. It returns an error if the schema is outdated
+// or newer than this version of limepipes.
+func GetInitDB(
+	dbConf config.DbConfig,
+) (*gorm.DB, error) {
+	db, err := OpenDB(dbConf)
+	if err != nil {
+		return nil, err
+	}
+
+	if dbConf.AutoMigrate {
+		if err = autoMigrate(db); err != nil {
+			return nil, err
+		}
+	}
+
+	err = Migrator(db).Check()
+	if errors.Is(err, migration.ErrPendingMigrations) {
+		return nil, fmt.Errorf("%w, run 'limepipes-cli db migrate' first", err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// autoMigrate applies the pending migrations of the database.
+func autoMigrate(db *gorm.DB) error {
+	applied, err := Migrator(db).Up()
+	for _, mig := range applied {
+		log.Info().Msgf("applied migration %d %s", mig.Version, mig.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("failed migrating database: %w", err)
+	}
+
+	return nil
+}
+
+// OpenDB opens the database of the configured driver without
+// checking its schema.
+func OpenDB(
+	dbConf config.DbConfig,
+) (*gorm.DB, error) {
+	switch dbConf.Driver {
+	case "", config.DbDriverPostgres:
+		return openPostgreSQLDB(dbConf)
+	case config.DbDriverSQLite:
+		return openSQLiteDB(dbConf)
+	default:
+		return nil, fmt.Errorf("unsupported database driver %s", dbConf.Driver)
+	}
+}
+
+// GetInitTestDB returns an empty database of the configured driver for tests
+// with all migrations applied.
+func GetInitTestDB(
+	dbConf config.DbConfig,
+	testDbName string,
+) (*gorm.DB, error) {
+	var db *gorm.DB
+	var err error
+	if dbConf.IsSQLite() {
+		db, err = openTestSQLiteDB(dbConf, testDbName)
+	} else {
+		db, err = openTestPostgreSQLDB(dbConf, testDbName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = Migrator(db).Up(); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func openSQLiteDB(
+	dbConf config.DbConfig,
+) (*gorm.DB, error) {
+	if dbConf.Path == "" {
+		return nil, fmt.Errorf("no path for the SQLite database configured")
+	}
+
+	return gorm.Open(sqlite.Open(dbConf.Path+sqliteDsnParams), &gorm.Config{})
+}
+
+// openTestSQLiteDB creates a new database file in the temp directory.
+// An existing file of an earlier test is removed.
+func openTestSQLiteDB(
+	dbConf config.DbConfig,
+	testDbName string,
+) (*gorm.DB, error) {
+	dbConf.Path = filepath.Join(os.TempDir(), testDbName+".sqlite")
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		err := os.Remove(dbConf.Path + suffix)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	return openSQLiteDB(dbConf)
+}
+
+func openPostgreSQLDB(
 	dbConf config.DbConfig,
 ) (*gorm.DB, error) {
 	dsnTpl := "host=%s port=%s dbname=%s user=%s password=%s sslmode=%s TimeZone=%s"
@@ -32,21 +145,12 @@ func GetInitPostgreSQLDB(
 		dbConf.SslMode,
 		dbConf.TimeZone,
 	)
-	db, err := gorm.Open(postgres.New(postgres.Config{
+	return gorm.Open(postgres.New(postgres.Config{
 		DSN: dsn,
 	}), &gorm.Config{})
-	if err != nil {
-		return nil, err
-	}
-
-	if err = migrateDb(db); err != nil {
-		return nil, err
-	}
-
-	return db, nil
 }
 
-func GetInitTestPostgreSQLDB(
+func openTestPostgreSQLDB(
 	dbConf config.DbConfig,
 	testDbName string,
 ) (*gorm.DB, error) {
@@ -88,5 +192,5 @@ func GetInitTestPostgreSQLDB(
 
 	dbConf.DbName = testDbName
 
-	return GetInitPostgreSQLDB(dbConf)
+	return openPostgreSQLDB(dbConf)
 }
@@ -0,0 +1,136 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"gorm.io/gorm"
+)
+
+func (d *Service) Memberships() ([]*model.Membership, error) {
+	var memberships []*model.Membership
+	err := d.db.Preload("User").
+		Order("created_at").
+		Find(&memberships).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return memberships, nil
+}
+
+func (d *Service) GetMembership(userID uuid.UUID) (*model.Membership, error) {
+	return getMembership(d.db, userID)
+}
+
+// SetMembership gives the user the role, either with a new membership or by
+// changing the role of an existing one. The role of the last admin can't be changed.
+func (d *Service) SetMembership(
+	userID uuid.UUID,
+	role common.Role,
+) (*model.Membership, error) {
+	role, err := common.ParseRole(string(role))
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := d.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var membership *model.Membership
+	err = d.db.Transaction(func(tx *gorm.DB) error {
+		membership, err = saveMembership(tx, userID, role)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed setting role of user %s: %w", user.Name, err)
+	}
+
+	membership.User = *user
+	return membership, nil
+}
+
+// DeleteMembership removes the membership of the user, who then only has the
+// default role. The membership of the last admin can't be deleted.
+func (d *Service) DeleteMembership(userID uuid.UUID) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		membership, err := getMembership(tx, userID)
+		if err != nil {
+			return err
+		}
+
+		if err = ensureOtherAdmin(tx, membership); err != nil {
+			return err
+		}
+
+		return tx.Delete(&model.Membership{}, membership.ID).Error
+	})
+}
+
+func saveMembership(
+	tx *gorm.DB,
+	userID uuid.UUID,
+	role common.Role,
+) (*model.Membership, error) {
+	membership, err := getMembership(tx, userID)
+	if errors.Is(err, common.ErrNotFound) {
+		membership = &model.Membership{UserID: userID}
+	} else if err != nil {
+		return nil, err
+	}
+
+	if role != common.RoleAdmin {
+		if err = ensureOtherAdmin(tx, membership); err != nil {
+			return nil, err
+		}
+	}
+
+	membership.Role = role
+	if err = tx.Omit("User").Save(membership).Error; err != nil {
+		return nil, err
+	}
+
+	return membership, nil
+}
+
+func getMembership(db *gorm.DB, userID uuid.UUID) (*model.Membership, error) {
+	membership := &model.Membership{}
+	err := db.Preload("User").
+		Where("user_id = ?", userID).
+		First(membership).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: membership of user %s", common.ErrNotFound, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return membership, nil
+}
+
+// ensureOtherAdmin returns an ErrInvalidArgument error if the membership is
+// the one of the last admin, as nobody could manage the memberships anymore
+// if it lost its role.
+func ensureOtherAdmin(tx *gorm.DB, membership *model.Membership) error {
+	if membership.Role != common.RoleAdmin {
+		return nil
+	}
+
+	var otherAdmins int64
+	err := tx.Model(&model.Membership{}).
+		Where("role = ? AND user_id <> ?", common.RoleAdmin, membership.UserID).
+		Count(&otherAdmins).Error
+	if err != nil {
+		return err
+	}
+	if otherAdmins == 0 {
+		return fmt.Errorf("%w: user %s is the last admin",
+			common.ErrInvalidArgument, membership.User.Name)
+	}
+
+	return nil
+}
@@ -0,0 +1,243 @@
+package migration
+
+import (
+	"fmt"
+	"github.com/SamuelTissot/sqltime"
+	"gorm.io/gorm"
+	"slices"
+)
+
+var ErrNewerSchema = fmt.Errorf("database schema is newer than this version of limepipes")
+var ErrPendingMigrations = fmt.Errorf("database schema is not up to date")
+
+// Migration is a versioned change of the database schema. Up applies the
+// change and Down reverts it. Both run in the transaction that also records
+// the migration in the schema_migrations table.
+type Migration struct {
+	Version uint
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// Status is the state of a migration in the database. AppliedAt is nil
+// if the migration isn't applied yet.
+type Status struct {
+	Version   uint
+	Name      string
+	AppliedAt *sqltime.Time
+}
+
+// schemaMigration is an applied migration.
+type schemaMigration struct {
+	Version   uint `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt sqltime.Time `gorm:"type:timestamp"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrator applies and reverts the migrations of a database in the order
+// of their versions.
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// Up applies all pending migrations and returns them.
+func (m *Migrator) Up() ([]Migration, error) {
+	applied, err := m.appliedVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, mig := range m.migrations {
+		if applied[mig.Version] {
+			continue
+		}
+
+		if err = m.apply(mig); err != nil {
+			return done, err
+		}
+		done = append(done, mig)
+	}
+
+	return done, nil
+}
+
+// Down reverts the given number of the latest applied migrations
+// and returns them.
+func (m *Migrator) Down(steps int) ([]Migration, error) {
+	applied, err := m.appliedVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		mig := m.migrations[i]
+		if !applied[mig.Version] {
+			continue
+		}
+
+		if err = m.revert(mig); err != nil {
+			return done, err
+		}
+		done = append(done, mig)
+	}
+
+	return done, nil
+}
+
+// Status returns the state of all migrations. Migrations that were applied
+// by a newer version of limepipes are part of it as well.
+func (m *Migrator) Status() ([]Status, error) {
+	records, err := m.schemaMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []Status
+	for _, mig := range m.migrations {
+		status := Status{Version: mig.Version, Name: mig.Name}
+		if r, ok := records[mig.Version]; ok {
+			status.AppliedAt = &r.AppliedAt
+			delete(records, mig.Version)
+		}
+		statuses = append(statuses, status)
+	}
+
+	for _, r := range records {
+		statuses = append(statuses, Status{
+			Version:   r.Version,
+			Name:      r.Name,
+			AppliedAt: &r.AppliedAt,
+		})
+	}
+	slices.SortFunc(statuses, func(a, b Status) int {
+		return int(a.Version) - int(b.Version)
+	})
+
+	return statuses, nil
+}
+
+// Check returns an ErrNewerSchema error if the database has migrations that
+// are unknown to this version and an ErrPendingMigrations error if not all
+// migrations are applied.
+func (m *Migrator) Check() error {
+	if _, err := m.appliedVersions(); err != nil {
+		return err
+	}
+
+	statuses, err := m.Status()
+	if err != nil {
+		return err
+	}
+
+	pending := 0
+	for _, s := range statuses {
+		if s.AppliedAt == nil {
+			pending++
+		}
+	}
+	if pending > 0 {
+		return fmt.Errorf("%w: %d migrations are pending", ErrPendingMigrations, pending)
+	}
+
+	return nil
+}
+
+func (m *Migrator) apply(mig Migration) error {
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		if err := mig.Up(tx); err != nil {
+			return err
+		}
+
+		return tx.Create(&schemaMigration{
+			Version:   mig.Version,
+			Name:      mig.Name,
+			AppliedAt: sqltime.Now(),
+		}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed applying migration %d %s: %w", mig.Version, mig.Name, err)
+	}
+
+	return nil
+}
+
+func (m *Migrator) revert(mig Migration) error {
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		if err := mig.Down(tx); err != nil {
+			return err
+		}
+
+		return tx.Delete(&schemaMigration{Version: mig.Version}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed reverting migration %d %s: %w", mig.Version, mig.Name, err)
+	}
+
+	return nil
+}
+
+// appliedVersions returns the versions of the applied migrations. It returns
+// an ErrNewerSchema error if a migration is applied that this version doesn't know.
+func (m *Migrator) appliedVersions() (map[uint]bool, error) {
+	records, err := m.schemaMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[uint]bool, len(m.migrations))
+	for _, mig := range m.migrations {
+		known[mig.Version] = true
+	}
+
+	applied := make(map[uint]bool, len(records))
+	for version := range records {
+		if !known[version] {
+			return nil, fmt.Errorf("%w: unknown migration %d is applied", ErrNewerSchema, version)
+		}
+		applied[version] = true
+	}
+
+	return applied, nil
+}
+
+// schemaMigrations returns the applied migrations by their version.
+// The schema_migrations table is created if it doesn't exist yet.
+func (m *Migrator) schemaMigrations() (map[uint]schemaMigration, error) {
+	if err := m.db.AutoMigrate(&schemaMigration{}); err != nil {
+		return nil, fmt.Errorf("failed creating schema_migrations table: %w", err)
+	}
+
+	var records []schemaMigration
+	if err := m.db.Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[uint]schemaMigration, len(records))
+	for _, r := range records {
+		byVersion[r.Version] = r
+	}
+
+	return byVersion, nil
+}
+
+// NewMigrator returns a migrator for the given migrations, which are
+// ordered by their version.
+func NewMigrator(db *gorm.DB, migrations []Migration) *Migrator {
+	sorted := slices.Clone(migrations)
+	slices.SortFunc(sorted, func(a, b Migration) int {
+		return int(a.Version) - int(b.Version)
+	})
+
+	return &Migrator{
+		db:         db,
+		migrations: sorted,
+	}
+}
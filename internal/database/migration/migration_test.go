@@ -0,0 +1,164 @@
+package migration
+
+import (
+	"fmt"
+	"github.com/glebarez/sqlite"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+	"path/filepath"
+)
+
+var _ = Describe("Migrator", func() {
+	var err error
+	var db *gorm.DB
+	var migrator *Migrator
+	var migrations []Migration
+	var done []Migration
+
+	createTable := func(name string) func(tx *gorm.DB) error {
+		return func(tx *gorm.DB) error {
+			return tx.Exec(fmt.Sprintf("CREATE TABLE %s (id integer)", name)).Error
+		}
+	}
+	dropTable := func(name string) func(tx *gorm.DB) error {
+		return func(tx *gorm.DB) error {
+			return tx.Exec(fmt.Sprintf("DROP TABLE %s", name)).Error
+		}
+	}
+	versions := func(migs []Migration) []uint {
+		var v []uint
+		for _, m := range migs {
+			v = append(v, m.Version)
+		}
+		return v
+	}
+
+	BeforeEach(func() {
+		db, err = gorm.Open(sqlite.Open(filepath.Join(GinkgoT().TempDir(), "test.sqlite")), &gorm.Config{})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		migrations = []Migration{
+			{Version: 2, Name: "create sets", Up: createTable("sets"), Down: dropTable("sets")},
+			{Version: 1, Name: "create tunes", Up: createTable("tunes"), Down: dropTable("tunes")},
+		}
+		migrator = NewMigrator(db, migrations)
+	})
+
+	AfterEach(func() {
+		sqlDB, err := db.DB()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(sqlDB.Close()).To(Succeed())
+	})
+
+	When("no migration was applied", func() {
+		It("should report all migrations as pending", func() {
+			Expect(migrator.Check()).To(MatchError(ErrPendingMigrations))
+
+			statuses, err := migrator.Status()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(statuses).To(HaveLen(2))
+			Expect(statuses[0].Version).To(BeEquivalentTo(1))
+			Expect(statuses[0].AppliedAt).To(BeNil())
+		})
+	})
+
+	Context("migrating up", func() {
+		BeforeEach(func() {
+			done, err = migrator.Up()
+		})
+
+		It("should apply all migrations in the order of their versions", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(versions(done)).To(Equal([]uint{1, 2}))
+			Expect(db.Migrator().HasTable("tunes")).To(BeTrue())
+			Expect(db.Migrator().HasTable("sets")).To(BeTrue())
+			Expect(migrator.Check()).To(Succeed())
+		})
+
+		It("should not apply any migration again", func() {
+			done, err = migrator.Up()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(done).To(BeEmpty())
+		})
+
+		When("rolling back one step", func() {
+			BeforeEach(func() {
+				done, err = migrator.Down(1)
+			})
+
+			It("should only revert the latest migration", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(versions(done)).To(Equal([]uint{2}))
+				Expect(db.Migrator().HasTable("sets")).To(BeFalse())
+				Expect(db.Migrator().HasTable("tunes")).To(BeTrue())
+				Expect(migrator.Check()).To(MatchError(ErrPendingMigrations))
+			})
+		})
+
+		When("rolling back more steps than applied", func() {
+			BeforeEach(func() {
+				done, err = migrator.Down(5)
+			})
+
+			It("should revert all migrations", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(versions(done)).To(Equal([]uint{2, 1}))
+				Expect(db.Migrator().HasTable("tunes")).To(BeFalse())
+			})
+		})
+
+		When("the database was migrated by a newer version", func() {
+			BeforeEach(func() {
+				newer := NewMigrator(db, append(migrations, Migration{
+					Version: 3,
+					Name:    "create people",
+					Up:      createTable("people"),
+					Down:    dropTable("people"),
+				}))
+				_, err = newer.Up()
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+
+			It("should refuse to run", func() {
+				Expect(migrator.Check()).To(MatchError(ErrNewerSchema))
+				_, err = migrator.Up()
+				Expect(err).To(MatchError(ErrNewerSchema))
+				_, err = migrator.Down(1)
+				Expect(err).To(MatchError(ErrNewerSchema))
+			})
+
+			It("should show the unknown migration in the status", func() {
+				statuses, err := migrator.Status()
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(statuses).To(HaveLen(3))
+				Expect(statuses[2].Name).To(Equal("create people"))
+				Expect(statuses[2].AppliedAt).NotTo(BeNil())
+			})
+		})
+	})
+
+	When("a migration fails", func() {
+		BeforeEach(func() {
+			migrations[0].Up = func(tx *gorm.DB) error {
+				if err := createTable("sets")(tx); err != nil {
+					return err
+				}
+				return fmt.Errorf("backfill failed")
+			}
+			migrator = NewMigrator(db, migrations)
+			done, err = migrator.Up()
+		})
+
+		It("should roll back the failed migration and keep the earlier ones", func() {
+			Expect(err).To(MatchError(ContainSubstring("backfill failed")))
+			Expect(versions(done)).To(Equal([]uint{1}))
+			Expect(db.Migrator().HasTable("sets")).To(BeFalse())
+
+			statuses, err := migrator.Status()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(statuses[0].AppliedAt).NotTo(BeNil())
+			Expect(statuses[1].AppliedAt).To(BeNil())
+		})
+	})
+})
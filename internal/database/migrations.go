@@ -0,0 +1,464 @@
+package database
+
+import (
+	"fmt"
+	"github.com/SamuelTissot/sqltime"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes/internal/analysis"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/migration"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	schemav1 "github.com/tomvodi/limepipes/internal/database/schema/v1"
+	schemav10 "github.com/tomvodi/limepipes/internal/database/schema/v10"
+	schemav11 "github.com/tomvodi/limepipes/internal/database/schema/v11"
+	schemav3 "github.com/tomvodi/limepipes/internal/database/schema/v3"
+	schemav4 "github.com/tomvodi/limepipes/internal/database/schema/v4"
+	schemav5 "github.com/tomvodi/limepipes/internal/database/schema/v5"
+	schemav6 "github.com/tomvodi/limepipes/internal/database/schema/v6"
+	schemav7 "github.com/tomvodi/limepipes/internal/database/schema/v7"
+	schemav8 "github.com/tomvodi/limepipes/internal/database/schema/v8"
+	schemav9 "github.com/tomvodi/limepipes/internal/database/schema/v9"
+	"github.com/tomvodi/limepipes/internal/fingerprint"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"slices"
+)
+
+// migrations are all changes of the database schema. A released migration
+// must not be changed anymore, a change needs a new migration with the next
+// version instead.
+var migrations = []migration.Migration{
+	{
+		Version: 1,
+		Name:    "create tables",
+		Up:      createTablesV1,
+		Down:    dropTablesV1,
+	},
+	{
+		Version: 2,
+		Name:    "create search indexes",
+		Up:      createSearchIndexes,
+		Down:    dropSearchIndexes,
+	},
+	{
+		Version: 3,
+		Name:    "add users and owners",
+		Up:      addUsersAndOwners,
+		Down:    dropUsersAndOwners,
+	},
+	{
+		Version: 4,
+		Name:    "add memberships",
+		Up:      addMemberships,
+		Down:    dropMemberships,
+	},
+	{
+		Version: 5,
+		Name:    "add tags",
+		Up:      addTags,
+		Down:    dropTags,
+	},
+	{
+		Version: 6,
+		Name:    "add tune type aliases",
+		Up:      addTuneTypeAliases,
+		Down:    dropTuneTypeAliases,
+	},
+	{
+		Version: 7,
+		Name:    "add people",
+		Up:      addPeople,
+		Down:    dropPeople,
+	},
+	{
+		Version: 8,
+		Name:    "add tune fingerprints",
+		Up:      addTuneFingerprints,
+		Down:    dropTuneFingerprints,
+	},
+	{
+		Version: 9,
+		Name:    "add parser messages",
+		Up:      addParserMessages,
+		Down:    dropParserMessages,
+	},
+	{
+		Version: 10,
+		Name:    "mark exported tune files",
+		Up:      addExportedTuneFiles,
+		Down:    dropExportedTuneFiles,
+	},
+	{
+		Version: 11,
+		Name:    "link imports to their tunes",
+		Up:      addImportFileTunes,
+		Down:    dropImportFileTunes,
+	},
+}
+
+// Migrator returns the migrator for the schema of the limepipes database.
+func Migrator(db *gorm.DB) *migration.Migrator {
+	return migration.NewMigrator(db, migrations)
+}
+
+// createTablesV1 creates the tables of the first schema. Databases that were
+// created before there were migrations already have these tables, which is
+// why they are auto migrated and not created.
+func createTablesV1(tx *gorm.DB) error {
+	return tx.AutoMigrate(schemav1.Tables()...)
+}
+
+func dropTablesV1(tx *gorm.DB) error {
+	tables := schemav1.Tables()
+	slices.Reverse(tables)
+
+	return tx.Migrator().DropTable(tables...)
+}
+
+func addUsersAndOwners(tx *gorm.DB) error {
+	if err := tx.Migrator().CreateTable(schemav3.Tables()...); err != nil {
+		return err
+	}
+
+	for _, table := range schemav3.OwnedTables() {
+		if err := tx.Migrator().AddColumn(table, "OwnerID"); err != nil {
+			return err
+		}
+		if err := tx.Migrator().CreateIndex(table, "OwnerID"); err != nil {
+			return err
+		}
+	}
+
+	return tx.Migrator().AddColumn(&schemav3.MusicSet{}, "Private")
+}
+
+func dropUsersAndOwners(tx *gorm.DB) error {
+	if err := dropColumn(tx, &schemav3.MusicSet{}, "Private"); err != nil {
+		return err
+	}
+
+	for _, table := range schemav3.OwnedTables() {
+		if err := tx.Migrator().DropIndex(table, "OwnerID"); err != nil {
+			return err
+		}
+		if err := dropColumn(tx, table, "OwnerID"); err != nil {
+			return err
+		}
+	}
+
+	tables := schemav3.Tables()
+	slices.Reverse(tables)
+
+	return tx.Migrator().DropTable(tables...)
+}
+
+// addMemberships creates the memberships table. Before there were roles,
+// every user was allowed to do everything, so all existing users become admins.
+func addMemberships(tx *gorm.DB) error {
+	if err := tx.Migrator().CreateTable(&schemav4.Membership{}); err != nil {
+		return err
+	}
+
+	var userIDs []uuid.UUID
+	if err := tx.Table("users").Pluck("id", &userIDs).Error; err != nil {
+		return err
+	}
+
+	now := sqltime.Now()
+	for _, id := range userIDs {
+		err := tx.Create(&schemav4.Membership{
+			BaseModel: schemav4.BaseModel{ID: uuid.New(), CreatedAt: now, UpdatedAt: now},
+			UserID:    id,
+			Role:      "admin",
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func dropMemberships(tx *gorm.DB) error {
+	return tx.Migrator().DropTable(&schemav4.Membership{})
+}
+
+func addTags(tx *gorm.DB) error {
+	if err := tx.Migrator().CreateTable(schemav5.Tables()...); err != nil {
+		return err
+	}
+
+	return tx.Migrator().AddColumn(&schemav5.ImportJob{}, "AutoTag")
+}
+
+func dropTags(tx *gorm.DB) error {
+	if err := dropColumn(tx, &schemav5.ImportJob{}, "AutoTag"); err != nil {
+		return err
+	}
+
+	tables := schemav5.Tables()
+	slices.Reverse(tables)
+
+	return tx.Migrator().DropTable(tables...)
+}
+
+func addTuneTypeAliases(tx *gorm.DB) error {
+	return tx.Migrator().CreateTable(&schemav6.TuneTypeAlias{})
+}
+
+func dropTuneTypeAliases(tx *gorm.DB) error {
+	return tx.Migrator().DropTable(&schemav6.TuneTypeAlias{})
+}
+
+// addPeople creates the people and links the tunes to them. The composers
+// and arrangers of the existing tunes become people, where spellings of the
+// same person's name become aliases of the most used spelling.
+func addPeople(tx *gorm.DB) error {
+	if err := tx.Migrator().CreateTable(schemav7.Tables()...); err != nil {
+		return err
+	}
+
+	for _, column := range schemav7.PersonColumns {
+		if err := tx.Migrator().AddColumn(&schemav7.Tune{}, column.IDField); err != nil {
+			return err
+		}
+		if err := tx.Migrator().CreateIndex(&schemav7.Tune{}, column.IDField); err != nil {
+			return err
+		}
+	}
+
+	return linkTunesToPeople(tx)
+}
+
+func dropPeople(tx *gorm.DB) error {
+	for _, column := range schemav7.PersonColumns {
+		if err := tx.Migrator().DropIndex(&schemav7.Tune{}, column.IDField); err != nil {
+			return err
+		}
+		if err := dropColumn(tx, &schemav7.Tune{}, column.IDField); err != nil {
+			return err
+		}
+	}
+
+	tables := schemav7.Tables()
+	slices.Reverse(tables)
+
+	return tx.Migrator().DropTable(tables...)
+}
+
+func linkTunesToPeople(tx *gorm.DB) error {
+	var names []string
+	err := tx.Raw(`SELECT name FROM (
+		SELECT composer AS name FROM tunes WHERE composer <> ''
+		UNION ALL
+		SELECT arranger AS name FROM tunes WHERE arranger <> ''
+	) AS names GROUP BY name ORDER BY count(*) DESC, name`).
+		Scan(&names).Error
+	if err != nil {
+		return err
+	}
+
+	people := &migratedPeople{}
+	for _, name := range names {
+		person, err := people.personFor(tx, name)
+		if err != nil {
+			return err
+		}
+		if err = linkTunesToMigratedPerson(tx, name, person); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// linkTunesToMigratedPerson makes the tunes with the name as composer
+// or arranger reference the person.
+func linkTunesToMigratedPerson(tx *gorm.DB, name string, person *schemav7.Person) error {
+	for _, column := range schemav7.PersonColumns {
+		err := tx.Model(&schemav7.Tune{}).
+			Where(column.Name+" = ?", name).
+			Updates(map[string]any{column.Name: person.Name, column.ID: person.ID}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migratedPeople are the people that were created from the names of the
+// composers and arrangers with all names they are known by.
+type migratedPeople struct {
+	people []*schemav7.Person
+	names  [][]string
+}
+
+// personFor returns the person whose names match the name. If there is none,
+// a new person is created, otherwise the name becomes an alias of the person
+// unless it is already known by the name regardless of its case.
+func (m *migratedPeople) personFor(tx *gorm.DB, name string) (*schemav7.Person, error) {
+	idx, match := common.BestPersonMatch(name, m.names)
+	if match == common.NoNameMatch {
+		person := &schemav7.Person{BaseModel: newSchemaV7Base(), Name: name}
+		m.people = append(m.people, person)
+		m.names = append(m.names, []string{name})
+		return person, tx.Create(person).Error
+	}
+
+	person := m.people[idx]
+	if containsName(m.names[idx], name) {
+		return person, nil
+	}
+
+	m.names[idx] = append(m.names[idx], name)
+	return person, tx.Create(&schemav7.PersonAlias{
+		BaseModel: newSchemaV7Base(),
+		Name:      name,
+		PersonID:  person.ID,
+	}).Error
+}
+
+func newSchemaV7Base() schemav7.BaseModel {
+	now := sqltime.Now()
+	return schemav7.BaseModel{ID: uuid.New(), CreatedAt: now, UpdatedAt: now}
+}
+
+// addTuneFingerprints adds the fingerprints of the tunes and creates
+// them from the music model files of the existing tunes.
+func addTuneFingerprints(tx *gorm.DB) error {
+	if err := tx.Migrator().AddColumn(&schemav8.Tune{}, "Fingerprint"); err != nil {
+		return err
+	}
+
+	var files []schemav8.TuneFile
+	err := tx.Where("format = ? AND single_tune_data = ?", fileformat.Format_MUSIC_MODEL, false).
+		Find(&files).Error
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		tf := &model.TuneFile{Format: f.Format, Data: f.Data}
+		t, err := tf.MusicModelTune()
+		if err != nil {
+			return fmt.Errorf("failed decoding music model of tune %s: %w", f.TuneID, err)
+		}
+
+		err = tx.Model(&schemav8.Tune{}).
+			Where("id = ?", f.TuneID).
+			Update("fingerprint", fingerprint.Of(t)).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func dropTuneFingerprints(tx *gorm.DB) error {
+	return dropColumn(tx, &schemav8.Tune{}, "Fingerprint")
+}
+
+// addParserMessages creates the table of the parser messages and fills it
+// with the messages in the music model files of the existing tunes.
+func addParserMessages(tx *gorm.DB) error {
+	if err := tx.Migrator().CreateTable(&schemav9.ParserMessage{}); err != nil {
+		return err
+	}
+
+	var files []schemav9.TuneFile
+	err := tx.Where("format = ? AND single_tune_data = ?", fileformat.Format_MUSIC_MODEL, false).
+		Find(&files).Error
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		if err = addParserMessagesOfFile(tx, f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func addParserMessagesOfFile(tx *gorm.DB, f schemav9.TuneFile) error {
+	tf := &model.TuneFile{Format: f.Format, Data: f.Data}
+	t, err := tf.MusicModelTune()
+	if err != nil {
+		return fmt.Errorf("failed decoding music model of tune %s: %w", f.TuneID, err)
+	}
+
+	for _, m := range analysis.Messages(f.TuneID, t) {
+		now := sqltime.Now()
+		err = tx.Create(&schemav9.ParserMessage{
+			BaseModel: schemav9.BaseModel{ID: uuid.New(), CreatedAt: now, UpdatedAt: now},
+			TuneID:    m.TuneId,
+			Measure:   uint(m.Measure),
+			Severity:  m.Severity,
+			Symbol:    m.Symbol,
+			Text:      m.Text,
+			Fix:       m.Fix,
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func dropParserMessages(tx *gorm.DB) error {
+	return tx.Migrator().DropTable(&schemav9.ParserMessage{})
+}
+
+// dropColumn drops a column of a table. The SQLite migrator of gorm recreates
+// the whole table instead, which loses its indexes and cascades the deletion
+// of the rows to other tables, so on SQLite the column is dropped directly.
+func dropColumn(tx *gorm.DB, table any, field string) error {
+	if !isSQLite(tx) {
+		return tx.Migrator().DropColumn(table, field)
+	}
+
+	stmt := &gorm.Statement{DB: tx}
+	if err := stmt.Parse(table); err != nil {
+		return err
+	}
+	column := stmt.Schema.LookUpField(field)
+	if column == nil {
+		return fmt.Errorf("table %s has no field %s", stmt.Table, field)
+	}
+
+	return tx.Exec("ALTER TABLE ? DROP COLUMN ?",
+		clause.Table{Name: stmt.Table},
+		clause.Column{Name: column.DBName},
+	).Error
+}
+
+// addExportedTuneFiles marks the tune files that were exported from the music
+// model. Exports that were stored before can't be told apart from uploaded
+// files, so all existing files stay unmarked.
+func addExportedTuneFiles(tx *gorm.DB) error {
+	return tx.Migrator().AddColumn(&schemav10.TuneFile{}, "Exported")
+}
+
+func dropExportedTuneFiles(tx *gorm.DB) error {
+	return dropColumn(tx, &schemav10.TuneFile{}, "Exported")
+}
+
+// addImportFileTunes links the import files to their tunes. The existing
+// tunes are linked to the import file that created them.
+func addImportFileTunes(tx *gorm.DB) error {
+	if err := tx.Migrator().CreateTable(&schemav11.ImportFileTune{}); err != nil {
+		return err
+	}
+
+	return tx.Exec(`INSERT INTO import_file_tunes (import_file_id, tune_id)
+SELECT import_file_id, id FROM tunes WHERE import_file_id IN (SELECT id FROM import_files)`).Error
+}
+
+func dropImportFileTunes(tx *gorm.DB) error {
+	return tx.Migrator().DropTable(&schemav11.ImportFileTune{})
+}
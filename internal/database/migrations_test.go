@@ -0,0 +1,371 @@
+package database
+
+import (
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/messages"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/config"
+	"github.com/tomvodi/limepipes/internal/database/migration"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/fingerprint"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"gorm.io/gorm"
+	"path/filepath"
+)
+
+var _ = Describe("Migrations", func() {
+	var err error
+	var cfg *config.Config
+	var gormDb *gorm.DB
+
+	BeforeEach(func() {
+		cfg, err = config.InitTest()
+		Expect(err).ShouldNot(HaveOccurred())
+		gormDb, err = GetInitTestDB(cfg.DbConfig(), "testdb")
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		db, err := gormDb.DB()
+		Expect(err).ShouldNot(HaveOccurred())
+		err = db.Close()
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should have applied all migrations to the test database", func() {
+		Expect(Migrator(gormDb).Check()).To(Succeed())
+	})
+
+	When("rolling back the migration of the links of imports to their tunes", func() {
+		var service *Service
+		var tunes []*apimodel.ImportTune
+		var importFile *model.ImportFile
+
+		BeforeEach(func() {
+			service = &Service{
+				db:        gormDb,
+				validator: mocks.NewAPIModelValidator(GinkgoT()),
+			}
+			fileInfo, err := common.NewImportFileInfo("testfile.bww", fileformat.Format_BWW, []byte(`BagpipeReader:1.0`))
+			Expect(err).ShouldNot(HaveOccurred())
+			tunes, _, err = service.ImportTunes([]*messages.ParsedTune{
+				model.TestParsedTune("tune 1"),
+			}, fileInfo)
+			Expect(err).ShouldNot(HaveOccurred())
+			importFile, err = service.GetImportFileByHash(fileInfo.Hash)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			_, err = Migrator(gormDb).Down(1)
+		})
+
+		It("should only drop the links", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(gormDb.Migrator().HasTable("import_file_tunes")).To(BeFalse())
+			Expect(gormDb.Migrator().HasColumn("tune_files", "exported")).To(BeTrue())
+		})
+
+		When("migrating up again", func() {
+			BeforeEach(func() {
+				_, err = Migrator(gormDb).Up()
+			})
+
+			It("should have linked the tunes to the import file that created them", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				var links []model.ImportFileTune
+				Expect(gormDb.Find(&links).Error).ShouldNot(HaveOccurred())
+				Expect(links).To(ConsistOf(SatisfyAll(
+					HaveField("ImportFileID", importFile.ID),
+					HaveField("TuneID", tunes[0].Id),
+				)))
+			})
+		})
+	})
+
+	When("rolling back the migration of the exported tune files", func() {
+		BeforeEach(func() {
+			_, err = Migrator(gormDb).Down(2)
+		})
+
+		It("should only drop the mark of the exported files", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(gormDb.Migrator().HasColumn("tune_files", "exported")).To(BeFalse())
+			Expect(gormDb.Migrator().HasTable("parser_messages")).To(BeTrue())
+		})
+	})
+
+	When("rolling back the migration of the parser messages", func() {
+		var service *Service
+		var tunes []*apimodel.ImportTune
+
+		BeforeEach(func() {
+			service = &Service{
+				db:        gormDb,
+				validator: mocks.NewAPIModelValidator(GinkgoT()),
+			}
+			fileInfo, err := common.NewImportFileInfo("testfile.bww", fileformat.Format_BWW, []byte(`BagpipeReader:1.0`))
+			Expect(err).ShouldNot(HaveOccurred())
+			tunes, _, err = service.ImportTunes([]*messages.ParsedTune{
+				model.TestParsedTune("tune 1"),
+			}, fileInfo)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			_, err = Migrator(gormDb).Down(3)
+		})
+
+		It("should only drop the parser messages", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(gormDb.Migrator().HasTable("parser_messages")).To(BeFalse())
+			Expect(gormDb.Migrator().HasColumn("tunes", "fingerprint")).To(BeTrue())
+		})
+
+		When("migrating up again", func() {
+			BeforeEach(func() {
+				_, err = Migrator(gormDb).Up()
+			})
+
+			It("should have stored the parser messages of the existing tunes", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				var msgs []model.ParserMessage
+				Expect(gormDb.Find(&msgs).Error).ShouldNot(HaveOccurred())
+				Expect(msgs).To(HaveLen(1))
+				Expect(msgs[0].TuneID).To(Equal(tunes[0].Id))
+				Expect(msgs[0].Severity).To(Equal("warning"))
+				Expect(msgs[0].Symbol).To(Equal("^te"))
+				Expect(msgs[0].Fix).To(Equal("skipsymbol"))
+			})
+		})
+	})
+
+	When("rolling back the migration of the tune fingerprints", func() {
+		var service *Service
+		var tunes []*apimodel.ImportTune
+
+		BeforeEach(func() {
+			service = &Service{
+				db:        gormDb,
+				validator: mocks.NewAPIModelValidator(GinkgoT()),
+			}
+			fileInfo, err := common.NewImportFileInfo("testfile.bww", fileformat.Format_BWW, []byte(`BagpipeReader:1.0`))
+			Expect(err).ShouldNot(HaveOccurred())
+			tunes, _, err = service.ImportTunes([]*messages.ParsedTune{
+				model.TestParsedTune("tune 1"),
+			}, fileInfo)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			_, err = Migrator(gormDb).Down(4)
+		})
+
+		It("should only drop the fingerprints", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(gormDb.Migrator().HasColumn("tunes", "fingerprint")).To(BeFalse())
+			Expect(gormDb.Migrator().HasColumn("tunes", "composer_id")).To(BeTrue())
+		})
+
+		When("migrating up again", func() {
+			BeforeEach(func() {
+				_, err = Migrator(gormDb).Up()
+			})
+
+			It("should have created the fingerprints of the existing tunes", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				t := &model.Tune{}
+				Expect(gormDb.First(t, tunes[0].Id).Error).ShouldNot(HaveOccurred())
+				Expect(t.Fingerprint).ToNot(BeEmpty())
+				Expect(t.Fingerprint).To(Equal(fingerprint.Of(model.TestParsedTune("tune 1").Tune)))
+			})
+		})
+	})
+
+	When("rolling back the migration of the people", func() {
+		BeforeEach(func() {
+			_, err = Migrator(gormDb).Down(5)
+		})
+
+		It("should only drop the people", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(gormDb.Migrator().HasTable("people")).To(BeFalse())
+			Expect(gormDb.Migrator().HasTable("person_aliases")).To(BeFalse())
+			Expect(gormDb.Migrator().HasColumn("tunes", "composer_id")).To(BeFalse())
+			Expect(gormDb.Migrator().HasColumn("tunes", "composer")).To(BeTrue())
+			Expect(gormDb.Migrator().HasIndex("tunes", "idx_tunes_owner_id")).To(BeTrue())
+		})
+
+		When("migrating up again with existing composers", func() {
+			var service *Service
+
+			BeforeEach(func() {
+				service = &Service{
+					db:        gormDb,
+					validator: mocks.NewAPIModelValidator(GinkgoT()),
+				}
+				for _, composer := range []string{"G.S. McLennan", "G. S. McLennan", "George S. MacLennan", "Donald MacLeod"} {
+					err = gormDb.Exec("INSERT INTO tunes (id, title, composer) VALUES (?, ?, ?)",
+						uuid.New(), "tune", composer).Error
+					Expect(err).ShouldNot(HaveOccurred())
+				}
+				_, err = Migrator(gormDb).Up()
+			})
+
+			It("should have created a person for every composer with the other spellings as alias", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				people, err := service.People()
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(people).To(Equal([]*apimodel.Person{
+					{Id: people[0].Id, Name: "Donald MacLeod"},
+					{Id: people[1].Id, Name: "G. S. McLennan", Aliases: []string{"G.S. McLennan", "George S. MacLennan"}},
+				}))
+
+				tunes, err := service.PersonTunes(people[1].Id, common.TuneListOptions{})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(tunes.Tunes).To(HaveLen(3))
+				for _, t := range tunes.Tunes {
+					Expect(t.Composer).To(Equal("G. S. McLennan"))
+				}
+			})
+		})
+	})
+
+	When("rolling back the migration of the tune type aliases", func() {
+		BeforeEach(func() {
+			_, err = Migrator(gormDb).Down(6)
+		})
+
+		It("should only drop the aliases", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(gormDb.Migrator().HasTable("tune_type_aliases")).To(BeFalse())
+			Expect(gormDb.Migrator().HasTable("tune_types")).To(BeTrue())
+		})
+	})
+
+	When("rolling back the migration of the tags", func() {
+		BeforeEach(func() {
+			_, err = Migrator(gormDb).Down(7)
+		})
+
+		It("should only drop the tags", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(gormDb.Migrator().HasTable("tags")).To(BeFalse())
+			Expect(gormDb.Migrator().HasTable("tune_tags")).To(BeFalse())
+			Expect(gormDb.Migrator().HasColumn("import_jobs", "auto_tag")).To(BeFalse())
+			Expect(gormDb.Migrator().HasIndex("import_jobs", "idx_import_jobs_status")).To(BeTrue())
+		})
+	})
+
+	When("rolling back the migration of the memberships", func() {
+		BeforeEach(func() {
+			_, err = Migrator(gormDb).Down(8)
+		})
+
+		It("should have dropped the memberships", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(gormDb.Migrator().HasTable("memberships")).To(BeFalse())
+			Expect(gormDb.Migrator().HasTable("users")).To(BeTrue())
+		})
+
+		When("migrating up again with existing users", func() {
+			var service *Service
+			var user *model.User
+
+			BeforeEach(func() {
+				service = &Service{
+					db:        gormDb,
+					validator: mocks.NewAPIModelValidator(GinkgoT()),
+				}
+				user, err = service.CreateUser("piper")
+				Expect(err).ShouldNot(HaveOccurred())
+				_, err = Migrator(gormDb).Up()
+			})
+
+			It("should have made the existing users admins", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				membership, err := service.GetMembership(user.ID)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(membership.Role).To(Equal(common.RoleAdmin))
+			})
+		})
+	})
+
+	When("rolling back the migration of the users", func() {
+		BeforeEach(func() {
+			_, err = Migrator(gormDb).Down(9)
+		})
+
+		It("should only drop the users and the owners", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(gormDb.Migrator().HasTable("users")).To(BeFalse())
+			Expect(gormDb.Migrator().HasColumn("tunes", "owner_id")).To(BeFalse())
+			Expect(gormDb.Migrator().HasColumn("music_sets", "private")).To(BeFalse())
+			Expect(gormDb.Migrator().HasIndex("import_files", "idx_import_files_import_job_id")).To(BeTrue())
+		})
+
+		It("should be possible to migrate up again", func() {
+			_, err = Migrator(gormDb).Up()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(Migrator(gormDb).Check()).To(Succeed())
+		})
+	})
+
+	When("rolling back all migrations", func() {
+		var reverted []migration.Migration
+
+		BeforeEach(func() {
+			reverted, err = Migrator(gormDb).Down(len(migrations))
+		})
+
+		It("should have dropped all tables", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(reverted).To(HaveLen(len(migrations)))
+			Expect(gormDb.Migrator().HasTable("tunes")).To(BeFalse())
+			Expect(Migrator(gormDb).Check()).To(MatchError(migration.ErrPendingMigrations))
+		})
+
+		When("migrating up again", func() {
+			BeforeEach(func() {
+				_, err = Migrator(gormDb).Up()
+			})
+
+			It("should be possible to store tunes", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				service := &Service{
+					db:        gormDb,
+					validator: mocks.NewAPIModelValidator(GinkgoT()),
+				}
+				_, err = service.CreateTune(apimodel.CreateTune{Title: "Scotland the Brave"}, nil, nil)
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+	})
+
+	When("opening a database that isn't migrated yet", func() {
+		var dbConf config.DbConfig
+
+		BeforeEach(func() {
+			if !isSQLite(gormDb) {
+				Skip("a new database is only created without a server with SQLite")
+			}
+
+			dbConf = cfg.DbConfig()
+			dbConf.Path = filepath.Join(GinkgoT().TempDir(), "new.sqlite")
+		})
+
+		It("should refuse to open it", func() {
+			_, err = GetInitDB(dbConf)
+			Expect(err).To(MatchError(migration.ErrPendingMigrations))
+		})
+
+		It("should apply the migrations if auto migration is enabled", func() {
+			dbConf.AutoMigrate = true
+			db, err := GetInitDB(dbConf)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(Migrator(db).Check()).To(Succeed())
+
+			sqlDB, err := db.DB()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(sqlDB.Close()).To(Succeed())
+		})
+	})
+})
@@ -3,10 +3,21 @@ package model
 import (
 	"github.com/SamuelTissot/sqltime"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type BaseModel struct {
-	ID        uuid.UUID    `gorm:"type:uuid;default:gen_random_uuid()" copier:"ID"`
+	ID        uuid.UUID    `gorm:"type:uuid" copier:"ID"`
 	CreatedAt sqltime.Time `gorm:"type:timestamp"`
 	UpdatedAt sqltime.Time `gorm:"type:timestamp"`
 }
+
+// BeforeCreate generates the id of a new record, as not every database
+// is able to generate it.
+func (b *BaseModel) BeforeCreate(_ *gorm.DB) error {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+
+	return nil
+}
@@ -1,9 +1,22 @@
 package model
 
+import "github.com/google/uuid"
+
 type ImportFile struct {
 	BaseModel
 	Name         string
 	OriginalPath string
 	Hash         string
-	Data         []byte // file content from original file
+	Data         []byte     // file content from original file
+	ImportJobID  *uuid.UUID `gorm:"type:uuid;index"`
+	OwnerID      *uuid.UUID `gorm:"type:uuid;index"`
+}
+
+// ImportFileTune links an import file to a tune of its report. The tunes
+// of an import are the tunes it created, updated or found already stored.
+type ImportFileTune struct {
+	ImportFileID uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	ImportFile   ImportFile `gorm:"constraint:OnDelete:CASCADE"`
+	TuneID       uuid.UUID  `gorm:"type:uuid;primaryKey;index"`
+	Tune         Tune       `gorm:"constraint:OnDelete:CASCADE"`
 }
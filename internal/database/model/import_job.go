@@ -0,0 +1,60 @@
+package model
+
+import (
+	"github.com/SamuelTissot/sqltime"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+)
+
+type ImportJobStatus string
+
+const (
+	ImportJobStatusQueued    ImportJobStatus = "queued"
+	ImportJobStatusRunning   ImportJobStatus = "running"
+	ImportJobStatusCompleted ImportJobStatus = "completed"
+	ImportJobStatusFailed    ImportJobStatus = "failed"
+)
+
+// ImportJob is an uploaded file that will be imported in the background
+// by an import worker.
+type ImportJob struct {
+	BaseModel
+	Status     ImportJobStatus `gorm:"index"`
+	OwnerID    *uuid.UUID      `gorm:"type:uuid;index"`
+	FileName   string
+	FileFormat fileformat.Format
+	Hash       string
+	Data       []byte
+
+	// SetPerFolder creates a set for every folder of an archive
+	// with all tunes of the files in that folder.
+	SetPerFolder bool
+
+	// Reimport updates the tunes of earlier imports of the files instead of
+	// creating new ones. TuneMapping maps tune titles to the tunes they update.
+	Reimport    bool
+	TuneMapping map[string]uuid.UUID `gorm:"serializer:json"`
+
+	// AutoTag tags the imported tunes with the folder and file names.
+	AutoTag bool `gorm:"not null;default:false"`
+
+	// FilesTotal and FilesDone are the progress of the job,
+	// as one uploaded file may contain multiple files to import.
+	FilesTotal uint
+	FilesDone  uint
+
+	// Error is set when the job failed as a whole
+	Error string
+
+	// Report is the JSON encoded import report of the job
+	Report []byte
+
+	StartedAt  *sqltime.Time `gorm:"type:timestamp"`
+	FinishedAt *sqltime.Time `gorm:"type:timestamp"`
+}
+
+// Finished returns true if the job will not be processed anymore.
+func (j *ImportJob) Finished() bool {
+	return j.Status == ImportJobStatusCompleted ||
+		j.Status == ImportJobStatusFailed
+}
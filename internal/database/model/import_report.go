@@ -0,0 +1,70 @@
+package model
+
+import (
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+)
+
+// The actions that are reported for the tunes of a re-imported file.
+const (
+	ReimportActionCreated   = "created"
+	ReimportActionUpdated   = "updated"
+	ReimportActionUnchanged = "unchanged"
+)
+
+// ImportReport is the consolidated result of importing a file or all files
+// of an archive.
+type ImportReport struct {
+	Files      []apimodel.ImportFile     `json:"files"`
+	FolderSets []*apimodel.BasicMusicSet `json:"folderSets,omitempty"`
+}
+
+// Imported returns the number of files that were imported.
+func (r *ImportReport) Imported() int {
+	cnt := 0
+	for _, f := range r.Files {
+		if f.Error == "" && !f.Duplicate {
+			cnt++
+		}
+	}
+
+	return cnt
+}
+
+// Duplicates returns the files that were skipped because
+// they were already imported before.
+func (r *ImportReport) Duplicates() []apimodel.ImportFile {
+	var files []apimodel.ImportFile
+	for _, f := range r.Files {
+		if f.Duplicate {
+			files = append(files, f)
+		}
+	}
+
+	return files
+}
+
+// Failures returns the files that couldn't be imported.
+func (r *ImportReport) Failures() []apimodel.ImportFile {
+	var files []apimodel.ImportFile
+	for _, f := range r.Files {
+		if f.Error != "" {
+			files = append(files, f)
+		}
+	}
+
+	return files
+}
+
+// Updated returns the tunes that were updated by a re-import.
+func (r *ImportReport) Updated() []*apimodel.ImportTune {
+	var tunes []*apimodel.ImportTune
+	for _, f := range r.Files {
+		for _, t := range f.Tunes {
+			if t.Action == ReimportActionUpdated {
+				tunes = append(tunes, t)
+			}
+		}
+	}
+
+	return tunes
+}
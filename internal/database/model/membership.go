@@ -0,0 +1,14 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes/internal/common"
+)
+
+// Membership assigns a role in the library to a user.
+type Membership struct {
+	BaseModel
+	UserID uuid.UUID `gorm:"type:uuid;uniqueIndex"`
+	User   User
+	Role   common.Role `gorm:"not null"`
+}
@@ -4,9 +4,15 @@ import "github.com/google/uuid"
 
 type MusicSet struct {
 	BaseModel
-	Title        string
-	Description  string
-	Creator      string
+	Title       string
+	Description string
+	Creator     string
+
+	// Private sets are only visible to their owner.
+	Private bool       `gorm:"not null;default:false"`
+	OwnerID *uuid.UUID `gorm:"type:uuid;index"`
+
 	Tunes        []Tune `gorm:"many2many:music_set_tunes;constraint:OnUpdate:CASCADE;OnDelete:RESTRICT"`
+	Tags         []Tag  `gorm:"many2many:music_set_tags" copier:"-"`
 	ImportFileID uuid.UUID
 }
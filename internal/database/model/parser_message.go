@@ -0,0 +1,26 @@
+package model
+
+import "github.com/google/uuid"
+
+// ParserMessage is a message of the parser about a measure of an imported
+// tune, e.g. a warning about a symbol it didn't understand. Editors mark the
+// messages as reviewed when they have checked the tune.
+type ParserMessage struct {
+	BaseModel
+	TuneID uuid.UUID `gorm:"type:uuid;index"`
+
+	// Measure is the number of the measure starting with 1
+	Measure uint
+
+	// Severity is info, warning or error and Fix how the parser handled
+	// the symbol, e.g. skipsymbol or empty if it didn't do anything
+	Severity string `gorm:"index"`
+	Symbol   string
+	Text     string
+	Fix      string
+
+	Reviewed bool `gorm:"index"`
+
+	// ReviewedBy is the user who marked the message as reviewed
+	ReviewedBy string
+}
@@ -0,0 +1,20 @@
+package model
+
+import "github.com/google/uuid"
+
+// Person is a composer or arranger of tunes. Other spellings of the
+// name the person is known by are the aliases of the person.
+type Person struct {
+	BaseModel
+	Name    string `gorm:"uniqueIndex"`
+	Aliases []PersonAlias
+}
+
+// PersonAlias is another spelling of the name of a person,
+// e.g. "G S McLennan" for "G.S. McLennan".
+type PersonAlias struct {
+	BaseModel
+	Name     string    `gorm:"uniqueIndex"`
+	PersonID uuid.UUID `gorm:"type:uuid;index"`
+	Person   Person    `gorm:"constraint:OnDelete:CASCADE"`
+}
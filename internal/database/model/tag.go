@@ -0,0 +1,27 @@
+package model
+
+import "github.com/google/uuid"
+
+// Tag groups tunes and sets, e.g. by competition grade or band repertoire.
+// Tag names are unique regardless of their case.
+type Tag struct {
+	BaseModel
+	Name        string `gorm:"uniqueIndex"`
+	Description string
+}
+
+// TuneTag links a tag to a tune.
+type TuneTag struct {
+	TuneID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Tune   Tune      `gorm:"constraint:OnDelete:CASCADE"`
+	TagID  uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Tag    Tag       `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// MusicSetTag links a tag to a set.
+type MusicSetTag struct {
+	MusicSetID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	MusicSet   MusicSet  `gorm:"constraint:OnDelete:CASCADE"`
+	TagID      uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Tag        Tag       `gorm:"constraint:OnDelete:CASCADE"`
+}
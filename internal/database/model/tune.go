@@ -8,9 +8,19 @@ type Tune struct {
 	TuneTypeID   *uuid.UUID
 	TuneType     *TuneType
 	TimeSig      string
+	ComposerID   *uuid.UUID `gorm:"type:uuid;index"`
 	Composer     string
+	ArrangerID   *uuid.UUID `gorm:"type:uuid;index"`
 	Arranger     string
-	Sets         []MusicSet  `gorm:"many2many:music_set_tunes;constraint:OnUpdate:CASCADE;"`
-	Files        []*TuneFile `gorm:"constraint:OnDelete:CASCADE;"`
+	Sets         []MusicSet      `gorm:"many2many:music_set_tunes;constraint:OnUpdate:CASCADE;"`
+	Files        []*TuneFile     `gorm:"constraint:OnDelete:CASCADE;"`
+	Revisions    []TuneRevision  `gorm:"constraint:OnDelete:CASCADE;"`
+	Messages     []ParserMessage `gorm:"constraint:OnDelete:CASCADE;"`
+	Tags         []Tag           `gorm:"many2many:tune_tags" copier:"-"`
 	ImportFileID uuid.UUID
+	OwnerID      *uuid.UUID `gorm:"type:uuid;index"`
+
+	// Fingerprint is the fingerprint of the music of the tune,
+	// which is empty if the tune has no music model.
+	Fingerprint string
 }
@@ -19,6 +19,10 @@ type TuneFile struct {
 	SingleTuneData bool `gorm:"primaryKey"`
 
 	Data []byte
+
+	// Exported is true if the file was exported from the music model of the
+	// tune and not imported or uploaded.
+	Exported bool
 }
 
 func (t *TuneFile) MusicModelTune() (*tune.Tune, error) {
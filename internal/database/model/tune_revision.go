@@ -0,0 +1,51 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+)
+
+// TuneRevision is a snapshot of the metadata and the files of a tune
+// after it was changed.
+type TuneRevision struct {
+	BaseModel
+	TuneID uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_tune_revision_number"`
+
+	// Number is the consecutive number of the revision for the tune, starting with 1.
+	Number uint `gorm:"uniqueIndex:idx_tune_revision_number"`
+
+	// Author is the one who made the change, it is empty for changes
+	// that were not made by a user, e.g. by an import.
+	Author string
+
+	Title    string
+	Type     string
+	TimeSig  string
+	Composer string
+	Arranger string
+	Files    []TuneRevisionFile `gorm:"constraint:OnDelete:CASCADE;"`
+}
+
+// TuneRevisionFile is the snapshot of a tune file for a revision.
+type TuneRevisionFile struct {
+	TuneRevisionID uuid.UUID         `gorm:"primaryKey"`
+	Format         fileformat.Format `gorm:"primaryKey"`
+	SingleTuneData bool              `gorm:"primaryKey"`
+	Data           []byte
+}
+
+// File returns the file of the revision with the given format. The file
+// for the whole tune is preferred over a file with data of a single tune.
+func (r *TuneRevision) File(format fileformat.Format) *TuneRevisionFile {
+	var file *TuneRevisionFile
+	for i, f := range r.Files {
+		if f.Format != format {
+			continue
+		}
+		if file == nil || !f.SingleTuneData {
+			file = &r.Files[i]
+		}
+	}
+
+	return file
+}
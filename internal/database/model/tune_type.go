@@ -1,6 +1,18 @@
 package model
 
+import "github.com/google/uuid"
+
 type TuneType struct {
 	BaseModel
-	Name string `gorm:"unique"`
+	Name    string `gorm:"unique"`
+	Aliases []TuneTypeAlias
+}
+
+// TuneTypeAlias is another name of a tune type, e.g. "2/4 March" for "March".
+// Tunes with the alias as type are given the tune type instead of a new one.
+type TuneTypeAlias struct {
+	BaseModel
+	Name       string    `gorm:"uniqueIndex"`
+	TuneTypeID uuid.UUID `gorm:"type:uuid;index"`
+	TuneType   TuneType  `gorm:"constraint:OnDelete:CASCADE"`
 }
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"github.com/SamuelTissot/sqltime"
+	"github.com/google/uuid"
+)
+
+// User is an account that authenticates against the API, either with an
+// API token or with a bearer token of an external identity provider.
+type User struct {
+	BaseModel
+	Name string `gorm:"uniqueIndex"`
+
+	// ExternalID is the subject of the user at the external identity
+	// provider. It is nil for users that only use API tokens.
+	ExternalID *string `gorm:"uniqueIndex"`
+}
+
+// APIToken is a token of a user to authenticate against the API. Only the
+// hash of the token is stored, the token itself is only shown once on creation.
+type APIToken struct {
+	BaseModel
+	UserID     uuid.UUID `gorm:"type:uuid;index"`
+	Name       string
+	Hash       string        `gorm:"uniqueIndex"`
+	LastUsedAt *sqltime.Time `gorm:"type:timestamp"`
+}
@@ -0,0 +1,249 @@
+package database
+
+import (
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"github.com/tomvodi/limepipes/internal/analysis"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"gorm.io/gorm"
+)
+
+// messageOrder orders the parser messages by their tune and measure.
+const messageOrder = "tunes.title, parser_messages.tune_id, parser_messages.measure, parser_messages.created_at"
+
+// messageRow is a parser message with the title of its tune.
+type messageRow struct {
+	model.ParserMessage
+	TuneTitle string
+}
+
+// messageKey identifies equal messages of different imports of a tune.
+type messageKey struct {
+	measure  uint
+	severity string
+	symbol   string
+	text     string
+	fix      string
+}
+
+// Messages returns a page of the parser messages of all tunes
+// that match the filters of the options.
+func (d *Service) Messages(opts common.MessageListOptions) (*apimodel.TuneMessageList, error) {
+	var totalCount int64
+	if err := d.messageQuery(opts).Count(&totalCount).Error; err != nil {
+		return nil, err
+	}
+
+	page := opts.PageOrDefault()
+	pageSize := opts.PageSizeOrDefault()
+	var rows []messageRow
+	err := d.messageQuery(opts).
+		Order(messageOrder).
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &apimodel.TuneMessageList{
+		Messages:   apiMessagesFromRows(rows),
+		Pagination: newPagination(page, pageSize, totalCount),
+	}, nil
+}
+
+// TuneMessages returns all parser messages of the tune that match the
+// filters of the options, the paging options are ignored.
+func (d *Service) TuneMessages(
+	tuneID uuid.UUID,
+	opts common.MessageListOptions,
+) ([]apimodel.TuneMessage, error) {
+	if err := d.db.Select("id").First(&model.Tune{}, tuneID).Error; err != nil {
+		return nil, common.ErrNotFound
+	}
+
+	var rows []messageRow
+	err := d.messageQuery(opts).
+		Where("parser_messages.tune_id = ?", tuneID).
+		Order(messageOrder).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return apiMessagesFromRows(rows), nil
+}
+
+// UpdateMessage marks the parser message as reviewed by the author or as not reviewed.
+func (d *Service) UpdateMessage(
+	id uuid.UUID,
+	update apimodel.UpdateTuneMessage,
+	author string,
+) (*apimodel.TuneMessage, error) {
+	if err := d.db.First(&model.ParserMessage{}, id).Error; err != nil {
+		return nil, common.ErrNotFound
+	}
+
+	err := d.db.Model(&model.ParserMessage{}).
+		Where("id = ?", id).
+		Updates(reviewUpdateVals(update, author)).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []messageRow
+	err = d.messageQuery(common.MessageListOptions{}).
+		Where("parser_messages.id = ?", id).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiMessagesFromRows(rows)[0], nil
+}
+
+// UpdateTuneMessages marks all parser messages of the tune
+// as reviewed by the author or as not reviewed.
+func (d *Service) UpdateTuneMessages(
+	tuneID uuid.UUID,
+	update apimodel.UpdateTuneMessage,
+	author string,
+) ([]apimodel.TuneMessage, error) {
+	if err := d.db.Select("id").First(&model.Tune{}, tuneID).Error; err != nil {
+		return nil, common.ErrNotFound
+	}
+
+	err := d.db.Model(&model.ParserMessage{}).
+		Where("tune_id = ?", tuneID).
+		Updates(reviewUpdateVals(update, author)).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return d.TuneMessages(tuneID, common.MessageListOptions{})
+}
+
+func (d *Service) messageQuery(opts common.MessageListOptions) *gorm.DB {
+	query := d.db.Model(&model.ParserMessage{}).
+		Select("parser_messages.*, tunes.title AS tune_title").
+		Joins("JOIN tunes ON tunes.id = parser_messages.tune_id")
+
+	if opts.Severity != "" {
+		query = query.Where("parser_messages.severity = ?", opts.Severity)
+	}
+	if opts.Reviewed != nil {
+		query = query.Where("parser_messages.reviewed = ?", *opts.Reviewed)
+	}
+
+	return query
+}
+
+func reviewUpdateVals(update apimodel.UpdateTuneMessage, author string) map[string]any {
+	reviewedBy := ""
+	if *update.Reviewed {
+		reviewedBy = author
+	}
+
+	return map[string]any{
+		"reviewed":    *update.Reviewed,
+		"reviewed_by": reviewedBy,
+	}
+}
+
+func apiMessagesFromRows(rows []messageRow) []apimodel.TuneMessage {
+	messages := make([]apimodel.TuneMessage, len(rows))
+	for i, r := range rows {
+		messages[i] = apimodel.TuneMessage{
+			Id:         r.ID,
+			TuneId:     r.TuneID,
+			TuneTitle:  r.TuneTitle,
+			Measure:    int32(r.Measure),
+			Severity:   r.Severity,
+			Symbol:     r.Symbol,
+			Text:       r.Text,
+			Fix:        r.Fix,
+			Reviewed:   r.Reviewed,
+			ReviewedBy: r.ReviewedBy,
+		}
+	}
+
+	return messages
+}
+
+// replaceParserMessages replaces the parser messages of the tune with the
+// messages of its music model. Messages that are in both stay unchanged,
+// so a message that was reviewed doesn't need to be reviewed again when
+// the tune is imported again with the same problem.
+func (d *Service) replaceParserMessages(tuneID uuid.UUID, t *tune.Tune) error {
+	var existing []model.ParserMessage
+	if err := d.db.Where("tune_id = ?", tuneID).Find(&existing).Error; err != nil {
+		return err
+	}
+	obsolete := map[messageKey][]uuid.UUID{}
+	for _, m := range existing {
+		obsolete[keyOfMessage(m)] = append(obsolete[keyOfMessage(m)], m.ID)
+	}
+
+	var added []model.ParserMessage
+	for _, m := range parserMessagesOf(tuneID, t) {
+		key := keyOfMessage(m)
+		if len(obsolete[key]) > 0 {
+			obsolete[key] = obsolete[key][1:]
+			continue
+		}
+		added = append(added, m)
+	}
+
+	return d.changeParserMessages(obsolete, added)
+}
+
+func (d *Service) changeParserMessages(obsolete map[messageKey][]uuid.UUID, added []model.ParserMessage) error {
+	var obsoleteIDs []uuid.UUID
+	for _, ids := range obsolete {
+		obsoleteIDs = append(obsoleteIDs, ids...)
+	}
+	if len(obsoleteIDs) > 0 {
+		if err := d.db.Where("id IN ?", obsoleteIDs).Delete(&model.ParserMessage{}).Error; err != nil {
+			return err
+		}
+	}
+	if len(added) == 0 {
+		return nil
+	}
+
+	return d.db.Create(&added).Error
+}
+
+// parserMessagesOf returns the parser messages of the music model of the tune,
+// which has no messages if it is nil.
+func parserMessagesOf(tuneID uuid.UUID, t *tune.Tune) []model.ParserMessage {
+	if t == nil {
+		return nil
+	}
+
+	var messages []model.ParserMessage
+	for _, m := range analysis.Messages(tuneID, t) {
+		messages = append(messages, model.ParserMessage{
+			TuneID:   tuneID,
+			Measure:  uint(m.Measure),
+			Severity: m.Severity,
+			Symbol:   m.Symbol,
+			Text:     m.Text,
+			Fix:      m.Fix,
+		})
+	}
+
+	return messages
+}
+
+func keyOfMessage(m model.ParserMessage) messageKey {
+	return messageKey{
+		measure:  m.Measure,
+		severity: m.Severity,
+		symbol:   m.Symbol,
+		text:     m.Text,
+		fix:      m.Fix,
+	}
+}
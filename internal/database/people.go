@@ -0,0 +1,394 @@
+package database
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"gorm.io/gorm"
+	"slices"
+	"strings"
+)
+
+// personColumns are the columns of the tunes with the name of a person
+// and the columns that reference the person.
+var personColumns = []struct {
+	name string
+	id   string
+}{
+	{name: "composer", id: "composer_id"},
+	{name: "arranger", id: "arranger_id"},
+}
+
+func (d *Service) People() ([]*apimodel.Person, error) {
+	var people []*model.Person
+	err := d.db.Preload("Aliases", preloadPersonAliases).
+		Order("name").
+		Find(&people).Error
+	if err != nil {
+		return nil, err
+	}
+
+	apiPeople := make([]*apimodel.Person, len(people))
+	for i, p := range people {
+		apiPeople[i] = apiPersonFromDbPerson(p)
+	}
+
+	return apiPeople, nil
+}
+
+func (d *Service) GetPerson(id uuid.UUID) (*apimodel.Person, error) {
+	person, err := getPerson(d.db.Preload("Aliases", preloadPersonAliases), id)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiPersonFromDbPerson(person), nil
+}
+
+// PersonTunes returns the tunes the person composed or arranged.
+func (d *Service) PersonTunes(
+	id uuid.UUID,
+	opts common.TuneListOptions,
+) (*apimodel.TuneList, error) {
+	if _, err := getPerson(d.db, id); err != nil {
+		return nil, err
+	}
+
+	opts.PersonID = &id
+	return d.Tunes(opts)
+}
+
+// UpdatePerson renames the person and the composer and arranger of the
+// person's tunes. The old name becomes an alias of the person.
+func (d *Service) UpdatePerson(
+	id uuid.UUID,
+	update apimodel.UpdatePerson,
+) (*apimodel.Person, error) {
+	name := normalizeName(update.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name of person must not be empty", common.ErrInvalidArgument)
+	}
+
+	err := d.changePersonTunes([]uuid.UUID{id}, func(tx *gorm.DB) error {
+		return renamePerson(tx, id, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.GetPerson(id)
+}
+
+// MergePeople merges the people of the given ids into the person with the id.
+// Their tunes reference this person and their names and aliases become aliases
+// of it, before they are deleted.
+func (d *Service) MergePeople(
+	id uuid.UUID,
+	merge apimodel.MergePeople,
+) (*apimodel.Person, error) {
+	sourceIDs := common.RemoveDuplicates(merge.PersonIds)
+	if slices.Contains(sourceIDs, id) {
+		return nil, fmt.Errorf("%w: can't merge person %s into itself",
+			common.ErrInvalidArgument, id)
+	}
+
+	err := d.changePersonTunes(sourceIDs, func(tx *gorm.DB) error {
+		return mergePeople(tx, id, sourceIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.GetPerson(id)
+}
+
+// changePersonTunes applies a change of people that changes the composer or
+// arranger of their tunes and records a revision of each of these tunes.
+func (d *Service) changePersonTunes(peopleIDs []uuid.UUID, change func(tx *gorm.DB) error) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		var tuneIDs []uuid.UUID
+		err := tx.Model(&model.Tune{}).
+			Where("composer_id IN ? OR arranger_id IN ?", peopleIDs, peopleIDs).
+			Pluck("id", &tuneIDs).Error
+		if err != nil {
+			return err
+		}
+
+		return d.withTx(tx).changeTunes(tuneIDs, "", func(txService *Service) error {
+			return change(txService.db)
+		})
+	})
+}
+
+// SetPersonAliases replaces the aliases of the person. An alias must
+// neither be the name nor an alias of another person.
+func (d *Service) SetPersonAliases(
+	id uuid.UUID,
+	aliases []string,
+) (*apimodel.Person, error) {
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		person, err := getPerson(tx, id)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Where("person_id = ?", id).Delete(&model.PersonAlias{}).Error
+		if err != nil {
+			return err
+		}
+
+		return createPersonAliases(tx, id, aliasNames(aliases, person.Name))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.GetPerson(id)
+}
+
+// setTunePeople links the tune to the people of its composer and arranger
+// names and replaces the names with the names of these people.
+func (d *Service) setTunePeople(t *model.Tune) error {
+	composer, err := d.getOrCreatePerson(t.Composer)
+	if err != nil {
+		return err
+	}
+	arranger, err := d.getOrCreatePerson(t.Arranger)
+	if err != nil {
+		return err
+	}
+
+	t.Composer, t.ComposerID = personNameAndID(composer)
+	t.Arranger, t.ArrangerID = personNameAndID(arranger)
+
+	return nil
+}
+
+// tunePeopleUpdateVals returns the values to update the composer
+// and arranger of a tune with the people of the given names.
+func (d *Service) tunePeopleUpdateVals(composer string, arranger string) (map[string]any, error) {
+	t := &model.Tune{Composer: composer, Arranger: arranger}
+	if err := d.setTunePeople(t); err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"composer":    t.Composer,
+		"composer_id": t.ComposerID,
+		"arranger":    t.Arranger,
+		"arranger_id": t.ArrangerID,
+	}, nil
+}
+
+// getOrCreatePerson returns the person known by the name or, if there is none,
+// the person whose name matches the name best with fuzzy matching, who then is
+// also known by the name as an alias. A new person is only created if no person
+// matches. It returns nil for an empty name.
+func (d *Service) getOrCreatePerson(name string) (*model.Person, error) {
+	name = normalizeName(name)
+	if name == "" {
+		return nil, nil
+	}
+
+	var people []model.Person
+	if err := d.db.Preload("Aliases").Order("name").Find(&people).Error; err != nil {
+		return nil, err
+	}
+
+	candidates := make([][]string, len(people))
+	for i, p := range people {
+		candidates[i] = personNames(p)
+	}
+	if idx := slices.IndexFunc(candidates, func(names []string) bool {
+		return containsName(names, name)
+	}); idx >= 0 {
+		return &people[idx], nil
+	}
+
+	idx, match := common.BestPersonMatch(name, candidates)
+	if match == common.NoNameMatch {
+		person := &model.Person{Name: name}
+		return person, d.db.Create(person).Error
+	}
+
+	person := &people[idx]
+	return person, createPersonAliases(d.db, person.ID, []string{name})
+}
+
+func renamePerson(tx *gorm.DB, id uuid.UUID, name string) error {
+	person, err := getPerson(tx, id)
+	if err != nil {
+		return err
+	}
+
+	if err = ensureFreePersonName(tx, name, id); err != nil {
+		return err
+	}
+
+	// The new name may have been an alias before
+	err = tx.Where("person_id = ? AND lower(name) = ?", id, strings.ToLower(name)).
+		Delete(&model.PersonAlias{}).Error
+	if err != nil {
+		return err
+	}
+
+	if err = createPersonAliases(tx, id, aliasNames([]string{person.Name}, name)); err != nil {
+		return err
+	}
+
+	if err = tx.Model(person).Update("name", name).Error; err != nil {
+		return err
+	}
+
+	return linkTunesToPerson(tx, []uuid.UUID{id}, person)
+}
+
+func mergePeople(tx *gorm.DB, id uuid.UUID, sourceIDs []uuid.UUID) error {
+	target, err := getPerson(tx, id)
+	if err != nil {
+		return err
+	}
+
+	var sources []model.Person
+	if err = tx.Where("id IN ?", sourceIDs).Find(&sources).Error; err != nil {
+		return err
+	}
+	if len(sources) != len(sourceIDs) {
+		return fmt.Errorf("%w: not all people to merge exist", common.ErrNotFound)
+	}
+
+	if err = linkTunesToPerson(tx, sourceIDs, target); err != nil {
+		return err
+	}
+
+	err = tx.Model(&model.PersonAlias{}).
+		Where("person_id IN ?", sourceIDs).
+		Update("person_id", id).Error
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Where("id IN ?", sourceIDs).Delete(&model.Person{}).Error; err != nil {
+		return err
+	}
+
+	sourceNames := make([]string, len(sources))
+	for i, s := range sources {
+		sourceNames[i] = s.Name
+	}
+
+	return createPersonAliases(tx, id, sourceNames)
+}
+
+// linkTunesToPerson makes the tunes that reference one of the people with
+// the given ids as composer or arranger reference the person.
+func linkTunesToPerson(tx *gorm.DB, peopleIDs []uuid.UUID, person *model.Person) error {
+	for _, column := range personColumns {
+		err := tx.Model(&model.Tune{}).
+			Where(column.id+" IN ?", peopleIDs).
+			Updates(map[string]any{column.name: person.Name, column.id: person.ID}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func createPersonAliases(tx *gorm.DB, id uuid.UUID, names []string) error {
+	for _, name := range names {
+		if err := ensureFreePersonName(tx, name, id); err != nil {
+			return err
+		}
+
+		alias := &model.PersonAlias{Name: name, PersonID: id}
+		if err := tx.Omit("Person").Create(alias).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ensureFreePersonName returns an ErrAlreadyExists error if the name is
+// the name or an alias of another person than the one with the id.
+func ensureFreePersonName(tx *gorm.DB, name string, id uuid.UUID) error {
+	lowerName := strings.ToLower(name)
+
+	var count int64
+	err := tx.Model(&model.Person{}).
+		Where("lower(name) = ? AND id <> ?", lowerName, id).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: person %s", common.ErrAlreadyExists, name)
+	}
+
+	err = tx.Model(&model.PersonAlias{}).
+		Where("lower(name) = ? AND person_id <> ?", lowerName, id).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %s is an alias of another person",
+			common.ErrAlreadyExists, name)
+	}
+
+	return nil
+}
+
+func getPerson(db *gorm.DB, id uuid.UUID) (*model.Person, error) {
+	person := &model.Person{}
+	if err := db.First(person, id).Error; err != nil {
+		return nil, fmt.Errorf("%w: person %s", common.ErrNotFound, id)
+	}
+
+	return person, nil
+}
+
+// personNames returns the name and the aliases of the person.
+func personNames(p model.Person) []string {
+	names := []string{p.Name}
+	for _, a := range p.Aliases {
+		names = append(names, a.Name)
+	}
+
+	return names
+}
+
+// containsName returns true if the names contain the name regardless of its case.
+func containsName(names []string, name string) bool {
+	return slices.ContainsFunc(names, func(n string) bool {
+		return strings.EqualFold(n, name)
+	})
+}
+
+func personNameAndID(p *model.Person) (string, *uuid.UUID) {
+	if p == nil {
+		return "", nil
+	}
+
+	return p.Name, &p.ID
+}
+
+func apiPersonFromDbPerson(p *model.Person) *apimodel.Person {
+	apiPerson := &apimodel.Person{
+		Id:   p.ID,
+		Name: p.Name,
+	}
+	for _, a := range p.Aliases {
+		apiPerson.Aliases = append(apiPerson.Aliases, a.Name)
+	}
+
+	return apiPerson
+}
+
+// preloadPersonAliases preloads the aliases ordered by their name.
+func preloadPersonAliases(db *gorm.DB) *gorm.DB {
+	return db.Order("person_aliases.name")
+}
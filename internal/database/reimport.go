@@ -0,0 +1,393 @@
+package database
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jinzhu/copier"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/messages"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"gorm.io/gorm"
+	"maps"
+)
+
+// reimporter updates the tunes of earlier imports of a file with the
+// tunes of a new version of that file.
+type reimporter struct {
+	service     *Service
+	importFile  *model.ImportFile
+	fileFormat  fileformat.Format
+	tuneMapping map[string]uuid.UUID
+
+	// previousTunes are the tunes of the earlier imports by their title
+	previousTunes map[string]*model.Tune
+}
+
+// withService returns a copy of the reimporter that uses the service,
+// e.g. the service of a transaction.
+func (r *reimporter) withService(service *Service) *reimporter {
+	c := *r
+	c.service = service
+
+	return &c
+}
+
+// reimportTunesToDatabase imports the tunes of a file that was already imported
+// before with other content. Tunes of the earlier imports are updated in place,
+// so that they keep their ids and the sets they are part of. Tunes that don't
+// match a tune of an earlier import are created.
+func (d *Service) reimportTunesToDatabase(
+	parsedTunes []*messages.ParsedTune,
+	fInfo *common.ImportFileInfo,
+) ([]*apimodel.ImportTune, *apimodel.BasicMusicSet, error) {
+	var apiTunes []*apimodel.ImportTune
+	var musicSet *apimodel.BasicMusicSet
+
+	dbTx := func(tx *gorm.DB) error {
+		txService := d.withTx(tx)
+		r := &reimporter{
+			service:     txService,
+			fileFormat:  fInfo.FileFormat,
+			tuneMapping: fInfo.TuneMapping,
+		}
+
+		var err error
+		r.previousTunes, err = txService.previouslyImportedTunes(fInfo.OriginalPath)
+		if err != nil {
+			return err
+		}
+
+		r.importFile, err = txService.createImportFile(fInfo)
+		if err != nil {
+			return err
+		}
+
+		apiTunes, err = r.reimportTunes(parsedTunes)
+		if err != nil {
+			return err
+		}
+
+		musicSet, err = txService.createTaggedMusicSetForTunes(apiTunes, r.importFile, fInfo.Tags)
+		return err
+	}
+
+	if err := d.db.Transaction(dbTx); err != nil {
+		return nil, nil, err
+	}
+
+	return apiTunes, musicSet, nil
+}
+
+// previouslyImportedTunes returns the tunes of all earlier imports of files with
+// the given path by their title. If more than one tune has the same title,
+// the most recently created one is returned.
+func (d *Service) previouslyImportedTunes(originalPath string) (map[string]*model.Tune, error) {
+	importedTuneIDs := d.db.Model(&model.ImportFileTune{}).
+		Select("import_file_tunes.tune_id").
+		Joins("JOIN import_files ON import_files.id = import_file_tunes.import_file_id").
+		Where("import_files.original_path = ?", originalPath)
+
+	var tunes []*model.Tune
+	err := d.db.Preload("TuneType").
+		Where("id IN (?)", importedTuneIDs).
+		Order("created_at").
+		Find(&tunes).Error
+	if err != nil {
+		return nil, err
+	}
+
+	byTitle := make(map[string]*model.Tune, len(tunes))
+	for _, t := range tunes {
+		byTitle[t.Title] = t
+	}
+
+	return byTitle, nil
+}
+
+// reimportTunes reimports the parsed tunes and links them to the import file.
+func (r *reimporter) reimportTunes(
+	parsedTunes []*messages.ParsedTune,
+) ([]*apimodel.ImportTune, error) {
+	var apiTunes []*apimodel.ImportTune
+	for _, pTune := range parsedTunes {
+		importTune, err := r.reimportTune(pTune)
+		if err != nil {
+			return nil, err
+		}
+		apiTunes = append(apiTunes, importTune)
+	}
+
+	return apiTunes, linkImportFileTunes(r.service.db, r.importFile.ID, apiTunes)
+}
+
+// reimportTune updates the tune that matches the parsed tune or creates
+// a new one if there is no matching tune.
+func (r *reimporter) reimportTune(pTune *messages.ParsedTune) (*apimodel.ImportTune, error) {
+	existing, err := r.target(pTune)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return r.createTune(pTune)
+	}
+
+	var changes []string
+	err = r.service.changeTune(existing.ID, "", func(tx *Service) error {
+		changes, err = r.withService(tx).updateTune(existing, pTune)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	updated := &model.Tune{}
+	if err = r.service.db.Preload("TuneType").First(updated, existing.ID).Error; err != nil {
+		return nil, err
+	}
+	importTune := &apimodel.ImportTune{}
+	if err = copier.Copy(importTune, updated); err != nil {
+		return nil, err
+	}
+	if updated.TuneType != nil {
+		importTune.Type = updated.TuneType.Name
+	}
+	setMessagesToAPITune(importTune, pTune.Tune)
+
+	importTune.Action = model.ReimportActionUnchanged
+	if len(changes) > 0 {
+		importTune.Action = model.ReimportActionUpdated
+		importTune.Changes = changes
+	}
+
+	return importTune, nil
+}
+
+// target returns the tune that is updated by the parsed tune. A tune of the
+// mapping has precedence over a tune of an earlier import with the same title.
+// It returns nil if there is no such tune.
+func (r *reimporter) target(pTune *messages.ParsedTune) (*model.Tune, error) {
+	title := pTune.Tune.Title
+	tuneID, ok := r.tuneMapping[title]
+	if !ok {
+		return r.previousTunes[title], nil
+	}
+
+	t := &model.Tune{}
+	err := r.service.db.Preload("TuneType").First(t, tuneID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: tune %s which is mapped to %s doesn't exist",
+			common.ErrInvalidArgument, tuneID, title)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// createTune creates a tune for a parsed tune that doesn't match a tune of
+// an earlier import. If there is already a tune with the same data, that tune
+// is reported as unchanged.
+func (r *reimporter) createTune(pTune *messages.ParsedTune) (*apimodel.ImportTune, error) {
+	existingTune, err := r.service.getImportTuneBySingleFileData(pTune.TuneFileData)
+	if err != nil {
+		return nil, err
+	}
+	if existingTune != nil {
+		existingTune.Action = model.ReimportActionUnchanged
+		return existingTune, nil
+	}
+
+	importTune, err := r.service.importTune(pTune, r.importFile, r.fileFormat)
+	if err != nil {
+		return nil, err
+	}
+	importTune.Action = model.ReimportActionCreated
+
+	return importTune, nil
+}
+
+// updateTune updates the fields and the files of the tune with the parsed tune
+// and returns the names of the fields and files that changed.
+func (r *reimporter) updateTune(t *model.Tune, pTune *messages.ParsedTune) ([]string, error) {
+	updateVals, changes, err := r.changedFields(t, pTune)
+	if err != nil {
+		return nil, err
+	}
+	if len(updateVals) > 0 {
+		if err = r.service.db.Model(t).Updates(updateVals).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	fileChanges, err := r.updateTuneFiles(t.ID, pTune)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(changes, fileChanges...), nil
+}
+
+// updateTuneFiles replaces the files of the tune with the files of the parsed
+// tune and returns the names of the formats whose files changed.
+func (r *reimporter) updateTuneFiles(tuneID uuid.UUID, pTune *messages.ParsedTune) ([]string, error) {
+	tuneFiles, err := parsedTuneFiles(pTune, r.fileFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []string
+	for _, tf := range tuneFiles {
+		changed, err := r.service.replaceTuneFile(tuneID, tf)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			changes = append(changes, common.FileFormatName(tf.Format))
+		}
+	}
+
+	return changes, nil
+}
+
+// changedFields returns the database values of the tune fields that differ
+// from the parsed tune and the API names of these fields.
+func (r *reimporter) changedFields(
+	t *model.Tune,
+	pTune *messages.ParsedTune,
+) (map[string]any, []string, error) {
+	fields := []struct {
+		name    string
+		column  string
+		current string
+		parsed  string
+	}{
+		{"title", "title", t.Title, pTune.Tune.Title},
+		{"timeSig", "time_sig", t.TimeSig, timeSigDisplayStringFromTune(pTune.Tune)},
+	}
+
+	updateVals := map[string]any{}
+	var changes []string
+	for _, f := range fields {
+		if f.current != f.parsed {
+			updateVals[f.column] = f.parsed
+			changes = append(changes, f.name)
+		}
+	}
+
+	peopleChanges, err := r.changedPeople(t, pTune.Tune, updateVals)
+	if err != nil {
+		return nil, nil, err
+	}
+	changes = append(changes, peopleChanges...)
+
+	typeChanged, tuneType, err := r.changedTuneType(t, pTune.Tune.Type)
+	if err != nil {
+		return nil, nil, err
+	}
+	if typeChanged {
+		updateVals["tune_type_id"] = tuneType
+		changes = append(changes, "type")
+	}
+
+	return updateVals, changes, nil
+}
+
+// changedPeople adds the values of the composer and arranger to the update
+// values if the parsed tune links to other people than the tune. It returns the
+// names of the fields whose names changed. A spelling of the same person's
+// name isn't a change, as it only becomes an alias of the person.
+func (r *reimporter) changedPeople(
+	t *model.Tune,
+	parsed *tune.Tune,
+	updateVals map[string]any,
+) ([]string, error) {
+	peopleVals, err := r.service.tunePeopleUpdateVals(parsed.Composer, parsed.Arranger)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := []struct {
+		name    string
+		current string
+	}{
+		{"composer", t.Composer},
+		{"arranger", t.Arranger},
+	}
+
+	var changes []string
+	for _, f := range fields {
+		if peopleVals[f.name] != f.current {
+			changes = append(changes, f.name)
+		}
+	}
+	if len(changes) > 0 || !samePeople(t, peopleVals) {
+		maps.Copy(updateVals, peopleVals)
+	}
+
+	return changes, nil
+}
+
+// samePeople returns true if the tune references the people of the update values.
+func samePeople(t *model.Tune, peopleVals map[string]any) bool {
+	return equalIDs(t.ComposerID, peopleVals["composer_id"].(*uuid.UUID)) &&
+		equalIDs(t.ArrangerID, peopleVals["arranger_id"].(*uuid.UUID))
+}
+
+func equalIDs(a *uuid.UUID, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+// changedTuneType returns whether the tune type changed and the id of the new
+// tune type, which is nil if the parsed tune has no type.
+func (r *reimporter) changedTuneType(t *model.Tune, typeName string) (bool, *uuid.UUID, error) {
+	if typeName == "" {
+		return t.TuneTypeID != nil, nil, nil
+	}
+
+	tuneType, err := r.service.getOrCreateTuneType(typeName)
+	if err != nil {
+		return false, nil, err
+	}
+	// The name may be an alias of the current tune type
+	if t.TuneTypeID != nil && *t.TuneTypeID == tuneType.ID {
+		return false, nil, nil
+	}
+
+	return true, &tuneType.ID, nil
+}
+
+// replaceTuneFile stores the tune file for the tune and replaces an existing
+// file of the same format. It returns false if the existing file has the same data.
+func (d *Service) replaceTuneFile(tuneID uuid.UUID, tFile *model.TuneFile) (bool, error) {
+	tFile.TuneID = tuneID
+
+	existing := &model.TuneFile{}
+	err := d.tuneFileQuery(tFile).First(existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return true, d.db.Create(tFile).Error
+	}
+	if err != nil {
+		return false, err
+	}
+	if bytes.Equal(existing.Data, tFile.Data) {
+		return false, nil
+	}
+
+	return true, d.tuneFileQuery(tFile).Update("data", tFile.Data).Error
+}
+
+func (d *Service) tuneFileQuery(tFile *model.TuneFile) *gorm.DB {
+	return d.db.Model(&model.TuneFile{}).
+		Where("tune_id = ? AND format = ? AND single_tune_data = ?",
+			tFile.TuneID, tFile.Format, tFile.SingleTuneData)
+}
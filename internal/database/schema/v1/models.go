@@ -0,0 +1,122 @@
+// Package v1 contains the database models as they were created by the first
+// migration. They must not be changed, as the migration has to create the same
+// schema, no matter how the models of the model package evolve.
+package v1
+
+import (
+	"github.com/SamuelTissot/sqltime"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+)
+
+type BaseModel struct {
+	ID        uuid.UUID    `gorm:"type:uuid"`
+	CreatedAt sqltime.Time `gorm:"type:timestamp"`
+	UpdatedAt sqltime.Time `gorm:"type:timestamp"`
+}
+
+type MusicSet struct {
+	BaseModel
+	Title        string
+	Description  string
+	Creator      string
+	Tunes        []Tune `gorm:"many2many:music_set_tunes;constraint:OnUpdate:CASCADE;OnDelete:RESTRICT"`
+	ImportFileID uuid.UUID
+}
+
+type Tune struct {
+	BaseModel
+	Title        string
+	TuneTypeID   *uuid.UUID
+	TuneType     *TuneType
+	TimeSig      string
+	Composer     string
+	Arranger     string
+	Sets         []MusicSet     `gorm:"many2many:music_set_tunes;constraint:OnUpdate:CASCADE;"`
+	Files        []*TuneFile    `gorm:"constraint:OnDelete:CASCADE;"`
+	Revisions    []TuneRevision `gorm:"constraint:OnDelete:CASCADE;"`
+	ImportFileID uuid.UUID
+}
+
+type MusicSetTunes struct {
+	BaseModel
+	MusicSetID uuid.UUID
+	MusicSet   MusicSet
+	TuneID     uuid.UUID
+	Tune       Tune
+	Order      uint `gorm:"not null"`
+}
+
+type TuneFile struct {
+	TuneID         uuid.UUID         `gorm:"primaryKey"`
+	Format         fileformat.Format `gorm:"primaryKey"`
+	SingleTuneData bool              `gorm:"primaryKey"`
+	Data           []byte
+}
+
+type ImportFile struct {
+	BaseModel
+	Name         string
+	OriginalPath string
+	Hash         string
+	Data         []byte
+	ImportJobID  *uuid.UUID `gorm:"type:uuid;index"`
+}
+
+type TuneType struct {
+	BaseModel
+	Name string `gorm:"unique"`
+}
+
+type ImportJob struct {
+	BaseModel
+	Status       string `gorm:"index"`
+	FileName     string
+	FileFormat   fileformat.Format
+	Hash         string
+	Data         []byte
+	SetPerFolder bool
+	Reimport     bool
+	TuneMapping  map[string]uuid.UUID `gorm:"serializer:json"`
+	FilesTotal   uint
+	FilesDone    uint
+	Error        string
+	Report       []byte
+	StartedAt    *sqltime.Time `gorm:"type:timestamp"`
+	FinishedAt   *sqltime.Time `gorm:"type:timestamp"`
+}
+
+type TuneRevision struct {
+	BaseModel
+	TuneID   uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_tune_revision_number"`
+	Number   uint      `gorm:"uniqueIndex:idx_tune_revision_number"`
+	Author   string
+	Title    string
+	Type     string
+	TimeSig  string
+	Composer string
+	Arranger string
+	Files    []TuneRevisionFile `gorm:"constraint:OnDelete:CASCADE;"`
+}
+
+type TuneRevisionFile struct {
+	TuneRevisionID uuid.UUID         `gorm:"primaryKey"`
+	Format         fileformat.Format `gorm:"primaryKey"`
+	SingleTuneData bool              `gorm:"primaryKey"`
+	Data           []byte
+}
+
+// Tables returns all models in the order they have to be created.
+func Tables() []any {
+	return []any{
+		&MusicSet{},
+		&Tune{},
+		&MusicSetTunes{},
+		&TuneFile{},
+		&ImportFile{},
+		&TuneType{},
+		&ImportJob{},
+		&TuneRevision{},
+		&TuneRevisionFile{},
+	}
+}
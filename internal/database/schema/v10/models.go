@@ -0,0 +1,16 @@
+// Package v10 contains the database models as they were changed by the tenth
+// migration, which marks the tune files that were exported from the music model.
+package v10
+
+import (
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+)
+
+type TuneFile struct {
+	TuneID         uuid.UUID         `gorm:"primaryKey"`
+	Format         fileformat.Format `gorm:"primaryKey"`
+	SingleTuneData bool              `gorm:"primaryKey"`
+	Data           []byte
+	Exported       bool
+}
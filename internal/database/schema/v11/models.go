@@ -0,0 +1,29 @@
+// Package v11 contains the database models as they were changed by the
+// eleventh migration, which links the imports to the tunes of their reports.
+package v11
+
+import (
+	"github.com/SamuelTissot/sqltime"
+	"github.com/google/uuid"
+)
+
+type BaseModel struct {
+	ID        uuid.UUID    `gorm:"type:uuid"`
+	CreatedAt sqltime.Time `gorm:"type:timestamp"`
+	UpdatedAt sqltime.Time `gorm:"type:timestamp"`
+}
+
+type Tune struct {
+	BaseModel
+}
+
+type ImportFile struct {
+	BaseModel
+}
+
+type ImportFileTune struct {
+	ImportFileID uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	ImportFile   ImportFile `gorm:"constraint:OnDelete:CASCADE"`
+	TuneID       uuid.UUID  `gorm:"type:uuid;primaryKey;index"`
+	Tune         Tune       `gorm:"constraint:OnDelete:CASCADE"`
+}
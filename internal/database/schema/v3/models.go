@@ -0,0 +1,64 @@
+// Package v3 contains the database models as they were changed by the third
+// migration, which adds the users and the owners of tunes, sets and imports.
+// Only the new tables and columns are part of it.
+package v3
+
+import (
+	"github.com/SamuelTissot/sqltime"
+	"github.com/google/uuid"
+)
+
+type BaseModel struct {
+	ID        uuid.UUID    `gorm:"type:uuid"`
+	CreatedAt sqltime.Time `gorm:"type:timestamp"`
+	UpdatedAt sqltime.Time `gorm:"type:timestamp"`
+}
+
+type User struct {
+	BaseModel
+	Name       string  `gorm:"uniqueIndex"`
+	ExternalID *string `gorm:"uniqueIndex"`
+}
+
+type APIToken struct {
+	BaseModel
+	UserID     uuid.UUID `gorm:"type:uuid;index"`
+	Name       string
+	Hash       string        `gorm:"uniqueIndex"`
+	LastUsedAt *sqltime.Time `gorm:"type:timestamp"`
+}
+
+type Tune struct {
+	OwnerID *uuid.UUID `gorm:"type:uuid;index"`
+}
+
+type MusicSet struct {
+	Private bool       `gorm:"not null;default:false"`
+	OwnerID *uuid.UUID `gorm:"type:uuid;index"`
+}
+
+type ImportJob struct {
+	OwnerID *uuid.UUID `gorm:"type:uuid;index"`
+}
+
+type ImportFile struct {
+	OwnerID *uuid.UUID `gorm:"type:uuid;index"`
+}
+
+// Tables returns the new tables in the order they have to be created.
+func Tables() []any {
+	return []any{
+		&User{},
+		&APIToken{},
+	}
+}
+
+// OwnedTables returns the tables that get an owner.
+func OwnedTables() []any {
+	return []any{
+		&Tune{},
+		&MusicSet{},
+		&ImportJob{},
+		&ImportFile{},
+	}
+}
@@ -0,0 +1,20 @@
+// Package v4 contains the database models as they were changed by the fourth
+// migration, which adds the memberships of the users with their roles.
+package v4
+
+import (
+	"github.com/SamuelTissot/sqltime"
+	"github.com/google/uuid"
+)
+
+type BaseModel struct {
+	ID        uuid.UUID    `gorm:"type:uuid"`
+	CreatedAt sqltime.Time `gorm:"type:timestamp"`
+	UpdatedAt sqltime.Time `gorm:"type:timestamp"`
+}
+
+type Membership struct {
+	BaseModel
+	UserID uuid.UUID `gorm:"type:uuid;uniqueIndex"`
+	Role   string    `gorm:"not null"`
+}
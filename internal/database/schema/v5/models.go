@@ -0,0 +1,55 @@
+// Package v5 contains the database models as they were changed by the fifth
+// migration, which adds the tags of tunes and sets and the auto tagging of imports.
+package v5
+
+import (
+	"github.com/SamuelTissot/sqltime"
+	"github.com/google/uuid"
+)
+
+type BaseModel struct {
+	ID        uuid.UUID    `gorm:"type:uuid"`
+	CreatedAt sqltime.Time `gorm:"type:timestamp"`
+	UpdatedAt sqltime.Time `gorm:"type:timestamp"`
+}
+
+type Tune struct {
+	BaseModel
+}
+
+type MusicSet struct {
+	BaseModel
+}
+
+type Tag struct {
+	BaseModel
+	Name        string `gorm:"uniqueIndex"`
+	Description string
+}
+
+type TuneTag struct {
+	TuneID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Tune   Tune      `gorm:"constraint:OnDelete:CASCADE"`
+	TagID  uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Tag    Tag       `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+type MusicSetTag struct {
+	MusicSetID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	MusicSet   MusicSet  `gorm:"constraint:OnDelete:CASCADE"`
+	TagID      uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Tag        Tag       `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+type ImportJob struct {
+	AutoTag bool `gorm:"not null;default:false"`
+}
+
+// Tables returns the new tables in the order they have to be created.
+func Tables() []any {
+	return []any{
+		&Tag{},
+		&TuneTag{},
+		&MusicSetTag{},
+	}
+}
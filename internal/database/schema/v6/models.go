@@ -0,0 +1,25 @@
+// Package v6 contains the database models as they were changed by the sixth
+// migration, which adds the aliases of tune types.
+package v6
+
+import (
+	"github.com/SamuelTissot/sqltime"
+	"github.com/google/uuid"
+)
+
+type BaseModel struct {
+	ID        uuid.UUID    `gorm:"type:uuid"`
+	CreatedAt sqltime.Time `gorm:"type:timestamp"`
+	UpdatedAt sqltime.Time `gorm:"type:timestamp"`
+}
+
+type TuneType struct {
+	BaseModel
+}
+
+type TuneTypeAlias struct {
+	BaseModel
+	Name       string    `gorm:"uniqueIndex"`
+	TuneTypeID uuid.UUID `gorm:"type:uuid;index"`
+	TuneType   TuneType  `gorm:"constraint:OnDelete:CASCADE"`
+}
@@ -0,0 +1,53 @@
+// Package v7 contains the database models as they were changed by the seventh
+// migration, which adds the people that tunes reference as composer and arranger.
+package v7
+
+import (
+	"github.com/SamuelTissot/sqltime"
+	"github.com/google/uuid"
+)
+
+type BaseModel struct {
+	ID        uuid.UUID    `gorm:"type:uuid"`
+	CreatedAt sqltime.Time `gorm:"type:timestamp"`
+	UpdatedAt sqltime.Time `gorm:"type:timestamp"`
+}
+
+type Tune struct {
+	BaseModel
+	ComposerID *uuid.UUID `gorm:"type:uuid;index"`
+	Composer   string
+	ArrangerID *uuid.UUID `gorm:"type:uuid;index"`
+	Arranger   string
+}
+
+type Person struct {
+	BaseModel
+	Name string `gorm:"uniqueIndex"`
+}
+
+type PersonAlias struct {
+	BaseModel
+	Name     string    `gorm:"uniqueIndex"`
+	PersonID uuid.UUID `gorm:"type:uuid;index"`
+	Person   Person    `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// Tables returns the new tables in the order they have to be created.
+func Tables() []any {
+	return []any{
+		&Person{},
+		&PersonAlias{},
+	}
+}
+
+// PersonColumns are the columns of the tunes with the name of a person and
+// the columns that reference the person with the field of these columns.
+var PersonColumns = []struct {
+	Name    string
+	ID      string
+	IDField string
+}{
+	{Name: "composer", ID: "composer_id", IDField: "ComposerID"},
+	{Name: "arranger", ID: "arranger_id", IDField: "ArrangerID"},
+}
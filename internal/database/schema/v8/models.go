@@ -0,0 +1,27 @@
+// Package v8 contains the database models as they were changed by the eighth
+// migration, which adds the fingerprints of the music of the tunes.
+package v8
+
+import (
+	"github.com/SamuelTissot/sqltime"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+)
+
+type BaseModel struct {
+	ID        uuid.UUID    `gorm:"type:uuid"`
+	CreatedAt sqltime.Time `gorm:"type:timestamp"`
+	UpdatedAt sqltime.Time `gorm:"type:timestamp"`
+}
+
+type Tune struct {
+	BaseModel
+	Fingerprint string
+}
+
+type TuneFile struct {
+	TuneID         uuid.UUID         `gorm:"primaryKey"`
+	Format         fileformat.Format `gorm:"primaryKey"`
+	SingleTuneData bool              `gorm:"primaryKey"`
+	Data           []byte
+}
@@ -0,0 +1,39 @@
+// Package v9 contains the database models as they were changed by the ninth
+// migration, which stores the messages of the parser about the imported tunes.
+package v9
+
+import (
+	"github.com/SamuelTissot/sqltime"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+)
+
+type BaseModel struct {
+	ID        uuid.UUID    `gorm:"type:uuid"`
+	CreatedAt sqltime.Time `gorm:"type:timestamp"`
+	UpdatedAt sqltime.Time `gorm:"type:timestamp"`
+}
+
+type Tune struct {
+	BaseModel
+}
+
+type ParserMessage struct {
+	BaseModel
+	TuneID     uuid.UUID `gorm:"type:uuid;index"`
+	Tune       Tune      `gorm:"constraint:OnDelete:CASCADE"`
+	Measure    uint
+	Severity   string `gorm:"index"`
+	Symbol     string
+	Text       string
+	Fix        string
+	Reviewed   bool `gorm:"index"`
+	ReviewedBy string
+}
+
+type TuneFile struct {
+	TuneID         uuid.UUID         `gorm:"primaryKey"`
+	Format         fileformat.Format `gorm:"primaryKey"`
+	SingleTuneData bool              `gorm:"primaryKey"`
+	Data           []byte
+}
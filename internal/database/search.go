@@ -0,0 +1,301 @@
+package database
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"gorm.io/gorm"
+	"html"
+	"slices"
+	"strings"
+)
+
+const (
+	highlightStart = "<mark>"
+	highlightStop  = "</mark>"
+)
+
+// matchStart and matchStop enclose the matches in the stored texts. The texts
+// are HTML escaped before the markers are replaced by the highlight tags,
+// so markup of the users is never returned as markup.
+const (
+	matchStart = "\x02"
+	matchStop  = "\x03"
+)
+
+// The search documents of tunes and sets. The same expressions are used
+// for the full text indexes, so they must not be changed without changing
+// the indexes.
+const (
+	tuneSearchDocument = `to_tsvector('simple', coalesce(tunes.title, '') || ' ' || ` +
+		`coalesce(tunes.composer, '') || ' ' || coalesce(tunes.arranger, ''))`
+	tuneTypeSearchDocument = `to_tsvector('simple', coalesce(tune_types.name, ''))`
+	setSearchDocument      = `to_tsvector('simple', coalesce(music_sets.title, '') || ' ' || ` +
+		`coalesce(music_sets.description, '') || ' ' || coalesce(music_sets.creator, ''))`
+)
+
+var searchIndexStatements = []string{
+	`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+	`CREATE INDEX IF NOT EXISTS idx_tunes_search ON tunes USING GIN (` + tuneSearchDocument + `)`,
+	`CREATE INDEX IF NOT EXISTS idx_tunes_title_trgm ON tunes USING GIN (title gin_trgm_ops)`,
+	`CREATE INDEX IF NOT EXISTS idx_tune_types_search ON tune_types USING GIN (` + tuneTypeSearchDocument + `)`,
+	`CREATE INDEX IF NOT EXISTS idx_music_sets_search ON music_sets USING GIN (` + setSearchDocument + `)`,
+	`CREATE INDEX IF NOT EXISTS idx_music_sets_title_trgm ON music_sets USING GIN (title gin_trgm_ops)`,
+}
+
+var searchIndexNames = []string{
+	"idx_tunes_search",
+	"idx_tunes_title_trgm",
+	"idx_tune_types_search",
+	"idx_music_sets_search",
+	"idx_music_sets_title_trgm",
+}
+
+// The headline options for ts_headline. MaxFragments makes long descriptions
+// only return the fragments around the matches.
+var searchHeadlineOptions = fmt.Sprintf(
+	`StartSel="%s", StopSel="%s", MaxFragments=2, MaxWords=20, MinWords=5`,
+	matchStart, matchStop,
+)
+
+// The matching tunes are found either by the full text search with prefix
+// matching of every word or by the trigram similarity of the title,
+// which also finds misspelled titles.
+const tuneSearchQuery = `SELECT tunes.id, tunes.title,
+	ts_rank(` + tuneSearchDocument + `, q.query) +
+		ts_rank(` + tuneTypeSearchDocument + `, q.query) +
+		word_similarity(@term, tunes.title) AS rank,
+	ts_headline('simple', coalesce(tunes.title, ''), q.query, @headline) AS title_highlight,
+	ts_headline('simple', coalesce(tunes.composer, ''), q.query, @headline) AS composer_highlight,
+	ts_headline('simple', coalesce(tunes.arranger, ''), q.query, @headline) AS arranger_highlight,
+	ts_headline('simple', coalesce(tune_types.name, ''), q.query, @headline) AS type_highlight
+FROM tunes
+	LEFT JOIN tune_types ON tune_types.id = tunes.tune_type_id
+	CROSS JOIN to_tsquery('simple', @tsquery) AS q(query)
+WHERE ` + tuneSearchDocument + ` @@ q.query
+	OR ` + tuneTypeSearchDocument + ` @@ q.query
+	OR tunes.title % @term
+	OR @term <% tunes.title
+ORDER BY rank DESC, tunes.title
+LIMIT @limit`
+
+const setSearchQuery = `SELECT music_sets.id, music_sets.title,
+	ts_rank(` + setSearchDocument + `, q.query) +
+		word_similarity(@term, music_sets.title) AS rank,
+	ts_headline('simple', coalesce(music_sets.title, ''), q.query, @headline) AS title_highlight,
+	ts_headline('simple', coalesce(music_sets.description, ''), q.query, @headline) AS description_highlight,
+	ts_headline('simple', coalesce(music_sets.creator, ''), q.query, @headline) AS creator_highlight
+FROM music_sets
+	CROSS JOIN to_tsquery('simple', @tsquery) AS q(query)
+WHERE (` + setSearchDocument + ` @@ q.query
+		OR music_sets.title % @term
+		OR @term <% music_sets.title)
+	AND (NOT music_sets.private OR music_sets.owner_id = @user)
+ORDER BY rank DESC, music_sets.title
+LIMIT @limit`
+
+type tuneSearchRow struct {
+	ID                uuid.UUID
+	Title             string
+	Rank              float64
+	TitleHighlight    string
+	ComposerHighlight string
+	ArrangerHighlight string
+	TypeHighlight     string
+}
+
+type setSearchRow struct {
+	ID                   uuid.UUID
+	Title                string
+	Rank                 float64
+	TitleHighlight       string
+	DescriptionHighlight string
+	CreatorHighlight     string
+}
+
+// createSearchIndexes creates the full text indexes. SQLite doesn't have
+// them, as it is searched in memory.
+func createSearchIndexes(db *gorm.DB) error {
+	if isSQLite(db) {
+		return nil
+	}
+
+	for _, stmt := range searchIndexStatements {
+		if err := db.Exec(stmt).Error; err != nil {
+			return fmt.Errorf("failed creating search index: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func dropSearchIndexes(db *gorm.DB) error {
+	if isSQLite(db) {
+		return nil
+	}
+
+	for _, name := range searchIndexNames {
+		if err := db.Exec("DROP INDEX IF EXISTS " + name).Error; err != nil {
+			return fmt.Errorf("failed dropping search index %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (d *Service) Search(opts common.SearchOptions) (*apimodel.SearchResult, error) {
+	terms := opts.SearchTerms()
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("%w: search query '%s' contains no words",
+			common.ErrInvalidArgument, opts.Query)
+	}
+
+	queryArgs := map[string]any{
+		"term":     strings.Join(terms, " "),
+		"tsquery":  prefixTsQuery(terms),
+		"headline": searchHeadlineOptions,
+		"limit":    opts.LimitOrDefault(),
+		"user":     opts.UserID,
+	}
+
+	var hits []apimodel.SearchHit
+	if opts.IncludesKind(common.SearchKindTune) {
+		tuneHits, err := d.searchTunes(terms, queryArgs)
+		if err != nil {
+			return nil, err
+		}
+		hits = append(hits, tuneHits...)
+	}
+	if opts.IncludesKind(common.SearchKindSet) {
+		setHits, err := d.searchSets(terms, queryArgs, opts.UserID)
+		if err != nil {
+			return nil, err
+		}
+		hits = append(hits, setHits...)
+	}
+
+	return &apimodel.SearchResult{
+		Query: opts.Query,
+		Hits:  bestSearchHits(hits, opts.LimitOrDefault()),
+	}, nil
+}
+
+func (d *Service) searchTunes(
+	terms []string,
+	queryArgs map[string]any,
+) ([]apimodel.SearchHit, error) {
+	if isSQLite(d.db) {
+		return d.searchTunesInMemory(terms)
+	}
+
+	var rows []tuneSearchRow
+	if err := d.db.Raw(tuneSearchQuery, queryArgs).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed searching tunes: %w", err)
+	}
+
+	hits := make([]apimodel.SearchHit, len(rows))
+	for i, r := range rows {
+		hits[i] = apimodel.SearchHit{
+			Kind:  string(common.SearchKindTune),
+			Id:    r.ID,
+			Title: r.Title,
+			Rank:  r.Rank,
+			Highlights: highlightedFields(map[string]string{
+				"title":    r.TitleHighlight,
+				"composer": r.ComposerHighlight,
+				"arranger": r.ArrangerHighlight,
+				"type":     r.TypeHighlight,
+			}),
+		}
+	}
+
+	return hits, nil
+}
+
+func (d *Service) searchSets(
+	terms []string,
+	queryArgs map[string]any,
+	userID *uuid.UUID,
+) ([]apimodel.SearchHit, error) {
+	if isSQLite(d.db) {
+		return d.searchSetsInMemory(terms, userID)
+	}
+
+	var rows []setSearchRow
+	if err := d.db.Raw(setSearchQuery, queryArgs).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed searching sets: %w", err)
+	}
+
+	hits := make([]apimodel.SearchHit, len(rows))
+	for i, r := range rows {
+		hits[i] = apimodel.SearchHit{
+			Kind:  string(common.SearchKindSet),
+			Id:    r.ID,
+			Title: r.Title,
+			Rank:  r.Rank,
+			Highlights: highlightedFields(map[string]string{
+				"title":       r.TitleHighlight,
+				"description": r.DescriptionHighlight,
+				"creator":     r.CreatorHighlight,
+			}),
+		}
+	}
+
+	return hits, nil
+}
+
+// prefixTsQuery returns a tsquery that matches documents which contain all
+// terms, where each term may also be the beginning of a longer word.
+// The terms must only contain letters and digits.
+func prefixTsQuery(terms []string) string {
+	prefixTerms := make([]string, len(terms))
+	for i, t := range terms {
+		prefixTerms[i] = t + ":*"
+	}
+
+	return strings.Join(prefixTerms, " & ")
+}
+
+// highlightedFields returns only the fields that contain a match as HTML
+// with the highlighted matches. It returns nil if no field contains a match.
+func highlightedFields(fields map[string]string) map[string]string {
+	var highlights map[string]string
+	for field, text := range fields {
+		if !strings.Contains(text, matchStart) {
+			continue
+		}
+		if highlights == nil {
+			highlights = map[string]string{}
+		}
+		highlights[field] = highlightHTML(text)
+	}
+
+	return highlights
+}
+
+// highlightHTML returns the HTML escaped text with the marked matches
+// enclosed in the highlight tags.
+func highlightHTML(text string) string {
+	return strings.NewReplacer(matchStart, highlightStart, matchStop, highlightStop).
+		Replace(html.EscapeString(text))
+}
+
+// bestSearchHits sorts the hits by their rank and returns at most limit hits.
+func bestSearchHits(hits []apimodel.SearchHit, limit int) []apimodel.SearchHit {
+	slices.SortStableFunc(hits, func(a, b apimodel.SearchHit) int {
+		if a.Rank > b.Rank {
+			return -1
+		}
+		if a.Rank < b.Rank {
+			return 1
+		}
+		return strings.Compare(a.Title, b.Title)
+	})
+
+	if hits == nil {
+		return []apimodel.SearchHit{}
+	}
+
+	return hits[:min(len(hits), limit)]
+}
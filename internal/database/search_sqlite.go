@@ -0,0 +1,215 @@
+package database
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// SQLite has neither the full text search nor the trigram similarity of
+// PostgreSQL, so there all tunes and sets are matched in memory by the same
+// rules: every term must be the beginning of a word of the object or the
+// title must be similar to the search terms.
+
+// trigramSimilarityThreshold is the default similarity threshold of pg_trgm.
+const trigramSimilarityThreshold = 0.3
+
+// prefixMatchRank is added to the rank of objects that contain all terms,
+// so they rank above objects that only have a similar title.
+const prefixMatchRank = 1.0
+
+var searchWordPattern = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+type tuneSearchFields struct {
+	ID       uuid.UUID
+	Title    string
+	Composer string
+	Arranger string
+	TypeName string
+}
+
+type setSearchFields struct {
+	ID          uuid.UUID
+	Title       string
+	Description string
+	Creator     string
+}
+
+func (d *Service) searchTunesInMemory(terms []string) ([]apimodel.SearchHit, error) {
+	var rows []tuneSearchFields
+	err := d.db.Model(&model.Tune{}).
+		Select("tunes.id, tunes.title, tunes.composer, tunes.arranger, " +
+			"coalesce(tune_types.name, '') AS type_name").
+		Joins("LEFT JOIN tune_types ON tune_types.id = tunes.tune_type_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed searching tunes: %w", err)
+	}
+
+	var hits []apimodel.SearchHit
+	for _, r := range rows {
+		hit, ok := matchSearchTerms(terms, r.Title, map[string]string{
+			"title":    r.Title,
+			"composer": r.Composer,
+			"arranger": r.Arranger,
+			"type":     r.TypeName,
+		})
+		if ok {
+			hit.Kind = string(common.SearchKindTune)
+			hit.Id = r.ID
+			hits = append(hits, hit)
+		}
+	}
+
+	return hits, nil
+}
+
+func (d *Service) searchSetsInMemory(
+	terms []string,
+	userID *uuid.UUID,
+) ([]apimodel.SearchHit, error) {
+	var rows []setSearchFields
+	err := d.db.Model(&model.MusicSet{}).
+		Scopes(visibleSets(userID)).
+		Select("id, title, description, creator").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed searching sets: %w", err)
+	}
+
+	var hits []apimodel.SearchHit
+	for _, r := range rows {
+		hit, ok := matchSearchTerms(terms, r.Title, map[string]string{
+			"title":       r.Title,
+			"description": r.Description,
+			"creator":     r.Creator,
+		})
+		if ok {
+			hit.Kind = string(common.SearchKindSet)
+			hit.Id = r.ID
+			hits = append(hits, hit)
+		}
+	}
+
+	return hits, nil
+}
+
+// matchSearchTerms returns the hit with rank and highlights if all terms
+// are found in the fields or if the title is similar to the terms.
+func matchSearchTerms(
+	terms []string,
+	title string,
+	fields map[string]string,
+) (apimodel.SearchHit, bool) {
+	var words []string
+	for _, text := range fields {
+		words = append(words, searchWords(text)...)
+	}
+
+	rank := trigramSimilarity(strings.Join(terms, " "), title)
+	containsTerms := containsAllPrefixes(words, terms)
+	if containsTerms {
+		rank += prefixMatchRank
+	} else if rank < trigramSimilarityThreshold {
+		return apimodel.SearchHit{}, false
+	}
+
+	highlights := make(map[string]string, len(fields))
+	for field, text := range fields {
+		highlights[field] = highlightPrefixes(text, terms)
+	}
+
+	return apimodel.SearchHit{
+		Title:      title,
+		Rank:       rank,
+		Highlights: highlightedFields(highlights),
+	}, true
+}
+
+// searchWords returns the lower case words of the text.
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), isNoWordRune)
+}
+
+func isNoWordRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+func containsAllPrefixes(words []string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if !hasWordWithPrefix(words, p) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func hasWordWithPrefix(words []string, prefix string) bool {
+	for _, w := range words {
+		if strings.HasPrefix(w, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasAnyPrefix(word string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(word, p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// highlightPrefixes marks all words of the text that begin with one of the
+// terms, like ts_headline does for the prefix queries.
+func highlightPrefixes(text string, terms []string) string {
+	return searchWordPattern.ReplaceAllStringFunc(text, func(word string) string {
+		if hasAnyPrefix(strings.ToLower(word), terms) {
+			return matchStart + word + matchStop
+		}
+		return word
+	})
+}
+
+// trigramSimilarity returns the share of common trigrams of both texts,
+// calculated like the similarity function of pg_trgm.
+func trigramSimilarity(a, b string) float64 {
+	aTrigrams := trigrams(a)
+	bTrigrams := trigrams(b)
+	if len(aTrigrams) == 0 || len(bTrigrams) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for t := range aTrigrams {
+		if bTrigrams[t] {
+			shared++
+		}
+	}
+
+	return float64(shared) / float64(len(aTrigrams)+len(bTrigrams)-shared)
+}
+
+// trigrams returns the trigrams of all words of the text. Every word is
+// prefixed with two spaces and suffixed with one.
+func trigrams(text string) map[string]bool {
+	result := make(map[string]bool)
+	for _, w := range searchWords(text) {
+		padded := []rune("  " + w + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			result[string(padded[i:i+3])] = true
+		}
+	}
+
+	return result
+}
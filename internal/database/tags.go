@@ -0,0 +1,324 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+)
+
+func (d *Service) Tags() ([]*apimodel.Tag, error) {
+	var tags []*model.Tag
+	if err := d.db.Order("name").Find(&tags).Error; err != nil {
+		return nil, err
+	}
+
+	apiTags := make([]*apimodel.Tag, len(tags))
+	for i, t := range tags {
+		apiTags[i] = apiTagFromDbTag(t)
+	}
+
+	return apiTags, nil
+}
+
+func (d *Service) CreateTag(create apimodel.CreateTag) (*apimodel.Tag, error) {
+	name, err := d.freeTagName(create.Name, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tag := &model.Tag{
+		Name:        name,
+		Description: create.Description,
+	}
+	if err = d.db.Create(tag).Error; err != nil {
+		return nil, fmt.Errorf("failed creating tag %s: %w", name, err)
+	}
+
+	return apiTagFromDbTag(tag), nil
+}
+
+func (d *Service) GetTag(id uuid.UUID) (*apimodel.Tag, error) {
+	tag, err := d.getTag(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiTagFromDbTag(tag), nil
+}
+
+func (d *Service) UpdateTag(id uuid.UUID, update apimodel.UpdateTag) (*apimodel.Tag, error) {
+	tag, err := d.getTag(id)
+	if err != nil {
+		return nil, err
+	}
+
+	tag.Name, err = d.freeTagName(update.Name, id)
+	if err != nil {
+		return nil, err
+	}
+	tag.Description = update.Description
+
+	if err = d.db.Save(tag).Error; err != nil {
+		return nil, fmt.Errorf("failed updating tag %s: %w", tag.Name, err)
+	}
+
+	return apiTagFromDbTag(tag), nil
+}
+
+// DeleteTag deletes the tag, which is removed from all tunes and sets.
+func (d *Service) DeleteTag(id uuid.UUID) error {
+	tag, err := d.getTag(id)
+	if err != nil {
+		return err
+	}
+
+	return d.db.Delete(tag).Error
+}
+
+// AssignTagsToTune replaces the tags of the tune with the given tags.
+func (d *Service) AssignTagsToTune(
+	tuneID uuid.UUID,
+	tagIDs []uuid.UUID,
+) (*apimodel.Tune, error) {
+	if err := d.db.First(&model.Tune{}, tuneID).Error; err != nil {
+		return nil, common.ErrNotFound
+	}
+
+	tags, err := d.tagsFromIDs(tagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("tune_id = ?", tuneID).Delete(&model.TuneTag{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tagTunes(tx, []uuid.UUID{tuneID}, tags)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed assigning tags to tune %s: %w", tuneID, err)
+	}
+
+	return d.GetTune(tuneID)
+}
+
+// AssignTagsToMusicSet replaces the tags of the set with the given tags.
+// The set must be visible to the user.
+func (d *Service) AssignTagsToMusicSet(
+	setID uuid.UUID,
+	tagIDs []uuid.UUID,
+	userID *uuid.UUID,
+) (*apimodel.MusicSet, error) {
+	if _, err := d.visibleMusicSet(setID, userID); err != nil {
+		return nil, err
+	}
+
+	tags, err := d.tagsFromIDs(tagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("music_set_id = ?", setID).Delete(&model.MusicSetTag{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tagMusicSet(tx, setID, tags)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed assigning tags to set %s: %w", setID, err)
+	}
+
+	return d.GetMusicSet(setID, userID)
+}
+
+// createTaggedMusicSetForTunes creates the set for the imported tunes and
+// adds the tags to the tunes and the set.
+func (d *Service) createTaggedMusicSetForTunes(
+	tunes []*apimodel.ImportTune,
+	importFile *model.ImportFile,
+	tagNames []string,
+) (*apimodel.BasicMusicSet, error) {
+	musicSet, err := d.createMusicSetForTunes(tunes, importFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return musicSet, d.tagImport(tunes, musicSet, tagNames)
+}
+
+// tagImport adds the tags to the imported tunes and their set.
+// Tags that don't exist yet are created.
+func (d *Service) tagImport(
+	tunes []*apimodel.ImportTune,
+	set *apimodel.BasicMusicSet,
+	tagNames []string,
+) error {
+	if len(tagNames) == 0 {
+		return nil
+	}
+
+	tags, err := d.getOrCreateTags(tagNames)
+	if err != nil {
+		return err
+	}
+
+	tuneIDs := make([]uuid.UUID, len(tunes))
+	for i, t := range tunes {
+		tuneIDs[i] = t.Id
+	}
+	if err = tagTunes(d.db, tuneIDs, tags); err != nil {
+		return err
+	}
+
+	if set == nil {
+		return nil
+	}
+
+	return tagMusicSet(d.db, set.Id, tags)
+}
+
+// tagTunes adds the tags to the tunes, tags the tunes already have are skipped.
+func tagTunes(tx *gorm.DB, tuneIDs []uuid.UUID, tags []model.Tag) error {
+	var tuneTags []model.TuneTag
+	for _, tuneID := range tuneIDs {
+		for _, tag := range tags {
+			tuneTags = append(tuneTags, model.TuneTag{TuneID: tuneID, TagID: tag.ID})
+		}
+	}
+	if len(tuneTags) == 0 {
+		return nil
+	}
+
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Omit(clause.Associations).
+		Create(&tuneTags).Error
+}
+
+// tagMusicSet adds the tags to the set, tags the set already has are skipped.
+func tagMusicSet(tx *gorm.DB, setID uuid.UUID, tags []model.Tag) error {
+	setTags := make([]model.MusicSetTag, len(tags))
+	for i, tag := range tags {
+		setTags[i] = model.MusicSetTag{MusicSetID: setID, TagID: tag.ID}
+	}
+	if len(setTags) == 0 {
+		return nil
+	}
+
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Omit(clause.Associations).
+		Create(&setTags).Error
+}
+
+func (d *Service) getTag(id uuid.UUID) (*model.Tag, error) {
+	tag := &model.Tag{}
+	if err := d.db.First(tag, id).Error; err != nil {
+		return nil, fmt.Errorf("%w: tag %s", common.ErrNotFound, id)
+	}
+
+	return tag, nil
+}
+
+// getTagByName returns the tag with the name regardless of its case.
+func (d *Service) getTagByName(name string) (*model.Tag, error) {
+	tag := &model.Tag{}
+	err := d.db.Where("lower(name) = ?", strings.ToLower(name)).First(tag).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: tag %s", common.ErrNotFound, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// freeTagName returns the trimmed name if it isn't empty and no other tag
+// than the one with the given id has this name.
+func (d *Service) freeTagName(name string, id uuid.UUID) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("%w: tag name must not be empty", common.ErrInvalidArgument)
+	}
+
+	existing, err := d.getTagByName(name)
+	if errors.Is(err, common.ErrNotFound) {
+		return name, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if existing.ID != id {
+		return "", fmt.Errorf("%w: tag %s", common.ErrAlreadyExists, existing.Name)
+	}
+
+	return name, nil
+}
+
+func (d *Service) getOrCreateTags(names []string) ([]model.Tag, error) {
+	tags := make([]model.Tag, len(names))
+	for i, name := range names {
+		tag, err := d.getTagByName(name)
+		if errors.Is(err, common.ErrNotFound) {
+			tag = &model.Tag{Name: name}
+			err = d.db.Create(tag).Error
+		}
+		if err != nil {
+			return nil, err
+		}
+		tags[i] = *tag
+	}
+
+	return tags, nil
+}
+
+// tagsFromIDs returns the tags with the given ids. It returns an
+// ErrNotFound error if one of them doesn't exist.
+func (d *Service) tagsFromIDs(ids []uuid.UUID) ([]model.Tag, error) {
+	ids = common.RemoveDuplicates(ids)
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var tags []model.Tag
+	if err := d.db.Where("id IN ?", ids).Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	if len(tags) != len(ids) {
+		return nil, fmt.Errorf("%w: not all tags exist", common.ErrNotFound)
+	}
+
+	return tags, nil
+}
+
+func apiTagFromDbTag(tag *model.Tag) *apimodel.Tag {
+	return &apimodel.Tag{
+		Id:          tag.ID,
+		Name:        tag.Name,
+		Description: tag.Description,
+	}
+}
+
+// tagNamesOf returns the names of the tags or nil if there are none.
+func tagNamesOf(tags []model.Tag) []string {
+	var names []string
+	for _, t := range tags {
+		names = append(names, t.Name)
+	}
+
+	return names
+}
+
+// preloadTags preloads the tags ordered by their name.
+func preloadTags(db *gorm.DB) *gorm.DB {
+	return db.Order("tags.name")
+}
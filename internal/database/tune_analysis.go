@@ -0,0 +1,115 @@
+package database
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes/internal/analysis"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"gorm.io/gorm"
+)
+
+// TuneAnalysis returns the analysis of the music of the tune. Tunes
+// without a music model, e.g. tunes that were not imported, have no analysis.
+func (d *Service) TuneAnalysis(id uuid.UUID) (*apimodel.TuneAnalysis, error) {
+	if err := d.db.Select("id").First(&model.Tune{}, id).Error; err != nil {
+		return nil, common.ErrNotFound
+	}
+
+	analyses, err := d.tuneAnalyses(d.db.Model(&model.Tune{}).Select("id").Where("id = ?", id))
+	if err != nil {
+		return nil, err
+	}
+	a, ok := analyses[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: tune %s has no music to analyse", common.ErrNotFound, id)
+	}
+
+	return &a, nil
+}
+
+// MusicSetAnalysis returns the combined analysis of the tunes of the set.
+func (d *Service) MusicSetAnalysis(id uuid.UUID, userID *uuid.UUID) (*apimodel.TuneAnalysis, error) {
+	if _, err := d.visibleMusicSet(id, userID); err != nil {
+		return nil, err
+	}
+
+	var tuneIDs []uuid.UUID
+	err := d.db.Model(&model.MusicSetTunes{}).
+		Where("music_set_id = ?", id).
+		Order("\"order\"").
+		Pluck("tune_id", &tuneIDs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return d.combinedTuneAnalysis(tuneIDs, d.db.Model(&model.MusicSetTunes{}).
+		Select("tune_id").
+		Where("music_set_id = ?", id))
+}
+
+// TunesAnalysis returns the combined analysis of all tunes
+// that match the filters of the options, regardless of the page.
+func (d *Service) TunesAnalysis(opts common.TuneListOptions) (*apimodel.TuneAnalysis, error) {
+	sortFields, err := opts.SortFields()
+	if err != nil {
+		return nil, err
+	}
+
+	var tuneIDs []uuid.UUID
+	err = d.tuneListQuery(opts).
+		Order(tuneListOrder(sortFields)).
+		Pluck("tunes.id", &tuneIDs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return d.combinedTuneAnalysis(tuneIDs, d.tuneListQuery(opts).Select("tunes.id"))
+}
+
+// combinedTuneAnalysis combines the analyses of the tunes in the order of their ids.
+// The analyses are created from the tunes of the given query of tune ids.
+func (d *Service) combinedTuneAnalysis(
+	tuneIDs []uuid.UUID,
+	tuneIDQuery *gorm.DB,
+) (*apimodel.TuneAnalysis, error) {
+	analyses, err := d.tuneAnalyses(tuneIDQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	ordered := make([]apimodel.TuneAnalysis, 0, len(tuneIDs))
+	for _, id := range tuneIDs {
+		if a, ok := analyses[id]; ok {
+			ordered = append(ordered, a)
+		}
+	}
+	combined := analysis.Combine(ordered)
+
+	return &combined, nil
+}
+
+// tuneAnalyses returns the analyses of the tunes of the query of tune ids
+// that have a music model by their id.
+func (d *Service) tuneAnalyses(tuneIDQuery *gorm.DB) (map[uuid.UUID]apimodel.TuneAnalysis, error) {
+	var files []model.TuneFile
+	err := d.db.Where("tune_id IN (?) AND format = ? AND single_tune_data = ?",
+		tuneIDQuery, fileformat.Format_MUSIC_MODEL, false).
+		Find(&files).Error
+	if err != nil {
+		return nil, err
+	}
+
+	analyses := make(map[uuid.UUID]apimodel.TuneAnalysis, len(files))
+	for _, f := range files {
+		t, err := f.MusicModelTune()
+		if err != nil {
+			return nil, fmt.Errorf("failed decoding music model of tune %s: %w", f.TuneID, err)
+		}
+		analyses[f.TuneID] = analysis.Analyze(f.TuneID, t)
+	}
+
+	return analyses, nil
+}
@@ -0,0 +1,251 @@
+package database
+
+import (
+	"cmp"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/fingerprint"
+	"gorm.io/gorm"
+	"maps"
+	"slices"
+)
+
+// minDuplicateSimilarity is the similarity the music of another tune
+// must at least have to be reported as duplicate of a tune.
+const minDuplicateSimilarity = 0.8
+
+// TuneDuplicates returns the tunes whose music is similar to the music of the
+// tune, most similar first. Tunes without a music model have no duplicates.
+func (d *Service) TuneDuplicates(id uuid.UUID) ([]*apimodel.TuneDuplicate, error) {
+	t := &model.Tune{}
+	if err := d.db.Select("id", "fingerprint").First(t, id).Error; err != nil {
+		return nil, common.ErrNotFound
+	}
+
+	similarities, err := d.similarTunes(t)
+	if err != nil {
+		return nil, err
+	}
+
+	var tunes []model.Tune
+	err = d.db.Preload("TuneType").
+		Preload("Tags", preloadTags).
+		Where("id IN ?", slices.Collect(maps.Keys(similarities))).
+		Find(&tunes).Error
+	if err != nil {
+		return nil, err
+	}
+
+	apiTunes, err := apiTunesFromDbTunes(tunes)
+	if err != nil {
+		return nil, err
+	}
+
+	duplicates := make([]*apimodel.TuneDuplicate, len(apiTunes))
+	for i, apiTune := range apiTunes {
+		duplicates[i] = &apimodel.TuneDuplicate{
+			Tune:       apiTune,
+			Similarity: similarities[apiTune.Id],
+		}
+	}
+	slices.SortFunc(duplicates, func(a, b *apimodel.TuneDuplicate) int {
+		return cmp.Or(
+			cmp.Compare(b.Similarity, a.Similarity),
+			cmp.Compare(a.Tune.Title, b.Tune.Title),
+		)
+	})
+
+	return duplicates, nil
+}
+
+// MergeTunes merges the tunes of the given ids into the tune with the id.
+// The sets of these tunes contain the tune in their place and their tags are
+// added to the tune, before they are deleted with their files and revisions.
+func (d *Service) MergeTunes(
+	id uuid.UUID,
+	merge apimodel.MergeTunes,
+) (*apimodel.Tune, error) {
+	sourceIDs := common.RemoveDuplicates(merge.TuneIds)
+	if slices.Contains(sourceIDs, id) {
+		return nil, fmt.Errorf("%w: can't merge tune %s into itself",
+			common.ErrInvalidArgument, id)
+	}
+
+	err := d.changeTune(id, "", func(tx *Service) error {
+		return mergeTunes(tx.db, id, sourceIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.GetTune(id)
+}
+
+// similarTunes returns the similarity of the music of all other tunes to
+// the music of the tune by their id, if they are similar enough.
+func (d *Service) similarTunes(t *model.Tune) (map[uuid.UUID]float64, error) {
+	similarities := map[uuid.UUID]float64{}
+	if t.Fingerprint == "" {
+		return similarities, nil
+	}
+
+	var others []model.Tune
+	err := d.db.Select("id", "fingerprint").
+		Where("id <> ? AND fingerprint <> ''", t.ID).
+		Find(&others).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, o := range others {
+		if fingerprint.MaxSimilarity(t.Fingerprint, o.Fingerprint) < minDuplicateSimilarity {
+			continue
+		}
+		if s := fingerprint.Similarity(t.Fingerprint, o.Fingerprint); s >= minDuplicateSimilarity {
+			similarities[o.ID] = s
+		}
+	}
+
+	return similarities, nil
+}
+
+func mergeTunes(tx *gorm.DB, id uuid.UUID, sourceIDs []uuid.UUID) error {
+	if err := tx.First(&model.Tune{}, id).Error; err != nil {
+		return fmt.Errorf("%w: tune %s", common.ErrNotFound, id)
+	}
+
+	var sources []model.Tune
+	if err := tx.Preload("Tags").Where("id IN ?", sourceIDs).Find(&sources).Error; err != nil {
+		return err
+	}
+	if len(sources) != len(sourceIDs) {
+		return fmt.Errorf("%w: not all tunes to merge exist", common.ErrNotFound)
+	}
+
+	if err := replaceTunesInSets(tx, id, sourceIDs); err != nil {
+		return err
+	}
+
+	for _, s := range sources {
+		if err := tagTunes(tx, []uuid.UUID{id}, s.Tags); err != nil {
+			return err
+		}
+	}
+
+	return tx.Where("id IN ?", sourceIDs).Delete(&model.Tune{}).Error
+}
+
+// replaceTunesInSets replaces the source tunes with the tune in all sets
+// of the source tunes. A set that already has the tune or more than one of
+// the source tunes only keeps the first of them.
+func replaceTunesInSets(tx *gorm.DB, id uuid.UUID, sourceIDs []uuid.UUID) error {
+	setTunes, err := setTunesBySet(tx, sourceIDs)
+	if err != nil {
+		return err
+	}
+
+	var keptSets [][]model.MusicSetTunes
+	var droppedIDs []uuid.UUID
+	for _, tunes := range setTunes {
+		kept, dropped := mergedSetTunes(tunes, id, sourceIDs)
+		keptSets = append(keptSets, kept)
+		droppedIDs = append(droppedIDs, dropped...)
+	}
+
+	if err = tx.Where("id IN ?", droppedIDs).Delete(&model.MusicSetTunes{}).Error; err != nil {
+		return err
+	}
+	for _, kept := range keptSets {
+		if err = updateSetTunes(tx, kept); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setTunesBySet returns the tunes of all sets that contain one of the tunes
+// by the id of the set. The tunes of a set are in the order of the set.
+func setTunesBySet(tx *gorm.DB, tuneIDs []uuid.UUID) (map[uuid.UUID][]model.MusicSetTunes, error) {
+	setIDs := tx.Model(&model.MusicSetTunes{}).
+		Select("music_set_id").
+		Where("tune_id IN ?", tuneIDs)
+
+	var setTunes []model.MusicSetTunes
+	err := tx.Where("music_set_id IN (?)", setIDs).
+		Order("music_set_id, \"order\"").
+		Find(&setTunes).Error
+	if err != nil {
+		return nil, err
+	}
+
+	bySet := map[uuid.UUID][]model.MusicSetTunes{}
+	for _, st := range setTunes {
+		bySet[st.MusicSetID] = append(bySet[st.MusicSetID], st)
+	}
+
+	return bySet, nil
+}
+
+// mergedSetTunes replaces the source tunes of the tunes of a set with the
+// tune. It returns the tunes the set keeps and the ids of the set tunes
+// that are dropped, as the set has the tune already.
+func mergedSetTunes(
+	setTunes []model.MusicSetTunes,
+	id uuid.UUID,
+	sourceIDs []uuid.UUID,
+) ([]model.MusicSetTunes, []uuid.UUID) {
+	hasTune := slices.ContainsFunc(setTunes, func(st model.MusicSetTunes) bool {
+		return st.TuneID == id
+	})
+
+	var kept []model.MusicSetTunes
+	var droppedIDs []uuid.UUID
+	for _, st := range setTunes {
+		if !slices.Contains(sourceIDs, st.TuneID) {
+			kept = append(kept, st)
+			continue
+		}
+		if hasTune {
+			droppedIDs = append(droppedIDs, st.ID)
+			continue
+		}
+
+		st.TuneID = id
+		hasTune = true
+		kept = append(kept, st)
+	}
+
+	return kept, droppedIDs
+}
+
+// updateSetTunes stores the tunes of a set numbered in the given order.
+func updateSetTunes(tx *gorm.DB, setTunes []model.MusicSetTunes) error {
+	for i, st := range setTunes {
+		err := tx.Model(&model.MusicSetTunes{}).
+			Where("id = ?", st.ID).
+			Updates(map[string]any{"tune_id": st.TuneID, "order": uint(i + 1)}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateTuneFingerprint sets the fingerprint of the tune to the fingerprint
+// of its music model, which is empty if the tune has no music model.
+func (d *Service) updateTuneFingerprint(tuneID uuid.UUID, t *tune.Tune) error {
+	fp := ""
+	if t != nil {
+		fp = fingerprint.Of(t)
+	}
+
+	return d.db.Model(&model.Tune{}).
+		Where("id = ?", tuneID).
+		Update("fingerprint", fp).Error
+}
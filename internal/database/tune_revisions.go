@@ -0,0 +1,231 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"gorm.io/gorm"
+)
+
+// TuneRevisions returns all revisions of a tune with the oldest revision
+// first. The files of the revisions don't contain their data.
+func (d *Service) TuneRevisions(tuneID uuid.UUID) ([]*model.TuneRevision, error) {
+	if err := d.db.First(&model.Tune{}, tuneID).Error; err != nil {
+		return nil, common.ErrNotFound
+	}
+
+	var revisions []*model.TuneRevision
+	err := d.db.Preload("Files", func(db *gorm.DB) *gorm.DB {
+		return db.Omit("data")
+	}).
+		Where("tune_id = ?", tuneID).
+		Order("number").
+		Find(&revisions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+// TuneRevision returns the revision with the given number of a tune.
+func (d *Service) TuneRevision(tuneID uuid.UUID, number uint) (*model.TuneRevision, error) {
+	revision := &model.TuneRevision{}
+	err := d.db.Preload("Files").
+		Where("tune_id = ? AND number = ?", tuneID, number).
+		First(revision).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: tune %s has no revision %d", common.ErrNotFound, tuneID, number)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+// RestoreTuneRevision sets the metadata and the files of a tune back to the
+// state of the revision with the given number. Restoring is a change of the
+// tune itself, so it creates a new revision.
+func (d *Service) RestoreTuneRevision(
+	tuneID uuid.UUID,
+	number uint,
+	author string,
+) (*apimodel.Tune, error) {
+	revision, err := d.TuneRevision(tuneID, number)
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.changeTune(tuneID, author, func(tx *Service) error {
+		return tx.restoreTuneRevision(revision)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.GetTune(tuneID)
+}
+
+func (d *Service) restoreTuneRevision(revision *model.TuneRevision) error {
+	updateVals, err := d.tunePeopleUpdateVals(revision.Composer, revision.Arranger)
+	if err != nil {
+		return err
+	}
+	updateVals["title"] = revision.Title
+	updateVals["time_sig"] = revision.TimeSig
+	updateVals["tune_type_id"] = nil
+	if revision.Type != "" {
+		tuneType, err := d.getOrCreateTuneType(revision.Type)
+		if err != nil {
+			return err
+		}
+		updateVals["tune_type_id"] = tuneType.ID
+	}
+
+	err = d.db.Model(&model.Tune{}).
+		Where("id = ?", revision.TuneID).
+		Updates(updateVals).Error
+	if err != nil {
+		return err
+	}
+
+	return d.restoreTuneRevisionFiles(revision)
+}
+
+// restoreTuneRevisionFiles replaces all files of the tune with the files of the revision.
+func (d *Service) restoreTuneRevisionFiles(revision *model.TuneRevision) error {
+	err := d.db.Where("tune_id = ?", revision.TuneID).Delete(&model.TuneFile{}).Error
+	if err != nil {
+		return err
+	}
+
+	for _, f := range revision.Files {
+		err = d.db.Create(&model.TuneFile{
+			TuneID:         revision.TuneID,
+			Format:         f.Format,
+			SingleTuneData: f.SingleTuneData,
+			Data:           f.Data,
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// changeTune applies a change to a tune and records the state of the tune
+// after the change as new revision. For tunes that were created before
+// revisions existed, the state before the change is recorded first.
+// The change and the revisions are stored in one transaction, so the change
+// gets the service of the transaction to write with.
+func (d *Service) changeTune(
+	tuneID uuid.UUID,
+	author string,
+	change func(tx *Service) error,
+) error {
+	return d.changeTunes([]uuid.UUID{tuneID}, author, change)
+}
+
+// changeTunes is changeTune for a change of multiple tunes, e.g. of all
+// tunes of a person that is renamed.
+func (d *Service) changeTunes(
+	tuneIDs []uuid.UUID,
+	author string,
+	change func(tx *Service) error,
+) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		txService := d.withTx(tx)
+		if err := forEachTune(tuneIDs, txService.recordInitialTuneRevision); err != nil {
+			return err
+		}
+
+		if err := change(txService); err != nil {
+			return err
+		}
+
+		return forEachTune(tuneIDs, func(tuneID uuid.UUID) error {
+			return txService.recordChangedTune(tuneID, author)
+		})
+	})
+}
+
+// recordChangedTune updates the music of a changed tune and records
+// its state as new revision.
+func (d *Service) recordChangedTune(tuneID uuid.UUID, author string) error {
+	if err := d.updateTuneMusic(tuneID); err != nil {
+		return err
+	}
+
+	return d.recordTuneRevision(tuneID, author)
+}
+
+func forEachTune(tuneIDs []uuid.UUID, f func(tuneID uuid.UUID) error) error {
+	for _, tuneID := range tuneIDs {
+		if err := f(tuneID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// recordInitialTuneRevision records the current state of a tune that has no revisions yet.
+func (d *Service) recordInitialTuneRevision(tuneID uuid.UUID) error {
+	var revisionCnt int64
+	err := d.db.Model(&model.TuneRevision{}).
+		Where("tune_id = ?", tuneID).
+		Count(&revisionCnt).Error
+	if err != nil || revisionCnt > 0 {
+		return err
+	}
+
+	return d.recordTuneRevision(tuneID, "")
+}
+
+// recordTuneRevision stores the current metadata and files of a tune as new
+// revision. Exported files are left out, as they are made from the other files.
+func (d *Service) recordTuneRevision(tuneID uuid.UUID, author string) error {
+	t := &model.Tune{}
+	if err := d.db.Preload("TuneType").Preload("Files").First(t, tuneID).Error; err != nil {
+		return common.ErrNotFound
+	}
+
+	var lastNumber uint
+	err := d.db.Model(&model.TuneRevision{}).
+		Where("tune_id = ?", tuneID).
+		Select("coalesce(max(number), 0)").
+		Scan(&lastNumber).Error
+	if err != nil {
+		return err
+	}
+
+	revision := &model.TuneRevision{
+		TuneID:   tuneID,
+		Number:   lastNumber + 1,
+		Author:   author,
+		Title:    t.Title,
+		TimeSig:  t.TimeSig,
+		Composer: t.Composer,
+		Arranger: t.Arranger,
+	}
+	if t.TuneType != nil {
+		revision.Type = t.TuneType.Name
+	}
+	for _, f := range t.Files {
+		if f.Exported {
+			continue
+		}
+		revision.Files = append(revision.Files, model.TuneRevisionFile{
+			Format:         f.Format,
+			SingleTuneData: f.SingleTuneData,
+			Data:           f.Data,
+		})
+	}
+
+	return d.db.Create(revision).Error
+}
@@ -0,0 +1,336 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"gorm.io/gorm"
+	"slices"
+	"strings"
+)
+
+func (d *Service) TuneTypes() ([]*apimodel.TuneType, error) {
+	var tuneTypes []*model.TuneType
+	err := d.db.Preload("Aliases", preloadAliases).
+		Order("name").
+		Find(&tuneTypes).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := d.tuneCountsByType()
+	if err != nil {
+		return nil, err
+	}
+
+	apiTypes := make([]*apimodel.TuneType, len(tuneTypes))
+	for i, t := range tuneTypes {
+		apiTypes[i] = apiTuneTypeFromDbTuneType(t, counts[t.ID])
+	}
+
+	return apiTypes, nil
+}
+
+func (d *Service) GetTuneType(id uuid.UUID) (*apimodel.TuneType, error) {
+	tuneType, err := getTuneType(d.db.Preload("Aliases", preloadAliases), id)
+	if err != nil {
+		return nil, err
+	}
+
+	var count int64
+	err = d.db.Model(&model.Tune{}).
+		Where("tune_type_id = ?", id).
+		Count(&count).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return apiTuneTypeFromDbTuneType(tuneType, count), nil
+}
+
+// UpdateTuneType renames the tune type. The old name becomes an alias of
+// the tune type, so tunes with the old name still get this type.
+func (d *Service) UpdateTuneType(
+	id uuid.UUID,
+	update apimodel.UpdateTuneType,
+) (*apimodel.TuneType, error) {
+	name := normalizeName(update.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: tune type name must not be empty", common.ErrInvalidArgument)
+	}
+
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		return renameTuneType(tx, id, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.GetTuneType(id)
+}
+
+// MergeTuneTypes merges the tune types of the given ids into the tune type with
+// the id. Their tunes get this tune type and their names and aliases become
+// aliases of it, before they are deleted.
+func (d *Service) MergeTuneTypes(
+	id uuid.UUID,
+	merge apimodel.MergeTuneTypes,
+) (*apimodel.TuneType, error) {
+	sourceIDs := common.RemoveDuplicates(merge.TuneTypeIds)
+	if slices.Contains(sourceIDs, id) {
+		return nil, fmt.Errorf("%w: can't merge tune type %s into itself",
+			common.ErrInvalidArgument, id)
+	}
+
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		var tuneIDs []uuid.UUID
+		err := tx.Model(&model.Tune{}).
+			Where("tune_type_id IN ?", sourceIDs).
+			Pluck("id", &tuneIDs).Error
+		if err != nil {
+			return err
+		}
+
+		// the tunes of the merged tune types get another type
+		return d.withTx(tx).changeTunes(tuneIDs, "", func(txService *Service) error {
+			return mergeTuneTypes(txService.db, id, sourceIDs)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.GetTuneType(id)
+}
+
+// SetTuneTypeAliases replaces the aliases of the tune type. An alias must
+// neither be the name nor an alias of another tune type.
+func (d *Service) SetTuneTypeAliases(
+	id uuid.UUID,
+	aliases []string,
+) (*apimodel.TuneType, error) {
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		tuneType, err := getTuneType(tx, id)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Where("tune_type_id = ?", id).Delete(&model.TuneTypeAlias{}).Error
+		if err != nil {
+			return err
+		}
+
+		return createTuneTypeAliases(tx, id, aliasNames(aliases, tuneType.Name))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.GetTuneType(id)
+}
+
+func renameTuneType(tx *gorm.DB, id uuid.UUID, name string) error {
+	tuneType, err := getTuneType(tx, id)
+	if err != nil {
+		return err
+	}
+
+	if err = ensureFreeTuneTypeName(tx, name, id); err != nil {
+		return err
+	}
+
+	// The new name may have been an alias before
+	err = tx.Where("tune_type_id = ? AND lower(name) = ?", id, strings.ToLower(name)).
+		Delete(&model.TuneTypeAlias{}).Error
+	if err != nil {
+		return err
+	}
+
+	if err = createTuneTypeAliases(tx, id, aliasNames([]string{tuneType.Name}, name)); err != nil {
+		return err
+	}
+
+	return tx.Model(tuneType).Update("name", name).Error
+}
+
+func mergeTuneTypes(tx *gorm.DB, id uuid.UUID, sourceIDs []uuid.UUID) error {
+	if _, err := getTuneType(tx, id); err != nil {
+		return err
+	}
+
+	var sources []model.TuneType
+	if err := tx.Where("id IN ?", sourceIDs).Find(&sources).Error; err != nil {
+		return err
+	}
+	if len(sources) != len(sourceIDs) {
+		return fmt.Errorf("%w: not all tune types to merge exist", common.ErrNotFound)
+	}
+
+	if err := moveToTuneType(tx, id, sourceIDs); err != nil {
+		return err
+	}
+
+	sourceNames := make([]string, len(sources))
+	for i, s := range sources {
+		sourceNames[i] = s.Name
+	}
+
+	return createTuneTypeAliases(tx, id, sourceNames)
+}
+
+// moveToTuneType gives the tunes and aliases of the source tune types the
+// tune type with the id and deletes the source tune types.
+func moveToTuneType(tx *gorm.DB, id uuid.UUID, sourceIDs []uuid.UUID) error {
+	err := tx.Model(&model.Tune{}).
+		Where("tune_type_id IN ?", sourceIDs).
+		Update("tune_type_id", id).Error
+	if err != nil {
+		return err
+	}
+
+	err = tx.Model(&model.TuneTypeAlias{}).
+		Where("tune_type_id IN ?", sourceIDs).
+		Update("tune_type_id", id).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Where("id IN ?", sourceIDs).Delete(&model.TuneType{}).Error
+}
+
+func createTuneTypeAliases(tx *gorm.DB, id uuid.UUID, names []string) error {
+	for _, name := range names {
+		if err := ensureFreeTuneTypeName(tx, name, id); err != nil {
+			return err
+		}
+
+		alias := &model.TuneTypeAlias{Name: name, TuneTypeID: id}
+		if err := tx.Omit("TuneType").Create(alias).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ensureFreeTuneTypeName returns an ErrAlreadyExists error if the name is
+// the name or an alias of another tune type than the one with the id.
+func ensureFreeTuneTypeName(tx *gorm.DB, name string, id uuid.UUID) error {
+	lowerName := strings.ToLower(name)
+
+	var count int64
+	err := tx.Model(&model.TuneType{}).
+		Where("lower(name) = ? AND id <> ?", lowerName, id).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: tune type %s", common.ErrAlreadyExists, name)
+	}
+
+	err = tx.Model(&model.TuneTypeAlias{}).
+		Where("lower(name) = ? AND tune_type_id <> ?", lowerName, id).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %s is an alias of another tune type",
+			common.ErrAlreadyExists, name)
+	}
+
+	return nil
+}
+
+func getTuneType(db *gorm.DB, id uuid.UUID) (*model.TuneType, error) {
+	tuneType := &model.TuneType{}
+	if err := db.First(tuneType, id).Error; err != nil {
+		return nil, fmt.Errorf("%w: tune type %s", common.ErrNotFound, id)
+	}
+
+	return tuneType, nil
+}
+
+// getTuneTypeByAlias returns the tune type that has the name as alias.
+func (d *Service) getTuneTypeByAlias(name string) (*model.TuneType, error) {
+	alias := &model.TuneTypeAlias{}
+	err := d.db.Preload("TuneType").
+		Where("lower(name) = ?", strings.ToLower(name)).
+		First(alias).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, common.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &alias.TuneType, nil
+}
+
+// tuneCountsByType returns the number of tunes of every tune type by its id.
+func (d *Service) tuneCountsByType() (map[uuid.UUID]int64, error) {
+	var rows []struct {
+		TuneTypeID uuid.UUID
+		Count      int64
+	}
+	err := d.db.Model(&model.Tune{}).
+		Select("tune_type_id, count(*) AS count").
+		Where("tune_type_id IS NOT NULL").
+		Group("tune_type_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uuid.UUID]int64, len(rows))
+	for _, r := range rows {
+		counts[r.TuneTypeID] = r.Count
+	}
+
+	return counts, nil
+}
+
+// normalizeName trims the name and replaces all whitespace
+// between its words with a single space.
+func normalizeName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// aliasNames returns the normalized names without empty ones, duplicates
+// and the name of the tune type or person itself, all regardless of their case.
+func aliasNames(names []string, ownName string) []string {
+	seen := map[string]bool{strings.ToLower(ownName): true}
+	var aliases []string
+	for _, n := range names {
+		n = normalizeName(n)
+		if n == "" || seen[strings.ToLower(n)] {
+			continue
+		}
+		seen[strings.ToLower(n)] = true
+		aliases = append(aliases, n)
+	}
+
+	return aliases
+}
+
+func apiTuneTypeFromDbTuneType(t *model.TuneType, tuneCount int64) *apimodel.TuneType {
+	apiType := &apimodel.TuneType{
+		Id:        t.ID,
+		Name:      t.Name,
+		TuneCount: tuneCount,
+	}
+	for _, a := range t.Aliases {
+		apiType.Aliases = append(apiType.Aliases, a.Name)
+	}
+
+	return apiType
+}
+
+// preloadAliases preloads the aliases ordered by their name.
+func preloadAliases(db *gorm.DB) *gorm.DB {
+	return db.Order("tune_type_aliases.name")
+}
@@ -0,0 +1,188 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"github.com/SamuelTissot/sqltime"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"gorm.io/gorm"
+	"strings"
+)
+
+// CreateUser creates a user with the given name, which must be unique.
+func (d *Service) CreateUser(name string) (*model.User, error) {
+	return d.createUser(name, nil)
+}
+
+func (d *Service) createUser(name string, externalID *string) (*model.User, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: user name must not be empty", common.ErrInvalidArgument)
+	}
+
+	_, err := d.GetUserByName(name)
+	if err == nil {
+		return nil, fmt.Errorf("%w: user %s", common.ErrAlreadyExists, name)
+	}
+	if !errors.Is(err, common.ErrNotFound) {
+		return nil, err
+	}
+
+	user := &model.User{
+		Name:       name,
+		ExternalID: externalID,
+	}
+	if err = d.db.Create(user).Error; err != nil {
+		return nil, fmt.Errorf("failed creating user %s: %w", name, err)
+	}
+
+	return user, nil
+}
+
+func (d *Service) GetUser(id uuid.UUID) (*model.User, error) {
+	user := &model.User{}
+	err := d.db.First(user, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: user %s", common.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (d *Service) GetUserByName(name string) (*model.User, error) {
+	user := &model.User{}
+	err := d.db.Where("name = ?", name).First(user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: user %s", common.ErrNotFound, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// UserForExternalID returns the user of the subject of an external identity
+// provider. The user is created on the first login, with the name of the
+// claims or the subject if there is no name. If a user with that name
+// already exists, the name gets a number as suffix.
+func (d *Service) UserForExternalID(claims common.TokenClaims) (*model.User, error) {
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: token has no subject", common.ErrInvalidArgument)
+	}
+
+	user := &model.User{}
+	err := d.db.Where("external_id = ?", claims.Subject).First(user).Error
+	if err == nil {
+		return user, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(claims.Name)
+	if name == "" {
+		name = claims.Subject
+	}
+	name, err = d.unusedUserName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.createUser(name, &claims.Subject)
+}
+
+// unusedUserName returns the name if there is no user with it yet. Otherwise
+// it returns the name with the first number as suffix that no user has.
+func (d *Service) unusedUserName(name string) (string, error) {
+	candidate := name
+	for i := 2; ; i++ {
+		_, err := d.GetUserByName(candidate)
+		if errors.Is(err, common.ErrNotFound) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		candidate = fmt.Sprintf("%s %d", name, i)
+	}
+}
+
+// UserByAPIToken returns the user of the API token and records
+// when the token was used.
+func (d *Service) UserByAPIToken(token string) (*model.User, error) {
+	apiToken := &model.APIToken{}
+	err := d.db.Where("hash = ?", common.APITokenHash(token)).First(apiToken).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: API token", common.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.db.Model(apiToken).UpdateColumn("last_used_at", sqltime.Now()).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed updating last use of API token: %w", err)
+	}
+
+	return d.GetUser(apiToken.UserID)
+}
+
+// CreateAPIToken creates a new API token for the user. The token itself is
+// only returned here, as only its hash is stored.
+func (d *Service) CreateAPIToken(
+	userID uuid.UUID,
+	name string,
+) (*model.APIToken, string, error) {
+	if _, err := d.GetUser(userID); err != nil {
+		return nil, "", err
+	}
+
+	token, err := common.NewAPIToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	apiToken := &model.APIToken{
+		UserID: userID,
+		Name:   name,
+		Hash:   common.APITokenHash(token),
+	}
+	if err = d.db.Create(apiToken).Error; err != nil {
+		return nil, "", fmt.Errorf("failed creating API token: %w", err)
+	}
+
+	return apiToken, token, nil
+}
+
+func (d *Service) APITokens(userID uuid.UUID) ([]*model.APIToken, error) {
+	var tokens []*model.APIToken
+	err := d.db.Where("user_id = ?", userID).
+		Order("created_at").
+		Find(&tokens).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// DeleteAPIToken deletes an API token of the user. Tokens of other
+// users are not found.
+func (d *Service) DeleteAPIToken(userID uuid.UUID, tokenID uuid.UUID) error {
+	result := d.db.Where("id = ? AND user_id = ?", tokenID, userID).
+		Delete(&model.APIToken{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: API token %s", common.ErrNotFound, tokenID)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/interfaces"
+)
+
+// Exporter converts stored tunes into other file formats. The music model
+// of a tune is handed to a plugin that can write the requested format and
+// the result is stored as exported tune file, so every format is only converted
+// once until the music of the tune changes.
+type Exporter struct {
+	service      interfaces.DataService
+	pluginLoader interfaces.PluginLoader
+}
+
+// ExportTune returns the file of a tune in the given format. If the tune
+// doesn't have a file in that format yet, it will be created from the
+// music model of the tune.
+func (e *Exporter) ExportTune(
+	tuneID uuid.UUID,
+	format fileformat.Format,
+) (*model.TuneFile, error) {
+	tuneFile, err := e.service.GetTuneFile(tuneID, format)
+	if err == nil {
+		return tuneFile, nil
+	}
+	if !errors.Is(err, common.ErrNotFound) {
+		return nil, err
+	}
+
+	muMoFile, err := e.service.GetTuneFile(tuneID, fileformat.Format_MUSIC_MODEL)
+	if err != nil {
+		return nil, fmt.Errorf("tune %s has no music model to export from: %w", tuneID, err)
+	}
+
+	data, err := e.exportMusicModel(muMoFile, format)
+	if err != nil {
+		return nil, err
+	}
+
+	tuneFile = &model.TuneFile{
+		Format:         format,
+		Data:           data,
+		SingleTuneData: true,
+	}
+	err = e.service.AddExportedFileToTune(tuneID, tuneFile)
+	if err != nil && !errors.Is(err, common.ErrAlreadyExists) {
+		return nil, fmt.Errorf("failed storing exported %s file of tune %s: %w",
+			format.String(), tuneID, err)
+	}
+
+	return tuneFile, nil
+}
+
+func (e *Exporter) exportMusicModel(
+	muMoFile *model.TuneFile,
+	format fileformat.Format,
+) ([]byte, error) {
+	exportPlugin, err := e.pluginLoader.ExportPluginForFileFormat(format)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", common.ErrInvalidArgument, err.Error())
+	}
+
+	muMoTune, err := muMoFile.MusicModelTune()
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding music model of tune %s: %w", muMoFile.TuneID, err)
+	}
+
+	data, err := exportPlugin.Export([]*tune.Tune{muMoTune})
+	if err != nil {
+		return nil, fmt.Errorf("failed exporting tune %s to %s: %w",
+			muMoFile.TuneID, format.String(), err)
+	}
+
+	return data, nil
+}
+
+func NewExporter(
+	service interfaces.DataService,
+	pluginLoader interfaces.PluginLoader,
+) *Exporter {
+	return &Exporter{
+		service:      service,
+		pluginLoader: pluginLoader,
+	}
+}
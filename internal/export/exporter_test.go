@@ -0,0 +1,167 @@
+package export
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	pmocks "github.com/tomvodi/limepipes-plugin-api/plugin/v1/interfaces/mocks"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+)
+
+var _ = Describe("Exporter", func() {
+	var err error
+	var exporter *Exporter
+	var tuneID uuid.UUID
+	var tuneFile *model.TuneFile
+	var muMoFile *model.TuneFile
+	var dataService *mocks.DataService
+	var pluginLoader *mocks.PluginLoader
+	var lpPlugin *pmocks.LimePipesPlugin
+
+	BeforeEach(func() {
+		tuneID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+		dataService = mocks.NewDataService(GinkgoT())
+		pluginLoader = mocks.NewPluginLoader(GinkgoT())
+		lpPlugin = pmocks.NewLimePipesPlugin(GinkgoT())
+		exporter = NewExporter(dataService, pluginLoader)
+
+		muMoFile, err = model.TuneFileFromMusicModelTune(model.TestParsedTune("test tune").Tune)
+		Expect(err).ShouldNot(HaveOccurred())
+		muMoFile.TuneID = tuneID
+	})
+
+	JustBeforeEach(func() {
+		tuneFile, err = exporter.ExportTune(tuneID, fileformat.Format_MUSIC_XML)
+	})
+
+	When("the tune already has a file of that format", func() {
+		BeforeEach(func() {
+			dataService.EXPECT().GetTuneFile(tuneID, fileformat.Format_MUSIC_XML).
+				Return(&model.TuneFile{
+					TuneID: tuneID,
+					Format: fileformat.Format_MUSIC_XML,
+					Data:   []byte("<score-partwise/>"),
+				}, nil)
+		})
+
+		It("should return that file without exporting it again", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(tuneFile.Data).To(Equal([]byte("<score-partwise/>")))
+		})
+	})
+
+	When("getting the tune file fails", func() {
+		BeforeEach(func() {
+			dataService.EXPECT().GetTuneFile(tuneID, fileformat.Format_MUSIC_XML).
+				Return(nil, fmt.Errorf("database error"))
+		})
+
+		It("should return that error", func() {
+			Expect(err).To(MatchError("database error"))
+		})
+	})
+
+	Context("the tune has no file of that format", func() {
+		BeforeEach(func() {
+			dataService.EXPECT().GetTuneFile(tuneID, fileformat.Format_MUSIC_XML).
+				Return(nil, common.ErrNotFound)
+		})
+
+		When("the tune has no music model", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().GetTuneFile(tuneID, fileformat.Format_MUSIC_MODEL).
+					Return(nil, common.ErrNotFound)
+			})
+
+			It("should return a not found error", func() {
+				Expect(err).To(MatchError(common.ErrNotFound))
+			})
+		})
+
+		Context("the tune has a music model", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().GetTuneFile(tuneID, fileformat.Format_MUSIC_MODEL).
+					Return(muMoFile, nil)
+			})
+
+			When("there is no plugin that exports that format", func() {
+				BeforeEach(func() {
+					pluginLoader.EXPECT().ExportPluginForFileFormat(fileformat.Format_MUSIC_XML).
+						Return(nil, fmt.Errorf("no plugin"))
+				})
+
+				It("should return an invalid argument error", func() {
+					Expect(err).To(MatchError(common.ErrInvalidArgument))
+				})
+			})
+
+			When("the plugin fails exporting the tune", func() {
+				BeforeEach(func() {
+					pluginLoader.EXPECT().ExportPluginForFileFormat(fileformat.Format_MUSIC_XML).
+						Return(lpPlugin, nil)
+					lpPlugin.EXPECT().Export(mock.Anything).
+						Return(nil, fmt.Errorf("export failed"))
+				})
+
+				It("should return an error", func() {
+					Expect(err).Should(HaveOccurred())
+				})
+			})
+
+			Context("the plugin exports the tune", func() {
+				BeforeEach(func() {
+					pluginLoader.EXPECT().ExportPluginForFileFormat(fileformat.Format_MUSIC_XML).
+						Return(lpPlugin, nil)
+					lpPlugin.EXPECT().Export(mock.MatchedBy(func(tunes []*tune.Tune) bool {
+						return len(tunes) == 1 && tunes[0].Title == "test tune"
+					})).Return([]byte("<score-partwise/>"), nil)
+				})
+
+				When("storing the exported file fails", func() {
+					BeforeEach(func() {
+						dataService.EXPECT().AddExportedFileToTune(tuneID, mock.Anything).
+							Return(fmt.Errorf("database error"))
+					})
+
+					It("should return an error", func() {
+						Expect(err).Should(HaveOccurred())
+					})
+				})
+
+				When("the file was stored in the meantime by someone else", func() {
+					BeforeEach(func() {
+						dataService.EXPECT().AddExportedFileToTune(tuneID, mock.Anything).
+							Return(common.ErrAlreadyExists)
+					})
+
+					It("should still return the exported file", func() {
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(tuneFile.Data).To(Equal([]byte("<score-partwise/>")))
+					})
+				})
+
+				When("the exported file is stored", func() {
+					BeforeEach(func() {
+						dataService.EXPECT().AddExportedFileToTune(tuneID, &model.TuneFile{
+							Format:         fileformat.Format_MUSIC_XML,
+							Data:           []byte("<score-partwise/>"),
+							SingleTuneData: true,
+						}).Return(nil)
+					})
+
+					It("should return the exported file", func() {
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(tuneFile.Format).To(Equal(fileformat.Format_MUSIC_XML))
+						Expect(tuneFile.Data).To(Equal([]byte("<score-partwise/>")))
+					})
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,103 @@
+// Package fingerprint creates fingerprints of the music of tunes, so that
+// the same tune is recognized even if it was written in different files.
+package fingerprint
+
+import (
+	"fmt"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/measure"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"strings"
+)
+
+const (
+	measureSeparator = "|"
+	symbolSeparator  = " "
+)
+
+// Of returns the fingerprint of the tune. It contains the pitch and length
+// of every note and the length of every rest, measure by measure. Everything
+// else, like embellishments, comments, barlines and measures without notes,
+// is left out, as it differs between the ways the same tune is written.
+func Of(t *tune.Tune) string {
+	var measures []string
+	for _, m := range t.Measures {
+		if fp := measureFingerprint(m); fp != "" {
+			measures = append(measures, fp)
+		}
+	}
+
+	return strings.Join(measures, measureSeparator)
+}
+
+func measureFingerprint(m *measure.Measure) string {
+	var syms []string
+	for _, s := range m.Symbols {
+		if fp := symbolFingerprint(s); fp != "" {
+			syms = append(syms, fp)
+		}
+	}
+
+	return strings.Join(syms, symbolSeparator)
+}
+
+// symbolFingerprint returns the pitch, length and dots of a note or the
+// length of a rest, for all other symbols it returns an empty string.
+func symbolFingerprint(s *symbols.Symbol) string {
+	switch {
+	case s.IsValidNote():
+		return fmt.Sprintf("%d/%d%s", s.Note.Pitch, s.Note.Length,
+			strings.Repeat(".", int(s.Note.Dots)))
+	case s.Rest != nil:
+		return fmt.Sprintf("r/%d", s.Rest.Length)
+	default:
+		return ""
+	}
+}
+
+// Similarity returns how similar the music of two fingerprints is, from 0 for
+// nothing in common to 1 for the same music. It is the share of measures of
+// both fingerprints that are part of their longest common sequence of measures.
+func Similarity(a string, b string) float64 {
+	if a == "" || b == "" {
+		return 0
+	}
+
+	measuresA := strings.Split(a, measureSeparator)
+	measuresB := strings.Split(b, measureSeparator)
+	common := commonSequenceLength(measuresA, measuresB)
+
+	return float64(2*common) / float64(len(measuresA)+len(measuresB))
+}
+
+// MaxSimilarity returns the similarity that two fingerprints can have at most,
+// which only depends on their numbers of measures. It is much cheaper to
+// calculate than the similarity itself.
+func MaxSimilarity(a string, b string) float64 {
+	if a == "" || b == "" {
+		return 0
+	}
+
+	countA := strings.Count(a, measureSeparator) + 1
+	countB := strings.Count(b, measureSeparator) + 1
+
+	return float64(2*min(countA, countB)) / float64(countA+countB)
+}
+
+// commonSequenceLength returns the length of the longest common subsequence of a and b.
+func commonSequenceLength(a []string, b []string) int {
+	prev := make([]int, len(b)+1)
+	for i := range a {
+		cur := make([]int, len(b)+1)
+		for j := range b {
+			if a[i] == b[j] {
+				cur[j+1] = prev[j] + 1
+			} else {
+				cur[j+1] = max(prev[j+1], cur[j])
+			}
+		}
+		prev = cur
+	}
+
+	return prev[len(b)]
+}
@@ -0,0 +1,110 @@
+package fingerprint
+
+import (
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/barline"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/length"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/measure"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/pitch"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/embellishment"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"testing"
+)
+
+func note(p pitch.Pitch, l length.Length, dots uint32) *symbols.Symbol {
+	return &symbols.Symbol{Note: &symbols.Note{Pitch: p, Length: l, Dots: dots}}
+}
+
+func TestOf(t *testing.T) {
+	g := NewWithT(t)
+
+	written := &tune.Tune{Measures: []*measure.Measure{
+		{
+			Time: &measure.TimeSignature{Beats: 2, BeatType: 4},
+			Symbols: []*symbols.Symbol{
+				note(pitch.Pitch_LowA, length.Length_Eighth, 1),
+				note(pitch.Pitch_B, length.Length_Sixteenth, 0),
+			},
+		},
+		{
+			Symbols: []*symbols.Symbol{
+				note(pitch.Pitch_C, length.Length_Quarter, 0),
+				{Rest: &symbols.Rest{Length: length.Length_Quarter}},
+			},
+		},
+	}}
+	rewritten := &tune.Tune{Measures: []*measure.Measure{
+		{
+			LeftBarline: &barline.Barline{Type: barline.Type_HeavyLight},
+			Comments:    []string{"first part"},
+			Symbols: []*symbols.Symbol{
+				{Note: &symbols.Note{Embellishment: &embellishment.Embellishment{Type: embellishment.Type_Doubling}}},
+				note(pitch.Pitch_LowA, length.Length_Eighth, 1),
+				note(pitch.Pitch_B, length.Length_Sixteenth, 0),
+			},
+		},
+		{
+			Symbols: []*symbols.Symbol{
+				{Note: &symbols.Note{
+					Pitch:         pitch.Pitch_C,
+					Length:        length.Length_Quarter,
+					Embellishment: &embellishment.Embellishment{Type: embellishment.Type_Grip},
+				}},
+				{Rest: &symbols.Rest{Length: length.Length_Quarter}},
+			},
+		},
+		{InlineTexts: []string{"D.C."}},
+	}}
+
+	g.Expect(Of(written)).To(Equal("2/4. 3/5|4/3 r/3"))
+	g.Expect(Of(rewritten)).To(Equal(Of(written)))
+	g.Expect(Of(&tune.Tune{})).To(BeEmpty())
+}
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want float64
+	}{
+		{
+			name: "same measures",
+			a:    "2/4|3/4",
+			b:    "2/4|3/4",
+			want: 1,
+		},
+		{
+			name: "one of four measures changed",
+			a:    "2/4|3/4|4/4|5/4",
+			b:    "2/4|3/4|6/4|5/4",
+			want: 0.75,
+		},
+		{
+			name: "additional measures",
+			a:    "2/4|3/4",
+			b:    "1/4|2/4|3/4|4/4",
+			want: 4.0 / 6.0,
+		},
+		{
+			name: "nothing in common",
+			a:    "2/4|3/4",
+			b:    "4/4|5/4",
+			want: 0,
+		},
+		{
+			name: "no music",
+			a:    "",
+			b:    "",
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(Similarity(tt.a, tt.b)).To(BeNumerically("~", tt.want, 0.0001))
+			g.Expect(MaxSimilarity(tt.a, tt.b)).To(BeNumerically(">=", Similarity(tt.a, tt.b)))
+		})
+	}
+}
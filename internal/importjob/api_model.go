@@ -0,0 +1,41 @@
+package importjob
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/database/model"
+)
+
+// APIImportJob converts an import job from the database into its API model.
+func APIImportJob(job *model.ImportJob) (*apimodel.ImportJob, error) {
+	apiJob := &apimodel.ImportJob{
+		Id:       job.ID,
+		Status:   string(job.Status),
+		FileName: job.FileName,
+		Error:    job.Error,
+		Progress: apimodel.ImportProgress{
+			Total: int32(job.FilesTotal),
+			Done:  int32(job.FilesDone),
+		},
+		CreatedAt: job.CreatedAt.Time,
+	}
+
+	if job.StartedAt != nil {
+		apiJob.StartedAt = &job.StartedAt.Time
+	}
+	if job.FinishedAt != nil {
+		apiJob.FinishedAt = &job.FinishedAt.Time
+	}
+
+	if len(job.Report) > 0 {
+		report := &model.ImportReport{}
+		if err := json.Unmarshal(job.Report, report); err != nil {
+			return nil, fmt.Errorf("failed reading report of import job %s: %w", job.ID, err)
+		}
+		apiJob.Files = report.Files
+		apiJob.FolderSets = report.FolderSets
+	}
+
+	return apiJob, nil
+}
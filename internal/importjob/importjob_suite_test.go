@@ -0,0 +1,13 @@
+package importjob_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestImportjob(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Importjob Suite")
+}
@@ -0,0 +1,361 @@
+package importjob
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/SamuelTissot/sqltime"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/afero"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/archive"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/interfaces"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Processor imports the files of an import job into the database.
+type Processor struct {
+	service      interfaces.DataService
+	pluginLoader interfaces.PluginLoader
+}
+
+// Options are the options of an import that apply to all of its files.
+type Options struct {
+	// SetPerFolder creates a set for every folder of an archive
+	// with all tunes of the files in that folder.
+	SetPerFolder bool
+
+	// Reimport updates the tunes of earlier imports of the files instead
+	// of creating new ones. TuneMapping maps tune titles to the ids of the
+	// tunes they update.
+	Reimport    bool
+	TuneMapping map[string]uuid.UUID
+
+	// OwnerID is the user who owns the imported tunes and sets
+	OwnerID *uuid.UUID
+
+	// AutoTag tags the tunes of a file with the name of its folder and
+	// the tags in square brackets of its file name.
+	AutoTag bool
+}
+
+// TagsForFile returns the tags for the tunes of the file with the given
+// path, which are only derived from the path if AutoTag is set.
+func (o Options) TagsForFile(filePath string) []string {
+	if !o.AutoTag {
+		return nil
+	}
+
+	return common.TagsFromPath(filePath)
+}
+
+// importEntry is a single file to import. For files of an archive,
+// the name is the path of the file inside the archive.
+type importEntry struct {
+	name  string
+	data  []byte
+	jobID *uuid.UUID // the job the file is imported by, if any
+}
+
+// Process imports the file of a claimed job and stores the result of the
+// import in the job. An archive is unpacked and all of its files are imported.
+// A file that can't be imported doesn't return an error, instead the error
+// is part of the job's report.
+func (p *Processor) Process(job *model.ImportJob) error {
+	entries, err := p.jobEntries(job)
+	if err != nil {
+		return p.Fail(job, err)
+	}
+
+	job.FilesTotal = uint(len(entries))
+	if err = p.service.UpdateImportJob(job); err != nil {
+		return err
+	}
+
+	report, err := p.importEntries(entries, jobOptions(job), func() {
+		job.FilesDone++
+		if err := p.service.UpdateImportJob(job); err != nil {
+			log.Error().Err(err).Msgf("failed updating progress of import job %s", job.ID)
+		}
+	})
+
+	return p.finish(job, report, err)
+}
+
+// ImportArchive imports all files of the given archive and returns the
+// consolidated report of the import.
+func (p *Processor) ImportArchive(
+	fileName string,
+	data []byte,
+	opts Options,
+) (*model.ImportReport, error) {
+	entries, err := p.archiveEntries(fileName, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.importEntries(entries, opts, nil)
+}
+
+// Fail marks the job as failed as a whole with the given error.
+func (p *Processor) Fail(job *model.ImportJob, jobErr error) error {
+	now := sqltime.Now()
+	job.Status = model.ImportJobStatusFailed
+	job.Error = jobErr.Error()
+	job.FinishedAt = &now
+
+	return p.service.UpdateImportJob(job)
+}
+
+func jobOptions(job *model.ImportJob) Options {
+	return Options{
+		SetPerFolder: job.SetPerFolder,
+		Reimport:     job.Reimport,
+		TuneMapping:  job.TuneMapping,
+		OwnerID:      job.OwnerID,
+		AutoTag:      job.AutoTag,
+	}
+}
+
+func (p *Processor) jobEntries(job *model.ImportJob) ([]importEntry, error) {
+	entries := []importEntry{{name: job.FileName, data: job.Data}}
+	if archive.IsArchive(job.FileName) {
+		var err error
+		if entries, err = p.archiveEntries(job.FileName, job.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range entries {
+		entries[i].jobID = &job.ID
+	}
+
+	return entries, nil
+}
+
+// archiveEntries returns all files of the archive that can be imported
+// by one of the loaded plugins. Other files are ignored.
+func (p *Processor) archiveEntries(fileName string, data []byte) ([]importEntry, error) {
+	afs, err := archive.Extract(fileName, data)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := archive.Files(afs)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := p.importableEntries(afs, files)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%w: archive %s contains no files that can be imported",
+			common.ErrInvalidArgument, fileName)
+	}
+
+	return entries, nil
+}
+
+func (p *Processor) importableEntries(afs afero.Fs, files []string) ([]importEntry, error) {
+	var entries []importEntry
+	for _, f := range files {
+		if _, err := p.pluginLoader.FileFormatForFileExtension(filepath.Ext(f)); err != nil {
+			log.Debug().Msgf("ignoring file %s of archive", f)
+			continue
+		}
+
+		data, err := afero.ReadFile(afs, f)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, importEntry{
+			name: strings.TrimPrefix(f, "/"),
+			data: data,
+		})
+	}
+
+	return entries, nil
+}
+
+// importEntries imports all entries and calls fileDone after every entry.
+// If SetPerFolder is set, a set is created for every folder with the tunes
+// of the imported files of that folder. Files in the root of an archive
+// are not part of any folder set.
+func (p *Processor) importEntries(
+	entries []importEntry,
+	opts Options,
+	fileDone func(),
+) (*model.ImportReport, error) {
+	report := &model.ImportReport{}
+	folderTunes := make(map[string][]uuid.UUID)
+	for _, e := range entries {
+		result := p.importFile(e, opts)
+		report.Files = append(report.Files, result)
+
+		folder := path.Dir(e.name)
+		for _, t := range result.Tunes {
+			folderTunes[folder] = append(folderTunes[folder], t.Id)
+		}
+
+		if fileDone != nil {
+			fileDone()
+		}
+	}
+
+	if !opts.SetPerFolder {
+		return report, nil
+	}
+
+	var err error
+	report.FolderSets, err = p.createFolderSets(folderTunes, opts.OwnerID)
+
+	return report, err
+}
+
+func (p *Processor) createFolderSets(
+	folderTunes map[string][]uuid.UUID,
+	ownerID *uuid.UUID,
+) ([]*apimodel.BasicMusicSet, error) {
+	var sets []*apimodel.BasicMusicSet
+	folders := make([]string, 0, len(folderTunes))
+	for folder := range folderTunes {
+		if folder != "." {
+			folders = append(folders, folder)
+		}
+	}
+	slices.Sort(folders)
+
+	for _, folder := range folders {
+		set, err := p.service.CreateMusicSet(apimodel.CreateSet{
+			Title: path.Base(folder),
+			Tunes: folderTunes[folder],
+		}, nil, ownerID)
+		if err != nil {
+			return sets, fmt.Errorf("failed creating set for folder %s: %w", folder, err)
+		}
+
+		sets = append(sets, &apimodel.BasicMusicSet{
+			Id:          set.Id,
+			Title:       set.Title,
+			Description: set.Description,
+			Creator:     set.Creator,
+		})
+	}
+
+	return sets, nil
+}
+
+func (p *Processor) importFile(e importEntry, opts Options) apimodel.ImportFile {
+	result := apimodel.ImportFile{
+		Name: e.name,
+	}
+
+	tunes, set, err := p.parseAndImport(e, opts)
+	switch {
+	case errors.Is(err, common.ErrAlreadyExists):
+		result.Duplicate = true
+	case err != nil:
+		result.Error = err.Error()
+	default:
+		result.Tunes = tunes
+		result.Set = set
+	}
+
+	return result
+}
+
+func (p *Processor) parseAndImport(
+	e importEntry,
+	opts Options,
+) ([]*apimodel.ImportTune, *apimodel.BasicMusicSet, error) {
+	fExt := filepath.Ext(e.name)
+	fFormat, err := p.pluginLoader.FileFormatForFileExtension(fExt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("file extension %s is currently not supported: %s", fExt, err.Error())
+	}
+
+	fInfo, err := common.NewImportFileInfo(e.name, fFormat, e.data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed creating import file info for file %s: %s", e.name, err.Error())
+	}
+
+	fInfo.ImportJobID = e.jobID
+	fInfo.Reimport = opts.Reimport
+	fInfo.TuneMapping = opts.TuneMapping
+	fInfo.OwnerID = opts.OwnerID
+	fInfo.Tags = opts.TagsForFile(e.name)
+
+	if err = p.checkAlreadyImported(fInfo); err != nil {
+		return nil, nil, err
+	}
+
+	filePlugin, err := p.pluginLoader.PluginForFileExtension(fExt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("file extension %s is currently not supported (no plugin): %s", fExt, err.Error())
+	}
+
+	parsedTunes, err := filePlugin.Parse(e.data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed parsing file %s: %s", e.name, err.Error())
+	}
+
+	return p.service.ImportTunes(parsedTunes, fInfo)
+}
+
+// checkAlreadyImported returns an ErrAlreadyExists error if a file
+// with the same content was already imported.
+func (p *Processor) checkAlreadyImported(fInfo *common.ImportFileInfo) error {
+	_, err := p.service.GetImportFileByHash(fInfo.Hash)
+	if err == nil {
+		return fmt.Errorf("%w: file %s was already imported", common.ErrAlreadyExists, fInfo.OriginalPath)
+	}
+	if errors.Is(err, common.ErrNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+// finish stores the report in the job. The job failed if not a single file
+// could be imported or if the folder sets couldn't be created.
+func (p *Processor) finish(job *model.ImportJob, report *model.ImportReport, importErr error) error {
+	reportData, err := json.Marshal(report)
+	if err != nil {
+		return p.Fail(job, fmt.Errorf("failed creating import report: %w", err))
+	}
+
+	now := sqltime.Now()
+	job.Report = reportData
+	job.FinishedAt = &now
+	job.Status = model.ImportJobStatusCompleted
+	job.Error = ""
+
+	switch {
+	case importErr != nil:
+		job.Status = model.ImportJobStatusFailed
+		job.Error = importErr.Error()
+	case len(report.Failures()) == len(report.Files):
+		job.Status = model.ImportJobStatusFailed
+		job.Error = "none of the files could be imported"
+	}
+
+	return p.service.UpdateImportJob(job)
+}
+
+func NewProcessor(
+	service interfaces.DataService,
+	pluginLoader interfaces.PluginLoader,
+) *Processor {
+	return &Processor{
+		service:      service,
+		pluginLoader: pluginLoader,
+	}
+}
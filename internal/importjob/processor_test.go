@@ -0,0 +1,277 @@
+package importjob
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	pmocks "github.com/tomvodi/limepipes-plugin-api/plugin/v1/interfaces/mocks"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/messages"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/archive"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"slices"
+)
+
+var _ = Describe("Processor", func() {
+	var err error
+	var processor *Processor
+	var job *model.ImportJob
+	var tuneID uuid.UUID
+	var parsedTunes []*messages.ParsedTune
+	var dataService *mocks.DataService
+	var pluginLoader *mocks.PluginLoader
+	var lpPlugin *pmocks.LimePipesPlugin
+
+	BeforeEach(func() {
+		tuneID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+		dataService = mocks.NewDataService(GinkgoT())
+		pluginLoader = mocks.NewPluginLoader(GinkgoT())
+		lpPlugin = pmocks.NewLimePipesPlugin(GinkgoT())
+		processor = NewProcessor(dataService, pluginLoader)
+		parsedTunes = []*messages.ParsedTune{model.TestParsedTune("test tune")}
+
+		job = &model.ImportJob{
+			Status:     model.ImportJobStatusRunning,
+			FileName:   "test.bww",
+			FileFormat: fileformat.Format_BWW,
+			Data:       []byte("test file content"),
+		}
+	})
+
+	JustBeforeEach(func() {
+		err = processor.Process(job)
+	})
+
+	report := func() *model.ImportReport {
+		r := &model.ImportReport{}
+		Expect(json.Unmarshal(job.Report, r)).To(Succeed())
+		return r
+	}
+
+	Context("importing a single file", func() {
+		BeforeEach(func() {
+			dataService.EXPECT().UpdateImportJob(job).Return(nil)
+		})
+
+		When("the file extension is not supported", func() {
+			BeforeEach(func() {
+				pluginLoader.EXPECT().FileFormatForFileExtension(".bww").
+					Return(fileformat.Format_Unknown, fmt.Errorf("not supported"))
+			})
+
+			It("should fail the job with the error in the report", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(job.Status).To(Equal(model.ImportJobStatusFailed))
+				Expect(job.FinishedAt).NotTo(BeNil())
+				Expect(report().Files).To(HaveLen(1))
+				Expect(report().Files[0].Error).To(ContainSubstring("not supported"))
+			})
+		})
+
+		When("the file was already imported", func() {
+			BeforeEach(func() {
+				pluginLoader.EXPECT().FileFormatForFileExtension(".bww").
+					Return(fileformat.Format_BWW, nil)
+				dataService.EXPECT().GetImportFileByHash(mock.Anything).
+					Return(&model.ImportFile{Name: "test"}, nil)
+			})
+
+			It("should report the file as duplicate", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(job.Status).To(Equal(model.ImportJobStatusCompleted))
+				Expect(report().Files).To(Equal([]apimodel.ImportFile{
+					{Name: "test.bww", Duplicate: true},
+				}))
+			})
+		})
+
+		Context("the file was not imported before", func() {
+			BeforeEach(func() {
+				pluginLoader.EXPECT().FileFormatForFileExtension(".bww").
+					Return(fileformat.Format_BWW, nil)
+				dataService.EXPECT().GetImportFileByHash(mock.Anything).
+					Return(nil, common.ErrNotFound)
+				pluginLoader.EXPECT().PluginForFileExtension(".bww").
+					Return(lpPlugin, nil)
+			})
+
+			When("the plugin fails parsing the file", func() {
+				BeforeEach(func() {
+					lpPlugin.EXPECT().Parse([]byte("test file content")).
+						Return(nil, fmt.Errorf("unexpected token"))
+				})
+
+				It("should fail the job with the error in the report", func() {
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(job.Status).To(Equal(model.ImportJobStatusFailed))
+					Expect(job.FilesTotal).To(BeEquivalentTo(1))
+					Expect(job.FilesDone).To(BeEquivalentTo(1))
+					Expect(report().Files[0].Error).To(Equal("failed parsing file test.bww: unexpected token"))
+				})
+			})
+
+			When("the file was imported", func() {
+				BeforeEach(func() {
+					lpPlugin.EXPECT().Parse([]byte("test file content")).
+						Return(parsedTunes, nil)
+					dataService.EXPECT().ImportTunes(parsedTunes, mock.Anything).
+						Return([]*apimodel.ImportTune{{Id: tuneID, Title: "test tune"}},
+							&apimodel.BasicMusicSet{Id: tuneID, Title: "test"}, nil)
+				})
+
+				It("should complete the job with the imported tunes in the report", func() {
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(job.Status).To(Equal(model.ImportJobStatusCompleted))
+					Expect(job.Error).To(BeEmpty())
+					Expect(report().Files).To(Equal([]apimodel.ImportFile{
+						{
+							Name:  "test.bww",
+							Set:   &apimodel.BasicMusicSet{Id: tuneID, Title: "test"},
+							Tunes: []*apimodel.ImportTune{{Id: tuneID, Title: "test tune"}},
+						},
+					}))
+				})
+			})
+
+			When("the file is reimported", func() {
+				BeforeEach(func() {
+					job.Reimport = true
+					job.TuneMapping = map[string]uuid.UUID{"test tune": tuneID}
+					lpPlugin.EXPECT().Parse([]byte("test file content")).
+						Return(parsedTunes, nil)
+					dataService.EXPECT().ImportTunes(parsedTunes, mock.Anything).
+						RunAndReturn(func(
+							_ []*messages.ParsedTune,
+							fInfo *common.ImportFileInfo,
+						) ([]*apimodel.ImportTune, *apimodel.BasicMusicSet, error) {
+							Expect(fInfo.Reimport).To(BeTrue())
+							Expect(fInfo.TuneMapping).To(Equal(job.TuneMapping))
+							return []*apimodel.ImportTune{{
+								Id:      tuneID,
+								Title:   "test tune",
+								Action:  model.ReimportActionUpdated,
+								Changes: []string{"composer"},
+							}}, nil, nil
+						})
+				})
+
+				It("should report the updated tunes", func() {
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(job.Status).To(Equal(model.ImportJobStatusCompleted))
+					Expect(report().Updated()).To(HaveLen(1))
+					Expect(report().Updated()[0].Changes).To(Equal([]string{"composer"}))
+				})
+			})
+
+			When("the file is imported with auto tagging", func() {
+				BeforeEach(func() {
+					job.AutoTag = true
+					job.FileName = "test [grade 2].bww"
+					lpPlugin.EXPECT().Parse([]byte("test file content")).
+						Return(parsedTunes, nil)
+					dataService.EXPECT().ImportTunes(parsedTunes, mock.MatchedBy(
+						func(fInfo *common.ImportFileInfo) bool {
+							return slices.Equal(fInfo.Tags, []string{"grade 2"})
+						})).
+						Return([]*apimodel.ImportTune{{Id: tuneID, Title: "test tune"}}, nil, nil)
+				})
+
+				It("should import the tunes with the tags of the file name", func() {
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(job.Status).To(Equal(model.ImportJobStatusCompleted))
+				})
+			})
+		})
+	})
+
+	Context("importing an archive", func() {
+		BeforeEach(func() {
+			job.FileName = "tunes.zip"
+			job.FileFormat = fileformat.Format_Unknown
+			job.Data = archive.TestZip(
+				archive.TestFile{Name: "marches/scotland.bww", Content: "scotland"},
+				archive.TestFile{Name: "marches/brown.bww", Content: "brown"},
+				archive.TestFile{Name: "readme.txt", Content: "readme"},
+			)
+			pluginLoader.EXPECT().FileFormatForFileExtension(".txt").
+				Return(fileformat.Format_Unknown, fmt.Errorf("not supported"))
+		})
+
+		When("the archive contains no file that can be imported", func() {
+			BeforeEach(func() {
+				job.Data = archive.TestZip(archive.TestFile{Name: "readme.txt", Content: "readme"})
+				dataService.EXPECT().UpdateImportJob(job).Return(nil)
+			})
+
+			It("should fail the job", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(job.Status).To(Equal(model.ImportJobStatusFailed))
+				Expect(job.Error).To(ContainSubstring("contains no files"))
+			})
+		})
+
+		When("one file of the archive was already imported", func() {
+			var brownID uuid.UUID
+			var folderSetID uuid.UUID
+			var ownerID uuid.UUID
+
+			BeforeEach(func() {
+				brownID = uuid.MustParse("00000000-0000-0000-0000-000000000002")
+				folderSetID = uuid.MustParse("00000000-0000-0000-0000-000000000003")
+				ownerID = uuid.MustParse("00000000-0000-0000-0000-000000000004")
+				job.SetPerFolder = true
+				job.OwnerID = &ownerID
+
+				pluginLoader.EXPECT().FileFormatForFileExtension(".bww").
+					Return(fileformat.Format_BWW, nil)
+				dataService.EXPECT().UpdateImportJob(job).Return(nil).Times(4)
+				dataService.EXPECT().GetImportFileByHash(mock.Anything).
+					Return(nil, common.ErrNotFound).Once()
+				dataService.EXPECT().GetImportFileByHash(mock.Anything).
+					Return(&model.ImportFile{Name: "scotland"}, nil).Once()
+				pluginLoader.EXPECT().PluginForFileExtension(".bww").
+					Return(lpPlugin, nil)
+				lpPlugin.EXPECT().Parse([]byte("brown")).
+					Return(parsedTunes, nil)
+				dataService.EXPECT().ImportTunes(parsedTunes, mock.MatchedBy(
+					func(fInfo *common.ImportFileInfo) bool {
+						return fInfo.OwnerID != nil && *fInfo.OwnerID == ownerID
+					})).
+					Return([]*apimodel.ImportTune{{Id: brownID, Title: "Brown"}}, nil, nil)
+				dataService.EXPECT().CreateMusicSet(apimodel.CreateSet{
+					Title: "marches",
+					Tunes: []uuid.UUID{brownID},
+				}, (*model.ImportFile)(nil), &ownerID).
+					Return(&apimodel.MusicSet{Id: folderSetID, Title: "marches"}, nil)
+			})
+
+			It("should report the imported file, the duplicate and the folder set", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(job.Status).To(Equal(model.ImportJobStatusCompleted))
+				Expect(job.FilesTotal).To(BeEquivalentTo(2))
+				Expect(job.FilesDone).To(BeEquivalentTo(2))
+				Expect(report()).To(Equal(&model.ImportReport{
+					Files: []apimodel.ImportFile{
+						{
+							Name:  "marches/brown.bww",
+							Tunes: []*apimodel.ImportTune{{Id: brownID, Title: "Brown"}},
+						},
+						{
+							Name:      "marches/scotland.bww",
+							Duplicate: true,
+						},
+					},
+					FolderSets: []*apimodel.BasicMusicSet{
+						{Id: folderSetID, Title: "marches"},
+					},
+				}))
+			})
+		})
+	})
+})
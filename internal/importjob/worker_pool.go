@@ -0,0 +1,134 @@
+package importjob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/config"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/interfaces"
+	"sync"
+	"time"
+)
+
+// WorkerPool processes queued import jobs in the background. The queue
+// is the import job table of the database, so jobs survive a restart.
+// Workers are woken up when a job is enqueued and additionally look
+// for queued jobs in an interval.
+type WorkerPool struct {
+	service      interfaces.DataService
+	processor    *Processor
+	workers      int
+	pollInterval time.Duration
+	wakeUp       chan struct{}
+	wg           sync.WaitGroup
+	cancel       context.CancelFunc
+}
+
+// Start requeues jobs that were interrupted by a shutdown and starts the workers.
+func (w *WorkerPool) Start(ctx context.Context) error {
+	requeued, err := w.service.RequeueRunningImportJobs()
+	if err != nil {
+		return fmt.Errorf("failed requeuing interrupted import jobs: %w", err)
+	}
+	if requeued > 0 {
+		log.Info().Msgf("requeued %d interrupted import jobs", requeued)
+	}
+
+	ctx, w.cancel = context.WithCancel(ctx)
+	for i := 0; i < w.workers; i++ {
+		w.wg.Add(1)
+		go w.work(ctx)
+	}
+
+	return nil
+}
+
+// Stop stops the workers and waits until they finished their current job.
+func (w *WorkerPool) Stop() {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	w.wg.Wait()
+}
+
+// Enqueue stores the job as queued job in the database and wakes up a worker.
+func (w *WorkerPool) Enqueue(job *model.ImportJob) error {
+	job.Status = model.ImportJobStatusQueued
+	if err := w.service.CreateImportJob(job); err != nil {
+		return err
+	}
+
+	select {
+	case w.wakeUp <- struct{}{}:
+	default: // all workers are busy or already woken up
+	}
+
+	return nil
+}
+
+func (w *WorkerPool) work(ctx context.Context) {
+	defer w.wg.Done()
+
+	for ctx.Err() == nil {
+		if w.processNextJob() {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-w.wakeUp:
+		case <-time.After(w.pollInterval):
+		}
+	}
+}
+
+// processNextJob returns true if there was a queued job that was processed.
+func (w *WorkerPool) processNextJob() bool {
+	job, err := w.service.ClaimNextImportJob()
+	if errors.Is(err, common.ErrNotFound) {
+		return false
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("failed claiming next import job")
+		return false
+	}
+
+	w.processJob(job)
+
+	return true
+}
+
+func (w *WorkerPool) processJob(job *model.ImportJob) {
+	defer func() {
+		if r := recover(); r != nil {
+			err := w.processor.Fail(job, fmt.Errorf("import failed unexpectedly: %v", r))
+			if err != nil {
+				log.Error().Err(err).Msgf("failed storing failed import job %s", job.ID)
+			}
+		}
+	}()
+
+	log.Info().Msgf("processing import job %s for file %s", job.ID, job.FileName)
+	if err := w.processor.Process(job); err != nil {
+		log.Error().Err(err).Msgf("failed processing import job %s", job.ID)
+	}
+}
+
+func NewWorkerPool(
+	service interfaces.DataService,
+	pluginLoader interfaces.PluginLoader,
+	cfg config.ImportConfig,
+) *WorkerPool {
+	workers := max(cfg.Workers, 1)
+
+	return &WorkerPool{
+		service:      service,
+		processor:    NewProcessor(service, pluginLoader),
+		workers:      workers,
+		pollInterval: time.Duration(max(cfg.PollIntervalSeconds, 1)) * time.Second,
+		wakeUp:       make(chan struct{}, workers),
+	}
+}
@@ -0,0 +1,111 @@
+package importjob
+
+import (
+	"context"
+	"fmt"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/config"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"github.com/tomvodi/limepipes/internal/interfaces/mocks"
+	"time"
+)
+
+var _ = Describe("WorkerPool", func() {
+	var err error
+	var pool *WorkerPool
+	var dataService *mocks.DataService
+	var pluginLoader *mocks.PluginLoader
+
+	BeforeEach(func() {
+		dataService = mocks.NewDataService(GinkgoT())
+		pluginLoader = mocks.NewPluginLoader(GinkgoT())
+		pool = NewWorkerPool(dataService, pluginLoader, config.ImportConfig{Workers: 1})
+	})
+
+	Context("Start", func() {
+		JustBeforeEach(func() {
+			err = pool.Start(context.Background())
+			DeferCleanup(pool.Stop)
+		})
+
+		When("requeuing interrupted jobs fails", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().RequeueRunningImportJobs().
+					Return(0, fmt.Errorf("database down"))
+			})
+
+			It("should return an error", func() {
+				Expect(err).Should(HaveOccurred())
+			})
+		})
+
+		When("there is a queued job", func() {
+			var job *model.ImportJob
+			var processed chan struct{}
+
+			BeforeEach(func() {
+				processed = make(chan struct{})
+				job = &model.ImportJob{
+					Status:     model.ImportJobStatusRunning,
+					FileName:   "test.bww",
+					FileFormat: fileformat.Format_BWW,
+					Data:       []byte("test file content"),
+				}
+				dataService.EXPECT().RequeueRunningImportJobs().Return(1, nil)
+				dataService.EXPECT().ClaimNextImportJob().Return(job, nil).Once()
+				dataService.EXPECT().ClaimNextImportJob().Return(nil, common.ErrNotFound).Maybe()
+				dataService.EXPECT().UpdateImportJob(job).Return(nil).Times(2)
+				pluginLoader.EXPECT().FileFormatForFileExtension(".bww").
+					Return(fileformat.Format_Unknown, fmt.Errorf("not supported"))
+				dataService.EXPECT().UpdateImportJob(job).
+					Run(func(*model.ImportJob) { close(processed) }).
+					Return(nil).Once()
+			})
+
+			It("should process that job", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Eventually(processed).Should(BeClosed())
+				Expect(job.Status).To(Equal(model.ImportJobStatusFailed))
+			})
+		})
+	})
+
+	Context("Enqueue", func() {
+		var job *model.ImportJob
+
+		BeforeEach(func() {
+			job = &model.ImportJob{FileName: "test.bww"}
+		})
+
+		JustBeforeEach(func() {
+			err = pool.Enqueue(job)
+		})
+
+		When("creating the job fails", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().CreateImportJob(mock.Anything).
+					Return(fmt.Errorf("database down"))
+			})
+
+			It("should return an error", func() {
+				Expect(err).Should(HaveOccurred())
+			})
+		})
+
+		When("the job was created", func() {
+			BeforeEach(func() {
+				dataService.EXPECT().CreateImportJob(job).Return(nil)
+			})
+
+			It("should queue the job and wake up a worker", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(job.Status).To(Equal(model.ImportJobStatusQueued))
+				Eventually(pool.wakeUp).WithTimeout(time.Second).Should(Receive())
+			})
+		})
+	})
+})
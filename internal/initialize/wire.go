@@ -7,10 +7,12 @@ import (
 	"github.com/google/wire"
 	"github.com/spf13/afero"
 	"github.com/tomvodi/limepipes/internal/api"
+	"github.com/tomvodi/limepipes/internal/auth"
 	"github.com/tomvodi/limepipes/internal/common"
 	"github.com/tomvodi/limepipes/internal/config"
 	"github.com/tomvodi/limepipes/internal/database"
 	"github.com/tomvodi/limepipes/internal/health"
+	"github.com/tomvodi/limepipes/internal/importjob"
 	"github.com/tomvodi/limepipes/internal/interfaces"
 	"github.com/tomvodi/limepipes/internal/pluginloader"
 	"gorm.io/gorm"
@@ -33,6 +35,7 @@ func ApiHandler(
 	db *gorm.DB,
 	healthConfig config.HealthConfig,
 	pluginloader interfaces.PluginLoader,
+	importQueue interfaces.ImportJobQueue,
 ) (*api.Handler, error) {
 	wire.Build(
 		api.NewGinValidator,
@@ -47,3 +50,52 @@ func ApiHandler(
 
 	return &api.Handler{}, nil
 }
+
+func ImportWorkerPool(
+	db *gorm.DB,
+	pluginloader interfaces.PluginLoader,
+	importConfig config.ImportConfig,
+) *importjob.WorkerPool {
+	wire.Build(
+		api.NewGinValidator,
+		api.NewAPIModelValidator,
+		wire.Bind(new(interfaces.APIModelValidator), new(*api.ModelValidator)),
+		database.NewDbDataService,
+		wire.Bind(new(interfaces.DataService), new(*database.Service)),
+		importjob.NewWorkerPool,
+	)
+
+	return &importjob.WorkerPool{}
+}
+
+func Authenticator(
+	db *gorm.DB,
+	verifier interfaces.TokenVerifier,
+) *auth.Authenticator {
+	wire.Build(
+		api.NewGinValidator,
+		api.NewAPIModelValidator,
+		wire.Bind(new(interfaces.APIModelValidator), new(*api.ModelValidator)),
+		database.NewDbDataService,
+		wire.Bind(new(interfaces.DataService), new(*database.Service)),
+		auth.NewAuthenticator,
+	)
+
+	return &auth.Authenticator{}
+}
+
+func Authorizer(
+	db *gorm.DB,
+	defaultRole common.Role,
+) *auth.Authorizer {
+	wire.Build(
+		api.NewGinValidator,
+		api.NewAPIModelValidator,
+		wire.Bind(new(interfaces.APIModelValidator), new(*api.ModelValidator)),
+		database.NewDbDataService,
+		wire.Bind(new(interfaces.DataService), new(*database.Service)),
+		auth.NewAuthorizer,
+	)
+
+	return &auth.Authorizer{}
+}
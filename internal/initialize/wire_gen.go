@@ -9,10 +9,12 @@ package initialize
 import (
 	"github.com/spf13/afero"
 	"github.com/tomvodi/limepipes/internal/api"
+	"github.com/tomvodi/limepipes/internal/auth"
 	"github.com/tomvodi/limepipes/internal/common"
 	"github.com/tomvodi/limepipes/internal/config"
 	"github.com/tomvodi/limepipes/internal/database"
 	"github.com/tomvodi/limepipes/internal/health"
+	"github.com/tomvodi/limepipes/internal/importjob"
 	"github.com/tomvodi/limepipes/internal/interfaces"
 	"github.com/tomvodi/limepipes/internal/pluginloader"
 	"gorm.io/gorm"
@@ -31,7 +33,7 @@ var (
 	_wireFsValue = afero.NewOsFs()
 )
 
-func ApiHandler(db *gorm.DB, healthConfig config.HealthConfig, pluginloader2 interfaces.PluginLoader) (*api.Handler, error) {
+func ApiHandler(db *gorm.DB, healthConfig config.HealthConfig, pluginloader2 interfaces.PluginLoader, importQueue interfaces.ImportJobQueue) (*api.Handler, error) {
 	validate := api.NewGinValidator()
 	modelValidator := api.NewAPIModelValidator(validate)
 	service := database.NewDbDataService(db, modelValidator)
@@ -39,6 +41,30 @@ func ApiHandler(db *gorm.DB, healthConfig config.HealthConfig, pluginloader2 int
 	if err != nil {
 		return nil, err
 	}
-	handler := api.NewAPIHandler(service, pluginloader2, check)
+	handler := api.NewAPIHandler(service, pluginloader2, check, importQueue)
 	return handler, nil
 }
+
+func ImportWorkerPool(db *gorm.DB, pluginloader2 interfaces.PluginLoader, importConfig config.ImportConfig) *importjob.WorkerPool {
+	validate := api.NewGinValidator()
+	modelValidator := api.NewAPIModelValidator(validate)
+	service := database.NewDbDataService(db, modelValidator)
+	workerPool := importjob.NewWorkerPool(service, pluginloader2, importConfig)
+	return workerPool
+}
+
+func Authenticator(db *gorm.DB, verifier interfaces.TokenVerifier) *auth.Authenticator {
+	validate := api.NewGinValidator()
+	modelValidator := api.NewAPIModelValidator(validate)
+	service := database.NewDbDataService(db, modelValidator)
+	authenticator := auth.NewAuthenticator(service, verifier)
+	return authenticator
+}
+
+func Authorizer(db *gorm.DB, defaultRole common.Role) *auth.Authorizer {
+	validate := api.NewGinValidator()
+	modelValidator := api.NewAPIModelValidator(validate)
+	service := database.NewDbDataService(db, modelValidator)
+	authorizer := auth.NewAuthorizer(service, defaultRole)
+	return authorizer
+}
@@ -10,28 +10,92 @@ import (
 )
 
 type DataService interface {
-	Tunes() ([]*apimodel.Tune, error)
-	CreateTune(tune apimodel.CreateTune, importFile *model.ImportFile) (*apimodel.Tune, error)
+	Tunes(opts common.TuneListOptions) (*apimodel.TuneList, error)
+	CreateTune(tune apimodel.CreateTune, importFile *model.ImportFile, ownerID *uuid.UUID) (*apimodel.Tune, error)
 	GetTune(id uuid.UUID) (*apimodel.Tune, error)
-	UpdateTune(id uuid.UUID, tune apimodel.UpdateTune) (*apimodel.Tune, error)
+	UpdateTune(id uuid.UUID, tune apimodel.UpdateTune, author string) (*apimodel.Tune, error)
 	DeleteTune(id uuid.UUID) error
 
-	AddFileToTune(tuneID uuid.UUID, tFile *model.TuneFile) error
-	DeleteFileFromTune(tuneID uuid.UUID, fType fileformat.Format) error
+	AddFileToTune(tuneID uuid.UUID, tFile *model.TuneFile, author string) error
+	AddExportedFileToTune(tuneID uuid.UUID, tFile *model.TuneFile) error
+	DeleteFileFromTune(tuneID uuid.UUID, fType fileformat.Format, author string) error
 	GetTuneFile(tuneID uuid.UUID, fType fileformat.Format) (*model.TuneFile, error)
 	GetTuneFiles(tuneID uuid.UUID) ([]*model.TuneFile, error)
 
-	MusicSets() ([]*apimodel.MusicSet, error)
-	CreateMusicSet(tune apimodel.CreateSet, importFile *model.ImportFile) (*apimodel.MusicSet, error)
-	GetMusicSet(id uuid.UUID) (*apimodel.MusicSet, error)
-	UpdateMusicSet(id uuid.UUID, tune apimodel.UpdateSet) (*apimodel.MusicSet, error)
-	DeleteMusicSet(id uuid.UUID) error
+	TuneRevisions(tuneID uuid.UUID) ([]*model.TuneRevision, error)
+	TuneRevision(tuneID uuid.UUID, number uint) (*model.TuneRevision, error)
+	RestoreTuneRevision(tuneID uuid.UUID, number uint, author string) (*apimodel.Tune, error)
 
-	AssignTunesToMusicSet(setID uuid.UUID, tuneIDs []uuid.UUID) (*apimodel.MusicSet, error)
+	// The userID of the music set functions is the user who accesses the sets,
+	// private sets of other users are not found.
+	MusicSets(userID *uuid.UUID) ([]*apimodel.MusicSet, error)
+	CreateMusicSet(tune apimodel.CreateSet, importFile *model.ImportFile, ownerID *uuid.UUID) (*apimodel.MusicSet, error)
+	GetMusicSet(id uuid.UUID, userID *uuid.UUID) (*apimodel.MusicSet, error)
+	UpdateMusicSet(id uuid.UUID, tune apimodel.UpdateSet, userID *uuid.UUID) (*apimodel.MusicSet, error)
+	DeleteMusicSet(id uuid.UUID, userID *uuid.UUID) error
+
+	AssignTunesToMusicSet(setID uuid.UUID, tuneIDs []uuid.UUID, userID *uuid.UUID) (*apimodel.MusicSet, error)
+
+	Search(opts common.SearchOptions) (*apimodel.SearchResult, error)
 
 	GetImportFileByHash(fHash string) (*model.ImportFile, error)
 	ImportTunes(
 		parsedTunes []*messages.ParsedTune,
 		fileInfo *common.ImportFileInfo,
 	) ([]*apimodel.ImportTune, *apimodel.BasicMusicSet, error)
+
+	CreateImportJob(job *model.ImportJob) error
+	GetImportJob(id uuid.UUID, userID *uuid.UUID) (*model.ImportJob, error)
+	UpdateImportJob(job *model.ImportJob) error
+	ClaimNextImportJob() (*model.ImportJob, error)
+	RequeueRunningImportJobs() (int64, error)
+	ImportJobs(opts common.ImportListOptions, userID *uuid.UUID) ([]*model.ImportJob, *apimodel.Pagination, error)
+	DeleteImport(id uuid.UUID, userID *uuid.UUID) error
+
+	CreateUser(name string) (*model.User, error)
+	GetUser(id uuid.UUID) (*model.User, error)
+	GetUserByName(name string) (*model.User, error)
+	UserForExternalID(claims common.TokenClaims) (*model.User, error)
+	UserByAPIToken(token string) (*model.User, error)
+	CreateAPIToken(userID uuid.UUID, name string) (*model.APIToken, string, error)
+	APITokens(userID uuid.UUID) ([]*model.APIToken, error)
+	DeleteAPIToken(userID uuid.UUID, tokenID uuid.UUID) error
+
+	Memberships() ([]*model.Membership, error)
+	GetMembership(userID uuid.UUID) (*model.Membership, error)
+	SetMembership(userID uuid.UUID, role common.Role) (*model.Membership, error)
+	DeleteMembership(userID uuid.UUID) error
+
+	Tags() ([]*apimodel.Tag, error)
+	CreateTag(tag apimodel.CreateTag) (*apimodel.Tag, error)
+	GetTag(id uuid.UUID) (*apimodel.Tag, error)
+	UpdateTag(id uuid.UUID, update apimodel.UpdateTag) (*apimodel.Tag, error)
+	DeleteTag(id uuid.UUID) error
+	AssignTagsToTune(tuneID uuid.UUID, tagIDs []uuid.UUID) (*apimodel.Tune, error)
+	AssignTagsToMusicSet(setID uuid.UUID, tagIDs []uuid.UUID, userID *uuid.UUID) (*apimodel.MusicSet, error)
+
+	TuneTypes() ([]*apimodel.TuneType, error)
+	GetTuneType(id uuid.UUID) (*apimodel.TuneType, error)
+	UpdateTuneType(id uuid.UUID, update apimodel.UpdateTuneType) (*apimodel.TuneType, error)
+	MergeTuneTypes(id uuid.UUID, merge apimodel.MergeTuneTypes) (*apimodel.TuneType, error)
+	SetTuneTypeAliases(id uuid.UUID, aliases []string) (*apimodel.TuneType, error)
+
+	People() ([]*apimodel.Person, error)
+	GetPerson(id uuid.UUID) (*apimodel.Person, error)
+	PersonTunes(id uuid.UUID, opts common.TuneListOptions) (*apimodel.TuneList, error)
+	UpdatePerson(id uuid.UUID, update apimodel.UpdatePerson) (*apimodel.Person, error)
+	MergePeople(id uuid.UUID, merge apimodel.MergePeople) (*apimodel.Person, error)
+	SetPersonAliases(id uuid.UUID, aliases []string) (*apimodel.Person, error)
+
+	TuneDuplicates(id uuid.UUID) ([]*apimodel.TuneDuplicate, error)
+	MergeTunes(id uuid.UUID, merge apimodel.MergeTunes) (*apimodel.Tune, error)
+
+	TuneAnalysis(id uuid.UUID) (*apimodel.TuneAnalysis, error)
+	MusicSetAnalysis(id uuid.UUID, userID *uuid.UUID) (*apimodel.TuneAnalysis, error)
+	TunesAnalysis(opts common.TuneListOptions) (*apimodel.TuneAnalysis, error)
+
+	Messages(opts common.MessageListOptions) (*apimodel.TuneMessageList, error)
+	TuneMessages(tuneID uuid.UUID, opts common.MessageListOptions) ([]apimodel.TuneMessage, error)
+	UpdateMessage(id uuid.UUID, update apimodel.UpdateTuneMessage, author string) (*apimodel.TuneMessage, error)
+	UpdateTuneMessages(tuneID uuid.UUID, update apimodel.UpdateTuneMessage, author string) ([]apimodel.TuneMessage, error)
 }
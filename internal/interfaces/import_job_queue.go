@@ -0,0 +1,7 @@
+package interfaces
+
+import "github.com/tomvodi/limepipes/internal/database/model"
+
+type ImportJobQueue interface {
+	Enqueue(job *model.ImportJob) error
+}
@@ -30,12 +30,70 @@ func (_m *DataService) EXPECT() *DataService_Expecter {
 	return &DataService_Expecter{mock: &_m.Mock}
 }
 
-// AddFileToTune provides a mock function with given fields: tuneID, tFile
-func (_m *DataService) AddFileToTune(tuneID uuid.UUID, tFile *model.TuneFile) error {
+// APITokens provides a mock function with given fields: userID
+func (_m *DataService) APITokens(userID uuid.UUID) ([]*model.APIToken, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for APITokens")
+	}
+
+	var r0 []*model.APIToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) ([]*model.APIToken, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []*model.APIToken); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.APIToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_APITokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'APITokens'
+type DataService_APITokens_Call struct {
+	*mock.Call
+}
+
+// APITokens is a helper method to define mock.On call
+//   - userID uuid.UUID
+func (_e *DataService_Expecter) APITokens(userID interface{}) *DataService_APITokens_Call {
+	return &DataService_APITokens_Call{Call: _e.mock.On("APITokens", userID)}
+}
+
+func (_c *DataService_APITokens_Call) Run(run func(userID uuid.UUID)) *DataService_APITokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *DataService_APITokens_Call) Return(_a0 []*model.APIToken, _a1 error) *DataService_APITokens_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_APITokens_Call) RunAndReturn(run func(uuid.UUID) ([]*model.APIToken, error)) *DataService_APITokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddExportedFileToTune provides a mock function with given fields: tuneID, tFile
+func (_m *DataService) AddExportedFileToTune(tuneID uuid.UUID, tFile *model.TuneFile) error {
 	ret := _m.Called(tuneID, tFile)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AddFileToTune")
+		panic("no return value specified for AddExportedFileToTune")
 	}
 
 	var r0 error
@@ -48,6 +106,53 @@ func (_m *DataService) AddFileToTune(tuneID uuid.UUID, tFile *model.TuneFile) er
 	return r0
 }
 
+// DataService_AddExportedFileToTune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddExportedFileToTune'
+type DataService_AddExportedFileToTune_Call struct {
+	*mock.Call
+}
+
+// AddExportedFileToTune is a helper method to define mock.On call
+//   - tuneID uuid.UUID
+//   - tFile *model.TuneFile
+func (_e *DataService_Expecter) AddExportedFileToTune(tuneID interface{}, tFile interface{}) *DataService_AddExportedFileToTune_Call {
+	return &DataService_AddExportedFileToTune_Call{Call: _e.mock.On("AddExportedFileToTune", tuneID, tFile)}
+}
+
+func (_c *DataService_AddExportedFileToTune_Call) Run(run func(tuneID uuid.UUID, tFile *model.TuneFile)) *DataService_AddExportedFileToTune_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(*model.TuneFile))
+	})
+	return _c
+}
+
+func (_c *DataService_AddExportedFileToTune_Call) Return(_a0 error) *DataService_AddExportedFileToTune_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DataService_AddExportedFileToTune_Call) RunAndReturn(run func(uuid.UUID, *model.TuneFile) error) *DataService_AddExportedFileToTune_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddFileToTune provides a mock function with given fields: tuneID, tFile, author
+func (_m *DataService) AddFileToTune(tuneID uuid.UUID, tFile *model.TuneFile, author string) error {
+	ret := _m.Called(tuneID, tFile, author)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddFileToTune")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *model.TuneFile, string) error); ok {
+		r0 = rf(tuneID, tFile, author)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DataService_AddFileToTune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddFileToTune'
 type DataService_AddFileToTune_Call struct {
 	*mock.Call
@@ -56,13 +161,14 @@ type DataService_AddFileToTune_Call struct {
 // AddFileToTune is a helper method to define mock.On call
 //   - tuneID uuid.UUID
 //   - tFile *model.TuneFile
-func (_e *DataService_Expecter) AddFileToTune(tuneID interface{}, tFile interface{}) *DataService_AddFileToTune_Call {
-	return &DataService_AddFileToTune_Call{Call: _e.mock.On("AddFileToTune", tuneID, tFile)}
+//   - author string
+func (_e *DataService_Expecter) AddFileToTune(tuneID interface{}, tFile interface{}, author interface{}) *DataService_AddFileToTune_Call {
+	return &DataService_AddFileToTune_Call{Call: _e.mock.On("AddFileToTune", tuneID, tFile, author)}
 }
 
-func (_c *DataService_AddFileToTune_Call) Run(run func(tuneID uuid.UUID, tFile *model.TuneFile)) *DataService_AddFileToTune_Call {
+func (_c *DataService_AddFileToTune_Call) Run(run func(tuneID uuid.UUID, tFile *model.TuneFile, author string)) *DataService_AddFileToTune_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uuid.UUID), args[1].(*model.TuneFile))
+		run(args[0].(uuid.UUID), args[1].(*model.TuneFile), args[2].(string))
 	})
 	return _c
 }
@@ -72,34 +178,34 @@ func (_c *DataService_AddFileToTune_Call) Return(_a0 error) *DataService_AddFile
 	return _c
 }
 
-func (_c *DataService_AddFileToTune_Call) RunAndReturn(run func(uuid.UUID, *model.TuneFile) error) *DataService_AddFileToTune_Call {
+func (_c *DataService_AddFileToTune_Call) RunAndReturn(run func(uuid.UUID, *model.TuneFile, string) error) *DataService_AddFileToTune_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// AssignTunesToMusicSet provides a mock function with given fields: setID, tuneIDs
-func (_m *DataService) AssignTunesToMusicSet(setID uuid.UUID, tuneIDs []uuid.UUID) (*apimodel.MusicSet, error) {
-	ret := _m.Called(setID, tuneIDs)
+// AssignTagsToMusicSet provides a mock function with given fields: setID, tagIDs, userID
+func (_m *DataService) AssignTagsToMusicSet(setID uuid.UUID, tagIDs []uuid.UUID, userID *uuid.UUID) (*apimodel.MusicSet, error) {
+	ret := _m.Called(setID, tagIDs, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AssignTunesToMusicSet")
+		panic("no return value specified for AssignTagsToMusicSet")
 	}
 
 	var r0 *apimodel.MusicSet
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uuid.UUID, []uuid.UUID) (*apimodel.MusicSet, error)); ok {
-		return rf(setID, tuneIDs)
+	if rf, ok := ret.Get(0).(func(uuid.UUID, []uuid.UUID, *uuid.UUID) (*apimodel.MusicSet, error)); ok {
+		return rf(setID, tagIDs, userID)
 	}
-	if rf, ok := ret.Get(0).(func(uuid.UUID, []uuid.UUID) *apimodel.MusicSet); ok {
-		r0 = rf(setID, tuneIDs)
+	if rf, ok := ret.Get(0).(func(uuid.UUID, []uuid.UUID, *uuid.UUID) *apimodel.MusicSet); ok {
+		r0 = rf(setID, tagIDs, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*apimodel.MusicSet)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uuid.UUID, []uuid.UUID) error); ok {
-		r1 = rf(setID, tuneIDs)
+	if rf, ok := ret.Get(1).(func(uuid.UUID, []uuid.UUID, *uuid.UUID) error); ok {
+		r1 = rf(setID, tagIDs, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -107,58 +213,118 @@ func (_m *DataService) AssignTunesToMusicSet(setID uuid.UUID, tuneIDs []uuid.UUI
 	return r0, r1
 }
 
-// DataService_AssignTunesToMusicSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignTunesToMusicSet'
-type DataService_AssignTunesToMusicSet_Call struct {
+// DataService_AssignTagsToMusicSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignTagsToMusicSet'
+type DataService_AssignTagsToMusicSet_Call struct {
 	*mock.Call
 }
 
-// AssignTunesToMusicSet is a helper method to define mock.On call
+// AssignTagsToMusicSet is a helper method to define mock.On call
 //   - setID uuid.UUID
-//   - tuneIDs []uuid.UUID
-func (_e *DataService_Expecter) AssignTunesToMusicSet(setID interface{}, tuneIDs interface{}) *DataService_AssignTunesToMusicSet_Call {
-	return &DataService_AssignTunesToMusicSet_Call{Call: _e.mock.On("AssignTunesToMusicSet", setID, tuneIDs)}
+//   - tagIDs []uuid.UUID
+//   - userID *uuid.UUID
+func (_e *DataService_Expecter) AssignTagsToMusicSet(setID interface{}, tagIDs interface{}, userID interface{}) *DataService_AssignTagsToMusicSet_Call {
+	return &DataService_AssignTagsToMusicSet_Call{Call: _e.mock.On("AssignTagsToMusicSet", setID, tagIDs, userID)}
+}
+
+func (_c *DataService_AssignTagsToMusicSet_Call) Run(run func(setID uuid.UUID, tagIDs []uuid.UUID, userID *uuid.UUID)) *DataService_AssignTagsToMusicSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].([]uuid.UUID), args[2].(*uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *DataService_AssignTagsToMusicSet_Call) Return(_a0 *apimodel.MusicSet, _a1 error) *DataService_AssignTagsToMusicSet_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_AssignTagsToMusicSet_Call) RunAndReturn(run func(uuid.UUID, []uuid.UUID, *uuid.UUID) (*apimodel.MusicSet, error)) *DataService_AssignTagsToMusicSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AssignTagsToTune provides a mock function with given fields: tuneID, tagIDs
+func (_m *DataService) AssignTagsToTune(tuneID uuid.UUID, tagIDs []uuid.UUID) (*apimodel.Tune, error) {
+	ret := _m.Called(tuneID, tagIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignTagsToTune")
+	}
+
+	var r0 *apimodel.Tune
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, []uuid.UUID) (*apimodel.Tune, error)); ok {
+		return rf(tuneID, tagIDs)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, []uuid.UUID) *apimodel.Tune); ok {
+		r0 = rf(tuneID, tagIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*apimodel.Tune)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, []uuid.UUID) error); ok {
+		r1 = rf(tuneID, tagIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_AssignTagsToTune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignTagsToTune'
+type DataService_AssignTagsToTune_Call struct {
+	*mock.Call
+}
+
+// AssignTagsToTune is a helper method to define mock.On call
+//   - tuneID uuid.UUID
+//   - tagIDs []uuid.UUID
+func (_e *DataService_Expecter) AssignTagsToTune(tuneID interface{}, tagIDs interface{}) *DataService_AssignTagsToTune_Call {
+	return &DataService_AssignTagsToTune_Call{Call: _e.mock.On("AssignTagsToTune", tuneID, tagIDs)}
 }
 
-func (_c *DataService_AssignTunesToMusicSet_Call) Run(run func(setID uuid.UUID, tuneIDs []uuid.UUID)) *DataService_AssignTunesToMusicSet_Call {
+func (_c *DataService_AssignTagsToTune_Call) Run(run func(tuneID uuid.UUID, tagIDs []uuid.UUID)) *DataService_AssignTagsToTune_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(uuid.UUID), args[1].([]uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *DataService_AssignTunesToMusicSet_Call) Return(_a0 *apimodel.MusicSet, _a1 error) *DataService_AssignTunesToMusicSet_Call {
+func (_c *DataService_AssignTagsToTune_Call) Return(_a0 *apimodel.Tune, _a1 error) *DataService_AssignTagsToTune_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *DataService_AssignTunesToMusicSet_Call) RunAndReturn(run func(uuid.UUID, []uuid.UUID) (*apimodel.MusicSet, error)) *DataService_AssignTunesToMusicSet_Call {
+func (_c *DataService_AssignTagsToTune_Call) RunAndReturn(run func(uuid.UUID, []uuid.UUID) (*apimodel.Tune, error)) *DataService_AssignTagsToTune_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateMusicSet provides a mock function with given fields: tune, importFile
-func (_m *DataService) CreateMusicSet(tune apimodel.CreateSet, importFile *model.ImportFile) (*apimodel.MusicSet, error) {
-	ret := _m.Called(tune, importFile)
+// AssignTunesToMusicSet provides a mock function with given fields: setID, tuneIDs, userID
+func (_m *DataService) AssignTunesToMusicSet(setID uuid.UUID, tuneIDs []uuid.UUID, userID *uuid.UUID) (*apimodel.MusicSet, error) {
+	ret := _m.Called(setID, tuneIDs, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateMusicSet")
+		panic("no return value specified for AssignTunesToMusicSet")
 	}
 
 	var r0 *apimodel.MusicSet
 	var r1 error
-	if rf, ok := ret.Get(0).(func(apimodel.CreateSet, *model.ImportFile) (*apimodel.MusicSet, error)); ok {
-		return rf(tune, importFile)
+	if rf, ok := ret.Get(0).(func(uuid.UUID, []uuid.UUID, *uuid.UUID) (*apimodel.MusicSet, error)); ok {
+		return rf(setID, tuneIDs, userID)
 	}
-	if rf, ok := ret.Get(0).(func(apimodel.CreateSet, *model.ImportFile) *apimodel.MusicSet); ok {
-		r0 = rf(tune, importFile)
+	if rf, ok := ret.Get(0).(func(uuid.UUID, []uuid.UUID, *uuid.UUID) *apimodel.MusicSet); ok {
+		r0 = rf(setID, tuneIDs, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*apimodel.MusicSet)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(apimodel.CreateSet, *model.ImportFile) error); ok {
-		r1 = rf(tune, importFile)
+	if rf, ok := ret.Get(1).(func(uuid.UUID, []uuid.UUID, *uuid.UUID) error); ok {
+		r1 = rf(setID, tuneIDs, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -166,58 +332,59 @@ func (_m *DataService) CreateMusicSet(tune apimodel.CreateSet, importFile *model
 	return r0, r1
 }
 
-// DataService_CreateMusicSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateMusicSet'
-type DataService_CreateMusicSet_Call struct {
+// DataService_AssignTunesToMusicSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignTunesToMusicSet'
+type DataService_AssignTunesToMusicSet_Call struct {
 	*mock.Call
 }
 
-// CreateMusicSet is a helper method to define mock.On call
-//   - tune apimodel.CreateSet
-//   - importFile *model.ImportFile
-func (_e *DataService_Expecter) CreateMusicSet(tune interface{}, importFile interface{}) *DataService_CreateMusicSet_Call {
-	return &DataService_CreateMusicSet_Call{Call: _e.mock.On("CreateMusicSet", tune, importFile)}
+// AssignTunesToMusicSet is a helper method to define mock.On call
+//   - setID uuid.UUID
+//   - tuneIDs []uuid.UUID
+//   - userID *uuid.UUID
+func (_e *DataService_Expecter) AssignTunesToMusicSet(setID interface{}, tuneIDs interface{}, userID interface{}) *DataService_AssignTunesToMusicSet_Call {
+	return &DataService_AssignTunesToMusicSet_Call{Call: _e.mock.On("AssignTunesToMusicSet", setID, tuneIDs, userID)}
 }
 
-func (_c *DataService_CreateMusicSet_Call) Run(run func(tune apimodel.CreateSet, importFile *model.ImportFile)) *DataService_CreateMusicSet_Call {
+func (_c *DataService_AssignTunesToMusicSet_Call) Run(run func(setID uuid.UUID, tuneIDs []uuid.UUID, userID *uuid.UUID)) *DataService_AssignTunesToMusicSet_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(apimodel.CreateSet), args[1].(*model.ImportFile))
+		run(args[0].(uuid.UUID), args[1].([]uuid.UUID), args[2].(*uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *DataService_CreateMusicSet_Call) Return(_a0 *apimodel.MusicSet, _a1 error) *DataService_CreateMusicSet_Call {
+func (_c *DataService_AssignTunesToMusicSet_Call) Return(_a0 *apimodel.MusicSet, _a1 error) *DataService_AssignTunesToMusicSet_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *DataService_CreateMusicSet_Call) RunAndReturn(run func(apimodel.CreateSet, *model.ImportFile) (*apimodel.MusicSet, error)) *DataService_CreateMusicSet_Call {
+func (_c *DataService_AssignTunesToMusicSet_Call) RunAndReturn(run func(uuid.UUID, []uuid.UUID, *uuid.UUID) (*apimodel.MusicSet, error)) *DataService_AssignTunesToMusicSet_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateTune provides a mock function with given fields: tune, importFile
-func (_m *DataService) CreateTune(tune apimodel.CreateTune, importFile *model.ImportFile) (*apimodel.Tune, error) {
-	ret := _m.Called(tune, importFile)
+// ClaimNextImportJob provides a mock function with given fields:
+func (_m *DataService) ClaimNextImportJob() (*model.ImportJob, error) {
+	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateTune")
+		panic("no return value specified for ClaimNextImportJob")
 	}
 
-	var r0 *apimodel.Tune
+	var r0 *model.ImportJob
 	var r1 error
-	if rf, ok := ret.Get(0).(func(apimodel.CreateTune, *model.ImportFile) (*apimodel.Tune, error)); ok {
-		return rf(tune, importFile)
+	if rf, ok := ret.Get(0).(func() (*model.ImportJob, error)); ok {
+		return rf()
 	}
-	if rf, ok := ret.Get(0).(func(apimodel.CreateTune, *model.ImportFile) *apimodel.Tune); ok {
-		r0 = rf(tune, importFile)
+	if rf, ok := ret.Get(0).(func() *model.ImportJob); ok {
+		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*apimodel.Tune)
+			r0 = ret.Get(0).(*model.ImportJob)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(apimodel.CreateTune, *model.ImportFile) error); ok {
-		r1 = rf(tune, importFile)
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -225,46 +392,110 @@ func (_m *DataService) CreateTune(tune apimodel.CreateTune, importFile *model.Im
 	return r0, r1
 }
 
-// DataService_CreateTune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTune'
-type DataService_CreateTune_Call struct {
+// DataService_ClaimNextImportJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimNextImportJob'
+type DataService_ClaimNextImportJob_Call struct {
 	*mock.Call
 }
 
-// CreateTune is a helper method to define mock.On call
-//   - tune apimodel.CreateTune
-//   - importFile *model.ImportFile
-func (_e *DataService_Expecter) CreateTune(tune interface{}, importFile interface{}) *DataService_CreateTune_Call {
-	return &DataService_CreateTune_Call{Call: _e.mock.On("CreateTune", tune, importFile)}
+// ClaimNextImportJob is a helper method to define mock.On call
+func (_e *DataService_Expecter) ClaimNextImportJob() *DataService_ClaimNextImportJob_Call {
+	return &DataService_ClaimNextImportJob_Call{Call: _e.mock.On("ClaimNextImportJob")}
 }
 
-func (_c *DataService_CreateTune_Call) Run(run func(tune apimodel.CreateTune, importFile *model.ImportFile)) *DataService_CreateTune_Call {
+func (_c *DataService_ClaimNextImportJob_Call) Run(run func()) *DataService_ClaimNextImportJob_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(apimodel.CreateTune), args[1].(*model.ImportFile))
+		run()
 	})
 	return _c
 }
 
-func (_c *DataService_CreateTune_Call) Return(_a0 *apimodel.Tune, _a1 error) *DataService_CreateTune_Call {
+func (_c *DataService_ClaimNextImportJob_Call) Return(_a0 *model.ImportJob, _a1 error) *DataService_ClaimNextImportJob_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *DataService_CreateTune_Call) RunAndReturn(run func(apimodel.CreateTune, *model.ImportFile) (*apimodel.Tune, error)) *DataService_CreateTune_Call {
+func (_c *DataService_ClaimNextImportJob_Call) RunAndReturn(run func() (*model.ImportJob, error)) *DataService_ClaimNextImportJob_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteFileFromTune provides a mock function with given fields: tuneID, fType
-func (_m *DataService) DeleteFileFromTune(tuneID uuid.UUID, fType fileformat.Format) error {
-	ret := _m.Called(tuneID, fType)
+// CreateAPIToken provides a mock function with given fields: userID, name
+func (_m *DataService) CreateAPIToken(userID uuid.UUID, name string) (*model.APIToken, string, error) {
+	ret := _m.Called(userID, name)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteFileFromTune")
+		panic("no return value specified for CreateAPIToken")
+	}
+
+	var r0 *model.APIToken
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) (*model.APIToken, string, error)); ok {
+		return rf(userID, name)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) *model.APIToken); ok {
+		r0 = rf(userID, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.APIToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, string) string); ok {
+		r1 = rf(userID, name)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(uuid.UUID, string) error); ok {
+		r2 = rf(userID, name)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DataService_CreateAPIToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAPIToken'
+type DataService_CreateAPIToken_Call struct {
+	*mock.Call
+}
+
+// CreateAPIToken is a helper method to define mock.On call
+//   - userID uuid.UUID
+//   - name string
+func (_e *DataService_Expecter) CreateAPIToken(userID interface{}, name interface{}) *DataService_CreateAPIToken_Call {
+	return &DataService_CreateAPIToken_Call{Call: _e.mock.On("CreateAPIToken", userID, name)}
+}
+
+func (_c *DataService_CreateAPIToken_Call) Run(run func(userID uuid.UUID, name string)) *DataService_CreateAPIToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *DataService_CreateAPIToken_Call) Return(_a0 *model.APIToken, _a1 string, _a2 error) *DataService_CreateAPIToken_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *DataService_CreateAPIToken_Call) RunAndReturn(run func(uuid.UUID, string) (*model.APIToken, string, error)) *DataService_CreateAPIToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateImportJob provides a mock function with given fields: job
+func (_m *DataService) CreateImportJob(job *model.ImportJob) error {
+	ret := _m.Called(job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateImportJob")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uuid.UUID, fileformat.Format) error); ok {
-		r0 = rf(tuneID, fType)
+	if rf, ok := ret.Get(0).(func(*model.ImportJob) error); ok {
+		r0 = rf(job)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -272,208 +503,2898 @@ func (_m *DataService) DeleteFileFromTune(tuneID uuid.UUID, fType fileformat.For
 	return r0
 }
 
-// DataService_DeleteFileFromTune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFileFromTune'
-type DataService_DeleteFileFromTune_Call struct {
+// DataService_CreateImportJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateImportJob'
+type DataService_CreateImportJob_Call struct {
 	*mock.Call
 }
 
-// DeleteFileFromTune is a helper method to define mock.On call
-//   - tuneID uuid.UUID
-//   - fType fileformat.Format
-func (_e *DataService_Expecter) DeleteFileFromTune(tuneID interface{}, fType interface{}) *DataService_DeleteFileFromTune_Call {
-	return &DataService_DeleteFileFromTune_Call{Call: _e.mock.On("DeleteFileFromTune", tuneID, fType)}
+// CreateImportJob is a helper method to define mock.On call
+//   - job *model.ImportJob
+func (_e *DataService_Expecter) CreateImportJob(job interface{}) *DataService_CreateImportJob_Call {
+	return &DataService_CreateImportJob_Call{Call: _e.mock.On("CreateImportJob", job)}
 }
 
-func (_c *DataService_DeleteFileFromTune_Call) Run(run func(tuneID uuid.UUID, fType fileformat.Format)) *DataService_DeleteFileFromTune_Call {
+func (_c *DataService_CreateImportJob_Call) Run(run func(job *model.ImportJob)) *DataService_CreateImportJob_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uuid.UUID), args[1].(fileformat.Format))
+		run(args[0].(*model.ImportJob))
 	})
 	return _c
 }
 
-func (_c *DataService_DeleteFileFromTune_Call) Return(_a0 error) *DataService_DeleteFileFromTune_Call {
+func (_c *DataService_CreateImportJob_Call) Return(_a0 error) *DataService_CreateImportJob_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *DataService_DeleteFileFromTune_Call) RunAndReturn(run func(uuid.UUID, fileformat.Format) error) *DataService_DeleteFileFromTune_Call {
+func (_c *DataService_CreateImportJob_Call) RunAndReturn(run func(*model.ImportJob) error) *DataService_CreateImportJob_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteMusicSet provides a mock function with given fields: id
-func (_m *DataService) DeleteMusicSet(id uuid.UUID) error {
-	ret := _m.Called(id)
+// CreateMusicSet provides a mock function with given fields: tune, importFile, ownerID
+func (_m *DataService) CreateMusicSet(tune apimodel.CreateSet, importFile *model.ImportFile, ownerID *uuid.UUID) (*apimodel.MusicSet, error) {
+	ret := _m.Called(tune, importFile, ownerID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteMusicSet")
+		panic("no return value specified for CreateMusicSet")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
-		r0 = rf(id)
+	var r0 *apimodel.MusicSet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(apimodel.CreateSet, *model.ImportFile, *uuid.UUID) (*apimodel.MusicSet, error)); ok {
+		return rf(tune, importFile, ownerID)
+	}
+	if rf, ok := ret.Get(0).(func(apimodel.CreateSet, *model.ImportFile, *uuid.UUID) *apimodel.MusicSet); ok {
+		r0 = rf(tune, importFile, ownerID)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*apimodel.MusicSet)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(apimodel.CreateSet, *model.ImportFile, *uuid.UUID) error); ok {
+		r1 = rf(tune, importFile, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// DataService_DeleteMusicSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMusicSet'
-type DataService_DeleteMusicSet_Call struct {
+// DataService_CreateMusicSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateMusicSet'
+type DataService_CreateMusicSet_Call struct {
+	*mock.Call
+}
+
+// CreateMusicSet is a helper method to define mock.On call
+//   - tune apimodel.CreateSet
+//   - importFile *model.ImportFile
+//   - ownerID *uuid.UUID
+func (_e *DataService_Expecter) CreateMusicSet(tune interface{}, importFile interface{}, ownerID interface{}) *DataService_CreateMusicSet_Call {
+	return &DataService_CreateMusicSet_Call{Call: _e.mock.On("CreateMusicSet", tune, importFile, ownerID)}
+}
+
+func (_c *DataService_CreateMusicSet_Call) Run(run func(tune apimodel.CreateSet, importFile *model.ImportFile, ownerID *uuid.UUID)) *DataService_CreateMusicSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(apimodel.CreateSet), args[1].(*model.ImportFile), args[2].(*uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *DataService_CreateMusicSet_Call) Return(_a0 *apimodel.MusicSet, _a1 error) *DataService_CreateMusicSet_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_CreateMusicSet_Call) RunAndReturn(run func(apimodel.CreateSet, *model.ImportFile, *uuid.UUID) (*apimodel.MusicSet, error)) *DataService_CreateMusicSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateTag provides a mock function with given fields: tag
+func (_m *DataService) CreateTag(tag apimodel.CreateTag) (*apimodel.Tag, error) {
+	ret := _m.Called(tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTag")
+	}
+
+	var r0 *apimodel.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(apimodel.CreateTag) (*apimodel.Tag, error)); ok {
+		return rf(tag)
+	}
+	if rf, ok := ret.Get(0).(func(apimodel.CreateTag) *apimodel.Tag); ok {
+		r0 = rf(tag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*apimodel.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(apimodel.CreateTag) error); ok {
+		r1 = rf(tag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_CreateTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTag'
+type DataService_CreateTag_Call struct {
+	*mock.Call
+}
+
+// CreateTag is a helper method to define mock.On call
+//   - tag apimodel.CreateTag
+func (_e *DataService_Expecter) CreateTag(tag interface{}) *DataService_CreateTag_Call {
+	return &DataService_CreateTag_Call{Call: _e.mock.On("CreateTag", tag)}
+}
+
+func (_c *DataService_CreateTag_Call) Run(run func(tag apimodel.CreateTag)) *DataService_CreateTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(apimodel.CreateTag))
+	})
+	return _c
+}
+
+func (_c *DataService_CreateTag_Call) Return(_a0 *apimodel.Tag, _a1 error) *DataService_CreateTag_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_CreateTag_Call) RunAndReturn(run func(apimodel.CreateTag) (*apimodel.Tag, error)) *DataService_CreateTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateTune provides a mock function with given fields: tune, importFile, ownerID
+func (_m *DataService) CreateTune(tune apimodel.CreateTune, importFile *model.ImportFile, ownerID *uuid.UUID) (*apimodel.Tune, error) {
+	ret := _m.Called(tune, importFile, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTune")
+	}
+
+	var r0 *apimodel.Tune
+	var r1 error
+	if rf, ok := ret.Get(0).(func(apimodel.CreateTune, *model.ImportFile, *uuid.UUID) (*apimodel.Tune, error)); ok {
+		return rf(tune, importFile, ownerID)
+	}
+	if rf, ok := ret.Get(0).(func(apimodel.CreateTune, *model.ImportFile, *uuid.UUID) *apimodel.Tune); ok {
+		r0 = rf(tune, importFile, ownerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*apimodel.Tune)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(apimodel.CreateTune, *model.ImportFile, *uuid.UUID) error); ok {
+		r1 = rf(tune, importFile, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_CreateTune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTune'
+type DataService_CreateTune_Call struct {
+	*mock.Call
+}
+
+// CreateTune is a helper method to define mock.On call
+//   - tune apimodel.CreateTune
+//   - importFile *model.ImportFile
+//   - ownerID *uuid.UUID
+func (_e *DataService_Expecter) CreateTune(tune interface{}, importFile interface{}, ownerID interface{}) *DataService_CreateTune_Call {
+	return &DataService_CreateTune_Call{Call: _e.mock.On("CreateTune", tune, importFile, ownerID)}
+}
+
+func (_c *DataService_CreateTune_Call) Run(run func(tune apimodel.CreateTune, importFile *model.ImportFile, ownerID *uuid.UUID)) *DataService_CreateTune_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(apimodel.CreateTune), args[1].(*model.ImportFile), args[2].(*uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *DataService_CreateTune_Call) Return(_a0 *apimodel.Tune, _a1 error) *DataService_CreateTune_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_CreateTune_Call) RunAndReturn(run func(apimodel.CreateTune, *model.ImportFile, *uuid.UUID) (*apimodel.Tune, error)) *DataService_CreateTune_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUser provides a mock function with given fields: name
+func (_m *DataService) CreateUser(name string) (*model.User, error) {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.User, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.User); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
+type DataService_CreateUser_Call struct {
+	*mock.Call
+}
+
+// CreateUser is a helper method to define mock.On call
+//   - name string
+func (_e *DataService_Expecter) CreateUser(name interface{}) *DataService_CreateUser_Call {
+	return &DataService_CreateUser_Call{Call: _e.mock.On("CreateUser", name)}
+}
+
+func (_c *DataService_CreateUser_Call) Run(run func(name string)) *DataService_CreateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *DataService_CreateUser_Call) Return(_a0 *model.User, _a1 error) *DataService_CreateUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_CreateUser_Call) RunAndReturn(run func(string) (*model.User, error)) *DataService_CreateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAPIToken provides a mock function with given fields: userID, tokenID
+func (_m *DataService) DeleteAPIToken(userID uuid.UUID, tokenID uuid.UUID) error {
+	ret := _m.Called(userID, tokenID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAPIToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(userID, tokenID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DataService_DeleteAPIToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAPIToken'
+type DataService_DeleteAPIToken_Call struct {
+	*mock.Call
+}
+
+// DeleteAPIToken is a helper method to define mock.On call
+//   - userID uuid.UUID
+//   - tokenID uuid.UUID
+func (_e *DataService_Expecter) DeleteAPIToken(userID interface{}, tokenID interface{}) *DataService_DeleteAPIToken_Call {
+	return &DataService_DeleteAPIToken_Call{Call: _e.mock.On("DeleteAPIToken", userID, tokenID)}
+}
+
+func (_c *DataService_DeleteAPIToken_Call) Run(run func(userID uuid.UUID, tokenID uuid.UUID)) *DataService_DeleteAPIToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *DataService_DeleteAPIToken_Call) Return(_a0 error) *DataService_DeleteAPIToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DataService_DeleteAPIToken_Call) RunAndReturn(run func(uuid.UUID, uuid.UUID) error) *DataService_DeleteAPIToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFileFromTune provides a mock function with given fields: tuneID, fType, author
+func (_m *DataService) DeleteFileFromTune(tuneID uuid.UUID, fType fileformat.Format, author string) error {
+	ret := _m.Called(tuneID, fType, author)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFileFromTune")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, fileformat.Format, string) error); ok {
+		r0 = rf(tuneID, fType, author)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DataService_DeleteFileFromTune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFileFromTune'
+type DataService_DeleteFileFromTune_Call struct {
+	*mock.Call
+}
+
+// DeleteFileFromTune is a helper method to define mock.On call
+//   - tuneID uuid.UUID
+//   - fType fileformat.Format
+//   - author string
+func (_e *DataService_Expecter) DeleteFileFromTune(tuneID interface{}, fType interface{}, author interface{}) *DataService_DeleteFileFromTune_Call {
+	return &DataService_DeleteFileFromTune_Call{Call: _e.mock.On("DeleteFileFromTune", tuneID, fType, author)}
+}
+
+func (_c *DataService_DeleteFileFromTune_Call) Run(run func(tuneID uuid.UUID, fType fileformat.Format, author string)) *DataService_DeleteFileFromTune_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(fileformat.Format), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *DataService_DeleteFileFromTune_Call) Return(_a0 error) *DataService_DeleteFileFromTune_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DataService_DeleteFileFromTune_Call) RunAndReturn(run func(uuid.UUID, fileformat.Format, string) error) *DataService_DeleteFileFromTune_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteImport provides a mock function with given fields: id, userID
+func (_m *DataService) DeleteImport(id uuid.UUID, userID *uuid.UUID) error {
+	ret := _m.Called(id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteImport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *uuid.UUID) error); ok {
+		r0 = rf(id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DataService_DeleteImport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteImport'
+type DataService_DeleteImport_Call struct {
+	*mock.Call
+}
+
+// DeleteImport is a helper method to define mock.On call
+//   - id uuid.UUID
+//   - userID *uuid.UUID
+func (_e *DataService_Expecter) DeleteImport(id interface{}, userID interface{}) *DataService_DeleteImport_Call {
+	return &DataService_DeleteImport_Call{Call: _e.mock.On("DeleteImport", id, userID)}
+}
+
+func (_c *DataService_DeleteImport_Call) Run(run func(id uuid.UUID, userID *uuid.UUID)) *DataService_DeleteImport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(*uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *DataService_DeleteImport_Call) Return(_a0 error) *DataService_DeleteImport_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DataService_DeleteImport_Call) RunAndReturn(run func(uuid.UUID, *uuid.UUID) error) *DataService_DeleteImport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteMembership provides a mock function with given fields: userID
+func (_m *DataService) DeleteMembership(userID uuid.UUID) error {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMembership")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DataService_DeleteMembership_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMembership'
+type DataService_DeleteMembership_Call struct {
+	*mock.Call
+}
+
+// DeleteMembership is a helper method to define mock.On call
+//   - userID uuid.UUID
+func (_e *DataService_Expecter) DeleteMembership(userID interface{}) *DataService_DeleteMembership_Call {
+	return &DataService_DeleteMembership_Call{Call: _e.mock.On("DeleteMembership", userID)}
+}
+
+func (_c *DataService_DeleteMembership_Call) Run(run func(userID uuid.UUID)) *DataService_DeleteMembership_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *DataService_DeleteMembership_Call) Return(_a0 error) *DataService_DeleteMembership_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DataService_DeleteMembership_Call) RunAndReturn(run func(uuid.UUID) error) *DataService_DeleteMembership_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteMusicSet provides a mock function with given fields: id, userID
+func (_m *DataService) DeleteMusicSet(id uuid.UUID, userID *uuid.UUID) error {
+	ret := _m.Called(id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMusicSet")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *uuid.UUID) error); ok {
+		r0 = rf(id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DataService_DeleteMusicSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMusicSet'
+type DataService_DeleteMusicSet_Call struct {
+	*mock.Call
+}
+
+// DeleteMusicSet is a helper method to define mock.On call
+//   - id uuid.UUID
+//   - userID *uuid.UUID
+func (_e *DataService_Expecter) DeleteMusicSet(id interface{}, userID interface{}) *DataService_DeleteMusicSet_Call {
+	return &DataService_DeleteMusicSet_Call{Call: _e.mock.On("DeleteMusicSet", id, userID)}
+}
+
+func (_c *DataService_DeleteMusicSet_Call) Run(run func(id uuid.UUID, userID *uuid.UUID)) *DataService_DeleteMusicSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(*uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *DataService_DeleteMusicSet_Call) Return(_a0 error) *DataService_DeleteMusicSet_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DataService_DeleteMusicSet_Call) RunAndReturn(run func(uuid.UUID, *uuid.UUID) error) *DataService_DeleteMusicSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTag provides a mock function with given fields: id
+func (_m *DataService) DeleteTag(id uuid.UUID) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DataService_DeleteTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTag'
+type DataService_DeleteTag_Call struct {
+	*mock.Call
+}
+
+// DeleteTag is a helper method to define mock.On call
+//   - id uuid.UUID
+func (_e *DataService_Expecter) DeleteTag(id interface{}) *DataService_DeleteTag_Call {
+	return &DataService_DeleteTag_Call{Call: _e.mock.On("DeleteTag", id)}
+}
+
+func (_c *DataService_DeleteTag_Call) Run(run func(id uuid.UUID)) *DataService_DeleteTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *DataService_DeleteTag_Call) Return(_a0 error) *DataService_DeleteTag_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DataService_DeleteTag_Call) RunAndReturn(run func(uuid.UUID) error) *DataService_DeleteTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTune provides a mock function with given fields: id
+func (_m *DataService) DeleteTune(id uuid.UUID) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTune")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DataService_DeleteTune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTune'
+type DataService_DeleteTune_Call struct {
+	*mock.Call
+}
+
+// DeleteTune is a helper method to define mock.On call
+//   - id uuid.UUID
+func (_e *DataService_Expecter) DeleteTune(id interface{}) *DataService_DeleteTune_Call {
+	return &DataService_DeleteTune_Call{Call: _e.mock.On("DeleteTune", id)}
+}
+
+func (_c *DataService_DeleteTune_Call) Run(run func(id uuid.UUID)) *DataService_DeleteTune_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *DataService_DeleteTune_Call) Return(_a0 error) *DataService_DeleteTune_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DataService_DeleteTune_Call) RunAndReturn(run func(uuid.UUID) error) *DataService_DeleteTune_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetImportFileByHash provides a mock function with given fields: fHash
+func (_m *DataService) GetImportFileByHash(fHash string) (*model.ImportFile, error) {
+	ret := _m.Called(fHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetImportFileByHash")
+	}
+
+	var r0 *model.ImportFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.ImportFile, error)); ok {
+		return rf(fHash)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.ImportFile); ok {
+		r0 = rf(fHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ImportFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(fHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_GetImportFileByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetImportFileByHash'
+type DataService_GetImportFileByHash_Call struct {
+	*mock.Call
+}
+
+// GetImportFileByHash is a helper method to define mock.On call
+//   - fHash string
+func (_e *DataService_Expecter) GetImportFileByHash(fHash interface{}) *DataService_GetImportFileByHash_Call {
+	return &DataService_GetImportFileByHash_Call{Call: _e.mock.On("GetImportFileByHash", fHash)}
+}
+
+func (_c *DataService_GetImportFileByHash_Call) Run(run func(fHash string)) *DataService_GetImportFileByHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *DataService_GetImportFileByHash_Call) Return(_a0 *model.ImportFile, _a1 error) *DataService_GetImportFileByHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_GetImportFileByHash_Call) RunAndReturn(run func(string) (*model.ImportFile, error)) *DataService_GetImportFileByHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetImportJob provides a mock function with given fields: id, userID
+func (_m *DataService) GetImportJob(id uuid.UUID, userID *uuid.UUID) (*model.ImportJob, error) {
+	ret := _m.Called(id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetImportJob")
+	}
+
+	var r0 *model.ImportJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *uuid.UUID) (*model.ImportJob, error)); ok {
+		return rf(id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *uuid.UUID) *model.ImportJob); ok {
+		r0 = rf(id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ImportJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, *uuid.UUID) error); ok {
+		r1 = rf(id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_GetImportJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetImportJob'
+type DataService_GetImportJob_Call struct {
+	*mock.Call
+}
+
+// GetImportJob is a helper method to define mock.On call
+//   - id uuid.UUID
+//   - userID *uuid.UUID
+func (_e *DataService_Expecter) GetImportJob(id interface{}, userID interface{}) *DataService_GetImportJob_Call {
+	return &DataService_GetImportJob_Call{Call: _e.mock.On("GetImportJob", id, userID)}
+}
+
+func (_c *DataService_GetImportJob_Call) Run(run func(id uuid.UUID, userID *uuid.UUID)) *DataService_GetImportJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(*uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *DataService_GetImportJob_Call) Return(_a0 *model.ImportJob, _a1 error) *DataService_GetImportJob_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_GetImportJob_Call) RunAndReturn(run func(uuid.UUID, *uuid.UUID) (*model.ImportJob, error)) *DataService_GetImportJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMembership provides a mock function with given fields: userID
+func (_m *DataService) GetMembership(userID uuid.UUID) (*model.Membership, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMembership")
+	}
+
+	var r0 *model.Membership
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*model.Membership, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *model.Membership); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Membership)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_GetMembership_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMembership'
+type DataService_GetMembership_Call struct {
+	*mock.Call
+}
+
+// GetMembership is a helper method to define mock.On call
+//   - userID uuid.UUID
+func (_e *DataService_Expecter) GetMembership(userID interface{}) *DataService_GetMembership_Call {
+	return &DataService_GetMembership_Call{Call: _e.mock.On("GetMembership", userID)}
+}
+
+func (_c *DataService_GetMembership_Call) Run(run func(userID uuid.UUID)) *DataService_GetMembership_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *DataService_GetMembership_Call) Return(_a0 *model.Membership, _a1 error) *DataService_GetMembership_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_GetMembership_Call) RunAndReturn(run func(uuid.UUID) (*model.Membership, error)) *DataService_GetMembership_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMusicSet provides a mock function with given fields: id, userID
+func (_m *DataService) GetMusicSet(id uuid.UUID, userID *uuid.UUID) (*apimodel.MusicSet, error) {
+	ret := _m.Called(id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMusicSet")
+	}
+
+	var r0 *apimodel.MusicSet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *uuid.UUID) (*apimodel.MusicSet, error)); ok {
+		return rf(id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *uuid.UUID) *apimodel.MusicSet); ok {
+		r0 = rf(id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*apimodel.MusicSet)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, *uuid.UUID) error); ok {
+		r1 = rf(id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_GetMusicSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMusicSet'
+type DataService_GetMusicSet_Call struct {
+	*mock.Call
+}
+
+// GetMusicSet is a helper method to define mock.On call
+//   - id uuid.UUID
+//   - userID *uuid.UUID
+func (_e *DataService_Expecter) GetMusicSet(id interface{}, userID interface{}) *DataService_GetMusicSet_Call {
+	return &DataService_GetMusicSet_Call{Call: _e.mock.On("GetMusicSet", id, userID)}
+}
+
+func (_c *DataService_GetMusicSet_Call) Run(run func(id uuid.UUID, userID *uuid.UUID)) *DataService_GetMusicSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(*uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *DataService_GetMusicSet_Call) Return(_a0 *apimodel.MusicSet, _a1 error) *DataService_GetMusicSet_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_GetMusicSet_Call) RunAndReturn(run func(uuid.UUID, *uuid.UUID) (*apimodel.MusicSet, error)) *DataService_GetMusicSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPerson provides a mock function with given fields: id
+func (_m *DataService) GetPerson(id uuid.UUID) (*apimodel.Person, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPerson")
+	}
+
+	var r0 *apimodel.Person
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*apimodel.Person, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *apimodel.Person); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*apimodel.Person)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_GetPerson_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPerson'
+type DataService_GetPerson_Call struct {
+	*mock.Call
+}
+
+// GetPerson is a helper method to define mock.On call
+//   - id uuid.UUID
+func (_e *DataService_Expecter) GetPerson(id interface{}) *DataService_GetPerson_Call {
+	return &DataService_GetPerson_Call{Call: _e.mock.On("GetPerson", id)}
+}
+
+func (_c *DataService_GetPerson_Call) Run(run func(id uuid.UUID)) *DataService_GetPerson_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *DataService_GetPerson_Call) Return(_a0 *apimodel.Person, _a1 error) *DataService_GetPerson_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_GetPerson_Call) RunAndReturn(run func(uuid.UUID) (*apimodel.Person, error)) *DataService_GetPerson_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTag provides a mock function with given fields: id
+func (_m *DataService) GetTag(id uuid.UUID) (*apimodel.Tag, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTag")
+	}
+
+	var r0 *apimodel.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*apimodel.Tag, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *apimodel.Tag); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*apimodel.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_GetTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTag'
+type DataService_GetTag_Call struct {
+	*mock.Call
+}
+
+// GetTag is a helper method to define mock.On call
+//   - id uuid.UUID
+func (_e *DataService_Expecter) GetTag(id interface{}) *DataService_GetTag_Call {
+	return &DataService_GetTag_Call{Call: _e.mock.On("GetTag", id)}
+}
+
+func (_c *DataService_GetTag_Call) Run(run func(id uuid.UUID)) *DataService_GetTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *DataService_GetTag_Call) Return(_a0 *apimodel.Tag, _a1 error) *DataService_GetTag_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_GetTag_Call) RunAndReturn(run func(uuid.UUID) (*apimodel.Tag, error)) *DataService_GetTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTune provides a mock function with given fields: id
+func (_m *DataService) GetTune(id uuid.UUID) (*apimodel.Tune, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTune")
+	}
+
+	var r0 *apimodel.Tune
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*apimodel.Tune, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *apimodel.Tune); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*apimodel.Tune)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_GetTune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTune'
+type DataService_GetTune_Call struct {
+	*mock.Call
+}
+
+// GetTune is a helper method to define mock.On call
+//   - id uuid.UUID
+func (_e *DataService_Expecter) GetTune(id interface{}) *DataService_GetTune_Call {
+	return &DataService_GetTune_Call{Call: _e.mock.On("GetTune", id)}
+}
+
+func (_c *DataService_GetTune_Call) Run(run func(id uuid.UUID)) *DataService_GetTune_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *DataService_GetTune_Call) Return(_a0 *apimodel.Tune, _a1 error) *DataService_GetTune_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_GetTune_Call) RunAndReturn(run func(uuid.UUID) (*apimodel.Tune, error)) *DataService_GetTune_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTuneFile provides a mock function with given fields: tuneID, fType
+func (_m *DataService) GetTuneFile(tuneID uuid.UUID, fType fileformat.Format) (*model.TuneFile, error) {
+	ret := _m.Called(tuneID, fType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTuneFile")
+	}
+
+	var r0 *model.TuneFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, fileformat.Format) (*model.TuneFile, error)); ok {
+		return rf(tuneID, fType)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, fileformat.Format) *model.TuneFile); ok {
+		r0 = rf(tuneID, fType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TuneFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, fileformat.Format) error); ok {
+		r1 = rf(tuneID, fType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_GetTuneFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTuneFile'
+type DataService_GetTuneFile_Call struct {
+	*mock.Call
+}
+
+// GetTuneFile is a helper method to define mock.On call
+//   - tuneID uuid.UUID
+//   - fType fileformat.Format
+func (_e *DataService_Expecter) GetTuneFile(tuneID interface{}, fType interface{}) *DataService_GetTuneFile_Call {
+	return &DataService_GetTuneFile_Call{Call: _e.mock.On("GetTuneFile", tuneID, fType)}
+}
+
+func (_c *DataService_GetTuneFile_Call) Run(run func(tuneID uuid.UUID, fType fileformat.Format)) *DataService_GetTuneFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(fileformat.Format))
+	})
+	return _c
+}
+
+func (_c *DataService_GetTuneFile_Call) Return(_a0 *model.TuneFile, _a1 error) *DataService_GetTuneFile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_GetTuneFile_Call) RunAndReturn(run func(uuid.UUID, fileformat.Format) (*model.TuneFile, error)) *DataService_GetTuneFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTuneFiles provides a mock function with given fields: tuneID
+func (_m *DataService) GetTuneFiles(tuneID uuid.UUID) ([]*model.TuneFile, error) {
+	ret := _m.Called(tuneID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTuneFiles")
+	}
+
+	var r0 []*model.TuneFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) ([]*model.TuneFile, error)); ok {
+		return rf(tuneID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []*model.TuneFile); ok {
+		r0 = rf(tuneID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.TuneFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(tuneID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_GetTuneFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTuneFiles'
+type DataService_GetTuneFiles_Call struct {
+	*mock.Call
+}
+
+// GetTuneFiles is a helper method to define mock.On call
+//   - tuneID uuid.UUID
+func (_e *DataService_Expecter) GetTuneFiles(tuneID interface{}) *DataService_GetTuneFiles_Call {
+	return &DataService_GetTuneFiles_Call{Call: _e.mock.On("GetTuneFiles", tuneID)}
+}
+
+func (_c *DataService_GetTuneFiles_Call) Run(run func(tuneID uuid.UUID)) *DataService_GetTuneFiles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *DataService_GetTuneFiles_Call) Return(_a0 []*model.TuneFile, _a1 error) *DataService_GetTuneFiles_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_GetTuneFiles_Call) RunAndReturn(run func(uuid.UUID) ([]*model.TuneFile, error)) *DataService_GetTuneFiles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTuneType provides a mock function with given fields: id
+func (_m *DataService) GetTuneType(id uuid.UUID) (*apimodel.TuneType, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTuneType")
+	}
+
+	var r0 *apimodel.TuneType
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*apimodel.TuneType, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *apimodel.TuneType); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*apimodel.TuneType)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_GetTuneType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTuneType'
+type DataService_GetTuneType_Call struct {
+	*mock.Call
+}
+
+// GetTuneType is a helper method to define mock.On call
+//   - id uuid.UUID
+func (_e *DataService_Expecter) GetTuneType(id interface{}) *DataService_GetTuneType_Call {
+	return &DataService_GetTuneType_Call{Call: _e.mock.On("GetTuneType", id)}
+}
+
+func (_c *DataService_GetTuneType_Call) Run(run func(id uuid.UUID)) *DataService_GetTuneType_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *DataService_GetTuneType_Call) Return(_a0 *apimodel.TuneType, _a1 error) *DataService_GetTuneType_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_GetTuneType_Call) RunAndReturn(run func(uuid.UUID) (*apimodel.TuneType, error)) *DataService_GetTuneType_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUser provides a mock function with given fields: id
+func (_m *DataService) GetUser(id uuid.UUID) (*model.User, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*model.User, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *model.User); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type DataService_GetUser_Call struct {
+	*mock.Call
+}
+
+// GetUser is a helper method to define mock.On call
+//   - id uuid.UUID
+func (_e *DataService_Expecter) GetUser(id interface{}) *DataService_GetUser_Call {
+	return &DataService_GetUser_Call{Call: _e.mock.On("GetUser", id)}
+}
+
+func (_c *DataService_GetUser_Call) Run(run func(id uuid.UUID)) *DataService_GetUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *DataService_GetUser_Call) Return(_a0 *model.User, _a1 error) *DataService_GetUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_GetUser_Call) RunAndReturn(run func(uuid.UUID) (*model.User, error)) *DataService_GetUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByName provides a mock function with given fields: name
+func (_m *DataService) GetUserByName(name string) (*model.User, error) {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByName")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.User, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.User); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_GetUserByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByName'
+type DataService_GetUserByName_Call struct {
+	*mock.Call
+}
+
+// GetUserByName is a helper method to define mock.On call
+//   - name string
+func (_e *DataService_Expecter) GetUserByName(name interface{}) *DataService_GetUserByName_Call {
+	return &DataService_GetUserByName_Call{Call: _e.mock.On("GetUserByName", name)}
+}
+
+func (_c *DataService_GetUserByName_Call) Run(run func(name string)) *DataService_GetUserByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *DataService_GetUserByName_Call) Return(_a0 *model.User, _a1 error) *DataService_GetUserByName_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_GetUserByName_Call) RunAndReturn(run func(string) (*model.User, error)) *DataService_GetUserByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportJobs provides a mock function with given fields: opts, userID
+func (_m *DataService) ImportJobs(opts common.ImportListOptions, userID *uuid.UUID) ([]*model.ImportJob, *apimodel.Pagination, error) {
+	ret := _m.Called(opts, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportJobs")
+	}
+
+	var r0 []*model.ImportJob
+	var r1 *apimodel.Pagination
+	var r2 error
+	if rf, ok := ret.Get(0).(func(common.ImportListOptions, *uuid.UUID) ([]*model.ImportJob, *apimodel.Pagination, error)); ok {
+		return rf(opts, userID)
+	}
+	if rf, ok := ret.Get(0).(func(common.ImportListOptions, *uuid.UUID) []*model.ImportJob); ok {
+		r0 = rf(opts, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ImportJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(common.ImportListOptions, *uuid.UUID) *apimodel.Pagination); ok {
+		r1 = rf(opts, userID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*apimodel.Pagination)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(common.ImportListOptions, *uuid.UUID) error); ok {
+		r2 = rf(opts, userID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DataService_ImportJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportJobs'
+type DataService_ImportJobs_Call struct {
+	*mock.Call
+}
+
+// ImportJobs is a helper method to define mock.On call
+//   - opts common.ImportListOptions
+//   - userID *uuid.UUID
+func (_e *DataService_Expecter) ImportJobs(opts interface{}, userID interface{}) *DataService_ImportJobs_Call {
+	return &DataService_ImportJobs_Call{Call: _e.mock.On("ImportJobs", opts, userID)}
+}
+
+func (_c *DataService_ImportJobs_Call) Run(run func(opts common.ImportListOptions, userID *uuid.UUID)) *DataService_ImportJobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(common.ImportListOptions), args[1].(*uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *DataService_ImportJobs_Call) Return(_a0 []*model.ImportJob, _a1 *apimodel.Pagination, _a2 error) *DataService_ImportJobs_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *DataService_ImportJobs_Call) RunAndReturn(run func(common.ImportListOptions, *uuid.UUID) ([]*model.ImportJob, *apimodel.Pagination, error)) *DataService_ImportJobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportTunes provides a mock function with given fields: parsedTunes, fileInfo
+func (_m *DataService) ImportTunes(parsedTunes []*messages.ParsedTune, fileInfo *common.ImportFileInfo) ([]*apimodel.ImportTune, *apimodel.BasicMusicSet, error) {
+	ret := _m.Called(parsedTunes, fileInfo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportTunes")
+	}
+
+	var r0 []*apimodel.ImportTune
+	var r1 *apimodel.BasicMusicSet
+	var r2 error
+	if rf, ok := ret.Get(0).(func([]*messages.ParsedTune, *common.ImportFileInfo) ([]*apimodel.ImportTune, *apimodel.BasicMusicSet, error)); ok {
+		return rf(parsedTunes, fileInfo)
+	}
+	if rf, ok := ret.Get(0).(func([]*messages.ParsedTune, *common.ImportFileInfo) []*apimodel.ImportTune); ok {
+		r0 = rf(parsedTunes, fileInfo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*apimodel.ImportTune)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]*messages.ParsedTune, *common.ImportFileInfo) *apimodel.BasicMusicSet); ok {
+		r1 = rf(parsedTunes, fileInfo)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*apimodel.BasicMusicSet)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func([]*messages.ParsedTune, *common.ImportFileInfo) error); ok {
+		r2 = rf(parsedTunes, fileInfo)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DataService_ImportTunes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportTunes'
+type DataService_ImportTunes_Call struct {
+	*mock.Call
+}
+
+// ImportTunes is a helper method to define mock.On call
+//   - parsedTunes []*messages.ParsedTune
+//   - fileInfo *common.ImportFileInfo
+func (_e *DataService_Expecter) ImportTunes(parsedTunes interface{}, fileInfo interface{}) *DataService_ImportTunes_Call {
+	return &DataService_ImportTunes_Call{Call: _e.mock.On("ImportTunes", parsedTunes, fileInfo)}
+}
+
+func (_c *DataService_ImportTunes_Call) Run(run func(parsedTunes []*messages.ParsedTune, fileInfo *common.ImportFileInfo)) *DataService_ImportTunes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]*messages.ParsedTune), args[1].(*common.ImportFileInfo))
+	})
+	return _c
+}
+
+func (_c *DataService_ImportTunes_Call) Return(_a0 []*apimodel.ImportTune, _a1 *apimodel.BasicMusicSet, _a2 error) *DataService_ImportTunes_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *DataService_ImportTunes_Call) RunAndReturn(run func([]*messages.ParsedTune, *common.ImportFileInfo) ([]*apimodel.ImportTune, *apimodel.BasicMusicSet, error)) *DataService_ImportTunes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Memberships provides a mock function with given fields:
+func (_m *DataService) Memberships() ([]*model.Membership, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Memberships")
+	}
+
+	var r0 []*model.Membership
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*model.Membership, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*model.Membership); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Membership)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_Memberships_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Memberships'
+type DataService_Memberships_Call struct {
+	*mock.Call
+}
+
+// Memberships is a helper method to define mock.On call
+func (_e *DataService_Expecter) Memberships() *DataService_Memberships_Call {
+	return &DataService_Memberships_Call{Call: _e.mock.On("Memberships")}
+}
+
+func (_c *DataService_Memberships_Call) Run(run func()) *DataService_Memberships_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *DataService_Memberships_Call) Return(_a0 []*model.Membership, _a1 error) *DataService_Memberships_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_Memberships_Call) RunAndReturn(run func() ([]*model.Membership, error)) *DataService_Memberships_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MergePeople provides a mock function with given fields: id, merge
+func (_m *DataService) MergePeople(id uuid.UUID, merge apimodel.MergePeople) (*apimodel.Person, error) {
+	ret := _m.Called(id, merge)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergePeople")
+	}
+
+	var r0 *apimodel.Person
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, apimodel.MergePeople) (*apimodel.Person, error)); ok {
+		return rf(id, merge)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, apimodel.MergePeople) *apimodel.Person); ok {
+		r0 = rf(id, merge)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*apimodel.Person)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, apimodel.MergePeople) error); ok {
+		r1 = rf(id, merge)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_MergePeople_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergePeople'
+type DataService_MergePeople_Call struct {
+	*mock.Call
+}
+
+// MergePeople is a helper method to define mock.On call
+//   - id uuid.UUID
+//   - merge apimodel.MergePeople
+func (_e *DataService_Expecter) MergePeople(id interface{}, merge interface{}) *DataService_MergePeople_Call {
+	return &DataService_MergePeople_Call{Call: _e.mock.On("MergePeople", id, merge)}
+}
+
+func (_c *DataService_MergePeople_Call) Run(run func(id uuid.UUID, merge apimodel.MergePeople)) *DataService_MergePeople_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(apimodel.MergePeople))
+	})
+	return _c
+}
+
+func (_c *DataService_MergePeople_Call) Return(_a0 *apimodel.Person, _a1 error) *DataService_MergePeople_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_MergePeople_Call) RunAndReturn(run func(uuid.UUID, apimodel.MergePeople) (*apimodel.Person, error)) *DataService_MergePeople_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MergeTuneTypes provides a mock function with given fields: id, merge
+func (_m *DataService) MergeTuneTypes(id uuid.UUID, merge apimodel.MergeTuneTypes) (*apimodel.TuneType, error) {
+	ret := _m.Called(id, merge)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeTuneTypes")
+	}
+
+	var r0 *apimodel.TuneType
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, apimodel.MergeTuneTypes) (*apimodel.TuneType, error)); ok {
+		return rf(id, merge)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, apimodel.MergeTuneTypes) *apimodel.TuneType); ok {
+		r0 = rf(id, merge)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*apimodel.TuneType)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, apimodel.MergeTuneTypes) error); ok {
+		r1 = rf(id, merge)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_MergeTuneTypes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergeTuneTypes'
+type DataService_MergeTuneTypes_Call struct {
+	*mock.Call
+}
+
+// MergeTuneTypes is a helper method to define mock.On call
+//   - id uuid.UUID
+//   - merge apimodel.MergeTuneTypes
+func (_e *DataService_Expecter) MergeTuneTypes(id interface{}, merge interface{}) *DataService_MergeTuneTypes_Call {
+	return &DataService_MergeTuneTypes_Call{Call: _e.mock.On("MergeTuneTypes", id, merge)}
+}
+
+func (_c *DataService_MergeTuneTypes_Call) Run(run func(id uuid.UUID, merge apimodel.MergeTuneTypes)) *DataService_MergeTuneTypes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(apimodel.MergeTuneTypes))
+	})
+	return _c
+}
+
+func (_c *DataService_MergeTuneTypes_Call) Return(_a0 *apimodel.TuneType, _a1 error) *DataService_MergeTuneTypes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_MergeTuneTypes_Call) RunAndReturn(run func(uuid.UUID, apimodel.MergeTuneTypes) (*apimodel.TuneType, error)) *DataService_MergeTuneTypes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MergeTunes provides a mock function with given fields: id, merge
+func (_m *DataService) MergeTunes(id uuid.UUID, merge apimodel.MergeTunes) (*apimodel.Tune, error) {
+	ret := _m.Called(id, merge)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeTunes")
+	}
+
+	var r0 *apimodel.Tune
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, apimodel.MergeTunes) (*apimodel.Tune, error)); ok {
+		return rf(id, merge)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, apimodel.MergeTunes) *apimodel.Tune); ok {
+		r0 = rf(id, merge)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*apimodel.Tune)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, apimodel.MergeTunes) error); ok {
+		r1 = rf(id, merge)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_MergeTunes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergeTunes'
+type DataService_MergeTunes_Call struct {
+	*mock.Call
+}
+
+// MergeTunes is a helper method to define mock.On call
+//   - id uuid.UUID
+//   - merge apimodel.MergeTunes
+func (_e *DataService_Expecter) MergeTunes(id interface{}, merge interface{}) *DataService_MergeTunes_Call {
+	return &DataService_MergeTunes_Call{Call: _e.mock.On("MergeTunes", id, merge)}
+}
+
+func (_c *DataService_MergeTunes_Call) Run(run func(id uuid.UUID, merge apimodel.MergeTunes)) *DataService_MergeTunes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(apimodel.MergeTunes))
+	})
+	return _c
+}
+
+func (_c *DataService_MergeTunes_Call) Return(_a0 *apimodel.Tune, _a1 error) *DataService_MergeTunes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_MergeTunes_Call) RunAndReturn(run func(uuid.UUID, apimodel.MergeTunes) (*apimodel.Tune, error)) *DataService_MergeTunes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Messages provides a mock function with given fields: opts
+func (_m *DataService) Messages(opts common.MessageListOptions) (*apimodel.TuneMessageList, error) {
+	ret := _m.Called(opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Messages")
+	}
+
+	var r0 *apimodel.TuneMessageList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(common.MessageListOptions) (*apimodel.TuneMessageList, error)); ok {
+		return rf(opts)
+	}
+	if rf, ok := ret.Get(0).(func(common.MessageListOptions) *apimodel.TuneMessageList); ok {
+		r0 = rf(opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*apimodel.TuneMessageList)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(common.MessageListOptions) error); ok {
+		r1 = rf(opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_Messages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Messages'
+type DataService_Messages_Call struct {
+	*mock.Call
+}
+
+// Messages is a helper method to define mock.On call
+//   - opts common.MessageListOptions
+func (_e *DataService_Expecter) Messages(opts interface{}) *DataService_Messages_Call {
+	return &DataService_Messages_Call{Call: _e.mock.On("Messages", opts)}
+}
+
+func (_c *DataService_Messages_Call) Run(run func(opts common.MessageListOptions)) *DataService_Messages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(common.MessageListOptions))
+	})
+	return _c
+}
+
+func (_c *DataService_Messages_Call) Return(_a0 *apimodel.TuneMessageList, _a1 error) *DataService_Messages_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_Messages_Call) RunAndReturn(run func(common.MessageListOptions) (*apimodel.TuneMessageList, error)) *DataService_Messages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MusicSetAnalysis provides a mock function with given fields: id, userID
+func (_m *DataService) MusicSetAnalysis(id uuid.UUID, userID *uuid.UUID) (*apimodel.TuneAnalysis, error) {
+	ret := _m.Called(id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MusicSetAnalysis")
+	}
+
+	var r0 *apimodel.TuneAnalysis
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *uuid.UUID) (*apimodel.TuneAnalysis, error)); ok {
+		return rf(id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *uuid.UUID) *apimodel.TuneAnalysis); ok {
+		r0 = rf(id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*apimodel.TuneAnalysis)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, *uuid.UUID) error); ok {
+		r1 = rf(id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_MusicSetAnalysis_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MusicSetAnalysis'
+type DataService_MusicSetAnalysis_Call struct {
+	*mock.Call
+}
+
+// MusicSetAnalysis is a helper method to define mock.On call
+//   - id uuid.UUID
+//   - userID *uuid.UUID
+func (_e *DataService_Expecter) MusicSetAnalysis(id interface{}, userID interface{}) *DataService_MusicSetAnalysis_Call {
+	return &DataService_MusicSetAnalysis_Call{Call: _e.mock.On("MusicSetAnalysis", id, userID)}
+}
+
+func (_c *DataService_MusicSetAnalysis_Call) Run(run func(id uuid.UUID, userID *uuid.UUID)) *DataService_MusicSetAnalysis_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(*uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *DataService_MusicSetAnalysis_Call) Return(_a0 *apimodel.TuneAnalysis, _a1 error) *DataService_MusicSetAnalysis_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_MusicSetAnalysis_Call) RunAndReturn(run func(uuid.UUID, *uuid.UUID) (*apimodel.TuneAnalysis, error)) *DataService_MusicSetAnalysis_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MusicSets provides a mock function with given fields: userID
+func (_m *DataService) MusicSets(userID *uuid.UUID) ([]*apimodel.MusicSet, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MusicSets")
+	}
+
+	var r0 []*apimodel.MusicSet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*uuid.UUID) ([]*apimodel.MusicSet, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(*uuid.UUID) []*apimodel.MusicSet); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*apimodel.MusicSet)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*uuid.UUID) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_MusicSets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MusicSets'
+type DataService_MusicSets_Call struct {
+	*mock.Call
+}
+
+// MusicSets is a helper method to define mock.On call
+//   - userID *uuid.UUID
+func (_e *DataService_Expecter) MusicSets(userID interface{}) *DataService_MusicSets_Call {
+	return &DataService_MusicSets_Call{Call: _e.mock.On("MusicSets", userID)}
+}
+
+func (_c *DataService_MusicSets_Call) Run(run func(userID *uuid.UUID)) *DataService_MusicSets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *DataService_MusicSets_Call) Return(_a0 []*apimodel.MusicSet, _a1 error) *DataService_MusicSets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_MusicSets_Call) RunAndReturn(run func(*uuid.UUID) ([]*apimodel.MusicSet, error)) *DataService_MusicSets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// People provides a mock function with given fields:
+func (_m *DataService) People() ([]*apimodel.Person, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for People")
+	}
+
+	var r0 []*apimodel.Person
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*apimodel.Person, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*apimodel.Person); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*apimodel.Person)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_People_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'People'
+type DataService_People_Call struct {
+	*mock.Call
+}
+
+// People is a helper method to define mock.On call
+func (_e *DataService_Expecter) People() *DataService_People_Call {
+	return &DataService_People_Call{Call: _e.mock.On("People")}
+}
+
+func (_c *DataService_People_Call) Run(run func()) *DataService_People_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *DataService_People_Call) Return(_a0 []*apimodel.Person, _a1 error) *DataService_People_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_People_Call) RunAndReturn(run func() ([]*apimodel.Person, error)) *DataService_People_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PersonTunes provides a mock function with given fields: id, opts
+func (_m *DataService) PersonTunes(id uuid.UUID, opts common.TuneListOptions) (*apimodel.TuneList, error) {
+	ret := _m.Called(id, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PersonTunes")
+	}
+
+	var r0 *apimodel.TuneList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, common.TuneListOptions) (*apimodel.TuneList, error)); ok {
+		return rf(id, opts)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, common.TuneListOptions) *apimodel.TuneList); ok {
+		r0 = rf(id, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*apimodel.TuneList)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, common.TuneListOptions) error); ok {
+		r1 = rf(id, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_PersonTunes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PersonTunes'
+type DataService_PersonTunes_Call struct {
+	*mock.Call
+}
+
+// PersonTunes is a helper method to define mock.On call
+//   - id uuid.UUID
+//   - opts common.TuneListOptions
+func (_e *DataService_Expecter) PersonTunes(id interface{}, opts interface{}) *DataService_PersonTunes_Call {
+	return &DataService_PersonTunes_Call{Call: _e.mock.On("PersonTunes", id, opts)}
+}
+
+func (_c *DataService_PersonTunes_Call) Run(run func(id uuid.UUID, opts common.TuneListOptions)) *DataService_PersonTunes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(common.TuneListOptions))
+	})
+	return _c
+}
+
+func (_c *DataService_PersonTunes_Call) Return(_a0 *apimodel.TuneList, _a1 error) *DataService_PersonTunes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_PersonTunes_Call) RunAndReturn(run func(uuid.UUID, common.TuneListOptions) (*apimodel.TuneList, error)) *DataService_PersonTunes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequeueRunningImportJobs provides a mock function with given fields:
+func (_m *DataService) RequeueRunningImportJobs() (int64, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequeueRunningImportJobs")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (int64, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_RequeueRunningImportJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequeueRunningImportJobs'
+type DataService_RequeueRunningImportJobs_Call struct {
+	*mock.Call
+}
+
+// RequeueRunningImportJobs is a helper method to define mock.On call
+func (_e *DataService_Expecter) RequeueRunningImportJobs() *DataService_RequeueRunningImportJobs_Call {
+	return &DataService_RequeueRunningImportJobs_Call{Call: _e.mock.On("RequeueRunningImportJobs")}
+}
+
+func (_c *DataService_RequeueRunningImportJobs_Call) Run(run func()) *DataService_RequeueRunningImportJobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *DataService_RequeueRunningImportJobs_Call) Return(_a0 int64, _a1 error) *DataService_RequeueRunningImportJobs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_RequeueRunningImportJobs_Call) RunAndReturn(run func() (int64, error)) *DataService_RequeueRunningImportJobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreTuneRevision provides a mock function with given fields: tuneID, number, author
+func (_m *DataService) RestoreTuneRevision(tuneID uuid.UUID, number uint, author string) (*apimodel.Tune, error) {
+	ret := _m.Called(tuneID, number, author)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreTuneRevision")
+	}
+
+	var r0 *apimodel.Tune
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uint, string) (*apimodel.Tune, error)); ok {
+		return rf(tuneID, number, author)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uint, string) *apimodel.Tune); ok {
+		r0 = rf(tuneID, number, author)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*apimodel.Tune)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, uint, string) error); ok {
+		r1 = rf(tuneID, number, author)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_RestoreTuneRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreTuneRevision'
+type DataService_RestoreTuneRevision_Call struct {
+	*mock.Call
+}
+
+// RestoreTuneRevision is a helper method to define mock.On call
+//   - tuneID uuid.UUID
+//   - number uint
+//   - author string
+func (_e *DataService_Expecter) RestoreTuneRevision(tuneID interface{}, number interface{}, author interface{}) *DataService_RestoreTuneRevision_Call {
+	return &DataService_RestoreTuneRevision_Call{Call: _e.mock.On("RestoreTuneRevision", tuneID, number, author)}
+}
+
+func (_c *DataService_RestoreTuneRevision_Call) Run(run func(tuneID uuid.UUID, number uint, author string)) *DataService_RestoreTuneRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(uint), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *DataService_RestoreTuneRevision_Call) Return(_a0 *apimodel.Tune, _a1 error) *DataService_RestoreTuneRevision_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_RestoreTuneRevision_Call) RunAndReturn(run func(uuid.UUID, uint, string) (*apimodel.Tune, error)) *DataService_RestoreTuneRevision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Search provides a mock function with given fields: opts
+func (_m *DataService) Search(opts common.SearchOptions) (*apimodel.SearchResult, error) {
+	ret := _m.Called(opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 *apimodel.SearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(common.SearchOptions) (*apimodel.SearchResult, error)); ok {
+		return rf(opts)
+	}
+	if rf, ok := ret.Get(0).(func(common.SearchOptions) *apimodel.SearchResult); ok {
+		r0 = rf(opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*apimodel.SearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(common.SearchOptions) error); ok {
+		r1 = rf(opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type DataService_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - opts common.SearchOptions
+func (_e *DataService_Expecter) Search(opts interface{}) *DataService_Search_Call {
+	return &DataService_Search_Call{Call: _e.mock.On("Search", opts)}
+}
+
+func (_c *DataService_Search_Call) Run(run func(opts common.SearchOptions)) *DataService_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(common.SearchOptions))
+	})
+	return _c
+}
+
+func (_c *DataService_Search_Call) Return(_a0 *apimodel.SearchResult, _a1 error) *DataService_Search_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_Search_Call) RunAndReturn(run func(common.SearchOptions) (*apimodel.SearchResult, error)) *DataService_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetMembership provides a mock function with given fields: userID, role
+func (_m *DataService) SetMembership(userID uuid.UUID, role common.Role) (*model.Membership, error) {
+	ret := _m.Called(userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMembership")
+	}
+
+	var r0 *model.Membership
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, common.Role) (*model.Membership, error)); ok {
+		return rf(userID, role)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, common.Role) *model.Membership); ok {
+		r0 = rf(userID, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Membership)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, common.Role) error); ok {
+		r1 = rf(userID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_SetMembership_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMembership'
+type DataService_SetMembership_Call struct {
+	*mock.Call
+}
+
+// SetMembership is a helper method to define mock.On call
+//   - userID uuid.UUID
+//   - role common.Role
+func (_e *DataService_Expecter) SetMembership(userID interface{}, role interface{}) *DataService_SetMembership_Call {
+	return &DataService_SetMembership_Call{Call: _e.mock.On("SetMembership", userID, role)}
+}
+
+func (_c *DataService_SetMembership_Call) Run(run func(userID uuid.UUID, role common.Role)) *DataService_SetMembership_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(common.Role))
+	})
+	return _c
+}
+
+func (_c *DataService_SetMembership_Call) Return(_a0 *model.Membership, _a1 error) *DataService_SetMembership_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_SetMembership_Call) RunAndReturn(run func(uuid.UUID, common.Role) (*model.Membership, error)) *DataService_SetMembership_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetPersonAliases provides a mock function with given fields: id, aliases
+func (_m *DataService) SetPersonAliases(id uuid.UUID, aliases []string) (*apimodel.Person, error) {
+	ret := _m.Called(id, aliases)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPersonAliases")
+	}
+
+	var r0 *apimodel.Person
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, []string) (*apimodel.Person, error)); ok {
+		return rf(id, aliases)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, []string) *apimodel.Person); ok {
+		r0 = rf(id, aliases)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*apimodel.Person)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, []string) error); ok {
+		r1 = rf(id, aliases)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_SetPersonAliases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPersonAliases'
+type DataService_SetPersonAliases_Call struct {
+	*mock.Call
+}
+
+// SetPersonAliases is a helper method to define mock.On call
+//   - id uuid.UUID
+//   - aliases []string
+func (_e *DataService_Expecter) SetPersonAliases(id interface{}, aliases interface{}) *DataService_SetPersonAliases_Call {
+	return &DataService_SetPersonAliases_Call{Call: _e.mock.On("SetPersonAliases", id, aliases)}
+}
+
+func (_c *DataService_SetPersonAliases_Call) Run(run func(id uuid.UUID, aliases []string)) *DataService_SetPersonAliases_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *DataService_SetPersonAliases_Call) Return(_a0 *apimodel.Person, _a1 error) *DataService_SetPersonAliases_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_SetPersonAliases_Call) RunAndReturn(run func(uuid.UUID, []string) (*apimodel.Person, error)) *DataService_SetPersonAliases_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTuneTypeAliases provides a mock function with given fields: id, aliases
+func (_m *DataService) SetTuneTypeAliases(id uuid.UUID, aliases []string) (*apimodel.TuneType, error) {
+	ret := _m.Called(id, aliases)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTuneTypeAliases")
+	}
+
+	var r0 *apimodel.TuneType
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, []string) (*apimodel.TuneType, error)); ok {
+		return rf(id, aliases)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, []string) *apimodel.TuneType); ok {
+		r0 = rf(id, aliases)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*apimodel.TuneType)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, []string) error); ok {
+		r1 = rf(id, aliases)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_SetTuneTypeAliases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTuneTypeAliases'
+type DataService_SetTuneTypeAliases_Call struct {
+	*mock.Call
+}
+
+// SetTuneTypeAliases is a helper method to define mock.On call
+//   - id uuid.UUID
+//   - aliases []string
+func (_e *DataService_Expecter) SetTuneTypeAliases(id interface{}, aliases interface{}) *DataService_SetTuneTypeAliases_Call {
+	return &DataService_SetTuneTypeAliases_Call{Call: _e.mock.On("SetTuneTypeAliases", id, aliases)}
+}
+
+func (_c *DataService_SetTuneTypeAliases_Call) Run(run func(id uuid.UUID, aliases []string)) *DataService_SetTuneTypeAliases_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *DataService_SetTuneTypeAliases_Call) Return(_a0 *apimodel.TuneType, _a1 error) *DataService_SetTuneTypeAliases_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_SetTuneTypeAliases_Call) RunAndReturn(run func(uuid.UUID, []string) (*apimodel.TuneType, error)) *DataService_SetTuneTypeAliases_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Tags provides a mock function with given fields:
+func (_m *DataService) Tags() ([]*apimodel.Tag, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Tags")
+	}
+
+	var r0 []*apimodel.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*apimodel.Tag, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*apimodel.Tag); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*apimodel.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_Tags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Tags'
+type DataService_Tags_Call struct {
+	*mock.Call
+}
+
+// Tags is a helper method to define mock.On call
+func (_e *DataService_Expecter) Tags() *DataService_Tags_Call {
+	return &DataService_Tags_Call{Call: _e.mock.On("Tags")}
+}
+
+func (_c *DataService_Tags_Call) Run(run func()) *DataService_Tags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *DataService_Tags_Call) Return(_a0 []*apimodel.Tag, _a1 error) *DataService_Tags_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_Tags_Call) RunAndReturn(run func() ([]*apimodel.Tag, error)) *DataService_Tags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TuneAnalysis provides a mock function with given fields: id
+func (_m *DataService) TuneAnalysis(id uuid.UUID) (*apimodel.TuneAnalysis, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TuneAnalysis")
+	}
+
+	var r0 *apimodel.TuneAnalysis
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*apimodel.TuneAnalysis, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *apimodel.TuneAnalysis); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*apimodel.TuneAnalysis)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_TuneAnalysis_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TuneAnalysis'
+type DataService_TuneAnalysis_Call struct {
+	*mock.Call
+}
+
+// TuneAnalysis is a helper method to define mock.On call
+//   - id uuid.UUID
+func (_e *DataService_Expecter) TuneAnalysis(id interface{}) *DataService_TuneAnalysis_Call {
+	return &DataService_TuneAnalysis_Call{Call: _e.mock.On("TuneAnalysis", id)}
+}
+
+func (_c *DataService_TuneAnalysis_Call) Run(run func(id uuid.UUID)) *DataService_TuneAnalysis_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *DataService_TuneAnalysis_Call) Return(_a0 *apimodel.TuneAnalysis, _a1 error) *DataService_TuneAnalysis_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_TuneAnalysis_Call) RunAndReturn(run func(uuid.UUID) (*apimodel.TuneAnalysis, error)) *DataService_TuneAnalysis_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TuneDuplicates provides a mock function with given fields: id
+func (_m *DataService) TuneDuplicates(id uuid.UUID) ([]*apimodel.TuneDuplicate, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TuneDuplicates")
+	}
+
+	var r0 []*apimodel.TuneDuplicate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) ([]*apimodel.TuneDuplicate, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []*apimodel.TuneDuplicate); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*apimodel.TuneDuplicate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_TuneDuplicates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TuneDuplicates'
+type DataService_TuneDuplicates_Call struct {
+	*mock.Call
+}
+
+// TuneDuplicates is a helper method to define mock.On call
+//   - id uuid.UUID
+func (_e *DataService_Expecter) TuneDuplicates(id interface{}) *DataService_TuneDuplicates_Call {
+	return &DataService_TuneDuplicates_Call{Call: _e.mock.On("TuneDuplicates", id)}
+}
+
+func (_c *DataService_TuneDuplicates_Call) Run(run func(id uuid.UUID)) *DataService_TuneDuplicates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *DataService_TuneDuplicates_Call) Return(_a0 []*apimodel.TuneDuplicate, _a1 error) *DataService_TuneDuplicates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_TuneDuplicates_Call) RunAndReturn(run func(uuid.UUID) ([]*apimodel.TuneDuplicate, error)) *DataService_TuneDuplicates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TuneMessages provides a mock function with given fields: tuneID, opts
+func (_m *DataService) TuneMessages(tuneID uuid.UUID, opts common.MessageListOptions) ([]apimodel.TuneMessage, error) {
+	ret := _m.Called(tuneID, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TuneMessages")
+	}
+
+	var r0 []apimodel.TuneMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, common.MessageListOptions) ([]apimodel.TuneMessage, error)); ok {
+		return rf(tuneID, opts)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, common.MessageListOptions) []apimodel.TuneMessage); ok {
+		r0 = rf(tuneID, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]apimodel.TuneMessage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, common.MessageListOptions) error); ok {
+		r1 = rf(tuneID, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_TuneMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TuneMessages'
+type DataService_TuneMessages_Call struct {
+	*mock.Call
+}
+
+// TuneMessages is a helper method to define mock.On call
+//   - tuneID uuid.UUID
+//   - opts common.MessageListOptions
+func (_e *DataService_Expecter) TuneMessages(tuneID interface{}, opts interface{}) *DataService_TuneMessages_Call {
+	return &DataService_TuneMessages_Call{Call: _e.mock.On("TuneMessages", tuneID, opts)}
+}
+
+func (_c *DataService_TuneMessages_Call) Run(run func(tuneID uuid.UUID, opts common.MessageListOptions)) *DataService_TuneMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(common.MessageListOptions))
+	})
+	return _c
+}
+
+func (_c *DataService_TuneMessages_Call) Return(_a0 []apimodel.TuneMessage, _a1 error) *DataService_TuneMessages_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_TuneMessages_Call) RunAndReturn(run func(uuid.UUID, common.MessageListOptions) ([]apimodel.TuneMessage, error)) *DataService_TuneMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TuneRevision provides a mock function with given fields: tuneID, number
+func (_m *DataService) TuneRevision(tuneID uuid.UUID, number uint) (*model.TuneRevision, error) {
+	ret := _m.Called(tuneID, number)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TuneRevision")
+	}
+
+	var r0 *model.TuneRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uint) (*model.TuneRevision, error)); ok {
+		return rf(tuneID, number)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uint) *model.TuneRevision); ok {
+		r0 = rf(tuneID, number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TuneRevision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, uint) error); ok {
+		r1 = rf(tuneID, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_TuneRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TuneRevision'
+type DataService_TuneRevision_Call struct {
+	*mock.Call
+}
+
+// TuneRevision is a helper method to define mock.On call
+//   - tuneID uuid.UUID
+//   - number uint
+func (_e *DataService_Expecter) TuneRevision(tuneID interface{}, number interface{}) *DataService_TuneRevision_Call {
+	return &DataService_TuneRevision_Call{Call: _e.mock.On("TuneRevision", tuneID, number)}
+}
+
+func (_c *DataService_TuneRevision_Call) Run(run func(tuneID uuid.UUID, number uint)) *DataService_TuneRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *DataService_TuneRevision_Call) Return(_a0 *model.TuneRevision, _a1 error) *DataService_TuneRevision_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_TuneRevision_Call) RunAndReturn(run func(uuid.UUID, uint) (*model.TuneRevision, error)) *DataService_TuneRevision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TuneRevisions provides a mock function with given fields: tuneID
+func (_m *DataService) TuneRevisions(tuneID uuid.UUID) ([]*model.TuneRevision, error) {
+	ret := _m.Called(tuneID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TuneRevisions")
+	}
+
+	var r0 []*model.TuneRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) ([]*model.TuneRevision, error)); ok {
+		return rf(tuneID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []*model.TuneRevision); ok {
+		r0 = rf(tuneID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.TuneRevision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(tuneID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_TuneRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TuneRevisions'
+type DataService_TuneRevisions_Call struct {
+	*mock.Call
+}
+
+// TuneRevisions is a helper method to define mock.On call
+//   - tuneID uuid.UUID
+func (_e *DataService_Expecter) TuneRevisions(tuneID interface{}) *DataService_TuneRevisions_Call {
+	return &DataService_TuneRevisions_Call{Call: _e.mock.On("TuneRevisions", tuneID)}
+}
+
+func (_c *DataService_TuneRevisions_Call) Run(run func(tuneID uuid.UUID)) *DataService_TuneRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *DataService_TuneRevisions_Call) Return(_a0 []*model.TuneRevision, _a1 error) *DataService_TuneRevisions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_TuneRevisions_Call) RunAndReturn(run func(uuid.UUID) ([]*model.TuneRevision, error)) *DataService_TuneRevisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TuneTypes provides a mock function with given fields:
+func (_m *DataService) TuneTypes() ([]*apimodel.TuneType, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for TuneTypes")
+	}
+
+	var r0 []*apimodel.TuneType
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*apimodel.TuneType, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*apimodel.TuneType); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*apimodel.TuneType)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_TuneTypes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TuneTypes'
+type DataService_TuneTypes_Call struct {
+	*mock.Call
+}
+
+// TuneTypes is a helper method to define mock.On call
+func (_e *DataService_Expecter) TuneTypes() *DataService_TuneTypes_Call {
+	return &DataService_TuneTypes_Call{Call: _e.mock.On("TuneTypes")}
+}
+
+func (_c *DataService_TuneTypes_Call) Run(run func()) *DataService_TuneTypes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *DataService_TuneTypes_Call) Return(_a0 []*apimodel.TuneType, _a1 error) *DataService_TuneTypes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataService_TuneTypes_Call) RunAndReturn(run func() ([]*apimodel.TuneType, error)) *DataService_TuneTypes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Tunes provides a mock function with given fields: opts
+func (_m *DataService) Tunes(opts common.TuneListOptions) (*apimodel.TuneList, error) {
+	ret := _m.Called(opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Tunes")
+	}
+
+	var r0 *apimodel.TuneList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(common.TuneListOptions) (*apimodel.TuneList, error)); ok {
+		return rf(opts)
+	}
+	if rf, ok := ret.Get(0).(func(common.TuneListOptions) *apimodel.TuneList); ok {
+		r0 = rf(opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*apimodel.TuneList)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(common.TuneListOptions) error); ok {
+		r1 = rf(opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataService_Tunes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Tunes'
+type DataService_Tunes_Call struct {
 	*mock.Call
 }
 
-// DeleteMusicSet is a helper method to define mock.On call
-//   - id uuid.UUID
-func (_e *DataService_Expecter) DeleteMusicSet(id interface{}) *DataService_DeleteMusicSet_Call {
-	return &DataService_DeleteMusicSet_Call{Call: _e.mock.On("DeleteMusicSet", id)}
+// Tunes is a helper method to define mock.On call
+//   - opts common.TuneListOptions
+func (_e *DataService_Expecter) Tunes(opts interface{}) *DataService_Tunes_Call {
+	return &DataService_Tunes_Call{Call: _e.mock.On("Tunes", opts)}
 }
 
-func (_c *DataService_DeleteMusicSet_Call) Run(run func(id uuid.UUID)) *DataService_DeleteMusicSet_Call {
+func (_c *DataService_Tunes_Call) Run(run func(opts common.TuneListOptions)) *DataService_Tunes_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uuid.UUID))
+		run(args[0].(common.TuneListOptions))
 	})
 	return _c
 }
 
-func (_c *DataService_DeleteMusicSet_Call) Return(_a0 error) *DataService_DeleteMusicSet_Call {
-	_c.Call.Return(_a0)
+func (_c *DataService_Tunes_Call) Return(_a0 *apimodel.TuneList, _a1 error) *DataService_Tunes_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *DataService_DeleteMusicSet_Call) RunAndReturn(run func(uuid.UUID) error) *DataService_DeleteMusicSet_Call {
+func (_c *DataService_Tunes_Call) RunAndReturn(run func(common.TuneListOptions) (*apimodel.TuneList, error)) *DataService_Tunes_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteTune provides a mock function with given fields: id
-func (_m *DataService) DeleteTune(id uuid.UUID) error {
-	ret := _m.Called(id)
+// TunesAnalysis provides a mock function with given fields: opts
+func (_m *DataService) TunesAnalysis(opts common.TuneListOptions) (*apimodel.TuneAnalysis, error) {
+	ret := _m.Called(opts)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteTune")
+		panic("no return value specified for TunesAnalysis")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
-		r0 = rf(id)
+	var r0 *apimodel.TuneAnalysis
+	var r1 error
+	if rf, ok := ret.Get(0).(func(common.TuneListOptions) (*apimodel.TuneAnalysis, error)); ok {
+		return rf(opts)
+	}
+	if rf, ok := ret.Get(0).(func(common.TuneListOptions) *apimodel.TuneAnalysis); ok {
+		r0 = rf(opts)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*apimodel.TuneAnalysis)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(common.TuneListOptions) error); ok {
+		r1 = rf(opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// DataService_DeleteTune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTune'
-type DataService_DeleteTune_Call struct {
+// DataService_TunesAnalysis_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TunesAnalysis'
+type DataService_TunesAnalysis_Call struct {
 	*mock.Call
 }
 
-// DeleteTune is a helper method to define mock.On call
-//   - id uuid.UUID
-func (_e *DataService_Expecter) DeleteTune(id interface{}) *DataService_DeleteTune_Call {
-	return &DataService_DeleteTune_Call{Call: _e.mock.On("DeleteTune", id)}
+// TunesAnalysis is a helper method to define mock.On call
+//   - opts common.TuneListOptions
+func (_e *DataService_Expecter) TunesAnalysis(opts interface{}) *DataService_TunesAnalysis_Call {
+	return &DataService_TunesAnalysis_Call{Call: _e.mock.On("TunesAnalysis", opts)}
 }
 
-func (_c *DataService_DeleteTune_Call) Run(run func(id uuid.UUID)) *DataService_DeleteTune_Call {
+func (_c *DataService_TunesAnalysis_Call) Run(run func(opts common.TuneListOptions)) *DataService_TunesAnalysis_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uuid.UUID))
+		run(args[0].(common.TuneListOptions))
 	})
 	return _c
 }
 
-func (_c *DataService_DeleteTune_Call) Return(_a0 error) *DataService_DeleteTune_Call {
-	_c.Call.Return(_a0)
+func (_c *DataService_TunesAnalysis_Call) Return(_a0 *apimodel.TuneAnalysis, _a1 error) *DataService_TunesAnalysis_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *DataService_DeleteTune_Call) RunAndReturn(run func(uuid.UUID) error) *DataService_DeleteTune_Call {
+func (_c *DataService_TunesAnalysis_Call) RunAndReturn(run func(common.TuneListOptions) (*apimodel.TuneAnalysis, error)) *DataService_TunesAnalysis_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetImportFileByHash provides a mock function with given fields: fHash
-func (_m *DataService) GetImportFileByHash(fHash string) (*model.ImportFile, error) {
-	ret := _m.Called(fHash)
+// UpdateImportJob provides a mock function with given fields: job
+func (_m *DataService) UpdateImportJob(job *model.ImportJob) error {
+	ret := _m.Called(job)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetImportFileByHash")
-	}
-
-	var r0 *model.ImportFile
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*model.ImportFile, error)); ok {
-		return rf(fHash)
-	}
-	if rf, ok := ret.Get(0).(func(string) *model.ImportFile); ok {
-		r0 = rf(fHash)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.ImportFile)
-		}
+		panic("no return value specified for UpdateImportJob")
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(fHash)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.ImportJob) error); ok {
+		r0 = rf(job)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// DataService_GetImportFileByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetImportFileByHash'
-type DataService_GetImportFileByHash_Call struct {
+// DataService_UpdateImportJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateImportJob'
+type DataService_UpdateImportJob_Call struct {
 	*mock.Call
 }
 
-// GetImportFileByHash is a helper method to define mock.On call
-//   - fHash string
-func (_e *DataService_Expecter) GetImportFileByHash(fHash interface{}) *DataService_GetImportFileByHash_Call {
-	return &DataService_GetImportFileByHash_Call{Call: _e.mock.On("GetImportFileByHash", fHash)}
+// UpdateImportJob is a helper method to define mock.On call
+//   - job *model.ImportJob
+func (_e *DataService_Expecter) UpdateImportJob(job interface{}) *DataService_UpdateImportJob_Call {
+	return &DataService_UpdateImportJob_Call{Call: _e.mock.On("UpdateImportJob", job)}
 }
 
-func (_c *DataService_GetImportFileByHash_Call) Run(run func(fHash string)) *DataService_GetImportFileByHash_Call {
+func (_c *DataService_UpdateImportJob_Call) Run(run func(job *model.ImportJob)) *DataService_UpdateImportJob_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(*model.ImportJob))
 	})
 	return _c
 }
 
-func (_c *DataService_GetImportFileByHash_Call) Return(_a0 *model.ImportFile, _a1 error) *DataService_GetImportFileByHash_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *DataService_UpdateImportJob_Call) Return(_a0 error) *DataService_UpdateImportJob_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *DataService_GetImportFileByHash_Call) RunAndReturn(run func(string) (*model.ImportFile, error)) *DataService_GetImportFileByHash_Call {
+func (_c *DataService_UpdateImportJob_Call) RunAndReturn(run func(*model.ImportJob) error) *DataService_UpdateImportJob_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetMusicSet provides a mock function with given fields: id
-func (_m *DataService) GetMusicSet(id uuid.UUID) (*apimodel.MusicSet, error) {
-	ret := _m.Called(id)
+// UpdateMessage provides a mock function with given fields: id, update, author
+func (_m *DataService) UpdateMessage(id uuid.UUID, update apimodel.UpdateTuneMessage, author string) (*apimodel.TuneMessage, error) {
+	ret := _m.Called(id, update, author)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMusicSet")
+		panic("no return value specified for UpdateMessage")
 	}
 
-	var r0 *apimodel.MusicSet
+	var r0 *apimodel.TuneMessage
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uuid.UUID) (*apimodel.MusicSet, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(uuid.UUID, apimodel.UpdateTuneMessage, string) (*apimodel.TuneMessage, error)); ok {
+		return rf(id, update, author)
 	}
-	if rf, ok := ret.Get(0).(func(uuid.UUID) *apimodel.MusicSet); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(uuid.UUID, apimodel.UpdateTuneMessage, string) *apimodel.TuneMessage); ok {
+		r0 = rf(id, update, author)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*apimodel.MusicSet)
+			r0 = ret.Get(0).(*apimodel.TuneMessage)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(uuid.UUID, apimodel.UpdateTuneMessage, string) error); ok {
+		r1 = rf(id, update, author)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -481,57 +3402,59 @@ func (_m *DataService) GetMusicSet(id uuid.UUID) (*apimodel.MusicSet, error) {
 	return r0, r1
 }
 
-// DataService_GetMusicSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMusicSet'
-type DataService_GetMusicSet_Call struct {
+// DataService_UpdateMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMessage'
+type DataService_UpdateMessage_Call struct {
 	*mock.Call
 }
 
-// GetMusicSet is a helper method to define mock.On call
+// UpdateMessage is a helper method to define mock.On call
 //   - id uuid.UUID
-func (_e *DataService_Expecter) GetMusicSet(id interface{}) *DataService_GetMusicSet_Call {
-	return &DataService_GetMusicSet_Call{Call: _e.mock.On("GetMusicSet", id)}
+//   - update apimodel.UpdateTuneMessage
+//   - author string
+func (_e *DataService_Expecter) UpdateMessage(id interface{}, update interface{}, author interface{}) *DataService_UpdateMessage_Call {
+	return &DataService_UpdateMessage_Call{Call: _e.mock.On("UpdateMessage", id, update, author)}
 }
 
-func (_c *DataService_GetMusicSet_Call) Run(run func(id uuid.UUID)) *DataService_GetMusicSet_Call {
+func (_c *DataService_UpdateMessage_Call) Run(run func(id uuid.UUID, update apimodel.UpdateTuneMessage, author string)) *DataService_UpdateMessage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uuid.UUID))
+		run(args[0].(uuid.UUID), args[1].(apimodel.UpdateTuneMessage), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *DataService_GetMusicSet_Call) Return(_a0 *apimodel.MusicSet, _a1 error) *DataService_GetMusicSet_Call {
+func (_c *DataService_UpdateMessage_Call) Return(_a0 *apimodel.TuneMessage, _a1 error) *DataService_UpdateMessage_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *DataService_GetMusicSet_Call) RunAndReturn(run func(uuid.UUID) (*apimodel.MusicSet, error)) *DataService_GetMusicSet_Call {
+func (_c *DataService_UpdateMessage_Call) RunAndReturn(run func(uuid.UUID, apimodel.UpdateTuneMessage, string) (*apimodel.TuneMessage, error)) *DataService_UpdateMessage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTune provides a mock function with given fields: id
-func (_m *DataService) GetTune(id uuid.UUID) (*apimodel.Tune, error) {
-	ret := _m.Called(id)
+// UpdateMusicSet provides a mock function with given fields: id, tune, userID
+func (_m *DataService) UpdateMusicSet(id uuid.UUID, tune apimodel.UpdateSet, userID *uuid.UUID) (*apimodel.MusicSet, error) {
+	ret := _m.Called(id, tune, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTune")
+		panic("no return value specified for UpdateMusicSet")
 	}
 
-	var r0 *apimodel.Tune
+	var r0 *apimodel.MusicSet
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uuid.UUID) (*apimodel.Tune, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(uuid.UUID, apimodel.UpdateSet, *uuid.UUID) (*apimodel.MusicSet, error)); ok {
+		return rf(id, tune, userID)
 	}
-	if rf, ok := ret.Get(0).(func(uuid.UUID) *apimodel.Tune); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(uuid.UUID, apimodel.UpdateSet, *uuid.UUID) *apimodel.MusicSet); ok {
+		r0 = rf(id, tune, userID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*apimodel.Tune)
+			r0 = ret.Get(0).(*apimodel.MusicSet)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(uuid.UUID, apimodel.UpdateSet, *uuid.UUID) error); ok {
+		r1 = rf(id, tune, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -539,57 +3462,59 @@ func (_m *DataService) GetTune(id uuid.UUID) (*apimodel.Tune, error) {
 	return r0, r1
 }
 
-// DataService_GetTune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTune'
-type DataService_GetTune_Call struct {
+// DataService_UpdateMusicSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMusicSet'
+type DataService_UpdateMusicSet_Call struct {
 	*mock.Call
 }
 
-// GetTune is a helper method to define mock.On call
+// UpdateMusicSet is a helper method to define mock.On call
 //   - id uuid.UUID
-func (_e *DataService_Expecter) GetTune(id interface{}) *DataService_GetTune_Call {
-	return &DataService_GetTune_Call{Call: _e.mock.On("GetTune", id)}
+//   - tune apimodel.UpdateSet
+//   - userID *uuid.UUID
+func (_e *DataService_Expecter) UpdateMusicSet(id interface{}, tune interface{}, userID interface{}) *DataService_UpdateMusicSet_Call {
+	return &DataService_UpdateMusicSet_Call{Call: _e.mock.On("UpdateMusicSet", id, tune, userID)}
 }
 
-func (_c *DataService_GetTune_Call) Run(run func(id uuid.UUID)) *DataService_GetTune_Call {
+func (_c *DataService_UpdateMusicSet_Call) Run(run func(id uuid.UUID, tune apimodel.UpdateSet, userID *uuid.UUID)) *DataService_UpdateMusicSet_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uuid.UUID))
+		run(args[0].(uuid.UUID), args[1].(apimodel.UpdateSet), args[2].(*uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *DataService_GetTune_Call) Return(_a0 *apimodel.Tune, _a1 error) *DataService_GetTune_Call {
+func (_c *DataService_UpdateMusicSet_Call) Return(_a0 *apimodel.MusicSet, _a1 error) *DataService_UpdateMusicSet_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *DataService_GetTune_Call) RunAndReturn(run func(uuid.UUID) (*apimodel.Tune, error)) *DataService_GetTune_Call {
+func (_c *DataService_UpdateMusicSet_Call) RunAndReturn(run func(uuid.UUID, apimodel.UpdateSet, *uuid.UUID) (*apimodel.MusicSet, error)) *DataService_UpdateMusicSet_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTuneFile provides a mock function with given fields: tuneID, fType
-func (_m *DataService) GetTuneFile(tuneID uuid.UUID, fType fileformat.Format) (*model.TuneFile, error) {
-	ret := _m.Called(tuneID, fType)
+// UpdatePerson provides a mock function with given fields: id, update
+func (_m *DataService) UpdatePerson(id uuid.UUID, update apimodel.UpdatePerson) (*apimodel.Person, error) {
+	ret := _m.Called(id, update)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTuneFile")
+		panic("no return value specified for UpdatePerson")
 	}
 
-	var r0 *model.TuneFile
+	var r0 *apimodel.Person
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uuid.UUID, fileformat.Format) (*model.TuneFile, error)); ok {
-		return rf(tuneID, fType)
+	if rf, ok := ret.Get(0).(func(uuid.UUID, apimodel.UpdatePerson) (*apimodel.Person, error)); ok {
+		return rf(id, update)
 	}
-	if rf, ok := ret.Get(0).(func(uuid.UUID, fileformat.Format) *model.TuneFile); ok {
-		r0 = rf(tuneID, fType)
+	if rf, ok := ret.Get(0).(func(uuid.UUID, apimodel.UpdatePerson) *apimodel.Person); ok {
+		r0 = rf(id, update)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.TuneFile)
+			r0 = ret.Get(0).(*apimodel.Person)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uuid.UUID, fileformat.Format) error); ok {
-		r1 = rf(tuneID, fType)
+	if rf, ok := ret.Get(1).(func(uuid.UUID, apimodel.UpdatePerson) error); ok {
+		r1 = rf(id, update)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -597,58 +3522,58 @@ func (_m *DataService) GetTuneFile(tuneID uuid.UUID, fType fileformat.Format) (*
 	return r0, r1
 }
 
-// DataService_GetTuneFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTuneFile'
-type DataService_GetTuneFile_Call struct {
+// DataService_UpdatePerson_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePerson'
+type DataService_UpdatePerson_Call struct {
 	*mock.Call
 }
 
-// GetTuneFile is a helper method to define mock.On call
-//   - tuneID uuid.UUID
-//   - fType fileformat.Format
-func (_e *DataService_Expecter) GetTuneFile(tuneID interface{}, fType interface{}) *DataService_GetTuneFile_Call {
-	return &DataService_GetTuneFile_Call{Call: _e.mock.On("GetTuneFile", tuneID, fType)}
+// UpdatePerson is a helper method to define mock.On call
+//   - id uuid.UUID
+//   - update apimodel.UpdatePerson
+func (_e *DataService_Expecter) UpdatePerson(id interface{}, update interface{}) *DataService_UpdatePerson_Call {
+	return &DataService_UpdatePerson_Call{Call: _e.mock.On("UpdatePerson", id, update)}
 }
 
-func (_c *DataService_GetTuneFile_Call) Run(run func(tuneID uuid.UUID, fType fileformat.Format)) *DataService_GetTuneFile_Call {
+func (_c *DataService_UpdatePerson_Call) Run(run func(id uuid.UUID, update apimodel.UpdatePerson)) *DataService_UpdatePerson_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uuid.UUID), args[1].(fileformat.Format))
+		run(args[0].(uuid.UUID), args[1].(apimodel.UpdatePerson))
 	})
 	return _c
 }
 
-func (_c *DataService_GetTuneFile_Call) Return(_a0 *model.TuneFile, _a1 error) *DataService_GetTuneFile_Call {
+func (_c *DataService_UpdatePerson_Call) Return(_a0 *apimodel.Person, _a1 error) *DataService_UpdatePerson_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *DataService_GetTuneFile_Call) RunAndReturn(run func(uuid.UUID, fileformat.Format) (*model.TuneFile, error)) *DataService_GetTuneFile_Call {
+func (_c *DataService_UpdatePerson_Call) RunAndReturn(run func(uuid.UUID, apimodel.UpdatePerson) (*apimodel.Person, error)) *DataService_UpdatePerson_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTuneFiles provides a mock function with given fields: tuneID
-func (_m *DataService) GetTuneFiles(tuneID uuid.UUID) ([]*model.TuneFile, error) {
-	ret := _m.Called(tuneID)
+// UpdateTag provides a mock function with given fields: id, update
+func (_m *DataService) UpdateTag(id uuid.UUID, update apimodel.UpdateTag) (*apimodel.Tag, error) {
+	ret := _m.Called(id, update)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTuneFiles")
+		panic("no return value specified for UpdateTag")
 	}
 
-	var r0 []*model.TuneFile
+	var r0 *apimodel.Tag
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uuid.UUID) ([]*model.TuneFile, error)); ok {
-		return rf(tuneID)
+	if rf, ok := ret.Get(0).(func(uuid.UUID, apimodel.UpdateTag) (*apimodel.Tag, error)); ok {
+		return rf(id, update)
 	}
-	if rf, ok := ret.Get(0).(func(uuid.UUID) []*model.TuneFile); ok {
-		r0 = rf(tuneID)
+	if rf, ok := ret.Get(0).(func(uuid.UUID, apimodel.UpdateTag) *apimodel.Tag); ok {
+		r0 = rf(id, update)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.TuneFile)
+			r0 = ret.Get(0).(*apimodel.Tag)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
-		r1 = rf(tuneID)
+	if rf, ok := ret.Get(1).(func(uuid.UUID, apimodel.UpdateTag) error); ok {
+		r1 = rf(id, update)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -656,125 +3581,118 @@ func (_m *DataService) GetTuneFiles(tuneID uuid.UUID) ([]*model.TuneFile, error)
 	return r0, r1
 }
 
-// DataService_GetTuneFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTuneFiles'
-type DataService_GetTuneFiles_Call struct {
+// DataService_UpdateTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTag'
+type DataService_UpdateTag_Call struct {
 	*mock.Call
 }
 
-// GetTuneFiles is a helper method to define mock.On call
-//   - tuneID uuid.UUID
-func (_e *DataService_Expecter) GetTuneFiles(tuneID interface{}) *DataService_GetTuneFiles_Call {
-	return &DataService_GetTuneFiles_Call{Call: _e.mock.On("GetTuneFiles", tuneID)}
+// UpdateTag is a helper method to define mock.On call
+//   - id uuid.UUID
+//   - update apimodel.UpdateTag
+func (_e *DataService_Expecter) UpdateTag(id interface{}, update interface{}) *DataService_UpdateTag_Call {
+	return &DataService_UpdateTag_Call{Call: _e.mock.On("UpdateTag", id, update)}
 }
 
-func (_c *DataService_GetTuneFiles_Call) Run(run func(tuneID uuid.UUID)) *DataService_GetTuneFiles_Call {
+func (_c *DataService_UpdateTag_Call) Run(run func(id uuid.UUID, update apimodel.UpdateTag)) *DataService_UpdateTag_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uuid.UUID))
+		run(args[0].(uuid.UUID), args[1].(apimodel.UpdateTag))
 	})
 	return _c
 }
 
-func (_c *DataService_GetTuneFiles_Call) Return(_a0 []*model.TuneFile, _a1 error) *DataService_GetTuneFiles_Call {
+func (_c *DataService_UpdateTag_Call) Return(_a0 *apimodel.Tag, _a1 error) *DataService_UpdateTag_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *DataService_GetTuneFiles_Call) RunAndReturn(run func(uuid.UUID) ([]*model.TuneFile, error)) *DataService_GetTuneFiles_Call {
+func (_c *DataService_UpdateTag_Call) RunAndReturn(run func(uuid.UUID, apimodel.UpdateTag) (*apimodel.Tag, error)) *DataService_UpdateTag_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ImportTunes provides a mock function with given fields: parsedTunes, fileInfo
-func (_m *DataService) ImportTunes(parsedTunes []*messages.ParsedTune, fileInfo *common.ImportFileInfo) ([]*apimodel.ImportTune, *apimodel.BasicMusicSet, error) {
-	ret := _m.Called(parsedTunes, fileInfo)
+// UpdateTune provides a mock function with given fields: id, tune, author
+func (_m *DataService) UpdateTune(id uuid.UUID, tune apimodel.UpdateTune, author string) (*apimodel.Tune, error) {
+	ret := _m.Called(id, tune, author)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ImportTunes")
+		panic("no return value specified for UpdateTune")
 	}
 
-	var r0 []*apimodel.ImportTune
-	var r1 *apimodel.BasicMusicSet
-	var r2 error
-	if rf, ok := ret.Get(0).(func([]*messages.ParsedTune, *common.ImportFileInfo) ([]*apimodel.ImportTune, *apimodel.BasicMusicSet, error)); ok {
-		return rf(parsedTunes, fileInfo)
+	var r0 *apimodel.Tune
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, apimodel.UpdateTune, string) (*apimodel.Tune, error)); ok {
+		return rf(id, tune, author)
 	}
-	if rf, ok := ret.Get(0).(func([]*messages.ParsedTune, *common.ImportFileInfo) []*apimodel.ImportTune); ok {
-		r0 = rf(parsedTunes, fileInfo)
+	if rf, ok := ret.Get(0).(func(uuid.UUID, apimodel.UpdateTune, string) *apimodel.Tune); ok {
+		r0 = rf(id, tune, author)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*apimodel.ImportTune)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func([]*messages.ParsedTune, *common.ImportFileInfo) *apimodel.BasicMusicSet); ok {
-		r1 = rf(parsedTunes, fileInfo)
-	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*apimodel.BasicMusicSet)
+			r0 = ret.Get(0).(*apimodel.Tune)
 		}
 	}
 
-	if rf, ok := ret.Get(2).(func([]*messages.ParsedTune, *common.ImportFileInfo) error); ok {
-		r2 = rf(parsedTunes, fileInfo)
+	if rf, ok := ret.Get(1).(func(uuid.UUID, apimodel.UpdateTune, string) error); ok {
+		r1 = rf(id, tune, author)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// DataService_ImportTunes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportTunes'
-type DataService_ImportTunes_Call struct {
+// DataService_UpdateTune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTune'
+type DataService_UpdateTune_Call struct {
 	*mock.Call
 }
 
-// ImportTunes is a helper method to define mock.On call
-//   - parsedTunes []*messages.ParsedTune
-//   - fileInfo *common.ImportFileInfo
-func (_e *DataService_Expecter) ImportTunes(parsedTunes interface{}, fileInfo interface{}) *DataService_ImportTunes_Call {
-	return &DataService_ImportTunes_Call{Call: _e.mock.On("ImportTunes", parsedTunes, fileInfo)}
+// UpdateTune is a helper method to define mock.On call
+//   - id uuid.UUID
+//   - tune apimodel.UpdateTune
+//   - author string
+func (_e *DataService_Expecter) UpdateTune(id interface{}, tune interface{}, author interface{}) *DataService_UpdateTune_Call {
+	return &DataService_UpdateTune_Call{Call: _e.mock.On("UpdateTune", id, tune, author)}
 }
 
-func (_c *DataService_ImportTunes_Call) Run(run func(parsedTunes []*messages.ParsedTune, fileInfo *common.ImportFileInfo)) *DataService_ImportTunes_Call {
+func (_c *DataService_UpdateTune_Call) Run(run func(id uuid.UUID, tune apimodel.UpdateTune, author string)) *DataService_UpdateTune_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].([]*messages.ParsedTune), args[1].(*common.ImportFileInfo))
+		run(args[0].(uuid.UUID), args[1].(apimodel.UpdateTune), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *DataService_ImportTunes_Call) Return(_a0 []*apimodel.ImportTune, _a1 *apimodel.BasicMusicSet, _a2 error) *DataService_ImportTunes_Call {
-	_c.Call.Return(_a0, _a1, _a2)
+func (_c *DataService_UpdateTune_Call) Return(_a0 *apimodel.Tune, _a1 error) *DataService_UpdateTune_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *DataService_ImportTunes_Call) RunAndReturn(run func([]*messages.ParsedTune, *common.ImportFileInfo) ([]*apimodel.ImportTune, *apimodel.BasicMusicSet, error)) *DataService_ImportTunes_Call {
+func (_c *DataService_UpdateTune_Call) RunAndReturn(run func(uuid.UUID, apimodel.UpdateTune, string) (*apimodel.Tune, error)) *DataService_UpdateTune_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MusicSets provides a mock function with given fields:
-func (_m *DataService) MusicSets() ([]*apimodel.MusicSet, error) {
-	ret := _m.Called()
+// UpdateTuneMessages provides a mock function with given fields: tuneID, update, author
+func (_m *DataService) UpdateTuneMessages(tuneID uuid.UUID, update apimodel.UpdateTuneMessage, author string) ([]apimodel.TuneMessage, error) {
+	ret := _m.Called(tuneID, update, author)
 
 	if len(ret) == 0 {
-		panic("no return value specified for MusicSets")
+		panic("no return value specified for UpdateTuneMessages")
 	}
 
-	var r0 []*apimodel.MusicSet
+	var r0 []apimodel.TuneMessage
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]*apimodel.MusicSet, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(uuid.UUID, apimodel.UpdateTuneMessage, string) ([]apimodel.TuneMessage, error)); ok {
+		return rf(tuneID, update, author)
 	}
-	if rf, ok := ret.Get(0).(func() []*apimodel.MusicSet); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(uuid.UUID, apimodel.UpdateTuneMessage, string) []apimodel.TuneMessage); ok {
+		r0 = rf(tuneID, update, author)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*apimodel.MusicSet)
+			r0 = ret.Get(0).([]apimodel.TuneMessage)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(uuid.UUID, apimodel.UpdateTuneMessage, string) error); ok {
+		r1 = rf(tuneID, update, author)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -782,56 +3700,59 @@ func (_m *DataService) MusicSets() ([]*apimodel.MusicSet, error) {
 	return r0, r1
 }
 
-// DataService_MusicSets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MusicSets'
-type DataService_MusicSets_Call struct {
+// DataService_UpdateTuneMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTuneMessages'
+type DataService_UpdateTuneMessages_Call struct {
 	*mock.Call
 }
 
-// MusicSets is a helper method to define mock.On call
-func (_e *DataService_Expecter) MusicSets() *DataService_MusicSets_Call {
-	return &DataService_MusicSets_Call{Call: _e.mock.On("MusicSets")}
+// UpdateTuneMessages is a helper method to define mock.On call
+//   - tuneID uuid.UUID
+//   - update apimodel.UpdateTuneMessage
+//   - author string
+func (_e *DataService_Expecter) UpdateTuneMessages(tuneID interface{}, update interface{}, author interface{}) *DataService_UpdateTuneMessages_Call {
+	return &DataService_UpdateTuneMessages_Call{Call: _e.mock.On("UpdateTuneMessages", tuneID, update, author)}
 }
 
-func (_c *DataService_MusicSets_Call) Run(run func()) *DataService_MusicSets_Call {
+func (_c *DataService_UpdateTuneMessages_Call) Run(run func(tuneID uuid.UUID, update apimodel.UpdateTuneMessage, author string)) *DataService_UpdateTuneMessages_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(uuid.UUID), args[1].(apimodel.UpdateTuneMessage), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *DataService_MusicSets_Call) Return(_a0 []*apimodel.MusicSet, _a1 error) *DataService_MusicSets_Call {
+func (_c *DataService_UpdateTuneMessages_Call) Return(_a0 []apimodel.TuneMessage, _a1 error) *DataService_UpdateTuneMessages_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *DataService_MusicSets_Call) RunAndReturn(run func() ([]*apimodel.MusicSet, error)) *DataService_MusicSets_Call {
+func (_c *DataService_UpdateTuneMessages_Call) RunAndReturn(run func(uuid.UUID, apimodel.UpdateTuneMessage, string) ([]apimodel.TuneMessage, error)) *DataService_UpdateTuneMessages_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Tunes provides a mock function with given fields:
-func (_m *DataService) Tunes() ([]*apimodel.Tune, error) {
-	ret := _m.Called()
+// UpdateTuneType provides a mock function with given fields: id, update
+func (_m *DataService) UpdateTuneType(id uuid.UUID, update apimodel.UpdateTuneType) (*apimodel.TuneType, error) {
+	ret := _m.Called(id, update)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Tunes")
+		panic("no return value specified for UpdateTuneType")
 	}
 
-	var r0 []*apimodel.Tune
+	var r0 *apimodel.TuneType
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]*apimodel.Tune, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(uuid.UUID, apimodel.UpdateTuneType) (*apimodel.TuneType, error)); ok {
+		return rf(id, update)
 	}
-	if rf, ok := ret.Get(0).(func() []*apimodel.Tune); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(uuid.UUID, apimodel.UpdateTuneType) *apimodel.TuneType); ok {
+		r0 = rf(id, update)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*apimodel.Tune)
+			r0 = ret.Get(0).(*apimodel.TuneType)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(uuid.UUID, apimodel.UpdateTuneType) error); ok {
+		r1 = rf(id, update)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -839,56 +3760,58 @@ func (_m *DataService) Tunes() ([]*apimodel.Tune, error) {
 	return r0, r1
 }
 
-// DataService_Tunes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Tunes'
-type DataService_Tunes_Call struct {
+// DataService_UpdateTuneType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTuneType'
+type DataService_UpdateTuneType_Call struct {
 	*mock.Call
 }
 
-// Tunes is a helper method to define mock.On call
-func (_e *DataService_Expecter) Tunes() *DataService_Tunes_Call {
-	return &DataService_Tunes_Call{Call: _e.mock.On("Tunes")}
+// UpdateTuneType is a helper method to define mock.On call
+//   - id uuid.UUID
+//   - update apimodel.UpdateTuneType
+func (_e *DataService_Expecter) UpdateTuneType(id interface{}, update interface{}) *DataService_UpdateTuneType_Call {
+	return &DataService_UpdateTuneType_Call{Call: _e.mock.On("UpdateTuneType", id, update)}
 }
 
-func (_c *DataService_Tunes_Call) Run(run func()) *DataService_Tunes_Call {
+func (_c *DataService_UpdateTuneType_Call) Run(run func(id uuid.UUID, update apimodel.UpdateTuneType)) *DataService_UpdateTuneType_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(uuid.UUID), args[1].(apimodel.UpdateTuneType))
 	})
 	return _c
 }
 
-func (_c *DataService_Tunes_Call) Return(_a0 []*apimodel.Tune, _a1 error) *DataService_Tunes_Call {
+func (_c *DataService_UpdateTuneType_Call) Return(_a0 *apimodel.TuneType, _a1 error) *DataService_UpdateTuneType_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *DataService_Tunes_Call) RunAndReturn(run func() ([]*apimodel.Tune, error)) *DataService_Tunes_Call {
+func (_c *DataService_UpdateTuneType_Call) RunAndReturn(run func(uuid.UUID, apimodel.UpdateTuneType) (*apimodel.TuneType, error)) *DataService_UpdateTuneType_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateMusicSet provides a mock function with given fields: id, tune
-func (_m *DataService) UpdateMusicSet(id uuid.UUID, tune apimodel.UpdateSet) (*apimodel.MusicSet, error) {
-	ret := _m.Called(id, tune)
+// UserByAPIToken provides a mock function with given fields: token
+func (_m *DataService) UserByAPIToken(token string) (*model.User, error) {
+	ret := _m.Called(token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateMusicSet")
+		panic("no return value specified for UserByAPIToken")
 	}
 
-	var r0 *apimodel.MusicSet
+	var r0 *model.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uuid.UUID, apimodel.UpdateSet) (*apimodel.MusicSet, error)); ok {
-		return rf(id, tune)
+	if rf, ok := ret.Get(0).(func(string) (*model.User, error)); ok {
+		return rf(token)
 	}
-	if rf, ok := ret.Get(0).(func(uuid.UUID, apimodel.UpdateSet) *apimodel.MusicSet); ok {
-		r0 = rf(id, tune)
+	if rf, ok := ret.Get(0).(func(string) *model.User); ok {
+		r0 = rf(token)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*apimodel.MusicSet)
+			r0 = ret.Get(0).(*model.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uuid.UUID, apimodel.UpdateSet) error); ok {
-		r1 = rf(id, tune)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -896,58 +3819,57 @@ func (_m *DataService) UpdateMusicSet(id uuid.UUID, tune apimodel.UpdateSet) (*a
 	return r0, r1
 }
 
-// DataService_UpdateMusicSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMusicSet'
-type DataService_UpdateMusicSet_Call struct {
+// DataService_UserByAPIToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserByAPIToken'
+type DataService_UserByAPIToken_Call struct {
 	*mock.Call
 }
 
-// UpdateMusicSet is a helper method to define mock.On call
-//   - id uuid.UUID
-//   - tune apimodel.UpdateSet
-func (_e *DataService_Expecter) UpdateMusicSet(id interface{}, tune interface{}) *DataService_UpdateMusicSet_Call {
-	return &DataService_UpdateMusicSet_Call{Call: _e.mock.On("UpdateMusicSet", id, tune)}
+// UserByAPIToken is a helper method to define mock.On call
+//   - token string
+func (_e *DataService_Expecter) UserByAPIToken(token interface{}) *DataService_UserByAPIToken_Call {
+	return &DataService_UserByAPIToken_Call{Call: _e.mock.On("UserByAPIToken", token)}
 }
 
-func (_c *DataService_UpdateMusicSet_Call) Run(run func(id uuid.UUID, tune apimodel.UpdateSet)) *DataService_UpdateMusicSet_Call {
+func (_c *DataService_UserByAPIToken_Call) Run(run func(token string)) *DataService_UserByAPIToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uuid.UUID), args[1].(apimodel.UpdateSet))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *DataService_UpdateMusicSet_Call) Return(_a0 *apimodel.MusicSet, _a1 error) *DataService_UpdateMusicSet_Call {
+func (_c *DataService_UserByAPIToken_Call) Return(_a0 *model.User, _a1 error) *DataService_UserByAPIToken_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *DataService_UpdateMusicSet_Call) RunAndReturn(run func(uuid.UUID, apimodel.UpdateSet) (*apimodel.MusicSet, error)) *DataService_UpdateMusicSet_Call {
+func (_c *DataService_UserByAPIToken_Call) RunAndReturn(run func(string) (*model.User, error)) *DataService_UserByAPIToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateTune provides a mock function with given fields: id, tune
-func (_m *DataService) UpdateTune(id uuid.UUID, tune apimodel.UpdateTune) (*apimodel.Tune, error) {
-	ret := _m.Called(id, tune)
+// UserForExternalID provides a mock function with given fields: claims
+func (_m *DataService) UserForExternalID(claims common.TokenClaims) (*model.User, error) {
+	ret := _m.Called(claims)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateTune")
+		panic("no return value specified for UserForExternalID")
 	}
 
-	var r0 *apimodel.Tune
+	var r0 *model.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uuid.UUID, apimodel.UpdateTune) (*apimodel.Tune, error)); ok {
-		return rf(id, tune)
+	if rf, ok := ret.Get(0).(func(common.TokenClaims) (*model.User, error)); ok {
+		return rf(claims)
 	}
-	if rf, ok := ret.Get(0).(func(uuid.UUID, apimodel.UpdateTune) *apimodel.Tune); ok {
-		r0 = rf(id, tune)
+	if rf, ok := ret.Get(0).(func(common.TokenClaims) *model.User); ok {
+		r0 = rf(claims)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*apimodel.Tune)
+			r0 = ret.Get(0).(*model.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uuid.UUID, apimodel.UpdateTune) error); ok {
-		r1 = rf(id, tune)
+	if rf, ok := ret.Get(1).(func(common.TokenClaims) error); ok {
+		r1 = rf(claims)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -955,31 +3877,30 @@ func (_m *DataService) UpdateTune(id uuid.UUID, tune apimodel.UpdateTune) (*apim
 	return r0, r1
 }
 
-// DataService_UpdateTune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTune'
-type DataService_UpdateTune_Call struct {
+// DataService_UserForExternalID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserForExternalID'
+type DataService_UserForExternalID_Call struct {
 	*mock.Call
 }
 
-// UpdateTune is a helper method to define mock.On call
-//   - id uuid.UUID
-//   - tune apimodel.UpdateTune
-func (_e *DataService_Expecter) UpdateTune(id interface{}, tune interface{}) *DataService_UpdateTune_Call {
-	return &DataService_UpdateTune_Call{Call: _e.mock.On("UpdateTune", id, tune)}
+// UserForExternalID is a helper method to define mock.On call
+//   - claims common.TokenClaims
+func (_e *DataService_Expecter) UserForExternalID(claims interface{}) *DataService_UserForExternalID_Call {
+	return &DataService_UserForExternalID_Call{Call: _e.mock.On("UserForExternalID", claims)}
 }
 
-func (_c *DataService_UpdateTune_Call) Run(run func(id uuid.UUID, tune apimodel.UpdateTune)) *DataService_UpdateTune_Call {
+func (_c *DataService_UserForExternalID_Call) Run(run func(claims common.TokenClaims)) *DataService_UserForExternalID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uuid.UUID), args[1].(apimodel.UpdateTune))
+		run(args[0].(common.TokenClaims))
 	})
 	return _c
 }
 
-func (_c *DataService_UpdateTune_Call) Return(_a0 *apimodel.Tune, _a1 error) *DataService_UpdateTune_Call {
+func (_c *DataService_UserForExternalID_Call) Return(_a0 *model.User, _a1 error) *DataService_UserForExternalID_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *DataService_UpdateTune_Call) RunAndReturn(run func(uuid.UUID, apimodel.UpdateTune) (*apimodel.Tune, error)) *DataService_UpdateTune_Call {
+func (_c *DataService_UserForExternalID_Call) RunAndReturn(run func(common.TokenClaims) (*model.User, error)) *DataService_UserForExternalID_Call {
 	_c.Call.Return(run)
 	return _c
 }
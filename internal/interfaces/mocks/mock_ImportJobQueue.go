@@ -0,0 +1,81 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	model "github.com/tomvodi/limepipes/internal/database/model"
+)
+
+// ImportJobQueue is an autogenerated mock type for the ImportJobQueue type
+type ImportJobQueue struct {
+	mock.Mock
+}
+
+type ImportJobQueue_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ImportJobQueue) EXPECT() *ImportJobQueue_Expecter {
+	return &ImportJobQueue_Expecter{mock: &_m.Mock}
+}
+
+// Enqueue provides a mock function with given fields: job
+func (_m *ImportJobQueue) Enqueue(job *model.ImportJob) error {
+	ret := _m.Called(job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enqueue")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.ImportJob) error); ok {
+		r0 = rf(job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ImportJobQueue_Enqueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enqueue'
+type ImportJobQueue_Enqueue_Call struct {
+	*mock.Call
+}
+
+// Enqueue is a helper method to define mock.On call
+//   - job *model.ImportJob
+func (_e *ImportJobQueue_Expecter) Enqueue(job interface{}) *ImportJobQueue_Enqueue_Call {
+	return &ImportJobQueue_Enqueue_Call{Call: _e.mock.On("Enqueue", job)}
+}
+
+func (_c *ImportJobQueue_Enqueue_Call) Run(run func(job *model.ImportJob)) *ImportJobQueue_Enqueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*model.ImportJob))
+	})
+	return _c
+}
+
+func (_c *ImportJobQueue_Enqueue_Call) Return(_a0 error) *ImportJobQueue_Enqueue_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ImportJobQueue_Enqueue_Call) RunAndReturn(run func(*model.ImportJob) error) *ImportJobQueue_Enqueue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewImportJobQueue creates a new instance of ImportJobQueue. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewImportJobQueue(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ImportJobQueue {
+	mock := &ImportJobQueue{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -4,10 +4,9 @@ package mocks
 
 import (
 	fileformat "github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	interfaces "github.com/tomvodi/limepipes-plugin-api/plugin/v1/interfaces"
 
 	mock "github.com/stretchr/testify/mock"
-
-	v1interfaces "github.com/tomvodi/limepipes-plugin-api/plugin/v1/interfaces"
 )
 
 // PluginLoader is an autogenerated mock type for the PluginLoader type
@@ -23,6 +22,64 @@ func (_m *PluginLoader) EXPECT() *PluginLoader_Expecter {
 	return &PluginLoader_Expecter{mock: &_m.Mock}
 }
 
+// ExportPluginForFileFormat provides a mock function with given fields: format
+func (_m *PluginLoader) ExportPluginForFileFormat(format fileformat.Format) (interfaces.LimePipesPlugin, error) {
+	ret := _m.Called(format)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportPluginForFileFormat")
+	}
+
+	var r0 interfaces.LimePipesPlugin
+	var r1 error
+	if rf, ok := ret.Get(0).(func(fileformat.Format) (interfaces.LimePipesPlugin, error)); ok {
+		return rf(format)
+	}
+	if rf, ok := ret.Get(0).(func(fileformat.Format) interfaces.LimePipesPlugin); ok {
+		r0 = rf(format)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.LimePipesPlugin)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(fileformat.Format) error); ok {
+		r1 = rf(format)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PluginLoader_ExportPluginForFileFormat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportPluginForFileFormat'
+type PluginLoader_ExportPluginForFileFormat_Call struct {
+	*mock.Call
+}
+
+// ExportPluginForFileFormat is a helper method to define mock.On call
+//   - format fileformat.Format
+func (_e *PluginLoader_Expecter) ExportPluginForFileFormat(format interface{}) *PluginLoader_ExportPluginForFileFormat_Call {
+	return &PluginLoader_ExportPluginForFileFormat_Call{Call: _e.mock.On("ExportPluginForFileFormat", format)}
+}
+
+func (_c *PluginLoader_ExportPluginForFileFormat_Call) Run(run func(format fileformat.Format)) *PluginLoader_ExportPluginForFileFormat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(fileformat.Format))
+	})
+	return _c
+}
+
+func (_c *PluginLoader_ExportPluginForFileFormat_Call) Return(_a0 interfaces.LimePipesPlugin, _a1 error) *PluginLoader_ExportPluginForFileFormat_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PluginLoader_ExportPluginForFileFormat_Call) RunAndReturn(run func(fileformat.Format) (interfaces.LimePipesPlugin, error)) *PluginLoader_ExportPluginForFileFormat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FileExtensionsForFileFormat provides a mock function with given fields: format
 func (_m *PluginLoader) FileExtensionsForFileFormat(format fileformat.Format) ([]string, error) {
 	ret := _m.Called(format)
@@ -184,23 +241,23 @@ func (_c *PluginLoader_LoadPluginsFromDir_Call) RunAndReturn(run func(string) er
 }
 
 // PluginForFileExtension provides a mock function with given fields: fileExtension
-func (_m *PluginLoader) PluginForFileExtension(fileExtension string) (v1interfaces.LimePipesPlugin, error) {
+func (_m *PluginLoader) PluginForFileExtension(fileExtension string) (interfaces.LimePipesPlugin, error) {
 	ret := _m.Called(fileExtension)
 
 	if len(ret) == 0 {
 		panic("no return value specified for PluginForFileExtension")
 	}
 
-	var r0 v1interfaces.LimePipesPlugin
+	var r0 interfaces.LimePipesPlugin
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (v1interfaces.LimePipesPlugin, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) (interfaces.LimePipesPlugin, error)); ok {
 		return rf(fileExtension)
 	}
-	if rf, ok := ret.Get(0).(func(string) v1interfaces.LimePipesPlugin); ok {
+	if rf, ok := ret.Get(0).(func(string) interfaces.LimePipesPlugin); ok {
 		r0 = rf(fileExtension)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(v1interfaces.LimePipesPlugin)
+			r0 = ret.Get(0).(interfaces.LimePipesPlugin)
 		}
 	}
 
@@ -231,12 +288,12 @@ func (_c *PluginLoader_PluginForFileExtension_Call) Run(run func(fileExtension s
 	return _c
 }
 
-func (_c *PluginLoader_PluginForFileExtension_Call) Return(_a0 v1interfaces.LimePipesPlugin, _a1 error) *PluginLoader_PluginForFileExtension_Call {
+func (_c *PluginLoader_PluginForFileExtension_Call) Return(_a0 interfaces.LimePipesPlugin, _a1 error) *PluginLoader_PluginForFileExtension_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *PluginLoader_PluginForFileExtension_Call) RunAndReturn(run func(string) (v1interfaces.LimePipesPlugin, error)) *PluginLoader_PluginForFileExtension_Call {
+func (_c *PluginLoader_PluginForFileExtension_Call) RunAndReturn(run func(string) (interfaces.LimePipesPlugin, error)) *PluginLoader_PluginForFileExtension_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,99 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	fileformat "github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/tomvodi/limepipes/internal/database/model"
+
+	uuid "github.com/google/uuid"
+)
+
+// TuneExporter is an autogenerated mock type for the TuneExporter type
+type TuneExporter struct {
+	mock.Mock
+}
+
+type TuneExporter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TuneExporter) EXPECT() *TuneExporter_Expecter {
+	return &TuneExporter_Expecter{mock: &_m.Mock}
+}
+
+// ExportTune provides a mock function with given fields: tuneID, format
+func (_m *TuneExporter) ExportTune(tuneID uuid.UUID, format fileformat.Format) (*model.TuneFile, error) {
+	ret := _m.Called(tuneID, format)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportTune")
+	}
+
+	var r0 *model.TuneFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, fileformat.Format) (*model.TuneFile, error)); ok {
+		return rf(tuneID, format)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, fileformat.Format) *model.TuneFile); ok {
+		r0 = rf(tuneID, format)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TuneFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, fileformat.Format) error); ok {
+		r1 = rf(tuneID, format)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TuneExporter_ExportTune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportTune'
+type TuneExporter_ExportTune_Call struct {
+	*mock.Call
+}
+
+// ExportTune is a helper method to define mock.On call
+//   - tuneID uuid.UUID
+//   - format fileformat.Format
+func (_e *TuneExporter_Expecter) ExportTune(tuneID interface{}, format interface{}) *TuneExporter_ExportTune_Call {
+	return &TuneExporter_ExportTune_Call{Call: _e.mock.On("ExportTune", tuneID, format)}
+}
+
+func (_c *TuneExporter_ExportTune_Call) Run(run func(tuneID uuid.UUID, format fileformat.Format)) *TuneExporter_ExportTune_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(fileformat.Format))
+	})
+	return _c
+}
+
+func (_c *TuneExporter_ExportTune_Call) Return(_a0 *model.TuneFile, _a1 error) *TuneExporter_ExportTune_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TuneExporter_ExportTune_Call) RunAndReturn(run func(uuid.UUID, fileformat.Format) (*model.TuneFile, error)) *TuneExporter_ExportTune_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTuneExporter creates a new instance of TuneExporter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTuneExporter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TuneExporter {
+	mock := &TuneExporter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
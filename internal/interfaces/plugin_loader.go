@@ -9,6 +9,7 @@ type PluginLoader interface {
 	LoadPluginsFromDir(pluginsDir string) error
 	UnloadPlugins() error
 	PluginForFileExtension(fileExtension string) (interfaces.LimePipesPlugin, error)
+	ExportPluginForFileFormat(format fileformat.Format) (interfaces.LimePipesPlugin, error)
 	FileExtensionsForFileFormat(format fileformat.Format) ([]string, error)
 	FileFormatForFileExtension(fileExtension string) (fileformat.Format, error)
 }
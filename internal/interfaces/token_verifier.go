@@ -0,0 +1,8 @@
+package interfaces
+
+import "github.com/tomvodi/limepipes/internal/common"
+
+// TokenVerifier verifies bearer tokens of an external identity provider.
+type TokenVerifier interface {
+	Verify(token string) (*common.TokenClaims, error)
+}
@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"github.com/google/uuid"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes/internal/database/model"
+)
+
+type TuneExporter interface {
+	ExportTune(tuneID uuid.UUID, format fileformat.Format) (*model.TuneFile, error)
+}
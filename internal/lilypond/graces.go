@@ -0,0 +1,80 @@
+package lilypond
+
+import (
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/pitch"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols"
+	"github.com/tomvodi/limepipes/internal/playback"
+	"strings"
+)
+
+// graceMacros are the embellishment macros of bagpipe.ly by the note
+// names of the gracenotes that they play.
+var graceMacros = map[string]string{
+	// single gracenotes
+	"G": "grG",
+	"a": "gra",
+	"b": "grb",
+	"c": "grc",
+	"d": "grd",
+	"e": "gre",
+	"f": "grf",
+	"g": "grg",
+	"A": "grA",
+
+	// doublings
+	"gGd": "dblG",
+	"gad": "dbla",
+	"gbd": "dblb",
+	"gcd": "dblc",
+	"gde": "dbld",
+	"gef": "dble",
+	"gfg": "dblf",
+	"gf":  "dblg",
+	"Ag":  "dblA",
+
+	// half doublings
+	"ad": "hdbla",
+	"bd": "hdblb",
+	"cd": "hdblc",
+	"de": "hdbld",
+	"ef": "hdble",
+	"fg": "hdblf",
+
+	// thumb doublings
+	"Aad": "tdbla",
+	"Abd": "tdblb",
+	"Acd": "tdblc",
+	"Ade": "tdbld",
+	"Aef": "tdble",
+	"Afg": "tdblf",
+
+	"GdG":   "grip",
+	"GdGe":  "taor",
+	"aGaG":  "birl",
+	"gaGaG": "gbirl",
+	"Gdc":   "thrwd",
+}
+
+// graceText returns the gracenotes of the embellishment or movement of the
+// ornament on the melody note as macro of bagpipe.ly or as grace group.
+func graceText(ornament *symbols.Note, melody pitch.Pitch) string {
+	graces := playback.GracePitches(ornament, melody)
+	if len(graces) == 0 {
+		return ""
+	}
+
+	names := make([]string, len(graces))
+	for i, g := range graces {
+		names[i] = pitchNames[g]
+	}
+	if macro, ok := graceMacros[strings.Join(names, "")]; ok {
+		return "\\" + macro
+	}
+
+	group := names[0] + "32"
+	if len(names) > 1 {
+		group += "[ " + strings.Join(names[1:], " ") + "]"
+	}
+
+	return "\\grace { " + group + " }"
+}
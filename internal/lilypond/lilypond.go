@@ -0,0 +1,138 @@
+// Package lilypond writes tunes as LilyPond source for the bagpipe.ly module
+// of LilyPond, so that arrangers can polish the score in LilyPond. Gracenotes
+// are written with the embellishment macros of bagpipe.ly like \dblg or \taor
+// and as plain grace groups if there is no macro for them.
+package lilypond
+
+import (
+	"fmt"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/barline"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/length"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/pitch"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/timeline"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"strconv"
+	"strings"
+)
+
+const (
+	// FormatName is the name of the export format in URLs and on the command line.
+	FormatName = "lilypond"
+	// Extension is the file extension of LilyPond source files.
+	Extension = ".ly"
+	// ContentType is the MIME type of LilyPond source files.
+	ContentType = "text/x-lilypond; charset=utf-8"
+	// version is the LilyPond version the source is written for
+	version = "2.24.0"
+)
+
+// pitchNames are the note names of bagpipe.ly from low G to high A.
+var pitchNames = map[pitch.Pitch]string{
+	pitch.Pitch_LowG:  "G",
+	pitch.Pitch_LowA:  "a",
+	pitch.Pitch_B:     "b",
+	pitch.Pitch_C:     "c",
+	pitch.Pitch_D:     "d",
+	pitch.Pitch_E:     "e",
+	pitch.Pitch_F:     "f",
+	pitch.Pitch_HighG: "g",
+	pitch.Pitch_HighA: "A",
+}
+
+var durations = map[length.Length]string{
+	length.Length_Whole:        "1",
+	length.Length_Half:         "2",
+	length.Length_Quarter:      "4",
+	length.Length_Eighth:       "8",
+	length.Length_Sixteenth:    "16",
+	length.Length_Thirtysecond: "32",
+}
+
+// sixtyFourths are the lengths of notes in sixty-fourth notes.
+var sixtyFourths = map[length.Length]int{
+	length.Length_Whole:        64,
+	length.Length_Half:         32,
+	length.Length_Quarter:      16,
+	length.Length_Eighth:       8,
+	length.Length_Sixteenth:    4,
+	length.Length_Thirtysecond: 2,
+}
+
+// barTypes are the bar types of LilyPond for barlines that are not regular ones.
+var barTypes = map[barline.Type]string{
+	barline.Type_Heavy:      ".",
+	barline.Type_HeavyHeavy: "..",
+	barline.Type_LightHeavy: "|.",
+	barline.Type_HeavyLight: ".|",
+}
+
+// voltaLabels are the texts of the volta brackets of timelines.
+var voltaLabels = map[timeline.Type]string{
+	timeline.Type_First:         "1",
+	timeline.Type_Second:        "2",
+	timeline.Type_Singling:      "Singling",
+	timeline.Type_Doubling:      "Doubling",
+	timeline.Type_SecondOf2:     "2 of 2",
+	timeline.Type_SecondOf3:     "2 of 3",
+	timeline.Type_SecondOf4:     "2 of 4",
+	timeline.Type_SecondOf2And4: "2 of 2 & 4",
+	timeline.Type_SecondOf5:     "2 of 5",
+	timeline.Type_SecondOf6:     "2 of 6",
+	timeline.Type_SecondOf7:     "2 of 7",
+	timeline.Type_SecondOf8:     "2 of 8",
+	timeline.Type_Bis:           "Bis",
+	timeline.Type_Intro:         "Intro",
+}
+
+// jumpTexts are the marks at barlines that jump to other parts of the tune.
+var jumpTexts = map[barline.Time]string{
+	barline.Time_Dalsegno:     "D.S.",
+	barline.Time_Fine:         "Fine",
+	barline.Time_DacapoAlFine: "D.C. al Fine",
+}
+
+// IsFormatName returns true if the export format name is the one of LilyPond.
+func IsFormatName(name string) bool {
+	return strings.EqualFold(strings.TrimSpace(name), FormatName)
+}
+
+// Write returns the LilyPond source of the tune. The source includes
+// bagpipe.ly and the same tune is always written to the same bytes.
+func Write(t *tune.Tune) []byte {
+	w := &writer{}
+	w.b = fmt.Appendf(w.b, "\\version %s\n\\include \"bagpipe.ly\"\n", strconv.Quote(version))
+	for _, c := range t.Comments {
+		w.b = append(w.b, "% "+c+"\n"...)
+	}
+	w.header(t)
+
+	w.b = append(w.b, "\n\\score {\n  {\n    \\hideKeySignature\n"...)
+	if t.Tempo > 0 {
+		w.b = fmt.Appendf(w.b, "    \\tempo 4 = %d\n", t.Tempo)
+	}
+	w.music(t.Measures)
+	w.b = append(w.b, "  }\n  \\layout { }\n}\n"...)
+
+	for _, f := range t.Footer {
+		w.b = fmt.Appendf(w.b, "\n\\markup { %s }\n", strconv.Quote(f))
+	}
+
+	return w.b
+}
+
+func (w *writer) header(t *tune.Tune) {
+	w.b = append(w.b, "\n\\header {\n"...)
+	w.headerField("title", t.Title)
+	w.headerField("meter", t.Type)
+	w.headerField("composer", t.Composer)
+	if t.Arranger != "" {
+		w.headerField("arranger", "arr. "+t.Arranger)
+	}
+	w.b = append(w.b, "  tagline = ##f\n}\n"...)
+}
+
+func (w *writer) headerField(name string, value string) {
+	if value != "" {
+		w.b = fmt.Appendf(w.b, "  %s = %s\n", name, strconv.Quote(value))
+	}
+}
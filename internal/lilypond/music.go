@@ -0,0 +1,253 @@
+package lilypond
+
+import (
+	"fmt"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/barline"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/boundary"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/length"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/measure"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/tie"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/timeline"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/tuplet"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// segnoMark is the mark of a segno above the barline.
+const segnoMark = `\mark \markup { \musicglyph "scripts.segno" }`
+
+// writer writes the music of a tune with a line for every measure.
+// Barlines are set with \bar and repeats and timelines with repeat
+// commands, so the music follows the measures of the tune.
+type writer struct {
+	b         []byte
+	lineStart bool
+	ornaments []*symbols.Note
+}
+
+func (w *writer) music(measures []*measure.Measure) {
+	for i, m := range measures {
+		var next *measure.Measure
+		if i+1 < len(measures) {
+			next = measures[i+1]
+		}
+
+		w.b = append(w.b, "    "...)
+		w.lineStart = true
+		w.measureStart(m, i == 0)
+		for _, s := range m.Symbols {
+			w.symbol(s)
+		}
+		w.measureEnd(m.RightBarline, next)
+		w.b = append(w.b, '\n')
+	}
+}
+
+// token writes the text separated by a space from the text before it.
+func (w *writer) token(text string) {
+	if !w.lineStart {
+		w.b = append(w.b, ' ')
+	}
+	w.b = append(w.b, text...)
+	w.lineStart = false
+}
+
+func (w *writer) measureStart(m *measure.Measure, first bool) {
+	if m.Time != nil && m.Time.BeatType > 0 {
+		w.token(fmt.Sprintf("\\time %d/%d", m.Time.Beats, m.Time.BeatType))
+	}
+	if first {
+		w.upbeat(m)
+	}
+	if m.LeftBarline == nil {
+		return
+	}
+
+	if bar := leftBarType(m.LeftBarline); first && bar != "" {
+		w.token(barCommand(bar))
+	}
+	if m.LeftBarline.Time == barline.Time_Segno {
+		w.token(segnoMark)
+	}
+}
+
+// upbeat marks the first measure as partial if it is shorter than its time signature.
+func (w *writer) upbeat(m *measure.Measure) {
+	if m.Time == nil || m.Time.BeatType == 0 {
+		return
+	}
+
+	full := int(64 * m.Time.Beats / m.Time.BeatType)
+	if l := measureLength(m); l > 0 && l < full {
+		w.token("\\partial " + partialDuration(l))
+	}
+}
+
+// measureEnd writes the barline at the end of a measure together with the
+// left barline of the next measure and a bar check.
+func (w *writer) measureEnd(right *barline.Barline, next *measure.Measure) {
+	if right == nil {
+		right = &barline.Barline{}
+	}
+	if text, ok := jumpTexts[right.Time]; ok {
+		w.token(fmt.Sprintf("\\mark \\markup { \\italic %s }", strconv.Quote(text)))
+	}
+
+	left := &barline.Barline{}
+	if next != nil && next.LeftBarline != nil {
+		left = next.LeftBarline
+	}
+	if bar := barType(right, left); bar != "" {
+		w.token(barCommand(bar))
+	}
+	w.token("|")
+}
+
+// barType returns the bar type between two measures. Barlines at the
+// start of a repeat or a part replace the barline before them.
+func barType(right *barline.Barline, left *barline.Barline) string {
+	repeatEnd := right.Time == barline.Time_Repeat
+	switch {
+	case repeatEnd && left.Time == barline.Time_Repeat:
+		return ":..:"
+	case repeatEnd:
+		return ":|."
+	case leftBarType(left) != "":
+		return leftBarType(left)
+	}
+
+	return barTypes[right.Type]
+}
+
+func leftBarType(b *barline.Barline) string {
+	if b.Time == barline.Time_Repeat {
+		return ".|:"
+	}
+
+	return barTypes[b.Type]
+}
+
+func barCommand(bar string) string {
+	return "\\bar " + strconv.Quote(bar)
+}
+
+func (w *writer) symbol(s *symbols.Symbol) {
+	if s.Tuplet != nil {
+		w.tuplet(s.Tuplet)
+	}
+	if s.Timeline != nil {
+		w.timeline(s.Timeline)
+	}
+	if s.TempoChange != nil {
+		w.token(fmt.Sprintf("\\tempo 4 = %d", *s.TempoChange))
+	}
+
+	switch {
+	case s.Note.IsValid():
+		w.note(s.Note)
+	case s.Note != nil:
+		w.ornament(s.Note)
+	case s.Rest != nil && s.Rest.Length != length.Length_NoLength:
+		w.token("r" + durations[s.Rest.Length])
+	}
+}
+
+func (w *writer) tuplet(t *tuplet.Tuplet) {
+	if t.BoundaryType == boundary.Boundary_End {
+		w.token("}")
+		return
+	}
+	if t.VisibleNotes > 0 && t.PlayedNotes > 0 {
+		w.token(fmt.Sprintf("\\tuplet %d/%d {", t.VisibleNotes, t.PlayedNotes))
+	}
+}
+
+// timeline starts or ends the volta bracket of the timeline.
+func (w *writer) timeline(tl *timeline.TimeLine) {
+	volta := "#f"
+	if tl.BoundaryType == boundary.Boundary_Start {
+		volta = strconv.Quote(voltaLabels[tl.Type])
+	}
+
+	w.token(fmt.Sprintf("\\set Score.repeatCommands = #'((volta %s))", volta))
+}
+
+// ornament keeps the embellishments and movements of symbols without pitch
+// for the next melody note and ties the last note to the next one.
+func (w *writer) ornament(n *symbols.Note) {
+	if n.Embellishment != nil || n.Movement != nil {
+		w.ornaments = append(w.ornaments, n)
+	}
+	if n.Tie == tie.Tie_Start {
+		w.b = append(w.b, '~')
+	}
+}
+
+func (w *writer) note(n *symbols.Note) {
+	for _, o := range append(w.ornaments, n) {
+		if g := graceText(o, n.Pitch); g != "" {
+			w.token(g)
+		}
+	}
+	w.ornaments = nil
+
+	text := pitchNames[n.Pitch] + durations[n.Length] + strings.Repeat(".", int(n.Dots))
+	if n.Fermata {
+		text += "\\fermata"
+	}
+	if n.Tie == tie.Tie_Start {
+		text += "~"
+	}
+	w.token(text)
+}
+
+// measureLength returns the length of the notes and rests of the measure
+// in sixty-fourth notes.
+func measureLength(m *measure.Measure) int {
+	total, factor := 0.0, 1.0
+	for _, s := range m.Symbols {
+		if s.Tuplet != nil {
+			factor = tupletFactor(s.Tuplet)
+		}
+		total += float64(symbolLength(s)) * factor
+	}
+
+	return int(math.Round(total))
+}
+
+func symbolLength(s *symbols.Symbol) int {
+	switch {
+	case s.Note.IsValid():
+		l := sixtyFourths[s.Note.Length]
+		return l * (1<<(s.Note.Dots+1) - 1) >> s.Note.Dots
+	case s.Rest != nil:
+		return sixtyFourths[s.Rest.Length]
+	}
+
+	return 0
+}
+
+func tupletFactor(t *tuplet.Tuplet) float64 {
+	if t.BoundaryType != boundary.Boundary_Start || t.VisibleNotes == 0 {
+		return 1
+	}
+
+	return float64(t.PlayedNotes) / float64(t.VisibleNotes)
+}
+
+// partialDuration returns the duration of an upbeat of sixty-fourth notes
+// like 8*3 for three eighth notes.
+func partialDuration(total int) string {
+	unit := 64
+	for total%2 == 0 && unit > 1 {
+		total /= 2
+		unit /= 2
+	}
+	if total == 1 {
+		return strconv.Itoa(unit)
+	}
+
+	return fmt.Sprintf("%d*%d", unit, total)
+}
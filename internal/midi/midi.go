@@ -0,0 +1,160 @@
+// Package midi renders the music model of tunes as Standard MIDI Files,
+// so pipers can listen to a tune before learning it.
+package midi
+
+import (
+	"encoding/binary"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"github.com/tomvodi/limepipes/internal/playback"
+	"math"
+	"sort"
+)
+
+// ContentType is the content type of MIDI files.
+const ContentType = "audio/midi"
+
+// Extension is the file extension of MIDI files with a leading dot.
+const Extension = ".mid"
+
+// Division are the ticks per quarter note of the rendered files.
+const Division = 480
+
+const (
+	chanterChannel = 0
+	droneChannel   = 1
+
+	// bagpipeProgram is the General MIDI program of the bag pipe.
+	bagpipeProgram  = 109
+	melodyVelocity  = 100
+	graceVelocity   = 90
+	droneVelocity   = 70
+	microsPerMinute = 60_000_000
+
+	// bendRange is the pitch bend range of General MIDI synthesizers in semitones.
+	bendRange  = 2
+	bendCenter = 8192
+)
+
+// Render returns the tune as a Standard MIDI File of format 1 with a tempo
+// track, a chanter track with the melody and gracenotes and a drone track.
+// The notes are tuned to the chanter with pitch bends. If tempo is not 0,
+// it replaces the tempo of the tune.
+func Render(t *tune.Tune, tempo uint32) []byte {
+	perf := playback.Play(t, tempo)
+	c := newClock(perf.Tempos)
+
+	data := appendChunk(nil, "MThd", header(3))
+	data = appendChunk(data, "MTrk", tempoTrack(t.Title, perf.Tempos, c))
+	data = appendChunk(data, "MTrk", chanterTrack(perf.Notes, c))
+
+	return appendChunk(data, "MTrk", droneTrack(perf.Duration, c))
+}
+
+func header(trackCount uint16) []byte {
+	data := make([]byte, 6)
+	binary.BigEndian.PutUint16(data[0:], 1)
+	binary.BigEndian.PutUint16(data[2:], trackCount)
+	binary.BigEndian.PutUint16(data[4:], Division)
+
+	return data
+}
+
+func appendChunk(data []byte, chunkType string, chunk []byte) []byte {
+	data = append(data, chunkType...)
+	data = binary.BigEndian.AppendUint32(data, uint32(len(chunk)))
+
+	return append(data, chunk...)
+}
+
+func tempoTrack(title string, tempos []playback.Tempo, c clock) []byte {
+	tr := &track{}
+	tr.addText(0, metaTrackName, title)
+	for _, t := range tempos {
+		micros := uint32(math.Round(microsPerMinute / t.QuarterBPM))
+		tr.addMeta(c.tick(t.Start), metaTempo, byte(micros>>16), byte(micros>>8), byte(micros))
+	}
+
+	return tr.bytes()
+}
+
+// chanterTrack returns the track with the notes of the chanter. A note ends
+// at the latest when the next one starts, as the chanter plays only one note.
+func chanterTrack(notes []playback.Note, c clock) []byte {
+	tr := &track{channel: chanterChannel}
+	tr.addText(0, metaTrackName, "Chanter")
+	tr.add(0, programChange|tr.channel, bagpipeProgram)
+	for i, n := range notes {
+		tn := note{
+			start:    c.tick(n.Start),
+			end:      c.tick(n.Start + n.Duration),
+			freq:     playback.Frequency(n.Pitch),
+			velocity: melodyVelocity,
+		}
+		if i+1 < len(notes) {
+			tn.end = min(tn.end, c.tick(notes[i+1].Start))
+		}
+		if n.Grace {
+			tn.velocity = graceVelocity
+		}
+		tr.addNote(tn)
+	}
+
+	return tr.bytes()
+}
+
+// droneTrack returns the track with the tenor and bass drone,
+// which sound for the whole tune.
+func droneTrack(duration float64, c clock) []byte {
+	tr := &track{channel: droneChannel}
+	tr.addText(0, metaTrackName, "Drones")
+	tr.add(0, programChange|tr.channel, bagpipeProgram)
+	end := c.tick(duration)
+	tr.addNote(note{end: end, freq: playback.TenorDroneFrequency, velocity: droneVelocity})
+	tr.addNote(note{end: end, freq: playback.BassDroneFrequency, velocity: droneVelocity})
+
+	return tr.bytes()
+}
+
+// key returns the MIDI key closest to the frequency
+// and the pitch bend that tunes the key to the frequency.
+func key(freq float64) (byte, uint16) {
+	semitones := 69 + 12*math.Log2(freq/440)
+	k := math.Round(semitones)
+	bend := bendCenter + (semitones-k)/bendRange*bendCenter
+
+	return byte(k), uint16(math.Round(bend))
+}
+
+// clock converts the seconds of a performance into ticks.
+type clock struct {
+	tempos []playback.Tempo
+	// ticks are the ticks at the start of the tempos
+	ticks []float64
+}
+
+func newClock(tempos []playback.Tempo) clock {
+	c := clock{
+		tempos: tempos,
+		ticks:  make([]float64, len(tempos)),
+	}
+	for i := 1; i < len(tempos); i++ {
+		c.ticks[i] = c.ticks[i-1] + ticksOf(tempos[i].Start-tempos[i-1].Start, tempos[i-1])
+	}
+
+	return c
+}
+
+func (c clock) tick(seconds float64) uint32 {
+	i := sort.Search(len(c.tempos), func(i int) bool {
+		return c.tempos[i].Start > seconds
+	}) - 1
+	if i < 0 {
+		return 0
+	}
+
+	return uint32(math.Round(c.ticks[i] + ticksOf(seconds-c.tempos[i].Start, c.tempos[i])))
+}
+
+func ticksOf(seconds float64, t playback.Tempo) float64 {
+	return seconds * t.QuarterBPM / 60 * Division
+}
@@ -0,0 +1,174 @@
+package midi
+
+import (
+	"encoding/binary"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/length"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/measure"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/pitch"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/embellishment"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"testing"
+)
+
+// decodedEvent is an event of a track with its absolute tick.
+type decodedEvent struct {
+	tick uint32
+	data []byte
+}
+
+type decodedFile struct {
+	format   uint16
+	division uint16
+	tracks   [][]decodedEvent
+}
+
+// decode decodes a Standard MIDI File that only uses the messages of the renderer.
+func decode(g *WithT, data []byte) decodedFile {
+	g.Expect(string(data[:4])).To(Equal("MThd"))
+	g.Expect(binary.BigEndian.Uint32(data[4:])).To(Equal(uint32(6)))
+	f := decodedFile{
+		format:   binary.BigEndian.Uint16(data[8:]),
+		division: binary.BigEndian.Uint16(data[12:]),
+	}
+	trackCount := int(binary.BigEndian.Uint16(data[10:]))
+
+	data = data[14:]
+	for range trackCount {
+		g.Expect(string(data[:4])).To(Equal("MTrk"))
+		size := binary.BigEndian.Uint32(data[4:])
+		f.tracks = append(f.tracks, decodeTrack(g, data[8:8+size]))
+		data = data[8+size:]
+	}
+	g.Expect(data).To(BeEmpty())
+
+	return f
+}
+
+func decodeTrack(g *WithT, data []byte) []decodedEvent {
+	var events []decodedEvent
+	tick := uint32(0)
+	for len(data) > 0 {
+		delta, n := readVarLen(data)
+		tick += delta
+		data = data[n:]
+
+		size := 3
+		switch {
+		case data[0] == meta:
+			l, n := readVarLen(data[2:])
+			size = 2 + n + int(l)
+		case data[0]&0xF0 == programChange:
+			size = 2
+		}
+		events = append(events, decodedEvent{tick: tick, data: data[:size]})
+		data = data[size:]
+	}
+	g.Expect(events[len(events)-1].data).To(Equal([]byte{meta, metaEndTrack, 0}))
+
+	return events
+}
+
+func readVarLen(data []byte) (uint32, int) {
+	v := uint32(0)
+	for i, b := range data {
+		v = v<<7 | uint32(b&0x7F)
+		if b&0x80 == 0 {
+			return v, i + 1
+		}
+	}
+
+	return v, len(data)
+}
+
+// notesOf returns the keys of the note on messages of the events.
+func notesOf(events []decodedEvent) []byte {
+	var keys []byte
+	for _, e := range events {
+		if e.data[0]&0xF0 == noteOn {
+			keys = append(keys, e.data[1])
+		}
+	}
+
+	return keys
+}
+
+func testTune() *tune.Tune {
+	return &tune.Tune{
+		Title: "Scotland the Brave",
+		Tempo: 60,
+		Measures: []*measure.Measure{
+			{
+				Time: &measure.TimeSignature{Beats: 2, BeatType: 4},
+				Symbols: []*symbols.Symbol{
+					{Note: &symbols.Note{
+						Pitch:         pitch.Pitch_LowA,
+						Length:        length.Length_Quarter,
+						Embellishment: &embellishment.Embellishment{Type: embellishment.Type_SingleGrace, Pitch: pitch.Pitch_HighG},
+					}},
+					{Note: &symbols.Note{Pitch: pitch.Pitch_E, Length: length.Length_Quarter}},
+				},
+			},
+		},
+	}
+}
+
+func TestRender(t *testing.T) {
+	g := NewWithT(t)
+
+	f := decode(g, Render(testTune(), 0))
+
+	g.Expect(f.format).To(Equal(uint16(1)))
+	g.Expect(f.division).To(Equal(uint16(Division)))
+	g.Expect(f.tracks).To(HaveLen(3))
+
+	tempoTrack := f.tracks[0]
+	g.Expect(tempoTrack[0].data).To(Equal(append([]byte{meta, metaTrackName, 18}, "Scotland the Brave"...)))
+	// 60 quarter notes per minute are 1000000 microseconds per quarter note
+	g.Expect(tempoTrack[1]).To(Equal(decodedEvent{tick: 0, data: []byte{meta, metaTempo, 3, 0x0F, 0x42, 0x40}}))
+
+	chanter := f.tracks[1]
+	g.Expect(chanter[1].data).To(Equal([]byte{programChange | chanterChannel, bagpipeProgram}))
+	// the high G gracenote, the low A and the E of the chanter
+	g.Expect(notesOf(chanter)).To(Equal([]byte{80, 71, 78}))
+	g.Expect(chanter[2:]).To(Equal([]decodedEvent{
+		{tick: 0, data: []byte{pitchBend, 8989 & 0x7F, 8989 >> 7}},
+		{tick: 0, data: []byte{noteOn, 80, graceVelocity}},
+		{tick: 19, data: []byte{noteOff, 80, 0}},
+		{tick: 19, data: []byte{pitchBend, 6170 & 0x7F, 6170 >> 7}},
+		{tick: 19, data: []byte{noteOn, 71, melodyVelocity}},
+		{tick: 480, data: []byte{noteOff, 71, 0}},
+		{tick: 480, data: []byte{pitchBend, 6250 & 0x7F, 6250 >> 7}},
+		{tick: 480, data: []byte{noteOn, 78, melodyVelocity}},
+		{tick: 960, data: []byte{noteOff, 78, 0}},
+		{tick: 960, data: []byte{meta, metaEndTrack, 0}},
+	}))
+
+	drones := f.tracks[2]
+	g.Expect(drones[1].data).To(Equal([]byte{programChange | droneChannel, bagpipeProgram}))
+	// the tenor and bass drone sound the low A one and two octaves lower
+	g.Expect(notesOf(drones)).To(Equal([]byte{59, 47}))
+	g.Expect(drones[len(drones)-2]).To(Equal(decodedEvent{tick: 960, data: []byte{noteOff | droneChannel, 47, 0}}))
+}
+
+func TestRenderWithTempo(t *testing.T) {
+	g := NewWithT(t)
+
+	f := decode(g, Render(testTune(), 120))
+
+	// 120 quarter notes per minute are 500000 microseconds per quarter note
+	g.Expect(f.tracks[0][1].data).To(Equal([]byte{meta, metaTempo, 3, 0x07, 0xA1, 0x20}))
+	// the gracenote takes the same time at a faster tempo, which are more ticks
+	g.Expect(f.tracks[1][4].tick).To(Equal(uint32(38)))
+	g.Expect(f.tracks[1][len(f.tracks[1])-1].tick).To(Equal(uint32(960)))
+}
+
+func TestVarLen(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(varLen(0)).To(Equal([]byte{0}))
+	g.Expect(varLen(0x7F)).To(Equal([]byte{0x7F}))
+	g.Expect(varLen(0x80)).To(Equal([]byte{0x81, 0x00}))
+	g.Expect(varLen(0x0FFFFFFF)).To(Equal([]byte{0xFF, 0xFF, 0xFF, 0x7F}))
+}
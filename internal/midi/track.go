@@ -0,0 +1,90 @@
+package midi
+
+import "sort"
+
+// status bytes of the channel messages
+const (
+	noteOff       = 0x80
+	noteOn        = 0x90
+	programChange = 0xC0
+	pitchBend     = 0xE0
+)
+
+// types of the meta events
+const (
+	meta          = 0xFF
+	metaTrackName = 0x03
+	metaEndTrack  = 0x2F
+	metaTempo     = 0x51
+)
+
+// event is a message of a track at a tick.
+type event struct {
+	tick uint32
+	data []byte
+}
+
+// note is a note of a track from its start to its end tick.
+type note struct {
+	start    uint32
+	end      uint32
+	freq     float64
+	velocity byte
+}
+
+// track collects the events of a track chunk
+// with the channel of its notes.
+type track struct {
+	channel byte
+	events  []event
+}
+
+func (tr *track) add(tick uint32, data ...byte) {
+	tr.events = append(tr.events, event{tick: tick, data: data})
+}
+
+func (tr *track) addMeta(tick uint32, metaType byte, data ...byte) {
+	msg := append([]byte{meta, metaType}, varLen(uint32(len(data)))...)
+	tr.add(tick, append(msg, data...)...)
+}
+
+func (tr *track) addText(tick uint32, metaType byte, text string) {
+	tr.addMeta(tick, metaType, []byte(text)...)
+}
+
+// addNote adds the note, which is tuned by a pitch bend before it starts.
+func (tr *track) addNote(n note) {
+	k, bend := key(n.freq)
+	tr.add(n.start, pitchBend|tr.channel, byte(bend&0x7F), byte(bend>>7&0x7F))
+	tr.add(n.start, noteOn|tr.channel, k, n.velocity)
+	tr.add(n.end, noteOff|tr.channel, k, 0)
+}
+
+// bytes returns the events ordered by their tick with delta times.
+// Events of the same tick keep the order in which they were added.
+func (tr *track) bytes() []byte {
+	sort.SliceStable(tr.events, func(i, j int) bool {
+		return tr.events[i].tick < tr.events[j].tick
+	})
+
+	var data []byte
+	last := uint32(0)
+	for _, e := range tr.events {
+		data = append(data, varLen(e.tick-last)...)
+		data = append(data, e.data...)
+		last = e.tick
+	}
+
+	return append(data, 0, meta, metaEndTrack, 0)
+}
+
+// varLen returns the value as variable length quantity
+// with 7 bits per byte and the most significant byte first.
+func varLen(v uint32) []byte {
+	data := []byte{byte(v & 0x7F)}
+	for v >>= 7; v > 0; v >>= 7 {
+		data = append([]byte{byte(v&0x7F) | 0x80}, data...)
+	}
+
+	return data
+}
@@ -0,0 +1,169 @@
+package playback
+
+import (
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/pitch"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/embellishment"
+)
+
+type graceFunc func(e *embellishment.Embellishment, melody pitch.Pitch) []pitch.Pitch
+
+// embellishmentGraces return the gracenotes of the embellishments
+// on a melody note.
+var embellishmentGraces = map[embellishment.Type]graceFunc{
+	embellishment.Type_SingleGrace: singleGrace,
+	embellishment.Type_DoubleGrace: singleGrace,
+	embellishment.Type_Doubling:    doubling,
+	embellishment.Type_Strike: func(e *embellishment.Embellishment, melody pitch.Pitch) []pitch.Pitch {
+		return append(variantGraces(e.Variant, melody), strikePitch(e, melody))
+	},
+	embellishment.Type_DoubleStrike: func(e *embellishment.Embellishment, melody pitch.Pitch) []pitch.Pitch {
+		lower := strikePitch(e, melody)
+		return append(variantGraces(e.Variant, melody), lower, melody, lower)
+	},
+	embellishment.Type_TripleStrike:      tripleStrike(nil),
+	embellishment.Type_GTripleStrike:     tripleStrike([]pitch.Pitch{pitch.Pitch_HighG}),
+	embellishment.Type_ThumbTripleStrike: tripleStrike([]pitch.Pitch{pitch.Pitch_HighA}),
+	embellishment.Type_HalfTripleStrike:  tripleStrike(nil),
+	embellishment.Type_Grip: func(_ *embellishment.Embellishment, melody pitch.Pitch) []pitch.Pitch {
+		return grip(melody)
+	},
+	embellishment.Type_Taorluath: func(_ *embellishment.Embellishment, melody pitch.Pitch) []pitch.Pitch {
+		return append(grip(melody), pitch.Pitch_E)
+	},
+	embellishment.Type_Bubbly: fixedGraces(pitch.Pitch_LowG, pitch.Pitch_D, pitch.Pitch_LowG, pitch.Pitch_C, pitch.Pitch_LowG),
+	embellishment.Type_Birl:   fixedGraces(pitch.Pitch_LowG, pitch.Pitch_LowA, pitch.Pitch_LowG),
+	embellishment.Type_ABirl:  fixedGraces(pitch.Pitch_LowA, pitch.Pitch_LowG, pitch.Pitch_LowA, pitch.Pitch_LowG),
+	embellishment.Type_GraceBirl: fixedGraces(
+		pitch.Pitch_HighG, pitch.Pitch_LowA, pitch.Pitch_LowG, pitch.Pitch_LowA, pitch.Pitch_LowG,
+	),
+	embellishment.Type_ThrowD: throwD,
+	embellishment.Type_Pele:   pele,
+}
+
+// doublingUpper are the pitches of the last gracenote of a doubling on a melody note.
+var doublingUpper = map[pitch.Pitch]pitch.Pitch{
+	pitch.Pitch_LowG:  pitch.Pitch_D,
+	pitch.Pitch_LowA:  pitch.Pitch_D,
+	pitch.Pitch_B:     pitch.Pitch_D,
+	pitch.Pitch_C:     pitch.Pitch_D,
+	pitch.Pitch_D:     pitch.Pitch_E,
+	pitch.Pitch_E:     pitch.Pitch_F,
+	pitch.Pitch_F:     pitch.Pitch_HighG,
+	pitch.Pitch_HighG: pitch.Pitch_F,
+	pitch.Pitch_HighA: pitch.Pitch_HighG,
+}
+
+// strikeLower are the pitches of the gracenote of a strike on a melody note.
+var strikeLower = map[pitch.Pitch]pitch.Pitch{
+	pitch.Pitch_LowG:  pitch.Pitch_LowG,
+	pitch.Pitch_LowA:  pitch.Pitch_LowG,
+	pitch.Pitch_B:     pitch.Pitch_LowG,
+	pitch.Pitch_C:     pitch.Pitch_LowG,
+	pitch.Pitch_D:     pitch.Pitch_LowG,
+	pitch.Pitch_E:     pitch.Pitch_LowA,
+	pitch.Pitch_F:     pitch.Pitch_E,
+	pitch.Pitch_HighG: pitch.Pitch_F,
+	pitch.Pitch_HighA: pitch.Pitch_HighG,
+}
+
+// GracePitches returns the pitches of the gracenotes of the embellishment
+// and movement of the note when they are played on a melody note.
+// Movements are played with the pitches they list.
+func GracePitches(n *symbols.Note, melody pitch.Pitch) []pitch.Pitch {
+	var graces []pitch.Pitch
+	if e := n.Embellishment; e != nil && embellishmentGraces[e.Type] != nil {
+		graces = embellishmentGraces[e.Type](e, melody)
+	}
+	if m := n.Movement; m != nil {
+		graces = append(graces, m.Pitches...)
+	}
+
+	return graces
+}
+
+func singleGrace(e *embellishment.Embellishment, _ pitch.Pitch) []pitch.Pitch {
+	if e.Pitch == pitch.Pitch_NoPitch {
+		return nil
+	}
+
+	return []pitch.Pitch{e.Pitch}
+}
+
+func fixedGraces(graces ...pitch.Pitch) graceFunc {
+	return func(_ *embellishment.Embellishment, _ pitch.Pitch) []pitch.Pitch {
+		return graces
+	}
+}
+
+// variantGraces returns the gracenotes before the gracenotes of embellishments
+// like strikes, which are a high G or thumb gracenote for the G and thumb variants.
+func variantGraces(v embellishment.Variant, melody pitch.Pitch) []pitch.Pitch {
+	switch {
+	case v == embellishment.Variant_G && melody < pitch.Pitch_HighG:
+		return []pitch.Pitch{pitch.Pitch_HighG}
+	case v == embellishment.Variant_Thumb && melody < pitch.Pitch_HighA:
+		return []pitch.Pitch{pitch.Pitch_HighA}
+	}
+
+	return nil
+}
+
+// doubling returns the gracenotes of a doubling, which starts with a high G
+// gracenote unless it is a half or thumb doubling or on high G or high A.
+func doubling(e *embellishment.Embellishment, melody pitch.Pitch) []pitch.Pitch {
+	variant := e.Variant
+	if variant == embellishment.Variant_NoVariant {
+		variant = embellishment.Variant_G
+	}
+
+	return append(variantGraces(variant, melody), melody, doublingUpper[melody])
+}
+
+func strikePitch(e *embellishment.Embellishment, melody pitch.Pitch) pitch.Pitch {
+	if e.Pitch != pitch.Pitch_NoPitch {
+		return e.Pitch
+	}
+
+	return strikeLower[melody]
+}
+
+func tripleStrike(before []pitch.Pitch) graceFunc {
+	return func(e *embellishment.Embellishment, melody pitch.Pitch) []pitch.Pitch {
+		lower := strikePitch(e, melody)
+		return append(append([]pitch.Pitch{}, before...), lower, melody, lower, melody, lower)
+	}
+}
+
+// grip returns the gracenotes of a grip, which uses a B instead of a D on D.
+func grip(melody pitch.Pitch) []pitch.Pitch {
+	if melody == pitch.Pitch_D {
+		return []pitch.Pitch{pitch.Pitch_LowG, pitch.Pitch_B, pitch.Pitch_LowG}
+	}
+
+	return []pitch.Pitch{pitch.Pitch_LowG, pitch.Pitch_D, pitch.Pitch_LowG}
+}
+
+func throwD(e *embellishment.Embellishment, _ pitch.Pitch) []pitch.Pitch {
+	if e.Weight == embellishment.Weight_Heavy {
+		return []pitch.Pitch{pitch.Pitch_LowG, pitch.Pitch_D, pitch.Pitch_LowG, pitch.Pitch_C}
+	}
+
+	return []pitch.Pitch{pitch.Pitch_LowG, pitch.Pitch_D, pitch.Pitch_C}
+}
+
+// pele returns the gracenotes of a pele, which starts with a high G gracenote
+// and the melody note unless it is a half pele.
+func pele(e *embellishment.Embellishment, melody pitch.Pitch) []pitch.Pitch {
+	graces := []pitch.Pitch{doublingUpper[melody], melody, strikeLower[melody]}
+	if e.Variant == embellishment.Variant_Half {
+		return graces
+	}
+
+	variant := e.Variant
+	if variant == embellishment.Variant_NoVariant {
+		variant = embellishment.Variant_G
+	}
+
+	return append(append(variantGraces(variant, melody), melody), graces...)
+}
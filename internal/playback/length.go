@@ -0,0 +1,51 @@
+package playback
+
+import (
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/boundary"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/length"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/measure"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/tuplet"
+)
+
+// quarterLengths are the lengths of notes in quarter notes.
+var quarterLengths = map[length.Length]float64{
+	length.Length_Whole:        4,
+	length.Length_Half:         2,
+	length.Length_Quarter:      1,
+	length.Length_Eighth:       0.5,
+	length.Length_Sixteenth:    0.25,
+	length.Length_Thirtysecond: 0.125,
+}
+
+// Quarters returns the length of a note or rest with the number of dots
+// in quarter notes.
+func Quarters(l length.Length, dots uint32) float64 {
+	return quarterLengths[l] * (2 - 1/float64(uint32(1)<<dots))
+}
+
+// TupletFactor returns the factor of the lengths of the notes after the tuplet
+// symbol. At the start of a tuplet, the visible notes take the length of the
+// played notes and at its end the notes get their normal length back.
+func TupletFactor(t *tuplet.Tuplet) float64 {
+	if t.BoundaryType != boundary.Boundary_Start || t.PlayedNotes == 0 || t.VisibleNotes == 0 {
+		return 1
+	}
+
+	return float64(t.PlayedNotes) / float64(t.VisibleNotes)
+}
+
+// BeatQuarters returns the length of a beat of the time signature in quarter
+// notes, which is three eighth notes for compound time signatures like 6/8.
+// It returns 0 for time signatures without beat type.
+func BeatQuarters(ts *measure.TimeSignature) float64 {
+	if ts.BeatType == 0 {
+		return 0
+	}
+
+	beat := 4 / float64(ts.BeatType)
+	if ts.BeatType >= 8 && ts.Beats%3 == 0 {
+		beat *= 3
+	}
+
+	return beat
+}
@@ -0,0 +1,308 @@
+// Package playback turns the music model of a tune into the notes of the
+// chanter as they are played, with their start and duration in seconds.
+// Renderers like the MIDI renderer write these notes into their format.
+package playback
+
+import (
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/barline"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/boundary"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/length"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/measure"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/pitch"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/tie"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/timeline"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+)
+
+// DefaultTempo are the beats per minute of tunes without a tempo.
+const DefaultTempo = 80
+
+// GraceDuration is the duration of a single gracenote in seconds.
+const GraceDuration = 0.04
+
+// maxGraceShare is the maximum share of a melody note
+// that its gracenotes may take.
+const maxGraceShare = 0.5
+
+// Note is a note of the chanter as it is played.
+type Note struct {
+	Pitch pitch.Pitch
+	// Start is the time of the note in seconds from the start of the tune
+	// and Duration its length in seconds.
+	Start    float64
+	Duration float64
+	// Grace is true for the gracenotes of embellishments and movements.
+	Grace bool
+}
+
+// Tempo is the tempo of the performance from its start time on.
+type Tempo struct {
+	Start float64
+	// BPM are the beats per minute and QuarterBPM the quarter notes per minute.
+	BPM        float64
+	QuarterBPM float64
+}
+
+// Performance is a tune as it is played.
+type Performance struct {
+	Notes []Note
+	// Tempos are the tempos of the performance, the first one starts at 0.
+	Tempos []Tempo
+	// Duration is the length of the performance in seconds.
+	Duration float64
+}
+
+// step is a measure of the tune in the order in which it is played.
+type step struct {
+	measure    int
+	secondPass bool
+}
+
+// player walks through the measures of a tune and collects its performance.
+type player struct {
+	perf *Performance
+
+	// tempo are the beats per minute, where a beat is a quarter note for simple
+	// and three eighth notes for compound time signatures like 6/8,
+	// tempoScale scales the tempo of the tune to the requested tempo
+	tempo        float64
+	tempoScale   float64
+	beat         float64
+	tupletFactor float64
+	seconds      float64
+
+	// ornaments are the embellishments and movements waiting for the melody
+	// note they are played on
+	ornaments  []*symbols.Note
+	tied       bool
+	ending     timeline.Type
+	secondPass bool
+}
+
+// Play returns the performance of the tune. Repeated parts are played twice
+// and first endings are skipped on the second pass. If tempo is not 0, it
+// replaces the tempo of the tune and the tempo changes in the tune are
+// scaled by the same factor.
+func Play(t *tune.Tune, tempo uint32) *Performance {
+	speed := 1.0
+	if tempo != 0 {
+		speed = float64(tempo) / tuneTempo(t)
+	}
+
+	return PlayAtSpeed(t, speed)
+}
+
+// PlayAtSpeed returns the performance of the tune with all tempos of
+// the tune scaled by the speed, e.g. 0.5 plays the tune at half its tempo.
+// Gracenotes keep their duration.
+func PlayAtSpeed(t *tune.Tune, speed float64) *Performance {
+	p := &player{
+		perf:         &Performance{},
+		tempoScale:   speed,
+		beat:         1,
+		tupletFactor: 1,
+	}
+	p.setTempo(tuneTempo(t))
+	for _, s := range playOrder(t.Measures) {
+		p.secondPass = s.secondPass
+		p.playMeasure(t.Measures[s.measure])
+	}
+	p.perf.Duration = p.seconds
+
+	return p.perf
+}
+
+// Append appends the other performance, so it starts when this one ends.
+func (perf *Performance) Append(other *Performance) {
+	for _, n := range other.Notes {
+		n.Start += perf.Duration
+		perf.Notes = append(perf.Notes, n)
+	}
+	for _, t := range other.Tempos {
+		t.Start += perf.Duration
+		perf.Tempos = append(perf.Tempos, t)
+	}
+	perf.Duration += other.Duration
+}
+
+func tuneTempo(t *tune.Tune) float64 {
+	if t.Tempo == 0 {
+		return DefaultTempo
+	}
+
+	return float64(t.Tempo)
+}
+
+// playOrder returns the measures in the order in which they are played.
+// A repeat starts at a repeat barline or at the start of a part.
+func playOrder(measures []*measure.Measure) []step {
+	var order []step
+	repeatStart := 0
+	secondPass := false
+	repeated := map[int]bool{}
+	for i := 0; i < len(measures); i++ {
+		if i > repeatStart && startsRepeat(measures, i) {
+			repeatStart = i
+			secondPass = false
+		}
+		order = append(order, step{measure: i, secondPass: secondPass})
+		if endsRepeat(measures[i]) && !repeated[i] {
+			repeated[i] = true
+			i = repeatStart - 1
+			secondPass = true
+		}
+	}
+
+	return order
+}
+
+func startsRepeat(measures []*measure.Measure, i int) bool {
+	m := measures[i]
+	if m.LeftBarline != nil && m.LeftBarline.Time == barline.Time_Repeat {
+		return true
+	}
+
+	return isHeavy(m.LeftBarline) || isHeavy(measures[i-1].RightBarline)
+}
+
+func endsRepeat(m *measure.Measure) bool {
+	return m.RightBarline != nil && m.RightBarline.Time == barline.Time_Repeat
+}
+
+func isHeavy(b *barline.Barline) bool {
+	return b != nil && b.Type != barline.Type_Regular
+}
+
+func (p *player) playMeasure(m *measure.Measure) {
+	if m.Time != nil {
+		p.setTime(m.Time)
+	}
+	for _, s := range m.Symbols {
+		p.playSymbol(s)
+	}
+}
+
+func (p *player) playSymbol(s *symbols.Symbol) {
+	if s.TempoChange != nil {
+		p.setTempo(float64(*s.TempoChange))
+	}
+	if s.Tuplet != nil {
+		p.tupletFactor = TupletFactor(s.Tuplet)
+	}
+	if s.Timeline != nil {
+		p.setEnding(s.Timeline)
+	}
+	if p.secondPass && p.ending == timeline.Type_First {
+		return
+	}
+
+	switch {
+	case s.Note != nil:
+		p.playNote(s.Note)
+	case s.Rest != nil:
+		p.seconds += p.noteSeconds(s.Rest.Length, 0)
+	}
+}
+
+// setEnding remembers the type of the timeline from its start to its end.
+func (p *player) setEnding(tl *timeline.TimeLine) {
+	p.ending = timeline.Type_NoType
+	if tl.BoundaryType == boundary.Boundary_Start {
+		p.ending = tl.Type
+	}
+}
+
+// playNote plays a melody note with the gracenotes of the ornaments before it.
+// Ornaments without a melody note are played on the next melody note and
+// a tied note of the same pitch extends the previous note.
+func (p *player) playNote(n *symbols.Note) {
+	if n.Embellishment != nil || n.Movement != nil {
+		p.ornaments = append(p.ornaments, n)
+	}
+	if !n.IsValid() {
+		p.tied = p.tied || n.Tie == tie.Tie_Start
+		return
+	}
+
+	duration := p.noteSeconds(n.Length, n.Dots)
+	graces := p.takeGraces(n.Pitch)
+	tied := p.tied
+	p.tied = n.Tie == tie.Tie_Start
+	if tied && len(graces) == 0 && p.extendLast(n.Pitch, duration) {
+		return
+	}
+	p.addNotes(graces, n.Pitch, duration)
+}
+
+// addNotes adds the gracenotes and the melody note that takes the duration
+// together with its gracenotes.
+func (p *player) addNotes(graces []pitch.Pitch, melody pitch.Pitch, duration float64) {
+	graceDuration := min(GraceDuration, duration*maxGraceShare/float64(max(len(graces), 1)))
+	start := p.seconds
+	for _, g := range graces {
+		p.perf.Notes = append(p.perf.Notes, Note{Pitch: g, Start: start, Duration: graceDuration, Grace: true})
+		start += graceDuration
+	}
+	p.perf.Notes = append(p.perf.Notes, Note{Pitch: melody, Start: start, Duration: p.seconds + duration - start})
+	p.seconds += duration
+}
+
+// takeGraces returns the gracenotes of the waiting ornaments
+// on a melody note of the pitch.
+func (p *player) takeGraces(melody pitch.Pitch) []pitch.Pitch {
+	var graces []pitch.Pitch
+	for _, o := range p.ornaments {
+		graces = append(graces, GracePitches(o, melody)...)
+	}
+	p.ornaments = nil
+
+	return graces
+}
+
+// extendLast extends the last note by the duration if it has the pitch.
+func (p *player) extendLast(pi pitch.Pitch, duration float64) bool {
+	last := len(p.perf.Notes) - 1
+	if last < 0 || p.perf.Notes[last].Pitch != pi {
+		return false
+	}
+	p.perf.Notes[last].Duration += duration
+	p.seconds += duration
+
+	return true
+}
+
+// noteSeconds returns the duration of a note or rest in seconds.
+func (p *player) noteSeconds(l length.Length, dots uint32) float64 {
+	return Quarters(l, dots) * p.tupletFactor / p.beat * 60 / p.tempo
+}
+
+// setTempo sets the beats per minute of the tune from the current time on.
+func (p *player) setTempo(bpm float64) {
+	p.tempo = bpm * p.tempoScale
+	p.addTempo()
+}
+
+// addTempo adds the current tempo to the performance. A tempo that starts
+// at the same time as the previous one replaces it.
+func (p *player) addTempo() {
+	t := Tempo{Start: p.seconds, BPM: p.tempo, QuarterBPM: p.tempo * p.beat}
+	last := len(p.perf.Tempos) - 1
+	if last >= 0 && p.perf.Tempos[last].Start == t.Start {
+		p.perf.Tempos[last] = t
+		return
+	}
+	if last >= 0 && p.perf.Tempos[last].QuarterBPM == t.QuarterBPM {
+		return
+	}
+	p.perf.Tempos = append(p.perf.Tempos, t)
+}
+
+// setTime sets the length of a beat for the time signature.
+func (p *player) setTime(ts *measure.TimeSignature) {
+	p.tupletFactor = 1
+	if beat := BeatQuarters(ts); beat > 0 {
+		p.beat = beat
+		p.addTempo()
+	}
+}
@@ -0,0 +1,250 @@
+package playback
+
+import (
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/barline"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/boundary"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/length"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/measure"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/pitch"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/embellishment"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/tie"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/timeline"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/tuplet"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"math"
+	"testing"
+)
+
+func note(p pitch.Pitch, l length.Length) *symbols.Symbol {
+	return &symbols.Symbol{Note: &symbols.Note{Pitch: p, Length: l}}
+}
+
+func ending(t timeline.Type, b boundary.Boundary) *symbols.Symbol {
+	return &symbols.Symbol{Timeline: &timeline.TimeLine{Type: t, BoundaryType: b}}
+}
+
+// rounded returns the notes with their times rounded to milliseconds.
+func rounded(notes []Note) []Note {
+	r := make([]Note, len(notes))
+	for i, n := range notes {
+		n.Start = math.Round(n.Start*1000) / 1000
+		n.Duration = math.Round(n.Duration*1000) / 1000
+		r[i] = n
+	}
+
+	return r
+}
+
+func pitchesOf(notes []Note) []pitch.Pitch {
+	var pitches []pitch.Pitch
+	for _, n := range notes {
+		pitches = append(pitches, n.Pitch)
+	}
+
+	return pitches
+}
+
+func repeatedTune() *tune.Tune {
+	return &tune.Tune{
+		Tempo: 60,
+		Measures: []*measure.Measure{
+			{
+				LeftBarline: &barline.Barline{Type: barline.Type_HeavyLight, Time: barline.Time_Repeat},
+				Time:        &measure.TimeSignature{Beats: 2, BeatType: 4},
+				Symbols: []*symbols.Symbol{
+					{Note: &symbols.Note{Embellishment: &embellishment.Embellishment{Type: embellishment.Type_Doubling}}},
+					note(pitch.Pitch_LowA, length.Length_Quarter),
+					{Note: &symbols.Note{Pitch: pitch.Pitch_B, Length: length.Length_Eighth, Tie: tie.Tie_Start}},
+				},
+			},
+			{
+				RightBarline: &barline.Barline{Type: barline.Type_LightHeavy, Time: barline.Time_Repeat},
+				Symbols: []*symbols.Symbol{
+					{Note: &symbols.Note{Pitch: pitch.Pitch_B, Length: length.Length_Eighth, Tie: tie.Tie_End}},
+					note(pitch.Pitch_C, length.Length_Eighth),
+					note(pitch.Pitch_D, length.Length_Eighth),
+				},
+			},
+		},
+	}
+}
+
+func TestPlay(t *testing.T) {
+	g := NewWithT(t)
+
+	perf := Play(repeatedTune(), 0)
+
+	g.Expect(perf.Duration).To(BeNumerically("~", 6, 1e-9))
+	g.Expect(perf.Tempos).To(Equal([]Tempo{{Start: 0, BPM: 60, QuarterBPM: 60}}))
+	g.Expect(perf.Notes).To(HaveLen(14))
+	g.Expect(rounded(perf.Notes[:7])).To(Equal([]Note{
+		{Pitch: pitch.Pitch_HighG, Start: 0, Duration: 0.04, Grace: true},
+		{Pitch: pitch.Pitch_LowA, Start: 0.04, Duration: 0.04, Grace: true},
+		{Pitch: pitch.Pitch_D, Start: 0.08, Duration: 0.04, Grace: true},
+		{Pitch: pitch.Pitch_LowA, Start: 0.12, Duration: 0.88},
+		{Pitch: pitch.Pitch_B, Start: 1, Duration: 1},
+		{Pitch: pitch.Pitch_C, Start: 2, Duration: 0.5},
+		{Pitch: pitch.Pitch_D, Start: 2.5, Duration: 0.5},
+	}))
+	g.Expect(perf.Notes[7].Start).To(BeNumerically("~", 3, 1e-9))
+	g.Expect(pitchesOf(perf.Notes[7:])).To(Equal(pitchesOf(perf.Notes[:7])))
+}
+
+func TestPlaySkipsFirstEndingOnSecondPass(t *testing.T) {
+	g := NewWithT(t)
+	tn := &tune.Tune{Measures: []*measure.Measure{
+		{
+			LeftBarline: &barline.Barline{Time: barline.Time_Repeat},
+			Symbols:     []*symbols.Symbol{note(pitch.Pitch_LowA, length.Length_Quarter)},
+		},
+		{
+			RightBarline: &barline.Barline{Type: barline.Type_LightHeavy, Time: barline.Time_Repeat},
+			Symbols: []*symbols.Symbol{
+				ending(timeline.Type_First, boundary.Boundary_Start),
+				note(pitch.Pitch_B, length.Length_Quarter),
+				ending(timeline.Type_First, boundary.Boundary_End),
+			},
+		},
+		{
+			Symbols: []*symbols.Symbol{
+				ending(timeline.Type_Second, boundary.Boundary_Start),
+				note(pitch.Pitch_C, length.Length_Quarter),
+				ending(timeline.Type_Second, boundary.Boundary_End),
+			},
+		},
+	}}
+
+	perf := Play(tn, 0)
+
+	g.Expect(pitchesOf(perf.Notes)).To(Equal([]pitch.Pitch{
+		pitch.Pitch_LowA, pitch.Pitch_B, pitch.Pitch_LowA, pitch.Pitch_C,
+	}))
+}
+
+func TestPlayTempo(t *testing.T) {
+	g := NewWithT(t)
+	tempoChange := uint64(120)
+	tn := &tune.Tune{Measures: []*measure.Measure{
+		{
+			Time: &measure.TimeSignature{Beats: 6, BeatType: 8},
+			Symbols: []*symbols.Symbol{
+				{Note: &symbols.Note{Pitch: pitch.Pitch_E, Length: length.Length_Quarter, Dots: 1}},
+				{TempoChange: &tempoChange},
+				{Rest: &symbols.Rest{Length: length.Length_Quarter}},
+				note(pitch.Pitch_F, length.Length_Eighth),
+			},
+		},
+	}}
+
+	perf := Play(tn, 0)
+	g.Expect(perf.Tempos).To(Equal([]Tempo{
+		{Start: 0, BPM: DefaultTempo, QuarterBPM: DefaultTempo * 1.5},
+		{Start: 0.75, BPM: 120, QuarterBPM: 180},
+	}))
+	g.Expect(perf.Duration).To(BeNumerically("~", 1.25, 1e-9))
+
+	// the tempo of the tune is doubled, so the tempo change is doubled as well
+	perf = Play(tn, DefaultTempo*2)
+	g.Expect(perf.Tempos).To(Equal([]Tempo{
+		{Start: 0, BPM: DefaultTempo * 2, QuarterBPM: DefaultTempo * 3},
+		{Start: 0.375, BPM: 240, QuarterBPM: 360},
+	}))
+	g.Expect(perf.Duration).To(BeNumerically("~", 0.625, 1e-9))
+	g.Expect(PlayAtSpeed(tn, 2)).To(Equal(perf))
+}
+
+func TestAppend(t *testing.T) {
+	g := NewWithT(t)
+
+	perf := Play(repeatedTune(), 0)
+	perf.Append(Play(repeatedTune(), 120))
+
+	g.Expect(perf.Duration).To(BeNumerically("~", 9, 1e-9))
+	g.Expect(perf.Notes).To(HaveLen(28))
+	g.Expect(perf.Notes[14].Start).To(BeNumerically("~", 6, 1e-9))
+	g.Expect(perf.Tempos).To(Equal([]Tempo{
+		{Start: 0, BPM: 60, QuarterBPM: 60},
+		{Start: 6, BPM: 120, QuarterBPM: 120},
+	}))
+}
+
+func TestPlayShortensGracenotesOfShortNotes(t *testing.T) {
+	g := NewWithT(t)
+	tn := &tune.Tune{Tempo: 120, Measures: []*measure.Measure{
+		{Symbols: []*symbols.Symbol{
+			{Note: &symbols.Note{
+				Pitch:         pitch.Pitch_LowA,
+				Length:        length.Length_Thirtysecond,
+				Embellishment: &embellishment.Embellishment{Type: embellishment.Type_GraceBirl},
+			}},
+		}},
+	}}
+
+	perf := Play(tn, 0)
+
+	g.Expect(perf.Notes).To(HaveLen(6))
+	for _, n := range perf.Notes[:5] {
+		g.Expect(n.Grace).To(BeTrue())
+		g.Expect(n.Duration).To(BeNumerically("~", 0.0625/2/5, 1e-9))
+	}
+	g.Expect(perf.Notes[5].Duration).To(BeNumerically("~", 0.0625/2, 1e-9))
+}
+
+func TestGracePitches(t *testing.T) {
+	g := NewWithT(t)
+	embell := func(t embellishment.Type, v embellishment.Variant) *symbols.Note {
+		return &symbols.Note{Embellishment: &embellishment.Embellishment{Type: t, Variant: v}}
+	}
+
+	g.Expect(GracePitches(embell(embellishment.Type_Doubling, embellishment.Variant_NoVariant), pitch.Pitch_E)).
+		To(Equal([]pitch.Pitch{pitch.Pitch_HighG, pitch.Pitch_E, pitch.Pitch_F}))
+	g.Expect(GracePitches(embell(embellishment.Type_Doubling, embellishment.Variant_Half), pitch.Pitch_B)).
+		To(Equal([]pitch.Pitch{pitch.Pitch_B, pitch.Pitch_D}))
+	g.Expect(GracePitches(embell(embellishment.Type_Doubling, embellishment.Variant_Thumb), pitch.Pitch_HighG)).
+		To(Equal([]pitch.Pitch{pitch.Pitch_HighA, pitch.Pitch_HighG, pitch.Pitch_F}))
+	g.Expect(GracePitches(embell(embellishment.Type_Strike, embellishment.Variant_G), pitch.Pitch_E)).
+		To(Equal([]pitch.Pitch{pitch.Pitch_HighG, pitch.Pitch_LowA}))
+	g.Expect(GracePitches(embell(embellishment.Type_Taorluath, embellishment.Variant_NoVariant), pitch.Pitch_D)).
+		To(Equal([]pitch.Pitch{pitch.Pitch_LowG, pitch.Pitch_B, pitch.Pitch_LowG, pitch.Pitch_E}))
+	g.Expect(GracePitches(embell(embellishment.Type_Pele, embellishment.Variant_NoVariant), pitch.Pitch_E)).
+		To(Equal([]pitch.Pitch{pitch.Pitch_HighG, pitch.Pitch_E, pitch.Pitch_F, pitch.Pitch_E, pitch.Pitch_LowA}))
+	g.Expect(GracePitches(&symbols.Note{Embellishment: &embellishment.Embellishment{
+		Type:  embellishment.Type_SingleGrace,
+		Pitch: pitch.Pitch_D,
+	}}, pitch.Pitch_C)).To(Equal([]pitch.Pitch{pitch.Pitch_D}))
+}
+
+func TestFrequency(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(Frequency(pitch.Pitch_LowA)).To(Equal(ChanterA))
+	g.Expect(Frequency(pitch.Pitch_HighA)).To(Equal(2 * ChanterA))
+	g.Expect(Frequency(pitch.Pitch_E)).To(Equal(720.0))
+	g.Expect(Frequency(pitch.Pitch_NoPitch)).To(BeZero())
+}
+
+func TestQuarters(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(Quarters(length.Length_Half, 0)).To(Equal(2.0))
+	g.Expect(Quarters(length.Length_Quarter, 1)).To(Equal(1.5))
+	g.Expect(Quarters(length.Length_Eighth, 2)).To(Equal(0.875))
+	g.Expect(Quarters(length.Length_NoLength, 0)).To(BeZero())
+}
+
+func TestTupletFactor(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(TupletFactor(&tuplet.Tuplet{BoundaryType: boundary.Boundary_Start, VisibleNotes: 3, PlayedNotes: 2})).To(BeNumerically("~", 2.0/3, 1e-9))
+	g.Expect(TupletFactor(&tuplet.Tuplet{BoundaryType: boundary.Boundary_End, VisibleNotes: 3, PlayedNotes: 2})).To(Equal(1.0))
+	g.Expect(TupletFactor(&tuplet.Tuplet{BoundaryType: boundary.Boundary_Start})).To(Equal(1.0))
+}
+
+func TestBeatQuarters(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(BeatQuarters(&measure.TimeSignature{Beats: 2, BeatType: 4})).To(Equal(1.0))
+	g.Expect(BeatQuarters(&measure.TimeSignature{Beats: 2, BeatType: 2})).To(Equal(2.0))
+	g.Expect(BeatQuarters(&measure.TimeSignature{Beats: 6, BeatType: 8})).To(Equal(1.5))
+	g.Expect(BeatQuarters(&measure.TimeSignature{Beats: 5, BeatType: 8})).To(Equal(0.5))
+	g.Expect(BeatQuarters(&measure.TimeSignature{})).To(BeZero())
+}
@@ -0,0 +1,33 @@
+package playback
+
+import "github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/pitch"
+
+// ChanterA is the frequency of the low A of the chanter in Hz. Modern
+// chanters are pitched well above concert pitch, which makes their
+// low A sound close to a B flat.
+const ChanterA = 480.0
+
+// The drones sound the A of the chanter one and two octaves lower.
+const (
+	TenorDroneFrequency = ChanterA / 2
+	BassDroneFrequency  = ChanterA / 4
+)
+
+// chanterRatios are the just intonation ratios of the notes of the chanter to its low A.
+var chanterRatios = map[pitch.Pitch]float64{
+	pitch.Pitch_LowG:  7.0 / 8,
+	pitch.Pitch_LowA:  1,
+	pitch.Pitch_B:     9.0 / 8,
+	pitch.Pitch_C:     5.0 / 4,
+	pitch.Pitch_D:     4.0 / 3,
+	pitch.Pitch_E:     3.0 / 2,
+	pitch.Pitch_F:     5.0 / 3,
+	pitch.Pitch_HighG: 7.0 / 4,
+	pitch.Pitch_HighA: 2,
+}
+
+// Frequency returns the frequency in Hz of the pitch on the chanter
+// or 0 for no pitch.
+func Frequency(p pitch.Pitch) float64 {
+	return ChanterA * chanterRatios[p]
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
 	plugininterfaces "github.com/tomvodi/limepipes-plugin-api/plugin/v1/interfaces"
 	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/messages"
+	"github.com/tomvodi/limepipes/internal/abc"
 	"github.com/tomvodi/limepipes/internal/common"
 	"github.com/tomvodi/limepipes/internal/interfaces"
 	"golang.org/x/exp/maps"
@@ -20,6 +21,9 @@ type Loader struct {
 	processHandler   interfaces.PluginProcessHandler
 	supportedPlugins []string
 	pluginInfos      map[string]*messages.PluginInfoResponse
+	// builtinPlugins are the plugins of file formats that are handled in this process
+	// and don't need a plugin executable.
+	builtinPlugins map[string]plugininterfaces.LimePipesPlugin
 }
 
 func (l *Loader) FileFormatForFileExtension(fileExtension string) (fileformat.Format, error) {
@@ -50,6 +54,15 @@ func (l *Loader) LoadedPlugins() []*messages.PluginInfoResponse {
 func (l *Loader) LoadPluginsFromDir(
 	pluginsDir string,
 ) error {
+	for pID, lpPlugin := range l.builtinPlugins {
+		pInfo, err := lpPlugin.PluginInfo()
+		if err != nil {
+			return fmt.Errorf("failed getting plugin info from built-in '%s': %v", pID, err)
+		}
+
+		l.pluginInfos[pID] = pInfo
+	}
+
 	for _, pID := range l.supportedPlugins {
 		err := l.loadPlugin(pluginsDir, pID)
 		if err != nil {
@@ -99,7 +112,7 @@ func (l *Loader) PluginForFileExtension(
 ) (plugininterfaces.LimePipesPlugin, error) {
 	for s, pInfo := range l.pluginInfos {
 		if slices.Contains(pInfo.FileExtensions, fileExtension) {
-			lp, err := l.processHandler.GetPlugin(s)
+			lp, err := l.plugin(s)
 			if err != nil {
 				return nil, err
 			}
@@ -111,6 +124,42 @@ func (l *Loader) PluginForFileExtension(
 	return nil, fmt.Errorf("no plugin found for file extension '%s'", fileExtension)
 }
 
+// ExportPluginForFileFormat returns a plugin that can write tunes in the given file format.
+// nolint: ireturn
+// linter exception is ok here, as the PluginLoader interface returns an interface here
+func (l *Loader) ExportPluginForFileFormat(
+	format fileformat.Format,
+) (plugininterfaces.LimePipesPlugin, error) {
+	for s, pInfo := range l.pluginInfos {
+		if pInfo.FileFormat != format || !canExport(pInfo.Type) {
+			continue
+		}
+
+		lp, err := l.plugin(s)
+		if err != nil {
+			return nil, err
+		}
+
+		return lp, nil
+	}
+
+	return nil, fmt.Errorf("no plugin found that exports file format '%s'", format.String())
+}
+
+// plugin returns the built-in plugin with the ID or the one of the running plugin processes.
+// nolint: ireturn
+func (l *Loader) plugin(pluginID string) (plugininterfaces.LimePipesPlugin, error) {
+	if lp, ok := l.builtinPlugins[pluginID]; ok {
+		return lp, nil
+	}
+
+	return l.processHandler.GetPlugin(pluginID)
+}
+
+func canExport(pType messages.PluginType) bool {
+	return pType == messages.PluginType_OUT || pType == messages.PluginType_INOUT
+}
+
 func (l *Loader) FileExtensionsForFileFormat(
 	format fileformat.Format,
 ) ([]string, error) {
@@ -133,5 +182,8 @@ func NewPluginLoader(
 		processHandler:   processHandler,
 		supportedPlugins: supportedPlugins,
 		pluginInfos:      map[string]*messages.PluginInfoResponse{},
+		builtinPlugins: map[string]plugininterfaces.LimePipesPlugin{
+			abc.PluginID: abc.NewPlugin(afs),
+		},
 	}
 }
@@ -194,4 +194,130 @@ var _ = Describe("Pluginloader", func() {
 			})
 		})
 	})
+
+	Context("ExportPluginForFileFormat", func() {
+		var plug interfaces.LimePipesPlugin
+
+		BeforeEach(func() {
+			loader.pluginInfos = map[string]*messages.PluginInfoResponse{
+				"bww": {
+					Name:       "bww",
+					Type:       messages.PluginType_IN,
+					FileFormat: fileformat.Format_BWW,
+				},
+				"musicxml": {
+					Name:       "musicxml",
+					Type:       messages.PluginType_OUT,
+					FileFormat: fileformat.Format_MUSIC_XML,
+				},
+			}
+		})
+
+		When("the plugin for that format can only parse files", func() {
+			JustBeforeEach(func() {
+				plug, err = loader.ExportPluginForFileFormat(fileformat.Format_BWW)
+			})
+
+			It("should return an error", func() {
+				Expect(err).Should(HaveOccurred())
+				Expect(plug).To(BeNil())
+			})
+		})
+
+		When("there is no plugin for that format", func() {
+			JustBeforeEach(func() {
+				plug, err = loader.ExportPluginForFileFormat(fileformat.Format_ABC)
+			})
+
+			It("should return an error", func() {
+				Expect(err).Should(HaveOccurred())
+			})
+		})
+
+		When("there is a plugin that exports that format", func() {
+			BeforeEach(func() {
+				processHandler.EXPECT().GetPlugin("musicxml").Return(lpPlugin, nil)
+			})
+
+			JustBeforeEach(func() {
+				plug, err = loader.ExportPluginForFileFormat(fileformat.Format_MUSIC_XML)
+			})
+
+			It("should return that plugin", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(plug).To(Equal(lpPlugin))
+			})
+		})
+	})
+
+	Context("having a built-in plugin", func() {
+		var builtin *pimocks.LimePipesPlugin
+
+		BeforeEach(func() {
+			builtin = pimocks.NewLimePipesPlugin(GinkgoT())
+			builtin.EXPECT().PluginInfo().
+				Return(&messages.PluginInfoResponse{
+					Name:           "abc",
+					Type:           messages.PluginType_INOUT,
+					FileFormat:     fileformat.Format_ABC,
+					FileExtensions: []string{".abc"},
+				}, nil)
+			loader.supportedPlugins = nil
+			loader.builtinPlugins = map[string]interfaces.LimePipesPlugin{
+				"abc": builtin,
+			}
+		})
+
+		JustBeforeEach(func() {
+			err = loader.LoadPluginsFromDir(pluginsDir)
+		})
+
+		It("should load it without a plugin executable", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(loader.LoadedPlugins()).To(HaveLen(1))
+		})
+
+		When("getting the plugin for its file extension", func() {
+			var plug interfaces.LimePipesPlugin
+			JustBeforeEach(func() {
+				plug, err = loader.PluginForFileExtension(".abc")
+			})
+
+			It("should return the built-in plugin", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(plug).To(Equal(builtin))
+			})
+		})
+
+		When("getting the export plugin for its file format", func() {
+			var plug interfaces.LimePipesPlugin
+			JustBeforeEach(func() {
+				plug, err = loader.ExportPluginForFileFormat(fileformat.Format_ABC)
+			})
+
+			It("should return the built-in plugin", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(plug).To(Equal(builtin))
+			})
+		})
+	})
+
+	When("creating a plugin loader", func() {
+		var ff fileformat.Format
+
+		BeforeEach(func() {
+			loader = NewPluginLoader(fs, processHandler, nil)
+		})
+
+		JustBeforeEach(func() {
+			err = loader.LoadPluginsFromDir(pluginsDir)
+			Expect(err).ShouldNot(HaveOccurred())
+			ff, err = loader.FileFormatForFileExtension(".abc")
+		})
+
+		It("should handle ABC files", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(ff).To(Equal(fileformat.Format_ABC))
+		})
+	})
 })
@@ -0,0 +1,225 @@
+package revision
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/measure"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/common"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"google.golang.org/protobuf/proto"
+)
+
+// The kinds of changes of a file or a measure between two revisions.
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// APITuneRevision converts a tune revision from the database into its API model.
+func APITuneRevision(r *model.TuneRevision) apimodel.TuneRevision {
+	apiRevision := apimodel.TuneRevision{
+		Number:    int32(r.Number),
+		Author:    r.Author,
+		CreatedAt: r.CreatedAt.Time,
+		Title:     r.Title,
+		Type:      r.Type,
+		TimeSig:   r.TimeSig,
+		Composer:  r.Composer,
+		Arranger:  r.Arranger,
+	}
+	for _, f := range r.Files {
+		apiRevision.Files = append(apiRevision.Files, common.FileFormatName(f.Format))
+	}
+
+	return apiRevision
+}
+
+// Diff returns the changes from one revision of a tune to another. Besides
+// the metadata and the files, it compares the measures of the music models
+// of both revisions.
+func Diff(from, to *model.TuneRevision) (*apimodel.TuneRevisionDiff, error) {
+	measureChanges, err := musicModelChanges(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apimodel.TuneRevisionDiff{
+		From:     int32(from.Number),
+		To:       int32(to.Number),
+		Fields:   fieldChanges(from, to),
+		Files:    fileChanges(from, to),
+		Measures: measureChanges,
+	}, nil
+}
+
+func fieldChanges(from, to *model.TuneRevision) []apimodel.FieldChange {
+	fields := []apimodel.FieldChange{
+		{Field: "title", From: from.Title, To: to.Title},
+		{Field: "type", From: from.Type, To: to.Type},
+		{Field: "timeSig", From: from.TimeSig, To: to.TimeSig},
+		{Field: "composer", From: from.Composer, To: to.Composer},
+		{Field: "arranger", From: from.Arranger, To: to.Arranger},
+	}
+
+	var changes []apimodel.FieldChange
+	for _, f := range fields {
+		if f.From != f.To {
+			changes = append(changes, f)
+		}
+	}
+
+	return changes
+}
+
+// revisionFileKey identifies a file of a revision, as a revision may have
+// a file for the whole tune and one for the single tune of the same format.
+type revisionFileKey struct {
+	format         fileformat.Format
+	singleTuneData bool
+}
+
+func fileChanges(from, to *model.TuneRevision) []apimodel.FileChange {
+	fromFiles := make(map[revisionFileKey][]byte)
+	for _, f := range from.Files {
+		fromFiles[revisionFileKey{f.Format, f.SingleTuneData}] = f.Data
+	}
+
+	var changes []apimodel.FileChange
+	for _, f := range to.Files {
+		key := revisionFileKey{f.Format, f.SingleTuneData}
+		fromData, ok := fromFiles[key]
+		delete(fromFiles, key)
+		switch {
+		case !ok:
+			changes = append(changes, fileChange(f.Format, ChangeAdded))
+		case !bytes.Equal(fromData, f.Data):
+			changes = append(changes, fileChange(f.Format, ChangeChanged))
+		}
+	}
+
+	for _, f := range from.Files {
+		if _, ok := fromFiles[revisionFileKey{f.Format, f.SingleTuneData}]; ok {
+			changes = append(changes, fileChange(f.Format, ChangeRemoved))
+		}
+	}
+
+	return changes
+}
+
+func fileChange(format fileformat.Format, change string) apimodel.FileChange {
+	return apimodel.FileChange{
+		Format: common.FileFormatName(format),
+		Change: change,
+	}
+}
+
+func musicModelChanges(from, to *model.TuneRevision) ([]apimodel.MeasureChange, error) {
+	fromMeasures, err := musicModelMeasures(from)
+	if err != nil {
+		return nil, err
+	}
+	toMeasures, err := musicModelMeasures(to)
+	if err != nil {
+		return nil, err
+	}
+
+	return MeasureChanges(fromMeasures, toMeasures), nil
+}
+
+// musicModelMeasures returns the measures of the music model of the
+// revision or nil if the revision has no music model.
+func musicModelMeasures(r *model.TuneRevision) ([]*measure.Measure, error) {
+	f := r.File(fileformat.Format_MUSIC_MODEL)
+	if f == nil {
+		return nil, nil
+	}
+
+	tf := &model.TuneFile{Format: f.Format, Data: f.Data}
+	t, err := tf.MusicModelTune()
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding music model of revision %d: %w", r.Number, err)
+	}
+
+	return t.Measures, nil
+}
+
+// MeasureChanges returns the measures that were added, removed or changed
+// from one list of measures to another. The changes are based on the longest
+// common subsequence of both lists. The numbers of the measures start with 1,
+// a number of 0 means that the measure doesn't exist in that list.
+func MeasureChanges(from, to []*measure.Measure) []apimodel.MeasureChange {
+	lcs := commonSubsequenceLengths(from, to)
+
+	var changes []apimodel.MeasureChange
+	var gap measureGap
+	i, j := 0, 0
+	for i < len(from) || j < len(to) {
+		switch {
+		case i < len(from) && j < len(to) && proto.Equal(from[i], to[j]):
+			changes = append(changes, gap.changes()...)
+			gap = measureGap{}
+			i++
+			j++
+		case i < len(from) && (j == len(to) || lcs[i+1][j] >= lcs[i][j+1]):
+			gap.removed = append(gap.removed, int32(i+1))
+			i++
+		default:
+			gap.added = append(gap.added, int32(j+1))
+			j++
+		}
+	}
+
+	return append(changes, gap.changes()...)
+}
+
+// measureGap are the measures between two equal measures of both lists.
+type measureGap struct {
+	removed []int32
+	added   []int32
+}
+
+// changes returns the changes of the gap. A removed and an added measure at
+// the same position of the gap are reported as changed measure.
+func (g measureGap) changes() []apimodel.MeasureChange {
+	var changes []apimodel.MeasureChange
+	for k := 0; k < max(len(g.removed), len(g.added)); k++ {
+		change := apimodel.MeasureChange{Change: ChangeChanged}
+		if k < len(g.removed) {
+			change.From = g.removed[k]
+		} else {
+			change.Change = ChangeAdded
+		}
+		if k < len(g.added) {
+			change.To = g.added[k]
+		} else {
+			change.Change = ChangeRemoved
+		}
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+// commonSubsequenceLengths returns a table with the lengths of the longest
+// common subsequence of from[i:] and to[j:] for all i and j.
+func commonSubsequenceLengths(from, to []*measure.Measure) [][]int {
+	lcs := make([][]int, len(from)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(to)+1)
+	}
+
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if proto.Equal(from[i], to[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	return lcs
+}
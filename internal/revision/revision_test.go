@@ -0,0 +1,144 @@
+package revision
+
+import (
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/measure"
+	"github.com/tomvodi/limepipes-plugin-api/plugin/v1/fileformat"
+	"github.com/tomvodi/limepipes/internal/apigen/apimodel"
+	"github.com/tomvodi/limepipes/internal/database/model"
+	"testing"
+)
+
+func testMeasures(beats ...uint32) []*measure.Measure {
+	measures := make([]*measure.Measure, len(beats))
+	for i, b := range beats {
+		measures[i] = &measure.Measure{
+			Time: &measure.TimeSignature{Beats: b, BeatType: 4},
+		}
+	}
+
+	return measures
+}
+
+func TestMeasureChanges(t *testing.T) {
+	tests := []struct {
+		name string
+		from []*measure.Measure
+		to   []*measure.Measure
+		want []apimodel.MeasureChange
+	}{
+		{
+			name: "equal measures",
+			from: testMeasures(2, 3, 4),
+			to:   testMeasures(2, 3, 4),
+			want: nil,
+		},
+		{
+			name: "added measure",
+			from: testMeasures(2, 4),
+			to:   testMeasures(2, 3, 4),
+			want: []apimodel.MeasureChange{
+				{Change: ChangeAdded, To: 2},
+			},
+		},
+		{
+			name: "removed measure at the end",
+			from: testMeasures(2, 3, 4),
+			to:   testMeasures(2, 3),
+			want: []apimodel.MeasureChange{
+				{Change: ChangeRemoved, From: 3},
+			},
+		},
+		{
+			name: "changed measures",
+			from: testMeasures(2, 3, 4, 5),
+			to:   testMeasures(2, 6, 7, 5),
+			want: []apimodel.MeasureChange{
+				{Change: ChangeChanged, From: 2, To: 2},
+				{Change: ChangeChanged, From: 3, To: 3},
+			},
+		},
+		{
+			name: "changed and added measure",
+			from: testMeasures(2, 3),
+			to:   testMeasures(2, 6, 7),
+			want: []apimodel.MeasureChange{
+				{Change: ChangeChanged, From: 2, To: 2},
+				{Change: ChangeAdded, To: 3},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			g.Expect(MeasureChanges(tt.from, tt.to)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	from := &model.TuneRevision{
+		Number:   1,
+		Title:    "Scotland the Brave",
+		Composer: "trad.",
+		Files: []model.TuneRevisionFile{
+			{Format: fileformat.Format_BWW, SingleTuneData: true, Data: []byte("bww")},
+			{Format: fileformat.Format_MUSIC_XML, SingleTuneData: true, Data: []byte("xml")},
+		},
+	}
+	to := &model.TuneRevision{
+		Number:   3,
+		Title:    "Scotland the Brave",
+		Composer: "unknown",
+		Files: []model.TuneRevisionFile{
+			{Format: fileformat.Format_BWW, SingleTuneData: true, Data: []byte("changed bww")},
+			{Format: fileformat.Format_ABC, SingleTuneData: true, Data: []byte("abc")},
+		},
+	}
+
+	diff, err := Diff(from, to)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(diff).To(Equal(&apimodel.TuneRevisionDiff{
+		From: 1,
+		To:   3,
+		Fields: []apimodel.FieldChange{
+			{Field: "composer", From: "trad.", To: "unknown"},
+		},
+		Files: []apimodel.FileChange{
+			{Format: "bww", Change: ChangeChanged},
+			{Format: "abc", Change: ChangeAdded},
+			{Format: "music_xml", Change: ChangeRemoved},
+		},
+	}))
+}
+
+func TestDiffOfMusicModels(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	fromTune := model.TestParsedTune("tune").Tune
+	toTune := model.TestParsedTune("tune").Tune
+	toTune.Measures = append(toTune.Measures, testMeasures(3)...)
+
+	fromFile, err := model.TuneFileFromMusicModelTune(fromTune)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	toFile, err := model.TuneFileFromMusicModelTune(toTune)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	diff, err := Diff(
+		&model.TuneRevision{Number: 1, Files: []model.TuneRevisionFile{
+			{Format: fromFile.Format, Data: fromFile.Data},
+		}},
+		&model.TuneRevision{Number: 2, Files: []model.TuneRevisionFile{
+			{Format: toFile.Format, Data: toFile.Data},
+		}},
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(diff.Files).To(Equal([]apimodel.FileChange{
+		{Format: "music_model", Change: ChangeChanged},
+	}))
+	g.Expect(diff.Measures).To(Equal([]apimodel.MeasureChange{
+		{Change: ChangeAdded, To: int32(len(toTune.Measures))},
+	}))
+}
@@ -0,0 +1,115 @@
+package score
+
+import (
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/barline"
+)
+
+// line widths and spaces of barlines in points
+const (
+	thinLine     = 0.8
+	thickLine    = 2.6
+	barlineGap   = 1.8
+	repeatRadius = 0.25 * sp
+)
+
+// barlineStrokes are the widths of the lines of the barline types from left to right.
+var barlineStrokes = map[barline.Type][]float64{
+	barline.Type_Regular:    {thinLine},
+	barline.Type_Heavy:      {thickLine},
+	barline.Type_HeavyHeavy: {thickLine, thickLine},
+	barline.Type_LightHeavy: {thinLine, thickLine},
+	barline.Type_HeavyLight: {thickLine, thinLine},
+}
+
+// timeTexts are the texts above barlines that jump to other parts of the tune.
+var timeTexts = map[barline.Time]string{
+	barline.Time_Dalsegno:     "D.S.",
+	barline.Time_Fine:         "Fine",
+	barline.Time_DacapoAlFine: "D.C. al Fine",
+}
+
+// barlineWidth returns the space that a barline takes. Regular barlines are
+// drawn on the border between measures and take no space.
+func barlineWidth(b *barline.Barline) float64 {
+	if b == nil {
+		return 0
+	}
+
+	width := 0.0
+	if b.Type != barline.Type_Regular {
+		for _, w := range barlineStrokes[b.Type] {
+			width += w + barlineGap
+		}
+		width -= barlineGap
+	}
+	if b.Time == barline.Time_Repeat {
+		width += barlineGap + 2*repeatRadius
+	}
+
+	return width
+}
+
+// leftBarline draws the barline at the start of a measure from the position
+// on, with the repeat dots after the lines.
+func (e *engraver) leftBarline(b *barline.Barline, x float64) {
+	if b.Type == barline.Type_Regular && b.Time != barline.Time_Repeat {
+		e.barlineTime(b, x)
+		return
+	}
+
+	x = e.barlineStrokes(b.Type, x)
+	if b.Time == barline.Time_Repeat {
+		e.repeatDots(x + barlineGap + repeatRadius)
+	}
+	e.barlineTime(b, x)
+}
+
+// rightBarline draws the barline at the end of a measure up to the position,
+// with the repeat dots before the lines.
+func (e *engraver) rightBarline(b *barline.Barline, x float64) {
+	if b == nil {
+		b = &barline.Barline{}
+	}
+
+	start := x - barlineWidth(b)
+	if b.Time == barline.Time_Repeat {
+		e.repeatDots(start + repeatRadius)
+		start += 2*repeatRadius + barlineGap
+	}
+	if b.Type == barline.Type_Regular {
+		e.c.line(point{x, e.top}, point{x, e.staffBottom()}, thinLine)
+	} else {
+		e.barlineStrokes(b.Type, start)
+	}
+	e.barlineTime(b, x)
+}
+
+// barlineStrokes draws the lines of the barline type from the position on
+// and returns the position after them.
+func (e *engraver) barlineStrokes(t barline.Type, x float64) float64 {
+	for i, w := range barlineStrokes[t] {
+		if i > 0 {
+			x += barlineGap
+		}
+		e.c.line(point{x + w/2, e.top}, point{x + w/2, e.staffBottom()}, w)
+		x += w
+	}
+
+	return x
+}
+
+// repeatDots draws the dots of a repeat in the spaces around the middle line.
+func (e *engraver) repeatDots(x float64) {
+	e.c.add(dot(point{x, e.top + 1.5*sp}, repeatRadius))
+	e.c.add(dot(point{x, e.top + 2.5*sp}, repeatRadius))
+}
+
+// barlineTime draws the segno or the text of a jump above the barline.
+func (e *engraver) barlineTime(b *barline.Barline, x float64) {
+	if b.Time == barline.Time_Segno {
+		e.c.segno(point{x, e.top - 2.5*sp})
+		return
+	}
+
+	e.c.rightText(text{pos: point{x, e.top - 3.4*sp}, size: 8, font: fontItalic, s: timeTexts[b.Time]})
+}
@@ -0,0 +1,147 @@
+package score
+
+import (
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"strconv"
+	"strings"
+)
+
+// sizes of the title page, table of contents and page decorations in points
+const (
+	bookletTitleSize = 28
+	tocHeadingSize   = 16
+	tocSetSize       = 12
+	tocTuneSize      = 10.5
+	tocLine          = 17
+	tocIndent        = 16
+	tocPageColumn    = 36
+	tocTop           = headerToSystem + 2*tocLine
+	decorationSize   = 9
+	runningHeadY     = margin - 18
+	pageNumberY      = pageHeight - margin/2
+)
+
+// tocLines is the number of entries that fit on a page of the table of contents.
+const tocLines = int((contentBottom - tocTop) / tocLine)
+
+// BookletSection is a set of a booklet with the tunes in their order.
+// Tunes that are added to a booklet on their own are sections without title.
+type BookletSection struct {
+	Title string
+	Tunes []*tune.Tune
+}
+
+// tocEntry is a line of the table of contents with the index of the
+// page in the body of the booklet on which the set or tune starts.
+type tocEntry struct {
+	title   string
+	credits string
+	page    int
+	set     bool
+	inSet   bool
+}
+
+// booklet collects the engraved pages of the sections and their entries
+// for the table of contents.
+type booklet struct {
+	entries []tocEntry
+	body    []*canvas
+}
+
+// Booklet engraves the tunes of the sections one after another with every
+// tune starting on a new page. They follow a title page and a table of contents
+// with the page numbers of the sets and tunes. Sections without tunes are left out.
+func Booklet(title string, sections []BookletSection) *Score {
+	b := &booklet{}
+	for _, s := range sections {
+		b.addSection(s)
+	}
+
+	toc := b.tableOfContents()
+	pages := append([]*canvas{titlePage(title)}, toc...)
+	pages = append(pages, b.body...)
+	for i, p := range pages[1:] {
+		p.centeredText(text{pos: point{pageWidth / 2, pageNumberY}, size: decorationSize, s: strconv.Itoa(i + 2)})
+	}
+
+	return &Score{
+		title: title,
+		pages: pages,
+	}
+}
+
+func (b *booklet) addSection(s BookletSection) {
+	if len(s.Tunes) == 0 {
+		return
+	}
+
+	inSet := s.Title != ""
+	if inSet {
+		b.entries = append(b.entries, tocEntry{title: s.Title, page: len(b.body), set: true})
+	}
+	for _, t := range s.Tunes {
+		b.entries = append(b.entries, tocEntry{title: t.Title, credits: credits(t), page: len(b.body), inSet: inSet})
+		pages := Engrave(t).pages
+		drawRunningHead(pages, s.Title)
+		b.body = append(b.body, pages...)
+	}
+}
+
+// drawRunningHead draws the title of the set above the music of its pages.
+func drawRunningHead(pages []*canvas, title string) {
+	for _, p := range pages {
+		p.text(text{pos: point{margin, runningHeadY}, size: decorationSize, font: fontItalic, s: title})
+	}
+}
+
+// tableOfContents returns the pages of the table of contents.
+func (b *booklet) tableOfContents() []*canvas {
+	count := max(1, (len(b.entries)+tocLines-1)/tocLines)
+	pages := make([]*canvas, count)
+	for i := range pages {
+		pages[i] = newPage()
+	}
+	pages[0].centeredText(text{pos: point{pageWidth / 2, titleBaseline}, size: tocHeadingSize, font: fontBold, s: "Contents"})
+
+	for i, e := range b.entries {
+		// the body follows the title page and the table of contents
+		e.page += 2 + count
+		drawTocEntry(pages[i/tocLines], e, tocTop+float64(i%tocLines)*tocLine)
+	}
+
+	return pages
+}
+
+func drawTocEntry(c *canvas, e tocEntry, y float64) {
+	x, size, f := float64(margin), float64(tocTuneSize), fontRegular
+	if e.set {
+		size, f = tocSetSize, fontBold
+	}
+	if e.inSet {
+		x += tocIndent
+	}
+
+	c.text(text{pos: point{x, y}, size: size, font: f, s: e.title})
+	c.rightText(text{pos: point{pageWidth - margin - tocPageColumn, y}, size: size, font: fontItalic, s: e.credits})
+	c.rightText(text{pos: point{pageWidth - margin, y}, size: size, font: f, s: strconv.Itoa(e.page)})
+}
+
+// credits returns the composer and arranger of the tune.
+func credits(t *tune.Tune) string {
+	var parts []string
+	if t.Composer != "" {
+		parts = append(parts, t.Composer)
+	}
+	if t.Arranger != "" {
+		parts = append(parts, "arr. "+t.Arranger)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func titlePage(title string) *canvas {
+	c := newPage()
+	c.centeredText(text{pos: point{pageWidth / 2, pageHeight / 3}, size: bookletTitleSize, font: fontBold, s: title})
+
+	return c
+}
@@ -0,0 +1,70 @@
+package score
+
+import (
+	"fmt"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"strings"
+	"testing"
+)
+
+func titledTune(title string) *tune.Tune {
+	t := testTune()
+	t.Title = title
+
+	return t
+}
+
+func testBooklet() *Score {
+	return Booklet("Band Practice", []BookletSection{
+		{Title: "Competition Set", Tunes: []*tune.Tune{titledTune("First Tune"), titledTune("Second Tune")}},
+		{Title: "Empty Set"},
+		{Tunes: []*tune.Tune{titledTune("Single Tune")}},
+	})
+}
+
+func TestBooklet(t *testing.T) {
+	g := NewWithT(t)
+
+	b := testBooklet()
+
+	g.Expect(b.Pages()).To(Equal(5))
+	contents, err := b.SVG(2)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	expectGolden(g, "booklet_contents.svg", contents)
+	g.Expect(string(contents)).ToNot(ContainSubstring("Empty Set"))
+
+	for page, title := range map[int]string{3: "First Tune", 4: "Second Tune", 5: "Single Tune"} {
+		svg, err := b.SVG(page)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(string(svg)).To(ContainSubstring(">" + title + "<"))
+		g.Expect(string(svg)).To(ContainSubstring(fmt.Sprintf(">%d</text>", page)))
+		g.Expect(strings.Contains(string(svg), ">Competition Set<")).To(Equal(page < 5))
+	}
+}
+
+func TestBookletPDF(t *testing.T) {
+	g := NewWithT(t)
+
+	pdf := string(testBooklet().PDF())
+
+	g.Expect(strings.Count(pdf, "/Type /Page ")).To(Equal(5))
+	g.Expect(pdf).To(ContainSubstring("/Title (Band Practice)"))
+	expectValidXref(g, pdf)
+}
+
+func TestBookletContentsOnSeveralPages(t *testing.T) {
+	g := NewWithT(t)
+	var tunes []*tune.Tune
+	for i := range tocLines + 1 {
+		tunes = append(tunes, titledTune(fmt.Sprintf("Tune %d", i+1)))
+	}
+
+	b := Booklet("Repertoire", []BookletSection{{Tunes: tunes}})
+
+	g.Expect(b.Pages()).To(Equal(3 + len(tunes)))
+	second, err := b.SVG(3)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(string(second)).To(ContainSubstring(fmt.Sprintf(">Tune %d<", tocLines+1)))
+	g.Expect(string(second)).To(ContainSubstring(fmt.Sprintf(">%d</text>", 4+tocLines)))
+}
@@ -0,0 +1,136 @@
+package score
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// point is a position on a page in points from its top left corner.
+type point struct {
+	x, y float64
+}
+
+// segment is a part of a path with the op of SVG path data,
+// M moves to, L draws a line and C a cubic bezier curve to its last point
+// and Z closes the path.
+type segment struct {
+	op  byte
+	pts []point
+}
+
+// path is a shape that is filled with the even-odd rule or,
+// if it has a line width, stroked.
+type path struct {
+	segments []segment
+	width    float64
+}
+
+type font int
+
+const (
+	fontRegular font = iota
+	fontBold
+	fontItalic
+)
+
+// text is a line of text with its baseline starting at the position.
+type text struct {
+	pos  point
+	size float64
+	font font
+	s    string
+}
+
+// shape is anything that is drawn on a page.
+type shape interface {
+	svg() string
+	pdf() string
+}
+
+// canvas is a page with the shapes in the order in which they are drawn.
+type canvas struct {
+	width, height float64
+	shapes        []shape
+}
+
+func (p *path) moveTo(x, y float64) *path {
+	p.segments = append(p.segments, segment{op: 'M', pts: []point{{x, y}}})
+	return p
+}
+
+func (p *path) lineTo(x, y float64) *path {
+	p.segments = append(p.segments, segment{op: 'L', pts: []point{{x, y}}})
+	return p
+}
+
+func (p *path) curveTo(c1, c2, to point) *path {
+	p.segments = append(p.segments, segment{op: 'C', pts: []point{c1, c2, to}})
+	return p
+}
+
+func (p *path) close() *path {
+	p.segments = append(p.segments, segment{op: 'Z'})
+	return p
+}
+
+// line draws a straight line with the width.
+func (c *canvas) line(from, to point, width float64) {
+	c.add((&path{width: width}).moveTo(from.x, from.y).lineTo(to.x, to.y))
+}
+
+// rect fills the rectangle between the corners.
+func (c *canvas) rect(topLeft, bottomRight point) {
+	c.add((&path{}).
+		moveTo(topLeft.x, topLeft.y).
+		lineTo(bottomRight.x, topLeft.y).
+		lineTo(bottomRight.x, bottomRight.y).
+		lineTo(topLeft.x, bottomRight.y).
+		close())
+}
+
+func (c *canvas) add(s shape) {
+	c.shapes = append(c.shapes, s)
+}
+
+// text draws the text with its baseline starting at the position.
+func (c *canvas) text(t text) {
+	if t.s == "" {
+		return
+	}
+	c.add(&t)
+}
+
+// centeredText draws the text centered at the position.
+func (c *canvas) centeredText(t text) {
+	t.pos.x -= textWidth(t.s, t.size) / 2
+	c.text(t)
+}
+
+// rightText draws the text ending at the position.
+func (c *canvas) rightText(t text) {
+	t.pos.x -= textWidth(t.s, t.size)
+	c.text(t)
+}
+
+// num formats coordinates with at most two decimals, so that
+// the output is the same on every platform.
+func num(v float64) string {
+	v = math.Round(v*100) / 100
+	if v == 0 {
+		// avoids negative zero
+		v = 0
+	}
+
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// nums formats the values separated by spaces.
+func nums(values ...float64) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = num(v)
+	}
+
+	return strings.Join(parts, " ")
+}
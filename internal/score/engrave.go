@@ -0,0 +1,500 @@
+package score
+
+import (
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/boundary"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/length"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/measure"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/pitch"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/accidental"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/tie"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/timeline"
+	"strconv"
+)
+
+// sizes of notes in staff spaces
+const (
+	headRadius    = 0.68 * sp
+	graceScale    = 0.6
+	ledgerReach   = 1.1 * sp
+	dotRadius     = 0.18 * sp
+	beamThickness = 0.5 * sp
+	beamSpace     = 0.8 * sp
+	stubLength    = 1.1 * sp
+	staffLine     = 0.6
+	stemWidth     = 0.8
+	graceStem     = 0.5
+)
+
+// flagCounts are the flags or beams of notes of the lengths.
+var flagCounts = map[length.Length]int{
+	length.Length_Eighth:       1,
+	length.Length_Sixteenth:    2,
+	length.Length_Thirtysecond: 3,
+}
+
+var timelineLabels = map[timeline.Type]string{
+	timeline.Type_First:         "1",
+	timeline.Type_Second:        "2",
+	timeline.Type_Singling:      "Singling",
+	timeline.Type_Doubling:      "Doubling",
+	timeline.Type_SecondOf2:     "2 of 2",
+	timeline.Type_SecondOf3:     "2 of 3",
+	timeline.Type_SecondOf4:     "2 of 4",
+	timeline.Type_SecondOf2And4: "2 of 2 & 4",
+	timeline.Type_SecondOf5:     "2 of 5",
+	timeline.Type_SecondOf6:     "2 of 6",
+	timeline.Type_SecondOf7:     "2 of 7",
+	timeline.Type_SecondOf8:     "2 of 8",
+	timeline.Type_Bis:           "Bis",
+	timeline.Type_Intro:         "Intro",
+}
+
+// openEndings are the timelines that are open at their end,
+// as the tune goes on after them.
+var openEndings = map[timeline.Type]bool{
+	timeline.Type_Second:        true,
+	timeline.Type_SecondOf2:     true,
+	timeline.Type_SecondOf3:     true,
+	timeline.Type_SecondOf4:     true,
+	timeline.Type_SecondOf2And4: true,
+	timeline.Type_SecondOf5:     true,
+	timeline.Type_SecondOf6:     true,
+	timeline.Type_SecondOf7:     true,
+	timeline.Type_SecondOf8:     true,
+}
+
+// beamedNote is the stem of a note that is beamed with its neighbours.
+type beamedNote struct {
+	x     float64
+	flags int
+}
+
+// beamKey identifies the notes that are beamed together.
+type beamKey struct {
+	measure *measureLayout
+	beam    int
+}
+
+// openTimeline is a timeline whose end isn't drawn yet. A timeline that goes on
+// in the next system is continued there without its label.
+type openTimeline struct {
+	timeline  *timeline.TimeLine
+	x         float64
+	continued bool
+}
+
+// openTuplet collects the heads of the notes of a tuplet.
+type openTuplet struct {
+	number uint32
+	heads  []float64
+}
+
+// engraver draws systems onto pages. Ties and timelines that aren't closed
+// at the end of a system are continued in the next one.
+type engraver struct {
+	c *canvas
+	// top is the top line of the staff of the current system and
+	// x the position of the next symbol
+	top     float64
+	x       float64
+	stretch float64
+
+	lastHead *point
+	tieFrom  *point
+	timeline *openTimeline
+	tuplet   *openTuplet
+
+	beamNotes []beamedNote
+	beamKey   beamKey
+}
+
+// drawSystem draws the system with the top line of its staff at the position.
+func (e *engraver) drawSystem(s *system, top float64) {
+	e.top = top
+	e.stretch = s.stretch
+	start, end := float64(margin), float64(pageWidth-margin)
+	for i := range 5 {
+		y := top + float64(i)*sp
+		e.c.line(point{start, y}, point{end, y}, staffLine)
+	}
+	e.c.trebleClef(point{start + 1.2*sp, e.yOf(pitch.Pitch_LowG)})
+	e.c.sharp(point{start + (clefWidth+0.7)*sp, e.yOf(pitch.Pitch_F)})
+	e.c.sharp(point{start + (clefWidth+1.7)*sp, e.yOf(pitch.Pitch_C)})
+
+	e.x = start + systemStartWidth()
+	e.continueOpen()
+	for i, ml := range s.measures {
+		e.drawMeasure(ml, i == 0)
+	}
+	e.closeOpen(end)
+}
+
+// continueOpen continues the tie and timeline of the previous system.
+func (e *engraver) continueOpen() {
+	if e.tieFrom != nil {
+		e.tieFrom = &point{e.x - sp, e.top + e.tieFrom.y}
+	}
+	if e.timeline != nil {
+		e.timeline.x = e.x
+		e.timeline.continued = true
+	}
+}
+
+// closeOpen draws the unfinished tie and timeline up to the end of the system.
+func (e *engraver) closeOpen(end float64) {
+	if e.tieFrom != nil {
+		e.drawTieArc(*e.tieFrom, point{end, e.tieFrom.y})
+		// remembers the position of the head relative to the staff
+		e.tieFrom.y -= e.top
+	}
+	if e.timeline != nil {
+		e.drawTimeline(end, false)
+	}
+}
+
+func (e *engraver) drawMeasure(ml *measureLayout, first bool) {
+	m := ml.measure
+	if ml.time != nil && first {
+		e.drawTime(ml.time)
+	}
+	if m.LeftBarline != nil {
+		e.leftBarline(m.LeftBarline, e.x)
+		e.x += barlineWidth(m.LeftBarline)
+	}
+	if ml.time != nil && !first {
+		e.drawTime(ml.time)
+	}
+	e.x += measurePadding * sp
+
+	for _, el := range ml.elements {
+		e.drawElement(ml, el)
+	}
+	e.flushBeam()
+
+	e.x += barlinePadding*sp + barlineWidth(m.RightBarline)
+	e.rightBarline(m.RightBarline, e.x)
+}
+
+// drawTime draws the time signature with the beats in the upper
+// and the beat type in the lower half of the staff.
+func (e *engraver) drawTime(ts *measure.TimeSignature) {
+	center := e.x + timeWidth*sp/2
+	for i, v := range []uint32{ts.Beats, ts.BeatType} {
+		e.c.centeredText(text{
+			pos:  point{center, e.top + float64(2*(i+1))*sp - 0.1*sp},
+			size: 2.8 * sp,
+			font: fontBold,
+			s:    strconv.Itoa(int(v)),
+		})
+	}
+	e.x += timeWidth * sp
+}
+
+func (e *engraver) drawElement(ml *measureLayout, el *element) {
+	switch {
+	case el.tuplet != nil:
+		e.setTuplet(el.tuplet.BoundaryType, el.tuplet.VisibleNotes)
+	case el.timeline != nil:
+		e.setTimeline(el.timeline)
+	case el.tieFromLast:
+		if e.lastHead != nil {
+			head := *e.lastHead
+			e.tieFrom = &head
+		}
+	case el.rest != nil:
+		e.flushBeam()
+		e.drawRest(el.rest.Length)
+		e.x += el.advance * e.stretch
+	case el.note != nil:
+		e.drawNote(ml, el)
+	}
+}
+
+func (e *engraver) setTuplet(b boundary.Boundary, number uint32) {
+	if b == boundary.Boundary_Start {
+		e.tuplet = &openTuplet{number: number}
+		return
+	}
+	if e.tuplet == nil || len(e.tuplet.heads) == 0 {
+		e.tuplet = nil
+		return
+	}
+
+	first, last := e.tuplet.heads[0], e.tuplet.heads[len(e.tuplet.heads)-1]
+	e.c.centeredText(text{
+		pos:  point{(first + last) / 2, e.beamY() + 2*sp},
+		size: 8,
+		font: fontItalic,
+		s:    strconv.Itoa(int(e.tuplet.number)),
+	})
+	e.tuplet = nil
+}
+
+func (e *engraver) setTimeline(tl *timeline.TimeLine) {
+	if tl.BoundaryType == boundary.Boundary_Start {
+		e.timeline = &openTimeline{timeline: tl, x: e.x}
+		return
+	}
+	if e.timeline != nil {
+		e.drawTimeline(e.x-0.4*sp, true)
+		e.timeline = nil
+	}
+}
+
+// drawTimeline draws the bracket of the open timeline above the staff up to the
+// position, with the hook at the end if the timeline ends there and is closed.
+func (e *engraver) drawTimeline(end float64, ends bool) {
+	tl := e.timeline
+	y := e.top - 4.6*sp
+	e.c.line(point{tl.x, y}, point{end, y}, staffLine)
+	if !tl.continued {
+		e.c.line(point{tl.x, y}, point{tl.x, y + 1.4*sp}, staffLine)
+		e.c.text(text{pos: point{tl.x + 0.4*sp, y + 1.5*sp}, size: 8, s: timelineLabels[tl.timeline.Type]})
+	}
+	if ends && !openEndings[tl.timeline.Type] {
+		e.c.line(point{end, y}, point{end, y + 1.4*sp}, staffLine)
+	}
+}
+
+// drawNote draws a melody note with its gracenotes and accidental before it
+// and adds its stem to the beamed notes.
+func (e *engraver) drawNote(ml *measureLayout, el *element) {
+	n := el.note
+	x := e.x
+	if len(el.graces) > 0 {
+		e.drawGraces(el.graces, x)
+		x += float64(len(el.graces))*graceStep*sp + graceGap*sp
+	}
+	if el.accidental != accidental.Accidental_NoAccidental {
+		e.drawAccidental(el.accidental, point{x + 0.55*sp, e.yOf(n.Pitch)})
+		x += accidentalWidth * sp
+	}
+
+	head := point{x + headRadius, e.yOf(n.Pitch)}
+	e.drawHead(n, head)
+	e.tieHead(head, n.Tie)
+	if e.tuplet != nil {
+		e.tuplet.heads = append(e.tuplet.heads, head.x)
+	}
+
+	if n.Length != length.Length_Whole {
+		stemX := head.x - headRadius + stemWidth/2
+		e.c.line(point{stemX, head.y}, point{stemX, e.beamY()}, stemWidth)
+		e.addBeamed(beamKey{ml, el.beam}, beamedNote{stemX, flagCounts[n.Length]})
+	}
+
+	e.x += el.lead + el.advance*e.stretch
+}
+
+func (e *engraver) drawHead(n *symbols.Note, head point) {
+	switch n.Length {
+	case length.Length_Whole:
+		e.c.add(wholeNotehead(head))
+	case length.Length_Half:
+		e.c.add(notehead(head, 1, true))
+	default:
+		e.c.add(notehead(head, 1, false))
+	}
+	e.ledgerLine(n.Pitch, head.x, ledgerReach)
+
+	dotY := head.y
+	if stepOf(n.Pitch)%2 == 0 {
+		// dots of notes on lines are in the space above
+		dotY -= sp / 2
+	}
+	for i := range n.Dots {
+		e.c.add(dot(point{head.x + headRadius + 0.5*sp + float64(i)*0.6*sp, dotY}, dotRadius))
+	}
+
+	if n.Fermata {
+		e.c.fermata(point{head.x, e.top - 3.4*sp})
+	}
+}
+
+// tieHead draws the tie from the previous note to the head
+// and starts a tie from the head if the note has one.
+func (e *engraver) tieHead(head point, t tie.Tie) {
+	if e.tieFrom != nil {
+		e.drawTieArc(*e.tieFrom, head)
+		e.tieFrom = nil
+	}
+	if t == tie.Tie_Start {
+		e.tieFrom = &point{head.x, head.y}
+	}
+	e.lastHead = &point{head.x, head.y}
+}
+
+// drawTieArc draws a tie as crescent above the heads,
+// opposite to the stems of the notes.
+func (e *engraver) drawTieArc(from, to point) {
+	a := point{from.x + 0.5*sp, from.y - 0.8*sp}
+	b := point{to.x - 0.5*sp, to.y - 0.8*sp}
+	quarter := (b.x - a.x) / 4
+	height := min(1.2*sp, quarter)
+	arc := (&path{}).moveTo(a.x, a.y)
+	arc.curveTo(point{a.x + quarter, a.y - height}, point{b.x - quarter, b.y - height}, b)
+	arc.curveTo(point{b.x - quarter, b.y - height + 0.3*sp}, point{a.x + quarter, a.y - height + 0.3*sp}, a)
+	e.c.add(arc.close())
+}
+
+// drawGraces draws the gracenotes from the position on with their stems
+// up to the same height, several gracenotes are beamed with three beams.
+func (e *engraver) drawGraces(graces []pitch.Pitch, x float64) {
+	radius := headRadius * graceScale
+	top := e.top - 3*sp
+	stems := make([]float64, len(graces))
+	for i, p := range graces {
+		head := point{x + float64(i)*graceStep*sp + radius, e.yOf(p)}
+		e.c.add(notehead(head, graceScale, false))
+		e.ledgerLine(p, head.x, ledgerReach*graceScale)
+		stems[i] = head.x + radius - graceStem/2
+		e.c.line(point{stems[i], head.y}, point{stems[i], top}, graceStem)
+	}
+
+	for i := range 3 {
+		y := top + float64(i)*0.5*sp
+		if len(stems) == 1 {
+			e.c.line(point{stems[0], y}, point{stems[0] + 0.7*sp, y + 0.6*sp}, 0.2*sp)
+			continue
+		}
+		e.c.rect(point{stems[0] - graceStem/2, y}, point{stems[len(stems)-1] + graceStem/2, y + 0.25*sp})
+	}
+}
+
+func (e *engraver) drawAccidental(a accidental.Accidental, center point) {
+	switch a {
+	case accidental.Accidental_Sharp:
+		e.c.sharp(center)
+	case accidental.Accidental_Flat:
+		e.c.flat(center)
+	case accidental.Accidental_Natural:
+		e.c.natural(center)
+	}
+}
+
+// ledgerLine draws the ledger line of the high A.
+func (e *engraver) ledgerLine(p pitch.Pitch, x float64, reach float64) {
+	if p == pitch.Pitch_HighA {
+		y := e.yOf(p)
+		e.c.line(point{x - reach, y}, point{x + reach, y}, staffLine)
+	}
+}
+
+func (e *engraver) drawRest(l length.Length) {
+	center := e.x + headRadius
+	middle := e.yOf(pitch.Pitch_B)
+	switch l {
+	case length.Length_Whole:
+		line := e.yOf(pitch.Pitch_D)
+		e.c.rect(point{center - 0.6*sp, line}, point{center + 0.6*sp, line + 0.5*sp})
+	case length.Length_Half:
+		e.c.rect(point{center - 0.6*sp, middle - 0.5*sp}, point{center + 0.6*sp, middle})
+	case length.Length_Quarter:
+		e.c.quarterRest(point{center, middle})
+	default:
+		e.c.flagRest(point{center, e.yOf(pitch.Pitch_C)}, flagCounts[l])
+	}
+}
+
+// addBeamed adds the stem of a note to the notes that are beamed together,
+// notes without flags end the beamed notes.
+func (e *engraver) addBeamed(key beamKey, n beamedNote) {
+	if n.flags == 0 || key != e.beamKey {
+		e.flushBeam()
+	}
+	if n.flags == 0 {
+		return
+	}
+
+	e.beamKey = key
+	e.beamNotes = append(e.beamNotes, n)
+}
+
+// flushBeam draws the flags of a single note or the beams of several notes.
+// Notes with more beams than their neighbour get a short beam towards the
+// next note, or the previous one if they are the last note.
+func (e *engraver) flushBeam() {
+	notes := e.beamNotes
+	e.beamNotes = nil
+	e.beamKey = beamKey{}
+	if len(notes) == 1 {
+		e.drawFlags(notes[0])
+		return
+	}
+
+	level := 1
+	for e.drawBeams(notes, level) {
+		level++
+	}
+}
+
+// drawBeams draws the beams of the level over the notes that have at least
+// as many flags and returns if any beam was drawn.
+func (e *engraver) drawBeams(notes []beamedNote, level int) bool {
+	drawn := false
+	for i := 0; i < len(notes); i++ {
+		if notes[i].flags < level {
+			continue
+		}
+		j := i
+		for j+1 < len(notes) && notes[j+1].flags >= level {
+			j++
+		}
+		e.drawBeam(level, beamSpan(notes, i, j))
+		drawn = true
+		i = j
+	}
+
+	return drawn
+}
+
+// beamSpan returns the horizontal span of the beam from note i to j,
+// which is a short beam if it's only one note.
+func beamSpan(notes []beamedNote, i, j int) [2]float64 {
+	if i != j {
+		return [2]float64{notes[i].x - stemWidth/2, notes[j].x + stemWidth/2}
+	}
+	if i == len(notes)-1 {
+		return [2]float64{notes[i].x - stubLength, notes[i].x + stemWidth/2}
+	}
+
+	return [2]float64{notes[i].x - stemWidth/2, notes[i].x + stubLength}
+}
+
+// drawBeam draws the beam of the level, counted from the end of the stems.
+func (e *engraver) drawBeam(level int, span [2]float64) {
+	bottom := e.beamY() - float64(level-1)*beamSpace
+	e.c.rect(point{span[0], bottom - beamThickness}, point{span[1], bottom})
+}
+
+func (e *engraver) drawFlags(n beamedNote) {
+	for i := range n.flags {
+		y := e.beamY() - float64(i)*beamSpace
+		flag := (&path{width: 0.25 * sp}).moveTo(n.x, y)
+		flag.curveTo(point{n.x + 0.1*sp, y - 0.9*sp}, point{n.x + 1.2*sp, y - 1.1*sp}, point{n.x + 0.9*sp, y - 2.4*sp})
+		e.c.add(flag)
+	}
+}
+
+// beamY is the height of the end of the stems of the melody notes
+// below the staff, where the beams are.
+func (e *engraver) beamY() float64 {
+	return e.staffBottom() + 2.5*sp
+}
+
+func (e *engraver) staffBottom() float64 {
+	return e.top + 4*sp
+}
+
+// yOf returns the height of the pitch on the staff.
+func (e *engraver) yOf(p pitch.Pitch) float64 {
+	return e.staffBottom() - float64(stepOf(p))*sp/2
+}
+
+// stepOf returns the number of lines and spaces of the pitch
+// above the bottom line of the staff.
+func stepOf(p pitch.Pitch) int {
+	// the low G is on the second line
+	return int(p-pitch.Pitch_LowG) + 2
+}
@@ -0,0 +1,31 @@
+package score
+
+// helveticaWidths are the widths of the printable ASCII characters of
+// Helvetica in thousandths of the font size, starting with the space.
+var helveticaWidths = [...]float64{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+// defaultWidth is the width of characters that aren't printable ASCII.
+const defaultWidth = 556
+
+// textWidth returns the width of the text in Helvetica of the size.
+// The bold and italic fonts are measured with the same widths, which
+// is close enough to center and align them.
+func textWidth(s string, size float64) float64 {
+	width := 0.0
+	for _, r := range s {
+		if r >= ' ' && int(r-' ') < len(helveticaWidths) {
+			width += helveticaWidths[r-' ']
+		} else {
+			width += defaultWidth
+		}
+	}
+
+	return width * size / 1000
+}
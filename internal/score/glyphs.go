@@ -0,0 +1,196 @@
+package score
+
+import (
+	"math"
+)
+
+// bezierCircle is the distance of the control points of a cubic bezier curve
+// that approximates a quarter of a circle with the radius 1.
+const bezierCircle = 0.5523
+
+// ellipse returns the path of an ellipse around the center with the radii
+// in x and y direction, which is rotated by the angle in degrees.
+func ellipse(center point, radii point, angle float64) *path {
+	sin, cos := math.Sincos(angle * math.Pi / 180)
+	at := func(x, y float64) point {
+		x, y = x*radii.x, y*radii.y
+		return point{center.x + x*cos - y*sin, center.y + x*sin + y*cos}
+	}
+
+	p := &path{}
+	start := at(1, 0)
+	p.moveTo(start.x, start.y)
+	// the four quarters of the ellipse with the directions of their start and end
+	quarters := [4][2]point{{{1, 0}, {0, 1}}, {{0, 1}, {-1, 0}}, {{-1, 0}, {0, -1}}, {{0, -1}, {1, 0}}}
+	for _, q := range quarters {
+		from, to := q[0], q[1]
+		p.curveTo(
+			at(from.x+bezierCircle*to.x, from.y+bezierCircle*to.y),
+			at(to.x+bezierCircle*from.x, to.y+bezierCircle*from.y),
+			at(to.x, to.y),
+		)
+	}
+
+	return p.close()
+}
+
+// dot returns a filled circle with the radius.
+func dot(center point, radius float64) *path {
+	return ellipse(center, point{radius, radius}, 0)
+}
+
+// notehead returns the head of a note at the center. Hollow heads of half
+// and whole notes have an inner ellipse, which is left out by the even-odd rule.
+func notehead(center point, scale float64, hollow bool) *path {
+	head := ellipse(center, point{headRadius * scale, 0.48 * sp * scale}, -20)
+	if hollow {
+		inner := ellipse(center, point{0.52 * sp * scale, 0.24 * sp * scale}, -35)
+		head.segments = append(head.segments, inner.segments...)
+	}
+
+	return head
+}
+
+// wholeNotehead returns the head of a whole note, which is wider
+// than the other heads and has an inner ellipse that leans the other way.
+func wholeNotehead(center point) *path {
+	head := ellipse(center, point{0.8 * sp, 0.5 * sp}, 0)
+	inner := ellipse(center, point{0.42 * sp, 0.3 * sp}, 55)
+	head.segments = append(head.segments, inner.segments...)
+
+	return head
+}
+
+// trebleClef draws the clef that curls around the line of the low G.
+func (c *canvas) trebleClef(g point) {
+	at := func(x, y float64) point {
+		return point{g.x + x*sp, g.y + y*sp}
+	}
+	curve := func(p *path, c1x, c1y, c2x, c2y, x, y float64) {
+		p.curveTo(at(c1x, c1y), at(c2x, c2y), at(x, y))
+	}
+
+	clef := &path{width: 0.2 * sp}
+	start := at(0.25, 0.55)
+	clef.moveTo(start.x, start.y)
+	curve(clef, -0.45, 0.45, -0.35, -0.75, 0.35, -0.75)
+	curve(clef, 1.25, -0.75, 1.2, 0.95, 0.2, 0.95)
+	curve(clef, -1.15, 0.95, -1.05, -1.1, 0.05, -2)
+	curve(clef, 0.75, -2.6, 0.95, -3.5, 0.55, -3.75)
+	curve(clef, 0.15, -4, -0.15, -3, 0.05, -2.2)
+	end := at(0.45, 1.9)
+	clef.lineTo(end.x, end.y)
+	curve(clef, 0.5, 2.5, -0.2, 2.65, -0.35, 2.15)
+
+	c.add(clef)
+	c.add(dot(at(-0.1, 2.1), 0.3*sp))
+}
+
+// sharp draws a sharp centered at the position.
+func (c *canvas) sharp(center point) {
+	for _, dx := range []float64{-0.22, 0.22} {
+		x := center.x + dx*sp
+		// the right line is a little higher like the slope of the bars
+		dy := -dx * 0.5 * sp
+		c.line(point{x, center.y - 1.3*sp + dy}, point{x, center.y + 1.3*sp + dy}, 0.1*sp)
+	}
+	for _, dy := range []float64{-0.45, 0.45} {
+		y := center.y + dy*sp
+		c.slantedBar(point{center.x - 0.5*sp, y + 0.15*sp}, point{center.x + 0.5*sp, y - 0.15*sp})
+	}
+}
+
+// flat draws a flat with the center of its bowl at the position.
+func (c *canvas) flat(center point) {
+	x := center.x - 0.3*sp
+	c.line(point{x, center.y - 2*sp}, point{x, center.y + 0.6*sp}, 0.1*sp)
+	bowl := (&path{width: 0.18 * sp}).moveTo(x, center.y+0.55*sp)
+	bowl.curveTo(
+		point{center.x + 0.7*sp, center.y - 0.1*sp},
+		point{center.x + 0.5*sp, center.y - 0.9*sp},
+		point{x, center.y - 0.25*sp},
+	)
+	c.add(bowl)
+}
+
+// natural draws a natural centered at the position.
+func (c *canvas) natural(center point) {
+	left, right := center.x-0.25*sp, center.x+0.25*sp
+	c.line(point{left, center.y - 1.4*sp}, point{left, center.y + 0.8*sp}, 0.1*sp)
+	c.line(point{right, center.y - 0.8*sp}, point{right, center.y + 1.4*sp}, 0.1*sp)
+	for _, dy := range []float64{-0.4, 0.4} {
+		y := center.y + dy*sp
+		c.slantedBar(point{left, y + 0.12*sp}, point{right, y - 0.12*sp})
+	}
+}
+
+// slantedBar draws the thick bars of sharps and naturals.
+func (c *canvas) slantedBar(from, to point) {
+	half := 0.11 * sp
+	c.add((&path{}).
+		moveTo(from.x, from.y-half).
+		lineTo(to.x, to.y-half).
+		lineTo(to.x, to.y+half).
+		lineTo(from.x, from.y+half).
+		close())
+}
+
+// quarterRest draws the rest of a quarter note centered at the middle line.
+func (c *canvas) quarterRest(center point) {
+	at := func(x, y float64) point {
+		return point{center.x + x*sp, center.y + y*sp}
+	}
+	rest := &path{width: 0.3 * sp}
+	start := at(-0.25, -1.5)
+	rest.moveTo(start.x, start.y)
+	for _, pt := range []point{at(0.35, -0.75), at(-0.25, -0.05), at(0.35, 0.65)} {
+		rest.lineTo(pt.x, pt.y)
+	}
+	rest.curveTo(at(-0.35, 0.45), at(-0.4, 1.1), at(-0.05, 1.4))
+	c.add(rest)
+}
+
+// flagRest draws the rest of an eighth or shorter note with a hook for every
+// flag of the note, the top of the rest is at the position.
+func (c *canvas) flagRest(top point, flags int) {
+	bottom := point{top.x - 0.15*sp*float64(flags), top.y + float64(flags+1)*sp}
+	c.line(point{top.x + 0.45*sp, top.y - 0.3*sp}, bottom, 0.12*sp)
+	for i := range flags {
+		y := top.y + float64(i)*sp
+		// the hook runs from the dot to the stem of the rest
+		stemX := top.x + 0.45*sp - 0.15*sp*float64(i)
+		c.add(dot(point{top.x - 0.25*sp, y}, 0.25*sp))
+		hook := (&path{width: 0.12 * sp}).moveTo(top.x-0.25*sp, y+0.1*sp)
+		hook.curveTo(point{top.x, y + 0.4*sp}, point{stemX - 0.2*sp, y + 0.2*sp}, point{stemX, y - 0.3*sp})
+		c.add(hook)
+	}
+}
+
+// segno draws the sign where a dal segno jumps to, centered at the position.
+func (c *canvas) segno(center point) {
+	at := func(x, y float64) point {
+		return point{center.x + x*sp, center.y + y*sp}
+	}
+	s := &path{width: 0.25 * sp}
+	start := at(0.5, -0.7)
+	s.moveTo(start.x, start.y)
+	s.curveTo(at(0.3, -1.2), at(-0.6, -1), at(-0.4, -0.4))
+	s.curveTo(at(-0.2, 0.1), at(0.6, -0.1), at(0.5, 0.5))
+	s.curveTo(at(0.4, 1.1), at(-0.4, 1.1), at(-0.5, 0.7))
+	c.add(s)
+	c.line(at(-0.7, 0.9), at(0.7, -0.9), 0.12*sp)
+	c.add(dot(at(-0.6, -0.3), 0.15*sp))
+	c.add(dot(at(0.6, 0.3), 0.15*sp))
+}
+
+// fermata draws an arc with a dot above the position.
+func (c *canvas) fermata(bottom point) {
+	arc := (&path{width: 0.2 * sp}).moveTo(bottom.x-1.1*sp, bottom.y)
+	arc.curveTo(
+		point{bottom.x - 1.1*sp, bottom.y - 1.4*sp},
+		point{bottom.x + 1.1*sp, bottom.y - 1.4*sp},
+		point{bottom.x + 1.1*sp, bottom.y},
+	)
+	c.add(arc)
+	c.add(dot(point{bottom.x, bottom.y - 0.25*sp}, 0.2*sp))
+}
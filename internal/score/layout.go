@@ -0,0 +1,340 @@
+package score
+
+import (
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/barline"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/length"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/measure"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/pitch"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/accidental"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/tie"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/timeline"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/tuplet"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"github.com/tomvodi/limepipes/internal/playback"
+)
+
+// horizontal spaces in staff spaces
+const (
+	clefWidth       = 3.4
+	keyWidth        = 2.4
+	timeWidth       = 2.6
+	measurePadding  = 1
+	barlinePadding  = 0.6
+	graceStep       = 1.1
+	graceGap        = 0.5
+	accidentalWidth = 1.3
+	dotAdvance      = 0.25
+)
+
+// lineWidth is the width of the systems.
+const lineWidth = pageWidth - 2*margin
+
+// minJustified is the share of the line width from which on systems
+// are stretched to the full width.
+const minJustified = 0.6
+
+// advances are the spaces in staff spaces that notes and rests of the lengths
+// take from their head to the next symbol.
+var advances = map[length.Length]float64{
+	length.Length_Whole:        6.4,
+	length.Length_Half:         4.6,
+	length.Length_Quarter:      3.3,
+	length.Length_Eighth:       2.5,
+	length.Length_Sixteenth:    2,
+	length.Length_Thirtysecond: 1.7,
+}
+
+// element is a note or rest of a measure or a marker where a tuplet,
+// timeline or tie between notes starts or ends.
+type element struct {
+	note *symbols.Note
+	rest *symbols.Rest
+	// graces are the gracenotes of the ornaments of the note and
+	// accidental the accidental before the note
+	graces     []pitch.Pitch
+	accidental accidental.Accidental
+
+	tuplet   *tuplet.Tuplet
+	timeline *timeline.TimeLine
+	// tieFromLast ties the previous note to the next one
+	tieFromLast bool
+
+	// lead is the space before the head of a note for its gracenotes and
+	// accidental and advance the space from the head to the next element
+	lead, advance float64
+	// beam is the beat of the measure, notes of the same beat are beamed
+	beam int
+}
+
+// measureLayout is a measure with its elements.
+type measureLayout struct {
+	measure *measure.Measure
+	// time is the time signature that is shown at the start of the measure
+	time     *measure.TimeSignature
+	elements []*element
+}
+
+// system is a line of measures on a staff.
+type system struct {
+	measures []*measureLayout
+	// stretch scales the advances of the elements to fill the line
+	stretch float64
+}
+
+// layouter creates the elements of the measures of a tune.
+type layouter struct {
+	time *measure.TimeSignature
+	// beat is the length of a beat in quarter notes and
+	// pos the position in the current measure
+	beat float64
+	pos  float64
+
+	tupletFactor float64
+	// tupletBeam is the beam of the notes of the current tuplet or -1
+	tupletBeam int
+
+	ornaments  []*symbols.Note
+	accidental accidental.Accidental
+}
+
+// layoutMeasures returns the measures of the tune with their elements.
+func layoutMeasures(t *tune.Tune) []*measureLayout {
+	l := &layouter{beat: 1, tupletFactor: 1, tupletBeam: -1}
+	layouts := make([]*measureLayout, 0, len(t.Measures))
+	for _, m := range t.Measures {
+		layouts = append(layouts, l.layoutMeasure(m))
+	}
+
+	return layouts
+}
+
+func (l *layouter) layoutMeasure(m *measure.Measure) *measureLayout {
+	ml := &measureLayout{measure: m}
+	if m.Time != nil && m.Time.BeatType > 0 && !sameTime(m.Time, l.time) {
+		ml.time = m.Time
+		l.setTime(m.Time)
+	}
+
+	l.pos = 0
+	for _, s := range m.Symbols {
+		ml.elements = append(ml.elements, l.layoutSymbol(s)...)
+	}
+
+	return ml
+}
+
+func (l *layouter) layoutSymbol(s *symbols.Symbol) []*element {
+	var elements []*element
+	if s.Tuplet != nil {
+		l.setTuplet(s.Tuplet)
+		elements = append(elements, &element{tuplet: s.Tuplet})
+	}
+	if s.Timeline != nil {
+		elements = append(elements, &element{timeline: s.Timeline})
+	}
+
+	switch {
+	case s.Note != nil:
+		if e := l.layoutNote(s.Note); e != nil {
+			elements = append(elements, e)
+		}
+	case s.Rest != nil && s.Rest.Length != length.Length_NoLength:
+		elements = append(elements, &element{
+			rest:    s.Rest,
+			advance: advances[s.Rest.Length] * sp,
+			beam:    -1,
+		})
+		l.pos += playback.Quarters(s.Rest.Length, 0) * l.tupletFactor
+	}
+
+	return elements
+}
+
+// layoutNote returns the element of a melody note with the gracenotes and
+// accidentals of the symbols without pitch before it. These symbols only
+// return a marker for a tie.
+func (l *layouter) layoutNote(n *symbols.Note) *element {
+	if n.Embellishment != nil || n.Movement != nil {
+		l.ornaments = append(l.ornaments, n)
+	}
+	if !n.IsValid() {
+		return l.layoutUnpitched(n)
+	}
+
+	e := l.melodyElement(n)
+	e.advance = advances[n.Length] * (1 + dotAdvance*float64(n.Dots)) * sp
+	l.pos += playback.Quarters(n.Length, n.Dots) * l.tupletFactor
+
+	return e
+}
+
+// layoutUnpitched keeps the accidental of a symbol without pitch
+// for the next melody note and returns a marker if it starts a tie.
+func (l *layouter) layoutUnpitched(n *symbols.Note) *element {
+	if n.IsOnlyAccidental() {
+		l.accidental = n.Accidental
+	}
+	if n.Tie == tie.Tie_Start {
+		return &element{tieFromLast: true}
+	}
+
+	return nil
+}
+
+// melodyElement returns the element of the melody note with the gracenotes
+// of the pending ornaments and the pending accidental.
+func (l *layouter) melodyElement(n *symbols.Note) *element {
+	e := &element{note: n, accidental: n.Accidental, beam: l.beamOf()}
+	for _, o := range l.ornaments {
+		e.graces = append(e.graces, playback.GracePitches(o, n.Pitch)...)
+	}
+	if e.accidental == accidental.Accidental_NoAccidental {
+		e.accidental = l.accidental
+	}
+	l.ornaments = nil
+	l.accidental = accidental.Accidental_NoAccidental
+
+	if len(e.graces) > 0 {
+		e.lead = float64(len(e.graces))*graceStep*sp + graceGap*sp
+	}
+	if e.accidental != accidental.Accidental_NoAccidental {
+		e.lead += accidentalWidth * sp
+	}
+
+	return e
+}
+
+// beamOf returns the beam of a note at the current position,
+// all notes of a tuplet are beamed together.
+func (l *layouter) beamOf() int {
+	if l.tupletBeam >= 0 {
+		return l.tupletBeam
+	}
+
+	// a little tolerance for the rounding of tuplets
+	return int(l.pos/l.beat + 1e-6)
+}
+
+func (l *layouter) setTuplet(t *tuplet.Tuplet) {
+	l.tupletFactor = playback.TupletFactor(t)
+	l.tupletBeam = -1
+	if l.tupletFactor != 1 {
+		l.tupletBeam = l.beamOf()
+	}
+}
+
+// setTime sets the length of a beat for the time signature.
+func (l *layouter) setTime(ts *measure.TimeSignature) {
+	l.time = ts
+	l.beat = playback.BeatQuarters(ts)
+}
+
+func sameTime(a, b *measure.TimeSignature) bool {
+	return b != nil && a.Beats == b.Beats && a.BeatType == b.BeatType
+}
+
+// breakSystems fills systems with measures and stretches them to the line
+// width. Parts start on a new system, so a system ends after a heavy barline.
+func breakSystems(layouts []*measureLayout) []*system {
+	var systems []*system
+	var current *system
+	newSystem := true
+	for _, ml := range layouts {
+		if newSystem || startsPart(ml.measure) ||
+			systemStartWidth()+current.naturalWidth()+ml.naturalWidth() > lineWidth {
+			current = &system{}
+			systems = append(systems, current)
+		}
+		current.measures = append(current.measures, ml)
+		newSystem = endsPart(ml.measure)
+	}
+
+	for _, s := range systems {
+		s.justify()
+	}
+
+	return systems
+}
+
+// justify sets the stretch of the advances so that the system fills the line.
+// Much shorter systems, like the last measures of a part, keep their width.
+func (s *system) justify() {
+	s.stretch = 1
+	natural := systemStartWidth() + s.naturalWidth()
+	if natural < minJustified*lineWidth {
+		return
+	}
+
+	advance := 0.0
+	for _, ml := range s.measures {
+		advance += ml.advance()
+	}
+	if advance > 0 {
+		s.stretch = 1 + (lineWidth-natural)/advance
+	}
+}
+
+func (s *system) naturalWidth() float64 {
+	width := 0.0
+	for _, ml := range s.measures {
+		width += ml.naturalWidth()
+	}
+
+	return width
+}
+
+// systemStartWidth is the width of the clef and key signature at
+// the start of every system.
+func systemStartWidth() float64 {
+	return (clefWidth + keyWidth) * sp
+}
+
+func (ml *measureLayout) naturalWidth() float64 {
+	return ml.leftWidth() + ml.advance() + ml.rightWidth() + ml.lead()
+}
+
+func (ml *measureLayout) lead() float64 {
+	lead := 0.0
+	for _, e := range ml.elements {
+		lead += e.lead
+	}
+
+	return lead
+}
+
+func (ml *measureLayout) advance() float64 {
+	advance := 0.0
+	for _, e := range ml.elements {
+		advance += e.advance
+	}
+
+	return advance
+}
+
+// leftWidth is the space before the first element for the left barline
+// and time signature of the measure.
+func (ml *measureLayout) leftWidth() float64 {
+	width := barlineWidth(ml.measure.LeftBarline) + measurePadding*sp
+	if ml.time != nil {
+		width += timeWidth * sp
+	}
+
+	return width
+}
+
+func (ml *measureLayout) rightWidth() float64 {
+	return barlinePadding*sp + barlineWidth(ml.measure.RightBarline)
+}
+
+func endsPart(m *measure.Measure) bool {
+	return isPartBarline(m.RightBarline)
+}
+
+func startsPart(m *measure.Measure) bool {
+	return isPartBarline(m.LeftBarline)
+}
+
+func isPartBarline(b *barline.Barline) bool {
+	return b != nil && (b.Type != barline.Type_Regular || b.Time == barline.Time_Repeat)
+}
@@ -0,0 +1,73 @@
+package score
+
+import (
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+)
+
+// vertical spaces in points
+const (
+	titleSize      = 18
+	subtitleSize   = 11
+	subtitleLine   = 15
+	footerSize     = 9
+	footerLine     = 12
+	titleSpace     = 10
+	staffAbove     = 5 * sp
+	systemHeight   = 15 * sp
+	contentBottom  = pageHeight - margin
+	contentTop     = margin
+	titleBaseline  = contentTop + titleSize
+	headerToSystem = titleBaseline + titleSpace
+)
+
+// paginate draws the header of the tune on the first page followed by the
+// systems and the footer of the tune, pages are added as they are filled.
+func paginate(t *tune.Tune, systems []*system) []*canvas {
+	pages := []*canvas{newPage()}
+	e := &engraver{c: pages[0]}
+	y := drawHeader(pages[0], t)
+
+	for _, s := range systems {
+		if y+systemHeight > contentBottom {
+			pages = append(pages, newPage())
+			e.c = pages[len(pages)-1]
+			y = contentTop
+		}
+		e.drawSystem(s, y+staffAbove)
+		y += systemHeight
+	}
+
+	if y+float64(len(t.Footer))*footerLine > contentBottom {
+		pages = append(pages, newPage())
+		y = contentTop
+	}
+	for _, f := range t.Footer {
+		y += footerLine
+		pages[len(pages)-1].centeredText(text{pos: point{pageWidth / 2, y}, size: footerSize, font: fontItalic, s: f})
+	}
+
+	return pages
+}
+
+func newPage() *canvas {
+	return &canvas{width: pageWidth, height: pageHeight}
+}
+
+// drawHeader draws the title of the tune with its type on the left and
+// its composer and arranger on the right and returns the height below them.
+func drawHeader(c *canvas, t *tune.Tune) float64 {
+	c.centeredText(text{pos: point{pageWidth / 2, titleBaseline}, size: titleSize, font: fontBold, s: t.Title})
+
+	y := float64(headerToSystem)
+	if t.Type != "" || t.Composer != "" {
+		y += subtitleLine
+		c.text(text{pos: point{margin, y}, size: subtitleSize, font: fontItalic, s: t.Type})
+		c.rightText(text{pos: point{pageWidth - margin, y}, size: subtitleSize, s: t.Composer})
+	}
+	if t.Arranger != "" {
+		y += subtitleLine
+		c.rightText(text{pos: point{pageWidth - margin, y}, size: subtitleSize, s: "arr. " + t.Arranger})
+	}
+
+	return y
+}
@@ -0,0 +1,136 @@
+package score
+
+import (
+	"fmt"
+	"strings"
+)
+
+// pdfFonts are the standard fonts of PDF viewers,
+// which don't need to be embedded into the document.
+var pdfFonts = []struct {
+	font     font
+	name     string
+	baseFont string
+}{
+	{fontRegular, "F1", "Helvetica"},
+	{fontBold, "F2", "Helvetica-Bold"},
+	{fontItalic, "F3", "Helvetica-Oblique"},
+}
+
+// pdfWriter writes the numbered objects of a PDF document
+// and remembers their offsets for the cross-reference table.
+type pdfWriter struct {
+	b       []byte
+	offsets []int
+}
+
+// writePDF returns the pages as PDF document with the title.
+func writePDF(pages []*canvas, title string) []byte {
+	w := &pdfWriter{b: []byte("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")}
+
+	// the objects of the catalog, page tree, info and fonts come first,
+	// followed by a page and its content for every page
+	const firstPage = 4
+	fontsRef := firstPage + 2*len(pages)
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+
+	w.object("<< /Type /Catalog /Pages 2 0 R >>")
+	w.object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	w.object(fmt.Sprintf("<< /Title %s /Producer (limepipes) >>", pdfString(title)))
+	for i, p := range pages {
+		w.object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s] "+
+			"/Resources << /Font %d 0 R >> /Contents %d 0 R >>", nums(p.width, p.height), fontsRef, firstPage+2*i+1))
+		content := p.pdf()
+		w.object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+	}
+
+	fonts := make([]string, len(pdfFonts))
+	for i, f := range pdfFonts {
+		fonts[i] = fmt.Sprintf("/%s %d 0 R", f.name, fontsRef+1+i)
+	}
+	w.object("<< " + strings.Join(fonts, " ") + " >>")
+	for _, f := range pdfFonts {
+		w.object("<< /Type /Font /Subtype /Type1 /BaseFont /" + f.baseFont + " /Encoding /WinAnsiEncoding >>")
+	}
+
+	w.trailer()
+
+	return w.b
+}
+
+func (w *pdfWriter) object(content string) {
+	w.offsets = append(w.offsets, len(w.b))
+	w.b = fmt.Appendf(w.b, "%d 0 obj\n%s\nendobj\n", len(w.offsets), content)
+}
+
+func (w *pdfWriter) trailer() {
+	xref := len(w.b)
+	w.b = fmt.Appendf(w.b, "xref\n0 %d\n0000000000 65535 f \n", len(w.offsets)+1)
+	for _, o := range w.offsets {
+		w.b = fmt.Appendf(w.b, "%010d 00000 n \n", o)
+	}
+	w.b = fmt.Appendf(w.b, "trailer\n<< /Size %d /Root 1 0 R /Info 3 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(w.offsets)+1, xref)
+}
+
+// pdf returns the content stream of the page. The y-axis is flipped,
+// so that the shapes are drawn with the coordinates from the top of the page.
+func (c *canvas) pdf() string {
+	ops := []string{"1 0 0 -1 0 " + num(c.height) + " cm", "0 g 0 G"}
+	for _, s := range c.shapes {
+		ops = append(ops, s.pdf())
+	}
+
+	return strings.Join(ops, "\n")
+}
+
+func (p *path) pdf() string {
+	var ops []string
+	for _, s := range p.segments {
+		switch s.op {
+		case 'M':
+			ops = append(ops, nums(s.pts[0].x, s.pts[0].y)+" m")
+		case 'L':
+			ops = append(ops, nums(s.pts[0].x, s.pts[0].y)+" l")
+		case 'C':
+			ops = append(ops, nums(s.pts[0].x, s.pts[0].y, s.pts[1].x, s.pts[1].y, s.pts[2].x, s.pts[2].y)+" c")
+		case 'Z':
+			ops = append(ops, "h")
+		}
+	}
+
+	if p.width > 0 {
+		return num(p.width) + " w " + strings.Join(ops, " ") + " S"
+	}
+
+	return strings.Join(ops, " ") + " f*"
+}
+
+func (t *text) pdf() string {
+	// the text matrix flips the text back
+	return fmt.Sprintf("BT /%s %s Tf 1 0 0 -1 %s Tm %s Tj ET",
+		pdfFonts[t.font].name, num(t.size), nums(t.pos.x, t.pos.y), pdfString(t.s))
+}
+
+// pdfString returns the text as PDF string in the WinAnsi encoding of the fonts,
+// characters that it doesn't have are replaced by a question mark.
+func pdfString(s string) string {
+	b := []byte{'('}
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b = append(b, '\\', byte(r))
+		case r < 0x20:
+			b = append(b, ' ')
+		case r < 0x7F || (r >= 0xA0 && r <= 0xFF):
+			b = append(b, byte(r))
+		default:
+			b = append(b, '?')
+		}
+	}
+
+	return string(append(b, ')'))
+}
@@ -0,0 +1,65 @@
+// Package score engraves the music model of tunes as sheet music. The measures
+// are laid out in systems on A4 pages like the scores of bagpipe music with the
+// stems of melody notes down and the gracenotes small with their stems up.
+// The pages are written as SVG or together as PDF document and the same tune
+// always results in the same output.
+package score
+
+import (
+	"fmt"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"github.com/tomvodi/limepipes/internal/common"
+)
+
+const (
+	ContentTypeSVG = "image/svg+xml"
+	ContentTypePDF = "application/pdf"
+	ExtensionSVG   = ".svg"
+	ExtensionPDF   = ".pdf"
+)
+
+// the size of A4 pages and the space around the music in points
+const (
+	pageWidth  = 595
+	pageHeight = 842
+	margin     = 48
+)
+
+// sp is the staff space, the distance between two staff lines in points,
+// the sizes of the symbols are given in staff spaces.
+const sp = 6.0
+
+// Score is the engraved sheet music of a tune.
+type Score struct {
+	title string
+	pages []*canvas
+}
+
+// Engrave lays out the tune on pages.
+func Engrave(t *tune.Tune) *Score {
+	systems := breakSystems(layoutMeasures(t))
+
+	return &Score{
+		title: t.Title,
+		pages: paginate(t, systems),
+	}
+}
+
+// Pages returns the number of pages of the score.
+func (s *Score) Pages() int {
+	return len(s.pages)
+}
+
+// SVG returns the page with the number, starting at 1, as SVG document.
+func (s *Score) SVG(page int) ([]byte, error) {
+	if page < 1 || page > len(s.pages) {
+		return nil, fmt.Errorf("%w: score has no page %d", common.ErrNotFound, page)
+	}
+
+	return s.pages[page-1].svg(), nil
+}
+
+// PDF returns all pages of the score as PDF document.
+func (s *Score) PDF() []byte {
+	return writePDF(s.pages, s.title)
+}
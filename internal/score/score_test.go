@@ -0,0 +1,278 @@
+package score
+
+import (
+	"flag"
+	"fmt"
+	. "github.com/onsi/gomega"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/barline"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/boundary"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/length"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/measure"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/pitch"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/accidental"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/embellishment"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/tie"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/timeline"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/symbols/tuplet"
+	"github.com/tomvodi/limepipes-plugin-api/musicmodel/v1/tune"
+	"github.com/tomvodi/limepipes/internal/common"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+func note(p pitch.Pitch, l length.Length) *symbols.Note {
+	return &symbols.Note{Pitch: p, Length: l}
+}
+
+func graced(n *symbols.Note, t embellishment.Type, p pitch.Pitch) *symbols.Note {
+	n.Embellishment = &embellishment.Embellishment{Type: t, Pitch: p}
+	return n
+}
+
+func dotted(n *symbols.Note) *symbols.Note {
+	n.Dots = 1
+	return n
+}
+
+func notes(nn ...*symbols.Note) []*symbols.Symbol {
+	syms := make([]*symbols.Symbol, len(nn))
+	for i, n := range nn {
+		syms[i] = &symbols.Symbol{Note: n}
+	}
+	return syms
+}
+
+func timelineSymbol(t timeline.Type, b boundary.Boundary) *symbols.Symbol {
+	return &symbols.Symbol{Timeline: &timeline.TimeLine{Type: t, BoundaryType: b}}
+}
+
+func tupletSymbol(b boundary.Boundary) *symbols.Symbol {
+	return &symbols.Symbol{Tuplet: &tuplet.Tuplet{BoundaryType: b, VisibleNotes: 3, PlayedNotes: 2}}
+}
+
+// testTune returns a tune with the symbols that the score draws.
+func testTune() *tune.Tune {
+	g := pitch.Pitch_HighG
+	first := append([]*symbols.Symbol{timelineSymbol(timeline.Type_First, boundary.Boundary_Start)},
+		notes(graced(note(pitch.Pitch_C, length.Length_Quarter), embellishment.Type_Grip, 0),
+			note(pitch.Pitch_LowA, length.Length_Quarter))...)
+	first = append(first, timelineSymbol(timeline.Type_First, boundary.Boundary_End))
+	second := append([]*symbols.Symbol{timelineSymbol(timeline.Type_Second, boundary.Boundary_Start)},
+		notes(note(pitch.Pitch_LowA, length.Length_Half))...)
+	second = append(second, timelineSymbol(timeline.Type_Second, boundary.Boundary_End))
+	triplet := append([]*symbols.Symbol{tupletSymbol(boundary.Boundary_Start)},
+		notes(note(pitch.Pitch_B, length.Length_Eighth), note(pitch.Pitch_C, length.Length_Eighth),
+			note(pitch.Pitch_D, length.Length_Eighth))...)
+	triplet = append(triplet, tupletSymbol(boundary.Boundary_End),
+		&symbols.Symbol{Rest: &symbols.Rest{Length: length.Length_Quarter}})
+
+	tiedStart := note(pitch.Pitch_E, length.Length_Quarter)
+	tiedStart.Tie = tie.Tie_Start
+	natural := note(pitch.Pitch_C, length.Length_Eighth)
+	natural.Accidental = accidental.Accidental_Natural
+	fermata := note(pitch.Pitch_HighA, length.Length_Quarter)
+	fermata.Fermata = true
+
+	return &tune.Tune{
+		Title:    "Scotland the Brave",
+		Type:     "March",
+		Composer: "Traditional",
+		Arranger: "Limepipes",
+		Footer:   []string{"Second part follows"},
+		Measures: []*measure.Measure{
+			{
+				LeftBarline: &barline.Barline{Type: barline.Type_HeavyLight, Time: barline.Time_Repeat},
+				Time:        &measure.TimeSignature{Beats: 4, BeatType: 4},
+				Symbols: notes(
+					graced(note(pitch.Pitch_LowA, length.Length_Quarter), embellishment.Type_SingleGrace, g),
+					graced(dotted(note(pitch.Pitch_LowA, length.Length_Eighth)), embellishment.Type_Doubling, 0),
+					note(pitch.Pitch_B, length.Length_Sixteenth),
+					graced(dotted(note(pitch.Pitch_C, length.Length_Eighth)), embellishment.Type_SingleGrace, g),
+					note(pitch.Pitch_LowA, length.Length_Sixteenth),
+					graced(note(pitch.Pitch_C, length.Length_Eighth), embellishment.Type_SingleGrace, g),
+					note(pitch.Pitch_E, length.Length_Eighth),
+				),
+			},
+			{
+				Symbols: notes(
+					graced(note(pitch.Pitch_HighA, length.Length_Eighth), embellishment.Type_SingleGrace, g),
+					note(pitch.Pitch_HighG, length.Length_Sixteenth),
+					note(pitch.Pitch_F, length.Length_Sixteenth),
+					tiedStart,
+					note(pitch.Pitch_E, length.Length_Quarter),
+					graced(note(pitch.Pitch_D, length.Length_Quarter), embellishment.Type_Taorluath, 0),
+				),
+			},
+			{Symbols: triplet},
+			{Symbols: notes(natural, note(pitch.Pitch_B, length.Length_Eighth), fermata, note(pitch.Pitch_LowG, length.Length_Half))},
+			{Symbols: first, RightBarline: &barline.Barline{Type: barline.Type_LightHeavy, Time: barline.Time_Repeat}},
+			{
+				LeftBarline:  &barline.Barline{Time: barline.Time_Segno},
+				Symbols:      second,
+				RightBarline: &barline.Barline{Type: barline.Type_LightHeavy, Time: barline.Time_Fine},
+			},
+			{
+				Time: &measure.TimeSignature{Beats: 6, BeatType: 8},
+				Symbols: append(notes(
+					note(pitch.Pitch_LowA, length.Length_Whole),
+				), &symbols.Symbol{Rest: &symbols.Rest{Length: length.Length_Eighth}},
+					&symbols.Symbol{Rest: &symbols.Rest{Length: length.Length_Half}}),
+				RightBarline: &barline.Barline{Type: barline.Type_Heavy, Time: barline.Time_Dalsegno},
+			},
+		},
+	}
+}
+
+// expectGolden compares the data with the golden file in testdata
+// or updates the golden file when the tests run with -update.
+func expectGolden(g *WithT, name string, data []byte) {
+	golden := filepath.Join("testdata", name)
+	if *update {
+		g.Expect(os.WriteFile(golden, data, 0644)).To(Succeed())
+	}
+
+	expected, err := os.ReadFile(golden)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(string(data)).To(Equal(string(expected)))
+}
+
+func TestEngraveSVG(t *testing.T) {
+	g := NewWithT(t)
+
+	s := Engrave(testTune())
+
+	g.Expect(s.Pages()).To(Equal(1))
+	svg, err := s.SVG(1)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	expectGolden(g, "scotland_the_brave.svg", svg)
+
+	_, err = s.SVG(2)
+	g.Expect(err).To(MatchError(common.ErrNotFound))
+}
+
+func TestEngravePDF(t *testing.T) {
+	g := NewWithT(t)
+
+	pdf := Engrave(testTune()).PDF()
+
+	expectGolden(g, "scotland_the_brave.pdf", pdf)
+	expectValidXref(g, string(pdf))
+}
+
+// expectValidXref checks that the offsets of the cross-reference
+// table point to the objects of the PDF document.
+func expectValidXref(g *WithT, pdf string) {
+	startxref := strings.LastIndex(pdf, "startxref\n")
+	var xref int
+	_, err := fmt.Sscanf(pdf[startxref+len("startxref\n"):], "%d", &xref)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(pdf[xref:]).To(HavePrefix("xref\n0 "))
+
+	entries := strings.Split(pdf[xref:strings.Index(pdf, "trailer")], "\n")[3:]
+	for i, entry := range entries[:len(entries)-1] {
+		var offset int
+		_, err = fmt.Sscanf(entry, "%d", &offset)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(pdf[offset:]).To(HavePrefix(fmt.Sprintf("%d 0 obj\n", i+1)))
+	}
+}
+
+func TestEngraveIsDeterministic(t *testing.T) {
+	g := NewWithT(t)
+
+	first, err := Engrave(testTune()).SVG(1)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	second, err := Engrave(testTune()).SVG(1)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(second).To(Equal(first))
+}
+
+func TestEngraveLongTuneOnSeveralPages(t *testing.T) {
+	g := NewWithT(t)
+	long := testTune()
+	for range 6 {
+		long.Measures = append(long.Measures, long.Measures...)
+	}
+
+	s := Engrave(long)
+
+	g.Expect(s.Pages()).To(BeNumerically(">", 1))
+	pdf := string(s.PDF())
+	g.Expect(strings.Count(pdf, "/Type /Page ")).To(Equal(s.Pages()))
+	expectValidXref(g, pdf)
+	last, err := s.SVG(s.Pages())
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(string(last)).To(ContainSubstring("Second part follows"))
+}
+
+func TestBreakSystems(t *testing.T) {
+	g := NewWithT(t)
+
+	systems := breakSystems(layoutMeasures(testTune()))
+
+	g.Expect(systems).To(HaveLen(4))
+	g.Expect(systems[0].measures).To(HaveLen(3))
+	g.Expect(systemStartWidth() + systems[0].naturalWidth() + (systems[0].stretch-1)*advanceOf(systems[0])).
+		To(BeNumerically("~", lineWidth, 1e-9))
+	// parts end at heavy barlines and the short systems aren't stretched
+	g.Expect(systems[1].measures).To(HaveLen(2))
+	g.Expect(systems[1].stretch).To(Equal(1.0))
+	g.Expect(systems[2].measures).To(HaveLen(1))
+	g.Expect(systems[2].measures[0].measure.LeftBarline.Time).To(Equal(barline.Time_Segno))
+	g.Expect(systems[3].measures[0].time.Beats).To(Equal(uint32(6)))
+}
+
+func advanceOf(s *system) float64 {
+	advance := 0.0
+	for _, ml := range s.measures {
+		advance += ml.advance()
+	}
+	return advance
+}
+
+func TestLayoutMeasures(t *testing.T) {
+	g := NewWithT(t)
+
+	layouts := layoutMeasures(testTune())
+
+	g.Expect(layouts[0].time.Beats).To(Equal(uint32(4)))
+	g.Expect(layouts[1].time).To(BeNil())
+	g.Expect(layouts[6].time.Beats).To(Equal(uint32(6)))
+
+	beams := make([]int, 0, len(layouts[0].elements))
+	for _, e := range layouts[0].elements {
+		beams = append(beams, e.beam)
+	}
+	g.Expect(beams).To(Equal([]int{0, 1, 1, 2, 2, 3, 3}))
+	g.Expect(layouts[0].elements[1].graces).
+		To(Equal([]pitch.Pitch{pitch.Pitch_HighG, pitch.Pitch_LowA, pitch.Pitch_D}))
+
+	// the notes of the triplet share the beam of its first note
+	var triplet []int
+	for _, e := range layouts[2].elements {
+		if e.note != nil {
+			triplet = append(triplet, e.beam)
+		}
+	}
+	g.Expect(triplet).To(Equal([]int{0, 0, 0}))
+}
+
+func TestPDFString(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(pdfString(`Jock (Wilson's) \ Ball`)).To(Equal(`(Jock \(Wilson's\) \\ Ball)`))
+	g.Expect(pdfString("Mòrag's Ωmega")).To(Equal("(M\xF2rag's ?mega)"))
+}
+
+func TestTextWidth(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(textWidth("Aa", 10)).To(BeNumerically("~", 12.23, 1e-9))
+	g.Expect(textWidth("é", 10)).To(BeNumerically("~", 5.56, 1e-9))
+}
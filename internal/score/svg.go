@@ -0,0 +1,53 @@
+package score
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+var svgFontAttributes = map[font]string{
+	fontRegular: ``,
+	fontBold:    ` font-weight="bold"`,
+	fontItalic:  ` font-style="italic"`,
+}
+
+// svg returns the page as SVG document.
+func (c *canvas) svg() []byte {
+	b := []byte(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	b = append(b, `<svg xmlns="http://www.w3.org/2000/svg" width="`+num(c.width)+
+		`" height="`+num(c.height)+`" viewBox="0 0 `+nums(c.width, c.height)+`">`+"\n"...)
+	b = append(b, `<rect width="`+num(c.width)+`" height="`+num(c.height)+`" fill="white"/>`+"\n"...)
+	for _, s := range c.shapes {
+		b = append(b, s.svg()+"\n"...)
+	}
+
+	return append(b, "</svg>\n"...)
+}
+
+func (p *path) svg() string {
+	var d []byte
+	for _, s := range p.segments {
+		d = append(d, s.op)
+		for i, pt := range s.pts {
+			if i > 0 {
+				d = append(d, ' ')
+			}
+			d = append(d, nums(pt.x, pt.y)...)
+		}
+	}
+
+	if p.width > 0 {
+		return `<path d="` + string(d) + `" fill="none" stroke="black" stroke-width="` + num(p.width) + `"/>`
+	}
+
+	return `<path d="` + string(d) + `" fill-rule="evenodd"/>`
+}
+
+func (t *text) svg() string {
+	var s strings.Builder
+	_ = xml.EscapeText(&s, []byte(t.s))
+
+	return `<text x="` + num(t.pos.x) + `" y="` + num(t.pos.y) +
+		`" font-family="Helvetica, Arial, sans-serif" font-size="` + num(t.size) + `"` +
+		svgFontAttributes[t.font] + `>` + s.String() + `</text>`
+}